@@ -0,0 +1,80 @@
+package build
+
+import (
+	"sync"
+
+	"github.com/stellar/go/xdr"
+)
+
+// Channel is a channel account: an account used only as the source account
+// of transactions, so that transactions of another account can be submitted
+// concurrently using many channels, each with its own sequence numbers. Use it
+// with the `ChannelSource` mutator.
+//
+// The sequence number of the channel is loaded from SequenceProvider once and
+// then incremented locally for each transaction. Call Reset when a transaction
+// using the channel fails so that the sequence number is loaded again.
+type Channel struct {
+	Address          string
+	SequenceProvider SequenceProvider
+
+	mutex    sync.Mutex
+	loaded   bool
+	sequence xdr.SequenceNumber
+}
+
+// ChannelPool hands out channels so that each channel is used by a single
+// transaction at a time.
+type ChannelPool struct {
+	channels chan *Channel
+}
+
+// NewChannelPool returns a pool of the channels with the provided addresses,
+// whose sequence numbers are loaded from provider.
+func NewChannelPool(provider SequenceProvider, addresses ...string) *ChannelPool {
+	pool := &ChannelPool{channels: make(chan *Channel, len(addresses))}
+	for _, address := range addresses {
+		pool.channels <- &Channel{Address: address, SequenceProvider: provider}
+	}
+	return pool
+}
+
+// Acquire returns a channel of the pool, waiting until one is released if
+// all channels are in use.
+func (p *ChannelPool) Acquire() *Channel {
+	return <-p.channels
+}
+
+// Release returns the channel to the pool once the transaction using it has
+// been submitted.
+func (p *ChannelPool) Release(channel *Channel) {
+	p.channels <- channel
+}
+
+// NextSequence returns the sequence number of the next transaction of the
+// channel.
+func (c *Channel) NextSequence() (xdr.SequenceNumber, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if !c.loaded {
+		seq, err := c.SequenceProvider.SequenceForAccount(c.Address)
+		if err != nil {
+			return 0, err
+		}
+
+		c.sequence = seq
+		c.loaded = true
+	}
+
+	c.sequence++
+	return c.sequence, nil
+}
+
+// Reset makes the channel load its sequence number again for the next
+// transaction.
+func (c *Channel) Reset() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.loaded = false
+}
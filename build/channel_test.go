@@ -0,0 +1,119 @@
+package build
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/stellar/go/xdr"
+)
+
+var _ = Describe("Channels:", func() {
+	var (
+		channelAddress = "GAXEMCEXBERNSRXOEKD4JAIKVECIXQCENHEBRVSPX2TTYZPMNEDSQCNQ"
+		accountAddress = "GBRPYHIL2CI3FNQ4BXLFMNDLFJUNPU2HY3ZMFSHONUCEOASW7QC7OX2H"
+		otherAddress   = "GDQHKHMFW5ICTQYM3QWCXMSZ56BNHMQG6NH6SGV3ZNZ72KRHYV5XINCE"
+
+		provider *MockSequenceProvider
+		channel  *Channel
+	)
+
+	BeforeEach(func() {
+		provider = &MockSequenceProvider{
+			Data: map[string]xdr.SequenceNumber{channelAddress: 10},
+		}
+		channel = &Channel{Address: channelAddress, SequenceProvider: provider}
+	})
+
+	Describe("Channel", func() {
+		It("increments the loaded sequence", func() {
+			Expect(channel.NextSequence()).To(BeEquivalentTo(11))
+			provider.Data[channelAddress] = 20
+			Expect(channel.NextSequence()).To(BeEquivalentTo(12))
+		})
+
+		It("loads the sequence again after Reset", func() {
+			Expect(channel.NextSequence()).To(BeEquivalentTo(11))
+			provider.Data[channelAddress] = 20
+			channel.Reset()
+			Expect(channel.NextSequence()).To(BeEquivalentTo(21))
+		})
+
+		It("fails when the sequence can't be loaded", func() {
+			delete(provider.Data, channelAddress)
+			_, err := channel.NextSequence()
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("ChannelPool", func() {
+		It("hands out each channel once until released", func() {
+			pool := NewChannelPool(provider, channelAddress, otherAddress)
+
+			first := pool.Acquire()
+			second := pool.Acquire()
+			Expect([]string{first.Address, second.Address}).To(ConsistOf(channelAddress, otherAddress))
+
+			pool.Release(first)
+			Expect(pool.Acquire()).To(BeIdenticalTo(first))
+		})
+	})
+
+	Describe("ChannelSource", func() {
+		var (
+			tx  *TransactionBuilder
+			err error
+		)
+
+		Context("added after the operations", func() {
+			BeforeEach(func() {
+				tx, err = Transaction(
+					Payment(Destination{otherAddress}, NativeAmount{"10"}),
+					Payment(SourceAccount{otherAddress}, Destination{accountAddress}, NativeAmount{"10"}),
+					ChannelSource{channel, accountAddress},
+				)
+			})
+
+			It("succeeds", func() { Expect(err).NotTo(HaveOccurred()) })
+
+			It("sets the channel as the source of the transaction", func() {
+				Expect(tx.TX.SourceAccount.Address()).To(Equal(channelAddress))
+				Expect(tx.TX.SeqNum).To(BeEquivalentTo(11))
+			})
+
+			It("sets the account as the source of the operations without one", func() {
+				Expect(tx.TX.Operations[0].SourceAccount.Address()).To(Equal(accountAddress))
+				Expect(tx.TX.Operations[1].SourceAccount.Address()).To(Equal(otherAddress))
+			})
+		})
+
+		Context("added before the operations", func() {
+			BeforeEach(func() {
+				tx, err = Transaction(
+					ChannelSource{channel, accountAddress},
+					Payment(Destination{otherAddress}, NativeAmount{"10"}),
+				)
+			})
+
+			It("succeeds", func() { Expect(err).NotTo(HaveOccurred()) })
+			It("sets the account as the source of the operations", func() {
+				Expect(tx.TX.Operations[0].SourceAccount.Address()).To(Equal(accountAddress))
+			})
+		})
+
+		Context("when the channel sequence can't be loaded", func() {
+			BeforeEach(func() {
+				provider.Data = map[string]xdr.SequenceNumber{}
+				_, err = Transaction(ChannelSource{channel, accountAddress})
+			})
+
+			It("fails", func() { Expect(err).To(HaveOccurred()) })
+		})
+
+		Context("without a channel", func() {
+			BeforeEach(func() {
+				_, err = Transaction(ChannelSource{Account: accountAddress})
+			})
+
+			It("fails", func() { Expect(err).To(HaveOccurred()) })
+		})
+	})
+})
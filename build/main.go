@@ -68,6 +68,13 @@ type AutoSequence struct {
 	SequenceProvider
 }
 
+// AutoFee loads the base fee to use for the transaction from an external
+// provider. The fee of the transaction is the base fee times the number of
+// operations, computed by the `Defaults` mutator.
+type AutoFee struct {
+	FeeProvider
+}
+
 // NativeAsset is a helper method to create native Asset object
 func NativeAsset() Asset {
 	return Asset{Native: true}
@@ -78,6 +85,20 @@ func CreditAsset(code, issuer string) Asset {
 	return Asset{code, issuer, false}
 }
 
+// ChannelSource is a mutator that makes Channel the source account of the
+// transaction, using the next sequence number of the channel, and Account the
+// source account of the operations of the transaction that don't have one.
+// Both Account and the channel must sign the transaction.
+type ChannelSource struct {
+	Channel *Channel
+	Account string
+}
+
+// ClearSignatures is a mutator that removes the signatures of the mutated
+// transaction envelope, for example before signing it again after changing the
+// transaction.
+type ClearSignatures struct{}
+
 // CreditAmount is a mutator that configures a payment to be using credit
 // asset and have the amount provided.
 type CreditAmount struct {
@@ -95,6 +116,12 @@ type Destination struct {
 	AddressOrSeed string
 }
 
+// Fee is a mutator that sets the total fee of the mutated transaction,
+// overriding the fee computed from the base fee.
+type Fee struct {
+	Amount uint64
+}
+
 // InflationDest is a mutator capable of setting the inflation destination
 type InflationDest string
 
@@ -185,6 +212,12 @@ type SequenceProvider interface {
 	SequenceForAccount(aid string) (xdr.SequenceNumber, error)
 }
 
+// FeeProvider is the interface that other packages may implement to be used
+// with the `AutoFee` mutator.
+type FeeProvider interface {
+	BaseFee() (uint64, error)
+}
+
 // Sign is a mutator that contributes a signature of the provided envelope's
 // transaction with the configured key
 type Sign struct {
@@ -217,6 +250,14 @@ type Thresholds struct {
 	High   *uint32
 }
 
+// Timebounds is a mutator that sets the time bounds on the mutated
+// transaction. Times are UNIX timestamps, a MaxTime of 0 means the transaction
+// has no upper time bound.
+type Timebounds struct {
+	MinTime uint64
+	MaxTime uint64
+}
+
 // Trustor is a mutator capable of setting the trustor on
 // allow_trust operation.
 type Trustor struct {
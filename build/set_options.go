@@ -1,6 +1,7 @@
 package build
 
 import (
+	"github.com/stellar/go/strkey"
 	"github.com/stellar/go/support/errors"
 	"github.com/stellar/go/xdr"
 )
@@ -95,6 +96,20 @@ func RemoveSigner(address string) Signer {
 	return Signer{address, 0}
 }
 
+// AddPreAuthTxSigner creates Signer mutator that sets a pre-authorized
+// transaction signer of the account. hash is the hash of the transaction, see
+// TransactionBuilder.PreAuthTxSigner.
+func AddPreAuthTxSigner(hash [32]byte, weight uint32) Signer {
+	return Signer{strkey.MustEncode(strkey.VersionByteHashTx, hash[:]), weight}
+}
+
+// AddHashXSigner creates Signer mutator that sets a hash(x) signer of the
+// account. hash is the SHA-256 hash of the preimage x that must be added as a
+// signature to transactions to authorize them.
+func AddHashXSigner(hash [32]byte, weight uint32) Signer {
+	return Signer{strkey.MustEncode(strkey.VersionByteHashX, hash[:]), weight}
+}
+
 // MutateSetOptions for Signer sets the SetOptionsOp's signer field
 func (m Signer) MutateSetOptions(o *xdr.SetOptionsOp) error {
 
@@ -58,6 +58,31 @@ func TestSetOptions_Signer(t *testing.T) {
 	}
 }
 
+func TestSetOptions_PreAuthTxAndHashXSigners(t *testing.T) {
+	hash := [32]byte{
+		0x69, 0xa8, 0xc4, 0xcb, 0xb9, 0xf6, 0x4e, 0x8a,
+		0x07, 0x98, 0xf6, 0xe1, 0xac, 0x65, 0xd0, 0x6c,
+		0x31, 0x62, 0x92, 0x90, 0x56, 0xbc, 0xf4, 0xcd,
+		0xb7, 0xd3, 0x73, 0x8d, 0x18, 0x55, 0xf3, 0x63,
+	}
+
+	var m SetOptionsBuilder
+	m.Mutate(AddHashXSigner(hash, 2))
+	if assert.NoError(t, m.Err) {
+		assert.Equal(t, xdr.SignerKeyTypeSignerKeyTypeHashX, m.SO.Signer.Key.Type)
+		assert.Equal(t, "XBU2RRGLXH3E5CQHTD3ODLDF2BWDCYUSSBLLZ5GNW7JXHDIYKXZWGTOG", m.SO.Signer.Key.Address())
+		assert.Equal(t, uint32(2), uint32(m.SO.Signer.Weight))
+	}
+
+	m = SetOptionsBuilder{}
+	m.Mutate(AddPreAuthTxSigner(hash, 3))
+	if assert.NoError(t, m.Err) {
+		assert.Equal(t, xdr.SignerKeyTypeSignerKeyTypeHashTx, m.SO.Signer.Key.Type)
+		assert.Equal(t, "TBU2RRGLXH3E5CQHTD3ODLDF2BWDCYUSSBLLZ5GNW7JXHDIYKXZWHXL7", m.SO.Signer.Key.Address())
+		assert.Equal(t, uint32(3), uint32(m.SO.Signer.Weight))
+	}
+}
+
 var _ = Describe("SetOptionsBuilder Mutators", func() {
 
 	var (
@@ -0,0 +1,191 @@
+package build
+
+import (
+	"bytes"
+	"crypto/sha256"
+
+	"github.com/stellar/go/keypair"
+	"github.com/stellar/go/network"
+	"github.com/stellar/go/support/errors"
+	"github.com/stellar/go/xdr"
+)
+
+// AccountSigners represents the signers of an account and its thresholds, as
+// used by the network to authorize transactions.
+type AccountSigners struct {
+	Signers         []AccountSigner
+	LowThreshold    uint32
+	MediumThreshold uint32
+	HighThreshold   uint32
+}
+
+// AccountSigner is a signer of an account. Key is the strkey encoded signer
+// key: an account address, a pre-authorized transaction hash or a hash(x).
+type AccountSigner struct {
+	Key    string
+	Weight uint32
+}
+
+// SignersProvider is the interface that other packages may implement to be
+// used with `TransactionEnvelopeBuilder.SignatureWeights`.
+type SignersProvider interface {
+	SignersForAccount(aid string) (AccountSigners, error)
+}
+
+// SignatureWeight reports the total weight of the signers of Account whose
+// signatures are present in a transaction envelope and which of the account
+// thresholds the weight satisfies.
+type SignatureWeight struct {
+	Account string
+	Weight  uint32
+	Low     bool
+	Medium  bool
+	High    bool
+}
+
+// MergeSignatures adds the signatures of the provided envelopes to this
+// builder's envelope, skipping signatures it already contains. Envelopes must
+// contain the same transaction as the builder, for example partially signed
+// envelopes returned by `TransactionBuilder.Sign` to each party.
+func (b *TransactionEnvelopeBuilder) MergeSignatures(envelopes ...xdr.TransactionEnvelope) error {
+	b.Init()
+
+	tx, err := xdr.MarshalBase64(b.E.Tx)
+	if err != nil {
+		return errors.Wrap(err, "marshal tx failed")
+	}
+
+	for i, envelope := range envelopes {
+		other, err := xdr.MarshalBase64(envelope.Tx)
+		if err != nil {
+			return errors.Wrap(err, "marshal tx failed")
+		}
+
+		if other != tx {
+			return errors.Errorf("envelope:%d has a different transaction", i)
+		}
+
+		for _, sig := range envelope.Signatures {
+			if !hasSignature(b.E.Signatures, sig) {
+				b.E.Signatures = append(b.E.Signatures, sig)
+			}
+		}
+	}
+
+	return nil
+}
+
+// SignatureWeights returns the weight of the signatures present in this
+// builder's envelope for the source account of the transaction and for each
+// distinct source account of its operations, in that order. Signers of the
+// accounts are loaded using the provided SignersProvider.
+//
+// A threshold is satisfied when the weight is not lower than the threshold
+// and at least one signer signed. Operations that need the medium or high
+// threshold of their source account are only authorized if the matching field
+// is true.
+func (b *TransactionEnvelopeBuilder) SignatureWeights(p SignersProvider) ([]SignatureWeight, error) {
+	b.Init()
+
+	hash, err := network.HashTransaction(&b.E.Tx, b.child.NetworkPassphrase)
+	if err != nil {
+		return nil, errors.Wrap(err, "hash tx failed")
+	}
+
+	accounts := []string{b.E.Tx.SourceAccount.Address()}
+	for _, op := range b.E.Tx.Operations {
+		if op.SourceAccount == nil {
+			continue
+		}
+
+		address := op.SourceAccount.Address()
+		if !containsString(accounts, address) {
+			accounts = append(accounts, address)
+		}
+	}
+
+	result := make([]SignatureWeight, 0, len(accounts))
+	for _, account := range accounts {
+		signers, err := p.SignersForAccount(account)
+		if err != nil {
+			return nil, errors.Wrap(err, "load signers failed")
+		}
+
+		var weight uint32
+		for _, signer := range signers.Signers {
+			signed, err := hasSigned(signer.Key, hash, b.E.Signatures)
+			if err != nil {
+				return nil, errors.Wrap(err, "check signer failed")
+			}
+
+			if signed {
+				weight += signer.Weight
+			}
+		}
+
+		result = append(result, SignatureWeight{
+			Account: account,
+			Weight:  weight,
+			Low:     weight > 0 && weight >= signers.LowThreshold,
+			Medium:  weight > 0 && weight >= signers.MediumThreshold,
+			High:    weight > 0 && weight >= signers.HighThreshold,
+		})
+	}
+
+	return result, nil
+}
+
+// hasSigned returns true if the signer with strkey encoded `key` authorized
+// the transaction with the provided hash: one of `sigs` is its valid
+// signature, the preimage of its hash(x) or the key is the hash of the
+// transaction.
+func hasSigned(key string, hash [32]byte, sigs []xdr.DecoratedSignature) (bool, error) {
+	var skey xdr.SignerKey
+	err := skey.SetAddress(key)
+	if err != nil {
+		return false, err
+	}
+
+	switch skey.Type {
+	case xdr.SignerKeyTypeSignerKeyTypeEd25519:
+		kp, err := keypair.Parse(key)
+		if err != nil {
+			return false, err
+		}
+
+		for _, sig := range sigs {
+			if sig.Hint == xdr.SignatureHint(kp.Hint()) && kp.Verify(hash[:], sig.Signature) == nil {
+				return true, nil
+			}
+		}
+	case xdr.SignerKeyTypeSignerKeyTypeHashX:
+		x := skey.MustHashX()
+		for _, sig := range sigs {
+			if sha256.Sum256(sig.Signature) == [32]byte(x) {
+				return true, nil
+			}
+		}
+	case xdr.SignerKeyTypeSignerKeyTypeHashTx:
+		return [32]byte(skey.MustHashTx()) == hash, nil
+	}
+
+	return false, nil
+}
+
+func hasSignature(sigs []xdr.DecoratedSignature, sig xdr.DecoratedSignature) bool {
+	for _, s := range sigs {
+		if s.Hint == sig.Hint && bytes.Equal(s.Signature, sig.Signature) {
+			return true
+		}
+	}
+	return false
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,206 @@
+package build
+
+import (
+	"crypto/sha256"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/stellar/go/keypair"
+	"github.com/stellar/go/strkey"
+	"github.com/stellar/go/xdr"
+)
+
+var _ = Describe("Multi-signature helpers:", func() {
+	var (
+		seed1    = "SDOTALIMPAM2IV65IOZA7KZL7XWZI5BODFXTRVLIHLQZQCKK57PH5F3H"
+		seed2    = "SDHOAMBNLGCE2MV5ZKIVZAQD3VCLGP53P3OBSBI6UN5L5XZI5TKHFQL4"
+		address1 = keypair.MustParse(seed1).Address()
+		address2 = keypair.MustParse(seed2).Address()
+
+		tx      *TransactionBuilder
+		subject TransactionEnvelopeBuilder
+		err     error
+	)
+
+	BeforeEach(func() {
+		tx, err = Transaction(
+			SourceAccount{address1},
+			Sequence{1},
+			TestNetwork,
+			Payment(
+				Destination{address2},
+				NativeAmount{"10"},
+			),
+		)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	Describe("MergeSignatures", func() {
+		var other1, other2 TransactionEnvelopeBuilder
+
+		BeforeEach(func() {
+			subject, err = tx.Sign()
+			Expect(err).NotTo(HaveOccurred())
+			other1, err = tx.Sign(seed1)
+			Expect(err).NotTo(HaveOccurred())
+			other2, err = tx.Sign(seed1, seed2)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("adds the signatures of each party once", func() {
+			err = subject.MergeSignatures(*other1.E, *other2.E)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(subject.E.Signatures).To(HaveLen(2))
+			Expect(subject.E.Signatures[0]).To(Equal(other1.E.Signatures[0]))
+			Expect(subject.E.Signatures[1]).To(Equal(other2.E.Signatures[1]))
+		})
+
+		Context("with an envelope of another transaction", func() {
+			BeforeEach(func() {
+				other1.E.Tx.SeqNum = 2
+			})
+
+			It("fails", func() {
+				err = subject.MergeSignatures(*other1.E)
+				Expect(err).To(HaveOccurred())
+				Expect(subject.E.Signatures).To(BeEmpty())
+			})
+		})
+	})
+
+	Describe("SignatureWeights", func() {
+		var (
+			provider *MockSignersProvider
+			weights  []SignatureWeight
+		)
+
+		BeforeEach(func() {
+			provider = &MockSignersProvider{
+				Data: map[string]AccountSigners{
+					address1: {
+						Signers: []AccountSigner{
+							{Key: address1, Weight: 1},
+							{Key: address2, Weight: 2},
+						},
+						LowThreshold:    1,
+						MediumThreshold: 2,
+						HighThreshold:   3,
+					},
+				},
+			}
+		})
+
+		JustBeforeEach(func() {
+			weights, err = subject.SignatureWeights(provider)
+		})
+
+		Context("signed by the master key", func() {
+			BeforeEach(func() {
+				subject, err = tx.Sign(seed1)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("satisfies the low threshold", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(weights).To(Equal([]SignatureWeight{
+					{Account: address1, Weight: 1, Low: true},
+				}))
+			})
+		})
+
+		Context("signed by all signers", func() {
+			BeforeEach(func() {
+				subject, err = tx.Sign(seed1, seed2)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("satisfies all thresholds", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(weights).To(Equal([]SignatureWeight{
+					{Account: address1, Weight: 3, Low: true, Medium: true, High: true},
+				}))
+			})
+		})
+
+		Context("signed by a key that isn't a signer", func() {
+			BeforeEach(func() {
+				provider.Data[address1] = AccountSigners{
+					Signers: []AccountSigner{{Key: address1, Weight: 1}},
+				}
+				subject, err = tx.Sign(seed2)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("satisfies no threshold", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(weights).To(Equal([]SignatureWeight{{Account: address1}}))
+			})
+		})
+
+		Context("with hash(x) and pre-authorized transaction signers", func() {
+			BeforeEach(func() {
+				preimage := []byte("secret")
+				x := sha256.Sum256(preimage)
+				hash, err := tx.Hash()
+				Expect(err).NotTo(HaveOccurred())
+
+				provider.Data[address1] = AccountSigners{
+					Signers: []AccountSigner{
+						{Key: strkey.MustEncode(strkey.VersionByteHashX, x[:]), Weight: 1},
+						{Key: strkey.MustEncode(strkey.VersionByteHashTx, hash[:]), Weight: 2},
+					},
+					HighThreshold: 3,
+				}
+
+				subject, err = tx.Sign()
+				Expect(err).NotTo(HaveOccurred())
+				subject.E.Signatures = append(subject.E.Signatures, xdr.DecoratedSignature{
+					Hint:      xdr.SignatureHint{x[28], x[29], x[30], x[31]},
+					Signature: preimage,
+				})
+			})
+
+			It("counts their weight", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(weights).To(Equal([]SignatureWeight{
+					{Account: address1, Weight: 3, Low: true, Medium: true, High: true},
+				}))
+			})
+		})
+
+		Context("with an operation from another source account", func() {
+			BeforeEach(func() {
+				provider.Data[address2] = AccountSigners{
+					Signers:      []AccountSigner{{Key: address2, Weight: 1}},
+					LowThreshold: 1,
+				}
+				err = tx.Mutate(Payment(
+					SourceAccount{address2},
+					Destination{address1},
+					NativeAmount{"10"},
+				))
+				Expect(err).NotTo(HaveOccurred())
+				subject, err = tx.Sign(seed1)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("reports the weight of each account", func() {
+				Expect(err).NotTo(HaveOccurred())
+				Expect(weights).To(Equal([]SignatureWeight{
+					{Account: address1, Weight: 1, Low: true},
+					{Account: address2},
+				}))
+			})
+		})
+
+		Context("when signers can't be loaded", func() {
+			BeforeEach(func() {
+				delete(provider.Data, address1)
+				subject, err = tx.Sign(seed1)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("fails", func() { Expect(err).To(HaveOccurred()) })
+		})
+	})
+})
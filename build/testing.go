@@ -26,3 +26,37 @@ func (sp *MockSequenceProvider) SequenceForAccount(
 
 	return ret, nil
 }
+
+// MockFeeProvider is a mock fee provider.
+type MockFeeProvider struct {
+	Fee uint64
+	Err error
+}
+
+var _ FeeProvider = &MockFeeProvider{}
+
+// BaseFee implements `FeeProvider`
+func (fp *MockFeeProvider) BaseFee() (uint64, error) {
+	return fp.Fee, fp.Err
+}
+
+// MockSignersProvider is a mock signers provider.
+type MockSignersProvider struct {
+	Data map[string]AccountSigners
+}
+
+var _ SignersProvider = &MockSignersProvider{}
+
+// SignersForAccount implements `SignersProvider`
+func (sp *MockSignersProvider) SignersForAccount(
+	accountID string,
+) (AccountSigners, error) {
+
+	ret, ok := sp.Data[accountID]
+
+	if !ok {
+		return AccountSigners{}, fmt.Errorf("No signers for %s in mock", accountID)
+	}
+
+	return ret, nil
+}
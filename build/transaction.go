@@ -2,6 +2,7 @@ package build
 
 import (
 	"encoding/hex"
+	"math"
 
 	"github.com/stellar/go/network"
 	"github.com/stellar/go/support/errors"
@@ -35,6 +36,10 @@ type TransactionBuilder struct {
 	TX                *xdr.Transaction
 	NetworkPassphrase string
 	BaseFee           uint64
+
+	// operationSource is the default source account of operations, set by
+	// ChannelSource.
+	operationSource string
 }
 
 // Mutate applies the provided TransactionMutators to this builder's transaction
@@ -68,6 +73,19 @@ func (b *TransactionBuilder) HashHex() (string, error) {
 	return hex.EncodeToString(hash[:]), nil
 }
 
+// PreAuthTxSigner returns a Signer mutator that adds this builder's
+// transaction as a pre-authorized transaction signer of an account. The
+// transaction must be complete, including its sequence number, as any change
+// to it changes its hash.
+func (b *TransactionBuilder) PreAuthTxSigner(weight uint32) (Signer, error) {
+	hash, err := b.Hash()
+	if err != nil {
+		return Signer{}, errors.Wrap(err, "hash tx failed")
+	}
+
+	return AddPreAuthTxSigner(hash, weight), nil
+}
+
 // Sign returns an new TransactionEnvelopeBuilder using this builder's
 // transaction as the basis and with signatures of that transaction from the
 // provided Signers.
@@ -88,6 +106,27 @@ func (b *TransactionBuilder) Sign(signers ...string) (TransactionEnvelopeBuilder
 	return result, nil
 }
 
+// setOperationSources sets operationSource as the source account of the
+// operations that don't have one.
+func (b *TransactionBuilder) setOperationSources() error {
+	if b.operationSource == "" {
+		return nil
+	}
+
+	for i := range b.TX.Operations {
+		if b.TX.Operations[i].SourceAccount != nil {
+			continue
+		}
+
+		err := SourceAccount{b.operationSource}.MutateOperation(&b.TX.Operations[i])
+		if err != nil {
+			return errors.Wrap(err, "set operation source failed")
+		}
+	}
+
+	return nil
+}
+
 // ------------------------------------------------------------
 //
 //   Mutator implementations
@@ -137,6 +176,44 @@ func (m AutoSequence) MutateTransaction(o *TransactionBuilder) error {
 	return nil
 }
 
+// MutateTransaction for AutoFee loads the base fee and sets it on the builder.
+// NOTE:  the fee of the transaction is computed from the base fee by the
+// `Defaults` mutator, so AutoFee has no effect on transactions whose fee has
+// already been set.
+func (m AutoFee) MutateTransaction(o *TransactionBuilder) error {
+	fee, err := m.FeeProvider.BaseFee()
+	if err != nil {
+		return err
+	}
+
+	o.BaseFee = fee
+	return nil
+}
+
+// MutateTransaction for ChannelSource sets the channel as the source account
+// of the transaction and loads its next sequence number. Account is set as the
+// source account of the operations added to the transaction so far, and of the
+// operations added later by the time `Defaults` is applied.
+func (m ChannelSource) MutateTransaction(o *TransactionBuilder) error {
+	if m.Channel == nil {
+		return errors.New("channel source used without a channel")
+	}
+
+	err := setAccountId(m.Channel.Address, &o.TX.SourceAccount)
+	if err != nil {
+		return errors.Wrap(err, "set channel source failed")
+	}
+
+	seq, err := m.Channel.NextSequence()
+	if err != nil {
+		return errors.Wrap(err, "load channel sequence failed")
+	}
+	o.TX.SeqNum = seq
+
+	o.operationSource = m.Account
+	return o.setOperationSources()
+}
+
 // MutateTransaction for ChangeTrustBuilder causes the underylying
 // CreateAccountOp to be added to the operation list for the provided
 // transaction
@@ -179,6 +256,17 @@ func (m Defaults) MutateTransaction(o *TransactionBuilder) error {
 	if o.NetworkPassphrase == "" {
 		o.NetworkPassphrase = DefaultNetwork.Passphrase
 	}
+
+	return o.setOperationSources()
+}
+
+// MutateTransaction for Fee sets the fee of the transaction
+func (m Fee) MutateTransaction(o *TransactionBuilder) error {
+	if m.Amount > math.MaxUint32 {
+		return errors.New("fee is too large")
+	}
+
+	o.TX.Fee = xdr.Uint32(m.Amount)
 	return nil
 }
 
@@ -305,6 +393,19 @@ func (m SourceAccount) MutateTransaction(o *TransactionBuilder) error {
 	return setAccountId(m.AddressOrSeed, &o.TX.SourceAccount)
 }
 
+// MutateTransaction for Timebounds sets the TimeBounds on the transaction.
+func (m Timebounds) MutateTransaction(o *TransactionBuilder) error {
+	if m.MaxTime != 0 && m.MinTime > m.MaxTime {
+		return errors.New("MinTime must not be greater than MaxTime")
+	}
+
+	o.TX.TimeBounds = &xdr.TimeBounds{
+		MinTime: xdr.Uint64(m.MinTime),
+		MaxTime: xdr.Uint64(m.MaxTime),
+	}
+	return nil
+}
+
 // MutateTransaction for BaseFee sets the base fee
 func (m BaseFee) MutateTransaction(o *TransactionBuilder) error {
 	o.BaseFee = m.Amount
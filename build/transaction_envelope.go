@@ -24,6 +24,29 @@ type TransactionEnvelopeBuilder struct {
 	child *TransactionBuilder
 }
 
+// TransactionEnvelopeFromBase64 parses the xdr-then-base64-encoded form of a
+// transaction envelope into a builder, for example to review a transaction
+// received from another party and countersign it. The network of the
+// transaction is not part of the envelope: DefaultNetwork is used unless
+// another `Network` mutator is applied using MutateTX before signing.
+func TransactionEnvelopeFromBase64(txeB64 string) (TransactionEnvelopeBuilder, error) {
+	var result TransactionEnvelopeBuilder
+	var envelope xdr.TransactionEnvelope
+	err := xdr.SafeUnmarshalBase64(txeB64, &envelope)
+	if err != nil {
+		return result, errors.Wrap(err, "unmarshal envelope failed")
+	}
+
+	result.E = &envelope
+	result.Init()
+	result.child.NetworkPassphrase = DefaultNetwork.Passphrase
+	if count := len(envelope.Tx.Operations); count > 0 {
+		result.child.BaseFee = uint64(envelope.Tx.Fee) / uint64(count)
+	}
+
+	return result, nil
+}
+
 func (b *TransactionEnvelopeBuilder) Init() {
 	if b.E == nil {
 		b.E = &xdr.TransactionEnvelope{}
@@ -62,6 +85,46 @@ func (b *TransactionEnvelopeBuilder) MutateTX(muts ...TransactionMutator) error
 	return nil
 }
 
+// Operations returns the operations of the builder's transaction as operation
+// builders, for example PaymentBuilder for payment and path_payment
+// operations, which can be inspected or added to another transaction.
+func (b *TransactionEnvelopeBuilder) Operations() ([]TransactionMutator, error) {
+	b.Init()
+
+	result := make([]TransactionMutator, len(b.E.Tx.Operations))
+	for i, op := range b.E.Tx.Operations {
+		body := op.Body
+		switch body.Type {
+		case xdr.OperationTypeCreateAccount:
+			result[i] = CreateAccountBuilder{O: op, CA: body.MustCreateAccountOp()}
+		case xdr.OperationTypePayment:
+			result[i] = PaymentBuilder{O: op, P: body.MustPaymentOp()}
+		case xdr.OperationTypePathPayment:
+			result[i] = PaymentBuilder{PathPayment: true, O: op, PP: body.MustPathPaymentOp()}
+		case xdr.OperationTypeManageOffer:
+			result[i] = ManageOfferBuilder{O: op, MO: body.MustManageOfferOp()}
+		case xdr.OperationTypeCreatePassiveOffer:
+			result[i] = ManageOfferBuilder{PassiveOffer: true, O: op, PO: body.MustCreatePassiveOfferOp()}
+		case xdr.OperationTypeSetOptions:
+			result[i] = SetOptionsBuilder{O: op, SO: body.MustSetOptionsOp()}
+		case xdr.OperationTypeChangeTrust:
+			result[i] = ChangeTrustBuilder{O: op, CT: body.MustChangeTrustOp()}
+		case xdr.OperationTypeAllowTrust:
+			result[i] = AllowTrustBuilder{O: op, AT: body.MustAllowTrustOp()}
+		case xdr.OperationTypeAccountMerge:
+			result[i] = AccountMergeBuilder{O: op, Destination: body.MustDestination()}
+		case xdr.OperationTypeInflation:
+			result[i] = InflationBuilder{O: op}
+		case xdr.OperationTypeManageData:
+			result[i] = ManageDataBuilder{O: op, MD: body.MustManageDataOp()}
+		default:
+			return nil, errors.Errorf("unknown operation type: %s", body.Type)
+		}
+	}
+
+	return result, nil
+}
+
 // Bytes encodes the builder's underlying envelope to XDR
 func (b *TransactionEnvelopeBuilder) Bytes() ([]byte, error) {
 	var txBytes bytes.Buffer
@@ -90,6 +153,12 @@ func (b *TransactionEnvelopeBuilder) Base64() (string, error) {
 //
 // ------------------------------------------------------------
 
+// MutateTransactionEnvelope removes the signatures of the provided envelope
+func (m ClearSignatures) MutateTransactionEnvelope(txe *TransactionEnvelopeBuilder) error {
+	txe.E.Signatures = nil
+	return nil
+}
+
 // MutateTransactionEnvelope adds a signature to the provided envelope
 func (m Sign) MutateTransactionEnvelope(txe *TransactionEnvelopeBuilder) error {
 	hash, err := txe.child.Hash()
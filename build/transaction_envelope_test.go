@@ -3,6 +3,9 @@ package build
 import (
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	"github.com/stellar/go/keypair"
+	"github.com/stellar/go/network"
+	"github.com/stellar/go/xdr"
 )
 
 var _ = Describe("TransactionEnvelope Mutators:", func() {
@@ -50,4 +53,153 @@ var _ = Describe("TransactionEnvelope Mutators:", func() {
 		})
 	})
 
+	Describe("ClearSignatures", func() {
+		BeforeEach(func() {
+			subject.MutateTX(SourceAccount{"SDOTALIMPAM2IV65IOZA7KZL7XWZI5BODFXTRVLIHLQZQCKK57PH5F3H"}, TestNetwork)
+			subject.Mutate(Sign{"SDOTALIMPAM2IV65IOZA7KZL7XWZI5BODFXTRVLIHLQZQCKK57PH5F3H"})
+			mut = ClearSignatures{}
+		})
+
+		It("succeeds", func() { Expect(err).NotTo(HaveOccurred()) })
+		It("removes the signatures of the envelope", func() {
+			Expect(subject.E.Signatures).To(BeEmpty())
+		})
+	})
+
+})
+
+var _ = Describe("TransactionEnvelopeFromBase64", func() {
+	var (
+		seed1    = "SDOTALIMPAM2IV65IOZA7KZL7XWZI5BODFXTRVLIHLQZQCKK57PH5F3H"
+		seed2    = "SDHOAMBNLGCE2MV5ZKIVZAQD3VCLGP53P3OBSBI6UN5L5XZI5TKHFQL4"
+		address1 = keypair.MustParse(seed1).Address()
+		address2 = keypair.MustParse(seed2).Address()
+
+		input   string
+		subject TransactionEnvelopeBuilder
+		err     error
+	)
+
+	BeforeEach(func() {
+		tx, err := Transaction(
+			SourceAccount{address1},
+			Sequence{1},
+			TestNetwork,
+			MemoText{"original"},
+			Payment(
+				Destination{address2},
+				NativeAmount{"10"},
+			),
+			Payment(
+				Destination{address2},
+				NativeAmount{"10"},
+				PayWith(NativeAsset(), "20"),
+			),
+			CreateOffer(Rate{
+				Selling: NativeAsset(),
+				Buying:  CreditAsset("USD", address2),
+				Price:   "0.5",
+			}, "10"),
+			SetOptions(HomeDomain("stellar.org")),
+			Inflation(),
+		)
+		Expect(err).NotTo(HaveOccurred())
+
+		txe, err := tx.Sign(seed1)
+		Expect(err).NotTo(HaveOccurred())
+
+		input, err = txe.Base64()
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	JustBeforeEach(func() { subject, err = TransactionEnvelopeFromBase64(input) })
+
+	Context("with a valid envelope", func() {
+		It("succeeds", func() { Expect(err).NotTo(HaveOccurred()) })
+
+		It("parses the transaction and its signatures", func() {
+			Expect(subject.E.Tx.SourceAccount.Address()).To(Equal(address1))
+			Expect(subject.E.Tx.SeqNum).To(BeEquivalentTo(1))
+			Expect(subject.E.Tx.Fee).To(BeEquivalentTo(500))
+			Expect(subject.E.Signatures).To(HaveLen(1))
+		})
+
+		It("round trips", func() {
+			output, err := subject.Base64()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(output).To(Equal(input))
+		})
+
+		It("returns the operations as builders", func() {
+			ops, err := subject.Operations()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ops).To(HaveLen(5))
+
+			payment, ok := ops[0].(PaymentBuilder)
+			Expect(ok).To(BeTrue())
+			Expect(payment.PathPayment).To(BeFalse())
+			Expect(payment.P.Destination.Address()).To(Equal(address2))
+			Expect(payment.P.Amount).To(BeEquivalentTo(100000000))
+
+			pathPayment, ok := ops[1].(PaymentBuilder)
+			Expect(ok).To(BeTrue())
+			Expect(pathPayment.PathPayment).To(BeTrue())
+			Expect(pathPayment.PP.SendMax).To(BeEquivalentTo(200000000))
+
+			offer, ok := ops[2].(ManageOfferBuilder)
+			Expect(ok).To(BeTrue())
+			Expect(offer.PassiveOffer).To(BeFalse())
+			Expect(offer.MO.Price).To(Equal(xdr.Price{N: 1, D: 2}))
+
+			options, ok := ops[3].(SetOptionsBuilder)
+			Expect(ok).To(BeTrue())
+			Expect(*options.SO.HomeDomain).To(BeEquivalentTo("stellar.org"))
+
+			Expect(ops[4]).To(BeAssignableToTypeOf(InflationBuilder{}))
+		})
+
+		It("can add the operations to another transaction", func() {
+			ops, err := subject.Operations()
+			Expect(err).NotTo(HaveOccurred())
+
+			tx, err := Transaction(append([]TransactionMutator{
+				SourceAccount{address2},
+				Sequence{2},
+				TestNetwork,
+			}, ops...)...)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(tx.TX.Operations).To(Equal(subject.E.Tx.Operations))
+		})
+
+		It("can modify and sign the transaction again", func() {
+			err := subject.MutateTX(
+				TestNetwork,
+				MemoText{"reviewed"},
+				Fee{1000},
+				Timebounds{MinTime: 1, MaxTime: 2},
+			)
+			Expect(err).NotTo(HaveOccurred())
+
+			err = subject.Mutate(ClearSignatures{}, Sign{seed1}, Sign{seed2})
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(subject.E.Tx.Memo.MustText()).To(Equal("reviewed"))
+			Expect(subject.E.Tx.Fee).To(BeEquivalentTo(1000))
+			Expect(subject.E.Tx.TimeBounds.MaxTime).To(BeEquivalentTo(2))
+			Expect(subject.E.Signatures).To(HaveLen(2))
+
+			hash, err := network.HashTransaction(&subject.E.Tx, TestNetwork.Passphrase)
+			Expect(err).NotTo(HaveOccurred())
+			for i, seed := range []string{seed1, seed2} {
+				kp := keypair.MustParse(seed)
+				Expect(kp.Verify(hash[:], subject.E.Signatures[i].Signature)).To(Succeed())
+			}
+		})
+	})
+
+	Context("with an invalid envelope", func() {
+		BeforeEach(func() { input = "AAAA" })
+
+		It("fails", func() { Expect(err).To(HaveOccurred()) })
+	})
 })
@@ -1,6 +1,8 @@
 package build
 
 import (
+	"errors"
+
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	"github.com/stellar/go/xdr"
@@ -72,6 +74,30 @@ var _ = Describe("Transaction Mutators:", func() {
 		})
 	})
 
+	Describe("AutoFee", func() {
+		BeforeEach(func() {
+			mut = AutoFee{&MockFeeProvider{Fee: 250}}
+		})
+
+		It("succeeds", func() { Expect(err).NotTo(HaveOccurred()) })
+		It("sets the base fee", func() { Expect(subject.BaseFee).To(BeEquivalentTo(250)) })
+
+		Context("on a transaction with 2 operations", func() {
+			JustBeforeEach(func() {
+				subject.Mutate(Payment(), Payment(), Defaults{})
+			})
+			It("sets the fee to 250 * 2", func() { Expect(subject.TX.Fee).To(BeEquivalentTo(250 * 2)) })
+		})
+
+		Context("when the provider fails", func() {
+			BeforeEach(func() {
+				mut = AutoFee{&MockFeeProvider{Err: errors.New("boom")}}
+			})
+			It("fails", func() { Expect(err).To(HaveOccurred()) })
+			It("leaves the base fee unset", func() { Expect(subject.BaseFee).To(BeEquivalentTo(0)) })
+		})
+	})
+
 	Describe("MemoHash", func() {
 		BeforeEach(func() { mut = MemoHash{[32]byte{0x01}} })
 		It("sets a Hash memo on the transaction", func() {
@@ -148,6 +174,62 @@ var _ = Describe("Transaction Mutators:", func() {
 		It("sets the sequence", func() { Expect(subject.TX.SeqNum).To(BeEquivalentTo(12345)) })
 	})
 
+	Describe("Timebounds", func() {
+		BeforeEach(func() { mut = Timebounds{MinTime: 1500000000, MaxTime: 1500000300} })
+		It("succeeds", func() { Expect(err).NotTo(HaveOccurred()) })
+		It("sets the time bounds", func() {
+			Expect(subject.TX.TimeBounds).ToNot(BeNil())
+			Expect(subject.TX.TimeBounds.MinTime).To(BeEquivalentTo(1500000000))
+			Expect(subject.TX.TimeBounds.MaxTime).To(BeEquivalentTo(1500000300))
+		})
+
+		It("survives an XDR round trip", func() {
+			encoded, err := xdr.MarshalBase64(subject.TX)
+			Expect(err).NotTo(HaveOccurred())
+
+			var decoded xdr.Transaction
+			Expect(xdr.SafeUnmarshalBase64(encoded, &decoded)).To(Succeed())
+			Expect(decoded.TimeBounds).To(Equal(subject.TX.TimeBounds))
+		})
+
+		Context("with no max time", func() {
+			BeforeEach(func() { mut = Timebounds{MinTime: 1500000000} })
+			It("succeeds", func() { Expect(err).NotTo(HaveOccurred()) })
+			It("sets max time to 0", func() { Expect(subject.TX.TimeBounds.MaxTime).To(BeEquivalentTo(0)) })
+		})
+
+		Context("with min time greater than max time", func() {
+			BeforeEach(func() { mut = Timebounds{MinTime: 1500000300, MaxTime: 1500000000} })
+			It("fails", func() { Expect(err).To(HaveOccurred()) })
+		})
+	})
+
+	Describe("TransactionBuilder.PreAuthTxSigner", func() {
+		var (
+			signer Signer
+			hash   [32]byte
+		)
+
+		BeforeEach(func() {
+			mut = Sequence{12345}
+		})
+
+		JustBeforeEach(func() {
+			hash, err = subject.Hash()
+			Expect(err).NotTo(HaveOccurred())
+			signer, err = subject.PreAuthTxSigner(1)
+		})
+
+		It("succeeds", func() { Expect(err).NotTo(HaveOccurred()) })
+		It("returns a pre-authorized transaction signer with the hash of the tx", func() {
+			var key xdr.SignerKey
+			Expect(key.SetAddress(signer.Address)).To(Succeed())
+			Expect(key.Type).To(Equal(xdr.SignerKeyTypeSignerKeyTypeHashTx))
+			Expect(key.MustHashTx()).To(BeEquivalentTo(hash))
+			Expect(signer.Weight).To(BeEquivalentTo(1))
+		})
+	})
+
 	Describe("AutoSequence", func() {
 		BeforeEach(func() {
 			mock := &MockSequenceProvider{
@@ -0,0 +1,64 @@
+package horizon
+
+import (
+	"sync"
+
+	"golang.org/x/net/context"
+)
+
+// DefaultConcurrency is the number of concurrent requests made by the batch
+// loaders of a Client whose Concurrency is not set.
+const DefaultConcurrency = 10
+
+// AccountResult is the outcome of loading a single account with
+// LoadAccounts.
+type AccountResult struct {
+	AccountID string
+	Account   Account
+	Err       error
+}
+
+// LoadAccounts loads the state of many accounts concurrently, making at most
+// c.Concurrency requests at a time.  The returned results are in the same
+// order as accountIDs, and each one holds either the account or the error
+// that occurred while loading it.
+func (c *Client) LoadAccounts(accountIDs []string) []AccountResult {
+	return c.LoadAccountsWithContext(context.Background(), accountIDs)
+}
+
+// LoadAccountsWithContext is like LoadAccounts but uses ctx for the
+// underlying requests.
+func (c *Client) LoadAccountsWithContext(ctx context.Context, accountIDs []string) []AccountResult {
+	results := make([]AccountResult, len(accountIDs))
+
+	workers := c.Concurrency
+	if workers <= 0 {
+		workers = DefaultConcurrency
+	}
+	if workers > len(accountIDs) {
+		workers = len(accountIDs)
+	}
+
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	wg.Add(workers)
+
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				result := &results[i]
+				result.AccountID = accountIDs[i]
+				result.Account, result.Err = c.LoadAccountWithContext(ctx, accountIDs[i])
+			}
+		}()
+	}
+
+	for i := range accountIDs {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	return results
+}
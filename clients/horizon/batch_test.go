@@ -0,0 +1,75 @@
+package horizon
+
+import (
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stellar/go/support/http/httptest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_LoadAccounts(t *testing.T) {
+	hmock := httptest.NewClient()
+	client := &Client{URL: "https://localhost", HTTP: hmock, Concurrency: 2}
+
+	ids := []string{
+		"GBRPYHIL2CI3FNQ4BXLFMNDLFJUNPU2HY3ZMFSHONUCEOASW7QC7OX2H",
+		"GAKLCFRTFDXKOEEUSBS23FBSUUVJRMDQHGCHNGGGJZQRK7BCPIMHUC4P",
+		"GBAUUA74H4XOQYRSOW2RZUA4QL5PB37U3JS5NE3RTB2ELJVMIF5RLMAG",
+	}
+
+	var (
+		mutex             sync.Mutex
+		active, maxActive int
+	)
+	responder := func(status int) httpmock.Responder {
+		return func(req *http.Request) (*http.Response, error) {
+			mutex.Lock()
+			active++
+			if active > maxActive {
+				maxActive = active
+			}
+			mutex.Unlock()
+
+			defer func() {
+				mutex.Lock()
+				active--
+				mutex.Unlock()
+			}()
+
+			if status != http.StatusOK {
+				return httpmock.NewStringResponse(status, notFoundResponse), nil
+			}
+			id := req.URL.Path[strings.LastIndex(req.URL.Path, "/")+1:]
+			return httpmock.NewStringResponse(status, `{"id": "`+id+`", "sequence": "1"}`), nil
+		}
+	}
+
+	hmock.On("GET", "https://localhost/accounts/"+ids[0]).Return(responder(http.StatusOK))
+	hmock.On("GET", "https://localhost/accounts/"+ids[1]).Return(responder(http.StatusNotFound))
+	hmock.On("GET", "https://localhost/accounts/"+ids[2]).Return(responder(http.StatusOK))
+
+	results := client.LoadAccounts(ids)
+	require.Len(t, results, 3)
+
+	for i, result := range results {
+		assert.Equal(t, ids[i], result.AccountID)
+	}
+
+	assert.NoError(t, results[0].Err)
+	assert.Equal(t, ids[0], results[0].Account.ID)
+
+	herr, ok := AsError(results[1].Err)
+	require.True(t, ok)
+	assert.True(t, herr.IsNotFound())
+
+	assert.NoError(t, results[2].Err)
+	assert.Equal(t, ids[2], results[2].Account.ID)
+
+	assert.True(t, maxActive <= 2)
+	assert.Empty(t, client.LoadAccounts(nil))
+}
@@ -0,0 +1,145 @@
+package horizon
+
+import (
+	"container/list"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"golang.org/x/net/context"
+)
+
+// DefaultCacheTTL is the time responses are cached for by a Client whose
+// CacheTTL is not set.
+const DefaultCacheTTL = 5 * time.Second
+
+// Cache stores the raw responses of idempotent requests, keyed by URL.  A
+// single Cache can be shared by several clients.  Implementations must be
+// safe for concurrent use.
+type Cache interface {
+	// Get returns the value stored for key, if it exists and has not
+	// expired.
+	Get(key string) ([]byte, bool)
+
+	// Set stores value for key for the duration of ttl.
+	Set(key string, value []byte, ttl time.Duration)
+}
+
+// LRUCache is an in-memory Cache holding a bounded number of entries.  When
+// it is full, the least recently used entry is evicted.
+type LRUCache struct {
+	mutex   sync.Mutex
+	size    int
+	entries map[string]*list.Element
+	order   *list.List
+}
+
+// lruEntry is an element of LRUCache.order.
+type lruEntry struct {
+	key       string
+	value     []byte
+	expiresAt time.Time
+}
+
+// NewLRUCache returns an empty LRUCache holding at most size entries.
+func NewLRUCache(size int) *LRUCache {
+	if size < 1 {
+		size = 1
+	}
+
+	return &LRUCache{
+		size:    size,
+		entries: map[string]*list.Element{},
+		order:   list.New(),
+	}
+}
+
+// Get implements Cache
+func (c *LRUCache) Get(key string) ([]byte, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	el, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+
+	entry := el.Value.(*lruEntry)
+	if time.Now().After(entry.expiresAt) {
+		c.remove(el)
+		return nil, false
+	}
+
+	c.order.MoveToFront(el)
+	return entry.value, true
+}
+
+// Set implements Cache
+func (c *LRUCache) Set(key string, value []byte, ttl time.Duration) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	expiresAt := time.Now().Add(ttl)
+
+	if el, ok := c.entries[key]; ok {
+		entry := el.Value.(*lruEntry)
+		entry.value = value
+		entry.expiresAt = expiresAt
+		c.order.MoveToFront(el)
+		return
+	}
+
+	c.entries[key] = c.order.PushFront(&lruEntry{
+		key:       key,
+		value:     value,
+		expiresAt: expiresAt,
+	})
+
+	for c.order.Len() > c.size {
+		c.remove(c.order.Back())
+	}
+}
+
+// Len returns the number of entries in the cache, including expired ones
+// that have not been evicted yet.
+func (c *LRUCache) Len() int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.order.Len()
+}
+
+// remove evicts el from the cache.  The caller must hold c.mutex.
+func (c *LRUCache) remove(el *list.Element) {
+	c.order.Remove(el)
+	delete(c.entries, el.Value.(*lruEntry).key)
+}
+
+// getCached is like get, but serves the response from c.Cache when possible
+// and stores successful responses in it.
+func (c *Client) getCached(ctx context.Context, endpoint string, object interface{}) error {
+	if c.Cache == nil {
+		_, err := c.get(ctx, endpoint, object)
+		return err
+	}
+
+	if data, ok := c.Cache.Get(endpoint); ok {
+		return json.Unmarshal(data, object)
+	}
+
+	var raw json.RawMessage
+	_, err := c.get(ctx, endpoint, &raw)
+	if err != nil {
+		return err
+	}
+
+	ttl := c.CacheTTL
+	if ttl <= 0 {
+		ttl = DefaultCacheTTL
+	}
+	c.Cache.Set(endpoint, raw, ttl)
+
+	return json.Unmarshal(raw, object)
+}
+
+// ensure that LRUCache implements Cache
+var _ Cache = &LRUCache{}
@@ -0,0 +1,91 @@
+package horizon
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stellar/go/support/http/httptest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLRUCache(t *testing.T) {
+	cache := NewLRUCache(2)
+
+	cache.Set("a", []byte("1"), time.Minute)
+	cache.Set("b", []byte("2"), time.Minute)
+
+	// reading a makes b the least recently used entry
+	value, ok := cache.Get("a")
+	require.True(t, ok)
+	assert.Equal(t, []byte("1"), value)
+
+	cache.Set("c", []byte("3"), time.Minute)
+	assert.Equal(t, 2, cache.Len())
+	_, ok = cache.Get("b")
+	assert.False(t, ok)
+	_, ok = cache.Get("c")
+	assert.True(t, ok)
+
+	// expired entries are not returned
+	cache.Set("d", []byte("4"), -time.Second)
+	_, ok = cache.Get("d")
+	assert.False(t, ok)
+}
+
+func TestClient_Cache(t *testing.T) {
+	hmock := httptest.NewClient()
+	client := &Client{
+		URL:   "https://localhost",
+		HTTP:  hmock,
+		Cache: NewLRUCache(10),
+	}
+
+	calls := 0
+	hmock.On(
+		"GET",
+		"https://localhost/accounts/GBRPYHIL2CI3FNQ4BXLFMNDLFJUNPU2HY3ZMFSHONUCEOASW7QC7OX2H",
+	).Return(func(req *http.Request) (*http.Response, error) {
+		calls++
+		return httpmock.NewStringResponse(http.StatusOK, accountResponse), nil
+	})
+
+	for i := 0; i < 3; i++ {
+		account, err := client.LoadAccount("GBRPYHIL2CI3FNQ4BXLFMNDLFJUNPU2HY3ZMFSHONUCEOASW7QC7OX2H")
+		require.NoError(t, err)
+		assert.Equal(t, "GBRPYHIL2CI3FNQ4BXLFMNDLFJUNPU2HY3ZMFSHONUCEOASW7QC7OX2H", account.ID)
+	}
+	assert.Equal(t, 1, calls)
+
+	// sequence numbers bypass the cache
+	_, err := client.SequenceForAccount("GBRPYHIL2CI3FNQ4BXLFMNDLFJUNPU2HY3ZMFSHONUCEOASW7QC7OX2H")
+	require.NoError(t, err)
+	assert.Equal(t, 2, calls)
+}
+
+func TestClient_CacheErrors(t *testing.T) {
+	hmock := httptest.NewClient()
+	client := &Client{
+		URL:   "https://localhost",
+		HTTP:  hmock,
+		Cache: NewLRUCache(10),
+	}
+
+	calls := 0
+	hmock.On("GET", "https://localhost/ledgers/1").Return(func(req *http.Request) (*http.Response, error) {
+		calls++
+		return httpmock.NewStringResponse(http.StatusNotFound, notFoundResponse), nil
+	})
+
+	for i := 0; i < 2; i++ {
+		_, err := client.LoadLedger(1)
+		herr, ok := AsError(err)
+		require.True(t, ok)
+		assert.True(t, herr.IsNotFound())
+	}
+
+	// errors are not cached
+	assert.Equal(t, 2, calls)
+}
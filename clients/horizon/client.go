@@ -1,15 +1,14 @@
 package horizon
 
 import (
-	"bufio"
 	"encoding/json"
 	"fmt"
-	"io"
 	"net/http"
 	"net/url"
 	"strconv"
 	"strings"
 
+	"github.com/stellar/go/build"
 	"github.com/stellar/go/support/errors"
 	"github.com/stellar/go/xdr"
 	"golang.org/x/net/context"
@@ -18,7 +17,13 @@ import (
 // HomeDomainForAccount returns the home domain for the provided strkey-encoded
 // account id.
 func (c *Client) HomeDomainForAccount(aid string) (string, error) {
-	a, err := c.LoadAccount(aid)
+	return c.HomeDomainForAccountWithContext(context.Background(), aid)
+}
+
+// HomeDomainForAccountWithContext is like HomeDomainForAccount but uses ctx
+// for the underlying request.
+func (c *Client) HomeDomainForAccountWithContext(ctx context.Context, aid string) (string, error) {
+	a, err := c.LoadAccountWithContext(ctx, aid)
 	if err != nil {
 		return "", errors.Wrap(err, "load account failed")
 	}
@@ -33,94 +38,300 @@ func (c *Client) fixURL() {
 
 // Root loads the root endpoint of horizon
 func (c *Client) Root() (root Root, err error) {
-	c.fixURLOnce.Do(c.fixURL)
-	resp, err := c.HTTP.Get(c.URL)
-	if err != nil {
-		return
-	}
+	return c.RootWithContext(context.Background())
+}
 
-	err = decodeResponse(resp, &root)
+// RootWithContext is like Root but uses ctx for the underlying request.
+func (c *Client) RootWithContext(ctx context.Context) (root Root, err error) {
+	c.fixURLOnce.Do(c.fixURL)
+	err = c.getCached(ctx, c.URL, &root)
 	return
 }
 
 // LoadAccount loads the account state from horizon. err can be either error
 // object or horizon.Error object.
 func (c *Client) LoadAccount(accountID string) (account Account, err error) {
+	return c.LoadAccountWithContext(context.Background(), accountID)
+}
+
+// LoadAccountWithContext is like LoadAccount but uses ctx for the underlying
+// request.
+func (c *Client) LoadAccountWithContext(ctx context.Context, accountID string) (account Account, err error) {
 	c.fixURLOnce.Do(c.fixURL)
-	resp, err := c.HTTP.Get(c.URL + "/accounts/" + accountID)
+	err = c.getCached(ctx, c.URL+"/accounts/"+accountID, &account)
+	return
+}
+
+// LoadAccountData loads the data entry named key of the account. Use
+// AccountData.Decode to get its raw value. err can be either error object or
+// horizon.Error object.
+func (c *Client) LoadAccountData(accountID string, key string) (data AccountData, err error) {
+	return c.LoadAccountDataWithContext(context.Background(), accountID, key)
+}
+
+// LoadAccountDataWithContext is like LoadAccountData but uses ctx for the
+// underlying request.
+func (c *Client) LoadAccountDataWithContext(ctx context.Context, accountID string, key string) (data AccountData, err error) {
+	c.fixURLOnce.Do(c.fixURL)
+	endpoint := c.URL + "/accounts/" + accountID + "/data/" + (&url.URL{Path: key}).EscapedPath()
+	_, err = c.get(ctx, endpoint, &data)
+	return
+}
+
+// LoadAccountDataEntries loads the decoded values of all the data entries of
+// the account, keyed by name. err can be either error object or horizon.Error
+// object.
+func (c *Client) LoadAccountDataEntries(accountID string) (entries map[string][]byte, err error) {
+	return c.LoadAccountDataEntriesWithContext(context.Background(), accountID)
+}
+
+// LoadAccountDataEntriesWithContext is like LoadAccountDataEntries but uses
+// ctx for the underlying request.
+func (c *Client) LoadAccountDataEntriesWithContext(ctx context.Context, accountID string) (entries map[string][]byte, err error) {
+	account, err := c.LoadAccountWithContext(ctx, accountID)
 	if err != nil {
 		return
 	}
 
-	err = decodeResponse(resp, &account)
-	return
+	return account.GetDataEntries()
 }
 
 // LoadAccountOffers loads the account offers from horizon. err can be either
 // error object or horizon.Error object.
-func (c *Client) LoadAccountOffers(accountID string, params ...interface{}) (offers OffersPage, err error) {
-	c.fixURLOnce.Do(c.fixURL)
-	endpoint := ""
-	query := url.Values{}
-
-	for _, param := range params {
-		switch param := param.(type) {
-		case At:
-			endpoint = string(param)
-		case Limit:
-			query.Add("limit", strconv.Itoa(int(param)))
-		case Order:
-			query.Add("order", string(param))
-		case Cursor:
-			query.Add("cursor", string(param))
-		default:
-			err = fmt.Errorf("Undefined parameter (%T): %+v", param, param)
-			return
+func (c *Client) LoadAccountOffers(accountID string, params ...Param) (offers OffersPage, err error) {
+	return c.LoadAccountOffersWithContext(context.Background(), accountID, params...)
+}
+
+// LoadAccountOffersWithContext is like LoadAccountOffers but uses ctx for the
+// underlying request.
+func (c *Client) LoadAccountOffersWithContext(ctx context.Context, accountID string, params ...Param) (offers OffersPage, err error) {
+	endpoint, err := c.buildURL("/accounts/"+accountID+"/offers", nil, params)
+	if err != nil {
+		err = errors.Wrap(err, "failed to parse endpoint")
+		return
+	}
+
+	_, err = c.get(ctx, endpoint, &offers)
+	if err != nil {
+		if _, ok := err.(*Error); !ok {
+			err = errors.Wrap(err, "failed to load endpoint")
 		}
+		return
 	}
 
-	if endpoint == "" {
-		endpoint = fmt.Sprintf(
-			"%s/accounts/%s/offers?%s",
-			c.URL,
-			accountID,
-			query.Encode(),
-		)
+	return
+}
+
+// LoadTransaction loads the transaction with the given hash from horizon.
+// err can be either error object or horizon.Error object.
+func (c *Client) LoadTransaction(transactionHash string) (transaction Transaction, err error) {
+	return c.LoadTransactionWithContext(context.Background(), transactionHash)
+}
+
+// LoadTransactionWithContext is like LoadTransaction but uses ctx for the
+// underlying request.
+func (c *Client) LoadTransactionWithContext(ctx context.Context, transactionHash string) (transaction Transaction, err error) {
+	c.fixURLOnce.Do(c.fixURL)
+	_, err = c.get(ctx, c.URL+"/transactions/"+transactionHash, &transaction)
+	return
+}
+
+// LoadOperation loads the operation with the given id from horizon. The
+// returned value is one of the *Operation types of this package, depending on
+// the type of the operation. err can be either error object or horizon.Error
+// object.
+func (c *Client) LoadOperation(operationID string) (operation Operation, err error) {
+	return c.LoadOperationWithContext(context.Background(), operationID)
+}
+
+// LoadOperationWithContext is like LoadOperation but uses ctx for the
+// underlying request.
+func (c *Client) LoadOperationWithContext(ctx context.Context, operationID string) (operation Operation, err error) {
+	c.fixURLOnce.Do(c.fixURL)
+
+	var raw json.RawMessage
+	_, err = c.get(ctx, c.URL+"/operations/"+operationID, &raw)
+	if err != nil {
+		return
 	}
 
-	// ensure our endpoint is a real url
-	_, err = url.Parse(endpoint)
+	var base BaseOperation
+	err = json.Unmarshal(raw, &base)
+	if err != nil {
+		err = errors.Wrap(err, "Error unmarshaling operation")
+		return
+	}
+
+	return UnmarshalOperation(base.TypeI, raw)
+}
+
+// LoadAssets loads the statistics of the assets issued on the network. Use
+// the AssetCode and AssetIssuer params to filter the results. err can be
+// either error object or horizon.Error object.
+func (c *Client) LoadAssets(params ...Param) (assets AssetsPage, err error) {
+	return c.LoadAssetsWithContext(context.Background(), params...)
+}
+
+// LoadAssetsWithContext is like LoadAssets but uses ctx for the underlying
+// request.
+func (c *Client) LoadAssetsWithContext(ctx context.Context, params ...Param) (assets AssetsPage, err error) {
+	endpoint, err := c.buildURL("/assets", nil, params)
 	if err != nil {
 		err = errors.Wrap(err, "failed to parse endpoint")
 		return
 	}
 
-	resp, err := c.HTTP.Get(endpoint)
+	_, err = c.get(ctx, endpoint, &assets)
+	return
+}
+
+// LoadFeeStats loads the fees accepted by the network in the last ledgers,
+// which can be used to pick a competitive fee before building a transaction.
+// err can be either error object or horizon.Error object.
+func (c *Client) LoadFeeStats() (feeStats FeeStats, err error) {
+	return c.LoadFeeStatsWithContext(context.Background())
+}
+
+// LoadFeeStatsWithContext is like LoadFeeStats but uses ctx for the
+// underlying request.
+func (c *Client) LoadFeeStatsWithContext(ctx context.Context) (feeStats FeeStats, err error) {
+	endpoint, err := c.buildURL("/fee_stats", nil, nil)
 	if err != nil {
-		err = errors.Wrap(err, "failed to load endpoint")
+		err = errors.Wrap(err, "failed to parse endpoint")
 		return
 	}
 
-	err = decodeResponse(resp, &offers)
+	_, err = c.get(ctx, endpoint, &feeStats)
+	return
+}
+
+// LoadLedger loads the ledger with the given sequence number. err can be
+// either error object or horizon.Error object.
+func (c *Client) LoadLedger(sequence int32) (ledger Ledger, err error) {
+	return c.LoadLedgerWithContext(context.Background(), sequence)
+}
+
+// LoadLedgerWithContext is like LoadLedger but uses ctx for the underlying
+// request.
+func (c *Client) LoadLedgerWithContext(ctx context.Context, sequence int32) (ledger Ledger, err error) {
+	c.fixURLOnce.Do(c.fixURL)
+	err = c.getCached(ctx, c.URL+ledgerPath(sequence), &ledger)
+	return
+}
+
+// LoadLedgers loads a page of the ledgers closed by the network. err can be
+// either error object or horizon.Error object.
+func (c *Client) LoadLedgers(params ...Param) (ledgers LedgersPage, err error) {
+	return c.LoadLedgersWithContext(context.Background(), params...)
+}
+
+// LoadLedgersWithContext is like LoadLedgers but uses ctx for the underlying
+// request.
+func (c *Client) LoadLedgersWithContext(ctx context.Context, params ...Param) (ledgers LedgersPage, err error) {
+	err = c.loadPage(ctx, "/ledgers", params, &ledgers)
+	return
+}
+
+// LoadLedgerTransactions loads a page of the transactions included in the
+// ledger with the given sequence number. err can be either error object or
+// horizon.Error object.
+func (c *Client) LoadLedgerTransactions(sequence int32, params ...Param) (transactions TransactionsPage, err error) {
+	return c.LoadLedgerTransactionsWithContext(context.Background(), sequence, params...)
+}
+
+// LoadLedgerTransactionsWithContext is like LoadLedgerTransactions but uses
+// ctx for the underlying request.
+func (c *Client) LoadLedgerTransactionsWithContext(ctx context.Context, sequence int32, params ...Param) (transactions TransactionsPage, err error) {
+	err = c.loadPage(ctx, ledgerPath(sequence)+"/transactions", params, &transactions)
+	return
+}
+
+// LoadLedgerOperations loads a page of the operations included in the ledger
+// with the given sequence number. err can be either error object or
+// horizon.Error object.
+func (c *Client) LoadLedgerOperations(sequence int32, params ...Param) (operations OperationsPage, err error) {
+	return c.LoadLedgerOperationsWithContext(context.Background(), sequence, params...)
+}
+
+// LoadLedgerOperationsWithContext is like LoadLedgerOperations but uses ctx
+// for the underlying request.
+func (c *Client) LoadLedgerOperationsWithContext(ctx context.Context, sequence int32, params ...Param) (operations OperationsPage, err error) {
+	err = c.loadPage(ctx, ledgerPath(sequence)+"/operations", params, &operations)
+	return
+}
+
+// LoadLedgerPayments loads a page of the payments included in the ledger with
+// the given sequence number. err can be either error object or horizon.Error
+// object.
+func (c *Client) LoadLedgerPayments(sequence int32, params ...Param) (payments PaymentsPage, err error) {
+	return c.LoadLedgerPaymentsWithContext(context.Background(), sequence, params...)
+}
+
+// LoadLedgerPaymentsWithContext is like LoadLedgerPayments but uses ctx for
+// the underlying request.
+func (c *Client) LoadLedgerPaymentsWithContext(ctx context.Context, sequence int32, params ...Param) (payments PaymentsPage, err error) {
+	err = c.loadPage(ctx, ledgerPath(sequence)+"/payments", params, &payments)
+	return
+}
+
+// LoadOffer loads the offer with the given id. err can be either error object
+// or horizon.Error object.
+func (c *Client) LoadOffer(offerID int64) (offer Offer, err error) {
+	return c.LoadOfferWithContext(context.Background(), offerID)
+}
+
+// LoadOfferWithContext is like LoadOffer but uses ctx for the underlying
+// request.
+func (c *Client) LoadOfferWithContext(ctx context.Context, offerID int64) (offer Offer, err error) {
+	c.fixURLOnce.Do(c.fixURL)
+	_, err = c.get(ctx, c.URL+offerPath(offerID), &offer)
+	return
+}
+
+// LoadTradesForOffer loads a page of the trades that filled the offer with
+// the given id. err can be either error object or horizon.Error object.
+func (c *Client) LoadTradesForOffer(offerID int64, params ...Param) (trades TradesPage, err error) {
+	return c.LoadTradesForOfferWithContext(context.Background(), offerID, params...)
+}
+
+// LoadTradesForOfferWithContext is like LoadTradesForOffer but uses ctx for
+// the underlying request.
+func (c *Client) LoadTradesForOfferWithContext(ctx context.Context, offerID int64, params ...Param) (trades TradesPage, err error) {
+	err = c.loadPage(ctx, offerPath(offerID)+"/trades", params, &trades)
 	return
 }
 
 // LoadMemo loads memo for a transaction in Payment
 func (c *Client) LoadMemo(p *Payment) (err error) {
-	res, err := c.HTTP.Get(p.Links.Transaction.Href)
-	if err != nil {
-		return errors.Wrap(err, "load transaction failed")
-	}
-	defer res.Body.Close()
-	return json.NewDecoder(res.Body).Decode(&p.Memo)
+	return c.LoadMemoWithContext(context.Background(), p)
+}
+
+// LoadMemoWithContext is like LoadMemo but uses ctx for the underlying
+// request.
+func (c *Client) LoadMemoWithContext(ctx context.Context, p *Payment) (err error) {
+	_, err = c.get(ctx, p.Links.Transaction.Href, &p.Memo)
+	return
 }
 
 // SequenceForAccount implements build.SequenceProvider
 func (c *Client) SequenceForAccount(
 	accountID string,
 ) (xdr.SequenceNumber, error) {
+	return c.SequenceForAccountWithContext(context.Background(), accountID)
+}
+
+// SequenceForAccountWithContext is like SequenceForAccount but uses ctx for
+// the underlying request.
+func (c *Client) SequenceForAccountWithContext(
+	ctx context.Context,
+	accountID string,
+) (xdr.SequenceNumber, error) {
 
-	a, err := c.LoadAccount(accountID)
+	// the sequence is always loaded from horizon, bypassing c.Cache, as a
+	// stale one would make the transaction fail
+	c.fixURLOnce.Do(c.fixURL)
+	var a Account
+	_, err := c.get(ctx, c.URL+"/accounts/"+accountID, &a)
 	if err != nil {
 		return 0, errors.Wrap(err, "load account failed")
 	}
@@ -133,126 +344,76 @@ func (c *Client) SequenceForAccount(
 	return xdr.SequenceNumber(seq), nil
 }
 
-// LoadOrderBook loads order book for given selling and buying assets.
-func (c *Client) LoadOrderBook(selling Asset, buying Asset, params ...interface{}) (orderBook OrderBookSummary, err error) {
-	c.fixURLOnce.Do(c.fixURL)
-	query := url.Values{}
-
-	query.Add("selling_asset_type", selling.Type)
-	query.Add("selling_asset_code", selling.Code)
-	query.Add("selling_asset_issuer", selling.Issuer)
-
-	query.Add("buying_asset_type", buying.Type)
-	query.Add("buying_asset_code", buying.Code)
-	query.Add("buying_asset_issuer", buying.Issuer)
-
-	for _, param := range params {
-		switch param := param.(type) {
-		case Limit:
-			query.Add("limit", strconv.Itoa(int(param)))
-		default:
-			err = fmt.Errorf("Undefined parameter (%T): %+v", param, param)
-			return
-		}
-	}
+// SignersForAccount implements build.SignersProvider
+func (c *Client) SignersForAccount(accountID string) (build.AccountSigners, error) {
+	return c.SignersForAccountWithContext(context.Background(), accountID)
+}
 
-	resp, err := c.HTTP.Get(c.URL + "/order_book?" + query.Encode())
+// SignersForAccountWithContext is like SignersForAccount but uses ctx for the
+// underlying request.
+func (c *Client) SignersForAccountWithContext(ctx context.Context, accountID string) (build.AccountSigners, error) {
+	a, err := c.LoadAccountWithContext(ctx, accountID)
 	if err != nil {
-		return
+		return build.AccountSigners{}, errors.Wrap(err, "load account failed")
 	}
 
-	err = decodeResponse(resp, &orderBook)
-	return
+	return a.AccountSigners(), nil
 }
 
-func (c *Client) stream(ctx context.Context, baseURL string, cursor *Cursor, handler func(data []byte) error) error {
-	query := url.Values{}
-	if cursor != nil {
-		query.Set("cursor", string(*cursor))
-	}
-
-	for {
-		req, err := http.NewRequest("GET", fmt.Sprintf("%s?%s", baseURL, query.Encode()), nil)
-		if err != nil {
-			return err
-		}
-		req.Header.Set("Accept", "text/event-stream")
+// BaseFee implements build.FeeProvider.  It returns the most common fee per
+// operation accepted by the network in the last ledgers, and never less than
+// the base fee of the last ledger.  When horizon doesn't serve /fee_stats the
+// base fee of the latest ledger is returned.
+func (c *Client) BaseFee() (uint64, error) {
+	return c.BaseFeeWithContext(context.Background())
+}
 
-		resp, err := c.HTTP.Do(req)
-		if err != nil {
-			return err
-		}
-		defer resp.Body.Close()
-		scanner := bufio.NewScanner(resp.Body)
-		scanner.Split(splitSSE)
-
-		var objectBytes []byte
-
-		for scanner.Scan() {
-			// Check if ctx is not cancelled
-			select {
-			case <-ctx.Done():
-				return nil
-			default:
-				// Continue streaming
-			}
-
-			if len(scanner.Bytes()) == 0 {
-				continue
-			}
-
-			ev, err := parseEvent(scanner.Bytes())
-			if err != nil {
-				return err
-			}
-
-			if ev.Event != "message" {
-				continue
-			}
-
-			switch data := ev.Data.(type) {
-			case string:
-				err = handler([]byte(data))
-				objectBytes = []byte(data)
-			case []byte:
-				err = handler(data)
-				objectBytes = data
-			default:
-				err = errors.New("Invalid ev.Data type")
-			}
-			if err != nil {
-				return err
-			}
+// BaseFeeWithContext is like BaseFee but uses ctx for the underlying
+// requests.
+func (c *Client) BaseFeeWithContext(ctx context.Context) (uint64, error) {
+	feeStats, err := c.LoadFeeStatsWithContext(ctx)
+	if err == nil {
+		fee := feeStats.ModeAcceptedFee
+		if fee < feeStats.LastLedgerBaseFee {
+			fee = feeStats.LastLedgerBaseFee
 		}
+		return uint64(fee), nil
+	}
 
-		err = scanner.Err()
+	// older horizon servers don't serve /fee_stats
+	if herr, ok := AsError(err); !ok || !herr.IsNotFound() {
+		return 0, errors.Wrap(err, "load fee stats failed")
+	}
 
-		// Start streaming from the next object:
-		// - if there was no error OR
-		// - if connection was lost
-		if err == nil || err == io.ErrUnexpectedEOF {
-			object := struct {
-				PT string `json:"paging_token"`
-			}{}
+	root, err := c.RootWithContext(ctx)
+	if err != nil {
+		return 0, errors.Wrap(err, "load root failed")
+	}
 
-			err := json.Unmarshal(objectBytes, &object)
-			if err != nil {
-				return errors.Wrap(err, "Error unmarshaling objectBytes")
-			}
+	ledger, err := c.LoadLedgerWithContext(ctx, root.HorizonSequence)
+	if err != nil {
+		return 0, errors.Wrap(err, "load ledger failed")
+	}
 
-			if object.PT != "" {
-				query.Set("cursor", object.PT)
-			} else {
-				return errors.New("no paging_token in object: cannot continue")
-			}
+	return uint64(ledger.BaseFee), nil
+}
 
-			continue
-		}
+// LoadOrderBook loads order book for given selling and buying assets.
+func (c *Client) LoadOrderBook(selling Asset, buying Asset, params ...Param) (orderBook OrderBookSummary, err error) {
+	return c.LoadOrderBookWithContext(context.Background(), selling, buying, params...)
+}
 
-		if err != nil {
-			return err
-		}
+// LoadOrderBookWithContext is like LoadOrderBook but uses ctx for the
+// underlying request.
+func (c *Client) LoadOrderBookWithContext(ctx context.Context, selling Asset, buying Asset, params ...Param) (orderBook OrderBookSummary, err error) {
+	endpoint, err := c.buildURL("/order_book", orderBookQuery(selling, buying), params)
+	if err != nil {
+		err = errors.Wrap(err, "failed to parse endpoint")
+		return
 	}
+
+	_, err = c.get(ctx, endpoint, &orderBook)
+	return
 }
 
 // StreamLedgers streams incoming ledgers. Use context.WithCancel to stop streaming or
@@ -303,20 +464,56 @@ func (c *Client) StreamTransactions(ctx context.Context, accountID string, curso
 	})
 }
 
+// StreamOrderBook streams the order book for the given selling and buying
+// assets. The handler is called with a new summary each time the order book
+// changes. Use context.WithCancel to stop streaming or context.Background() if
+// you want to stream indefinitely.
+func (c *Client) StreamOrderBook(ctx context.Context, selling Asset, buying Asset, handler OrderBookHandler) (err error) {
+	c.fixURLOnce.Do(c.fixURL)
+	url := fmt.Sprintf("%s/order_book", c.URL)
+	return c.streamQuery(ctx, url, orderBookQuery(selling, buying), false, messageHandler(func(data []byte) error {
+		var orderBook OrderBookSummary
+		err = json.Unmarshal(data, &orderBook)
+		if err != nil {
+			return errors.Wrap(err, "Error unmarshaling data")
+		}
+		handler(orderBook)
+		return nil
+	}))
+}
+
 // SubmitTransaction submits a transaction to the network. err can be either error object or horizon.Error object.
 func (c *Client) SubmitTransaction(transactionEnvelopeXdr string) (response TransactionSuccess, err error) {
+	return c.SubmitTransactionWithContext(context.Background(), transactionEnvelopeXdr)
+}
+
+// SubmitTransactionWithContext is like SubmitTransaction but uses ctx for the
+// underlying request.
+func (c *Client) SubmitTransactionWithContext(ctx context.Context, transactionEnvelopeXdr string) (response TransactionSuccess, err error) {
 	c.fixURLOnce.Do(c.fixURL)
-	v := url.Values{}
-	v.Set("tx", transactionEnvelopeXdr)
 
-	resp, err := c.HTTP.PostForm(c.URL+"/transactions", v)
-	if err != nil {
-		err = errors.Wrap(err, "http post failed")
-		return
+	if c.Preflight != nil {
+		err = c.Preflight.ValidateTransaction(transactionEnvelopeXdr)
+		if err != nil {
+			return
+		}
+	}
+
+	if c.VerifyNetwork {
+		err = c.verifyNetwork(ctx, transactionEnvelopeXdr)
+		if err != nil {
+			return
+		}
 	}
 
-	err = decodeResponse(resp, &response)
+	v := url.Values{}
+	v.Set("tx", transactionEnvelopeXdr)
+
+	resp, err := c.postForm(ctx, c.URL+"/transactions", v, &response)
 	if err != nil {
+		if _, ok := err.(*Error); !ok {
+			err = errors.Wrap(err, "http post failed")
+		}
 		return
 	}
 
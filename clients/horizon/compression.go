@@ -0,0 +1,54 @@
+package horizon
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+
+	"github.com/stellar/go/support/errors"
+)
+
+// setAcceptEncoding asks horizon to compress the response to req, unless
+// c.DisableCompression is set.  Setting the header explicitly, rather than
+// relying on the transparent compression of http.Transport, makes it work
+// with any HTTP implementation.
+func (c *Client) setAcceptEncoding(req *http.Request) {
+	if c.DisableCompression {
+		req.Header.Set("Accept-Encoding", "identity")
+		return
+	}
+	req.Header.Set("Accept-Encoding", "gzip")
+}
+
+// decompressResponse replaces the body of a gzip encoded resp with a reader of
+// its decompressed content.
+func decompressResponse(resp *http.Response) error {
+	if resp.Header.Get("Content-Encoding") != "gzip" {
+		return nil
+	}
+
+	reader, err := gzip.NewReader(resp.Body)
+	if err != nil {
+		resp.Body.Close()
+		return errors.Wrap(err, "failed to decompress response")
+	}
+
+	resp.Body = &gzipBody{Reader: reader, body: resp.Body}
+	resp.Header.Del("Content-Encoding")
+	resp.Header.Del("Content-Length")
+	resp.ContentLength = -1
+	return nil
+}
+
+// gzipBody is a decompressing response body.  Closing it closes the
+// underlying body.
+type gzipBody struct {
+	*gzip.Reader
+	body io.ReadCloser
+}
+
+// Close implements io.Closer
+func (b *gzipBody) Close() error {
+	b.Reader.Close()
+	return b.body.Close()
+}
@@ -0,0 +1,100 @@
+package horizon
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/stellar/go/support/http/httptest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/context"
+)
+
+func gzipResponse(t *testing.T, status int, body string) *http.Response {
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	_, err := w.Write([]byte(body))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+
+	return &http.Response{
+		StatusCode: status,
+		Header:     http.Header{"Content-Encoding": []string{"gzip"}},
+		Body:       ioutil.NopCloser(&buf),
+	}
+}
+
+func TestClient_Compression(t *testing.T) {
+	hmock := httptest.NewClient()
+	client := &Client{URL: "https://localhost", HTTP: hmock}
+
+	var encoding string
+	hmock.On("GET", "https://localhost/ledgers/3128812").Return(func(req *http.Request) (*http.Response, error) {
+		encoding = req.Header.Get("Accept-Encoding")
+		return gzipResponse(t, 200, ledgerResponse), nil
+	})
+
+	ledger, err := client.LoadLedger(3128812)
+	require.NoError(t, err)
+	assert.Equal(t, "gzip", encoding)
+	assert.Equal(t, int32(3128812), ledger.Sequence)
+}
+
+func TestClient_CompressionError(t *testing.T) {
+	hmock := httptest.NewClient()
+	client := &Client{URL: "https://localhost", HTTP: hmock}
+
+	hmock.On("GET", "https://localhost/ledgers/3128812").Return(func(req *http.Request) (*http.Response, error) {
+		return gzipResponse(t, 404, notFoundResponse), nil
+	})
+
+	_, err := client.LoadLedger(3128812)
+	herr, ok := err.(*Error)
+	require.True(t, ok)
+	assert.True(t, herr.IsNotFound())
+}
+
+func TestClient_DisableCompression(t *testing.T) {
+	hmock := httptest.NewClient()
+	client := &Client{URL: "https://localhost", HTTP: hmock, DisableCompression: true}
+
+	var encoding string
+	hmock.On("GET", "https://localhost/ledgers/3128812").Return(func(req *http.Request) (*http.Response, error) {
+		encoding = req.Header.Get("Accept-Encoding")
+		return &http.Response{
+			StatusCode: 200,
+			Header:     http.Header{},
+			Body:       ioutil.NopCloser(bytes.NewBufferString(ledgerResponse)),
+		}, nil
+	})
+
+	_, err := client.LoadLedger(3128812)
+	require.NoError(t, err)
+	assert.Equal(t, "identity", encoding)
+}
+
+func TestClient_StreamsAreNotCompressed(t *testing.T) {
+	hmock := httptest.NewClient()
+	client := &Client{URL: "https://localhost", HTTP: hmock}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var encoding string
+	hmock.On("GET", "https://localhost/ledgers").Return(func(req *http.Request) (*http.Response, error) {
+		encoding = req.Header.Get("Accept-Encoding")
+		cancel()
+		return &http.Response{
+			StatusCode: 200,
+			Header:     http.Header{},
+			Body:       ioutil.NopCloser(bytes.NewBufferString(ledgerStreamResponse)),
+		}, nil
+	})
+
+	err := client.StreamLedgers(ctx, nil, func(Ledger) {})
+	require.NoError(t, err)
+	assert.Equal(t, "identity", encoding)
+}
@@ -0,0 +1,231 @@
+package horizon
+
+import (
+	"encoding/json"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/stellar/go/support/errors"
+)
+
+// Effect is implemented by all the effect resources returned by horizon.  Use
+// a type switch to access the fields of a specific effect type:
+//
+//	switch e := effect.(type) {
+//	case AccountCreditedEffect:
+//	  ...
+//	case GenericEffect:
+//	  // an effect type unknown to this version of the client
+//	}
+type Effect interface {
+	GetBase() BaseEffect
+}
+
+// BaseEffect represents the common attributes of an effect resource.  It is
+// also the resource type of the effects that have no attributes of their own,
+// e.g. account_removed.
+type BaseEffect struct {
+	Links struct {
+		Operation Link `json:"operation"`
+		Succeeds  Link `json:"succeeds"`
+		Precedes  Link `json:"precedes"`
+	} `json:"_links"`
+
+	ID              string    `json:"id"`
+	PT              string    `json:"paging_token"`
+	Account         string    `json:"account"`
+	Type            string    `json:"type"`
+	TypeI           int32     `json:"type_i"`
+	LedgerCloseTime time.Time `json:"created_at"`
+}
+
+// GetBase implements Effect
+func (e BaseEffect) GetBase() BaseEffect {
+	return e
+}
+
+// GenericEffect is the resource returned for the effect types that are not
+// known to the client, so that new effects added to horizon don't break
+// existing applications.  Raw holds the full JSON representation of the
+// effect.
+type GenericEffect struct {
+	BaseEffect
+	Raw json.RawMessage
+}
+
+// AccountCreatedEffect is the resource representing an account_created
+// effect.
+type AccountCreatedEffect struct {
+	BaseEffect
+	StartingBalance string `json:"starting_balance"`
+}
+
+// AccountCreditedEffect is the resource representing an account_credited
+// effect.
+type AccountCreditedEffect struct {
+	BaseEffect
+	Asset
+	Amount string `json:"amount"`
+}
+
+// AccountDebitedEffect is the resource representing an account_debited
+// effect.
+type AccountDebitedEffect struct {
+	BaseEffect
+	Asset
+	Amount string `json:"amount"`
+}
+
+// AccountThresholdsUpdatedEffect is the resource representing an
+// account_thresholds_updated effect.
+type AccountThresholdsUpdatedEffect struct {
+	BaseEffect
+	LowThreshold  int32 `json:"low_threshold"`
+	MedThreshold  int32 `json:"med_threshold"`
+	HighThreshold int32 `json:"high_threshold"`
+}
+
+// AccountHomeDomainUpdatedEffect is the resource representing an
+// account_home_domain_updated effect.
+type AccountHomeDomainUpdatedEffect struct {
+	BaseEffect
+	HomeDomain string `json:"home_domain"`
+}
+
+// AccountFlagsUpdatedEffect is the resource representing an
+// account_flags_updated effect.
+type AccountFlagsUpdatedEffect struct {
+	BaseEffect
+	AuthRequired  *bool `json:"auth_required_flag,omitempty"`
+	AuthRevokable *bool `json:"auth_revokable_flag,omitempty"`
+}
+
+// SignerEffect is the resource representing the signer_created,
+// signer_removed and signer_updated effects.
+type SignerEffect struct {
+	BaseEffect
+	Weight    int32  `json:"weight"`
+	PublicKey string `json:"public_key"`
+	Key       string `json:"key"`
+}
+
+// TrustlineEffect is the resource representing the trustline_created,
+// trustline_removed and trustline_updated effects.
+type TrustlineEffect struct {
+	BaseEffect
+	Asset
+	Limit string `json:"limit"`
+}
+
+// TrustlineAuthorizationEffect is the resource representing the
+// trustline_authorized and trustline_deauthorized effects.
+type TrustlineAuthorizationEffect struct {
+	BaseEffect
+	Trustor   string `json:"trustor"`
+	AssetType string `json:"asset_type"`
+	AssetCode string `json:"asset_code,omitempty"`
+}
+
+// TradeEffect is the resource representing a trade effect.
+type TradeEffect struct {
+	BaseEffect
+	Seller            string `json:"seller"`
+	OfferID           int64  `json:"offer_id"`
+	SoldAmount        string `json:"sold_amount"`
+	SoldAssetType     string `json:"sold_asset_type"`
+	SoldAssetCode     string `json:"sold_asset_code,omitempty"`
+	SoldAssetIssuer   string `json:"sold_asset_issuer,omitempty"`
+	BoughtAmount      string `json:"bought_amount"`
+	BoughtAssetType   string `json:"bought_asset_type"`
+	BoughtAssetCode   string `json:"bought_asset_code,omitempty"`
+	BoughtAssetIssuer string `json:"bought_asset_issuer,omitempty"`
+}
+
+// EffectDecoder decodes the JSON representation of an effect into its
+// resource type.
+type EffectDecoder func(data []byte) (Effect, error)
+
+// NewEffectDecoder returns an EffectDecoder that decodes effects into values
+// of the same struct type as prototype, which is typically a zero value.
+func NewEffectDecoder(prototype Effect) EffectDecoder {
+	t := reflect.TypeOf(prototype)
+	return func(data []byte) (Effect, error) {
+		v := reflect.New(t)
+		err := json.Unmarshal(data, v.Interface())
+		if err != nil {
+			return nil, err
+		}
+		return v.Elem().Interface().(Effect), nil
+	}
+}
+
+var (
+	effectDecodersMutex sync.RWMutex
+	effectDecoders      = map[string]EffectDecoder{
+		"account_created":                       NewEffectDecoder(AccountCreatedEffect{}),
+		"account_removed":                       NewEffectDecoder(BaseEffect{}),
+		"account_credited":                      NewEffectDecoder(AccountCreditedEffect{}),
+		"account_debited":                       NewEffectDecoder(AccountDebitedEffect{}),
+		"account_thresholds_updated":            NewEffectDecoder(AccountThresholdsUpdatedEffect{}),
+		"account_home_domain_updated":           NewEffectDecoder(AccountHomeDomainUpdatedEffect{}),
+		"account_flags_updated":                 NewEffectDecoder(AccountFlagsUpdatedEffect{}),
+		"account_inflation_destination_updated": NewEffectDecoder(BaseEffect{}),
+		"signer_created":                        NewEffectDecoder(SignerEffect{}),
+		"signer_removed":                        NewEffectDecoder(SignerEffect{}),
+		"signer_updated":                        NewEffectDecoder(SignerEffect{}),
+		"trustline_created":                     NewEffectDecoder(TrustlineEffect{}),
+		"trustline_removed":                     NewEffectDecoder(TrustlineEffect{}),
+		"trustline_updated":                     NewEffectDecoder(TrustlineEffect{}),
+		"trustline_authorized":                  NewEffectDecoder(TrustlineAuthorizationEffect{}),
+		"trustline_deauthorized":                NewEffectDecoder(TrustlineAuthorizationEffect{}),
+		"offer_created":                         NewEffectDecoder(BaseEffect{}),
+		"offer_removed":                         NewEffectDecoder(BaseEffect{}),
+		"offer_updated":                         NewEffectDecoder(BaseEffect{}),
+		"trade":                                 NewEffectDecoder(TradeEffect{}),
+		"data_created":                          NewEffectDecoder(BaseEffect{}),
+		"data_removed":                          NewEffectDecoder(BaseEffect{}),
+		"data_updated":                          NewEffectDecoder(BaseEffect{}),
+	}
+)
+
+// RegisterEffect registers the decoder used by UnmarshalEffect for effects of
+// the given type, replacing the built-in one if any.  Applications can use it
+// to decode effects added to horizon after this version of the client:
+//
+//	horizon.RegisterEffect("my_effect", horizon.NewEffectDecoder(MyEffect{}))
+func RegisterEffect(effectType string, decoder EffectDecoder) {
+	effectDecodersMutex.Lock()
+	defer effectDecodersMutex.Unlock()
+	effectDecoders[effectType] = decoder
+}
+
+// UnmarshalEffect decodes the JSON representation of an effect into the
+// resource type registered for effectType.  Effects of unknown types are
+// returned as GenericEffect rather than failing.
+func UnmarshalEffect(effectType string, data []byte) (Effect, error) {
+	effectDecodersMutex.RLock()
+	decoder, ok := effectDecoders[effectType]
+	effectDecodersMutex.RUnlock()
+
+	if !ok {
+		decoder = decodeGenericEffect
+	}
+
+	effect, err := decoder(data)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error unmarshaling effect")
+	}
+
+	return effect, nil
+}
+
+// decodeGenericEffect decodes an effect of an unknown type.
+func decodeGenericEffect(data []byte) (Effect, error) {
+	e := GenericEffect{Raw: append(json.RawMessage(nil), data...)}
+	err := json.Unmarshal(data, &e.BaseEffect)
+	if err != nil {
+		return nil, err
+	}
+	return e, nil
+}
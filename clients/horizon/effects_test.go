@@ -0,0 +1,271 @@
+package horizon
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnmarshalEffect(t *testing.T) {
+	t.Run("known type", func(t *testing.T) {
+		effect, err := UnmarshalEffect("account_created", []byte(`{
+			"id": "0000000012884905985-0000000001",
+			"type": "account_created",
+			"type_i": 0,
+			"account": "GBRPYHIL2CI3FNQ4BXLFMNDLFJUNPU2HY3ZMFSHONUCEOASW7QC7OX2H",
+			"starting_balance": "10000.0000000"
+		}`))
+		require.NoError(t, err)
+
+		created, ok := effect.(AccountCreatedEffect)
+		require.True(t, ok)
+		assert.Equal(t, "10000.0000000", created.StartingBalance)
+		assert.Equal(t, "GBRPYHIL2CI3FNQ4BXLFMNDLFJUNPU2HY3ZMFSHONUCEOASW7QC7OX2H", created.GetBase().Account)
+	})
+
+	t.Run("unknown type", func(t *testing.T) {
+		data := []byte(`{"id": "1", "type": "bump_sequence", "type_i": 43, "new_seq": "5"}`)
+		effect, err := UnmarshalEffect("bump_sequence", data)
+		require.NoError(t, err)
+
+		generic, ok := effect.(GenericEffect)
+		require.True(t, ok)
+		assert.Equal(t, "bump_sequence", generic.Type)
+		assert.Equal(t, int32(43), generic.TypeI)
+		assert.JSONEq(t, string(data), string(generic.Raw))
+	})
+
+	t.Run("registered type", func(t *testing.T) {
+		type customEffect struct {
+			BaseEffect
+			Value string `json:"value"`
+		}
+
+		RegisterEffect("custom_effect", NewEffectDecoder(customEffect{}))
+		defer func() {
+			effectDecodersMutex.Lock()
+			delete(effectDecoders, "custom_effect")
+			effectDecodersMutex.Unlock()
+		}()
+
+		effect, err := UnmarshalEffect("custom_effect", []byte(`{"type": "custom_effect", "value": "42"}`))
+		require.NoError(t, err)
+
+		custom, ok := effect.(customEffect)
+		require.True(t, ok)
+		assert.Equal(t, "42", custom.Value)
+	})
+
+	t.Run("invalid json", func(t *testing.T) {
+		_, err := UnmarshalEffect("unknown", []byte(`{`))
+		assert.Error(t, err)
+		_, err = UnmarshalEffect("account_created", []byte(`{`))
+		assert.Error(t, err)
+	})
+}
+
+// effectFixtures holds a JSON fixture, as rendered by horizon, of every
+// effect type known to the client along with the resource it decodes to.
+var effectFixtures = []struct {
+	Type     string
+	JSON     string
+	Expected Effect
+}{
+	{
+		Type:     "account_created",
+		JSON:     `{"id": "1", "paging_token": "1", "account": "GA", "type": "account_created", "type_i": 0, "starting_balance": "10000.0000000"}`,
+		Expected: AccountCreatedEffect{BaseEffect: effectBase("account_created", 0), StartingBalance: "10000.0000000"},
+	},
+	{
+		Type:     "account_removed",
+		JSON:     `{"id": "1", "paging_token": "1", "account": "GA", "type": "account_removed", "type_i": 1}`,
+		Expected: effectBase("account_removed", 1),
+	},
+	{
+		Type: "account_credited",
+		JSON: `{"id": "1", "paging_token": "1", "account": "GA", "type": "account_credited", "type_i": 2, "asset_type": "credit_alphanum4", "asset_code": "USD", "asset_issuer": "GI", "amount": "10.0000000"}`,
+		Expected: AccountCreditedEffect{
+			BaseEffect: effectBase("account_credited", 2),
+			Asset:      Asset{Type: "credit_alphanum4", Code: "USD", Issuer: "GI"},
+			Amount:     "10.0000000",
+		},
+	},
+	{
+		Type: "account_debited",
+		JSON: `{"id": "1", "paging_token": "1", "account": "GA", "type": "account_debited", "type_i": 3, "asset_type": "native", "amount": "10.0000000"}`,
+		Expected: AccountDebitedEffect{
+			BaseEffect: effectBase("account_debited", 3),
+			Asset:      Asset{Type: "native"},
+			Amount:     "10.0000000",
+		},
+	},
+	{
+		Type: "account_thresholds_updated",
+		JSON: `{"id": "1", "paging_token": "1", "account": "GA", "type": "account_thresholds_updated", "type_i": 4, "low_threshold": 1, "med_threshold": 2, "high_threshold": 3}`,
+		Expected: AccountThresholdsUpdatedEffect{
+			BaseEffect:    effectBase("account_thresholds_updated", 4),
+			LowThreshold:  1,
+			MedThreshold:  2,
+			HighThreshold: 3,
+		},
+	},
+	{
+		Type:     "account_home_domain_updated",
+		JSON:     `{"id": "1", "paging_token": "1", "account": "GA", "type": "account_home_domain_updated", "type_i": 5, "home_domain": "stellar.org"}`,
+		Expected: AccountHomeDomainUpdatedEffect{BaseEffect: effectBase("account_home_domain_updated", 5), HomeDomain: "stellar.org"},
+	},
+	{
+		Type: "account_flags_updated",
+		JSON: `{"id": "1", "paging_token": "1", "account": "GA", "type": "account_flags_updated", "type_i": 6, "auth_required_flag": true}`,
+		Expected: AccountFlagsUpdatedEffect{
+			BaseEffect:   effectBase("account_flags_updated", 6),
+			AuthRequired: boolPtr(true),
+		},
+	},
+	{
+		Type:     "account_inflation_destination_updated",
+		JSON:     `{"id": "1", "paging_token": "1", "account": "GA", "type": "account_inflation_destination_updated", "type_i": 7}`,
+		Expected: effectBase("account_inflation_destination_updated", 7),
+	},
+	{
+		Type:     "signer_created",
+		JSON:     `{"id": "1", "paging_token": "1", "account": "GA", "type": "signer_created", "type_i": 10, "weight": 1, "public_key": "GS", "key": "GS"}`,
+		Expected: SignerEffect{BaseEffect: effectBase("signer_created", 10), Weight: 1, PublicKey: "GS", Key: "GS"},
+	},
+	{
+		Type:     "signer_removed",
+		JSON:     `{"id": "1", "paging_token": "1", "account": "GA", "type": "signer_removed", "type_i": 11, "weight": 0, "public_key": "GS", "key": "GS"}`,
+		Expected: SignerEffect{BaseEffect: effectBase("signer_removed", 11), PublicKey: "GS", Key: "GS"},
+	},
+	{
+		Type:     "signer_updated",
+		JSON:     `{"id": "1", "paging_token": "1", "account": "GA", "type": "signer_updated", "type_i": 12, "weight": 2, "public_key": "GS", "key": "GS"}`,
+		Expected: SignerEffect{BaseEffect: effectBase("signer_updated", 12), Weight: 2, PublicKey: "GS", Key: "GS"},
+	},
+	{
+		Type: "trustline_created",
+		JSON: `{"id": "1", "paging_token": "1", "account": "GA", "type": "trustline_created", "type_i": 20, "asset_type": "credit_alphanum4", "asset_code": "USD", "asset_issuer": "GI", "limit": "100.0000000"}`,
+		Expected: TrustlineEffect{
+			BaseEffect: effectBase("trustline_created", 20),
+			Asset:      Asset{Type: "credit_alphanum4", Code: "USD", Issuer: "GI"},
+			Limit:      "100.0000000",
+		},
+	},
+	{
+		Type: "trustline_removed",
+		JSON: `{"id": "1", "paging_token": "1", "account": "GA", "type": "trustline_removed", "type_i": 21, "asset_type": "credit_alphanum4", "asset_code": "USD", "asset_issuer": "GI", "limit": "0.0000000"}`,
+		Expected: TrustlineEffect{
+			BaseEffect: effectBase("trustline_removed", 21),
+			Asset:      Asset{Type: "credit_alphanum4", Code: "USD", Issuer: "GI"},
+			Limit:      "0.0000000",
+		},
+	},
+	{
+		Type: "trustline_updated",
+		JSON: `{"id": "1", "paging_token": "1", "account": "GA", "type": "trustline_updated", "type_i": 22, "asset_type": "credit_alphanum12", "asset_code": "SCOTTBUCKS", "asset_issuer": "GI", "limit": "50.0000000"}`,
+		Expected: TrustlineEffect{
+			BaseEffect: effectBase("trustline_updated", 22),
+			Asset:      Asset{Type: "credit_alphanum12", Code: "SCOTTBUCKS", Issuer: "GI"},
+			Limit:      "50.0000000",
+		},
+	},
+	{
+		Type: "trustline_authorized",
+		JSON: `{"id": "1", "paging_token": "1", "account": "GA", "type": "trustline_authorized", "type_i": 23, "trustor": "GT", "asset_type": "credit_alphanum4", "asset_code": "USD"}`,
+		Expected: TrustlineAuthorizationEffect{
+			BaseEffect: effectBase("trustline_authorized", 23),
+			Trustor:    "GT",
+			AssetType:  "credit_alphanum4",
+			AssetCode:  "USD",
+		},
+	},
+	{
+		Type: "trustline_deauthorized",
+		JSON: `{"id": "1", "paging_token": "1", "account": "GA", "type": "trustline_deauthorized", "type_i": 24, "trustor": "GT", "asset_type": "credit_alphanum4", "asset_code": "USD"}`,
+		Expected: TrustlineAuthorizationEffect{
+			BaseEffect: effectBase("trustline_deauthorized", 24),
+			Trustor:    "GT",
+			AssetType:  "credit_alphanum4",
+			AssetCode:  "USD",
+		},
+	},
+	{
+		Type:     "offer_created",
+		JSON:     `{"id": "1", "paging_token": "1", "account": "GA", "type": "offer_created", "type_i": 30}`,
+		Expected: effectBase("offer_created", 30),
+	},
+	{
+		Type:     "offer_removed",
+		JSON:     `{"id": "1", "paging_token": "1", "account": "GA", "type": "offer_removed", "type_i": 31}`,
+		Expected: effectBase("offer_removed", 31),
+	},
+	{
+		Type:     "offer_updated",
+		JSON:     `{"id": "1", "paging_token": "1", "account": "GA", "type": "offer_updated", "type_i": 32}`,
+		Expected: effectBase("offer_updated", 32),
+	},
+	{
+		Type: "trade",
+		JSON: `{"id": "1", "paging_token": "1", "account": "GA", "type": "trade", "type_i": 33, "seller": "GS", "offer_id": 7, "sold_amount": "1.0000000", "sold_asset_type": "native", "bought_amount": "2.0000000", "bought_asset_type": "credit_alphanum4", "bought_asset_code": "USD", "bought_asset_issuer": "GI"}`,
+		Expected: TradeEffect{
+			BaseEffect:        effectBase("trade", 33),
+			Seller:            "GS",
+			OfferID:           7,
+			SoldAmount:        "1.0000000",
+			SoldAssetType:     "native",
+			BoughtAmount:      "2.0000000",
+			BoughtAssetType:   "credit_alphanum4",
+			BoughtAssetCode:   "USD",
+			BoughtAssetIssuer: "GI",
+		},
+	},
+	{
+		Type:     "data_created",
+		JSON:     `{"id": "1", "paging_token": "1", "account": "GA", "type": "data_created", "type_i": 40}`,
+		Expected: effectBase("data_created", 40),
+	},
+	{
+		Type:     "data_removed",
+		JSON:     `{"id": "1", "paging_token": "1", "account": "GA", "type": "data_removed", "type_i": 41}`,
+		Expected: effectBase("data_removed", 41),
+	},
+	{
+		Type:     "data_updated",
+		JSON:     `{"id": "1", "paging_token": "1", "account": "GA", "type": "data_updated", "type_i": 42}`,
+		Expected: effectBase("data_updated", 42),
+	},
+}
+
+func TestUnmarshalEffect_Fixtures(t *testing.T) {
+	for _, fixture := range effectFixtures {
+		t.Run(fixture.Type, func(t *testing.T) {
+			effect, err := UnmarshalEffect(fixture.Type, []byte(fixture.JSON))
+			require.NoError(t, err)
+			assert.Equal(t, fixture.Expected, effect)
+		})
+	}
+}
+
+// TestUnmarshalEffect_FixturesComplete ensures that a fixture is added along
+// with the decoder of every new effect type.
+func TestUnmarshalEffect_FixturesComplete(t *testing.T) {
+	covered := map[string]bool{}
+	for _, fixture := range effectFixtures {
+		covered[fixture.Type] = true
+	}
+
+	effectDecodersMutex.RLock()
+	defer effectDecodersMutex.RUnlock()
+	for effectType := range effectDecoders {
+		assert.True(t, covered[effectType], "missing fixture for %s", effectType)
+	}
+}
+
+func effectBase(effectType string, typeI int32) BaseEffect {
+	return BaseEffect{ID: "1", PT: "1", Account: "GA", Type: effectType, TypeI: typeI}
+}
+
+func boolPtr(b bool) *bool {
+	return &b
+}
@@ -2,6 +2,8 @@ package horizon
 
 import (
 	"encoding/json"
+	"net/http"
+	"strings"
 
 	"github.com/stellar/go/support/errors"
 	"github.com/stellar/go/support/render/problem"
@@ -12,6 +14,45 @@ func (herr Error) Error() string {
 	return `Horizon error: "` + herr.Problem.Title + `". Check horizon.Error.Problem for more information.`
 }
 
+// AsError returns the horizon.Error that caused err, if any.  Errors returned
+// by the client may wrap a horizon.Error, so use this function rather than a
+// type assertion.
+func AsError(err error) (*Error, bool) {
+	herr, ok := errors.Cause(err).(*Error)
+	return herr, ok
+}
+
+// IsNotFound returns true if the requested resource does not exist.
+func (herr *Error) IsNotFound() bool {
+	return herr.hasType("not_found") || herr.Problem.Status == http.StatusNotFound
+}
+
+// IsRateLimited returns true if the request was rejected because the rate
+// limit of the client was exceeded.
+func (herr *Error) IsRateLimited() bool {
+	return herr.hasType("rate_limit_exceeded") || herr.Problem.Status == http.StatusTooManyRequests
+}
+
+// IsTimeout returns true if horizon timed out before it could produce a
+// response.  For transaction submissions the transaction may still be
+// included in a later ledger.
+func (herr *Error) IsTimeout() bool {
+	return herr.hasType("timeout") || herr.Problem.Status == http.StatusGatewayTimeout
+}
+
+// IsTransactionFailed returns true if a submitted transaction was rejected
+// by the network, in which case ResultCodes returns the reason.
+func (herr *Error) IsTransactionFailed() bool {
+	return herr.hasType("transaction_failed")
+}
+
+// hasType returns true if the problem type is name, either as the bare name or
+// as a URL ending with it, e.g. "https://stellar.org/horizon-errors/timeout".
+func (herr *Error) hasType(name string) bool {
+	t := herr.Problem.Type
+	return t == name || strings.HasSuffix(t, "/"+name)
+}
+
 // ToProblem converts the Prolem to a problem.P
 func (prob Problem) ToProblem() problem.P {
 	extras := make(map[string]interface{})
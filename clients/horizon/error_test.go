@@ -2,8 +2,12 @@ package horizon
 
 import (
 	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"strings"
 	"testing"
 
+	"github.com/stellar/go/support/errors"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -65,3 +69,80 @@ func TestError_Envelope(t *testing.T) {
 		assert.Contains(t, err.Error(), "xdr decode")
 	}
 }
+
+func TestError_Helpers(t *testing.T) {
+	cases := []struct {
+		Name              string
+		Problem           Problem
+		NotFound          bool
+		RateLimited       bool
+		Timeout           bool
+		TransactionFailed bool
+	}{
+		{
+			Name:     "not found",
+			Problem:  Problem{Type: "https://stellar.org/horizon-errors/not_found", Status: 404},
+			NotFound: true,
+		},
+		{
+			Name:        "rate limited",
+			Problem:     Problem{Type: "https://stellar.org/horizon-errors/rate_limit_exceeded", Status: 429},
+			RateLimited: true,
+		},
+		{
+			Name:    "timeout",
+			Problem: Problem{Type: "https://stellar.org/horizon-errors/timeout", Status: 504},
+			Timeout: true,
+		},
+		{
+			Name:    "timeout from a proxy",
+			Problem: Problem{Status: 504},
+			Timeout: true,
+		},
+		{
+			Name:              "transaction failed",
+			Problem:           Problem{Type: "transaction_failed", Status: 400},
+			TransactionFailed: true,
+		},
+		{
+			Name:    "server error",
+			Problem: Problem{Type: "https://stellar.org/horizon-errors/server_error", Status: 500},
+		},
+	}
+
+	for _, kase := range cases {
+		t.Run(kase.Name, func(t *testing.T) {
+			herr := &Error{Problem: kase.Problem}
+			assert.Equal(t, kase.NotFound, herr.IsNotFound())
+			assert.Equal(t, kase.RateLimited, herr.IsRateLimited())
+			assert.Equal(t, kase.Timeout, herr.IsTimeout())
+			assert.Equal(t, kase.TransactionFailed, herr.IsTransactionFailed())
+		})
+	}
+}
+
+func TestAsError(t *testing.T) {
+	herr := &Error{Problem: Problem{Status: 404}}
+
+	actual, ok := AsError(errors.Wrap(herr, "load failed"))
+	if assert.True(t, ok) {
+		assert.Equal(t, herr, actual)
+	}
+
+	_, ok = AsError(errors.New("boom"))
+	assert.False(t, ok)
+}
+
+func TestDecodeResponse_NonProblemBody(t *testing.T) {
+	resp := &http.Response{
+		StatusCode: http.StatusBadGateway,
+		Body:       ioutil.NopCloser(strings.NewReader("<html>Bad Gateway</html>")),
+	}
+
+	err := decodeResponse(resp, &Account{})
+	herr, ok := err.(*Error)
+	if assert.True(t, ok) {
+		assert.Equal(t, http.StatusBadGateway, herr.Problem.Status)
+		assert.Equal(t, "Bad Gateway", herr.Problem.Title)
+	}
+}
@@ -0,0 +1,59 @@
+package horizon
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stellar/go/support/http/httptest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/context"
+)
+
+func TestClient_Headers(t *testing.T) {
+	hmock := httptest.NewClient()
+	client := &Client{
+		URL:  "https://localhost",
+		HTTP: hmock,
+		Headers: http.Header{
+			"authorization": []string{"Bearer secret"},
+			"User-Agent":    []string{"wallet/1.0"},
+		},
+		AppName:    "wallet",
+		AppVersion: "1.0",
+	}
+
+	var headers []http.Header
+	record := func(body string) httpmock.Responder {
+		return func(req *http.Request) (*http.Response, error) {
+			headers = append(headers, req.Header)
+			return httpmock.NewStringResponse(http.StatusOK, body), nil
+		}
+	}
+
+	hmock.On(
+		"GET",
+		"https://localhost/accounts/GBRPYHIL2CI3FNQ4BXLFMNDLFJUNPU2HY3ZMFSHONUCEOASW7QC7OX2H",
+	).Return(record(accountResponse))
+	hmock.On("GET", "https://localhost/ledgers").Return(record("data: {}\n\n"))
+
+	_, err := client.LoadAccount("GBRPYHIL2CI3FNQ4BXLFMNDLFJUNPU2HY3ZMFSHONUCEOASW7QC7OX2H")
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	err = client.StreamRaw(ctx, "/ledgers", nil, func(Event) error {
+		cancel()
+		return nil
+	})
+	require.NoError(t, err)
+
+	require.Len(t, headers, 2)
+	for _, h := range headers {
+		assert.Equal(t, "Bearer secret", h.Get("Authorization"))
+		assert.Equal(t, "wallet/1.0", h.Get("User-Agent"))
+		assert.Equal(t, "wallet", h.Get("X-App-Name"))
+		assert.Equal(t, "1.0", h.Get("X-App-Version"))
+	}
+	assert.Equal(t, "text/event-stream", headers[1].Get("Accept"))
+}
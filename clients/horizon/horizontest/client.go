@@ -0,0 +1,764 @@
+package horizontest
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/stellar/go/build"
+	"github.com/stellar/go/clients/horizon"
+	"github.com/stellar/go/xdr"
+	"golang.org/x/net/context"
+)
+
+// Root implements horizon.ClientInterface
+func (c *Client) Root() (horizon.Root, error) {
+	return c.RootWithContext(context.Background())
+}
+
+// RootWithContext implements horizon.ClientInterface
+func (c *Client) RootWithContext(ctx context.Context) (horizon.Root, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if err := c.check(ctx, "Root"); err != nil {
+		return horizon.Root{}, err
+	}
+	return c.root, nil
+}
+
+// ServerInfo implements horizon.ClientInterface, returning the root set with
+// SetRoot.
+func (c *Client) ServerInfo(ctx context.Context) (horizon.Root, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if err := c.check(ctx, "ServerInfo"); err != nil {
+		return horizon.Root{}, err
+	}
+	return c.root, nil
+}
+
+// NetworkPassphrase implements horizon.ClientInterface
+func (c *Client) NetworkPassphrase() (string, error) {
+	return c.NetworkPassphraseWithContext(context.Background())
+}
+
+// NetworkPassphraseWithContext implements horizon.ClientInterface, returning
+// the passphrase of the root set with SetRoot.
+func (c *Client) NetworkPassphraseWithContext(ctx context.Context) (string, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if err := c.check(ctx, "NetworkPassphrase"); err != nil {
+		return "", err
+	}
+	return c.root.NetworkPassphrase, nil
+}
+
+// HomeDomainForAccount implements horizon.ClientInterface
+func (c *Client) HomeDomainForAccount(aid string) (string, error) {
+	return c.HomeDomainForAccountWithContext(context.Background(), aid)
+}
+
+// HomeDomainForAccountWithContext implements horizon.ClientInterface
+func (c *Client) HomeDomainForAccountWithContext(ctx context.Context, aid string) (string, error) {
+	account, err := c.loadAccount(ctx, "HomeDomainForAccount", aid)
+	if err != nil {
+		return "", err
+	}
+	return account.HomeDomain, nil
+}
+
+// SequenceForAccount implements build.SequenceProvider
+func (c *Client) SequenceForAccount(accountID string) (xdr.SequenceNumber, error) {
+	return c.SequenceForAccountWithContext(context.Background(), accountID)
+}
+
+// SequenceForAccountWithContext implements horizon.ClientInterface
+func (c *Client) SequenceForAccountWithContext(ctx context.Context, accountID string) (xdr.SequenceNumber, error) {
+	account, err := c.loadAccount(ctx, "SequenceForAccount", accountID)
+	if err != nil {
+		return 0, err
+	}
+
+	seq, err := strconv.ParseUint(account.Sequence, 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	return xdr.SequenceNumber(seq), nil
+}
+
+// SignersForAccount implements build.SignersProvider
+func (c *Client) SignersForAccount(accountID string) (build.AccountSigners, error) {
+	return c.SignersForAccountWithContext(context.Background(), accountID)
+}
+
+// SignersForAccountWithContext implements horizon.ClientInterface
+func (c *Client) SignersForAccountWithContext(ctx context.Context, accountID string) (build.AccountSigners, error) {
+	account, err := c.loadAccount(ctx, "SignersForAccount", accountID)
+	if err != nil {
+		return build.AccountSigners{}, err
+	}
+	return account.AccountSigners(), nil
+}
+
+// BaseFee implements build.FeeProvider
+func (c *Client) BaseFee() (uint64, error) {
+	return c.BaseFeeWithContext(context.Background())
+}
+
+// BaseFeeWithContext implements horizon.ClientInterface using the fee stats
+// set with SetFeeStats, like horizon.Client.BaseFee.
+func (c *Client) BaseFeeWithContext(ctx context.Context) (uint64, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if err := c.check(ctx, "BaseFee"); err != nil {
+		return 0, err
+	}
+
+	fee := c.feeStats.ModeAcceptedFee
+	if fee < c.feeStats.LastLedgerBaseFee {
+		fee = c.feeStats.LastLedgerBaseFee
+	}
+	return uint64(fee), nil
+}
+
+// LoadAccount implements horizon.ClientInterface
+func (c *Client) LoadAccount(accountID string) (horizon.Account, error) {
+	return c.LoadAccountWithContext(context.Background(), accountID)
+}
+
+// LoadAccountWithContext implements horizon.ClientInterface
+func (c *Client) LoadAccountWithContext(ctx context.Context, accountID string) (horizon.Account, error) {
+	return c.loadAccount(ctx, "LoadAccount", accountID)
+}
+
+// LoadAccounts implements horizon.ClientInterface
+func (c *Client) LoadAccounts(accountIDs []string) []horizon.AccountResult {
+	return c.LoadAccountsWithContext(context.Background(), accountIDs)
+}
+
+// LoadAccountsWithContext implements horizon.ClientInterface.  Canned errors
+// set for LoadAccount apply to every account.
+func (c *Client) LoadAccountsWithContext(ctx context.Context, accountIDs []string) []horizon.AccountResult {
+	results := make([]horizon.AccountResult, len(accountIDs))
+	for i, id := range accountIDs {
+		results[i].AccountID = id
+		results[i].Account, results[i].Err = c.loadAccount(ctx, "LoadAccount", id)
+	}
+	return results
+}
+
+// LoadAccountData implements horizon.ClientInterface
+func (c *Client) LoadAccountData(accountID string, key string) (horizon.AccountData, error) {
+	return c.LoadAccountDataWithContext(context.Background(), accountID, key)
+}
+
+// LoadAccountDataWithContext implements horizon.ClientInterface
+func (c *Client) LoadAccountDataWithContext(ctx context.Context, accountID string, key string) (horizon.AccountData, error) {
+	account, err := c.loadAccount(ctx, "LoadAccountData", accountID)
+	if err != nil {
+		return horizon.AccountData{}, err
+	}
+
+	value, ok := account.Data[key]
+	if !ok {
+		return horizon.AccountData{}, NotFound()
+	}
+	return horizon.AccountData{Value: value}, nil
+}
+
+// LoadAccountDataEntries implements horizon.ClientInterface
+func (c *Client) LoadAccountDataEntries(accountID string) (map[string][]byte, error) {
+	return c.LoadAccountDataEntriesWithContext(context.Background(), accountID)
+}
+
+// LoadAccountDataEntriesWithContext implements horizon.ClientInterface
+func (c *Client) LoadAccountDataEntriesWithContext(ctx context.Context, accountID string) (map[string][]byte, error) {
+	account, err := c.loadAccount(ctx, "LoadAccountDataEntries", accountID)
+	if err != nil {
+		return nil, err
+	}
+	return account.GetDataEntries()
+}
+
+// LoadAccountOffers implements horizon.ClientInterface
+func (c *Client) LoadAccountOffers(accountID string, params ...horizon.Param) (horizon.OffersPage, error) {
+	return c.LoadAccountOffersWithContext(context.Background(), accountID, params...)
+}
+
+// LoadAccountOffersWithContext implements horizon.ClientInterface
+func (c *Client) LoadAccountOffersWithContext(ctx context.Context, accountID string, params ...horizon.Param) (offers horizon.OffersPage, err error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if err = c.check(ctx, "LoadAccountOffers"); err != nil {
+		return
+	}
+
+	for _, offer := range c.offers {
+		if offer.Seller == accountID {
+			offers.Embedded.Records = append(offers.Embedded.Records, offer)
+		}
+	}
+	return
+}
+
+// LoadTransaction implements horizon.ClientInterface
+func (c *Client) LoadTransaction(transactionHash string) (horizon.Transaction, error) {
+	return c.LoadTransactionWithContext(context.Background(), transactionHash)
+}
+
+// LoadTransactionWithContext implements horizon.ClientInterface
+func (c *Client) LoadTransactionWithContext(ctx context.Context, transactionHash string) (horizon.Transaction, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if err := c.check(ctx, "LoadTransaction"); err != nil {
+		return horizon.Transaction{}, err
+	}
+
+	tx, ok := c.transaction(transactionHash)
+	if !ok {
+		return horizon.Transaction{}, NotFound()
+	}
+	return tx, nil
+}
+
+// LoadOperation implements horizon.ClientInterface
+func (c *Client) LoadOperation(operationID string) (horizon.Operation, error) {
+	return c.LoadOperationWithContext(context.Background(), operationID)
+}
+
+// LoadOperationWithContext implements horizon.ClientInterface
+func (c *Client) LoadOperationWithContext(ctx context.Context, operationID string) (horizon.Operation, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if err := c.check(ctx, "LoadOperation"); err != nil {
+		return nil, err
+	}
+
+	for _, op := range c.operations {
+		if op.GetBase().ID == operationID {
+			return op, nil
+		}
+	}
+	return nil, NotFound()
+}
+
+// LoadAssets implements horizon.ClientInterface
+func (c *Client) LoadAssets(params ...horizon.Param) (horizon.AssetsPage, error) {
+	return c.LoadAssetsWithContext(context.Background(), params...)
+}
+
+// LoadAssetsWithContext implements horizon.ClientInterface
+func (c *Client) LoadAssetsWithContext(ctx context.Context, params ...horizon.Param) (assets horizon.AssetsPage, err error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if err = c.check(ctx, "LoadAssets"); err != nil {
+		return
+	}
+
+	assets.Embedded.Records = append(assets.Embedded.Records, c.assets...)
+	return
+}
+
+// LoadFeeStats implements horizon.ClientInterface
+func (c *Client) LoadFeeStats() (horizon.FeeStats, error) {
+	return c.LoadFeeStatsWithContext(context.Background())
+}
+
+// LoadFeeStatsWithContext implements horizon.ClientInterface
+func (c *Client) LoadFeeStatsWithContext(ctx context.Context) (horizon.FeeStats, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if err := c.check(ctx, "LoadFeeStats"); err != nil {
+		return horizon.FeeStats{}, err
+	}
+	return c.feeStats, nil
+}
+
+// LoadLedger implements horizon.ClientInterface
+func (c *Client) LoadLedger(sequence int32) (horizon.Ledger, error) {
+	return c.LoadLedgerWithContext(context.Background(), sequence)
+}
+
+// LoadLedgerWithContext implements horizon.ClientInterface
+func (c *Client) LoadLedgerWithContext(ctx context.Context, sequence int32) (horizon.Ledger, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if err := c.check(ctx, "LoadLedger"); err != nil {
+		return horizon.Ledger{}, err
+	}
+
+	for _, ledger := range c.ledgers {
+		if ledger.Sequence == sequence {
+			return ledger, nil
+		}
+	}
+	return horizon.Ledger{}, NotFound()
+}
+
+// LoadLedgers implements horizon.ClientInterface
+func (c *Client) LoadLedgers(params ...horizon.Param) (horizon.LedgersPage, error) {
+	return c.LoadLedgersWithContext(context.Background(), params...)
+}
+
+// LoadLedgersWithContext implements horizon.ClientInterface
+func (c *Client) LoadLedgersWithContext(ctx context.Context, params ...horizon.Param) (ledgers horizon.LedgersPage, err error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if err = c.check(ctx, "LoadLedgers"); err != nil {
+		return
+	}
+
+	ledgers.Embedded.Records = append(ledgers.Embedded.Records, c.ledgers...)
+	return
+}
+
+// LoadLedgerTransactions implements horizon.ClientInterface
+func (c *Client) LoadLedgerTransactions(sequence int32, params ...horizon.Param) (horizon.TransactionsPage, error) {
+	return c.LoadLedgerTransactionsWithContext(context.Background(), sequence, params...)
+}
+
+// LoadLedgerTransactionsWithContext implements horizon.ClientInterface
+func (c *Client) LoadLedgerTransactionsWithContext(ctx context.Context, sequence int32, params ...horizon.Param) (transactions horizon.TransactionsPage, err error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if err = c.check(ctx, "LoadLedgerTransactions"); err != nil {
+		return
+	}
+
+	for _, tx := range c.transactions {
+		if tx.Ledger == sequence {
+			transactions.Embedded.Records = append(transactions.Embedded.Records, tx)
+		}
+	}
+	return
+}
+
+// LoadLedgerOperations implements horizon.ClientInterface
+func (c *Client) LoadLedgerOperations(sequence int32, params ...horizon.Param) (horizon.OperationsPage, error) {
+	return c.LoadLedgerOperationsWithContext(context.Background(), sequence, params...)
+}
+
+// LoadLedgerOperationsWithContext implements horizon.ClientInterface
+func (c *Client) LoadLedgerOperationsWithContext(ctx context.Context, sequence int32, params ...horizon.Param) (operations horizon.OperationsPage, err error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if err = c.check(ctx, "LoadLedgerOperations"); err != nil {
+		return
+	}
+
+	for _, op := range c.operations {
+		tx, ok := c.transaction(op.GetBase().TransactionHash)
+		if ok && tx.Ledger == sequence {
+			operations.Embedded.Records = append(operations.Embedded.Records, op)
+		}
+	}
+	return
+}
+
+// LoadLedgerPayments implements horizon.ClientInterface
+func (c *Client) LoadLedgerPayments(sequence int32, params ...horizon.Param) (horizon.PaymentsPage, error) {
+	return c.LoadLedgerPaymentsWithContext(context.Background(), sequence, params...)
+}
+
+// LoadLedgerPaymentsWithContext implements horizon.ClientInterface
+func (c *Client) LoadLedgerPaymentsWithContext(ctx context.Context, sequence int32, params ...horizon.Param) (payments horizon.PaymentsPage, err error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if err = c.check(ctx, "LoadLedgerPayments"); err != nil {
+		return
+	}
+
+	for _, payment := range c.payments {
+		tx, ok := c.transaction(transactionHash(payment))
+		if ok && tx.Ledger == sequence {
+			payments.Embedded.Records = append(payments.Embedded.Records, payment)
+		}
+	}
+	return
+}
+
+// LoadOffer implements horizon.ClientInterface
+func (c *Client) LoadOffer(offerID int64) (horizon.Offer, error) {
+	return c.LoadOfferWithContext(context.Background(), offerID)
+}
+
+// LoadOfferWithContext implements horizon.ClientInterface
+func (c *Client) LoadOfferWithContext(ctx context.Context, offerID int64) (horizon.Offer, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if err := c.check(ctx, "LoadOffer"); err != nil {
+		return horizon.Offer{}, err
+	}
+
+	for _, offer := range c.offers {
+		if offer.ID == offerID {
+			return offer, nil
+		}
+	}
+	return horizon.Offer{}, NotFound()
+}
+
+// LoadTradesForOffer implements horizon.ClientInterface
+func (c *Client) LoadTradesForOffer(offerID int64, params ...horizon.Param) (horizon.TradesPage, error) {
+	return c.LoadTradesForOfferWithContext(context.Background(), offerID, params...)
+}
+
+// LoadTradesForOfferWithContext implements horizon.ClientInterface
+func (c *Client) LoadTradesForOfferWithContext(ctx context.Context, offerID int64, params ...horizon.Param) (trades horizon.TradesPage, err error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if err = c.check(ctx, "LoadTradesForOffer"); err != nil {
+		return
+	}
+
+	id := strconv.FormatInt(offerID, 10)
+	for _, trade := range c.trades {
+		if trade.OfferID == id {
+			trades.Embedded.Records = append(trades.Embedded.Records, trade)
+		}
+	}
+	return
+}
+
+// LoadMetrics implements horizon.ClientInterface
+func (c *Client) LoadMetrics() (horizon.Metrics, error) {
+	return c.LoadMetricsWithContext(context.Background())
+}
+
+// LoadMetricsWithContext implements horizon.ClientInterface
+func (c *Client) LoadMetricsWithContext(ctx context.Context) (horizon.Metrics, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if err := c.check(ctx, "LoadMetrics"); err != nil {
+		return horizon.Metrics{}, err
+	}
+	return c.metrics, nil
+}
+
+// LoadIngestionStatus implements horizon.ClientInterface.  The status is
+// derived from the root set with SetRoot.
+func (c *Client) LoadIngestionStatus() (horizon.IngestionStatus, error) {
+	return c.LoadIngestionStatusWithContext(context.Background())
+}
+
+// LoadIngestionStatusWithContext implements horizon.ClientInterface
+func (c *Client) LoadIngestionStatusWithContext(ctx context.Context) (horizon.IngestionStatus, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if err := c.check(ctx, "LoadIngestionStatus"); err != nil {
+		return horizon.IngestionStatus{}, err
+	}
+	return horizon.IngestionStatus{
+		HistoryLatestLedger: c.root.HorizonSequence,
+		HistoryElderLedger:  c.root.HistoryElderSequence,
+		CoreLatestLedger:    c.root.CoreSequence,
+		CoreElderLedger:     c.root.CoreElderSequence,
+	}, nil
+}
+
+// LoadMemo implements horizon.ClientInterface
+func (c *Client) LoadMemo(p *horizon.Payment) error {
+	return c.LoadMemoWithContext(context.Background(), p)
+}
+
+// LoadMemoWithContext implements horizon.ClientInterface
+func (c *Client) LoadMemoWithContext(ctx context.Context, p *horizon.Payment) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if err := c.check(ctx, "LoadMemo"); err != nil {
+		return err
+	}
+
+	tx, ok := c.transaction(transactionHash(*p))
+	if !ok {
+		return NotFound()
+	}
+
+	p.Memo.Type = tx.MemoType
+	p.Memo.Value = tx.Memo
+	return nil
+}
+
+// LoadOrderBook implements horizon.ClientInterface
+func (c *Client) LoadOrderBook(selling horizon.Asset, buying horizon.Asset, params ...horizon.Param) (horizon.OrderBookSummary, error) {
+	return c.LoadOrderBookWithContext(context.Background(), selling, buying, params...)
+}
+
+// LoadOrderBookWithContext implements horizon.ClientInterface
+func (c *Client) LoadOrderBookWithContext(ctx context.Context, selling horizon.Asset, buying horizon.Asset, params ...horizon.Param) (horizon.OrderBookSummary, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if err := c.check(ctx, "LoadOrderBook"); err != nil {
+		return horizon.OrderBookSummary{}, err
+	}
+	return c.orderBook(selling, buying), nil
+}
+
+// Transactions implements horizon.ClientInterface, iterating over the seeded
+// transactions.
+func (c *Client) Transactions(ctx context.Context, params ...horizon.Param) horizon.Iterator {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	records := make([]interface{}, len(c.transactions))
+	for i, tx := range c.transactions {
+		records[i] = tx
+	}
+	return &iterator{records: records, err: c.check(ctx, "Transactions")}
+}
+
+// Operations implements horizon.ClientInterface, iterating over the seeded
+// operations.
+func (c *Client) Operations(ctx context.Context, params ...horizon.Param) horizon.Iterator {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	records := make([]interface{}, len(c.operations))
+	for i, op := range c.operations {
+		records[i] = op
+	}
+	return &iterator{records: records, err: c.check(ctx, "Operations")}
+}
+
+// Effects implements horizon.ClientInterface, iterating over the seeded
+// effects.
+func (c *Client) Effects(ctx context.Context, params ...horizon.Param) horizon.Iterator {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	records := make([]interface{}, len(c.effects))
+	for i, effect := range c.effects {
+		records[i] = effect
+	}
+	return &iterator{records: records, err: c.check(ctx, "Effects")}
+}
+
+// Payments implements horizon.ClientInterface, iterating over the seeded
+// payments.
+func (c *Client) Payments(ctx context.Context, params ...horizon.Param) horizon.Iterator {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	records := make([]interface{}, len(c.payments))
+	for i, payment := range c.payments {
+		records[i] = payment
+	}
+	return &iterator{records: records, err: c.check(ctx, "Payments")}
+}
+
+// StreamRaw implements horizon.ClientInterface.  The fake has no raw events
+// to replay, so it returns as soon as the canned error, if any, is checked.
+func (c *Client) StreamRaw(ctx context.Context, path string, cursor *horizon.Cursor, handler horizon.EventHandler) error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.check(ctx, "StreamRaw")
+}
+
+// StreamLedgers implements horizon.ClientInterface
+func (c *Client) StreamLedgers(ctx context.Context, cursor *horizon.Cursor, handler horizon.LedgerHandler) error {
+	c.mutex.Lock()
+	err := c.check(ctx, "StreamLedgers")
+	ledgers := append([]horizon.Ledger(nil), c.ledgers...)
+	c.mutex.Unlock()
+
+	if err != nil {
+		return err
+	}
+
+	for _, ledger := range ledgers {
+		if ctx.Err() != nil {
+			return nil
+		}
+		handler(ledger)
+	}
+	return nil
+}
+
+// StreamOrderBook implements horizon.ClientInterface
+func (c *Client) StreamOrderBook(ctx context.Context, selling horizon.Asset, buying horizon.Asset, handler horizon.OrderBookHandler) error {
+	c.mutex.Lock()
+	err := c.check(ctx, "StreamOrderBook")
+	orderBook := c.orderBook(selling, buying)
+	c.mutex.Unlock()
+
+	if err != nil {
+		return err
+	}
+
+	handler(orderBook)
+	return nil
+}
+
+// StreamPayments implements horizon.ClientInterface
+func (c *Client) StreamPayments(ctx context.Context, accountID string, cursor *horizon.Cursor, handler horizon.PaymentHandler) error {
+	c.mutex.Lock()
+	err := c.check(ctx, "StreamPayments")
+	var payments []horizon.Payment
+	for _, payment := range c.payments {
+		if payment.From == accountID || payment.To == accountID || payment.Account == accountID {
+			payments = append(payments, payment)
+		}
+	}
+	c.mutex.Unlock()
+
+	if err != nil {
+		return err
+	}
+
+	for _, payment := range payments {
+		if ctx.Err() != nil {
+			return nil
+		}
+		handler(payment)
+	}
+	return nil
+}
+
+// StreamPaymentsWithOptions implements horizon.ClientInterface.  When
+// opts.ResolveMemos is set, the memos are resolved from the seeded
+// transactions.
+func (c *Client) StreamPaymentsWithOptions(ctx context.Context, accountID string, cursor *horizon.Cursor, opts horizon.PaymentStreamOptions, handler horizon.PaymentHandler) error {
+	if !opts.ResolveMemos {
+		return c.StreamPayments(ctx, accountID, cursor, handler)
+	}
+
+	var memoErr error
+	err := c.StreamPayments(ctx, accountID, cursor, func(payment horizon.Payment) {
+		if memoErr != nil {
+			return
+		}
+		memoErr = c.LoadMemoWithContext(ctx, &payment)
+		if memoErr == nil {
+			handler(payment)
+		}
+	})
+	if err != nil {
+		return err
+	}
+	return memoErr
+}
+
+// StreamTransactions implements horizon.ClientInterface
+func (c *Client) StreamTransactions(ctx context.Context, accountID string, cursor *horizon.Cursor, handler horizon.TransactionHandler) error {
+	c.mutex.Lock()
+	err := c.check(ctx, "StreamTransactions")
+	var transactions []horizon.Transaction
+	for _, tx := range c.transactions {
+		if tx.Account == accountID {
+			transactions = append(transactions, tx)
+		}
+	}
+	c.mutex.Unlock()
+
+	if err != nil {
+		return err
+	}
+
+	for _, tx := range transactions {
+		if ctx.Err() != nil {
+			return nil
+		}
+		handler(tx)
+	}
+	return nil
+}
+
+// SubmitTransaction implements horizon.ClientInterface
+func (c *Client) SubmitTransaction(txeBase64 string) (horizon.TransactionSuccess, error) {
+	return c.SubmitTransactionWithContext(context.Background(), txeBase64)
+}
+
+// SubmitTransactionWithContext implements horizon.ClientInterface.  The
+// envelope is recorded, see Submitted, and the result set with
+// SetSubmitResult is returned.
+func (c *Client) SubmitTransactionWithContext(ctx context.Context, txeBase64 string) (horizon.TransactionSuccess, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if err := c.check(ctx, "SubmitTransaction"); err != nil {
+		return horizon.TransactionSuccess{}, err
+	}
+
+	c.submitted = append(c.submitted, txeBase64)
+	return c.submitResult, nil
+}
+
+// SubmitTransactionWithOptions implements horizon.ClientInterface.  The fake
+// never rejects transactions with tx_bad_seq, so opts is ignored.
+func (c *Client) SubmitTransactionWithOptions(ctx context.Context, txeBase64 string, opts horizon.SubmitOptions) (horizon.TransactionSuccess, error) {
+	return c.SubmitTransactionWithContext(ctx, txeBase64)
+}
+
+// check returns the error a call to method should fail with, if any.  The
+// caller must hold c.mutex.
+func (c *Client) check(ctx context.Context, method string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return c.errors[method]
+}
+
+// loadAccount returns the seeded account with the given id, on behalf of
+// method.
+func (c *Client) loadAccount(ctx context.Context, method string, accountID string) (horizon.Account, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if err := c.check(ctx, method); err != nil {
+		return horizon.Account{}, err
+	}
+
+	account, ok := c.accounts[accountID]
+	if !ok {
+		return horizon.Account{}, NotFound()
+	}
+	return account, nil
+}
+
+// transaction returns the seeded transaction with the given hash.  The caller
+// must hold c.mutex.
+func (c *Client) transaction(hash string) (horizon.Transaction, bool) {
+	for _, tx := range c.transactions {
+		if tx.Hash == hash {
+			return tx, true
+		}
+	}
+	return horizon.Transaction{}, false
+}
+
+// orderBook returns the seeded order book for the given assets, or an empty
+// one.  The caller must hold c.mutex.
+func (c *Client) orderBook(selling horizon.Asset, buying horizon.Asset) horizon.OrderBookSummary {
+	for _, ob := range c.orderBooks {
+		if ob.Selling == selling && ob.Buying == buying {
+			return ob
+		}
+	}
+	return horizon.OrderBookSummary{Selling: selling, Buying: buying}
+}
+
+// transactionHash returns the hash of the transaction of a payment, taken
+// from its transaction link.
+func transactionHash(p horizon.Payment) string {
+	href := p.Links.Transaction.Href
+	return href[strings.LastIndex(href, "/")+1:]
+}
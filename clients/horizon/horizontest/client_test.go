@@ -0,0 +1,173 @@
+package horizontest
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stellar/go/build"
+	"github.com/stellar/go/clients/horizon"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/context"
+)
+
+func TestClient(t *testing.T) {
+	client := NewClient()
+
+	var account horizon.Account
+	account.ID = "GBRPYHIL2CI3FNQ4BXLFMNDLFJUNPU2HY3ZMFSHONUCEOASW7QC7OX2H"
+	account.Sequence = "42"
+	account.HomeDomain = "stellar.org"
+	account.Signers = []horizon.Signer{{Key: account.ID, Weight: 1}}
+	client.AddAccount(account)
+
+	client.AddTransaction(horizon.Transaction{
+		Hash:     "5131aed266a639a6eb4802a92fba310454e711ded830ed899745b9e777d7110c",
+		Ledger:   3128812,
+		Account:  account.ID,
+		MemoType: "text",
+		Memo:     "hello",
+	})
+
+	t.Run("loads seeded resources", func(t *testing.T) {
+		loaded, err := client.LoadAccount(account.ID)
+		require.NoError(t, err)
+		assert.Equal(t, account.ID, loaded.ID)
+
+		domain, err := client.HomeDomainForAccount(account.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "stellar.org", domain)
+
+		seq, err := client.SequenceForAccount(account.ID)
+		require.NoError(t, err)
+		assert.EqualValues(t, 42, seq)
+
+		signers, err := client.SignersForAccount(account.ID)
+		require.NoError(t, err)
+		assert.Equal(t, []build.AccountSigner{{Key: account.ID, Weight: 1}}, signers.Signers)
+
+		txs, err := client.LoadLedgerTransactions(3128812)
+		require.NoError(t, err)
+		assert.Len(t, txs.Embedded.Records, 1)
+	})
+
+	t.Run("loads offers and their trades", func(t *testing.T) {
+		client.AddOffer(horizon.Offer{ID: 101, Seller: account.ID})
+		client.AddTrade(horizon.Trade{ID: "1-1", OfferID: "101"})
+		client.AddTrade(horizon.Trade{ID: "2-1", OfferID: "102"})
+
+		offer, err := client.LoadOffer(101)
+		require.NoError(t, err)
+		assert.Equal(t, account.ID, offer.Seller)
+
+		trades, err := client.LoadTradesForOffer(101)
+		require.NoError(t, err)
+		require.Len(t, trades.Embedded.Records, 1)
+		assert.Equal(t, "1-1", trades.Embedded.Records[0].ID)
+
+		_, err = client.LoadOffer(102)
+		herr, ok := horizon.AsError(err)
+		require.True(t, ok)
+		assert.True(t, herr.IsNotFound())
+	})
+
+	t.Run("provides the base fee", func(t *testing.T) {
+		client.SetFeeStats(horizon.FeeStats{LastLedgerBaseFee: 100, ModeAcceptedFee: 250})
+
+		fee, err := client.BaseFee()
+		require.NoError(t, err)
+		assert.EqualValues(t, 250, fee)
+	})
+
+	t.Run("missing resources are not found", func(t *testing.T) {
+		_, err := client.LoadAccount("GAKLCFRTFDXKOEEUSBS23FBSUUVJRMDQHGCHNGGGJZQRK7BCPIMHUC4P")
+		herr, ok := horizon.AsError(err)
+		require.True(t, ok)
+		assert.True(t, herr.IsNotFound())
+	})
+
+	t.Run("loads memos", func(t *testing.T) {
+		var payment horizon.Payment
+		payment.Links.Transaction.Href = "https://localhost/transactions/5131aed266a639a6eb4802a92fba310454e711ded830ed899745b9e777d7110c"
+
+		require.NoError(t, client.LoadMemo(&payment))
+		assert.Equal(t, "text", payment.Memo.Type)
+		assert.Equal(t, "hello", payment.Memo.Value)
+	})
+
+	t.Run("streams seeded resources", func(t *testing.T) {
+		var streamed []horizon.Transaction
+		err := client.StreamTransactions(context.Background(), account.ID, nil, func(tx horizon.Transaction) {
+			streamed = append(streamed, tx)
+		})
+		require.NoError(t, err)
+		assert.Len(t, streamed, 1)
+	})
+
+	t.Run("streams payments with their memos", func(t *testing.T) {
+		var payment horizon.Payment
+		payment.From = account.ID
+		payment.Links.Transaction.Href = "https://localhost/transactions/5131aed266a639a6eb4802a92fba310454e711ded830ed899745b9e777d7110c"
+		client.AddPayment(payment)
+
+		var streamed []horizon.Payment
+		opts := horizon.PaymentStreamOptions{ResolveMemos: true}
+		err := client.StreamPaymentsWithOptions(context.Background(), account.ID, nil, opts, func(p horizon.Payment) {
+			streamed = append(streamed, p)
+		})
+		require.NoError(t, err)
+		require.Len(t, streamed, 1)
+		assert.Equal(t, "hello", streamed[0].Memo.Value)
+	})
+
+	t.Run("iterates over seeded resources", func(t *testing.T) {
+		it := client.Transactions(context.Background())
+
+		var hashes []string
+		for it.Next() {
+			var tx horizon.Transaction
+			require.NoError(t, it.Scan(&tx))
+			hashes = append(hashes, tx.Hash)
+		}
+		require.NoError(t, it.Err())
+		assert.Equal(t, []string{"5131aed266a639a6eb4802a92fba310454e711ded830ed899745b9e777d7110c"}, hashes)
+
+		boom := errors.New("boom")
+		client.SetError("Payments", boom)
+		it = client.Payments(context.Background())
+		assert.False(t, it.Next())
+		assert.Equal(t, boom, it.Err())
+		client.SetError("Payments", nil)
+	})
+
+	t.Run("provides the network passphrase", func(t *testing.T) {
+		client.SetRoot(horizon.Root{NetworkPassphrase: "Test SDF Network ; September 2015"})
+
+		passphrase, err := client.NetworkPassphrase()
+		require.NoError(t, err)
+		assert.Equal(t, "Test SDF Network ; September 2015", passphrase)
+	})
+
+	t.Run("records submissions", func(t *testing.T) {
+		client.SetSubmitResult(horizon.TransactionSuccess{Hash: "abc"})
+
+		result, err := client.SubmitTransaction("AAAA")
+		require.NoError(t, err)
+		assert.Equal(t, "abc", result.Hash)
+		assert.Equal(t, []string{"AAAA"}, client.Submitted())
+	})
+
+	t.Run("canned errors", func(t *testing.T) {
+		boom := errors.New("boom")
+		client.SetError("LoadAccount", boom)
+
+		_, err := client.LoadAccount(account.ID)
+		assert.Equal(t, boom, err)
+		_, err = client.LoadAccountWithContext(context.Background(), account.ID)
+		assert.Equal(t, boom, err)
+
+		client.SetError("LoadAccount", nil)
+		_, err = client.LoadAccount(account.ID)
+		assert.NoError(t, err)
+	})
+}
@@ -0,0 +1,51 @@
+package horizontest
+
+import (
+	"encoding/json"
+
+	"github.com/stellar/go/support/errors"
+)
+
+// iterator is the horizon.Iterator of the fake client, walking records that
+// were seeded.  Like the iterator of horizon.Client, it decodes the JSON
+// representation of the current record in Scan.
+type iterator struct {
+	records []interface{}
+	current interface{}
+	err     error
+}
+
+// Next implements horizon.Iterator
+func (it *iterator) Next() bool {
+	if it.err != nil || len(it.records) == 0 {
+		it.current = nil
+		return false
+	}
+
+	it.current, it.records = it.records[0], it.records[1:]
+	return true
+}
+
+// Scan implements horizon.Iterator
+func (it *iterator) Scan(dest interface{}) error {
+	if it.current == nil {
+		return errors.New("no current record: call Next first")
+	}
+
+	data, err := json.Marshal(it.current)
+	if err != nil {
+		return errors.Wrap(err, "Error marshaling record")
+	}
+
+	err = json.Unmarshal(data, dest)
+	if err != nil {
+		return errors.Wrap(err, "Error unmarshaling record")
+	}
+
+	return nil
+}
+
+// Err implements horizon.Iterator
+func (it *iterator) Err() error {
+	return it.err
+}
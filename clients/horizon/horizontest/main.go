@@ -0,0 +1,196 @@
+// Package horizontest provides an in-memory fake of the horizon client that
+// can be seeded with resources and canned errors, allowing code that depends
+// on horizon.ClientInterface to be unit tested without an http server.
+//
+// The fake ignores paging params: collection loaders return every matching
+// record in a single page, and streams replay every matching record once
+// before returning.
+package horizontest
+
+import (
+	"net/http"
+	"sync"
+
+	"github.com/stellar/go/clients/horizon"
+)
+
+// Client is an in-memory implementation of horizon.ClientInterface.  Create
+// one with NewClient, seed it using the Add* and Set* methods, then pass it to
+// the code under test.  It is safe for concurrent use.
+type Client struct {
+	mutex sync.Mutex
+
+	root         horizon.Root
+	feeStats     horizon.FeeStats
+	metrics      horizon.Metrics
+	accounts     map[string]horizon.Account
+	offers       []horizon.Offer
+	trades       []horizon.Trade
+	transactions []horizon.Transaction
+	operations   []horizon.Operation
+	effects      []horizon.Effect
+	payments     []horizon.Payment
+	ledgers      []horizon.Ledger
+	assets       []horizon.AssetStat
+	orderBooks   []horizon.OrderBookSummary
+	errors       map[string]error
+
+	submitResult horizon.TransactionSuccess
+	submitted    []string
+}
+
+// NewClient returns an empty fake client.  Loading a resource that was not
+// seeded fails with a not found horizon.Error.
+func NewClient() *Client {
+	return &Client{
+		accounts: map[string]horizon.Account{},
+		errors:   map[string]error{},
+	}
+}
+
+// AddAccount seeds the client with an account, replacing any account with the
+// same id.
+func (c *Client) AddAccount(account horizon.Account) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.accounts[account.ID] = account
+}
+
+// AddOffer seeds the client with an offer of its seller.
+func (c *Client) AddOffer(offer horizon.Offer) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.offers = append(c.offers, offer)
+}
+
+// AddTrade seeds the client with a trade of the offer matching its offer id.
+func (c *Client) AddTrade(trade horizon.Trade) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.trades = append(c.trades, trade)
+}
+
+// AddTransaction seeds the client with a transaction.
+func (c *Client) AddTransaction(tx horizon.Transaction) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.transactions = append(c.transactions, tx)
+}
+
+// AddOperation seeds the client with an operation.  The operation is part of
+// the ledger of the seeded transaction matching its transaction hash.
+func (c *Client) AddOperation(op horizon.Operation) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.operations = append(c.operations, op)
+}
+
+// AddEffect seeds the client with an effect.
+func (c *Client) AddEffect(effect horizon.Effect) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.effects = append(c.effects, effect)
+}
+
+// AddPayment seeds the client with a payment.  The payment is part of the
+// ledger of the seeded transaction matching its transaction link.
+func (c *Client) AddPayment(payment horizon.Payment) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.payments = append(c.payments, payment)
+}
+
+// AddLedger seeds the client with a ledger.
+func (c *Client) AddLedger(ledger horizon.Ledger) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.ledgers = append(c.ledgers, ledger)
+}
+
+// AddAsset seeds the client with the statistics of an asset.
+func (c *Client) AddAsset(asset horizon.AssetStat) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.assets = append(c.assets, asset)
+}
+
+// SetOrderBook seeds the client with the order book of its selling and buying
+// assets, replacing any existing one.
+func (c *Client) SetOrderBook(orderBook horizon.OrderBookSummary) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	for i, ob := range c.orderBooks {
+		if ob.Selling == orderBook.Selling && ob.Buying == orderBook.Buying {
+			c.orderBooks[i] = orderBook
+			return
+		}
+	}
+	c.orderBooks = append(c.orderBooks, orderBook)
+}
+
+// SetRoot sets the response of Root, ServerInfo and NetworkPassphrase.
+func (c *Client) SetRoot(root horizon.Root) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.root = root
+}
+
+// SetFeeStats sets the response of LoadFeeStats and BaseFee.
+func (c *Client) SetFeeStats(feeStats horizon.FeeStats) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.feeStats = feeStats
+}
+
+// SetMetrics sets the response of LoadMetrics.
+func (c *Client) SetMetrics(metrics horizon.Metrics) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.metrics = metrics
+}
+
+// SetSubmitResult sets the response of SubmitTransaction.
+func (c *Client) SetSubmitResult(result horizon.TransactionSuccess) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.submitResult = result
+}
+
+// SetError makes every call to the named method, e.g. "LoadAccount", fail
+// with err, including calls to its WithContext variant.  Passing a nil err
+// clears a previously set error.
+func (c *Client) SetError(method string, err error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if err == nil {
+		delete(c.errors, method)
+		return
+	}
+	c.errors[method] = err
+}
+
+// Submitted returns the envelopes of the transactions submitted to the client,
+// in order.
+func (c *Client) Submitted() []string {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return append([]string(nil), c.submitted...)
+}
+
+// NotFound returns the error returned by the fake for resources that were not
+// seeded.  It matches horizon's own not found problem.
+func NotFound() *horizon.Error {
+	return &horizon.Error{
+		Problem: horizon.Problem{
+			Type:   "https://stellar.org/horizon-errors/not_found",
+			Title:  "Resource Missing",
+			Status: http.StatusNotFound,
+			Detail: "The resource at the url requested was not found.",
+		},
+	}
+}
+
+// ensure that the fake client implements ClientInterface
+var _ horizon.ClientInterface = &Client{}
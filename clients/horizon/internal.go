@@ -4,11 +4,16 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"io/ioutil"
 	"net/http"
+	"net/url"
 	"regexp"
+	"strconv"
+	"strings"
+	"time"
 
-	"github.com/manucorporat/sse"
 	"github.com/stellar/go/support/errors"
+	"golang.org/x/net/context"
 )
 
 var endEvent = regexp.MustCompile("(\r\n|\r|\n){2}")
@@ -22,8 +27,15 @@ func decodeResponse(resp *http.Response, object interface{}) (err error) {
 			Response: resp,
 		}
 		decodeError := decoder.Decode(&horizonError.Problem)
-		if decodeError != nil {
-			return errors.Wrap(decodeError, "error decoding horizon.Problem")
+
+		// Responses that are not problem documents, for example errors
+		// returned by a proxy in front of horizon, are still reported as a
+		// horizon.Error so that its helpers can be used.
+		if decodeError != nil || horizonError.Problem.Status == 0 {
+			horizonError.Problem.Status = resp.StatusCode
+		}
+		if decodeError != nil || horizonError.Problem.Title == "" {
+			horizonError.Problem.Title = http.StatusText(resp.StatusCode)
 		}
 		return horizonError
 	}
@@ -35,28 +47,199 @@ func decodeResponse(resp *http.Response, object interface{}) (err error) {
 	return
 }
 
-func loadMemo(p *Payment) error {
-	res, err := http.Get(p.Links.Transaction.Href)
+// get performs a GET request against endpoint using ctx and decodes the
+// response into object.
+func (c *Client) get(ctx context.Context, endpoint string, object interface{}) (*http.Response, error) {
+	req, err := http.NewRequest("GET", endpoint, nil)
 	if err != nil {
-		return err
+		return nil, errors.Wrap(err, "failed to create request")
 	}
-	defer res.Body.Close()
-	return json.NewDecoder(res.Body).Decode(&p.Memo)
+	c.setHeaders(req)
+	c.setAcceptEncoding(req)
+
+	return c.sendRequest(ctx, req, object)
 }
 
-func parseEvent(data []byte) (result sse.Event, err error) {
-	r := bytes.NewReader(data)
-	events, err := sse.Decode(r)
+// postForm performs a form encoded POST request against endpoint using ctx and
+// decodes the response into object.
+func (c *Client) postForm(ctx context.Context, endpoint string, data url.Values, object interface{}) (*http.Response, error) {
+	req, err := http.NewRequest("POST", endpoint, strings.NewReader(data.Encode()))
 	if err != nil {
-		return
+		return nil, errors.Wrap(err, "failed to create request")
 	}
+	c.setHeaders(req)
+	c.setAcceptEncoding(req)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 
-	if len(events) != 1 {
-		err = fmt.Errorf("only expected 1 event, got: %d", len(events))
-		return
+	return c.sendRequest(ctx, req, object)
+}
+
+// setHeaders adds the headers configured on the client to req.
+func (c *Client) setHeaders(req *http.Request) {
+	for name, values := range c.Headers {
+		req.Header[http.CanonicalHeaderKey(name)] = values
+	}
+
+	if c.AppName != "" {
+		req.Header.Set("X-App-Name", c.AppName)
+	}
+	if c.AppVersion != "" {
+		req.Header.Set("X-App-Version", c.AppVersion)
+	}
+}
+
+// sendRequest sends req bound to ctx and decodes the response into object.
+// The response is returned so that callers can inspect the status code.
+// Failed requests are retried according to c.Retry.
+func (c *Client) sendRequest(ctx context.Context, req *http.Request, object interface{}) (*http.Response, error) {
+	shouldRetry := shouldRetryLoad
+	if req.Method != "GET" {
+		shouldRetry = shouldRetrySubmission
+	}
+
+	// The body is buffered so that it can be sent again on retries.
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = ioutil.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read request body")
+		}
+	}
+
+	for attempt := 1; ; attempt++ {
+		err := c.waitRateLimit(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		if body != nil {
+			req.Body = ioutil.NopCloser(bytes.NewReader(body))
+		}
+
+		resp, err := c.HTTP.Do(req.WithContext(ctx))
+		c.reportRateLimit(resp)
+
+		if c.canRetry(ctx, attempt) && shouldRetry(resp, err) {
+			wait := c.Retry.backoff(attempt)
+			if ra := retryAfter(resp); ra > wait {
+				wait = ra
+			}
+			discardResponse(resp)
+
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-time.After(wait):
+			}
+			continue
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		err = decompressResponse(resp)
+		if err != nil {
+			return resp, err
+		}
+
+		err = decodeResponse(resp, object)
+		if err != nil {
+			return resp, err
+		}
+
+		return resp, nil
+	}
+}
+
+// canRetry reports whether a request may be attempted again after the given
+// (1-based) attempt failed.
+func (c *Client) canRetry(ctx context.Context, attempt int) bool {
+	if c.Retry == nil || attempt >= c.Retry.MaxAttempts {
+		return false
+	}
+
+	return ctx.Err() == nil
+}
+
+// orderBookQuery returns the query identifying the order book for the given
+// selling and buying assets.
+func orderBookQuery(selling Asset, buying Asset) url.Values {
+	query := url.Values{}
+
+	query.Add("selling_asset_type", selling.Type)
+	query.Add("selling_asset_code", selling.Code)
+	query.Add("selling_asset_issuer", selling.Issuer)
+
+	query.Add("buying_asset_type", buying.Type)
+	query.Add("buying_asset_code", buying.Code)
+	query.Add("buying_asset_issuer", buying.Issuer)
+
+	return query
+}
+
+// loadPage loads the collection page at path with params applied to it into
+// page.
+func (c *Client) loadPage(ctx context.Context, path string, params []Param, page interface{}) error {
+	endpoint, err := c.buildURL(path, nil, params)
+	if err != nil {
+		return errors.Wrap(err, "failed to parse endpoint")
+	}
+
+	_, err = c.get(ctx, endpoint, page)
+	return err
+}
+
+// ledgerPath returns the path of the ledger with the given sequence number.
+func ledgerPath(sequence int32) string {
+	return "/ledgers/" + strconv.FormatInt(int64(sequence), 10)
+}
+
+// offerPath returns the path of the offer with the given id.
+func offerPath(offerID int64) string {
+	return "/offers/" + strconv.FormatInt(offerID, 10)
+}
+
+// parseEvent parses a single server-sent event, as delimited by splitSSE.
+// See https://www.w3.org/TR/eventsource/#event-stream-interpretation
+func parseEvent(data []byte) (result Event, err error) {
+	result.Event = "message"
+
+	var dataLines [][]byte
+	for _, line := range bytes.FieldsFunc(data, func(r rune) bool { return r == '\n' || r == '\r' }) {
+		// lines starting with a colon are comments, used as heartbeats
+		if len(line) == 0 || line[0] == ':' {
+			continue
+		}
+
+		field, value := line, []byte{}
+		if i := bytes.IndexByte(line, ':'); i >= 0 {
+			field, value = line[:i], line[i+1:]
+			if len(value) > 0 && value[0] == ' ' {
+				value = value[1:]
+			}
+		}
+
+		switch string(field) {
+		case "event":
+			result.Event = string(value)
+		case "id":
+			result.ID = string(value)
+		case "retry":
+			ms, perr := strconv.ParseUint(string(value), 10, 32)
+			if perr != nil {
+				err = fmt.Errorf("invalid retry value: %s", value)
+				return
+			}
+			result.Retry = time.Duration(ms) * time.Millisecond
+		case "data":
+			dataLines = append(dataLines, value)
+		}
 	}
 
-	result = events[0]
+	result.Data = bytes.Join(dataLines, []byte("\n"))
 	return
 }
 
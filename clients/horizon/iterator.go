@@ -0,0 +1,143 @@
+package horizon
+
+import (
+	"encoding/json"
+
+	"github.com/stellar/go/support/errors"
+	"golang.org/x/net/context"
+)
+
+// Iterator walks the records of a horizon collection one at a time, loading
+// the following pages through the collection's HAL "next" links as needed.
+// The iteration order is controlled by the Order param used to create the
+// iterator.
+//
+// Usage follows the pattern of sql.Rows:
+//
+//	it := client.Transactions(ctx, Limit(200), OrderDesc)
+//	for it.Next() {
+//	  var tx horizon.Transaction
+//	  if err := it.Scan(&tx); err != nil {
+//	    ...
+//	  }
+//	}
+//	if err := it.Err(); err != nil {
+//	  ...
+//	}
+type Iterator interface {
+	// Next advances the iterator to the next record.  It returns false when
+	// there are no more records or an error occurred, in which case Err
+	// returns it.
+	Next() bool
+
+	// Scan decodes the current record into dest, which is usually a pointer
+	// to one of the resource structs of this package (e.g. *Transaction).
+	Scan(dest interface{}) error
+
+	// Err returns the error, if any, that stopped the iteration.
+	Err() error
+}
+
+// pageIterator is the Iterator of Client, loading the records of a collection
+// page by page.
+type pageIterator struct {
+	client  *Client
+	ctx     context.Context
+	next    string
+	records []json.RawMessage
+	current json.RawMessage
+	err     error
+}
+
+// page is the generic shape of a horizon collection page.
+type page struct {
+	Links struct {
+		Next Link `json:"next"`
+	} `json:"_links"`
+	Embedded struct {
+		Records []json.RawMessage `json:"records"`
+	} `json:"_embedded"`
+}
+
+// Transactions returns an iterator over the /transactions collection.
+func (c *Client) Transactions(ctx context.Context, params ...Param) Iterator {
+	return c.iterate(ctx, "/transactions", params)
+}
+
+// Operations returns an iterator over the /operations collection.
+func (c *Client) Operations(ctx context.Context, params ...Param) Iterator {
+	return c.iterate(ctx, "/operations", params)
+}
+
+// Effects returns an iterator over the /effects collection.
+func (c *Client) Effects(ctx context.Context, params ...Param) Iterator {
+	return c.iterate(ctx, "/effects", params)
+}
+
+// Payments returns an iterator over the /payments collection.
+func (c *Client) Payments(ctx context.Context, params ...Param) Iterator {
+	return c.iterate(ctx, "/payments", params)
+}
+
+func (c *Client) iterate(ctx context.Context, path string, params []Param) Iterator {
+	it := &pageIterator{client: c, ctx: ctx}
+	it.next, it.err = c.buildURL(path, nil, params)
+	if it.err != nil {
+		it.err = errors.Wrap(it.err, "failed to parse endpoint")
+	}
+	return it
+}
+
+// Next implements Iterator, loading the next page from horizon when the
+// current one is exhausted.
+func (it *pageIterator) Next() bool {
+	if it.err != nil {
+		return false
+	}
+
+	for len(it.records) == 0 {
+		if it.next == "" {
+			it.current = nil
+			return false
+		}
+
+		var p page
+		_, err := it.client.get(it.ctx, it.next, &p)
+		if err != nil {
+			it.err = err
+			it.current = nil
+			return false
+		}
+
+		it.records = p.Embedded.Records
+
+		// an empty page marks the end of the collection
+		if len(it.records) == 0 || p.Links.Next.Href == it.next {
+			it.next = ""
+		} else {
+			it.next = p.Links.Next.Href
+		}
+	}
+
+	it.current, it.records = it.records[0], it.records[1:]
+	return true
+}
+
+// Scan implements Iterator
+func (it *pageIterator) Scan(dest interface{}) error {
+	if it.current == nil {
+		return errors.New("no current record: call Next first")
+	}
+
+	err := json.Unmarshal(it.current, dest)
+	if err != nil {
+		return errors.Wrap(err, "Error unmarshaling record")
+	}
+
+	return nil
+}
+
+// Err implements Iterator
+func (it *pageIterator) Err() error {
+	return it.err
+}
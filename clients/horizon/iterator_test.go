@@ -0,0 +1,60 @@
+package horizon
+
+import (
+	"testing"
+
+	"github.com/stellar/go/support/http/httptest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/context"
+)
+
+func TestIterator(t *testing.T) {
+	hmock := httptest.NewClient()
+	client := &Client{URL: "https://localhost", HTTP: hmock}
+
+	hmock.On("GET", "https://localhost/transactions?limit=2&order=desc").
+		ReturnString(200, `{
+  "_links": {"next": {"href": "https://localhost/transactions?cursor=2&limit=2&order=desc"}},
+  "_embedded": {"records": [{"id": "a", "paging_token": "4"}, {"id": "b", "paging_token": "3"}]}
+}`)
+	hmock.On("GET", "https://localhost/transactions?cursor=2&limit=2&order=desc").
+		ReturnString(200, `{
+  "_links": {"next": {"href": "https://localhost/transactions?cursor=1&limit=2&order=desc"}},
+  "_embedded": {"records": [{"id": "c", "paging_token": "2"}]}
+}`)
+	hmock.On("GET", "https://localhost/transactions?cursor=1&limit=2&order=desc").
+		ReturnString(200, `{
+  "_links": {"next": {"href": "https://localhost/transactions?cursor=1&limit=2&order=desc"}},
+  "_embedded": {"records": []}
+}`)
+
+	it := client.Transactions(context.Background(), Limit(2), OrderDesc)
+
+	var ids []string
+	for it.Next() {
+		var tx Transaction
+		require.NoError(t, it.Scan(&tx))
+		ids = append(ids, tx.ID)
+	}
+
+	require.NoError(t, it.Err())
+	assert.Equal(t, []string{"a", "b", "c"}, ids)
+	assert.False(t, it.Next())
+}
+
+func TestIterator_Error(t *testing.T) {
+	hmock := httptest.NewClient()
+	client := &Client{URL: "https://localhost", HTTP: hmock}
+
+	hmock.On("GET", "https://localhost/operations").ReturnString(404, notFoundResponse)
+
+	it := client.Operations(context.Background())
+	assert.False(t, it.Next())
+
+	herr, ok := it.Err().(*Error)
+	if assert.True(t, ok) {
+		assert.Equal(t, "Resource Missing", herr.Problem.Title)
+	}
+	assert.Error(t, it.Scan(&Payment{}))
+}
@@ -10,9 +10,11 @@ import (
 	"net/http"
 	"net/url"
 	"sync"
+	"time"
 
 	"github.com/stellar/go/build"
 	"github.com/stellar/go/support/errors"
+	"github.com/stellar/go/xdr"
 	"golang.org/x/net/context"
 )
 
@@ -41,6 +43,12 @@ type Limit uint
 // Order represents `order` param in queries
 type Order string
 
+// AssetCode represents `asset_code` param in queries
+type AssetCode string
+
+// AssetIssuer represents `asset_issuer` param in queries
+type AssetIssuer string
+
 const (
 	OrderAsc  Order = "asc"
 	OrderDesc Order = "desc"
@@ -66,20 +74,128 @@ type Client struct {
 	// HTTP client to make requests with
 	HTTP HTTP
 
+	// Retry configures how requests that failed for transient reasons are
+	// retried.  Requests are not retried when nil.
+	Retry *RetryPolicy
+
+	// StreamPolicy configures how streams reconnect after failures.  Streams
+	// only reconnect when horizon closes the connection when nil.
+	StreamPolicy *StreamPolicy
+
+	// RateLimiter, when set, throttles the requests made by the client,
+	// including stream (re)connections.
+	RateLimiter RateLimiter
+
+	// OnRateLimit, when set, is called with the rate limit state reported by
+	// horizon after each request.
+	OnRateLimit RateLimitHandler
+
+	// Headers are added to every request made by the client, including
+	// streams.  Use them to authenticate against a horizon server behind an
+	// API gateway or to override the User-Agent.
+	Headers http.Header
+
+	// AppName and AppVersion, when set, identify the application using the
+	// client to horizon through the X-App-Name and X-App-Version headers.
+	AppName    string
+	AppVersion string
+
+	// Concurrency is the maximum number of concurrent requests made by batch
+	// loaders such as LoadAccounts.  DefaultConcurrency is used when zero.
+	Concurrency int
+
+	// Cache, when set, stores the responses of Root, LoadAccount and
+	// LoadLedger for CacheTTL, or DefaultCacheTTL when zero.  The sequence
+	// numbers returned by SequenceForAccount are never cached.
+	Cache    Cache
+	CacheTTL time.Duration
+
+	// VerifyNetwork, when set, makes the client check that submitted
+	// transactions were signed for the network of the horizon server, and
+	// reject them with ErrWrongNetwork before submission otherwise.
+	VerifyNetwork bool
+
+	// DisableCompression, when set, asks horizon for uncompressed responses,
+	// e.g. to inspect the traffic when debugging.  Streams are never
+	// compressed.
+	DisableCompression bool
+
+	// Preflight, when set, makes the client validate transactions locally
+	// before submitting them, see PreflightPolicy.
+	Preflight *PreflightPolicy
+
 	fixURLOnce sync.Once
+	rootMutex  sync.Mutex
+	root       *Root
 }
 
 type ClientInterface interface {
 	Root() (Root, error)
+	RootWithContext(ctx context.Context) (Root, error)
+	ServerInfo(ctx context.Context) (Root, error)
+	NetworkPassphrase() (string, error)
+	NetworkPassphraseWithContext(ctx context.Context) (string, error)
 	HomeDomainForAccount(aid string) (string, error)
+	HomeDomainForAccountWithContext(ctx context.Context, aid string) (string, error)
 	LoadAccount(accountID string) (Account, error)
-	LoadAccountOffers(accountID string, params ...interface{}) (offers OffersPage, err error)
+	LoadAccountWithContext(ctx context.Context, accountID string) (Account, error)
+	LoadAccounts(accountIDs []string) []AccountResult
+	LoadAccountsWithContext(ctx context.Context, accountIDs []string) []AccountResult
+	LoadAccountData(accountID string, key string) (AccountData, error)
+	LoadAccountDataWithContext(ctx context.Context, accountID string, key string) (AccountData, error)
+	LoadAccountDataEntries(accountID string) (map[string][]byte, error)
+	LoadAccountDataEntriesWithContext(ctx context.Context, accountID string) (map[string][]byte, error)
+	LoadAccountOffers(accountID string, params ...Param) (offers OffersPage, err error)
+	LoadAccountOffersWithContext(ctx context.Context, accountID string, params ...Param) (offers OffersPage, err error)
+	LoadTransaction(transactionHash string) (Transaction, error)
+	LoadTransactionWithContext(ctx context.Context, transactionHash string) (Transaction, error)
+	LoadOperation(operationID string) (Operation, error)
+	LoadOperationWithContext(ctx context.Context, operationID string) (Operation, error)
+	LoadAssets(params ...Param) (AssetsPage, error)
+	LoadAssetsWithContext(ctx context.Context, params ...Param) (AssetsPage, error)
+	LoadFeeStats() (FeeStats, error)
+	LoadFeeStatsWithContext(ctx context.Context) (FeeStats, error)
+	LoadLedger(sequence int32) (Ledger, error)
+	LoadLedgerWithContext(ctx context.Context, sequence int32) (Ledger, error)
+	LoadLedgers(params ...Param) (LedgersPage, error)
+	LoadLedgersWithContext(ctx context.Context, params ...Param) (LedgersPage, error)
+	LoadLedgerTransactions(sequence int32, params ...Param) (TransactionsPage, error)
+	LoadLedgerTransactionsWithContext(ctx context.Context, sequence int32, params ...Param) (TransactionsPage, error)
+	LoadLedgerOperations(sequence int32, params ...Param) (OperationsPage, error)
+	LoadLedgerOperationsWithContext(ctx context.Context, sequence int32, params ...Param) (OperationsPage, error)
+	LoadLedgerPayments(sequence int32, params ...Param) (PaymentsPage, error)
+	LoadLedgerPaymentsWithContext(ctx context.Context, sequence int32, params ...Param) (PaymentsPage, error)
+	LoadOffer(offerID int64) (Offer, error)
+	LoadOfferWithContext(ctx context.Context, offerID int64) (Offer, error)
+	LoadTradesForOffer(offerID int64, params ...Param) (TradesPage, error)
+	LoadTradesForOfferWithContext(ctx context.Context, offerID int64, params ...Param) (TradesPage, error)
+	LoadMetrics() (Metrics, error)
+	LoadMetricsWithContext(ctx context.Context) (Metrics, error)
+	LoadIngestionStatus() (IngestionStatus, error)
+	LoadIngestionStatusWithContext(ctx context.Context) (IngestionStatus, error)
 	LoadMemo(p *Payment) error
-	LoadOrderBook(selling Asset, buying Asset, params ...interface{}) (orderBook OrderBookSummary, err error)
+	LoadMemoWithContext(ctx context.Context, p *Payment) error
+	SequenceForAccount(accountID string) (xdr.SequenceNumber, error)
+	SequenceForAccountWithContext(ctx context.Context, accountID string) (xdr.SequenceNumber, error)
+	SignersForAccount(accountID string) (build.AccountSigners, error)
+	SignersForAccountWithContext(ctx context.Context, accountID string) (build.AccountSigners, error)
+	BaseFee() (uint64, error)
+	BaseFeeWithContext(ctx context.Context) (uint64, error)
+	LoadOrderBook(selling Asset, buying Asset, params ...Param) (orderBook OrderBookSummary, err error)
+	LoadOrderBookWithContext(ctx context.Context, selling Asset, buying Asset, params ...Param) (orderBook OrderBookSummary, err error)
+	Transactions(ctx context.Context, params ...Param) Iterator
+	Operations(ctx context.Context, params ...Param) Iterator
+	Effects(ctx context.Context, params ...Param) Iterator
+	Payments(ctx context.Context, params ...Param) Iterator
+	StreamRaw(ctx context.Context, path string, cursor *Cursor, handler EventHandler) error
 	StreamLedgers(ctx context.Context, cursor *Cursor, handler LedgerHandler) error
+	StreamOrderBook(ctx context.Context, selling Asset, buying Asset, handler OrderBookHandler) error
 	StreamPayments(ctx context.Context, accountID string, cursor *Cursor, handler PaymentHandler) error
+	StreamPaymentsWithOptions(ctx context.Context, accountID string, cursor *Cursor, opts PaymentStreamOptions, handler PaymentHandler) error
 	StreamTransactions(ctx context.Context, accountID string, cursor *Cursor, handler TransactionHandler) error
 	SubmitTransaction(txeBase64 string) (TransactionSuccess, error)
+	SubmitTransactionWithContext(ctx context.Context, txeBase64 string) (TransactionSuccess, error)
+	SubmitTransactionWithOptions(ctx context.Context, txeBase64 string, opts SubmitOptions) (TransactionSuccess, error)
 }
 
 // Error struct contains the problem returned by Horizon
@@ -95,17 +211,27 @@ type HTTP interface {
 	PostForm(url string, data url.Values) (resp *http.Response, err error)
 }
 
+// EventHandler is a function that is called when a raw server-sent event is
+// received
+type EventHandler func(Event) error
+
 // LedgerHandler is a function that is called when a new ledger is received
 type LedgerHandler func(Ledger)
 
+// OrderBookHandler is a function that is called when the order book changes
+type OrderBookHandler func(OrderBookSummary)
+
 // PaymentHandler is a function that is called when a new payment is received
 type PaymentHandler func(Payment)
 
 // TransactionHandler is a function that is called when a new transaction is received
 type TransactionHandler func(Transaction)
 
-// ensure that the horizon client can be used as a SequenceProvider
+// ensure that the horizon client can be used as a SequenceProvider, a
+// FeeProvider and a SignersProvider
 var _ build.SequenceProvider = &Client{}
+var _ build.FeeProvider = &Client{}
+var _ build.SignersProvider = &Client{}
 
 // ensure that the horizon client implements ClientInterface
 var _ ClientInterface = &Client{}
@@ -2,11 +2,14 @@ package horizon
 
 import (
 	"fmt"
+	"net/http"
+	"reflect"
 	"testing"
 	"time"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	"github.com/stellar/go/build"
 	"github.com/stellar/go/support/errors"
 	"github.com/stellar/go/support/http/httptest"
 	"golang.org/x/net/context"
@@ -56,6 +59,21 @@ func ExampleClient_SubmitTransaction() {
 	fmt.Println(response)
 }
 
+// TestClientInterface checks that ClientInterface has every exported method of
+// Client, so that code depending on it can use all of them.  Their signatures
+// are checked by the compiler, as Client implements ClientInterface.
+func TestClientInterface(t *testing.T) {
+	clientType := reflect.TypeOf(&Client{})
+	interfaceType := reflect.TypeOf((*ClientInterface)(nil)).Elem()
+
+	for i := 0; i < clientType.NumMethod(); i++ {
+		name := clientType.Method(i).Name
+		if _, ok := interfaceType.MethodByName(name); !ok {
+			t.Errorf("ClientInterface is missing Client.%s", name)
+		}
+	}
+}
+
 func TestHorizon(t *testing.T) {
 	RegisterFailHandler(Fail)
 	RunSpecs(t, "Package: github.com/stellar/go/horizon")
@@ -92,6 +110,21 @@ var _ = Describe("Horizon", func() {
 			Expect(account.GetNativeBalance()).To(Equal("948522307.6146000"))
 		})
 
+		It("provides the signers of the account", func() {
+			hmock.On(
+				"GET",
+				"https://localhost/accounts/GBRPYHIL2CI3FNQ4BXLFMNDLFJUNPU2HY3ZMFSHONUCEOASW7QC7OX2H",
+			).ReturnString(200, accountResponse)
+
+			signers, err := client.SignersForAccount("GBRPYHIL2CI3FNQ4BXLFMNDLFJUNPU2HY3ZMFSHONUCEOASW7QC7OX2H")
+			Expect(err).To(BeNil())
+			Expect(signers.Signers).To(Equal([]build.AccountSigner{
+				{Key: "XBT5HNPK6DAL6222MAWTLHNOZSDKPJ2AKNEQ5Q324CHHCNQFQ7EHBHZN", Weight: 1},
+				{Key: "GDQHKHMFW5ICTQYM3QWCXMSZ56BNHMQG6NH6SGV3ZNZ72KRHYV5XINCE", Weight: 1},
+			}))
+			Expect(signers.LowThreshold).To(BeEquivalentTo(0))
+		})
+
 		It("failure response", func() {
 			hmock.On(
 				"GET",
@@ -120,6 +153,82 @@ var _ = Describe("Horizon", func() {
 		})
 	})
 
+	Describe("LoadAccountWithContext", func() {
+		It("success response", func() {
+			hmock.On(
+				"GET",
+				"https://localhost/accounts/GBRPYHIL2CI3FNQ4BXLFMNDLFJUNPU2HY3ZMFSHONUCEOASW7QC7OX2H",
+			).ReturnString(200, accountResponse)
+
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+
+			account, err := client.LoadAccountWithContext(ctx, "GBRPYHIL2CI3FNQ4BXLFMNDLFJUNPU2HY3ZMFSHONUCEOASW7QC7OX2H")
+			Expect(err).To(BeNil())
+			Expect(account.ID).To(Equal("GBRPYHIL2CI3FNQ4BXLFMNDLFJUNPU2HY3ZMFSHONUCEOASW7QC7OX2H"))
+		})
+
+		It("cancelled context", func() {
+			hmock.On(
+				"GET",
+				"https://localhost/accounts/GBRPYHIL2CI3FNQ4BXLFMNDLFJUNPU2HY3ZMFSHONUCEOASW7QC7OX2H",
+			).Return(func(req *http.Request) (*http.Response, error) {
+				<-req.Context().Done()
+				return nil, req.Context().Err()
+			})
+
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+
+			_, err := client.LoadAccountWithContext(ctx, "GBRPYHIL2CI3FNQ4BXLFMNDLFJUNPU2HY3ZMFSHONUCEOASW7QC7OX2H")
+			Expect(err).NotTo(BeNil())
+			_, ok := err.(*Error)
+			Expect(ok).To(BeFalse())
+		})
+	})
+
+	Describe("LoadAccountData", func() {
+		It("success response", func() {
+			hmock.On(
+				"GET",
+				"https://localhost/accounts/GBRPYHIL2CI3FNQ4BXLFMNDLFJUNPU2HY3ZMFSHONUCEOASW7QC7OX2H/data/test",
+			).ReturnString(200, `{"value": "R0NCVkwzU1FGRVZLUkxQNkFKNDdVS0tXWUVCWTQ1V0hBSkhDRVpLVldNVEdNQ1Q0SDROS1FZTEg="}`)
+
+			data, err := client.LoadAccountData("GBRPYHIL2CI3FNQ4BXLFMNDLFJUNPU2HY3ZMFSHONUCEOASW7QC7OX2H", "test")
+			Expect(err).To(BeNil())
+
+			value, err := data.Decode()
+			Expect(err).To(BeNil())
+			Expect(string(value)).To(Equal("GCBVL3SQFEVKRLP6AJ47UKKWYEBY45WHAJHCEZKVWMTGMCT4H4NKQYLH"))
+		})
+
+		It("failure response", func() {
+			hmock.On(
+				"GET",
+				"https://localhost/accounts/GBRPYHIL2CI3FNQ4BXLFMNDLFJUNPU2HY3ZMFSHONUCEOASW7QC7OX2H/data/test",
+			).ReturnString(404, notFoundResponse)
+
+			_, err := client.LoadAccountData("GBRPYHIL2CI3FNQ4BXLFMNDLFJUNPU2HY3ZMFSHONUCEOASW7QC7OX2H", "test")
+			Expect(err).NotTo(BeNil())
+			_, ok := err.(*Error)
+			Expect(ok).To(BeTrue())
+		})
+	})
+
+	Describe("LoadAccountDataEntries", func() {
+		It("success response", func() {
+			hmock.On(
+				"GET",
+				"https://localhost/accounts/GBRPYHIL2CI3FNQ4BXLFMNDLFJUNPU2HY3ZMFSHONUCEOASW7QC7OX2H",
+			).ReturnString(200, accountResponse)
+
+			entries, err := client.LoadAccountDataEntries("GBRPYHIL2CI3FNQ4BXLFMNDLFJUNPU2HY3ZMFSHONUCEOASW7QC7OX2H")
+			Expect(err).To(BeNil())
+			Expect(len(entries)).To(Equal(1))
+			Expect(string(entries["test"])).To(Equal("GCBVL3SQFEVKRLP6AJ47UKKWYEBY45WHAJHCEZKVWMTGMCT4H4NKQYLH"))
+		})
+	})
+
 	Describe("LoadAccountOffers", func() {
 		It("success response", func() {
 			hmock.On(
@@ -266,6 +375,318 @@ var _ = Describe("Horizon", func() {
 		})
 	})
 
+	Describe("LoadAssets", func() {
+		It("success response", func() {
+			hmock.On(
+				"GET",
+				"https://localhost/assets?asset_code=USD&asset_issuer=GBAUUA74H4XOQYRSOW2RZUA4QL5PB37U3JS5NE3RTB2ELJVMIF5RLMAG&limit=1",
+			).ReturnString(200, assetsResponse)
+
+			assets, err := client.LoadAssets(
+				AssetCode("USD"),
+				AssetIssuer("GBAUUA74H4XOQYRSOW2RZUA4QL5PB37U3JS5NE3RTB2ELJVMIF5RLMAG"),
+				Limit(1),
+			)
+			Expect(err).To(BeNil())
+			Expect(len(assets.Embedded.Records)).To(Equal(1))
+
+			asset := assets.Embedded.Records[0]
+			Expect(asset.Type).To(Equal("credit_alphanum4"))
+			Expect(asset.Code).To(Equal("USD"))
+			Expect(asset.Issuer).To(Equal("GBAUUA74H4XOQYRSOW2RZUA4QL5PB37U3JS5NE3RTB2ELJVMIF5RLMAG"))
+			Expect(asset.Amount).To(Equal("111.0010000"))
+			Expect(asset.NumAccounts).To(Equal(int32(3)))
+			Expect(asset.Flags.AuthRequired).To(BeTrue())
+			Expect(asset.Flags.AuthRevocable).To(BeFalse())
+			Expect(asset.Links.Toml.Href).To(Equal("https://www.stellar.org/.well-known/stellar.toml"))
+		})
+
+		It("failure response", func() {
+			hmock.On("GET", "https://localhost/assets").ReturnString(404, notFoundResponse)
+
+			_, err := client.LoadAssets()
+			Expect(err).NotTo(BeNil())
+			_, ok := err.(*Error)
+			Expect(ok).To(BeTrue())
+		})
+	})
+
+	Describe("LoadFeeStats", func() {
+		It("success response", func() {
+			hmock.On("GET", "https://localhost/fee_stats").ReturnString(200, feeStatsResponse)
+
+			fees, err := client.LoadFeeStats()
+			Expect(err).To(BeNil())
+			Expect(fees.LastLedger).To(Equal(int32(22606298)))
+			Expect(fees.LastLedgerBaseFee).To(Equal(int32(100)))
+			Expect(fees.LedgerCapacityUsage).To(Equal(0.97))
+			Expect(fees.MinAcceptedFee).To(Equal(int32(100)))
+			Expect(fees.ModeAcceptedFee).To(Equal(int32(250)))
+			Expect(fees.P50AcceptedFee).To(Equal(int32(200)))
+			Expect(fees.P99AcceptedFee).To(Equal(int32(1000)))
+		})
+
+		It("failure response", func() {
+			hmock.On("GET", "https://localhost/fee_stats").ReturnString(404, notFoundResponse)
+
+			_, err := client.LoadFeeStats()
+			Expect(err).NotTo(BeNil())
+			_, ok := err.(*Error)
+			Expect(ok).To(BeTrue())
+		})
+	})
+
+	Describe("BaseFee", func() {
+		It("returns the mode accepted fee", func() {
+			hmock.On("GET", "https://localhost/fee_stats").ReturnString(200, feeStatsResponse)
+
+			fee, err := client.BaseFee()
+			Expect(err).To(BeNil())
+			Expect(fee).To(Equal(uint64(250)))
+		})
+
+		It("falls back to the base fee of the latest ledger", func() {
+			hmock.On("GET", "https://localhost/fee_stats").ReturnString(404, notFoundResponse)
+			hmock.On("GET", "https://localhost").ReturnString(200, `{"history_latest_ledger": 3128812}`)
+			hmock.On("GET", "https://localhost/ledgers/3128812").ReturnString(200, ledgerResponse)
+
+			fee, err := client.BaseFee()
+			Expect(err).To(BeNil())
+			Expect(fee).To(Equal(uint64(100)))
+		})
+
+		It("failure response", func() {
+			hmock.On("GET", "https://localhost/fee_stats").
+				ReturnString(500, `{"type": "server_error", "title": "Internal Server Error", "status": 500}`)
+
+			_, err := client.BaseFee()
+			Expect(err).NotTo(BeNil())
+		})
+	})
+
+	Describe("LoadTransaction", func() {
+		It("success response", func() {
+			hmock.On(
+				"GET",
+				"https://localhost/transactions/5131aed266a639a6eb4802a92fba310454e711ded830ed899745b9e777d7110c",
+			).ReturnString(200, transactionResponse)
+
+			tx, err := client.LoadTransaction("5131aed266a639a6eb4802a92fba310454e711ded830ed899745b9e777d7110c")
+			Expect(err).To(BeNil())
+			Expect(tx.Hash).To(Equal("5131aed266a639a6eb4802a92fba310454e711ded830ed899745b9e777d7110c"))
+			Expect(tx.Ledger).To(Equal(int32(3128812)))
+
+			envelope, err := tx.Envelope()
+			Expect(err).To(BeNil())
+			Expect(envelope.Tx.SourceAccount.Address()).To(Equal("GAKLCFRTFDXKOEEUSBS23FBSUUVJRMDQHGCHNGGGJZQRK7BCPIMHUC4P"))
+			Expect(len(envelope.Tx.Operations)).To(Equal(1))
+
+			result, err := tx.Result()
+			Expect(err).To(BeNil())
+			Expect(result.FeeCharged).To(BeEquivalentTo(100))
+		})
+
+		It("failure response", func() {
+			hmock.On(
+				"GET",
+				"https://localhost/transactions/5131aed266a639a6eb4802a92fba310454e711ded830ed899745b9e777d7110c",
+			).ReturnString(404, notFoundResponse)
+
+			_, err := client.LoadTransaction("5131aed266a639a6eb4802a92fba310454e711ded830ed899745b9e777d7110c")
+			Expect(err).NotTo(BeNil())
+			horizonError, ok := err.(*Error)
+			Expect(ok).To(BeTrue())
+			Expect(horizonError.Problem.Title).To(Equal("Resource Missing"))
+		})
+	})
+
+	Describe("LoadOperation", func() {
+		It("success response", func() {
+			hmock.On(
+				"GET",
+				"https://localhost/operations/13438361174495233",
+			).ReturnString(200, paymentOperationResponse)
+
+			op, err := client.LoadOperation("13438361174495233")
+			Expect(err).To(BeNil())
+
+			payment, ok := op.(PaymentOperation)
+			Expect(ok).To(BeTrue())
+			Expect(payment.GetBase().ID).To(Equal("13438361174495233"))
+			Expect(payment.Type).To(Equal("payment"))
+			Expect(payment.From).To(Equal("GAKLCFRTFDXKOEEUSBS23FBSUUVJRMDQHGCHNGGGJZQRK7BCPIMHUC4P"))
+			Expect(payment.Asset.Type).To(Equal("native"))
+			Expect(payment.Amount).To(Equal("1.0000000"))
+		})
+
+		It("failure response", func() {
+			hmock.On(
+				"GET",
+				"https://localhost/operations/13438361174495233",
+			).ReturnString(404, notFoundResponse)
+
+			_, err := client.LoadOperation("13438361174495233")
+			Expect(err).NotTo(BeNil())
+			_, ok := err.(*Error)
+			Expect(ok).To(BeTrue())
+		})
+	})
+
+	Describe("LoadLedger", func() {
+		It("success response", func() {
+			hmock.On("GET", "https://localhost/ledgers/3128812").ReturnString(200, ledgerResponse)
+
+			ledger, err := client.LoadLedger(3128812)
+			Expect(err).To(BeNil())
+			Expect(ledger.Sequence).To(Equal(int32(3128812)))
+			Expect(ledger.Hash).To(Equal("7e3a1cd6d4eb1d4b9d3e9a0e8b1c8e3e4f3a6b3c2d1e0f9a8b7c6d5e4f3a2b1c"))
+			Expect(ledger.TransactionCount).To(Equal(int32(1)))
+			Expect(ledger.BaseFee).To(Equal(int32(100)))
+		})
+
+		It("failure response", func() {
+			hmock.On("GET", "https://localhost/ledgers/3128812").ReturnString(404, notFoundResponse)
+
+			_, err := client.LoadLedger(3128812)
+			Expect(err).NotTo(BeNil())
+			_, ok := err.(*Error)
+			Expect(ok).To(BeTrue())
+		})
+	})
+
+	Describe("LoadLedgers", func() {
+		It("success response", func() {
+			hmock.On("GET", "https://localhost/ledgers?limit=1&order=desc").
+				ReturnString(200, "{\"_embedded\":{\"records\":["+ledgerResponse+"]}}")
+
+			ledgers, err := client.LoadLedgers(Limit(1), OrderDesc)
+			Expect(err).To(BeNil())
+			Expect(len(ledgers.Embedded.Records)).To(Equal(1))
+			Expect(ledgers.Embedded.Records[0].Sequence).To(Equal(int32(3128812)))
+		})
+	})
+
+	Describe("LoadLedgerTransactions", func() {
+		It("success response", func() {
+			hmock.On("GET", "https://localhost/ledgers/3128812/transactions").
+				ReturnString(200, "{\"_embedded\":{\"records\":["+transactionResponse+"]}}")
+
+			transactions, err := client.LoadLedgerTransactions(3128812)
+			Expect(err).To(BeNil())
+			Expect(len(transactions.Embedded.Records)).To(Equal(1))
+			Expect(transactions.Embedded.Records[0].Ledger).To(Equal(int32(3128812)))
+		})
+	})
+
+	Describe("LoadLedgerOperations", func() {
+		It("success response", func() {
+			hmock.On("GET", "https://localhost/ledgers/3128812/operations?limit=10").
+				ReturnString(200, "{\"_embedded\":{\"records\":["+paymentOperationResponse+"]}}")
+
+			operations, err := client.LoadLedgerOperations(3128812, Limit(10))
+			Expect(err).To(BeNil())
+			Expect(len(operations.Embedded.Records)).To(Equal(1))
+
+			payment, ok := operations.Embedded.Records[0].(PaymentOperation)
+			Expect(ok).To(BeTrue())
+			Expect(payment.Amount).To(Equal("1.0000000"))
+		})
+
+		It("failure response", func() {
+			hmock.On("GET", "https://localhost/ledgers/3128812/operations").ReturnString(404, notFoundResponse)
+
+			_, err := client.LoadLedgerOperations(3128812)
+			Expect(err).NotTo(BeNil())
+			_, ok := err.(*Error)
+			Expect(ok).To(BeTrue())
+		})
+	})
+
+	Describe("LoadLedgerPayments", func() {
+		It("success response", func() {
+			hmock.On("GET", "https://localhost/ledgers/3128812/payments").
+				ReturnString(200, "{\"_embedded\":{\"records\":["+paymentOperationResponse+"]}}")
+
+			payments, err := client.LoadLedgerPayments(3128812)
+			Expect(err).To(BeNil())
+			Expect(len(payments.Embedded.Records)).To(Equal(1))
+			Expect(payments.Embedded.Records[0].Type).To(Equal("payment"))
+			Expect(payments.Embedded.Records[0].From).To(Equal("GAKLCFRTFDXKOEEUSBS23FBSUUVJRMDQHGCHNGGGJZQRK7BCPIMHUC4P"))
+		})
+	})
+
+	Describe("LoadOffer", func() {
+		It("success response", func() {
+			hmock.On("GET", "https://localhost/offers/4").ReturnString(200, offerResponse)
+
+			offer, err := client.LoadOffer(4)
+			Expect(err).To(BeNil())
+			Expect(offer.ID).To(Equal(int64(4)))
+			Expect(offer.Seller).To(Equal("GAKLCFRTFDXKOEEUSBS23FBSUUVJRMDQHGCHNGGGJZQRK7BCPIMHUC4P"))
+			Expect(offer.Selling.Type).To(Equal("native"))
+			Expect(offer.Buying.Code).To(Equal("USD"))
+			Expect(offer.PriceR).To(Equal(Price{N: 1, D: 4}))
+		})
+
+		It("failure response", func() {
+			hmock.On("GET", "https://localhost/offers/4").ReturnString(404, notFoundResponse)
+
+			_, err := client.LoadOffer(4)
+			Expect(err).NotTo(BeNil())
+			_, ok := err.(*Error)
+			Expect(ok).To(BeTrue())
+		})
+	})
+
+	Describe("LoadTradesForOffer", func() {
+		It("success response", func() {
+			hmock.On("GET", "https://localhost/offers/4/trades?order=desc").
+				ReturnString(200, "{\"_embedded\":{\"records\":["+tradeResponse+"]}}")
+
+			trades, err := client.LoadTradesForOffer(4, OrderDesc)
+			Expect(err).To(BeNil())
+			Expect(len(trades.Embedded.Records)).To(Equal(1))
+
+			trade := trades.Embedded.Records[0]
+			Expect(trade.OfferID).To(Equal("4"))
+			Expect(trade.BaseAmount).To(Equal("10.0000000"))
+			Expect(trade.CounterAssetCode).To(Equal("USD"))
+			Expect(trade.BaseIsSeller).To(BeTrue())
+			Expect(trade.Price).To(Equal(Price{N: 1, D: 4}))
+		})
+	})
+
+	Describe("StreamOrderBook", func() {
+		It("reconnects and streams summaries", func() {
+			hmock.On(
+				"GET",
+				"https://localhost/order_book?buying_asset_code=DEMO&buying_asset_issuer=GBAMBOOZDWZPVV52RCLJQYMQNXOBLOXWNQAY2IF2FREV2WL46DBCH3BE&buying_asset_type=credit_alphanum4&selling_asset_code=&selling_asset_issuer=&selling_asset_type=native",
+			).ReturnString(200, orderBookStreamResponse)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			var summaries []OrderBookSummary
+			err := client.StreamOrderBook(
+				ctx,
+				Asset{Type: "native"},
+				Asset{"credit_alphanum4", "DEMO", "GBAMBOOZDWZPVV52RCLJQYMQNXOBLOXWNQAY2IF2FREV2WL46DBCH3BE"},
+				func(summary OrderBookSummary) {
+					summaries = append(summaries, summary)
+					if len(summaries) == 3 {
+						cancel()
+					}
+				},
+			)
+
+			Expect(err).To(BeNil())
+			Expect(len(summaries)).To(Equal(3))
+			Expect(summaries[0].Bids[0].Price).To(Equal("0.0024937"))
+			Expect(summaries[1].Bids[0].Price).To(Equal("0.0024938"))
+			Expect(summaries[2].Bids[0].Price).To(Equal("0.0024937"))
+		})
+	})
+
 	Describe("SubmitTransaction", func() {
 		var tx = "AAAAADSMMRmQGDH6EJzkgi/7PoKhphMHyNGQgDp2tlS/dhGXAAAAZAAT3TUAAAAwAAAAAAAAAAAAAAABAAAAAAAAAAMAAAABSU5SAAAAAAA0jDEZkBgx+hCc5IIv+z6CoaYTB8jRkIA6drZUv3YRlwAAAAFVU0QAAAAAADSMMRmQGDH6EJzkgi/7PoKhphMHyNGQgDp2tlS/dhGXAAAAAAX14QAAAAAKAAAAAQAAAAAAAAAAAAAAAAAAAAG/dhGXAAAAQLuStfImg0OeeGAQmvLkJSZ1MPSkCzCYNbGqX5oYNuuOqZ5SmWhEsC7uOD9ha4V7KengiwNlc0oMNqBVo22S7gk="
 
@@ -813,3 +1234,196 @@ var transactionFailure = `{
     "result_xdr": "AAAAAAAAAAD////4AAAAAA=="
   }
 }`
+
+var transactionResponse = `{
+  "_links": {
+    "self": {
+      "href": "https://horizon-testnet.stellar.org/transactions/5131aed266a639a6eb4802a92fba310454e711ded830ed899745b9e777d7110c"
+    }
+  },
+  "id": "5131aed266a639a6eb4802a92fba310454e711ded830ed899745b9e777d7110c",
+  "paging_token": "13438361174495232",
+  "hash": "5131aed266a639a6eb4802a92fba310454e711ded830ed899745b9e777d7110c",
+  "ledger": 3128812,
+  "created_at": "2017-11-17T12:31:48Z",
+  "source_account": "GAKLCFRTFDXKOEEUSBS23FBSUUVJRMDQHGCHNGGGJZQRK7BCPIMHUC4P",
+  "source_account_sequence": "2384637369565273",
+  "fee_paid": 100,
+  "operation_count": 1,
+  "envelope_xdr": "AAAAABSxFjMo7qcQlJBlrZQypSqYsHA5hHaYxk5hFXwiehh6AAAAZAAIdakAAABZAAAAAAAAAAAAAAABAAAAAAAAAAEAAAAAFLEWMyjupxCUkGWtlDKlKpiwcDmEdpjGTmEVfCJ6GHoAAAAAAAAAAACYloAAAAAAAAAAASJ6GHoAAABAp0FnKOQ9lJPDXPTh/a91xoZ8BaznwLj59sdDGK94eGzCOk7oetw7Yw50yOSZg2mqXAST6Agc9Ao/f5T9gB+GCw==",
+  "result_xdr": "AAAAAAAAAGQAAAAAAAAAAQAAAAAAAAABAAAAAAAAAAA=",
+  "result_meta_xdr": "AAAAAAAAAAEAAAAA",
+  "fee_meta_xdr": "AAAAAA==",
+  "memo_type": "none",
+  "signatures": [
+    "p0FnKOQ9lJPDXPTh/a91xoZ8BaznwLj59sdDGK94eGzCOk7oetw7Yw50yOSZg2mqXAST6Agc9Ao/f5T9gB+GCw=="
+  ]
+}`
+
+var paymentOperationResponse = `{
+  "_links": {
+    "self": {
+      "href": "https://horizon-testnet.stellar.org/operations/13438361174495233"
+    },
+    "transaction": {
+      "href": "https://horizon-testnet.stellar.org/transactions/5131aed266a639a6eb4802a92fba310454e711ded830ed899745b9e777d7110c"
+    }
+  },
+  "id": "13438361174495233",
+  "paging_token": "13438361174495233",
+  "source_account": "GAKLCFRTFDXKOEEUSBS23FBSUUVJRMDQHGCHNGGGJZQRK7BCPIMHUC4P",
+  "type": "payment",
+  "type_i": 1,
+  "created_at": "2017-11-17T12:31:48Z",
+  "transaction_hash": "5131aed266a639a6eb4802a92fba310454e711ded830ed899745b9e777d7110c",
+  "asset_type": "native",
+  "from": "GAKLCFRTFDXKOEEUSBS23FBSUUVJRMDQHGCHNGGGJZQRK7BCPIMHUC4P",
+  "to": "GAKLCFRTFDXKOEEUSBS23FBSUUVJRMDQHGCHNGGGJZQRK7BCPIMHUC4P",
+  "amount": "1.0000000"
+}`
+
+var ledgerResponse = `{
+  "_links": {
+    "self": {
+      "href": "https://horizon-testnet.stellar.org/ledgers/3128812"
+    }
+  },
+  "id": "7e3a1cd6d4eb1d4b9d3e9a0e8b1c8e3e4f3a6b3c2d1e0f9a8b7c6d5e4f3a2b1c",
+  "paging_token": "13438361174491136",
+  "hash": "7e3a1cd6d4eb1d4b9d3e9a0e8b1c8e3e4f3a6b3c2d1e0f9a8b7c6d5e4f3a2b1c",
+  "prev_hash": "b1c3d5e7f9a1b3c5d7e9f1a3b5c7d9e1f3a5b7c9d1e3f5a7b9c1d3e5f7a9b1c3",
+  "sequence": 3128812,
+  "transaction_count": 1,
+  "operation_count": 1,
+  "closed_at": "2017-11-17T12:31:48Z",
+  "total_coins": "100000000000.0000000",
+  "fee_pool": "1024.4531800",
+  "base_fee_in_stroops": 100,
+  "base_reserve_in_stroops": 5000000,
+  "max_tx_set_size": 50,
+  "protocol_version": 9
+}`
+
+var assetsResponse = `{
+  "_links": {
+    "self": {
+      "href": "https://horizon-testnet.stellar.org/assets?order=asc\u0026limit=1\u0026cursor="
+    },
+    "next": {
+      "href": "https://horizon-testnet.stellar.org/assets?order=asc\u0026limit=1\u0026cursor=USD_GBAUUA74H4XOQYRSOW2RZUA4QL5PB37U3JS5NE3RTB2ELJVMIF5RLMAG_credit_alphanum4"
+    },
+    "prev": {
+      "href": "https://horizon-testnet.stellar.org/assets?order=desc\u0026limit=1\u0026cursor=USD_GBAUUA74H4XOQYRSOW2RZUA4QL5PB37U3JS5NE3RTB2ELJVMIF5RLMAG_credit_alphanum4"
+    }
+  },
+  "_embedded": {
+    "records": [
+      {
+        "_links": {
+          "toml": {
+            "href": "https://www.stellar.org/.well-known/stellar.toml"
+          }
+        },
+        "asset_type": "credit_alphanum4",
+        "asset_code": "USD",
+        "asset_issuer": "GBAUUA74H4XOQYRSOW2RZUA4QL5PB37U3JS5NE3RTB2ELJVMIF5RLMAG",
+        "paging_token": "USD_GBAUUA74H4XOQYRSOW2RZUA4QL5PB37U3JS5NE3RTB2ELJVMIF5RLMAG_credit_alphanum4",
+        "amount": "111.0010000",
+        "num_accounts": 3,
+        "flags": {
+          "auth_required": true,
+          "auth_revocable": false
+        }
+      }
+    ]
+  }
+}`
+
+var orderBookStreamResponse = `event: message
+data: {"bids":[{"price_r":{"n":24937,"d":10000000},"price":"0.0024937","amount":"0.4363975"}],"asks":[],"base":{"asset_type":"native"},"counter":{"asset_type":"credit_alphanum4","asset_code":"DEMO","asset_issuer":"GBAMBOOZDWZPVV52RCLJQYMQNXOBLOXWNQAY2IF2FREV2WL46DBCH3BE"}}
+
+event: message
+data: {"bids":[{"price_r":{"n":24938,"d":10000000},"price":"0.0024938","amount":"0.4363975"}],"asks":[],"base":{"asset_type":"native"},"counter":{"asset_type":"credit_alphanum4","asset_code":"DEMO","asset_issuer":"GBAMBOOZDWZPVV52RCLJQYMQNXOBLOXWNQAY2IF2FREV2WL46DBCH3BE"}}
+
+`
+
+var feeStatsResponse = `{
+  "last_ledger": "22606298",
+  "last_ledger_base_fee": "100",
+  "ledger_capacity_usage": "0.97",
+  "min_accepted_fee": "100",
+  "mode_accepted_fee": "250",
+  "p10_accepted_fee": "100",
+  "p20_accepted_fee": "100",
+  "p30_accepted_fee": "100",
+  "p40_accepted_fee": "150",
+  "p50_accepted_fee": "200",
+  "p60_accepted_fee": "250",
+  "p70_accepted_fee": "250",
+  "p80_accepted_fee": "300",
+  "p90_accepted_fee": "500",
+  "p95_accepted_fee": "750",
+  "p99_accepted_fee": "1000"
+}`
+
+var offerResponse = `{
+  "_links": {
+    "self": {
+      "href": "https://horizon-testnet.stellar.org/offers/4"
+    },
+    "offer_maker": {
+      "href": "https://horizon-testnet.stellar.org/accounts/GAKLCFRTFDXKOEEUSBS23FBSUUVJRMDQHGCHNGGGJZQRK7BCPIMHUC4P"
+    }
+  },
+  "id": 4,
+  "paging_token": "4",
+  "seller": "GAKLCFRTFDXKOEEUSBS23FBSUUVJRMDQHGCHNGGGJZQRK7BCPIMHUC4P",
+  "selling": {
+    "asset_type": "native"
+  },
+  "buying": {
+    "asset_type": "credit_alphanum4",
+    "asset_code": "USD",
+    "asset_issuer": "GC23QF2HUE52AMXUFUH3AYJAXXGXXV2VHXYYR6EYXETPKDXZSAW67XO4"
+  },
+  "amount": "20.0000000",
+  "price_r": {
+    "n": 1,
+    "d": 4
+  },
+  "price": "0.2500000"
+}`
+
+var tradeResponse = `{
+  "_links": {
+    "self": {
+      "href": ""
+    },
+    "base": {
+      "href": "https://horizon-testnet.stellar.org/accounts/GAKLCFRTFDXKOEEUSBS23FBSUUVJRMDQHGCHNGGGJZQRK7BCPIMHUC4P"
+    },
+    "counter": {
+      "href": "https://horizon-testnet.stellar.org/accounts/GC23QF2HUE52AMXUFUH3AYJAXXGXXV2VHXYYR6EYXETPKDXZSAW67XO4"
+    },
+    "operation": {
+      "href": "https://horizon-testnet.stellar.org/operations/13438152634441729"
+    }
+  },
+  "id": "13438152634441729-0",
+  "paging_token": "13438152634441729-0",
+  "ledger_close_time": "2018-02-02T00:20:10Z",
+  "offer_id": "4",
+  "base_account": "GAKLCFRTFDXKOEEUSBS23FBSUUVJRMDQHGCHNGGGJZQRK7BCPIMHUC4P",
+  "base_amount": "10.0000000",
+  "base_asset_type": "native",
+  "counter_account": "GC23QF2HUE52AMXUFUH3AYJAXXGXXV2VHXYYR6EYXETPKDXZSAW67XO4",
+  "counter_amount": "2.5000000",
+  "counter_asset_type": "credit_alphanum4",
+  "counter_asset_code": "USD",
+  "counter_asset_issuer": "GC23QF2HUE52AMXUFUH3AYJAXXGXXV2VHXYYR6EYXETPKDXZSAW67XO4",
+  "base_is_seller": true,
+  "price": {
+    "n": 1,
+    "d": 4
+  }
+}`
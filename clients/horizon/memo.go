@@ -0,0 +1,182 @@
+package horizon
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/stellar/go/support/errors"
+	"golang.org/x/net/context"
+)
+
+// memoCacheSize is the number of transaction memos remembered by a payment
+// stream resolving memos.  The payments of a transaction are streamed one
+// after the other, so a small cache is enough to load each memo once.
+const memoCacheSize = 100
+
+// PaymentStreamOptions configures StreamPaymentsWithOptions.
+type PaymentStreamOptions struct {
+	// ResolveMemos makes the stream load the memo of the transaction of each
+	// payment, as LoadMemo does, before passing the payment to the handler.
+	ResolveMemos bool
+
+	// MemoConcurrency is the maximum number of memos loaded concurrently
+	// while the handler processes earlier payments.  DefaultConcurrency is
+	// used when zero.
+	MemoConcurrency int
+}
+
+// StreamPaymentsWithOptions is like StreamPayments but can, when configured
+// by opts, resolve the memo of each payment before invoking the handler.
+// Payments are still passed to the handler one at a time and in order.  If a
+// memo cannot be loaded, streaming stops and the error is returned; payments
+// streamed after the failed one are not passed to the handler, so callers
+// tracking the paging token of the last handled payment can safely resume
+// from it.
+func (c *Client) StreamPaymentsWithOptions(ctx context.Context, accountID string, cursor *Cursor, opts PaymentStreamOptions, handler PaymentHandler) error {
+	if !opts.ResolveMemos {
+		return c.StreamPayments(ctx, accountID, cursor, handler)
+	}
+
+	workers := opts.MemoConcurrency
+	if workers <= 0 {
+		workers = DefaultConcurrency
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	resolver := newMemoResolver(c, workers)
+	queue := make(chan *pendingPayment, workers)
+	delivered := make(chan error, 1)
+
+	go func() {
+		err := deliverPayments(ctx, queue, handler)
+		if err != nil {
+			cancel()
+		}
+		delivered <- err
+	}()
+
+	c.fixURLOnce.Do(c.fixURL)
+	url := fmt.Sprintf("%s/accounts/%s/payments", c.URL, accountID)
+	err := c.stream(ctx, url, cursor, func(data []byte) error {
+		var payment Payment
+		err := json.Unmarshal(data, &payment)
+		if err != nil {
+			return errors.Wrap(err, "Error unmarshaling data")
+		}
+
+		p := &pendingPayment{payment: payment, memo: resolver.resolve(ctx, payment)}
+		select {
+		case queue <- p:
+			return nil
+		case <-ctx.Done():
+			return nil
+		}
+	})
+
+	close(queue)
+	if derr := <-delivered; derr != nil {
+		return derr
+	}
+	return err
+}
+
+// pendingPayment is a streamed payment waiting for its memo to be loaded.
+type pendingPayment struct {
+	payment Payment
+	memo    *memoResult
+}
+
+// deliverPayments passes the payments received on queue to handler, in
+// order, as soon as their memo is loaded.  It returns when queue is closed,
+// when ctx is done, or when a memo fails to load.
+func deliverPayments(ctx context.Context, queue <-chan *pendingPayment, handler PaymentHandler) error {
+	for p := range queue {
+		select {
+		case <-p.memo.done:
+		case <-ctx.Done():
+			return nil
+		}
+
+		if p.memo.err != nil {
+			return errors.Wrap(p.memo.err, "load memo failed")
+		}
+
+		p.payment.Memo.Type = p.memo.memoType
+		p.payment.Memo.Value = p.memo.memo
+		handler(p.payment)
+	}
+	return nil
+}
+
+// memoResult is the memo of a transaction, which is available once done is
+// closed.
+type memoResult struct {
+	done     chan struct{}
+	memoType string
+	memo     string
+	err      error
+}
+
+// memoResolver loads the memos of the transactions of streamed payments,
+// making at most cap(sem) requests at a time and loading the memo of each
+// transaction once.
+type memoResolver struct {
+	client *Client
+	sem    chan struct{}
+
+	mutex sync.Mutex
+	memos map[string]*memoResult
+	order []string
+}
+
+func newMemoResolver(client *Client, workers int) *memoResolver {
+	return &memoResolver{
+		client: client,
+		sem:    make(chan struct{}, workers),
+		memos:  map[string]*memoResult{},
+	}
+}
+
+// resolve starts loading the memo of the transaction of payment in the
+// background, unless it is already loaded or being loaded.
+func (r *memoResolver) resolve(ctx context.Context, payment Payment) *memoResult {
+	// the link of the transaction embeds its hash, and is what LoadMemo
+	// loads
+	key := payment.Links.Transaction.Href
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if result, ok := r.memos[key]; ok {
+		return result
+	}
+
+	result := &memoResult{done: make(chan struct{})}
+	r.memos[key] = result
+	r.order = append(r.order, key)
+	if len(r.order) > memoCacheSize {
+		delete(r.memos, r.order[0])
+		r.order = r.order[1:]
+	}
+
+	go func() {
+		defer close(result.done)
+
+		select {
+		case r.sem <- struct{}{}:
+		case <-ctx.Done():
+			result.err = ctx.Err()
+			return
+		}
+		defer func() { <-r.sem }()
+
+		result.err = r.client.LoadMemoWithContext(ctx, &payment)
+		result.memoType = payment.Memo.Type
+		result.memo = payment.Memo.Value
+	}()
+
+	return result
+}
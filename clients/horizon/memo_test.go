@@ -0,0 +1,121 @@
+package horizon
+
+import (
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stellar/go/support/http/httptest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/context"
+)
+
+var memoPaymentStreamResponse = `event: message
+data: {"id":"1","paging_token":"1","type":"payment","amount":"1.0000000","_links":{"transaction":{"href":"https://localhost/transactions/aaa"}}}
+
+event: message
+data: {"id":"2","paging_token":"2","type":"payment","amount":"2.0000000","_links":{"transaction":{"href":"https://localhost/transactions/aaa"}}}
+
+event: message
+data: {"id":"3","paging_token":"3","type":"payment","amount":"3.0000000","_links":{"transaction":{"href":"https://localhost/transactions/bbb"}}}
+
+`
+
+// mockPaymentStream makes the payments stream of hmock send
+// memoPaymentStreamResponse once, then hang until the request is cancelled.
+func mockPaymentStream(hmock *httptest.Client) {
+	var calls int32
+	hmock.On("GET", "https://localhost/accounts/GABC/payments").Return(func(req *http.Request) (*http.Response, error) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			return httpmock.NewStringResponse(200, memoPaymentStreamResponse), nil
+		}
+		<-req.Context().Done()
+		return nil, req.Context().Err()
+	})
+}
+
+func TestStreamPaymentsWithOptions_ResolveMemos(t *testing.T) {
+	hmock := httptest.NewClient()
+	client := &Client{URL: "https://localhost", HTTP: hmock}
+	mockPaymentStream(hmock)
+
+	var loads int32
+	memo := func(value string) httpmock.Responder {
+		return func(*http.Request) (*http.Response, error) {
+			atomic.AddInt32(&loads, 1)
+			return httpmock.NewStringResponse(200, `{"memo_type":"text","memo":"`+value+`"}`), nil
+		}
+	}
+	hmock.On("GET", "https://localhost/transactions/aaa").Return(memo("first"))
+	hmock.On("GET", "https://localhost/transactions/bbb").Return(memo("second"))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var payments []Payment
+	opts := PaymentStreamOptions{ResolveMemos: true, MemoConcurrency: 2}
+	err := client.StreamPaymentsWithOptions(ctx, "GABC", nil, opts, func(p Payment) {
+		payments = append(payments, p)
+		if len(payments) == 3 {
+			cancel()
+		}
+	})
+
+	require.NoError(t, err)
+	require.Len(t, payments, 3)
+	for i, value := range []string{"first", "first", "second"} {
+		assert.Equal(t, "text", payments[i].Memo.Type)
+		assert.Equal(t, value, payments[i].Memo.Value)
+	}
+	assert.Equal(t, []string{"1", "2", "3"}, []string{payments[0].ID, payments[1].ID, payments[2].ID})
+	assert.Equal(t, int32(2), atomic.LoadInt32(&loads), "each memo is loaded once")
+}
+
+func TestStreamPaymentsWithOptions_MemoError(t *testing.T) {
+	hmock := httptest.NewClient()
+	client := &Client{URL: "https://localhost", HTTP: hmock}
+	mockPaymentStream(hmock)
+
+	hmock.On("GET", "https://localhost/transactions/aaa").
+		ReturnString(200, `{"memo_type":"text","memo":"first"}`)
+	hmock.On("GET", "https://localhost/transactions/bbb").
+		Return(func(*http.Request) (*http.Response, error) {
+			return nil, errors.New("boom")
+		})
+
+	var payments []Payment
+	opts := PaymentStreamOptions{ResolveMemos: true}
+	err := client.StreamPaymentsWithOptions(context.Background(), "GABC", nil, opts, func(p Payment) {
+		payments = append(payments, p)
+	})
+
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "load memo failed")
+	}
+	assert.Len(t, payments, 2)
+}
+
+func TestLoadMemo(t *testing.T) {
+	hmock := httptest.NewClient()
+	client := &Client{URL: "https://localhost", HTTP: hmock}
+
+	var p Payment
+	p.Links.Transaction.Href = "https://localhost/transactions/aaa"
+
+	hmock.On("GET", "https://localhost/transactions/aaa").ReturnString(200, `{"memo_type":"text","memo":"hello"}`)
+	require.NoError(t, client.LoadMemo(&p))
+	assert.Equal(t, "text", p.Memo.Type)
+	assert.Equal(t, "hello", p.Memo.Value)
+
+	// error responses are reported instead of being decoded into the memo
+	p = Payment{}
+	p.Links.Transaction.Href = "https://localhost/transactions/bbb"
+	hmock.On("GET", "https://localhost/transactions/bbb").ReturnString(404, notFoundResponse)
+	err := client.LoadMemo(&p)
+	_, ok := err.(*Error)
+	assert.True(t, ok, "expected a horizon.Error, got %v", err)
+	assert.Equal(t, "", p.Memo.Type)
+}
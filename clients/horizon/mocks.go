@@ -1,6 +1,8 @@
 package horizon
 
 import (
+	"github.com/stellar/go/build"
+	"github.com/stellar/go/xdr"
 	"github.com/stretchr/testify/mock"
 	"golang.org/x/net/context"
 )
@@ -16,20 +18,96 @@ func (m *MockClient) Root() (Root, error) {
 	return a.Get(0).(Root), a.Error(1)
 }
 
+// RootWithContext is a mocking a method
+func (m *MockClient) RootWithContext(ctx context.Context) (Root, error) {
+	a := m.Called(ctx)
+	return a.Get(0).(Root), a.Error(1)
+}
+
+// ServerInfo is a mocking a method
+func (m *MockClient) ServerInfo(ctx context.Context) (Root, error) {
+	a := m.Called(ctx)
+	return a.Get(0).(Root), a.Error(1)
+}
+
+// NetworkPassphrase is a mocking a method
+func (m *MockClient) NetworkPassphrase() (string, error) {
+	a := m.Called()
+	return a.Get(0).(string), a.Error(1)
+}
+
+// NetworkPassphraseWithContext is a mocking a method
+func (m *MockClient) NetworkPassphraseWithContext(ctx context.Context) (string, error) {
+	a := m.Called(ctx)
+	return a.Get(0).(string), a.Error(1)
+}
+
 // HomeDomainForAccount is a mocking a method
 func (m *MockClient) HomeDomainForAccount(aid string) (string, error) {
 	a := m.Called(aid)
 	return a.Get(0).(string), a.Error(1)
 }
 
+// HomeDomainForAccountWithContext is a mocking a method
+func (m *MockClient) HomeDomainForAccountWithContext(ctx context.Context, aid string) (string, error) {
+	a := m.Called(ctx, aid)
+	return a.Get(0).(string), a.Error(1)
+}
+
 // LoadAccount is a mocking a method
 func (m *MockClient) LoadAccount(accountID string) (Account, error) {
 	a := m.Called(accountID)
 	return a.Get(0).(Account), a.Error(1)
 }
 
+// LoadAccountWithContext is a mocking a method
+func (m *MockClient) LoadAccountWithContext(ctx context.Context, accountID string) (Account, error) {
+	a := m.Called(ctx, accountID)
+	return a.Get(0).(Account), a.Error(1)
+}
+
+// LoadAccounts is a mocking a method
+func (m *MockClient) LoadAccounts(accountIDs []string) []AccountResult {
+	a := m.Called(accountIDs)
+	results, _ := a.Get(0).([]AccountResult)
+	return results
+}
+
+// LoadAccountsWithContext is a mocking a method
+func (m *MockClient) LoadAccountsWithContext(ctx context.Context, accountIDs []string) []AccountResult {
+	a := m.Called(ctx, accountIDs)
+	results, _ := a.Get(0).([]AccountResult)
+	return results
+}
+
+// LoadAccountData is a mocking a method
+func (m *MockClient) LoadAccountData(accountID string, key string) (AccountData, error) {
+	a := m.Called(accountID, key)
+	return a.Get(0).(AccountData), a.Error(1)
+}
+
+// LoadAccountDataWithContext is a mocking a method
+func (m *MockClient) LoadAccountDataWithContext(ctx context.Context, accountID string, key string) (AccountData, error) {
+	a := m.Called(ctx, accountID, key)
+	return a.Get(0).(AccountData), a.Error(1)
+}
+
+// LoadAccountDataEntries is a mocking a method
+func (m *MockClient) LoadAccountDataEntries(accountID string) (map[string][]byte, error) {
+	a := m.Called(accountID)
+	entries, _ := a.Get(0).(map[string][]byte)
+	return entries, a.Error(1)
+}
+
+// LoadAccountDataEntriesWithContext is a mocking a method
+func (m *MockClient) LoadAccountDataEntriesWithContext(ctx context.Context, accountID string) (map[string][]byte, error) {
+	a := m.Called(ctx, accountID)
+	entries, _ := a.Get(0).(map[string][]byte)
+	return entries, a.Error(1)
+}
+
 // LoadAccountOffers is a mocking a method
-func (m *MockClient) LoadAccountOffers(accountID string, params ...interface{}) (offers OffersPage, err error) {
+func (m *MockClient) LoadAccountOffers(accountID string, params ...Param) (offers OffersPage, err error) {
 	// There is no way to simply call:
 	//
 	// a := m.Called(accountID, params...)
@@ -43,30 +121,292 @@ func (m *MockClient) LoadAccountOffers(accountID string, params ...interface{})
 	return a.Get(0).(OffersPage), a.Error(1)
 }
 
+// LoadAccountOffersWithContext is a mocking a method
+func (m *MockClient) LoadAccountOffersWithContext(ctx context.Context, accountID string, params ...Param) (offers OffersPage, err error) {
+	args := []interface{}{ctx, accountID}
+	for _, param := range params {
+		args = append(args, param)
+	}
+	a := m.Called(args...)
+	return a.Get(0).(OffersPage), a.Error(1)
+}
+
+// LoadTransaction is a mocking a method
+func (m *MockClient) LoadTransaction(transactionHash string) (Transaction, error) {
+	a := m.Called(transactionHash)
+	return a.Get(0).(Transaction), a.Error(1)
+}
+
+// LoadTransactionWithContext is a mocking a method
+func (m *MockClient) LoadTransactionWithContext(ctx context.Context, transactionHash string) (Transaction, error) {
+	a := m.Called(ctx, transactionHash)
+	return a.Get(0).(Transaction), a.Error(1)
+}
+
+// LoadOperation is a mocking a method
+func (m *MockClient) LoadOperation(operationID string) (Operation, error) {
+	a := m.Called(operationID)
+	op, _ := a.Get(0).(Operation)
+	return op, a.Error(1)
+}
+
+// LoadOperationWithContext is a mocking a method
+func (m *MockClient) LoadOperationWithContext(ctx context.Context, operationID string) (Operation, error) {
+	a := m.Called(ctx, operationID)
+	op, _ := a.Get(0).(Operation)
+	return op, a.Error(1)
+}
+
+// LoadAssets is a mocking a method
+func (m *MockClient) LoadAssets(params ...Param) (AssetsPage, error) {
+	a := m.Called(params)
+	return a.Get(0).(AssetsPage), a.Error(1)
+}
+
+// LoadAssetsWithContext is a mocking a method
+func (m *MockClient) LoadAssetsWithContext(ctx context.Context, params ...Param) (AssetsPage, error) {
+	a := m.Called(ctx, params)
+	return a.Get(0).(AssetsPage), a.Error(1)
+}
+
+// LoadFeeStats is a mocking a method
+func (m *MockClient) LoadFeeStats() (FeeStats, error) {
+	a := m.Called()
+	return a.Get(0).(FeeStats), a.Error(1)
+}
+
+// LoadFeeStatsWithContext is a mocking a method
+func (m *MockClient) LoadFeeStatsWithContext(ctx context.Context) (FeeStats, error) {
+	a := m.Called(ctx)
+	return a.Get(0).(FeeStats), a.Error(1)
+}
+
+// LoadLedger is a mocking a method
+func (m *MockClient) LoadLedger(sequence int32) (Ledger, error) {
+	a := m.Called(sequence)
+	return a.Get(0).(Ledger), a.Error(1)
+}
+
+// LoadLedgerWithContext is a mocking a method
+func (m *MockClient) LoadLedgerWithContext(ctx context.Context, sequence int32) (Ledger, error) {
+	a := m.Called(ctx, sequence)
+	return a.Get(0).(Ledger), a.Error(1)
+}
+
+// LoadLedgers is a mocking a method
+func (m *MockClient) LoadLedgers(params ...Param) (LedgersPage, error) {
+	a := m.Called(params)
+	return a.Get(0).(LedgersPage), a.Error(1)
+}
+
+// LoadLedgersWithContext is a mocking a method
+func (m *MockClient) LoadLedgersWithContext(ctx context.Context, params ...Param) (LedgersPage, error) {
+	a := m.Called(ctx, params)
+	return a.Get(0).(LedgersPage), a.Error(1)
+}
+
+// LoadLedgerTransactions is a mocking a method
+func (m *MockClient) LoadLedgerTransactions(sequence int32, params ...Param) (TransactionsPage, error) {
+	a := m.Called(sequence, params)
+	return a.Get(0).(TransactionsPage), a.Error(1)
+}
+
+// LoadLedgerTransactionsWithContext is a mocking a method
+func (m *MockClient) LoadLedgerTransactionsWithContext(ctx context.Context, sequence int32, params ...Param) (TransactionsPage, error) {
+	a := m.Called(ctx, sequence, params)
+	return a.Get(0).(TransactionsPage), a.Error(1)
+}
+
+// LoadLedgerOperations is a mocking a method
+func (m *MockClient) LoadLedgerOperations(sequence int32, params ...Param) (OperationsPage, error) {
+	a := m.Called(sequence, params)
+	return a.Get(0).(OperationsPage), a.Error(1)
+}
+
+// LoadLedgerOperationsWithContext is a mocking a method
+func (m *MockClient) LoadLedgerOperationsWithContext(ctx context.Context, sequence int32, params ...Param) (OperationsPage, error) {
+	a := m.Called(ctx, sequence, params)
+	return a.Get(0).(OperationsPage), a.Error(1)
+}
+
+// LoadLedgerPayments is a mocking a method
+func (m *MockClient) LoadLedgerPayments(sequence int32, params ...Param) (PaymentsPage, error) {
+	a := m.Called(sequence, params)
+	return a.Get(0).(PaymentsPage), a.Error(1)
+}
+
+// LoadLedgerPaymentsWithContext is a mocking a method
+func (m *MockClient) LoadLedgerPaymentsWithContext(ctx context.Context, sequence int32, params ...Param) (PaymentsPage, error) {
+	a := m.Called(ctx, sequence, params)
+	return a.Get(0).(PaymentsPage), a.Error(1)
+}
+
+// LoadOffer is a mocking a method
+func (m *MockClient) LoadOffer(offerID int64) (Offer, error) {
+	a := m.Called(offerID)
+	return a.Get(0).(Offer), a.Error(1)
+}
+
+// LoadOfferWithContext is a mocking a method
+func (m *MockClient) LoadOfferWithContext(ctx context.Context, offerID int64) (Offer, error) {
+	a := m.Called(ctx, offerID)
+	return a.Get(0).(Offer), a.Error(1)
+}
+
+// LoadTradesForOffer is a mocking a method
+func (m *MockClient) LoadTradesForOffer(offerID int64, params ...Param) (TradesPage, error) {
+	a := m.Called(offerID, params)
+	return a.Get(0).(TradesPage), a.Error(1)
+}
+
+// LoadTradesForOfferWithContext is a mocking a method
+func (m *MockClient) LoadTradesForOfferWithContext(ctx context.Context, offerID int64, params ...Param) (TradesPage, error) {
+	a := m.Called(ctx, offerID, params)
+	return a.Get(0).(TradesPage), a.Error(1)
+}
+
+// LoadMetrics is a mocking a method
+func (m *MockClient) LoadMetrics() (Metrics, error) {
+	a := m.Called()
+	return a.Get(0).(Metrics), a.Error(1)
+}
+
+// LoadMetricsWithContext is a mocking a method
+func (m *MockClient) LoadMetricsWithContext(ctx context.Context) (Metrics, error) {
+	a := m.Called(ctx)
+	return a.Get(0).(Metrics), a.Error(1)
+}
+
+// LoadIngestionStatus is a mocking a method
+func (m *MockClient) LoadIngestionStatus() (IngestionStatus, error) {
+	a := m.Called()
+	return a.Get(0).(IngestionStatus), a.Error(1)
+}
+
+// LoadIngestionStatusWithContext is a mocking a method
+func (m *MockClient) LoadIngestionStatusWithContext(ctx context.Context) (IngestionStatus, error) {
+	a := m.Called(ctx)
+	return a.Get(0).(IngestionStatus), a.Error(1)
+}
+
 // LoadMemo is a mocking a method
 func (m *MockClient) LoadMemo(p *Payment) error {
 	a := m.Called(p)
 	return a.Error(0)
 }
 
+// LoadMemoWithContext is a mocking a method
+func (m *MockClient) LoadMemoWithContext(ctx context.Context, p *Payment) error {
+	a := m.Called(ctx, p)
+	return a.Error(0)
+}
+
+// SequenceForAccount is a mocking a method
+func (m *MockClient) SequenceForAccount(accountID string) (xdr.SequenceNumber, error) {
+	a := m.Called(accountID)
+	return a.Get(0).(xdr.SequenceNumber), a.Error(1)
+}
+
+// SequenceForAccountWithContext is a mocking a method
+func (m *MockClient) SequenceForAccountWithContext(ctx context.Context, accountID string) (xdr.SequenceNumber, error) {
+	a := m.Called(ctx, accountID)
+	return a.Get(0).(xdr.SequenceNumber), a.Error(1)
+}
+
+// SignersForAccount is a mocking a method
+func (m *MockClient) SignersForAccount(accountID string) (build.AccountSigners, error) {
+	a := m.Called(accountID)
+	return a.Get(0).(build.AccountSigners), a.Error(1)
+}
+
+// SignersForAccountWithContext is a mocking a method
+func (m *MockClient) SignersForAccountWithContext(ctx context.Context, accountID string) (build.AccountSigners, error) {
+	a := m.Called(ctx, accountID)
+	return a.Get(0).(build.AccountSigners), a.Error(1)
+}
+
+// BaseFee is a mocking a method
+func (m *MockClient) BaseFee() (uint64, error) {
+	a := m.Called()
+	return a.Get(0).(uint64), a.Error(1)
+}
+
+// BaseFeeWithContext is a mocking a method
+func (m *MockClient) BaseFeeWithContext(ctx context.Context) (uint64, error) {
+	a := m.Called(ctx)
+	return a.Get(0).(uint64), a.Error(1)
+}
+
 // LoadOrderBook is a mocking a method
-func (m *MockClient) LoadOrderBook(selling Asset, buying Asset, params ...interface{}) (orderBook OrderBookSummary, err error) {
+func (m *MockClient) LoadOrderBook(selling Asset, buying Asset, params ...Param) (orderBook OrderBookSummary, err error) {
 	a := m.Called(selling, buying, params)
 	return a.Get(0).(OrderBookSummary), a.Error(1)
 }
 
+// LoadOrderBookWithContext is a mocking a method
+func (m *MockClient) LoadOrderBookWithContext(ctx context.Context, selling Asset, buying Asset, params ...Param) (orderBook OrderBookSummary, err error) {
+	a := m.Called(ctx, selling, buying, params)
+	return a.Get(0).(OrderBookSummary), a.Error(1)
+}
+
+// Transactions is a mocking a method
+func (m *MockClient) Transactions(ctx context.Context, params ...Param) Iterator {
+	a := m.Called(ctx, params)
+	it, _ := a.Get(0).(Iterator)
+	return it
+}
+
+// Operations is a mocking a method
+func (m *MockClient) Operations(ctx context.Context, params ...Param) Iterator {
+	a := m.Called(ctx, params)
+	it, _ := a.Get(0).(Iterator)
+	return it
+}
+
+// Effects is a mocking a method
+func (m *MockClient) Effects(ctx context.Context, params ...Param) Iterator {
+	a := m.Called(ctx, params)
+	it, _ := a.Get(0).(Iterator)
+	return it
+}
+
+// Payments is a mocking a method
+func (m *MockClient) Payments(ctx context.Context, params ...Param) Iterator {
+	a := m.Called(ctx, params)
+	it, _ := a.Get(0).(Iterator)
+	return it
+}
+
+// StreamRaw is a mocking a method
+func (m *MockClient) StreamRaw(ctx context.Context, path string, cursor *Cursor, handler EventHandler) error {
+	a := m.Called(ctx, path, cursor, handler)
+	return a.Error(0)
+}
+
 // StreamLedgers is a mocking a method
 func (m *MockClient) StreamLedgers(ctx context.Context, cursor *Cursor, handler LedgerHandler) error {
 	a := m.Called(ctx, cursor, handler)
 	return a.Error(0)
 }
 
+// StreamOrderBook is a mocking a method
+func (m *MockClient) StreamOrderBook(ctx context.Context, selling Asset, buying Asset, handler OrderBookHandler) error {
+	a := m.Called(ctx, selling, buying, handler)
+	return a.Error(0)
+}
+
 // StreamPayments is a mocking a method
 func (m *MockClient) StreamPayments(ctx context.Context, accountID string, cursor *Cursor, handler PaymentHandler) error {
 	a := m.Called(ctx, accountID, cursor, handler)
 	return a.Error(0)
 }
 
+// StreamPaymentsWithOptions is a mocking a method
+func (m *MockClient) StreamPaymentsWithOptions(ctx context.Context, accountID string, cursor *Cursor, opts PaymentStreamOptions, handler PaymentHandler) error {
+	a := m.Called(ctx, accountID, cursor, opts, handler)
+	return a.Error(0)
+}
+
 // StreamTransactions is a mocking a method
 func (m *MockClient) StreamTransactions(ctx context.Context, accountID string, cursor *Cursor, handler TransactionHandler) error {
 	a := m.Called(ctx, accountID, cursor, handler)
@@ -79,5 +419,17 @@ func (m *MockClient) SubmitTransaction(txeBase64 string) (TransactionSuccess, er
 	return a.Get(0).(TransactionSuccess), a.Error(1)
 }
 
+// SubmitTransactionWithContext is a mocking a method
+func (m *MockClient) SubmitTransactionWithContext(ctx context.Context, txeBase64 string) (TransactionSuccess, error) {
+	a := m.Called(ctx, txeBase64)
+	return a.Get(0).(TransactionSuccess), a.Error(1)
+}
+
+// SubmitTransactionWithOptions is a mocking a method
+func (m *MockClient) SubmitTransactionWithOptions(ctx context.Context, txeBase64 string, opts SubmitOptions) (TransactionSuccess, error) {
+	a := m.Called(ctx, txeBase64, opts)
+	return a.Get(0).(TransactionSuccess), a.Error(1)
+}
+
 // ensure that the MockClient implements ClientInterface
 var _ ClientInterface = &MockClient{}
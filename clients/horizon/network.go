@@ -0,0 +1,100 @@
+package horizon
+
+import (
+	"github.com/stellar/go/keypair"
+	"github.com/stellar/go/network"
+	"github.com/stellar/go/support/errors"
+	"github.com/stellar/go/xdr"
+	"golang.org/x/net/context"
+)
+
+// ErrWrongNetwork is the error returned when submitting a transaction that
+// was signed for a different network than the one of the horizon server,
+// when Client.VerifyNetwork is set.
+var ErrWrongNetwork = errors.New("transaction was not signed for the network of the horizon server")
+
+// ServerInfo returns the root resource of the horizon server, describing its
+// network and versions.  It is loaded once and reused for the lifetime of
+// the client.
+func (c *Client) ServerInfo(ctx context.Context) (Root, error) {
+	c.rootMutex.Lock()
+	defer c.rootMutex.Unlock()
+
+	if c.root != nil {
+		return *c.root, nil
+	}
+
+	root, err := c.RootWithContext(ctx)
+	if err != nil {
+		return Root{}, errors.Wrap(err, "load root failed")
+	}
+
+	c.root = &root
+	return root, nil
+}
+
+// NetworkPassphrase returns the passphrase of the network the horizon server
+// is connected to.
+func (c *Client) NetworkPassphrase() (string, error) {
+	return c.NetworkPassphraseWithContext(context.Background())
+}
+
+// NetworkPassphraseWithContext is like NetworkPassphrase but uses ctx for the
+// underlying request, if any.
+func (c *Client) NetworkPassphraseWithContext(ctx context.Context) (string, error) {
+	root, err := c.ServerInfo(ctx)
+	if err != nil {
+		return "", err
+	}
+	return root.NetworkPassphrase, nil
+}
+
+// verifyNetwork checks that the signatures of the transaction made by its
+// source accounts are valid for the network of the horizon server, returning
+// ErrWrongNetwork otherwise.  Signatures made by other signers cannot be
+// attributed to a key, so they are not checked.
+func (c *Client) verifyNetwork(ctx context.Context, transactionEnvelopeXdr string) error {
+	passphrase, err := c.NetworkPassphraseWithContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	var envelope xdr.TransactionEnvelope
+	err = xdr.SafeUnmarshalBase64(transactionEnvelopeXdr, &envelope)
+	if err != nil {
+		return errors.Wrap(err, "decode envelope failed")
+	}
+
+	hash, err := network.HashTransaction(&envelope.Tx, passphrase)
+	if err != nil {
+		return errors.Wrap(err, "hash transaction failed")
+	}
+
+	var keys []keypair.KP
+	addKey := func(aid xdr.AccountId) {
+		kp, err := keypair.Parse(aid.Address())
+		if err == nil {
+			keys = append(keys, kp)
+		}
+	}
+
+	addKey(envelope.Tx.SourceAccount)
+	for _, op := range envelope.Tx.Operations {
+		if op.SourceAccount != nil {
+			addKey(*op.SourceAccount)
+		}
+	}
+
+	for _, sig := range envelope.Signatures {
+		for _, kp := range keys {
+			if kp.Hint() != [4]byte(sig.Hint) {
+				continue
+			}
+			if kp.Verify(hash[:], sig.Signature) != nil {
+				return ErrWrongNetwork
+			}
+		}
+	}
+
+	return nil
+}
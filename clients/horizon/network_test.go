@@ -0,0 +1,70 @@
+package horizon
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stellar/go/build"
+	"github.com/stellar/go/keypair"
+	"github.com/stellar/go/network"
+	"github.com/stellar/go/support/http/httptest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClient_NetworkPassphrase(t *testing.T) {
+	hmock := httptest.NewClient()
+	client := &Client{URL: "https://localhost", HTTP: hmock}
+
+	calls := 0
+	hmock.On("GET", "https://localhost").Return(func(req *http.Request) (*http.Response, error) {
+		calls++
+		return httpmock.NewStringResponse(http.StatusOK, `{
+			"horizon_version": "0.12.0",
+			"core_version": "stellar-core 9.1.0",
+			"network_passphrase": "Test SDF Network ; September 2015"
+		}`), nil
+	})
+
+	for i := 0; i < 2; i++ {
+		passphrase, err := client.NetworkPassphrase()
+		require.NoError(t, err)
+		assert.Equal(t, network.TestNetworkPassphrase, passphrase)
+	}
+	assert.Equal(t, 1, calls)
+}
+
+func TestClient_VerifyNetwork(t *testing.T) {
+	kp, err := keypair.Random()
+	require.NoError(t, err)
+
+	signedFor := func(n build.Network) string {
+		tx, err := build.Transaction(
+			build.SourceAccount{AddressOrSeed: kp.Address()},
+			build.Sequence{Sequence: 1},
+			n,
+			build.Inflation(),
+		)
+		require.NoError(t, err)
+
+		txe, err := tx.Sign(kp.Seed())
+		require.NoError(t, err)
+
+		b64, err := txe.Base64()
+		require.NoError(t, err)
+		return b64
+	}
+
+	hmock := httptest.NewClient()
+	client := &Client{URL: "https://localhost", HTTP: hmock, VerifyNetwork: true}
+	hmock.On("GET", "https://localhost").
+		ReturnString(200, `{"network_passphrase": "Test SDF Network ; September 2015"}`)
+	hmock.On("POST", "https://localhost/transactions").ReturnString(200, submitResponse)
+
+	_, err = client.SubmitTransaction(signedFor(build.TestNetwork))
+	assert.NoError(t, err)
+
+	_, err = client.SubmitTransaction(signedFor(build.PublicNetwork))
+	assert.Equal(t, ErrWrongNetwork, err)
+}
@@ -0,0 +1,306 @@
+package horizon
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/stellar/go/support/errors"
+)
+
+// Operation is implemented by all the operation resources returned by
+// horizon.  Use a type switch to access the fields of a specific operation
+// type:
+//
+//	switch op := op.(type) {
+//	case PaymentOperation:
+//	  ...
+//	}
+type Operation interface {
+	GetBase() BaseOperation
+}
+
+// BaseOperation represents the common attributes of an operation resource.
+type BaseOperation struct {
+	Links struct {
+		Self        Link `json:"self"`
+		Transaction Link `json:"transaction"`
+		Effects     Link `json:"effects"`
+		Succeeds    Link `json:"succeeds"`
+		Precedes    Link `json:"precedes"`
+	} `json:"_links"`
+
+	ID              string    `json:"id"`
+	PT              string    `json:"paging_token"`
+	SourceAccount   string    `json:"source_account"`
+	Type            string    `json:"type"`
+	TypeI           int32     `json:"type_i"`
+	LedgerCloseTime time.Time `json:"created_at"`
+	TransactionHash string    `json:"transaction_hash"`
+}
+
+// GetBase implements Operation
+func (op BaseOperation) GetBase() BaseOperation {
+	return op
+}
+
+// CreateAccountOperation is the resource representing a create_account
+// operation.
+type CreateAccountOperation struct {
+	BaseOperation
+	StartingBalance string `json:"starting_balance"`
+	Funder          string `json:"funder"`
+	Account         string `json:"account"`
+}
+
+// PaymentOperation is the resource representing a payment operation.
+type PaymentOperation struct {
+	BaseOperation
+	Asset
+	From   string `json:"from"`
+	To     string `json:"to"`
+	Amount string `json:"amount"`
+}
+
+// PathPaymentOperation is the resource representing a path_payment operation.
+type PathPaymentOperation struct {
+	PaymentOperation
+	Path              []Asset `json:"path"`
+	SourceMax         string  `json:"source_max"`
+	SourceAssetType   string  `json:"source_asset_type"`
+	SourceAssetCode   string  `json:"source_asset_code,omitempty"`
+	SourceAssetIssuer string  `json:"source_asset_issuer,omitempty"`
+}
+
+// SourceAsset returns the asset sent by the source of the path payment.
+func (op PathPaymentOperation) SourceAsset() Asset {
+	return Asset{Type: op.SourceAssetType, Code: op.SourceAssetCode, Issuer: op.SourceAssetIssuer}
+}
+
+// CreatePassiveOfferOperation is the resource representing a
+// create_passive_offer operation.
+type CreatePassiveOfferOperation struct {
+	BaseOperation
+	Amount             string `json:"amount"`
+	Price              string `json:"price"`
+	PriceR             Price  `json:"price_r"`
+	BuyingAssetType    string `json:"buying_asset_type"`
+	BuyingAssetCode    string `json:"buying_asset_code,omitempty"`
+	BuyingAssetIssuer  string `json:"buying_asset_issuer,omitempty"`
+	SellingAssetType   string `json:"selling_asset_type"`
+	SellingAssetCode   string `json:"selling_asset_code,omitempty"`
+	SellingAssetIssuer string `json:"selling_asset_issuer,omitempty"`
+}
+
+// SellingAsset returns the asset sold by the offer.
+func (op CreatePassiveOfferOperation) SellingAsset() Asset {
+	return Asset{Type: op.SellingAssetType, Code: op.SellingAssetCode, Issuer: op.SellingAssetIssuer}
+}
+
+// BuyingAsset returns the asset bought by the offer.
+func (op CreatePassiveOfferOperation) BuyingAsset() Asset {
+	return Asset{Type: op.BuyingAssetType, Code: op.BuyingAssetCode, Issuer: op.BuyingAssetIssuer}
+}
+
+// ManageOfferOperation is the resource representing a manage_offer operation.
+type ManageOfferOperation struct {
+	CreatePassiveOfferOperation
+	OfferID int64 `json:"offer_id"`
+}
+
+// SetOptionsOperation is the resource representing a set_options operation.
+type SetOptionsOperation struct {
+	BaseOperation
+	HomeDomain    string `json:"home_domain,omitempty"`
+	InflationDest string `json:"inflation_dest,omitempty"`
+
+	MasterKeyWeight *int   `json:"master_key_weight,omitempty"`
+	SignerKey       string `json:"signer_key,omitempty"`
+	SignerWeight    *int   `json:"signer_weight,omitempty"`
+
+	SetFlags    []int    `json:"set_flags,omitempty"`
+	SetFlagsS   []string `json:"set_flags_s,omitempty"`
+	ClearFlags  []int    `json:"clear_flags,omitempty"`
+	ClearFlagsS []string `json:"clear_flags_s,omitempty"`
+
+	LowThreshold  *int `json:"low_threshold,omitempty"`
+	MedThreshold  *int `json:"med_threshold,omitempty"`
+	HighThreshold *int `json:"high_threshold,omitempty"`
+}
+
+// ChangeTrustOperation is the resource representing a change_trust operation.
+type ChangeTrustOperation struct {
+	BaseOperation
+	Asset
+	Limit   string `json:"limit"`
+	Trustee string `json:"trustee"`
+	Trustor string `json:"trustor"`
+}
+
+// AllowTrustOperation is the resource representing an allow_trust operation.
+type AllowTrustOperation struct {
+	BaseOperation
+	Asset
+	Trustee   string `json:"trustee"`
+	Trustor   string `json:"trustor"`
+	Authorize bool   `json:"authorize"`
+}
+
+// AccountMergeOperation is the resource representing an account_merge
+// operation.
+type AccountMergeOperation struct {
+	BaseOperation
+	Account string `json:"account"`
+	Into    string `json:"into"`
+}
+
+// InflationOperation is the resource representing an inflation operation.
+type InflationOperation struct {
+	BaseOperation
+}
+
+// ManageDataOperation is the resource representing a manage_data operation.
+type ManageDataOperation struct {
+	BaseOperation
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// BumpSequenceOperation is the resource representing a bump_sequence
+// operation.
+type BumpSequenceOperation struct {
+	BaseOperation
+	BumpTo string `json:"bump_to"`
+}
+
+// GenericOperation is the resource returned for the operation types that are
+// not known to the client, so that new operations added to the network don't
+// break existing applications.  Raw holds the full JSON representation of the
+// operation.
+type GenericOperation struct {
+	BaseOperation
+	Raw json.RawMessage
+}
+
+// OperationsPage is a page of operations.  Its records are decoded into the
+// resource types matching their type_i.
+type OperationsPage struct {
+	Links struct {
+		Self Link `json:"self"`
+		Next Link `json:"next"`
+		Prev Link `json:"prev"`
+	} `json:"_links"`
+	Embedded struct {
+		Records []Operation
+	}
+}
+
+// UnmarshalJSON implements json.Unmarshaler
+func (p *OperationsPage) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		Links    json.RawMessage `json:"_links"`
+		Embedded struct {
+			Records []json.RawMessage `json:"records"`
+		} `json:"_embedded"`
+	}
+
+	err := json.Unmarshal(data, &raw)
+	if err != nil {
+		return err
+	}
+
+	if raw.Links != nil {
+		err = json.Unmarshal(raw.Links, &p.Links)
+		if err != nil {
+			return err
+		}
+	}
+
+	p.Embedded.Records = make([]Operation, 0, len(raw.Embedded.Records))
+	for _, record := range raw.Embedded.Records {
+		var base BaseOperation
+		err = json.Unmarshal(record, &base)
+		if err != nil {
+			return errors.Wrap(err, "Error unmarshaling operation")
+		}
+
+		op, err := UnmarshalOperation(base.TypeI, record)
+		if err != nil {
+			return err
+		}
+
+		p.Embedded.Records = append(p.Embedded.Records, op)
+	}
+
+	return nil
+}
+
+// UnmarshalOperation decodes the JSON representation of an operation into the
+// resource type matching its type_i.  Operations of unknown types are
+// returned as GenericOperation rather than failing.
+func UnmarshalOperation(typeI int32, data []byte) (Operation, error) {
+	var (
+		op  Operation
+		err error
+	)
+
+	switch typeI {
+	case 0:
+		var o CreateAccountOperation
+		err = json.Unmarshal(data, &o)
+		op = o
+	case 1:
+		var o PaymentOperation
+		err = json.Unmarshal(data, &o)
+		op = o
+	case 2:
+		var o PathPaymentOperation
+		err = json.Unmarshal(data, &o)
+		op = o
+	case 3:
+		var o ManageOfferOperation
+		err = json.Unmarshal(data, &o)
+		op = o
+	case 4:
+		var o CreatePassiveOfferOperation
+		err = json.Unmarshal(data, &o)
+		op = o
+	case 5:
+		var o SetOptionsOperation
+		err = json.Unmarshal(data, &o)
+		op = o
+	case 6:
+		var o ChangeTrustOperation
+		err = json.Unmarshal(data, &o)
+		op = o
+	case 7:
+		var o AllowTrustOperation
+		err = json.Unmarshal(data, &o)
+		op = o
+	case 8:
+		var o AccountMergeOperation
+		err = json.Unmarshal(data, &o)
+		op = o
+	case 9:
+		var o InflationOperation
+		err = json.Unmarshal(data, &o)
+		op = o
+	case 10:
+		var o ManageDataOperation
+		err = json.Unmarshal(data, &o)
+		op = o
+	case 11:
+		var o BumpSequenceOperation
+		err = json.Unmarshal(data, &o)
+		op = o
+	default:
+		o := GenericOperation{Raw: append(json.RawMessage(nil), data...)}
+		err = json.Unmarshal(data, &o.BaseOperation)
+		op = o
+	}
+
+	if err != nil {
+		return nil, errors.Wrap(err, "Error unmarshaling operation")
+	}
+
+	return op, nil
+}
@@ -0,0 +1,44 @@
+package horizon
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnmarshalOperation(t *testing.T) {
+	t.Run("bump sequence", func(t *testing.T) {
+		op, err := UnmarshalOperation(11, []byte(`{
+			"id": "1",
+			"type": "bump_sequence",
+			"type_i": 11,
+			"bump_to": "1234567890"
+		}`))
+		require.NoError(t, err)
+
+		bump, ok := op.(BumpSequenceOperation)
+		require.True(t, ok)
+		assert.Equal(t, "1234567890", bump.BumpTo)
+		assert.Equal(t, "bump_sequence", bump.GetBase().Type)
+	})
+
+	t.Run("unknown type", func(t *testing.T) {
+		data := []byte(`{"id": "2", "type": "future_operation", "type_i": 99, "value": "x"}`)
+		op, err := UnmarshalOperation(99, data)
+		require.NoError(t, err)
+
+		generic, ok := op.(GenericOperation)
+		require.True(t, ok)
+		assert.Equal(t, "2", generic.ID)
+		assert.Equal(t, "future_operation", generic.Type)
+		assert.JSONEq(t, string(data), string(generic.Raw))
+	})
+
+	t.Run("invalid json", func(t *testing.T) {
+		_, err := UnmarshalOperation(1, []byte(`{`))
+		assert.Error(t, err)
+		_, err = UnmarshalOperation(99, []byte(`{`))
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,150 @@
+package horizon
+
+import (
+	"encoding/json"
+
+	"golang.org/x/net/context"
+)
+
+// GaugeMetric is the value of a metric reported by horizon that measures a
+// quantity, e.g. a number of open connections.
+type GaugeMetric struct {
+	Value int64 `json:"value"`
+}
+
+// MeterMetric is the value of a metric reported by horizon that counts
+// events, e.g. failed requests, and measures their rate per second.
+type MeterMetric struct {
+	Count    int64   `json:"count"`
+	Rate1m   float64 `json:"1m.rate"`
+	Rate5m   float64 `json:"5m.rate"`
+	Rate15m  float64 `json:"15m.rate"`
+	RateMean float64 `json:"mean.rate"`
+}
+
+// TimerMetric is the value of a metric reported by horizon that measures the
+// duration of events, e.g. requests, as well as their rate.  Durations are in
+// nanoseconds.
+type TimerMetric struct {
+	MeterMetric
+	Min    int64   `json:"min"`
+	Max    int64   `json:"max"`
+	Mean   float64 `json:"mean"`
+	StdDev float64 `json:"stddev"`
+	Median float64 `json:"median"`
+	P75    float64 `json:"75%"`
+	P95    float64 `json:"95%"`
+	P99    float64 `json:"99%"`
+	P999   float64 `json:"99.9%"`
+}
+
+// Metrics is the snapshot of the operational metrics of a horizon server,
+// as served by its /metrics endpoint.  The metrics of components that are not
+// enabled on the server, e.g. the ingester, are zero.
+type Metrics struct {
+	Goroutines GaugeMetric `json:"goroutines"`
+
+	HistoryLatestLedger    GaugeMetric `json:"history.latest_ledger"`
+	HistoryElderLedger     GaugeMetric `json:"history.elder_ledger"`
+	HistoryOpenConnections GaugeMetric `json:"history.open_connections"`
+
+	HistoryIngestLag GaugeMetric `json:"history.ingest_lag"`
+	StreamsOpen      GaugeMetric `json:"streams.open"`
+
+	CoreLatestLedger    GaugeMetric `json:"stellar_core.latest_ledger"`
+	CoreOpenConnections GaugeMetric `json:"stellar_core.open_connections"`
+
+	IngestLedger TimerMetric `json:"ingester.ingest_ledger"`
+	ClearLedger  TimerMetric `json:"ingester.clear_ledger"`
+
+	RequestsTotal     TimerMetric `json:"requests.total"`
+	RequestsSucceeded MeterMetric `json:"requests.succeeded"`
+	RequestsFailed    MeterMetric `json:"requests.failed"`
+
+	TxSubBuffered  GaugeMetric `json:"txsub.buffered"`
+	TxSubOpen      GaugeMetric `json:"txsub.open"`
+	TxSubSucceeded MeterMetric `json:"txsub.succeeded"`
+	TxSubFailed    MeterMetric `json:"txsub.failed"`
+	TxSubTotal     TimerMetric `json:"txsub.total"`
+
+	// Raw holds every metric reported by horizon, keyed by name, including
+	// the ones without a field above such as the logging meters.
+	Raw map[string]json.RawMessage `json:"-"`
+}
+
+// UnmarshalJSON decodes the metrics snapshot, keeping the raw metrics in
+// m.Raw.
+func (m *Metrics) UnmarshalJSON(data []byte) error {
+	type metrics Metrics
+	err := json.Unmarshal(data, (*metrics)(m))
+	if err != nil {
+		return err
+	}
+
+	err = json.Unmarshal(data, &m.Raw)
+	if err != nil {
+		return err
+	}
+	delete(m.Raw, "_links")
+	return nil
+}
+
+// IngestionStatus describes how far the history database of a horizon server
+// lags behind the stellar-core instance it ingests from.
+type IngestionStatus struct {
+	HistoryLatestLedger int32
+	HistoryElderLedger  int32
+	CoreLatestLedger    int32
+	CoreElderLedger     int32
+}
+
+// Lag returns the number of ledgers closed by stellar-core that have not been
+// ingested yet.
+func (s IngestionStatus) Lag() int32 {
+	if s.CoreLatestLedger < s.HistoryLatestLedger {
+		return 0
+	}
+	return s.CoreLatestLedger - s.HistoryLatestLedger
+}
+
+// LoadMetrics loads the operational metrics of the horizon server.  err can
+// be either error object or horizon.Error object, e.g. when the endpoint is
+// not exposed.
+func (c *Client) LoadMetrics() (Metrics, error) {
+	return c.LoadMetricsWithContext(context.Background())
+}
+
+// LoadMetricsWithContext is like LoadMetrics but uses ctx for the underlying
+// request.
+func (c *Client) LoadMetricsWithContext(ctx context.Context) (metrics Metrics, err error) {
+	c.fixURLOnce.Do(c.fixURL)
+	_, err = c.get(ctx, c.URL+"/metrics", &metrics)
+	return
+}
+
+// LoadIngestionStatus loads the ingestion status of the horizon server.  It
+// is always loaded from horizon, bypassing c.Cache, so that monitoring tools
+// see the latest state. err can be either error object or horizon.Error
+// object.
+func (c *Client) LoadIngestionStatus() (IngestionStatus, error) {
+	return c.LoadIngestionStatusWithContext(context.Background())
+}
+
+// LoadIngestionStatusWithContext is like LoadIngestionStatus but uses ctx
+// for the underlying request.
+func (c *Client) LoadIngestionStatusWithContext(ctx context.Context) (IngestionStatus, error) {
+	c.fixURLOnce.Do(c.fixURL)
+
+	var root Root
+	_, err := c.get(ctx, c.URL, &root)
+	if err != nil {
+		return IngestionStatus{}, err
+	}
+
+	return IngestionStatus{
+		HistoryLatestLedger: root.HorizonSequence,
+		HistoryElderLedger:  root.HistoryElderSequence,
+		CoreLatestLedger:    root.CoreSequence,
+		CoreElderLedger:     root.CoreElderSequence,
+	}, nil
+}
@@ -0,0 +1,99 @@
+package horizon
+
+import (
+	"testing"
+
+	"github.com/stellar/go/support/http/httptest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var metricsResponse = `{
+  "_links": {
+    "self": {
+      "href": "/metrics"
+    }
+  },
+  "goroutines": {
+    "value": 42
+  },
+  "history.latest_ledger": {
+    "value": 3128812
+  },
+  "stellar_core.latest_ledger": {
+    "value": 3128815
+  },
+  "logging.error": {
+    "15m.rate": 0,
+    "1m.rate": 0,
+    "5m.rate": 0,
+    "count": 3,
+    "mean.rate": 0.001
+  },
+  "requests.failed": {
+    "15m.rate": 0.5,
+    "1m.rate": 0.25,
+    "5m.rate": 0.75,
+    "count": 12,
+    "mean.rate": 0.1
+  },
+  "requests.total": {
+    "15m.rate": 10.5,
+    "1m.rate": 12.25,
+    "5m.rate": 11,
+    "75%": 4500000,
+    "95%": 9000000,
+    "99%": 20000000,
+    "99.9%": 40000000,
+    "count": 1500,
+    "max": 50000000,
+    "mean": 3000000.5,
+    "mean.rate": 10,
+    "median": 2500000,
+    "min": 100000,
+    "stddev": 1000000
+  }
+}`
+
+func TestClient_LoadMetrics(t *testing.T) {
+	hmock := httptest.NewClient()
+	client := &Client{URL: "https://localhost", HTTP: hmock}
+	hmock.On("GET", "https://localhost/metrics").ReturnString(200, metricsResponse)
+
+	metrics, err := client.LoadMetrics()
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(42), metrics.Goroutines.Value)
+	assert.Equal(t, int64(3128812), metrics.HistoryLatestLedger.Value)
+	assert.Equal(t, int64(3128815), metrics.CoreLatestLedger.Value)
+	assert.Equal(t, int64(12), metrics.RequestsFailed.Count)
+	assert.Equal(t, 0.25, metrics.RequestsFailed.Rate1m)
+	assert.Equal(t, int64(1500), metrics.RequestsTotal.Count)
+	assert.Equal(t, int64(50000000), metrics.RequestsTotal.Max)
+	assert.Equal(t, float64(20000000), metrics.RequestsTotal.P99)
+	assert.Equal(t, int64(0), metrics.IngestLedger.Count)
+
+	assert.Contains(t, metrics.Raw, "logging.error")
+	assert.NotContains(t, metrics.Raw, "_links")
+}
+
+func TestClient_LoadIngestionStatus(t *testing.T) {
+	hmock := httptest.NewClient()
+	client := &Client{URL: "https://localhost", HTTP: hmock}
+	hmock.On("GET", "https://localhost").ReturnString(200, `{
+		"history_latest_ledger": 3128812,
+		"history_elder_ledger": 1,
+		"core_latest_ledger": 3128815,
+		"core_elder_ledger": 1
+	}`)
+
+	status, err := client.LoadIngestionStatus()
+	require.NoError(t, err)
+	assert.Equal(t, int32(3128812), status.HistoryLatestLedger)
+	assert.Equal(t, int32(3), status.Lag())
+
+	hmock.On("GET", "https://localhost").ReturnString(503, notFoundResponse)
+	_, err = client.LoadIngestionStatus()
+	_, ok := err.(*Error)
+	assert.True(t, ok)
+}
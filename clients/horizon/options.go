@@ -0,0 +1,158 @@
+package horizon
+
+import (
+	"crypto/tls"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+
+	"golang.org/x/net/http2"
+)
+
+// ClientOption configures a Client created with NewClient.
+type ClientOption func(*clientOptions)
+
+// clientOptions holds the settings used by NewClient.
+type clientOptions struct {
+	keepAlive           time.Duration
+	maxIdleConns        int
+	maxIdleConnsPerHost int
+	idleConnTimeout     time.Duration
+	tlsConfig           *tls.Config
+	proxy               func(*http.Request) (*url.URL, error)
+	http1Only           bool
+	verifyNetwork       bool
+	preflight           *PreflightPolicy
+	disableCompression  bool
+}
+
+// NewClient returns a Client connected to the horizon server at url.  Its
+// http transport keeps connections alive and reuses them across requests,
+// uses the proxy configured in the environment, and negotiates HTTP/2 with
+// servers that support it.  Use opts to tune these defaults.
+func NewClient(url string, opts ...ClientOption) *Client {
+	o := clientOptions{
+		keepAlive:           30 * time.Second,
+		maxIdleConns:        100,
+		maxIdleConnsPerHost: 16,
+		idleConnTimeout:     90 * time.Second,
+		proxy:               http.ProxyFromEnvironment,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	return &Client{
+		URL:                url,
+		HTTP:               &http.Client{Transport: o.transport()},
+		VerifyNetwork:      o.verifyNetwork,
+		Preflight:          o.preflight,
+		DisableCompression: o.disableCompression,
+	}
+}
+
+// WithKeepAlive sets the interval between TCP keep-alive probes of the
+// connections to horizon.  A negative value disables them.
+func WithKeepAlive(d time.Duration) ClientOption {
+	return func(o *clientOptions) {
+		o.keepAlive = d
+	}
+}
+
+// WithMaxIdleConns sets the maximum number of idle connections kept open, in
+// total and per host.
+func WithMaxIdleConns(total, perHost int) ClientOption {
+	return func(o *clientOptions) {
+		o.maxIdleConns = total
+		o.maxIdleConnsPerHost = perHost
+	}
+}
+
+// WithIdleConnTimeout sets how long an idle connection is kept open before
+// being closed.
+func WithIdleConnTimeout(d time.Duration) ClientOption {
+	return func(o *clientOptions) {
+		o.idleConnTimeout = d
+	}
+}
+
+// WithTLSConfig sets the TLS configuration used to connect to horizon, e.g.
+// to trust a private certificate authority.
+func WithTLSConfig(config *tls.Config) ClientOption {
+	return func(o *clientOptions) {
+		o.tlsConfig = config
+	}
+}
+
+// WithProxy sets the function selecting the proxy used for each request.  Use
+// http.ProxyURL to always use the same proxy, or pass nil to connect
+// directly.
+func WithProxy(proxy func(*http.Request) (*url.URL, error)) ClientOption {
+	return func(o *clientOptions) {
+		o.proxy = proxy
+	}
+}
+
+// WithHTTP1Only disables HTTP/2.  Use it when streaming through proxies that
+// buffer or break HTTP/2 server-sent events.
+func WithHTTP1Only() ClientOption {
+	return func(o *clientOptions) {
+		o.http1Only = true
+	}
+}
+
+// WithoutCompression makes the client ask horizon for uncompressed
+// responses, see Client.DisableCompression.
+func WithoutCompression() ClientOption {
+	return func(o *clientOptions) {
+		o.disableCompression = true
+	}
+}
+
+// WithNetworkVerification makes the client reject transactions that were not
+// signed for the network of the horizon server, see Client.VerifyNetwork.
+func WithNetworkVerification() ClientOption {
+	return func(o *clientOptions) {
+		o.verifyNetwork = true
+	}
+}
+
+// WithPreflight makes the client validate transactions according to policy
+// before submitting them, see Client.Preflight.
+func WithPreflight(policy PreflightPolicy) ClientOption {
+	return func(o *clientOptions) {
+		o.preflight = &policy
+	}
+}
+
+// transport returns the http transport described by o.
+func (o *clientOptions) transport() *http.Transport {
+	dialer := &net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: o.keepAlive,
+	}
+
+	t := &http.Transport{
+		Proxy:                 o.proxy,
+		DialContext:           dialer.DialContext,
+		MaxIdleConns:          o.maxIdleConns,
+		MaxIdleConnsPerHost:   o.maxIdleConnsPerHost,
+		IdleConnTimeout:       o.idleConnTimeout,
+		TLSClientConfig:       o.tlsConfig,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ExpectContinueTimeout: 1 * time.Second,
+	}
+
+	if o.http1Only {
+		// a non-nil empty map prevents the transport from negotiating h2
+		t.TLSNextProto = map[string]func(string, *tls.Conn) http.RoundTripper{}
+		return t
+	}
+
+	// a transport with a custom dialer or TLS config doesn't enable HTTP/2 on
+	// its own.  ConfigureTransport only fails when called twice, which can't
+	// happen on a fresh transport.
+	http2.ConfigureTransport(t)
+	return t
+}
@@ -0,0 +1,54 @@
+package horizon
+
+import (
+	"crypto/tls"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewClient(t *testing.T) {
+	client := NewClient("https://horizon-testnet.stellar.org")
+	assert.Equal(t, "https://horizon-testnet.stellar.org", client.URL)
+
+	hc, ok := client.HTTP.(*http.Client)
+	require.True(t, ok)
+	transport, ok := hc.Transport.(*http.Transport)
+	require.True(t, ok)
+
+	assert.Equal(t, 16, transport.MaxIdleConnsPerHost)
+	assert.Equal(t, 90*time.Second, transport.IdleConnTimeout)
+	assert.NotNil(t, transport.Proxy)
+	assert.Contains(t, transport.TLSNextProto, "h2")
+}
+
+func TestNewClient_Options(t *testing.T) {
+	proxyURL, err := url.Parse("http://proxy.local:3128")
+	require.NoError(t, err)
+	tlsConfig := &tls.Config{ServerName: "horizon.local"}
+
+	client := NewClient(
+		"https://horizon.local",
+		WithMaxIdleConns(10, 2),
+		WithIdleConnTimeout(time.Minute),
+		WithTLSConfig(tlsConfig),
+		WithProxy(http.ProxyURL(proxyURL)),
+		WithHTTP1Only(),
+	)
+
+	transport := client.HTTP.(*http.Client).Transport.(*http.Transport)
+	assert.Equal(t, 10, transport.MaxIdleConns)
+	assert.Equal(t, 2, transport.MaxIdleConnsPerHost)
+	assert.Equal(t, time.Minute, transport.IdleConnTimeout)
+	assert.Equal(t, tlsConfig, transport.TLSClientConfig)
+	assert.NotContains(t, transport.TLSNextProto, "h2")
+	assert.NotNil(t, transport.TLSNextProto)
+
+	proxy, err := transport.Proxy(&http.Request{URL: &url.URL{Scheme: "https", Host: "horizon.local"}})
+	require.NoError(t, err)
+	assert.Equal(t, proxyURL, proxy)
+}
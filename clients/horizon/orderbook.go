@@ -0,0 +1,226 @@
+package horizon
+
+import (
+	"reflect"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/stellar/go/amount"
+	"github.com/stellar/go/support/errors"
+	"github.com/stellar/go/xdr"
+	"golang.org/x/net/context"
+)
+
+// OrderBookTracker maintains an up to date local copy of the order book of a
+// market, fed by the order book stream of horizon (see Run) or by polling it
+// (see Poll), so that trading applications can query it at any time without
+// making requests.  It is safe for concurrent use.
+type OrderBookTracker struct {
+	// OnChange, when set, is called with the new order book each time it
+	// changes.  It is called from the goroutine running Run or Poll, so it
+	// should not block for long.
+	OnChange OrderBookHandler
+
+	client  ClientInterface
+	selling Asset
+	buying  Asset
+
+	mutex sync.RWMutex
+	book  OrderBookSummary
+	bids  []bookLevel
+	asks  []bookLevel
+}
+
+// bookLevel is a parsed PriceLevel.
+type bookLevel struct {
+	level  PriceLevel
+	amount xdr.Int64
+}
+
+// NewOrderBookTracker returns a tracker of the order book of the market
+// selling the selling asset for the buying asset.  The book is empty until Run
+// or Poll is called.
+func NewOrderBookTracker(client ClientInterface, selling Asset, buying Asset) *OrderBookTracker {
+	return &OrderBookTracker{
+		client:  client,
+		selling: selling,
+		buying:  buying,
+	}
+}
+
+// Run keeps the order book up to date by streaming it from horizon until ctx
+// is done or the stream fails.
+func (t *OrderBookTracker) Run(ctx context.Context) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var updateErr error
+	err := t.client.StreamOrderBook(ctx, t.selling, t.buying, func(book OrderBookSummary) {
+		if updateErr != nil {
+			return
+		}
+		updateErr = t.update(book)
+		if updateErr != nil {
+			cancel()
+		}
+	})
+
+	if updateErr != nil {
+		return updateErr
+	}
+	return err
+}
+
+// Poll keeps the order book up to date by loading it from horizon every
+// interval until ctx is done or a request fails.  Use it when streaming is not
+// possible, e.g. behind proxies that buffer server-sent events.
+func (t *OrderBookTracker) Poll(ctx context.Context, interval time.Duration) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		book, err := t.client.LoadOrderBookWithContext(ctx, t.selling, t.buying)
+		if ctx.Err() != nil {
+			return nil
+		}
+		if err != nil {
+			return errors.Wrap(err, "load order book failed")
+		}
+
+		err = t.update(book)
+		if err != nil {
+			return err
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// OrderBook returns the current order book.
+func (t *OrderBookTracker) OrderBook() OrderBookSummary {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+	return t.book
+}
+
+// BestBid returns the highest priced bid of the order book, or false if there
+// are no bids.
+func (t *OrderBookTracker) BestBid() (PriceLevel, bool) {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+
+	if len(t.bids) == 0 {
+		return PriceLevel{}, false
+	}
+	return t.bids[0].level, true
+}
+
+// BestAsk returns the lowest priced ask of the order book, or false if there
+// are no asks.
+func (t *OrderBookTracker) BestAsk() (PriceLevel, bool) {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+
+	if len(t.asks) == 0 {
+		return PriceLevel{}, false
+	}
+	return t.asks[0].level, true
+}
+
+// DepthAt returns the depth of the order book at price: the total amount of
+// the bids priced at or above price, and the total amount of the asks priced
+// at or below it.  Amounts are in stroops, as reported by horizon for each
+// side of the book.
+func (t *OrderBookTracker) DepthAt(price Price) (bids xdr.Int64, asks xdr.Int64) {
+	t.mutex.RLock()
+	defer t.mutex.RUnlock()
+
+	for _, l := range t.bids {
+		if comparePrices(l.level.PriceR, price) < 0 {
+			break
+		}
+		bids += l.amount
+	}
+
+	for _, l := range t.asks {
+		if comparePrices(l.level.PriceR, price) > 0 {
+			break
+		}
+		asks += l.amount
+	}
+
+	return
+}
+
+// update replaces the order book with book, notifying t.OnChange if it
+// changed.
+func (t *OrderBookTracker) update(book OrderBookSummary) error {
+	bids, err := parseLevels(book.Bids)
+	if err != nil {
+		return errors.Wrap(err, "parse bids failed")
+	}
+	asks, err := parseLevels(book.Asks)
+	if err != nil {
+		return errors.Wrap(err, "parse asks failed")
+	}
+
+	// horizon sorts the levels from the best price, but don't rely on it
+	sort.Stable(sort.Reverse(levelsByPrice(bids)))
+	sort.Stable(levelsByPrice(asks))
+
+	t.mutex.Lock()
+	changed := t.bids == nil ||
+		!reflect.DeepEqual(t.book.Bids, book.Bids) ||
+		!reflect.DeepEqual(t.book.Asks, book.Asks)
+	t.book = book
+	t.bids = bids
+	t.asks = asks
+	t.mutex.Unlock()
+
+	if changed && t.OnChange != nil {
+		t.OnChange(book)
+	}
+	return nil
+}
+
+// parseLevels parses the amounts of levels.  The result is never nil.
+func parseLevels(levels []PriceLevel) ([]bookLevel, error) {
+	parsed := make([]bookLevel, 0, len(levels))
+	for _, l := range levels {
+		a, err := amount.Parse(l.Amount)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid amount %q", l.Amount)
+		}
+		parsed = append(parsed, bookLevel{level: l, amount: a})
+	}
+	return parsed, nil
+}
+
+// levelsByPrice sorts levels from the lowest to the highest price.
+type levelsByPrice []bookLevel
+
+func (l levelsByPrice) Len() int      { return len(l) }
+func (l levelsByPrice) Swap(i, j int) { l[i], l[j] = l[j], l[i] }
+func (l levelsByPrice) Less(i, j int) bool {
+	return comparePrices(l[i].level.PriceR, l[j].level.PriceR) < 0
+}
+
+// comparePrices returns -1, 0 or 1 when a is respectively lower than, equal
+// to or higher than b.
+func comparePrices(a, b Price) int {
+	x := int64(a.N) * int64(b.D)
+	y := int64(b.N) * int64(a.D)
+	switch {
+	case x < y:
+		return -1
+	case x > y:
+		return 1
+	default:
+		return 0
+	}
+}
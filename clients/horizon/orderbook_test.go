@@ -0,0 +1,106 @@
+package horizon
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stellar/go/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/context"
+)
+
+func TestOrderBookTracker(t *testing.T) {
+	selling := Asset{Type: "native"}
+	buying := Asset{Type: "credit_alphanum4", Code: "USD", Issuer: "GC23QF2HUE52AMXUFUH3AYJAXXGXXV2VHXYYR6EYXETPKDXZSAW67XO4"}
+
+	books := []OrderBookSummary{
+		{
+			Bids: []PriceLevel{
+				{PriceR: Price{N: 1, D: 5}, Price: "0.2000000", Amount: "10.0000000"},
+				{PriceR: Price{N: 1, D: 4}, Price: "0.2500000", Amount: "5.0000000"},
+			},
+			Asks: []PriceLevel{
+				{PriceR: Price{N: 1, D: 2}, Price: "0.5000000", Amount: "20.0000000"},
+				{PriceR: Price{N: 1, D: 3}, Price: "0.3333333", Amount: "1.0000000"},
+			},
+		},
+		// unchanged, no notification
+		{
+			Bids: []PriceLevel{
+				{PriceR: Price{N: 1, D: 5}, Price: "0.2000000", Amount: "10.0000000"},
+				{PriceR: Price{N: 1, D: 4}, Price: "0.2500000", Amount: "5.0000000"},
+			},
+			Asks: []PriceLevel{
+				{PriceR: Price{N: 1, D: 2}, Price: "0.5000000", Amount: "20.0000000"},
+				{PriceR: Price{N: 1, D: 3}, Price: "0.3333333", Amount: "1.0000000"},
+			},
+		},
+	}
+
+	client := &MockClient{}
+	client.On("StreamOrderBook", mock.Anything, selling, buying, mock.Anything).
+		Run(func(args mock.Arguments) {
+			handler := args.Get(3).(OrderBookHandler)
+			for _, book := range books {
+				handler(book)
+			}
+		}).
+		Return(nil)
+
+	tracker := NewOrderBookTracker(client, selling, buying)
+	_, ok := tracker.BestBid()
+	assert.False(t, ok)
+
+	changes := 0
+	tracker.OnChange = func(OrderBookSummary) { changes++ }
+
+	require.NoError(t, tracker.Run(context.Background()))
+	assert.Equal(t, 1, changes)
+
+	bid, ok := tracker.BestBid()
+	require.True(t, ok)
+	assert.Equal(t, "0.2500000", bid.Price)
+
+	ask, ok := tracker.BestAsk()
+	require.True(t, ok)
+	assert.Equal(t, "0.3333333", ask.Price)
+
+	bids, asks := tracker.DepthAt(Price{N: 1, D: 5})
+	assert.Equal(t, xdr.Int64(150000000), bids)
+	assert.Equal(t, xdr.Int64(0), asks)
+
+	bids, asks = tracker.DepthAt(Price{N: 1, D: 2})
+	assert.Equal(t, xdr.Int64(0), bids)
+	assert.Equal(t, xdr.Int64(210000000), asks)
+}
+
+func TestOrderBookTracker_InvalidAmount(t *testing.T) {
+	client := &MockClient{}
+	client.On("StreamOrderBook", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			handler := args.Get(3).(OrderBookHandler)
+			handler(OrderBookSummary{Bids: []PriceLevel{{PriceR: Price{N: 1, D: 1}, Amount: "lots"}}})
+		}).
+		Return(nil)
+
+	tracker := NewOrderBookTracker(client, Asset{Type: "native"}, Asset{Type: "native"})
+	err := tracker.Run(context.Background())
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "parse bids failed")
+	}
+}
+
+func TestOrderBookTracker_Poll(t *testing.T) {
+	client := &MockClient{}
+	ctx, cancel := context.WithCancel(context.Background())
+
+	client.On("LoadOrderBookWithContext", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Run(func(mock.Arguments) { cancel() }).
+		Return(OrderBookSummary{}, nil)
+
+	tracker := NewOrderBookTracker(client, Asset{Type: "native"}, Asset{Type: "native"})
+	assert.NoError(t, tracker.Poll(ctx, time.Millisecond))
+	client.AssertNumberOfCalls(t, "LoadOrderBookWithContext", 1)
+}
@@ -0,0 +1,75 @@
+package horizon
+
+import (
+	"net/url"
+	"strconv"
+)
+
+// Param is a typed request parameter accepted by the client's collection
+// loaders, for example `Limit(20)` or `OrderDesc`.  Passing a value of an
+// unsupported type is a compile time error rather than a runtime one.
+type Param interface {
+	applyTo(r *request)
+}
+
+// request is the endpoint being built from a list of params.
+type request struct {
+	// at, when not empty, overrides the URL computed by the loader.
+	at    string
+	query url.Values
+}
+
+func (p At) applyTo(r *request) {
+	r.at = string(p)
+}
+
+func (p Cursor) applyTo(r *request) {
+	r.query.Set("cursor", string(p))
+}
+
+func (p Limit) applyTo(r *request) {
+	r.query.Set("limit", strconv.FormatUint(uint64(p), 10))
+}
+
+func (p Order) applyTo(r *request) {
+	r.query.Set("order", string(p))
+}
+
+func (p AssetCode) applyTo(r *request) {
+	r.query.Set("asset_code", string(p))
+}
+
+func (p AssetIssuer) applyTo(r *request) {
+	r.query.Set("asset_issuer", string(p))
+}
+
+// buildURL returns the URL for path on the connected horizon server, with
+// query and params applied to it.  query may be nil.
+func (c *Client) buildURL(path string, query url.Values, params []Param) (string, error) {
+	c.fixURLOnce.Do(c.fixURL)
+
+	r := request{query: url.Values{}}
+	for k, v := range query {
+		r.query[k] = v
+	}
+
+	for _, param := range params {
+		param.applyTo(&r)
+	}
+
+	endpoint := r.at
+	if endpoint == "" {
+		endpoint = c.URL + path
+		if len(r.query) > 0 {
+			endpoint += "?" + r.query.Encode()
+		}
+	}
+
+	// ensure our endpoint is a real url
+	_, err := url.Parse(endpoint)
+	if err != nil {
+		return "", err
+	}
+
+	return endpoint, nil
+}
@@ -0,0 +1,60 @@
+package horizon
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_buildURL(t *testing.T) {
+	c := &Client{URL: "https://localhost/"}
+
+	cases := []struct {
+		Name     string
+		Path     string
+		Query    url.Values
+		Params   []Param
+		Expected string
+	}{
+		{
+			Name:     "no params",
+			Path:     "/ledgers",
+			Expected: "https://localhost/ledgers",
+		},
+		{
+			Name:     "paging params",
+			Path:     "/ledgers",
+			Params:   []Param{Cursor("now"), Limit(200), OrderDesc},
+			Expected: "https://localhost/ledgers?cursor=now&limit=200&order=desc",
+		},
+		{
+			Name:     "query and params",
+			Path:     "/order_book",
+			Query:    url.Values{"selling_asset_type": []string{"native"}},
+			Params:   []Param{Limit(5)},
+			Expected: "https://localhost/order_book?limit=5&selling_asset_type=native",
+		},
+		{
+			Name:     "last param wins",
+			Path:     "/ledgers",
+			Params:   []Param{Limit(5), Limit(10)},
+			Expected: "https://localhost/ledgers?limit=10",
+		},
+		{
+			Name:     "overridden location",
+			Path:     "/ledgers",
+			Params:   []Param{Limit(5), At("https://localhost/beepboop")},
+			Expected: "https://localhost/beepboop",
+		},
+	}
+
+	for _, kase := range cases {
+		t.Run(kase.Name, func(t *testing.T) {
+			actual, err := c.buildURL(kase.Path, kase.Query, kase.Params)
+			if assert.NoError(t, err) {
+				assert.Equal(t, kase.Expected, actual)
+			}
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package horizon
+
+import (
+	"time"
+
+	"github.com/stellar/go/build"
+	"github.com/stellar/go/support/errors"
+	"github.com/stellar/go/xdr"
+)
+
+var (
+	// ErrNoOperations is the error returned by preflight validation for a
+	// transaction without operations.
+	ErrNoOperations = errors.New("transaction has no operations")
+
+	// ErrInsufficientFee is the error returned by preflight validation for a
+	// transaction whose fee is lower than the base fee times its number of
+	// operations.
+	ErrInsufficientFee = errors.New("transaction fee is too low")
+
+	// ErrTooEarly is the error returned by preflight validation for a
+	// transaction whose time bounds start in the future.
+	ErrTooEarly = errors.New("transaction is not valid yet")
+
+	// ErrTooLate is the error returned by preflight validation for a
+	// transaction whose time bounds have expired.
+	ErrTooLate = errors.New("transaction has expired")
+
+	// ErrNoSignatures is the error returned by preflight validation for a
+	// transaction that is not signed.
+	ErrNoSignatures = errors.New("transaction is not signed")
+)
+
+// PreflightPolicy configures the validation of transactions made by a client
+// before submitting them, so that transactions horizon would reject for
+// these reasons fail fast with a descriptive error.  Use errors.Cause to
+// compare the returned errors with ErrInsufficientFee and the like.
+type PreflightPolicy struct {
+	// BaseFee is the minimum fee per operation, in stroops.
+	// build.DefaultBaseFee is used when zero.
+	BaseFee uint64
+
+	// MaxClockSkew is the tolerated difference between the local clock and
+	// the clock of the network when checking time bounds.
+	MaxClockSkew time.Duration
+}
+
+// ValidateTransaction checks that the base64 encoded transaction envelope
+// txeBase64 has operations, pays at least the base fee for each of them, is
+// valid at the current time and is signed, returning a descriptive error
+// otherwise.
+func (p *PreflightPolicy) ValidateTransaction(txeBase64 string) error {
+	return p.validate(txeBase64, time.Now())
+}
+
+func (p *PreflightPolicy) validate(txeBase64 string, now time.Time) error {
+	var envelope xdr.TransactionEnvelope
+	err := xdr.SafeUnmarshalBase64(txeBase64, &envelope)
+	if err != nil {
+		return errors.Wrap(err, "decode envelope failed")
+	}
+	tx := envelope.Tx
+
+	ops := uint64(len(tx.Operations))
+	if ops == 0 {
+		return ErrNoOperations
+	}
+
+	baseFee := p.BaseFee
+	if baseFee == 0 {
+		baseFee = build.DefaultBaseFee
+	}
+	if uint64(tx.Fee) < baseFee*ops {
+		return errors.Wrapf(ErrInsufficientFee,
+			"fee %d is lower than the minimum of %d for %d operations", tx.Fee, baseFee*ops, ops)
+	}
+
+	if tb := tx.TimeBounds; tb != nil {
+		if tb.MinTime != 0 {
+			minTime := time.Unix(int64(tb.MinTime), 0)
+			if minTime.After(now.Add(p.MaxClockSkew)) {
+				return errors.Wrapf(ErrTooEarly, "valid from %s", minTime.UTC().Format(time.RFC3339))
+			}
+		}
+		if tb.MaxTime != 0 {
+			maxTime := time.Unix(int64(tb.MaxTime), 0)
+			if maxTime.Before(now.Add(-p.MaxClockSkew)) {
+				return errors.Wrapf(ErrTooLate, "valid until %s", maxTime.UTC().Format(time.RFC3339))
+			}
+		}
+	}
+
+	if len(envelope.Signatures) == 0 {
+		return ErrNoSignatures
+	}
+
+	return nil
+}
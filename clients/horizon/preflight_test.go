@@ -0,0 +1,117 @@
+package horizon
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stellar/go/build"
+	"github.com/stellar/go/keypair"
+	"github.com/stellar/go/support/errors"
+	"github.com/stellar/go/support/http/httptest"
+	"github.com/stellar/go/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPreflightPolicy_ValidateTransaction(t *testing.T) {
+	kp, err := keypair.Random()
+	require.NoError(t, err)
+
+	now := time.Unix(1500000000, 0)
+
+	encode := func(mutate func(*xdr.Transaction), sign bool) string {
+		tx, err := build.Transaction(
+			build.SourceAccount{AddressOrSeed: kp.Address()},
+			build.Sequence{Sequence: 1},
+			build.TestNetwork,
+			build.Inflation(),
+			build.Inflation(),
+		)
+		require.NoError(t, err)
+		if mutate != nil {
+			mutate(tx.TX)
+		}
+
+		if !sign {
+			b64, err := xdr.MarshalBase64(xdr.TransactionEnvelope{Tx: *tx.TX})
+			require.NoError(t, err)
+			return b64
+		}
+
+		txe, err := tx.Sign(kp.Seed())
+		require.NoError(t, err)
+		b64, err := txe.Base64()
+		require.NoError(t, err)
+		return b64
+	}
+
+	timeBounds := func(min, max time.Time) func(*xdr.Transaction) {
+		return func(tx *xdr.Transaction) {
+			tx.TimeBounds = &xdr.TimeBounds{
+				MinTime: xdr.Uint64(min.Unix()),
+				MaxTime: xdr.Uint64(max.Unix()),
+			}
+		}
+	}
+
+	policy := PreflightPolicy{MaxClockSkew: 10 * time.Second}
+
+	cases := []struct {
+		name     string
+		txe      string
+		expected error
+	}{
+		{"valid", encode(nil, true), nil},
+		{"valid time bounds", encode(timeBounds(now.Add(-time.Minute), now.Add(time.Minute)), true), nil},
+		{"within clock skew", encode(timeBounds(now.Add(5*time.Second), now.Add(time.Minute)), true), nil},
+		{"no operations", encode(func(tx *xdr.Transaction) { tx.Operations = nil }, true), ErrNoOperations},
+		{"fee too low", encode(func(tx *xdr.Transaction) { tx.Fee = 150 }, true), ErrInsufficientFee},
+		{"too early", encode(timeBounds(now.Add(time.Minute), now.Add(time.Hour)), true), ErrTooEarly},
+		{"too late", encode(timeBounds(now.Add(-time.Hour), now.Add(-time.Minute)), true), ErrTooLate},
+		{"unsigned", encode(nil, false), ErrNoSignatures},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			err := policy.validate(c.txe, now)
+			assert.Equal(t, c.expected, errors.Cause(err))
+		})
+	}
+
+	t.Run("invalid envelope", func(t *testing.T) {
+		err := policy.validate("not xdr", now)
+		if assert.Error(t, err) {
+			assert.Contains(t, err.Error(), "decode envelope failed")
+		}
+	})
+
+	t.Run("custom base fee", func(t *testing.T) {
+		err := (&PreflightPolicy{BaseFee: 1000}).validate(encode(nil, true), now)
+		if assert.Equal(t, ErrInsufficientFee, errors.Cause(err)) {
+			assert.Contains(t, err.Error(), "lower than the minimum of 2000 for 2 operations")
+		}
+	})
+}
+
+func TestClient_Preflight(t *testing.T) {
+	hmock := httptest.NewClient()
+	client := &Client{
+		URL:       "https://localhost",
+		HTTP:      hmock,
+		Preflight: &PreflightPolicy{},
+	}
+
+	tx, err := build.Transaction(
+		build.SourceAccount{AddressOrSeed: "GAKLCFRTFDXKOEEUSBS23FBSUUVJRMDQHGCHNGGGJZQRK7BCPIMHUC4P"},
+		build.Sequence{Sequence: 1},
+		build.TestNetwork,
+		build.Inflation(),
+	)
+	require.NoError(t, err)
+	b64, err := xdr.MarshalBase64(xdr.TransactionEnvelope{Tx: *tx.TX})
+	require.NoError(t, err)
+
+	// no request is expected by hmock, the transaction is rejected locally
+	_, err = client.SubmitTransaction(b64)
+	assert.Equal(t, ErrNoSignatures, errors.Cause(err))
+}
@@ -0,0 +1,145 @@
+package horizon
+
+import (
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"golang.org/x/net/context"
+)
+
+// RateLimiter throttles the requests made by a Client.  A single RateLimiter
+// can be shared by several clients to enforce a global limit on all of them.
+type RateLimiter interface {
+	// Wait blocks until a request is allowed to proceed or ctx is done.
+	Wait(ctx context.Context) error
+}
+
+// RateLimit is the rate limit state reported by horizon in the
+// X-RateLimit-* headers of a response.
+type RateLimit struct {
+	// Limit is the number of requests allowed in the current window.
+	Limit int
+	// Remaining is the number of requests left in the current window.
+	Remaining int
+	// Reset is the time left until the current window is reset.
+	Reset time.Duration
+}
+
+// RateLimitHandler is a function that is called with the rate limit state
+// reported by horizon after each request.
+type RateLimitHandler func(RateLimit)
+
+// NewRateLimiter returns a token bucket RateLimiter allowing rps requests per
+// second on average, and bursts of up to burst requests.
+func NewRateLimiter(rps float64, burst int) RateLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+
+	return &tokenBucket{
+		rate:     rps,
+		capacity: float64(burst),
+		tokens:   float64(burst),
+		last:     time.Now(),
+	}
+}
+
+// tokenBucket is the RateLimiter returned by NewRateLimiter.
+type tokenBucket struct {
+	mutex    sync.Mutex
+	rate     float64
+	capacity float64
+	tokens   float64
+	last     time.Time
+}
+
+// Wait implements RateLimiter
+func (b *tokenBucket) Wait(ctx context.Context) error {
+	for {
+		wait := b.take()
+		if wait == 0 {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(wait):
+		}
+	}
+}
+
+// take consumes a token if one is available and returns zero, otherwise it
+// returns the time until the next token is available.
+func (b *tokenBucket) take() time.Duration {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	now := time.Now()
+	b.tokens += now.Sub(b.last).Seconds() * b.rate
+	if b.tokens > b.capacity {
+		b.tokens = b.capacity
+	}
+	b.last = now
+
+	if b.tokens >= 1 {
+		b.tokens--
+		return 0
+	}
+
+	if b.rate <= 0 {
+		return time.Second
+	}
+
+	return time.Duration((1 - b.tokens) / b.rate * float64(time.Second))
+}
+
+// waitRateLimit blocks until c.RateLimiter allows a request.
+func (c *Client) waitRateLimit(ctx context.Context) error {
+	if c.RateLimiter == nil {
+		return nil
+	}
+	return c.RateLimiter.Wait(ctx)
+}
+
+// reportRateLimit passes the rate limit headers of resp to
+// c.OnRateLimit, if both are present.
+func (c *Client) reportRateLimit(resp *http.Response) {
+	if c.OnRateLimit == nil || resp == nil {
+		return
+	}
+
+	limit, ok := parseRateLimit(resp.Header)
+	if ok {
+		c.OnRateLimit(limit)
+	}
+}
+
+// parseRateLimit extracts the rate limit state from the X-RateLimit-*
+// headers, returning false if horizon didn't send them.
+func parseRateLimit(header http.Header) (RateLimit, bool) {
+	var (
+		result RateLimit
+		err    error
+	)
+
+	result.Limit, err = strconv.Atoi(header.Get("X-RateLimit-Limit"))
+	if err != nil {
+		return result, false
+	}
+
+	result.Remaining, err = strconv.Atoi(header.Get("X-RateLimit-Remaining"))
+	if err != nil {
+		return result, false
+	}
+
+	// the reset header is optional
+	reset, err := strconv.Atoi(header.Get("X-RateLimit-Reset"))
+	if err == nil {
+		result.Reset = time.Duration(reset) * time.Second
+	}
+
+	return result, true
+}
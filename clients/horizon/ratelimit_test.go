@@ -0,0 +1,90 @@
+package horizon
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stellar/go/support/http/httptest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/context"
+)
+
+func TestTokenBucket(t *testing.T) {
+	limiter := NewRateLimiter(100, 2)
+	ctx := context.Background()
+
+	// the burst is available immediately
+	start := time.Now()
+	require.NoError(t, limiter.Wait(ctx))
+	require.NoError(t, limiter.Wait(ctx))
+	assert.True(t, time.Since(start) < 5*time.Millisecond)
+
+	// the next token needs to be refilled
+	require.NoError(t, limiter.Wait(ctx))
+	assert.True(t, time.Since(start) >= 5*time.Millisecond)
+
+	// waiting is interrupted by the context
+	limiter = NewRateLimiter(0.001, 1)
+	require.NoError(t, limiter.Wait(ctx))
+
+	ctx, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	assert.Equal(t, context.DeadlineExceeded, limiter.Wait(ctx))
+}
+
+func TestParseRateLimit(t *testing.T) {
+	header := http.Header{}
+	_, ok := parseRateLimit(header)
+	assert.False(t, ok)
+
+	header.Set("X-RateLimit-Limit", "3600")
+	header.Set("X-RateLimit-Remaining", "3599")
+	limit, ok := parseRateLimit(header)
+	require.True(t, ok)
+	assert.Equal(t, RateLimit{Limit: 3600, Remaining: 3599}, limit)
+
+	header.Set("X-RateLimit-Reset", "42")
+	limit, ok = parseRateLimit(header)
+	require.True(t, ok)
+	assert.Equal(t, 42*time.Second, limit.Reset)
+}
+
+func TestClient_RateLimit(t *testing.T) {
+	hmock := httptest.NewClient()
+	var reported []RateLimit
+	client := &Client{
+		URL:         "https://localhost",
+		HTTP:        hmock,
+		RateLimiter: NewRateLimiter(1000, 1),
+		OnRateLimit: func(limit RateLimit) {
+			reported = append(reported, limit)
+		},
+	}
+
+	hmock.On(
+		"GET",
+		"https://localhost/accounts/GBRPYHIL2CI3FNQ4BXLFMNDLFJUNPU2HY3ZMFSHONUCEOASW7QC7OX2H",
+	).Return(func(req *http.Request) (*http.Response, error) {
+		resp := httpmock.NewStringResponse(http.StatusOK, accountResponse)
+		resp.Header.Set("X-RateLimit-Limit", "3600")
+		resp.Header.Set("X-RateLimit-Remaining", "3599")
+		resp.Header.Set("X-RateLimit-Reset", "60")
+		return resp, nil
+	})
+
+	_, err := client.LoadAccount("GBRPYHIL2CI3FNQ4BXLFMNDLFJUNPU2HY3ZMFSHONUCEOASW7QC7OX2H")
+	require.NoError(t, err)
+	assert.Equal(t, []RateLimit{{Limit: 3600, Remaining: 3599, Reset: time.Minute}}, reported)
+
+	// a cancelled context stops the request before it is sent
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	client.RateLimiter = NewRateLimiter(0.001, 1)
+	client.RateLimiter.Wait(context.Background())
+	_, err = client.LoadAccountWithContext(ctx, "GBRPYHIL2CI3FNQ4BXLFMNDLFJUNPU2HY3ZMFSHONUCEOASW7QC7OX2H")
+	assert.Error(t, err)
+	assert.Len(t, reported, 1)
+}
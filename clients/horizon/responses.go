@@ -4,6 +4,10 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"time"
+
+	"github.com/stellar/go/build"
+	"github.com/stellar/go/support/errors"
+	"github.com/stellar/go/xdr"
 )
 
 type Problem struct {
@@ -69,6 +73,28 @@ func (a Account) GetNativeBalance() string {
 	return "0"
 }
 
+// AccountSigners returns the signers and thresholds of the account in the form
+// used by the build package to check the signatures of transactions.
+func (a Account) AccountSigners() build.AccountSigners {
+	signers := build.AccountSigners{
+		Signers:         make([]build.AccountSigner, len(a.Signers)),
+		LowThreshold:    uint32(a.Thresholds.LowThreshold),
+		MediumThreshold: uint32(a.Thresholds.MedThreshold),
+		HighThreshold:   uint32(a.Thresholds.HighThreshold),
+	}
+
+	for i, signer := range a.Signers {
+		key := signer.Key
+		// older horizon servers only return public_key
+		if key == "" {
+			key = signer.PublicKey
+		}
+		signers.Signers[i] = build.AccountSigner{Key: key, Weight: uint32(signer.Weight)}
+	}
+
+	return signers
+}
+
 func (a Account) GetCreditBalance(code, issuer string) string {
 	for _, balance := range a.Balances {
 		if balance.Asset.Code == code && balance.Asset.Issuer == issuer {
@@ -96,6 +122,31 @@ func (this *Account) GetData(key string) ([]byte, error) {
 	return base64.StdEncoding.DecodeString(this.Data[key])
 }
 
+// GetDataEntries returns the decoded values of all the data entries of the
+// account, keyed by name.
+func (this *Account) GetDataEntries() (map[string][]byte, error) {
+	entries := make(map[string][]byte, len(this.Data))
+	for key := range this.Data {
+		value, err := this.GetData(key)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to decode data entry %s", key)
+		}
+		entries[key] = value
+	}
+	return entries, nil
+}
+
+// AccountData represents a single data entry of an account, as returned by
+// the /accounts/{id}/data/{key} endpoint.
+type AccountData struct {
+	Value string `json:"value"`
+}
+
+// Decode returns the decoded value of the data entry.
+func (d AccountData) Decode() ([]byte, error) {
+	return base64.StdEncoding.DecodeString(d.Value)
+}
+
 type AccountFlags struct {
 	AuthRequired  bool `json:"auth_required"`
 	AuthRevocable bool `json:"auth_revocable"`
@@ -113,12 +164,91 @@ type Asset struct {
 	Issuer string `json:"asset_issuer,omitempty"`
 }
 
+// ToXDR returns the XDR representation of the asset.
+func (a Asset) ToXDR() (xdr.Asset, error) {
+	if a.Type == "native" {
+		return xdr.NewAsset(xdr.AssetTypeAssetTypeNative, nil)
+	}
+
+	var issuer xdr.AccountId
+	err := issuer.SetAddress(a.Issuer)
+	if err != nil {
+		return xdr.Asset{}, errors.Wrap(err, "invalid issuer")
+	}
+
+	switch a.Type {
+	case "credit_alphanum4":
+		body := xdr.AssetAlphaNum4{Issuer: issuer}
+		if len(a.Code) < 1 || len(a.Code) > len(body.AssetCode) {
+			return xdr.Asset{}, errors.Errorf("invalid asset code: %s", a.Code)
+		}
+		copy(body.AssetCode[:], a.Code)
+		return xdr.NewAsset(xdr.AssetTypeAssetTypeCreditAlphanum4, body)
+	case "credit_alphanum12":
+		body := xdr.AssetAlphaNum12{Issuer: issuer}
+		if len(a.Code) < 5 || len(a.Code) > len(body.AssetCode) {
+			return xdr.Asset{}, errors.Errorf("invalid asset code: %s", a.Code)
+		}
+		copy(body.AssetCode[:], a.Code)
+		return xdr.NewAsset(xdr.AssetTypeAssetTypeCreditAlphanum12, body)
+	default:
+		return xdr.Asset{}, errors.Errorf("unknown asset type: %s", a.Type)
+	}
+}
+
+// AssetStat represents the statistics of a single asset issued on the
+// network, as returned by the /assets endpoint.
+type AssetStat struct {
+	Links struct {
+		Toml Link `json:"toml"`
+	} `json:"_links"`
+
+	Asset
+	PT          string       `json:"paging_token"`
+	Amount      string       `json:"amount"`
+	NumAccounts int32        `json:"num_accounts"`
+	Flags       AccountFlags `json:"flags"`
+}
+
+type AssetsPage struct {
+	Links struct {
+		Self Link `json:"self"`
+		Next Link `json:"next"`
+		Prev Link `json:"prev"`
+	} `json:"_links"`
+	Embedded struct {
+		Records []AssetStat `json:"records"`
+	} `json:"_embedded"`
+}
+
 type Balance struct {
 	Balance string `json:"balance"`
 	Limit   string `json:"limit,omitempty"`
 	Asset
 }
 
+// FeeStats represents the fees accepted by the network in the last ledgers,
+// as reported by the /fee_stats endpoint.  Fees are expressed in stroops.
+type FeeStats struct {
+	LastLedger          int32   `json:"last_ledger,string"`
+	LastLedgerBaseFee   int32   `json:"last_ledger_base_fee,string"`
+	LedgerCapacityUsage float64 `json:"ledger_capacity_usage,string"`
+
+	MinAcceptedFee  int32 `json:"min_accepted_fee,string"`
+	ModeAcceptedFee int32 `json:"mode_accepted_fee,string"`
+	P10AcceptedFee  int32 `json:"p10_accepted_fee,string"`
+	P20AcceptedFee  int32 `json:"p20_accepted_fee,string"`
+	P30AcceptedFee  int32 `json:"p30_accepted_fee,string"`
+	P40AcceptedFee  int32 `json:"p40_accepted_fee,string"`
+	P50AcceptedFee  int32 `json:"p50_accepted_fee,string"`
+	P60AcceptedFee  int32 `json:"p60_accepted_fee,string"`
+	P70AcceptedFee  int32 `json:"p70_accepted_fee,string"`
+	P80AcceptedFee  int32 `json:"p80_accepted_fee,string"`
+	P90AcceptedFee  int32 `json:"p90_accepted_fee,string"`
+	P95AcceptedFee  int32 `json:"p95_accepted_fee,string"`
+	P99AcceptedFee  int32 `json:"p99_accepted_fee,string"`
+}
+
 type HistoryAccount struct {
 	ID        string `json:"id"`
 	PT        string `json:"paging_token"`
@@ -149,6 +279,17 @@ type Ledger struct {
 	ProtocolVersion  int32     `json:"protocol_version"`
 }
 
+type LedgersPage struct {
+	Links struct {
+		Self Link `json:"self"`
+		Next Link `json:"next"`
+		Prev Link `json:"prev"`
+	} `json:"_links"`
+	Embedded struct {
+		Records []Ledger `json:"records"`
+	} `json:"_embedded"`
+}
+
 type Link struct {
 	Href      string `json:"href"`
 	Templated bool   `json:"templated,omitempty"`
@@ -243,17 +384,78 @@ type Payment struct {
 	}
 }
 
+type PaymentsPage struct {
+	Links struct {
+		Self Link `json:"self"`
+		Next Link `json:"next"`
+		Prev Link `json:"prev"`
+	} `json:"_links"`
+	Embedded struct {
+		Records []Payment `json:"records"`
+	} `json:"_embedded"`
+}
+
+// Asset returns the XDR representation of the asset of the payment.  It
+// fails for create_account payments, which have no asset.
+func (p Payment) Asset() (xdr.Asset, error) {
+	return Asset{Type: p.AssetType, Code: p.AssetCode, Issuer: p.AssetIssuer}.ToXDR()
+}
+
 type Price struct {
 	N int32 `json:"n"`
 	D int32 `json:"d"`
 }
 
+// ToXDR returns the XDR representation of the price.
+func (p Price) ToXDR() xdr.Price {
+	return xdr.Price{N: xdr.Int32(p.N), D: xdr.Int32(p.D)}
+}
+
 type PriceLevel struct {
 	PriceR Price  `json:"price_r"`
 	Price  string `json:"price"`
 	Amount string `json:"amount"`
 }
 
+// Trade represents a trade between two offers, or an offer and a path
+// payment, in the market of its base and counter assets.
+type Trade struct {
+	Links struct {
+		Self      Link `json:"self"`
+		Base      Link `json:"base"`
+		Counter   Link `json:"counter"`
+		Operation Link `json:"operation"`
+	} `json:"_links"`
+
+	ID                 string    `json:"id"`
+	PT                 string    `json:"paging_token"`
+	LedgerCloseTime    time.Time `json:"ledger_close_time"`
+	OfferID            string    `json:"offer_id"`
+	BaseAccount        string    `json:"base_account"`
+	BaseAmount         string    `json:"base_amount"`
+	BaseAssetType      string    `json:"base_asset_type"`
+	BaseAssetCode      string    `json:"base_asset_code,omitempty"`
+	BaseAssetIssuer    string    `json:"base_asset_issuer,omitempty"`
+	CounterAccount     string    `json:"counter_account"`
+	CounterAmount      string    `json:"counter_amount"`
+	CounterAssetType   string    `json:"counter_asset_type"`
+	CounterAssetCode   string    `json:"counter_asset_code,omitempty"`
+	CounterAssetIssuer string    `json:"counter_asset_issuer,omitempty"`
+	BaseIsSeller       bool      `json:"base_is_seller"`
+	Price              Price     `json:"price"`
+}
+
+type TradesPage struct {
+	Links struct {
+		Self Link `json:"self"`
+		Next Link `json:"next"`
+		Prev Link `json:"prev"`
+	} `json:"_links"`
+	Embedded struct {
+		Records []Trade `json:"records"`
+	} `json:"_embedded"`
+}
+
 type Transaction struct {
 	ID              string    `json:"id"`
 	PagingToken     string    `json:"paging_token"`
@@ -274,3 +476,39 @@ type Transaction struct {
 	ValidAfter      string    `json:"valid_after,omitempty"`
 	ValidBefore     string    `json:"valid_before,omitempty"`
 }
+
+// Envelope decodes the transaction envelope of the transaction.
+func (t Transaction) Envelope() (envelope xdr.TransactionEnvelope, err error) {
+	err = xdr.SafeUnmarshalBase64(t.EnvelopeXdr, &envelope)
+	return
+}
+
+// Result decodes the result of the transaction.
+func (t Transaction) Result() (result xdr.TransactionResult, err error) {
+	err = xdr.SafeUnmarshalBase64(t.ResultXdr, &result)
+	return
+}
+
+// ResultMeta decodes the changes applied to the ledger by the transaction.
+func (t Transaction) ResultMeta() (meta xdr.TransactionMeta, err error) {
+	err = xdr.SafeUnmarshalBase64(t.ResultMetaXdr, &meta)
+	return
+}
+
+// FeeMeta decodes the changes applied to the ledger by charging the fee of
+// the transaction.
+func (t Transaction) FeeMeta() (changes xdr.LedgerEntryChanges, err error) {
+	err = xdr.SafeUnmarshalBase64(t.FeeMetaXdr, &changes)
+	return
+}
+
+type TransactionsPage struct {
+	Links struct {
+		Self Link `json:"self"`
+		Next Link `json:"next"`
+		Prev Link `json:"prev"`
+	} `json:"_links"`
+	Embedded struct {
+		Records []Transaction `json:"records"`
+	} `json:"_embedded"`
+}
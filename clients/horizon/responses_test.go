@@ -0,0 +1,53 @@
+package horizon
+
+import (
+	"testing"
+
+	"github.com/stellar/go/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAsset_ToXDR(t *testing.T) {
+	issuer := "GBAUUA74H4XOQYRSOW2RZUA4QL5PB37U3JS5NE3RTB2ELJVMIF5RLMAG"
+
+	native, err := Asset{Type: "native"}.ToXDR()
+	require.NoError(t, err)
+	assert.Equal(t, xdr.AssetTypeAssetTypeNative, native.Type)
+
+	usd, err := Asset{Type: "credit_alphanum4", Code: "USD", Issuer: issuer}.ToXDR()
+	require.NoError(t, err)
+	var typ, code, iss string
+	require.NoError(t, usd.Extract(&typ, &code, &iss))
+	assert.Equal(t, "credit_alphanum4", typ)
+	assert.Equal(t, "USD", code)
+	assert.Equal(t, issuer, iss)
+
+	long, err := Asset{Type: "credit_alphanum12", Code: "SCOTTBUCKS", Issuer: issuer}.ToXDR()
+	require.NoError(t, err)
+	require.NoError(t, long.Extract(&typ, &code, &iss))
+	assert.Equal(t, "credit_alphanum12", typ)
+	assert.Equal(t, "SCOTTBUCKS", code)
+
+	_, err = Asset{Type: "credit_alphanum4", Code: "TOOLONG", Issuer: issuer}.ToXDR()
+	assert.Error(t, err)
+	_, err = Asset{Type: "credit_alphanum4", Code: "USD", Issuer: "bad"}.ToXDR()
+	assert.Error(t, err)
+	_, err = Asset{Type: "unknown"}.ToXDR()
+	assert.Error(t, err)
+}
+
+func TestPayment_Asset(t *testing.T) {
+	p := Payment{AssetType: "native"}
+	asset, err := p.Asset()
+	require.NoError(t, err)
+	assert.Equal(t, xdr.AssetTypeAssetTypeNative, asset.Type)
+
+	// create_account payments have no asset
+	_, err = Payment{Type: "create_account"}.Asset()
+	assert.Error(t, err)
+}
+
+func TestPrice_ToXDR(t *testing.T) {
+	assert.Equal(t, xdr.Price{N: 1, D: 3}, Price{N: 1, D: 3}.ToXDR())
+}
@@ -0,0 +1,114 @@
+package horizon
+
+import (
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// DefaultRetryPolicy is a reasonable retry policy for clients talking to a
+// public horizon server.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts: 3,
+	MinBackoff:  500 * time.Millisecond,
+	MaxBackoff:  5 * time.Second,
+	Jitter:      0.2,
+}
+
+// RetryPolicy configures how a Client retries requests that failed for
+// transient reasons: network errors, rate limiting (429) and server errors
+// (5xx).
+//
+// Requests that load data are always safe to retry.  Transaction submissions
+// are only retried when horizon did not process the request (429) or when the
+// submission timed out (504 or a network timeout).  Resubmitting the same
+// envelope is safe in that case because horizon returns the existing result
+// for a transaction hash it has already seen.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts made for a request,
+	// including the first one.  Values lower than 2 disable retries.
+	MaxAttempts int
+
+	// MinBackoff is the delay before the first retry.  Subsequent delays
+	// double until MaxBackoff is reached.
+	MinBackoff time.Duration
+
+	// MaxBackoff caps the delay between two attempts.
+	MaxBackoff time.Duration
+
+	// Jitter is the fraction (0 to 1) of each delay that is randomized to
+	// avoid many clients retrying in lockstep.
+	Jitter float64
+}
+
+// backoff returns the delay to wait after the given (1-based) failed
+// attempt.
+func (p *RetryPolicy) backoff(attempt int) time.Duration {
+	d := p.MinBackoff
+	for i := 1; i < attempt && d < p.MaxBackoff; i++ {
+		d *= 2
+	}
+
+	if p.MaxBackoff > 0 && d > p.MaxBackoff {
+		d = p.MaxBackoff
+	}
+
+	if p.Jitter > 0 && d > 0 {
+		delta := time.Duration(p.Jitter * float64(d))
+		d = d - delta + time.Duration(rand.Int63n(int64(2*delta)+1))
+	}
+
+	return d
+}
+
+// retryAfter returns the delay requested by the server through the
+// Retry-After header, or zero if there is none.
+func retryAfter(resp *http.Response) time.Duration {
+	if resp == nil {
+		return 0
+	}
+
+	seconds, err := strconv.Atoi(resp.Header.Get("Retry-After"))
+	if err != nil || seconds < 0 {
+		return 0
+	}
+
+	return time.Duration(seconds) * time.Second
+}
+
+// shouldRetryLoad reports whether a request loading data should be retried
+// given its outcome.
+func shouldRetryLoad(resp *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+
+	return resp.StatusCode == http.StatusTooManyRequests ||
+		resp.StatusCode >= http.StatusInternalServerError
+}
+
+// shouldRetrySubmission reports whether a transaction submission should be
+// retried given its outcome.
+func shouldRetrySubmission(resp *http.Response, err error) bool {
+	if err != nil {
+		nerr, ok := err.(net.Error)
+		return ok && nerr.Timeout()
+	}
+
+	return resp.StatusCode == http.StatusTooManyRequests ||
+		resp.StatusCode == http.StatusGatewayTimeout
+}
+
+// discardResponse drains and closes the body of a response that will not be
+// decoded, so that the underlying connection can be reused.
+func discardResponse(resp *http.Response) {
+	if resp == nil {
+		return
+	}
+	io.Copy(ioutil.Discard, resp.Body)
+	resp.Body.Close()
+}
@@ -0,0 +1,111 @@
+package horizon
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stellar/go/support/http/httptest"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// sequenceResponder returns the given responses in order, repeating the
+// last one, and counts the calls made.
+func sequenceResponder(calls *int, statuses ...int) httpmock.Responder {
+	return func(req *http.Request) (*http.Response, error) {
+		i := *calls
+		if i >= len(statuses) {
+			i = len(statuses) - 1
+		}
+		*calls++
+
+		switch statuses[i] {
+		case http.StatusOK:
+			return httpmock.NewStringResponse(statuses[i], accountResponse), nil
+		default:
+			return httpmock.NewStringResponse(statuses[i], notFoundResponse), nil
+		}
+	}
+}
+
+func TestClient_Retry(t *testing.T) {
+	policy := &RetryPolicy{MaxAttempts: 3, MinBackoff: time.Millisecond, MaxBackoff: time.Millisecond}
+	url := "https://localhost/accounts/GBRPYHIL2CI3FNQ4BXLFMNDLFJUNPU2HY3ZMFSHONUCEOASW7QC7OX2H"
+
+	t.Run("retries server errors", func(t *testing.T) {
+		hmock := httptest.NewClient()
+		client := &Client{URL: "https://localhost", HTTP: hmock, Retry: policy}
+
+		calls := 0
+		hmock.On("GET", url).Return(sequenceResponder(&calls, 503, 429, 200))
+
+		account, err := client.LoadAccount("GBRPYHIL2CI3FNQ4BXLFMNDLFJUNPU2HY3ZMFSHONUCEOASW7QC7OX2H")
+		require.NoError(t, err)
+		assert.Equal(t, "GBRPYHIL2CI3FNQ4BXLFMNDLFJUNPU2HY3ZMFSHONUCEOASW7QC7OX2H", account.ID)
+		assert.Equal(t, 3, calls)
+	})
+
+	t.Run("gives up after max attempts", func(t *testing.T) {
+		hmock := httptest.NewClient()
+		client := &Client{URL: "https://localhost", HTTP: hmock, Retry: policy}
+
+		calls := 0
+		hmock.On("GET", url).Return(sequenceResponder(&calls, 500))
+
+		_, err := client.LoadAccount("GBRPYHIL2CI3FNQ4BXLFMNDLFJUNPU2HY3ZMFSHONUCEOASW7QC7OX2H")
+		assert.Error(t, err)
+		assert.Equal(t, 3, calls)
+	})
+
+	t.Run("does not retry client errors", func(t *testing.T) {
+		hmock := httptest.NewClient()
+		client := &Client{URL: "https://localhost", HTTP: hmock, Retry: policy}
+
+		calls := 0
+		hmock.On("GET", url).Return(sequenceResponder(&calls, 404, 200))
+
+		_, err := client.LoadAccount("GBRPYHIL2CI3FNQ4BXLFMNDLFJUNPU2HY3ZMFSHONUCEOASW7QC7OX2H")
+		assert.Error(t, err)
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("disabled without a policy", func(t *testing.T) {
+		hmock := httptest.NewClient()
+		client := &Client{URL: "https://localhost", HTTP: hmock}
+
+		calls := 0
+		hmock.On("GET", url).Return(sequenceResponder(&calls, 503, 200))
+
+		_, err := client.LoadAccount("GBRPYHIL2CI3FNQ4BXLFMNDLFJUNPU2HY3ZMFSHONUCEOASW7QC7OX2H")
+		assert.Error(t, err)
+		assert.Equal(t, 1, calls)
+	})
+
+	t.Run("submission only retries when safe", func(t *testing.T) {
+		hmock := httptest.NewClient()
+		client := &Client{URL: "https://localhost", HTTP: hmock, Retry: policy}
+
+		calls := 0
+		hmock.On("POST", "https://localhost/transactions").Return(sequenceResponder(&calls, 500, 200))
+
+		_, err := client.SubmitTransaction("AAAA")
+		assert.Error(t, err)
+		assert.Equal(t, 1, calls)
+	})
+}
+
+func TestRetryPolicy_backoff(t *testing.T) {
+	p := RetryPolicy{MinBackoff: time.Second, MaxBackoff: 5 * time.Second}
+	assert.Equal(t, time.Second, p.backoff(1))
+	assert.Equal(t, 2*time.Second, p.backoff(2))
+	assert.Equal(t, 4*time.Second, p.backoff(3))
+	assert.Equal(t, 5*time.Second, p.backoff(4))
+
+	p.Jitter = 0.5
+	for i := 0; i < 10; i++ {
+		d := p.backoff(1)
+		assert.True(t, d >= 500*time.Millisecond && d <= 1500*time.Millisecond, "backoff out of range: %s", d)
+	}
+}
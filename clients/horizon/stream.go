@@ -0,0 +1,357 @@
+package horizon
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sync/atomic"
+	"time"
+
+	"github.com/stellar/go/support/errors"
+	"golang.org/x/net/context"
+)
+
+// DefaultStreamPolicy is a reasonable stream policy for long running
+// consumers of a public horizon server.
+var DefaultStreamPolicy = StreamPolicy{
+	MinBackoff:   time.Second,
+	MaxBackoff:   time.Minute,
+	Jitter:       0.2,
+	StallTimeout: time.Minute,
+}
+
+// ErrStreamStalled is the error reported when no data was received on a
+// stream for longer than StreamPolicy.StallTimeout.
+var ErrStreamStalled = errors.New("stream stalled: no data received")
+
+// StreamPolicy configures how the Stream* methods of a Client recover from
+// failures.  Without a policy a stream is only restarted when horizon closes
+// the connection, and any other failure is returned to the caller.
+type StreamPolicy struct {
+	// MaxReconnects is the number of consecutive failed reconnections after
+	// which streaming is aborted.  Zero means there is no limit.  The count is
+	// reset each time an event is received.
+	MaxReconnects int
+
+	// MinBackoff is the delay before the first reconnection attempt.
+	// Subsequent delays double until MaxBackoff is reached.
+	MinBackoff time.Duration
+
+	// MaxBackoff caps the delay between two reconnection attempts.
+	MaxBackoff time.Duration
+
+	// Jitter is the fraction (0 to 1) of each delay that is randomized.
+	Jitter float64
+
+	// StallTimeout is the maximum time to wait for data, events or
+	// heartbeats, on an open stream before reconnecting.  Zero disables stall
+	// detection.
+	StallTimeout time.Duration
+}
+
+func (p *StreamPolicy) backoff(attempt int) time.Duration {
+	rp := RetryPolicy{MinBackoff: p.MinBackoff, MaxBackoff: p.MaxBackoff, Jitter: p.Jitter}
+	return rp.backoff(attempt)
+}
+
+// streamConnectionError is a failure of the connection to horizon, as opposed
+// to a failure of the handler or of the decoding of events.  Only the former
+// can be recovered by reconnecting.
+type streamConnectionError struct {
+	error
+}
+
+// Event is a raw server-sent event received from horizon.
+type Event struct {
+	// Event is the name of the event.  Resources are sent in "message" events,
+	// horizon also sends "open" and "close" events when a stream is opened and
+	// about to be closed.
+	Event string
+
+	// ID is the id of the event, which is the paging token of the resource
+	// it carries for resumable streams.
+	ID string
+
+	// Retry is the reconnection delay requested by the server, or zero if
+	// the event carries no such hint.
+	Retry time.Duration
+
+	// Data is the payload of the event, usually JSON.
+	Data []byte
+}
+
+// streamState is what a stream remembers across reconnections.
+type streamState struct {
+	// lastData is the data of the last "message" event received
+	lastData []byte
+	// lastID is the id of the last event received with an id
+	lastID string
+	// retry is the last reconnection delay requested by horizon
+	retry time.Duration
+}
+
+// cursor returns the cursor to resume streaming from, using the id of the
+// last event or, if horizon did not send ids, the paging_token of the last
+// object received.
+func (s *streamState) cursor() (string, error) {
+	if s.lastID != "" {
+		return s.lastID, nil
+	}
+
+	object := struct {
+		PT string `json:"paging_token"`
+	}{}
+
+	err := json.Unmarshal(s.lastData, &object)
+	if err != nil {
+		return "", errors.Wrap(err, "Error unmarshaling objectBytes")
+	}
+
+	if object.PT == "" {
+		return "", errors.New("no paging_token in object: cannot continue")
+	}
+
+	return object.PT, nil
+}
+
+// StreamRaw streams the server-sent events of the horizon endpoint at path
+// (e.g. "/ledgers" or "/accounts/{id}/payments"), including the "open" and
+// "close" events and the id and retry fields of each event, for consumers
+// that implement their own decoding or checkpointing.  Streaming stops when
+// handler returns an error, which is then returned.  Use context.WithCancel to
+// stop streaming or context.Background() if you want to stream indefinitely.
+func (c *Client) StreamRaw(ctx context.Context, path string, cursor *Cursor, handler EventHandler) error {
+	c.fixURLOnce.Do(c.fixURL)
+
+	query := url.Values{}
+	if cursor != nil {
+		query.Set("cursor", string(*cursor))
+	}
+
+	return c.streamQuery(ctx, c.URL+path, query, true, handler)
+}
+
+func (c *Client) stream(ctx context.Context, baseURL string, cursor *Cursor, handler func(data []byte) error) error {
+	query := url.Values{}
+	if cursor != nil {
+		query.Set("cursor", string(*cursor))
+	}
+
+	return c.streamQuery(ctx, baseURL, query, true, messageHandler(handler))
+}
+
+// messageHandler adapts a handler of resource data to an EventHandler,
+// ignoring events other than "message" ones.
+func messageHandler(handler func(data []byte) error) EventHandler {
+	return func(ev Event) error {
+		if ev.Event != "message" {
+			return nil
+		}
+		return handler(ev.Data)
+	}
+}
+
+// streamQuery streams the events of baseURL with the given query. When
+// resumable is true each reconnection continues after the last received
+// object; otherwise the stream is simply reopened with the same query, which
+// suits resources that are sent as a whole on each event such as order books.
+func (c *Client) streamQuery(ctx context.Context, baseURL string, query url.Values, resumable bool, handler EventHandler) error {
+	var state streamState
+	failures := 0
+
+	for {
+		state.lastData = nil
+		err := c.streamOnce(ctx, fmt.Sprintf("%s?%s", baseURL, query.Encode()), &state, handler)
+
+		// streaming was cancelled
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		if _, ok := err.(streamConnectionError); err != nil && !ok {
+			return err
+		}
+
+		received := state.lastData != nil
+		if received {
+			failures = 0
+
+			if resumable {
+				cursor, err := state.cursor()
+				if err != nil {
+					return err
+				}
+				query.Set("cursor", cursor)
+			}
+		}
+
+		// Start streaming from the next object if horizon closed the
+		// connection after sending events.
+		if err == nil && received {
+			if !c.sleep(ctx, state.retry) {
+				return nil
+			}
+			continue
+		}
+
+		if err == nil {
+			err = streamConnectionError{errors.New("stream closed before any event was received")}
+		}
+
+		if c.StreamPolicy == nil {
+			return err.(streamConnectionError).error
+		}
+
+		failures++
+		if c.StreamPolicy.MaxReconnects > 0 && failures > c.StreamPolicy.MaxReconnects {
+			return errors.Wrap(err.(streamConnectionError).error, "too many failed reconnection attempts")
+		}
+
+		wait := c.StreamPolicy.backoff(failures)
+		if state.retry > wait {
+			wait = state.retry
+		}
+		if !c.sleep(ctx, wait) {
+			return nil
+		}
+	}
+}
+
+// sleep waits for d, returning false if ctx is done before.
+func (c *Client) sleep(ctx context.Context, d time.Duration) bool {
+	if d <= 0 {
+		return true
+	}
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-time.After(d):
+		return true
+	}
+}
+
+// streamOnce opens a single connection to endpoint and passes the events it
+// receives to handler until the connection is closed, recording them in
+// state.  Errors caused by the connection are returned as
+// streamConnectionError.
+func (c *Client) streamOnce(ctx context.Context, endpoint string, state *streamState, handler EventHandler) error {
+	req, err := http.NewRequest("GET", endpoint, nil)
+	if err != nil {
+		return err
+	}
+	c.setHeaders(req)
+	req.Header.Set("Accept", "text/event-stream")
+	// compressed event streams are buffered by some proxies and gzip
+	// readers, delaying events
+	req.Header.Set("Accept-Encoding", "identity")
+
+	err = c.waitRateLimit(ctx)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.HTTP.Do(req.WithContext(ctx))
+	if err != nil {
+		return streamConnectionError{err}
+	}
+	c.reportRateLimit(resp)
+
+	if !(resp.StatusCode >= 200 && resp.StatusCode < 300) {
+		err = decodeResponse(resp, nil)
+		if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= http.StatusInternalServerError {
+			return streamConnectionError{err}
+		}
+		return err
+	}
+
+	body := resp.Body
+	if c.StreamPolicy != nil && c.StreamPolicy.StallTimeout > 0 {
+		body = newStallReader(body, c.StreamPolicy.StallTimeout)
+	}
+	defer body.Close()
+
+	scanner := bufio.NewScanner(body)
+	scanner.Split(splitSSE)
+
+	for scanner.Scan() {
+		// Check if ctx is not cancelled
+		select {
+		case <-ctx.Done():
+			return nil
+		default:
+			// Continue streaming
+		}
+
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+
+		ev, err := parseEvent(scanner.Bytes())
+		if err != nil {
+			return err
+		}
+
+		err = handler(ev)
+		if err != nil {
+			return err
+		}
+
+		if ev.ID != "" {
+			state.lastID = ev.ID
+		}
+		if ev.Retry > 0 {
+			state.retry = ev.Retry
+		}
+		if ev.Event == "message" {
+			state.lastData = ev.Data
+		}
+	}
+
+	err = scanner.Err()
+
+	// A lost connection is reported like a closed one, streaming restarts
+	// from the last received object in both cases.
+	if err == nil || err == io.ErrUnexpectedEOF {
+		return nil
+	}
+
+	return streamConnectionError{err}
+}
+
+// stallReader wraps the body of a stream and closes it when no data was read
+// from it for longer than timeout.
+type stallReader struct {
+	body    io.ReadCloser
+	timeout time.Duration
+	timer   *time.Timer
+	stalled int32
+}
+
+func newStallReader(body io.ReadCloser, timeout time.Duration) *stallReader {
+	r := &stallReader{body: body, timeout: timeout}
+	r.timer = time.AfterFunc(timeout, func() {
+		atomic.StoreInt32(&r.stalled, 1)
+		body.Close()
+	})
+	return r
+}
+
+func (r *stallReader) Read(p []byte) (int, error) {
+	n, err := r.body.Read(p)
+	if n > 0 {
+		r.timer.Reset(r.timeout)
+	}
+	if err != nil && atomic.LoadInt32(&r.stalled) == 1 {
+		err = ErrStreamStalled
+	}
+	return n, err
+}
+
+func (r *stallReader) Close() error {
+	r.timer.Stop()
+	return r.body.Close()
+}
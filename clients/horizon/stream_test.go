@@ -0,0 +1,199 @@
+package horizon
+
+import (
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stellar/go/support/http/httptest"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+)
+
+var ledgerStreamResponse = `event: message
+data: {"id":"1","paging_token":"100","sequence":1}
+
+event: message
+data: {"id":"2","paging_token":"200","sequence":2}
+
+`
+
+func TestStream_ReconnectPolicy(t *testing.T) {
+	hmock := httptest.NewClient()
+	client := &Client{
+		URL:  "https://localhost",
+		HTTP: hmock,
+		StreamPolicy: &StreamPolicy{
+			MaxReconnects: 2,
+			MinBackoff:    time.Millisecond,
+			MaxBackoff:    time.Millisecond,
+		},
+	}
+
+	calls := 0
+	hmock.On("GET", "https://localhost/ledgers").Return(func(req *http.Request) (*http.Response, error) {
+		calls++
+		if calls == 1 {
+			return httpmock.NewStringResponse(503, notFoundResponse), nil
+		}
+		return httpmock.NewStringResponse(200, ledgerStreamResponse), nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var sequences []int32
+	err := client.StreamLedgers(ctx, nil, func(l Ledger) {
+		sequences = append(sequences, l.Sequence)
+		if len(sequences) == 2 {
+			cancel()
+		}
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []int32{1, 2}, sequences)
+	assert.Equal(t, 2, calls)
+}
+
+func TestStream_MaxReconnects(t *testing.T) {
+	hmock := httptest.NewClient()
+	client := &Client{
+		URL:  "https://localhost",
+		HTTP: hmock,
+		StreamPolicy: &StreamPolicy{
+			MaxReconnects: 2,
+			MinBackoff:    time.Millisecond,
+			MaxBackoff:    time.Millisecond,
+		},
+	}
+
+	calls := 0
+	hmock.On("GET", "https://localhost/ledgers").Return(func(req *http.Request) (*http.Response, error) {
+		calls++
+		return httpmock.NewStringResponse(503, notFoundResponse), nil
+	})
+
+	err := client.StreamLedgers(context.Background(), nil, func(l Ledger) {})
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "too many failed reconnection attempts")
+	}
+	assert.Equal(t, 3, calls)
+}
+
+func TestStream_NoPolicy(t *testing.T) {
+	hmock := httptest.NewClient()
+	client := &Client{URL: "https://localhost", HTTP: hmock}
+
+	hmock.On("GET", "https://localhost/ledgers").ReturnString(503, notFoundResponse)
+
+	err := client.StreamLedgers(context.Background(), nil, func(l Ledger) {})
+	_, ok := err.(*Error)
+	assert.True(t, ok)
+}
+
+// blockingBody returns its data and then blocks until it is closed.
+type blockingBody struct {
+	data   *strings.Reader
+	closed chan struct{}
+}
+
+func (b *blockingBody) Read(p []byte) (int, error) {
+	if b.data.Len() > 0 {
+		return b.data.Read(p)
+	}
+	<-b.closed
+	return 0, http.ErrBodyReadAfterClose
+}
+
+func (b *blockingBody) Close() error {
+	select {
+	case <-b.closed:
+	default:
+		close(b.closed)
+	}
+	return nil
+}
+
+func TestStallReader(t *testing.T) {
+	body := &blockingBody{data: strings.NewReader("hello"), closed: make(chan struct{})}
+	r := newStallReader(body, 10*time.Millisecond)
+	defer r.Close()
+
+	data, err := ioutil.ReadAll(r)
+	assert.Equal(t, "hello", string(data))
+	assert.Equal(t, ErrStreamStalled, err)
+}
+
+func TestParseEvent(t *testing.T) {
+	ev, err := parseEvent([]byte("retry: 1000\nid: 12884905985\nevent: message\ndata: {\"a\":1}\n\n"))
+	if assert.NoError(t, err) {
+		assert.Equal(t, "message", ev.Event)
+		assert.Equal(t, "12884905985", ev.ID)
+		assert.Equal(t, time.Second, ev.Retry)
+		assert.Equal(t, `{"a":1}`, string(ev.Data))
+	}
+
+	// event defaults to message, comments are ignored and data lines joined
+	ev, err = parseEvent([]byte(": heartbeat\ndata: a\ndata: b\n\n"))
+	if assert.NoError(t, err) {
+		assert.Equal(t, "message", ev.Event)
+		assert.Equal(t, "", ev.ID)
+		assert.Equal(t, time.Duration(0), ev.Retry)
+		assert.Equal(t, "a\nb", string(ev.Data))
+	}
+
+	_, err = parseEvent([]byte("retry: soon\ndata: a\n\n"))
+	assert.Error(t, err)
+}
+
+func TestStreamRaw(t *testing.T) {
+	hmock := httptest.NewClient()
+	client := &Client{URL: "https://localhost", HTTP: hmock}
+
+	var urls []string
+	hmock.On("GET", "https://localhost/ledgers").Return(func(req *http.Request) (*http.Response, error) {
+		urls = append(urls, req.URL.String())
+		return httpmock.NewStringResponse(200, `retry: 1
+event: open
+data: "hello"
+
+id: 100
+data: {"sequence":1}
+
+id: 200
+data: {"sequence":2}
+
+event: close
+data: "byebye"
+
+`), nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var events []Event
+	err := client.StreamRaw(ctx, "/ledgers", nil, func(ev Event) error {
+		events = append(events, ev)
+		if len(events) == 5 {
+			cancel()
+		}
+		return nil
+	})
+
+	assert.NoError(t, err)
+	if assert.Len(t, events, 5) {
+		assert.Equal(t, "open", events[0].Event)
+		assert.Equal(t, time.Millisecond, events[0].Retry)
+		assert.Equal(t, "100", events[1].ID)
+		assert.Equal(t, "200", events[2].ID)
+		assert.Equal(t, "close", events[3].Event)
+		assert.Equal(t, "open", events[4].Event)
+	}
+	if assert.Len(t, urls, 2) {
+		assert.Equal(t, "https://localhost/ledgers?cursor=200", urls[1])
+	}
+}
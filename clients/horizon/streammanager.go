@@ -0,0 +1,255 @@
+package horizon
+
+import (
+	"sync"
+	"time"
+
+	"github.com/stellar/go/support/errors"
+	"golang.org/x/net/context"
+)
+
+// DefaultStreamRestartDelay is the delay before a failed stream is restarted
+// by a StreamManager whose RestartDelay is not set.
+const DefaultStreamRestartDelay = 5 * time.Second
+
+// DefaultDedupeSize is the number of resource ids remembered by a
+// StreamManager whose DedupeSize is not set.
+const DefaultDedupeSize = 10000
+
+// CursorStore persists the cursors of the streams run by a StreamManager, so
+// that they resume where they stopped after a restart of the application.
+// Implementations must be safe for concurrent use.
+type CursorStore interface {
+	// GetCursor returns the cursor stored for the stream identified by key,
+	// or an empty string if there is none.
+	GetCursor(key string) (string, error)
+
+	// SetCursor stores the cursor of the stream identified by key.
+	SetCursor(key string, cursor string) error
+}
+
+// MemoryCursorStore is a CursorStore keeping the cursors in memory, for
+// applications that don't need to resume streams across restarts.
+type MemoryCursorStore struct {
+	mutex   sync.Mutex
+	cursors map[string]string
+}
+
+// NewMemoryCursorStore returns an empty MemoryCursorStore.
+func NewMemoryCursorStore() *MemoryCursorStore {
+	return &MemoryCursorStore{cursors: map[string]string{}}
+}
+
+// GetCursor implements CursorStore
+func (s *MemoryCursorStore) GetCursor(key string) (string, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.cursors[key], nil
+}
+
+// SetCursor implements CursorStore
+func (s *MemoryCursorStore) SetCursor(key string, cursor string) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.cursors[key] = cursor
+	return nil
+}
+
+// StreamErrorHandler is a function that is called when a stream run by a
+// StreamManager fails, before it is restarted.
+type StreamErrorHandler func(key string, err error)
+
+// StreamManager runs the payment and transaction streams of many accounts
+// concurrently.  Each stream saves its cursor in a CursorStore after every
+// resource, resumes from it when (re)started, and is restarted on its own
+// when it fails.  Resources received by several streams, e.g. a payment
+// between two watched accounts, are only passed to a handler once.
+//
+// Handlers of different streams are called concurrently, so they must be safe
+// for concurrent use.
+type StreamManager struct {
+	// RestartDelay is the delay before a failed stream is restarted.
+	// DefaultStreamRestartDelay is used when zero.
+	RestartDelay time.Duration
+
+	// DedupeSize is the number of recently received resource ids remembered
+	// to detect duplicates.  DefaultDedupeSize is used when zero.
+	DedupeSize int
+
+	// OnError, when set, is called with the errors of the streams and of the
+	// cursor store.
+	OnError StreamErrorHandler
+
+	client ClientInterface
+	store  CursorStore
+	ctx    context.Context
+	cancel context.CancelFunc
+	wg     sync.WaitGroup
+
+	mutex   sync.Mutex
+	streams map[string]context.CancelFunc
+	seen    map[string]struct{}
+	order   []string
+}
+
+// NewStreamManager returns a StreamManager streaming from client and saving
+// cursors in store.
+func NewStreamManager(client ClientInterface, store CursorStore) *StreamManager {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &StreamManager{
+		client:  client,
+		store:   store,
+		ctx:     ctx,
+		cancel:  cancel,
+		streams: map[string]context.CancelFunc{},
+		seen:    map[string]struct{}{},
+	}
+}
+
+// WatchPayments starts streaming the payments of accountID to handler.  It
+// does nothing if the payments of the account are already watched.
+func (m *StreamManager) WatchPayments(accountID string, handler PaymentHandler) {
+	m.watch("payments/"+accountID, func(ctx context.Context, cursor *Cursor, save func(string)) error {
+		return m.client.StreamPayments(ctx, accountID, cursor, func(p Payment) {
+			if m.firstSeen("payment/" + p.ID) {
+				handler(p)
+			}
+			save(p.PagingToken)
+		})
+	})
+}
+
+// WatchTransactions starts streaming the transactions of accountID to
+// handler.  It does nothing if the transactions of the account are already
+// watched.
+func (m *StreamManager) WatchTransactions(accountID string, handler TransactionHandler) {
+	m.watch("transactions/"+accountID, func(ctx context.Context, cursor *Cursor, save func(string)) error {
+		return m.client.StreamTransactions(ctx, accountID, cursor, func(tx Transaction) {
+			if m.firstSeen("transaction/" + tx.ID) {
+				handler(tx)
+			}
+			save(tx.PagingToken)
+		})
+	})
+}
+
+// Unwatch stops the payment and transaction streams of accountID.
+func (m *StreamManager) Unwatch(accountID string) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	for _, key := range []string{"payments/" + accountID, "transactions/" + accountID} {
+		if cancel, ok := m.streams[key]; ok {
+			cancel()
+			delete(m.streams, key)
+		}
+	}
+}
+
+// Close stops all the streams and waits for them to return.
+func (m *StreamManager) Close() {
+	m.cancel()
+	m.wg.Wait()
+}
+
+// streamFunc runs a stream from cursor until it fails or ctx is done, calling
+// save with the paging token of each resource received.
+type streamFunc func(ctx context.Context, cursor *Cursor, save func(string)) error
+
+// watch starts running the stream identified by key in the background,
+// unless it is already running.
+func (m *StreamManager) watch(key string, stream streamFunc) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if _, ok := m.streams[key]; ok {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(m.ctx)
+	m.streams[key] = cancel
+
+	m.wg.Add(1)
+	go func() {
+		defer m.wg.Done()
+		m.run(ctx, key, stream)
+	}()
+}
+
+// run runs the stream identified by key, restarting it from its saved cursor
+// whenever it fails, until ctx is done.
+func (m *StreamManager) run(ctx context.Context, key string, stream streamFunc) {
+	save := func(cursor string) {
+		err := m.store.SetCursor(key, cursor)
+		if err != nil {
+			m.reportError(key, errors.Wrap(err, "save cursor failed"))
+		}
+	}
+
+	for {
+		saved, err := m.store.GetCursor(key)
+		if err != nil {
+			err = errors.Wrap(err, "load cursor failed")
+		} else {
+			var cursor *Cursor
+			if saved != "" {
+				c := Cursor(saved)
+				cursor = &c
+			}
+			err = stream(ctx, cursor, save)
+		}
+
+		if ctx.Err() != nil {
+			return
+		}
+
+		if err == nil {
+			err = errors.New("stream closed")
+		}
+		m.reportError(key, err)
+
+		delay := m.RestartDelay
+		if delay <= 0 {
+			delay = DefaultStreamRestartDelay
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(delay):
+		}
+	}
+}
+
+// firstSeen reports whether the resource identified by id is received for the
+// first time, remembering it.
+func (m *StreamManager) firstSeen(id string) bool {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if _, ok := m.seen[id]; ok {
+		return false
+	}
+
+	size := m.DedupeSize
+	if size <= 0 {
+		size = DefaultDedupeSize
+	}
+
+	m.seen[id] = struct{}{}
+	m.order = append(m.order, id)
+	for len(m.order) > size {
+		delete(m.seen, m.order[0])
+		m.order = m.order[1:]
+	}
+	return true
+}
+
+func (m *StreamManager) reportError(key string, err error) {
+	if m.OnError != nil {
+		m.OnError(key, err)
+	}
+}
+
+// ensure that MemoryCursorStore implements CursorStore
+var _ CursorStore = &MemoryCursorStore{}
@@ -0,0 +1,123 @@
+package horizon
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/context"
+)
+
+func TestStreamManager(t *testing.T) {
+	client := &MockClient{}
+	store := NewMemoryCursorStore()
+	require.NoError(t, store.SetCursor("payments/GA", "5"))
+
+	payment := func(id string) Payment {
+		return Payment{ID: id, PagingToken: id}
+	}
+
+	var mutex sync.Mutex
+	var cursors []string
+	recordCursor := func(args mock.Arguments) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		cursors = append(cursors, string(*args.Get(2).(*Cursor)))
+	}
+
+	// the first stream of GA fails after a payment, and is restarted from
+	// its saved cursor
+	client.On("StreamPayments", mock.Anything, "GA", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			recordCursor(args)
+			args.Get(3).(PaymentHandler)(payment("6"))
+		}).
+		Return(errors.New("boom")).Once()
+	client.On("StreamPayments", mock.Anything, "GA", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			recordCursor(args)
+			args.Get(3).(PaymentHandler)(payment("7"))
+			<-args.Get(0).(context.Context).Done()
+		}).
+		Return(nil)
+
+	// GB receives payment 7 as well
+	received := make(chan struct{})
+	client.On("StreamPayments", mock.Anything, "GB", (*Cursor)(nil), mock.Anything).
+		Run(func(args mock.Arguments) {
+			<-received
+			args.Get(3).(PaymentHandler)(payment("7"))
+			<-args.Get(0).(context.Context).Done()
+		}).
+		Return(nil)
+
+	manager := NewStreamManager(client, store)
+	manager.RestartDelay = time.Millisecond
+
+	var errs []string
+	manager.OnError = func(key string, err error) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		errs = append(errs, key+": "+err.Error())
+	}
+
+	var ids []string
+	handled := make(chan string, 10)
+	handler := func(p Payment) {
+		handled <- p.ID
+	}
+
+	manager.WatchPayments("GA", handler)
+	manager.WatchPayments("GA", handler)
+
+	ids = append(ids, <-handled, <-handled)
+	assert.Equal(t, []string{"6", "7"}, ids)
+
+	manager.WatchPayments("GB", handler)
+	close(received)
+
+	select {
+	case id := <-handled:
+		t.Fatalf("duplicate payment %s", id)
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	manager.Close()
+
+	assert.Equal(t, []string{"5", "6"}, cursors)
+	assert.Equal(t, []string{"payments/GA: boom"}, errs)
+
+	cursor, err := store.GetCursor("payments/GB")
+	require.NoError(t, err)
+	assert.Equal(t, "7", cursor)
+}
+
+func TestStreamManager_Unwatch(t *testing.T) {
+	client := &MockClient{}
+	stopped := make(chan struct{})
+	client.On("StreamTransactions", mock.Anything, "GA", (*Cursor)(nil), mock.Anything).
+		Run(func(args mock.Arguments) {
+			<-args.Get(0).(context.Context).Done()
+			close(stopped)
+		}).
+		Return(nil).Once()
+
+	manager := NewStreamManager(client, NewMemoryCursorStore())
+	manager.OnError = func(key string, err error) {
+		t.Errorf("unexpected error for %s: %s", key, err)
+	}
+
+	manager.WatchTransactions("GA", func(Transaction) {})
+	manager.Unwatch("GA")
+
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		t.Fatal("stream was not stopped")
+	}
+	manager.Close()
+}
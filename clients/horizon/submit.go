@@ -0,0 +1,82 @@
+package horizon
+
+import (
+	"github.com/stellar/go/support/errors"
+	"github.com/stellar/go/xdr"
+	"golang.org/x/net/context"
+)
+
+// ResignFunc rebuilds and signs a transaction rejected with tx_bad_seq.  It is
+// called with the current sequence number of the source account, so the new
+// transaction must use sequence+1, and returns the new base64 encoded
+// envelope.
+type ResignFunc func(sequence xdr.SequenceNumber) (string, error)
+
+// SubmitOptions configures SubmitTransactionWithOptions.
+type SubmitOptions struct {
+	// MaxResubmits is the number of times a transaction rejected with
+	// tx_bad_seq is rebuilt and resubmitted.  Zero disables resubmission.
+	MaxResubmits int
+
+	// Resign is called to rebuild the transaction after a tx_bad_seq
+	// rejection.  Resubmission is disabled when nil.
+	Resign ResignFunc
+}
+
+// SubmitTransactionWithOptions submits a transaction to the network like
+// SubmitTransactionWithContext.  When the transaction is rejected because its
+// sequence number is stale (tx_bad_seq), the sequence of the source account
+// is reloaded and passed to opts.Resign, and the returned envelope is
+// submitted instead, up to opts.MaxResubmits times.
+func (c *Client) SubmitTransactionWithOptions(
+	ctx context.Context,
+	transactionEnvelopeXdr string,
+	opts SubmitOptions,
+) (response TransactionSuccess, err error) {
+	for attempt := 0; ; attempt++ {
+		response, err = c.SubmitTransactionWithContext(ctx, transactionEnvelopeXdr)
+		if err == nil || !isBadSequence(err) {
+			return
+		}
+
+		if opts.Resign == nil || attempt >= opts.MaxResubmits {
+			return
+		}
+
+		var envelope xdr.TransactionEnvelope
+		err = xdr.SafeUnmarshalBase64(transactionEnvelopeXdr, &envelope)
+		if err != nil {
+			err = errors.Wrap(err, "decode envelope failed")
+			return
+		}
+
+		var seq xdr.SequenceNumber
+		seq, err = c.SequenceForAccountWithContext(ctx, envelope.Tx.SourceAccount.Address())
+		if err != nil {
+			err = errors.Wrap(err, "refresh sequence failed")
+			return
+		}
+
+		transactionEnvelopeXdr, err = opts.Resign(seq)
+		if err != nil {
+			err = errors.Wrap(err, "resign failed")
+			return
+		}
+	}
+}
+
+// isBadSequence returns true if err is the rejection of a transaction whose
+// sequence number is not the next one of its source account.
+func isBadSequence(err error) bool {
+	herr, ok := AsError(err)
+	if !ok || !herr.IsTransactionFailed() {
+		return false
+	}
+
+	codes, err := herr.ResultCodes()
+	if err != nil {
+		return false
+	}
+
+	return codes.TransactionCode == "tx_bad_seq"
+}
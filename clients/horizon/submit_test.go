@@ -0,0 +1,118 @@
+package horizon
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/jarcoal/httpmock"
+	"github.com/stellar/go/support/http/httptest"
+	"github.com/stellar/go/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/net/context"
+)
+
+func TestClient_SubmitTransactionWithOptions(t *testing.T) {
+	tx := "AAAAADSMMRmQGDH6EJzkgi/7PoKhphMHyNGQgDp2tlS/dhGXAAAAZAAT3TUAAAAwAAAAAAAAAAAAAAABAAAAAAAAAAMAAAABSU5SAAAAAAA0jDEZkBgx+hCc5IIv+z6CoaYTB8jRkIA6drZUv3YRlwAAAAFVU0QAAAAAADSMMRmQGDH6EJzkgi/7PoKhphMHyNGQgDp2tlS/dhGXAAAAAAX14QAAAAAKAAAAAQAAAAAAAAAAAAAAAAAAAAG/dhGXAAAAQLuStfImg0OeeGAQmvLkJSZ1MPSkCzCYNbGqX5oYNuuOqZ5SmWhEsC7uOD9ha4V7KengiwNlc0oMNqBVo22S7gk="
+
+	var envelope xdr.TransactionEnvelope
+	require.NoError(t, xdr.SafeUnmarshalBase64(tx, &envelope))
+	source := envelope.Tx.SourceAccount.Address()
+
+	// submissions is a responder failing the first failures submissions with
+	// tx_bad_seq and recording the submitted envelopes.
+	submissions := func(failures int, submitted *[]string) httpmock.Responder {
+		return func(req *http.Request) (*http.Response, error) {
+			*submitted = append(*submitted, req.FormValue("tx"))
+			if len(*submitted) <= failures {
+				return httpmock.NewStringResponse(http.StatusBadRequest, badSequenceResponse), nil
+			}
+			return httpmock.NewStringResponse(http.StatusOK, submitResponse), nil
+		}
+	}
+
+	t.Run("resubmits after tx_bad_seq", func(t *testing.T) {
+		hmock := httptest.NewClient()
+		client := &Client{URL: "https://localhost", HTTP: hmock}
+
+		var submitted []string
+		hmock.On("POST", "https://localhost/transactions").Return(submissions(1, &submitted))
+		hmock.On("GET", "https://localhost/accounts/"+source).
+			ReturnString(200, `{"id": "`+source+`", "sequence": "42"}`)
+
+		var sequences []xdr.SequenceNumber
+		result, err := client.SubmitTransactionWithOptions(context.Background(), tx, SubmitOptions{
+			MaxResubmits: 2,
+			Resign: func(seq xdr.SequenceNumber) (string, error) {
+				sequences = append(sequences, seq)
+				return "resigned", nil
+			},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, int32(3128812), result.Ledger)
+		assert.Equal(t, []xdr.SequenceNumber{42}, sequences)
+		assert.Equal(t, []string{tx, "resigned"}, submitted)
+	})
+
+	t.Run("gives up after max resubmits", func(t *testing.T) {
+		hmock := httptest.NewClient()
+		client := &Client{URL: "https://localhost", HTTP: hmock}
+
+		var submitted []string
+		hmock.On("POST", "https://localhost/transactions").Return(submissions(10, &submitted))
+		hmock.On("GET", "https://localhost/accounts/"+source).
+			ReturnString(200, `{"id": "`+source+`", "sequence": "42"}`)
+
+		_, err := client.SubmitTransactionWithOptions(context.Background(), tx, SubmitOptions{
+			MaxResubmits: 2,
+			Resign: func(seq xdr.SequenceNumber) (string, error) {
+				return tx, nil
+			},
+		})
+		require.Error(t, err)
+		assert.True(t, isBadSequence(err))
+		assert.Len(t, submitted, 3)
+	})
+
+	t.Run("disabled without a resign func", func(t *testing.T) {
+		hmock := httptest.NewClient()
+		client := &Client{URL: "https://localhost", HTTP: hmock}
+
+		var submitted []string
+		hmock.On("POST", "https://localhost/transactions").Return(submissions(1, &submitted))
+
+		_, err := client.SubmitTransactionWithOptions(context.Background(), tx, SubmitOptions{MaxResubmits: 2})
+		require.Error(t, err)
+		assert.Len(t, submitted, 1)
+	})
+
+	t.Run("does not resubmit other failures", func(t *testing.T) {
+		hmock := httptest.NewClient()
+		client := &Client{URL: "https://localhost", HTTP: hmock}
+
+		hmock.On("POST", "https://localhost/transactions").ReturnString(400, transactionFailure)
+
+		called := false
+		_, err := client.SubmitTransactionWithOptions(context.Background(), tx, SubmitOptions{
+			MaxResubmits: 2,
+			Resign: func(seq xdr.SequenceNumber) (string, error) {
+				called = true
+				return tx, nil
+			},
+		})
+		require.Error(t, err)
+		assert.False(t, called)
+	})
+}
+
+var badSequenceResponse = `{
+  "type": "https://stellar.org/horizon-errors/transaction_failed",
+  "title": "Transaction Failed",
+  "status": 400,
+  "extras": {
+    "result_codes": {
+      "transaction": "tx_bad_seq"
+    },
+    "result_xdr": "AAAAAAAAAAD////7AAAAAA=="
+  }
+}`
@@ -0,0 +1,22 @@
+package txnbuild
+
+import (
+	"github.com/stellar/go/xdr"
+)
+
+// AccountMerge is an account_merge operation, transferring the lumens of the
+// source account to Destination and removing the source account.
+type AccountMerge struct {
+	Destination   string
+	SourceAccount string
+}
+
+// BuildXDR implements Operation.
+func (op AccountMerge) BuildXDR() (xdr.Operation, error) {
+	destination, err := parseAccount("Destination", op.Destination)
+	if err != nil {
+		return xdr.Operation{}, err
+	}
+
+	return newOperation(op.SourceAccount, xdr.OperationTypeAccountMerge, destination)
+}
@@ -0,0 +1,20 @@
+package txnbuild
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccountMerge_BuildXDR(t *testing.T) {
+	op, err := AccountMerge{Destination: address2}.BuildXDR()
+	require.NoError(t, err)
+	destination := op.Body.MustDestination()
+	assert.Equal(t, address2, destination.Address())
+
+	_, err = AccountMerge{Destination: "foo"}.BuildXDR()
+	if assert.IsType(t, &FieldError{}, err) {
+		assert.Equal(t, "Destination", err.(*FieldError).Field)
+	}
+}
@@ -0,0 +1,48 @@
+package txnbuild
+
+import (
+	"github.com/stellar/go/support/errors"
+	"github.com/stellar/go/xdr"
+)
+
+// AllowTrust is an allow_trust operation, authorizing or deauthorizing the
+// trust line of Trustor to the asset with AssetCode issued by the source
+// account.
+type AllowTrust struct {
+	Trustor       string
+	AssetCode     string
+	Authorize     bool
+	SourceAccount string
+}
+
+// BuildXDR implements Operation.
+func (op AllowTrust) BuildXDR() (xdr.Operation, error) {
+	trustor, err := parseAccount("Trustor", op.Trustor)
+	if err != nil {
+		return xdr.Operation{}, err
+	}
+
+	var asset xdr.AllowTrustOpAsset
+	length := len(op.AssetCode)
+	switch {
+	case length >= 1 && length <= 4:
+		var code [4]byte
+		copy(code[:], op.AssetCode)
+		asset, err = xdr.NewAllowTrustOpAsset(xdr.AssetTypeAssetTypeCreditAlphanum4, code)
+	case length >= 5 && length <= 12:
+		var code [12]byte
+		copy(code[:], op.AssetCode)
+		asset, err = xdr.NewAllowTrustOpAsset(xdr.AssetTypeAssetTypeCreditAlphanum12, code)
+	default:
+		err = &FieldError{Field: "AssetCode", Err: errors.New("asset code length is invalid")}
+	}
+	if err != nil {
+		return xdr.Operation{}, err
+	}
+
+	return newOperation(op.SourceAccount, xdr.OperationTypeAllowTrust, xdr.AllowTrustOp{
+		Trustor:   trustor,
+		Asset:     asset,
+		Authorize: op.Authorize,
+	})
+}
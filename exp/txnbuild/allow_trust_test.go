@@ -0,0 +1,29 @@
+package txnbuild
+
+import (
+	"testing"
+
+	"github.com/stellar/go/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAllowTrust_BuildXDR(t *testing.T) {
+	op, err := AllowTrust{Trustor: address2, AssetCode: "USD", Authorize: true}.BuildXDR()
+	require.NoError(t, err)
+
+	body := op.Body.MustAllowTrustOp()
+	assert.Equal(t, address2, body.Trustor.Address())
+	assert.Equal(t, xdr.AssetTypeAssetTypeCreditAlphanum4, body.Asset.Type)
+	assert.Equal(t, [4]byte{'U', 'S', 'D'}, body.Asset.MustAssetCode4())
+	assert.True(t, body.Authorize)
+
+	op, err = AllowTrust{Trustor: address2, AssetCode: "LONGCODE"}.BuildXDR()
+	require.NoError(t, err)
+	assert.Equal(t, xdr.AssetTypeAssetTypeCreditAlphanum12, op.Body.MustAllowTrustOp().Asset.Type)
+
+	_, err = AllowTrust{Trustor: address2}.BuildXDR()
+	if assert.IsType(t, &FieldError{}, err) {
+		assert.Equal(t, "AssetCode", err.(*FieldError).Field)
+	}
+}
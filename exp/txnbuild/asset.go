@@ -0,0 +1,48 @@
+package txnbuild
+
+import (
+	"github.com/stellar/go/support/errors"
+	"github.com/stellar/go/xdr"
+)
+
+// NativeAsset is the native asset of the network, lumens.
+var NativeAsset = Asset{}
+
+// Asset is an asset issued on the network. The zero value is the native
+// asset.
+type Asset struct {
+	Code   string
+	Issuer string
+}
+
+// IsNative returns true if the asset is the native asset.
+func (a Asset) IsNative() bool {
+	return a.Code == "" && a.Issuer == ""
+}
+
+// ToXDR returns the XDR form of the asset.
+func (a Asset) ToXDR() (xdr.Asset, error) {
+	if a.IsNative() {
+		return xdr.NewAsset(xdr.AssetTypeAssetTypeNative, nil)
+	}
+
+	var issuer xdr.AccountId
+	err := issuer.SetAddress(a.Issuer)
+	if err != nil {
+		return xdr.Asset{}, errors.Wrap(err, "invalid issuer")
+	}
+
+	length := len(a.Code)
+	switch {
+	case length >= 1 && length <= 4:
+		body := xdr.AssetAlphaNum4{Issuer: issuer}
+		copy(body.AssetCode[:], a.Code)
+		return xdr.NewAsset(xdr.AssetTypeAssetTypeCreditAlphanum4, body)
+	case length >= 5 && length <= 12:
+		body := xdr.AssetAlphaNum12{Issuer: issuer}
+		copy(body.AssetCode[:], a.Code)
+		return xdr.NewAsset(xdr.AssetTypeAssetTypeCreditAlphanum12, body)
+	default:
+		return xdr.Asset{}, errors.New("asset code length is invalid")
+	}
+}
@@ -0,0 +1,31 @@
+package txnbuild
+
+import (
+	"testing"
+
+	"github.com/stellar/go/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAsset_ToXDR(t *testing.T) {
+	asset, err := NativeAsset.ToXDR()
+	require.NoError(t, err)
+	assert.Equal(t, xdr.AssetTypeAssetTypeNative, asset.Type)
+
+	asset, err = Asset{Code: "USD", Issuer: address1}.ToXDR()
+	require.NoError(t, err)
+	assert.Equal(t, xdr.AssetTypeAssetTypeCreditAlphanum4, asset.Type)
+	assert.Equal(t, "credit_alphanum4/USD/"+address1, asset.String())
+
+	asset, err = Asset{Code: "LONGCODE", Issuer: address1}.ToXDR()
+	require.NoError(t, err)
+	assert.Equal(t, xdr.AssetTypeAssetTypeCreditAlphanum12, asset.Type)
+	assert.Equal(t, "credit_alphanum12/LONGCODE/"+address1, asset.String())
+
+	_, err = Asset{Code: "USD", Issuer: "foo"}.ToXDR()
+	assert.Error(t, err)
+
+	_, err = Asset{Code: "1234567890123", Issuer: address1}.ToXDR()
+	assert.Error(t, err)
+}
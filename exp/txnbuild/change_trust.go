@@ -0,0 +1,37 @@
+package txnbuild
+
+import (
+	"math"
+
+	"github.com/stellar/go/xdr"
+)
+
+// ChangeTrust is a change_trust operation, creating, updating or deleting
+// the trust line of the source account to Line. An empty Limit is the
+// maximum limit, a Limit of "0" deletes the trust line.
+type ChangeTrust struct {
+	Line          Asset
+	Limit         string
+	SourceAccount string
+}
+
+// BuildXDR implements Operation.
+func (op ChangeTrust) BuildXDR() (xdr.Operation, error) {
+	line, err := parseAsset("Line", op.Line)
+	if err != nil {
+		return xdr.Operation{}, err
+	}
+
+	limit := xdr.Int64(math.MaxInt64)
+	if op.Limit != "" {
+		limit, err = parseAmount("Limit", op.Limit)
+		if err != nil {
+			return xdr.Operation{}, err
+		}
+	}
+
+	return newOperation(op.SourceAccount, xdr.OperationTypeChangeTrust, xdr.ChangeTrustOp{
+		Line:  line,
+		Limit: limit,
+	})
+}
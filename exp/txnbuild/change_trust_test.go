@@ -0,0 +1,28 @@
+package txnbuild
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChangeTrust_BuildXDR(t *testing.T) {
+	usd := Asset{Code: "USD", Issuer: address1}
+
+	op, err := ChangeTrust{Line: usd}.BuildXDR()
+	require.NoError(t, err)
+	body := op.Body.MustChangeTrustOp()
+	assert.Equal(t, "credit_alphanum4/USD/"+address1, body.Line.String())
+	assert.EqualValues(t, math.MaxInt64, body.Limit)
+
+	op, err = ChangeTrust{Line: usd, Limit: "0"}.BuildXDR()
+	require.NoError(t, err)
+	assert.EqualValues(t, 0, op.Body.MustChangeTrustOp().Limit)
+
+	_, err = ChangeTrust{Line: usd, Limit: "foo"}.BuildXDR()
+	if assert.IsType(t, &FieldError{}, err) {
+		assert.Equal(t, "Limit", err.(*FieldError).Field)
+	}
+}
@@ -0,0 +1,31 @@
+package txnbuild
+
+import (
+	"github.com/stellar/go/xdr"
+)
+
+// CreateAccount is a create_account operation, funding the new account
+// Destination with Amount lumens.
+type CreateAccount struct {
+	Destination   string
+	Amount        string
+	SourceAccount string
+}
+
+// BuildXDR implements Operation.
+func (op CreateAccount) BuildXDR() (xdr.Operation, error) {
+	destination, err := parseAccount("Destination", op.Destination)
+	if err != nil {
+		return xdr.Operation{}, err
+	}
+
+	startingBalance, err := parseAmount("Amount", op.Amount)
+	if err != nil {
+		return xdr.Operation{}, err
+	}
+
+	return newOperation(op.SourceAccount, xdr.OperationTypeCreateAccount, xdr.CreateAccountOp{
+		Destination:     destination,
+		StartingBalance: startingBalance,
+	})
+}
@@ -0,0 +1,23 @@
+package txnbuild
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateAccount_BuildXDR(t *testing.T) {
+	op, err := CreateAccount{Destination: address2, Amount: "41", SourceAccount: address1}.BuildXDR()
+	require.NoError(t, err)
+
+	body := op.Body.MustCreateAccountOp()
+	assert.Equal(t, address2, body.Destination.Address())
+	assert.EqualValues(t, 410000000, body.StartingBalance)
+	assert.Equal(t, address1, op.SourceAccount.Address())
+
+	_, err = CreateAccount{Destination: address2, Amount: "foo"}.BuildXDR()
+	if assert.IsType(t, &FieldError{}, err) {
+		assert.Equal(t, "Amount", err.(*FieldError).Field)
+	}
+}
@@ -0,0 +1,31 @@
+package txnbuild
+
+import (
+	"github.com/stellar/go/xdr"
+)
+
+// CreatePassiveOffer is a create_passive_offer operation, creating an offer
+// to sell Amount of Selling for Buying at Price that doesn't take offers of
+// the same price.
+type CreatePassiveOffer struct {
+	Selling       Asset
+	Buying        Asset
+	Amount        string
+	Price         string
+	SourceAccount string
+}
+
+// BuildXDR implements Operation.
+func (op CreatePassiveOffer) BuildXDR() (xdr.Operation, error) {
+	var (
+		body xdr.CreatePassiveOfferOp
+		err  error
+	)
+
+	body.Selling, body.Buying, body.Amount, body.Price, err = parseOffer(op.Selling, op.Buying, op.Amount, op.Price)
+	if err != nil {
+		return xdr.Operation{}, err
+	}
+
+	return newOperation(op.SourceAccount, xdr.OperationTypeCreatePassiveOffer, body)
+}
@@ -0,0 +1,30 @@
+package txnbuild
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreatePassiveOffer_BuildXDR(t *testing.T) {
+	op, err := CreatePassiveOffer{
+		Selling: Asset{Code: "USD", Issuer: address1},
+		Buying:  NativeAsset,
+		Amount:  "10",
+		Price:   "4",
+	}.BuildXDR()
+	require.NoError(t, err)
+
+	body := op.Body.MustCreatePassiveOfferOp()
+	assert.Equal(t, "credit_alphanum4/USD/"+address1, body.Selling.String())
+	assert.Equal(t, "native", body.Buying.String())
+	assert.EqualValues(t, 100000000, body.Amount)
+	assert.EqualValues(t, 4, body.Price.N)
+	assert.EqualValues(t, 1, body.Price.D)
+
+	_, err = CreatePassiveOffer{Selling: NativeAsset, Buying: NativeAsset, Amount: "foo", Price: "1"}.BuildXDR()
+	if assert.IsType(t, &FieldError{}, err) {
+		assert.Equal(t, "Amount", err.(*FieldError).Field)
+	}
+}
@@ -0,0 +1,44 @@
+package txnbuild
+
+import (
+	"fmt"
+
+	"github.com/stellar/go/support/errors"
+)
+
+var (
+	// ErrMissingNetwork is returned by Transaction.Build when the network
+	// passphrase of the transaction is not set.
+	ErrMissingNetwork = errors.New("network passphrase is required")
+
+	// ErrNoOperations is returned by Transaction.Build when the transaction
+	// has no operations.
+	ErrNoOperations = errors.New("transaction has no operations")
+
+	// ErrNotBuilt is returned when a transaction is signed, hashed or
+	// encoded before Transaction.Build is called.
+	ErrNotBuilt = errors.New("transaction has not been built")
+)
+
+// FieldError is returned when a field of a transaction or an operation is
+// invalid.
+type FieldError struct {
+	Field string
+	Err   error
+}
+
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("invalid %s: %s", e.Field, e.Err)
+}
+
+// OperationError is returned by Transaction.Build when the operation at Index
+// can't be built. Err is usually a *FieldError.
+type OperationError struct {
+	Index     int
+	Operation Operation
+	Err       error
+}
+
+func (e *OperationError) Error() string {
+	return fmt.Sprintf("operation %d (%T): %s", e.Index, e.Operation, e.Err)
+}
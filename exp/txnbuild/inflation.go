@@ -0,0 +1,15 @@
+package txnbuild
+
+import (
+	"github.com/stellar/go/xdr"
+)
+
+// Inflation is an inflation operation, running the inflation of the network.
+type Inflation struct {
+	SourceAccount string
+}
+
+// BuildXDR implements Operation.
+func (op Inflation) BuildXDR() (xdr.Operation, error) {
+	return newOperation(op.SourceAccount, xdr.OperationTypeInflation, nil)
+}
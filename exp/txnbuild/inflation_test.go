@@ -0,0 +1,21 @@
+package txnbuild
+
+import (
+	"testing"
+
+	"github.com/stellar/go/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestInflation_BuildXDR(t *testing.T) {
+	op, err := Inflation{SourceAccount: address1}.BuildXDR()
+	require.NoError(t, err)
+	assert.Equal(t, xdr.OperationTypeInflation, op.Body.Type)
+	assert.Equal(t, address1, op.SourceAccount.Address())
+
+	_, err = Inflation{SourceAccount: "foo"}.BuildXDR()
+	if assert.IsType(t, &FieldError{}, err) {
+		assert.Equal(t, "SourceAccount", err.(*FieldError).Field)
+	}
+}
@@ -0,0 +1,108 @@
+package txnbuild
+
+import (
+	"github.com/stellar/go/amount"
+	"github.com/stellar/go/price"
+	"github.com/stellar/go/xdr"
+)
+
+// flagsToXDR returns the XDR form of flags, or nil when there are no flags.
+func flagsToXDR(flags []AccountFlag) *xdr.Uint32 {
+	if len(flags) == 0 {
+		return nil
+	}
+
+	var value xdr.Uint32
+	for _, flag := range flags {
+		value |= xdr.Uint32(flag)
+	}
+	return &value
+}
+
+// newOperation returns an operation with the given body, setting its source
+// account when source is not empty.
+func newOperation(source string, typ xdr.OperationType, body interface{}) (xdr.Operation, error) {
+	var op xdr.Operation
+
+	if source != "" {
+		aid, err := parseAccount("SourceAccount", source)
+		if err != nil {
+			return op, err
+		}
+		op.SourceAccount = &aid
+	}
+
+	var err error
+	op.Body, err = xdr.NewOperationBody(typ, body)
+	return op, err
+}
+
+func parseAccount(field, address string) (xdr.AccountId, error) {
+	var aid xdr.AccountId
+	err := aid.SetAddress(address)
+	if err != nil {
+		return aid, &FieldError{Field: field, Err: err}
+	}
+	return aid, nil
+}
+
+func parseAmount(field, value string) (xdr.Int64, error) {
+	parsed, err := amount.Parse(value)
+	if err != nil {
+		return 0, &FieldError{Field: field, Err: err}
+	}
+	return parsed, nil
+}
+
+func parseAsset(field string, asset Asset) (xdr.Asset, error) {
+	xdrAsset, err := asset.ToXDR()
+	if err != nil {
+		return xdrAsset, &FieldError{Field: field, Err: err}
+	}
+	return xdrAsset, nil
+}
+
+// parseOffer parses the fields shared by offer operations.
+func parseOffer(selling, buying Asset, amount, price string) (xdr.Asset, xdr.Asset, xdr.Int64, xdr.Price, error) {
+	var (
+		xdrSelling, xdrBuying xdr.Asset
+		xdrAmount             xdr.Int64
+		xdrPrice              xdr.Price
+		err                   error
+	)
+
+	xdrSelling, err = parseAsset("Selling", selling)
+	if err != nil {
+		return xdrSelling, xdrBuying, xdrAmount, xdrPrice, err
+	}
+
+	xdrBuying, err = parseAsset("Buying", buying)
+	if err != nil {
+		return xdrSelling, xdrBuying, xdrAmount, xdrPrice, err
+	}
+
+	xdrAmount, err = parseAmount("Amount", amount)
+	if err != nil {
+		return xdrSelling, xdrBuying, xdrAmount, xdrPrice, err
+	}
+
+	xdrPrice, err = parsePrice("Price", price)
+	return xdrSelling, xdrBuying, xdrAmount, xdrPrice, err
+}
+
+func parsePrice(field, value string) (xdr.Price, error) {
+	parsed, err := price.Parse(value)
+	if err != nil {
+		return parsed, &FieldError{Field: field, Err: err}
+	}
+	return parsed, nil
+}
+
+func uint32ToXDR(value *uint32) *xdr.Uint32 {
+	if value == nil {
+		return nil
+	}
+
+	xdrValue := xdr.Uint32(*value)
+	return &xdrValue
+}
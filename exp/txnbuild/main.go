@@ -0,0 +1,73 @@
+// Package txnbuild implements an API to build, sign and encode stellar
+// transactions using plain structs.
+//
+// Each operation type is a struct, e.g. Payment, whose fields are the
+// parameters of the operation. Operations are added to a Transaction which is
+// then explicitly built, signed and encoded:
+//
+//	tx := txnbuild.Transaction{
+//		SourceAccount: "GAXEMCEXBERNSRXOEKD4JAIKVECIXQCENHEBRVSPX2TTYZPMNEDSQCNQ",
+//		Sequence:      1,
+//		Network:       network.TestNetworkPassphrase,
+//		Operations: []txnbuild.Operation{
+//			txnbuild.Payment{
+//				Destination: "GBRPYHIL2CI3FNQ4BXLFMNDLFJUNPU2HY3ZMFSHONUCEOASW7QC7OX2H",
+//				Amount:      "10",
+//				Asset:       txnbuild.NativeAsset,
+//			},
+//		},
+//	}
+//
+//	err := tx.Build()
+//	err = tx.Sign(seed)
+//	txeB64, err := tx.Base64()
+//
+// Invalid transactions are reported using the typed errors in errors.go, so
+// callers can tell which operation and which field is invalid.
+//
+// This package is an experimental replacement of the mutator based `build`
+// package.
+package txnbuild
+
+import (
+	"github.com/stellar/go/xdr"
+)
+
+const (
+	// DefaultBaseFee is the fee per operation, in stroops, used when the
+	// BaseFee of a transaction is zero.
+	DefaultBaseFee = 100
+
+	// MemoTextMaxLength is the maximum number of bytes of a MemoText.
+	MemoTextMaxLength = 28
+)
+
+// AccountFlag is a flag of an account, set or cleared by SetOptions.
+type AccountFlag uint32
+
+const (
+	// AuthRequired requires the issuer of the account to approve trust lines
+	// to its assets.
+	AuthRequired = AccountFlag(xdr.AccountFlagsAuthRequiredFlag)
+
+	// AuthRevocable allows the issuer of the account to revoke its approval
+	// of trust lines to its assets.
+	AuthRevocable = AccountFlag(xdr.AccountFlagsAuthRevocableFlag)
+
+	// AuthImmutable prevents the authorization flags and the signers of the
+	// account from being changed, and the account from being merged.
+	AuthImmutable = AccountFlag(xdr.AccountFlagsAuthImmutableFlag)
+)
+
+// Operation is an operation that can be added to a Transaction. BuildXDR
+// returns the XDR form of the operation.
+type Operation interface {
+	BuildXDR() (xdr.Operation, error)
+}
+
+// Timebounds are the UNIX times between which a transaction is valid. A
+// MaxTime of 0 means the transaction has no upper time bound.
+type Timebounds struct {
+	MinTime uint64
+	MaxTime uint64
+}
@@ -0,0 +1,33 @@
+package txnbuild
+
+import (
+	"github.com/stellar/go/support/errors"
+	"github.com/stellar/go/xdr"
+)
+
+// ManageData is a manage_data operation, setting the data entry Name of the
+// source account to Value, or deleting it when Value is nil.
+type ManageData struct {
+	Name          string
+	Value         []byte
+	SourceAccount string
+}
+
+// BuildXDR implements Operation.
+func (op ManageData) BuildXDR() (xdr.Operation, error) {
+	if len(op.Name) == 0 || len(op.Name) > 64 {
+		return xdr.Operation{}, &FieldError{Field: "Name", Err: errors.New("name must be 1 to 64 bytes long")}
+	}
+
+	if len(op.Value) > 64 {
+		return xdr.Operation{}, &FieldError{Field: "Value", Err: errors.New("value must be at most 64 bytes long")}
+	}
+
+	body := xdr.ManageDataOp{DataName: xdr.String64(op.Name)}
+	if op.Value != nil {
+		value := xdr.DataValue(op.Value)
+		body.DataValue = &value
+	}
+
+	return newOperation(op.SourceAccount, xdr.OperationTypeManageData, body)
+}
@@ -0,0 +1,27 @@
+package txnbuild
+
+import (
+	"testing"
+
+	"github.com/stellar/go/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestManageData_BuildXDR(t *testing.T) {
+	op, err := ManageData{Name: "key", Value: []byte("value")}.BuildXDR()
+	require.NoError(t, err)
+	body := op.Body.MustManageDataOp()
+	assert.EqualValues(t, "key", body.DataName)
+	require.NotNil(t, body.DataValue)
+	assert.Equal(t, xdr.DataValue("value"), *body.DataValue)
+
+	op, err = ManageData{Name: "key"}.BuildXDR()
+	require.NoError(t, err)
+	assert.Nil(t, op.Body.MustManageDataOp().DataValue)
+
+	_, err = ManageData{}.BuildXDR()
+	if assert.IsType(t, &FieldError{}, err) {
+		assert.Equal(t, "Name", err.(*FieldError).Field)
+	}
+}
@@ -0,0 +1,31 @@
+package txnbuild
+
+import (
+	"github.com/stellar/go/xdr"
+)
+
+// ManageOffer is a manage_offer operation, creating an offer to sell Amount
+// of Selling for Buying at Price (units of Buying per unit of Selling) when
+// OfferID is zero, or updating the offer with OfferID. An Amount of "0"
+// deletes the offer.
+type ManageOffer struct {
+	Selling       Asset
+	Buying        Asset
+	Amount        string
+	Price         string
+	OfferID       uint64
+	SourceAccount string
+}
+
+// BuildXDR implements Operation.
+func (op ManageOffer) BuildXDR() (xdr.Operation, error) {
+	body := xdr.ManageOfferOp{OfferId: xdr.Uint64(op.OfferID)}
+
+	var err error
+	body.Selling, body.Buying, body.Amount, body.Price, err = parseOffer(op.Selling, op.Buying, op.Amount, op.Price)
+	if err != nil {
+		return xdr.Operation{}, err
+	}
+
+	return newOperation(op.SourceAccount, xdr.OperationTypeManageOffer, body)
+}
@@ -0,0 +1,32 @@
+package txnbuild
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestManageOffer_BuildXDR(t *testing.T) {
+	op, err := ManageOffer{
+		Selling: NativeAsset,
+		Buying:  Asset{Code: "USD", Issuer: address1},
+		Amount:  "100",
+		Price:   "0.25",
+		OfferID: 7,
+	}.BuildXDR()
+	require.NoError(t, err)
+
+	body := op.Body.MustManageOfferOp()
+	assert.Equal(t, "native", body.Selling.String())
+	assert.Equal(t, "credit_alphanum4/USD/"+address1, body.Buying.String())
+	assert.EqualValues(t, 1000000000, body.Amount)
+	assert.EqualValues(t, 1, body.Price.N)
+	assert.EqualValues(t, 4, body.Price.D)
+	assert.EqualValues(t, 7, body.OfferId)
+
+	_, err = ManageOffer{Selling: NativeAsset, Buying: NativeAsset, Amount: "1", Price: "foo"}.BuildXDR()
+	if assert.IsType(t, &FieldError{}, err) {
+		assert.Equal(t, "Price", err.(*FieldError).Field)
+	}
+}
@@ -0,0 +1,47 @@
+package txnbuild
+
+import (
+	"github.com/stellar/go/support/errors"
+	"github.com/stellar/go/xdr"
+)
+
+// Memo is the memo of a transaction: MemoText, MemoID, MemoHash or
+// MemoReturn.
+type Memo interface {
+	ToXDR() (xdr.Memo, error)
+}
+
+// MemoHash is a memo of type MEMO_HASH.
+type MemoHash [32]byte
+
+// MemoID is a memo of type MEMO_ID.
+type MemoID uint64
+
+// MemoReturn is a memo of type MEMO_RETURN.
+type MemoReturn [32]byte
+
+// MemoText is a memo of type MEMO_TEXT, of at most MemoTextMaxLength bytes.
+type MemoText string
+
+// ToXDR implements Memo.
+func (m MemoHash) ToXDR() (xdr.Memo, error) {
+	return xdr.NewMemo(xdr.MemoTypeMemoHash, xdr.Hash(m))
+}
+
+// ToXDR implements Memo.
+func (m MemoID) ToXDR() (xdr.Memo, error) {
+	return xdr.NewMemo(xdr.MemoTypeMemoId, xdr.Uint64(m))
+}
+
+// ToXDR implements Memo.
+func (m MemoReturn) ToXDR() (xdr.Memo, error) {
+	return xdr.NewMemo(xdr.MemoTypeMemoReturn, xdr.Hash(m))
+}
+
+// ToXDR implements Memo.
+func (m MemoText) ToXDR() (xdr.Memo, error) {
+	if len(m) > MemoTextMaxLength {
+		return xdr.Memo{}, errors.Errorf("memo text is longer than %d bytes", MemoTextMaxLength)
+	}
+	return xdr.NewMemo(xdr.MemoTypeMemoText, string(m))
+}
@@ -0,0 +1,30 @@
+package txnbuild
+
+import (
+	"testing"
+
+	"github.com/stellar/go/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemo_ToXDR(t *testing.T) {
+	memo, err := MemoText("hello").ToXDR()
+	require.NoError(t, err)
+	assert.Equal(t, "hello", memo.MustText())
+
+	memo, err = MemoID(123).ToXDR()
+	require.NoError(t, err)
+	assert.EqualValues(t, 123, memo.MustId())
+
+	memo, err = MemoHash{0x01}.ToXDR()
+	require.NoError(t, err)
+	assert.Equal(t, xdr.Hash{0x01}, memo.MustHash())
+
+	memo, err = MemoReturn{0x02}.ToXDR()
+	require.NoError(t, err)
+	assert.Equal(t, xdr.Hash{0x02}, memo.MustRetHash())
+
+	_, err = MemoText("12345678901234567890123456789").ToXDR()
+	assert.Error(t, err)
+}
@@ -0,0 +1,61 @@
+package txnbuild
+
+import (
+	"github.com/stellar/go/xdr"
+)
+
+// PathPayment is a path_payment operation, sending DestAmount of DestAsset to
+// Destination while spending at most SendMax of SendAsset, converted through
+// the assets of Path.
+type PathPayment struct {
+	SendAsset     Asset
+	SendMax       string
+	Destination   string
+	DestAsset     Asset
+	DestAmount    string
+	Path          []Asset
+	SourceAccount string
+}
+
+// BuildXDR implements Operation.
+func (op PathPayment) BuildXDR() (xdr.Operation, error) {
+	var (
+		body xdr.PathPaymentOp
+		err  error
+	)
+
+	body.SendAsset, err = parseAsset("SendAsset", op.SendAsset)
+	if err != nil {
+		return xdr.Operation{}, err
+	}
+
+	body.SendMax, err = parseAmount("SendMax", op.SendMax)
+	if err != nil {
+		return xdr.Operation{}, err
+	}
+
+	body.Destination, err = parseAccount("Destination", op.Destination)
+	if err != nil {
+		return xdr.Operation{}, err
+	}
+
+	body.DestAsset, err = parseAsset("DestAsset", op.DestAsset)
+	if err != nil {
+		return xdr.Operation{}, err
+	}
+
+	body.DestAmount, err = parseAmount("DestAmount", op.DestAmount)
+	if err != nil {
+		return xdr.Operation{}, err
+	}
+
+	for _, asset := range op.Path {
+		xdrAsset, err := parseAsset("Path", asset)
+		if err != nil {
+			return xdr.Operation{}, err
+		}
+		body.Path = append(body.Path, xdrAsset)
+	}
+
+	return newOperation(op.SourceAccount, xdr.OperationTypePathPayment, body)
+}
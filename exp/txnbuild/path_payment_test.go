@@ -0,0 +1,37 @@
+package txnbuild
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPathPayment_BuildXDR(t *testing.T) {
+	usd := Asset{Code: "USD", Issuer: address1}
+	eur := Asset{Code: "EUR", Issuer: address1}
+
+	op, err := PathPayment{
+		SendAsset:   NativeAsset,
+		SendMax:     "100",
+		Destination: address2,
+		DestAsset:   eur,
+		DestAmount:  "10",
+		Path:        []Asset{usd},
+	}.BuildXDR()
+	require.NoError(t, err)
+
+	body := op.Body.MustPathPaymentOp()
+	assert.Equal(t, "native", body.SendAsset.String())
+	assert.EqualValues(t, 1000000000, body.SendMax)
+	assert.Equal(t, address2, body.Destination.Address())
+	assert.Equal(t, "credit_alphanum4/EUR/"+address1, body.DestAsset.String())
+	assert.EqualValues(t, 100000000, body.DestAmount)
+	require.Len(t, body.Path, 1)
+	assert.Equal(t, "credit_alphanum4/USD/"+address1, body.Path[0].String())
+
+	_, err = PathPayment{SendMax: "100", Destination: address2, DestAmount: "10", Path: []Asset{{Code: "USD"}}}.BuildXDR()
+	if assert.IsType(t, &FieldError{}, err) {
+		assert.Equal(t, "Path", err.(*FieldError).Field)
+	}
+}
@@ -0,0 +1,37 @@
+package txnbuild
+
+import (
+	"github.com/stellar/go/xdr"
+)
+
+// Payment is a payment operation, sending Amount of Asset to Destination.
+type Payment struct {
+	Destination   string
+	Amount        string
+	Asset         Asset
+	SourceAccount string
+}
+
+// BuildXDR implements Operation.
+func (op Payment) BuildXDR() (xdr.Operation, error) {
+	destination, err := parseAccount("Destination", op.Destination)
+	if err != nil {
+		return xdr.Operation{}, err
+	}
+
+	amount, err := parseAmount("Amount", op.Amount)
+	if err != nil {
+		return xdr.Operation{}, err
+	}
+
+	asset, err := parseAsset("Asset", op.Asset)
+	if err != nil {
+		return xdr.Operation{}, err
+	}
+
+	return newOperation(op.SourceAccount, xdr.OperationTypePayment, xdr.PaymentOp{
+		Destination: destination,
+		Asset:       asset,
+		Amount:      amount,
+	})
+}
@@ -0,0 +1,28 @@
+package txnbuild
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPayment_BuildXDR(t *testing.T) {
+	op, err := Payment{
+		Destination: address2,
+		Amount:      "12.5",
+		Asset:       Asset{Code: "USD", Issuer: address1},
+	}.BuildXDR()
+	require.NoError(t, err)
+
+	body := op.Body.MustPaymentOp()
+	assert.Equal(t, address2, body.Destination.Address())
+	assert.EqualValues(t, 125000000, body.Amount)
+	assert.Equal(t, "credit_alphanum4/USD/"+address1, body.Asset.String())
+	assert.Nil(t, op.SourceAccount)
+
+	_, err = Payment{Destination: address2, Amount: "1", Asset: Asset{Code: "USD"}}.BuildXDR()
+	if assert.IsType(t, &FieldError{}, err) {
+		assert.Equal(t, "Asset", err.(*FieldError).Field)
+	}
+}
@@ -0,0 +1,68 @@
+package txnbuild
+
+import (
+	"github.com/stellar/go/support/errors"
+	"github.com/stellar/go/xdr"
+)
+
+// SetOptions is a set_options operation, changing the options of the source
+// account. Nil and empty fields are left unchanged.
+type SetOptions struct {
+	InflationDestination string
+	SetFlags             []AccountFlag
+	ClearFlags           []AccountFlag
+	MasterWeight         *uint32
+	LowThreshold         *uint32
+	MediumThreshold      *uint32
+	HighThreshold        *uint32
+	HomeDomain           *string
+	Signer               *Signer
+	SourceAccount        string
+}
+
+// Signer is a signer added, updated or removed (when Weight is 0) by
+// SetOptions. Key is the strkey encoded signer key: an account address, a
+// pre-authorized transaction hash or a hash(x).
+type Signer struct {
+	Key    string
+	Weight uint32
+}
+
+// BuildXDR implements Operation.
+func (op SetOptions) BuildXDR() (xdr.Operation, error) {
+	var body xdr.SetOptionsOp
+
+	if op.InflationDestination != "" {
+		aid, err := parseAccount("InflationDestination", op.InflationDestination)
+		if err != nil {
+			return xdr.Operation{}, err
+		}
+		body.InflationDest = &aid
+	}
+
+	body.SetFlags = flagsToXDR(op.SetFlags)
+	body.ClearFlags = flagsToXDR(op.ClearFlags)
+	body.MasterWeight = uint32ToXDR(op.MasterWeight)
+	body.LowThreshold = uint32ToXDR(op.LowThreshold)
+	body.MedThreshold = uint32ToXDR(op.MediumThreshold)
+	body.HighThreshold = uint32ToXDR(op.HighThreshold)
+
+	if op.HomeDomain != nil {
+		if len(*op.HomeDomain) > 32 {
+			return xdr.Operation{}, &FieldError{Field: "HomeDomain", Err: errors.New("home domain must be at most 32 bytes long")}
+		}
+		homeDomain := xdr.String32(*op.HomeDomain)
+		body.HomeDomain = &homeDomain
+	}
+
+	if op.Signer != nil {
+		signer := xdr.Signer{Weight: xdr.Uint32(op.Signer.Weight)}
+		err := signer.Key.SetAddress(op.Signer.Key)
+		if err != nil {
+			return xdr.Operation{}, &FieldError{Field: "Signer", Err: err}
+		}
+		body.Signer = &signer
+	}
+
+	return newOperation(op.SourceAccount, xdr.OperationTypeSetOptions, body)
+}
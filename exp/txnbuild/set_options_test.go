@@ -0,0 +1,49 @@
+package txnbuild
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetOptions_BuildXDR(t *testing.T) {
+	masterWeight := uint32(1)
+	threshold := uint32(2)
+	homeDomain := "stellar.org"
+
+	op, err := SetOptions{
+		InflationDestination: address2,
+		SetFlags:             []AccountFlag{AuthRequired, AuthRevocable},
+		ClearFlags:           []AccountFlag{AuthImmutable},
+		MasterWeight:         &masterWeight,
+		MediumThreshold:      &threshold,
+		HomeDomain:           &homeDomain,
+		Signer:               &Signer{Key: address2, Weight: 3},
+	}.BuildXDR()
+	require.NoError(t, err)
+
+	body := op.Body.MustSetOptionsOp()
+	assert.Equal(t, address2, body.InflationDest.Address())
+	assert.EqualValues(t, 3, *body.SetFlags)
+	assert.EqualValues(t, 4, *body.ClearFlags)
+	assert.EqualValues(t, 1, *body.MasterWeight)
+	assert.Nil(t, body.LowThreshold)
+	assert.EqualValues(t, 2, *body.MedThreshold)
+	assert.Nil(t, body.HighThreshold)
+	assert.EqualValues(t, "stellar.org", *body.HomeDomain)
+	assert.Equal(t, address2, body.Signer.Key.Address())
+	assert.EqualValues(t, 3, body.Signer.Weight)
+
+	op, err = SetOptions{}.BuildXDR()
+	require.NoError(t, err)
+	body = op.Body.MustSetOptionsOp()
+	assert.Nil(t, body.InflationDest)
+	assert.Nil(t, body.SetFlags)
+	assert.Nil(t, body.Signer)
+
+	_, err = SetOptions{Signer: &Signer{Key: "foo"}}.BuildXDR()
+	if assert.IsType(t, &FieldError{}, err) {
+		assert.Equal(t, "Signer", err.(*FieldError).Field)
+	}
+}
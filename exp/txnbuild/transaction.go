@@ -0,0 +1,143 @@
+package txnbuild
+
+import (
+	"math"
+
+	"github.com/stellar/go/keypair"
+	"github.com/stellar/go/network"
+	"github.com/stellar/go/support/errors"
+	"github.com/stellar/go/xdr"
+)
+
+// Transaction is a transaction of SourceAccount with sequence number
+// Sequence, usually the current sequence number of the account plus one.
+// Network is the passphrase of the network the transaction is for, e.g.
+// network.TestNetworkPassphrase. The fee of the transaction is BaseFee, or
+// DefaultBaseFee when zero, times the number of operations.
+//
+// Build must be called after the fields are set, and again after any of them
+// is changed, before the transaction is signed, hashed or encoded.
+type Transaction struct {
+	SourceAccount string
+	Sequence      uint64
+	BaseFee       uint64
+	Memo          Memo
+	Timebounds    *Timebounds
+	Network       string
+	Operations    []Operation
+
+	envelope *xdr.TransactionEnvelope
+}
+
+// Build validates the transaction and builds its XDR form. Signatures added
+// by a previous call to Sign are discarded.
+func (tx *Transaction) Build() error {
+	tx.envelope = nil
+
+	if tx.Network == "" {
+		return ErrMissingNetwork
+	}
+
+	if len(tx.Operations) == 0 {
+		return ErrNoOperations
+	}
+
+	var envelope xdr.TransactionEnvelope
+
+	source, err := parseAccount("SourceAccount", tx.SourceAccount)
+	if err != nil {
+		return err
+	}
+	envelope.Tx.SourceAccount = source
+	envelope.Tx.SeqNum = xdr.SequenceNumber(tx.Sequence)
+
+	baseFee := tx.BaseFee
+	if baseFee == 0 {
+		baseFee = DefaultBaseFee
+	}
+	fee := baseFee * uint64(len(tx.Operations))
+	if fee > math.MaxUint32 {
+		return &FieldError{Field: "BaseFee", Err: errors.New("fee of the transaction is too large")}
+	}
+	envelope.Tx.Fee = xdr.Uint32(fee)
+
+	if tx.Memo != nil {
+		envelope.Tx.Memo, err = tx.Memo.ToXDR()
+		if err != nil {
+			return &FieldError{Field: "Memo", Err: err}
+		}
+	}
+
+	if tx.Timebounds != nil {
+		if tx.Timebounds.MaxTime != 0 && tx.Timebounds.MinTime > tx.Timebounds.MaxTime {
+			return &FieldError{Field: "Timebounds", Err: errors.New("MinTime must not be greater than MaxTime")}
+		}
+		envelope.Tx.TimeBounds = &xdr.TimeBounds{
+			MinTime: xdr.Uint64(tx.Timebounds.MinTime),
+			MaxTime: xdr.Uint64(tx.Timebounds.MaxTime),
+		}
+	}
+
+	for i, op := range tx.Operations {
+		xdrOp, err := op.BuildXDR()
+		if err != nil {
+			return &OperationError{Index: i, Operation: op, Err: err}
+		}
+		envelope.Tx.Operations = append(envelope.Tx.Operations, xdrOp)
+	}
+
+	tx.envelope = &envelope
+	return nil
+}
+
+// Hash returns the hash of the built transaction, which is signed by Sign.
+func (tx *Transaction) Hash() ([32]byte, error) {
+	if tx.envelope == nil {
+		return [32]byte{}, ErrNotBuilt
+	}
+
+	return network.HashTransaction(&tx.envelope.Tx, tx.Network)
+}
+
+// Sign adds the signatures of the built transaction by the provided seeds.
+func (tx *Transaction) Sign(seeds ...string) error {
+	hash, err := tx.Hash()
+	if err != nil {
+		return err
+	}
+
+	for _, seed := range seeds {
+		kp, err := keypair.Parse(seed)
+		if err != nil {
+			return errors.Wrap(err, "parse seed failed")
+		}
+
+		sig, err := kp.SignDecorated(hash[:])
+		if err != nil {
+			return errors.Wrap(err, "sign tx failed")
+		}
+
+		tx.envelope.Signatures = append(tx.envelope.Signatures, sig)
+	}
+
+	return nil
+}
+
+// Base64 returns the base64 encoded XDR form of the built and signed
+// transaction envelope, which can be submitted to horizon.
+func (tx *Transaction) Base64() (string, error) {
+	if tx.envelope == nil {
+		return "", ErrNotBuilt
+	}
+
+	return xdr.MarshalBase64(tx.envelope)
+}
+
+// Envelope returns the XDR form of the built and signed transaction envelope.
+func (tx *Transaction) Envelope() (*xdr.TransactionEnvelope, error) {
+	if tx.envelope == nil {
+		return nil, ErrNotBuilt
+	}
+
+	return tx.envelope, nil
+}
@@ -0,0 +1,123 @@
+package txnbuild
+
+import (
+	"testing"
+
+	"github.com/stellar/go/keypair"
+	"github.com/stellar/go/network"
+	"github.com/stellar/go/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestTransaction() Transaction {
+	return Transaction{
+		SourceAccount: address1,
+		Sequence:      42,
+		Network:       network.TestNetworkPassphrase,
+		Operations: []Operation{
+			Payment{Destination: address2, Amount: "10", Asset: NativeAsset},
+			Inflation{},
+		},
+	}
+}
+
+func TestTransaction(t *testing.T) {
+	tx := newTestTransaction()
+	tx.BaseFee = 200
+	tx.Memo = MemoText("hello")
+	tx.Timebounds = &Timebounds{MinTime: 1500000000, MaxTime: 1500000300}
+
+	require.NoError(t, tx.Build())
+	require.NoError(t, tx.Sign(seed))
+
+	txeB64, err := tx.Base64()
+	require.NoError(t, err)
+
+	var envelope xdr.TransactionEnvelope
+	require.NoError(t, xdr.SafeUnmarshalBase64(txeB64, &envelope))
+
+	assert.Equal(t, address1, envelope.Tx.SourceAccount.Address())
+	assert.EqualValues(t, 42, envelope.Tx.SeqNum)
+	assert.EqualValues(t, 400, envelope.Tx.Fee)
+	assert.Equal(t, "hello", envelope.Tx.Memo.MustText())
+	assert.EqualValues(t, 1500000000, envelope.Tx.TimeBounds.MinTime)
+	assert.EqualValues(t, 1500000300, envelope.Tx.TimeBounds.MaxTime)
+	require.Len(t, envelope.Tx.Operations, 2)
+	assert.Equal(t, xdr.OperationTypePayment, envelope.Tx.Operations[0].Body.Type)
+	assert.Equal(t, xdr.OperationTypeInflation, envelope.Tx.Operations[1].Body.Type)
+
+	hash, err := tx.Hash()
+	require.NoError(t, err)
+	require.Len(t, envelope.Signatures, 1)
+	kp := keypair.MustParse(seed)
+	assert.NoError(t, kp.Verify(hash[:], envelope.Signatures[0].Signature))
+
+	// building again discards the signatures
+	require.NoError(t, tx.Build())
+	built, err := tx.Envelope()
+	require.NoError(t, err)
+	assert.Empty(t, built.Signatures)
+}
+
+func TestTransaction_DefaultBaseFee(t *testing.T) {
+	tx := newTestTransaction()
+	require.NoError(t, tx.Build())
+
+	envelope, err := tx.Envelope()
+	require.NoError(t, err)
+	assert.EqualValues(t, DefaultBaseFee*2, envelope.Tx.Fee)
+	assert.Equal(t, xdr.MemoTypeMemoNone, envelope.Tx.Memo.Type)
+	assert.Nil(t, envelope.Tx.TimeBounds)
+}
+
+func TestTransaction_Errors(t *testing.T) {
+	tx := newTestTransaction()
+	_, err := tx.Base64()
+	assert.Equal(t, ErrNotBuilt, err)
+	assert.Equal(t, ErrNotBuilt, tx.Sign(seed))
+
+	tx = newTestTransaction()
+	tx.Network = ""
+	assert.Equal(t, ErrMissingNetwork, tx.Build())
+
+	tx = newTestTransaction()
+	tx.Operations = nil
+	assert.Equal(t, ErrNoOperations, tx.Build())
+
+	tx = newTestTransaction()
+	tx.SourceAccount = "foo"
+	err = tx.Build()
+	if assert.IsType(t, &FieldError{}, err) {
+		assert.Equal(t, "SourceAccount", err.(*FieldError).Field)
+	}
+
+	tx = newTestTransaction()
+	tx.Memo = MemoText("12345678901234567890123456789")
+	err = tx.Build()
+	if assert.IsType(t, &FieldError{}, err) {
+		assert.Equal(t, "Memo", err.(*FieldError).Field)
+	}
+
+	tx = newTestTransaction()
+	tx.Timebounds = &Timebounds{MinTime: 1500000300, MaxTime: 1500000000}
+	err = tx.Build()
+	if assert.IsType(t, &FieldError{}, err) {
+		assert.Equal(t, "Timebounds", err.(*FieldError).Field)
+	}
+
+	tx = newTestTransaction()
+	tx.Operations = append(tx.Operations, Payment{Destination: "foo", Amount: "10"})
+	err = tx.Build()
+	if assert.IsType(t, &OperationError{}, err) {
+		opErr := err.(*OperationError)
+		assert.Equal(t, 2, opErr.Index)
+		if assert.IsType(t, &FieldError{}, opErr.Err) {
+			assert.Equal(t, "Destination", opErr.Err.(*FieldError).Field)
+		}
+	}
+
+	// a failed build leaves the transaction unbuilt
+	_, err = tx.Base64()
+	assert.Equal(t, ErrNotBuilt, err)
+}
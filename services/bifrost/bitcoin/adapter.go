@@ -0,0 +1,275 @@
+package bitcoin
+
+import (
+	"math/big"
+
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/rpcclient"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcutil"
+	"github.com/btcsuite/btcutil/base58"
+	"github.com/stellar/go/services/bifrost/chains"
+	"github.com/stellar/go/services/bifrost/config"
+	"github.com/stellar/go/services/bifrost/database"
+	"github.com/stellar/go/services/bifrost/queue"
+	"github.com/stellar/go/support/errors"
+)
+
+func init() {
+	chains.Register(database.ChainBitcoin, NewAdapter)
+}
+
+// Adapter implements chains.ChainAdapter using Listener and AddressGenerator,
+// chains.Withdrawer using Wallet and chains.Refunder using RawTransactions. If
+// Failover is set, it's started before Listener to check health of the nodes.
+type Adapter struct {
+	Listener         *Listener
+	AddressGenerator *AddressGenerator
+	Wallet           WalletClient
+	RawTransactions  RawTransactionClient
+	Failover         *chains.Failover
+
+	minimumValueSat int64
+	gapLimit        uint32
+}
+
+// NewAdapter creates Bitcoin adapter from `[bitcoin]` config section. It
+// implements chains.Factory.
+func NewAdapter(cfg *config.Config, db database.Database) (chains.ChainAdapter, error) {
+	if cfg.Bitcoin == nil {
+		return nil, nil
+	}
+
+	minimumValueSat, err := BtcToSat(cfg.Bitcoin.MinimumValueBtc)
+	if err != nil {
+		return nil, errors.Wrap(err, "Invalid minimum accepted Bitcoin transaction value: "+cfg.Bitcoin.MinimumValueBtc)
+	}
+
+	if minimumValueSat == 0 {
+		return nil, errors.New("Minimum accepted Bitcoin transaction value must be larger than 0")
+	}
+
+	clients := make([]Client, len(cfg.Bitcoin.RpcServer))
+	var wallet WalletClient
+	var rawTransactions RawTransactionClient
+	for i, host := range cfg.Bitcoin.RpcServer {
+		connConfig := &rpcclient.ConnConfig{
+			Host:         host,
+			User:         cfg.Bitcoin.RpcUser,
+			Pass:         cfg.Bitcoin.RpcPass,
+			HTTPPostMode: true,
+			DisableTLS:   true,
+		}
+		client, err := rpcclient.New(connConfig, nil)
+		if err != nil {
+			return nil, errors.Wrap(err, "Error connecting to bitcoin-core: "+host)
+		}
+
+		clients[i] = client
+		// Withdrawals are sent from the wallet of the first node only, failing
+		// over to another wallet could spend from a different set of keys.
+		if i == 0 {
+			wallet = client
+			rawTransactions = client
+		}
+	}
+	failoverClient := NewFailoverClient(cfg.Bitcoin.RpcServer, clients)
+
+	var chainParams *chaincfg.Params
+	if cfg.Bitcoin.Testnet {
+		chainParams = &chaincfg.TestNet3Params
+	} else {
+		chainParams = &chaincfg.MainNetParams
+	}
+
+	addressGenerator, err := NewAddressGenerator(cfg.Bitcoin.MasterPublicKey, cfg.Bitcoin.DerivationPath, chainParams)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Adapter{
+		Listener: &Listener{
+			Client:        failoverClient,
+			Storage:       db,
+			Testnet:       cfg.Bitcoin.Testnet,
+			Confirmations: cfg.Bitcoin.Confirmations,
+		},
+		AddressGenerator: addressGenerator,
+		Wallet:           wallet,
+		RawTransactions:  rawTransactions,
+		Failover:         failoverClient.Failover,
+		minimumValueSat:  minimumValueSat,
+		gapLimit:         cfg.Bitcoin.GapLimit,
+	}, nil
+}
+
+func (a *Adapter) Start() error {
+	if a.Failover != nil {
+		err := a.Failover.Start()
+		if err != nil {
+			return errors.Wrap(err, "Error starting failover")
+		}
+	}
+	return a.Listener.Start()
+}
+
+func (a *Adapter) Rescan(fromBlock, toBlock uint64) error {
+	if a.Failover != nil {
+		err := a.Failover.Start()
+		if err != nil {
+			return errors.Wrap(err, "Error starting failover")
+		}
+	}
+	return a.Listener.Rescan(fromBlock, toBlock)
+}
+
+func (a *Adapter) StreamTransactions(handler chains.TransactionHandler) {
+	a.Listener.TransactionHandler = func(transaction Transaction) error {
+		chainTransaction := transaction.toChain()
+		chainTransaction.RequiredConfirmations = a.Listener.Confirmations
+		return handler(chainTransaction)
+	}
+}
+
+func (a *Adapter) StreamBlocks(handler chains.BlockHandler) {
+	a.Listener.BlockHandler = func(blockNumber uint64) error {
+		return handler(database.ChainBitcoin, blockNumber)
+	}
+}
+
+func (a *Adapter) ValidateAddress(address string) error {
+	decoded, err := btcutil.DecodeAddress(address, a.AddressGenerator.chainParams)
+	if err != nil {
+		return errors.Wrap(err, "Error decoding address")
+	}
+
+	if !decoded.IsForNet(a.AddressGenerator.chainParams) {
+		return errors.New("Address is not for " + a.AddressGenerator.chainParams.Name + " network")
+	}
+
+	return nil
+}
+
+func (a *Adapter) DeriveAddress(index uint32) (string, error) {
+	return a.AddressGenerator.Generate(index)
+}
+
+// GapLimit implements chains.GapLimiter.
+func (a *Adapter) GapLimit() uint32 {
+	return a.gapLimit
+}
+
+func (a *Adapter) MinimumValue(assetCode queue.AssetCode) *big.Int {
+	if assetCode != queue.AssetCodeBTC {
+		return nil
+	}
+	return big.NewInt(a.minimumValueSat)
+}
+
+// DecodeMemoAddress decodes P2PKH or P2SH address from the last 25 bytes of
+// `memo` (version, hash and checksum of base58 encoded address).
+func (a *Adapter) DecodeMemoAddress(memo []byte) (string, error) {
+	if len(memo) != 32 {
+		return "", errors.New("Invalid memo length")
+	}
+
+	for _, b := range memo[:7] {
+		if b != 0 {
+			return "", errors.New("Memo must be padded with zeros")
+		}
+	}
+
+	address := base58.Encode(memo[7:])
+	err := a.ValidateAddress(address)
+	if err != nil {
+		return "", err
+	}
+
+	return address, nil
+}
+
+func (a *Adapter) Withdraw(address string, assetCode queue.AssetCode, amount string) (string, error) {
+	if assetCode != queue.AssetCodeBTC {
+		return "", errors.New("Asset not supported: " + string(assetCode))
+	}
+
+	decoded, err := btcutil.DecodeAddress(address, a.AddressGenerator.chainParams)
+	if err != nil {
+		return "", errors.Wrap(err, "Error decoding address")
+	}
+
+	valueSat, err := BtcToSat(amount)
+	if err != nil {
+		return "", errors.Wrap(err, "Invalid amount")
+	}
+
+	hash, err := a.Wallet.SendToAddress(decoded, btcutil.Amount(valueSat))
+	if err != nil {
+		return "", errors.Wrap(err, "Error sending payment")
+	}
+
+	return hash.String(), nil
+}
+
+// RefundAddress returns the address of the output spent by the first input of
+// the transaction. Only P2PK, P2PKH and P2SH outputs are supported.
+func (a *Adapter) RefundAddress(transactionID string) (string, error) {
+	hash, err := chainhash.NewHashFromStr(transactionID)
+	if err != nil {
+		return "", errors.Wrap(err, "Invalid transaction ID")
+	}
+
+	transaction, err := a.RawTransactions.GetRawTransaction(hash)
+	if err != nil {
+		return "", errors.Wrap(err, "Error getting transaction")
+	}
+
+	inputs := transaction.MsgTx().TxIn
+	if len(inputs) == 0 || inputs[0].PreviousOutPoint.Index == wire.MaxPrevOutIndex {
+		return "", errors.New("Coinbase transaction can't be refunded")
+	}
+	previousOutPoint := inputs[0].PreviousOutPoint
+
+	previousTransaction, err := a.RawTransactions.GetRawTransaction(&previousOutPoint.Hash)
+	if err != nil {
+		return "", errors.Wrap(err, "Error getting input transaction")
+	}
+
+	outputs := previousTransaction.MsgTx().TxOut
+	if int(previousOutPoint.Index) >= len(outputs) {
+		return "", errors.New("Input transaction output not found")
+	}
+
+	class, addresses, _, err := txscript.ExtractPkScriptAddrs(outputs[previousOutPoint.Index].PkScript, a.AddressGenerator.chainParams)
+	if err != nil {
+		return "", errors.Wrap(err, "Error extracting addresses")
+	}
+
+	if class != txscript.PubKeyTy && class != txscript.PubKeyHashTy && class != txscript.ScriptHashTy {
+		return "", errors.Errorf("Unsupported sender addresses class: %s", class)
+	}
+
+	// Paranoid, standard scripts of these classes pay a single address.
+	if len(addresses) != 1 {
+		return "", errors.New("Invalid addresses length")
+	}
+
+	return addresses[0].EncodeAddress(), nil
+}
+
+func (t Transaction) toChain() chains.Transaction {
+	return chains.Transaction{
+		Chain:         database.ChainBitcoin,
+		Hash:          t.Hash,
+		Index:         t.TxOutIndex,
+		To:            t.To,
+		Value:         big.NewInt(t.ValueSat),
+		Decimals:      8,
+		AssetCode:     queue.AssetCodeBTC,
+		Amount:        t.ValueToStellar(),
+		BlockNumber:   t.BlockNumber,
+		Confirmations: t.Confirmations,
+	}
+}
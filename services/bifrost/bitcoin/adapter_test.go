@@ -0,0 +1,140 @@
+package bitcoin
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/txscript"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcutil"
+	"github.com/btcsuite/btcutil/base58"
+	"github.com/stellar/go/services/bifrost/database"
+	"github.com/stellar/go/services/bifrost/queue"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdapterValidateAddress(t *testing.T) {
+	generator, err := NewAddressGenerator("xpub6ExUcpBZP8LfA1q6asykSFvkeask9Jmhs3fjBNovyk3iqr8q2bN6PryKKCvLLkMs1u2667wJnoM5LRQc3JcsGbQAhjUqJavxhtdk363GbP2", "", &chaincfg.MainNetParams)
+	assert.NoError(t, err)
+	adapter := &Adapter{AddressGenerator: generator}
+
+	assert.NoError(t, adapter.ValidateAddress("1Q74qRud8bXUn6FMtXWZwJa5pj56s3mdyf"))
+	// Testnet address
+	assert.Error(t, adapter.ValidateAddress("mipcBbFg9gMiCh81Kj8tqqdgoZub1ZJRfn"))
+	// Ethereum address
+	assert.Error(t, adapter.ValidateAddress("0x80D3ee1268DC1A2d1b9E73D49050083E75Ef7c2D"))
+	assert.Error(t, adapter.ValidateAddress(""))
+}
+
+func TestAdapterDecodeMemoAddress(t *testing.T) {
+	generator, err := NewAddressGenerator("xpub6ExUcpBZP8LfA1q6asykSFvkeask9Jmhs3fjBNovyk3iqr8q2bN6PryKKCvLLkMs1u2667wJnoM5LRQc3JcsGbQAhjUqJavxhtdk363GbP2", "", &chaincfg.MainNetParams)
+	assert.NoError(t, err)
+	adapter := &Adapter{AddressGenerator: generator}
+
+	memo := make([]byte, 32)
+	copy(memo[7:], base58.Decode("1Q74qRud8bXUn6FMtXWZwJa5pj56s3mdyf"))
+
+	address, err := adapter.DecodeMemoAddress(memo)
+	assert.NoError(t, err)
+	assert.Equal(t, "1Q74qRud8bXUn6FMtXWZwJa5pj56s3mdyf", address)
+
+	// Invalid checksum
+	invalid := make([]byte, 32)
+	copy(invalid, memo)
+	invalid[31]++
+	_, err = adapter.DecodeMemoAddress(invalid)
+	assert.Error(t, err)
+
+	// Not padded with zeros
+	copy(invalid, memo)
+	invalid[0] = 1
+	_, err = adapter.DecodeMemoAddress(invalid)
+	assert.Error(t, err)
+
+	_, err = adapter.DecodeMemoAddress(memo[1:])
+	assert.Error(t, err)
+}
+
+type testRawTransactionClient struct {
+	transactions map[chainhash.Hash]*wire.MsgTx
+}
+
+func (c *testRawTransactionClient) GetRawTransaction(txHash *chainhash.Hash) (*btcutil.Tx, error) {
+	transaction, ok := c.transactions[*txHash]
+	if !ok {
+		return nil, errors.New("No such mempool or blockchain transaction")
+	}
+	return btcutil.NewTx(transaction), nil
+}
+
+func TestAdapterRefundAddress(t *testing.T) {
+	generator, err := NewAddressGenerator("xpub6ExUcpBZP8LfA1q6asykSFvkeask9Jmhs3fjBNovyk3iqr8q2bN6PryKKCvLLkMs1u2667wJnoM5LRQc3JcsGbQAhjUqJavxhtdk363GbP2", "", &chaincfg.MainNetParams)
+	require.NoError(t, err)
+
+	sender, err := btcutil.DecodeAddress("1Q74qRud8bXUn6FMtXWZwJa5pj56s3mdyf", &chaincfg.MainNetParams)
+	require.NoError(t, err)
+	senderScript, err := txscript.PayToAddrScript(sender)
+	require.NoError(t, err)
+
+	// Transaction paying the sender in the second output
+	previous := wire.NewMsgTx(wire.TxVersion)
+	previous.AddTxOut(wire.NewTxOut(1000, []byte{txscript.OP_RETURN}))
+	previous.AddTxOut(wire.NewTxOut(200000000, senderScript))
+	previousHash := previous.TxHash()
+
+	deposit := wire.NewMsgTx(wire.TxVersion)
+	deposit.AddTxIn(wire.NewTxIn(wire.NewOutPoint(&previousHash, 1), nil, nil))
+	deposit.AddTxOut(wire.NewTxOut(100000000, senderScript))
+	depositHash := deposit.TxHash()
+
+	coinbase := wire.NewMsgTx(wire.TxVersion)
+	coinbase.AddTxIn(wire.NewTxIn(wire.NewOutPoint(&chainhash.Hash{}, wire.MaxPrevOutIndex), nil, nil))
+	coinbaseHash := coinbase.TxHash()
+
+	adapter := &Adapter{
+		AddressGenerator: generator,
+		RawTransactions: &testRawTransactionClient{
+			transactions: map[chainhash.Hash]*wire.MsgTx{
+				previousHash: previous,
+				depositHash:  deposit,
+				coinbaseHash: coinbase,
+			},
+		},
+	}
+
+	address, err := adapter.RefundAddress(depositHash.String())
+	assert.NoError(t, err)
+	assert.Equal(t, "1Q74qRud8bXUn6FMtXWZwJa5pj56s3mdyf", address)
+
+	_, err = adapter.RefundAddress(coinbaseHash.String())
+	assert.Error(t, err)
+
+	_, err = adapter.RefundAddress("109fa1c369680c2f27643fdd160620d010851a376d25b9b00ef71afe789ea6ed")
+	assert.Error(t, err)
+
+	_, err = adapter.RefundAddress("invalid")
+	assert.Error(t, err)
+}
+
+func TestTransactionToChain(t *testing.T) {
+	transaction := Transaction{
+		Hash:       "109fa1c369680c2f27643fdd160620d010851a376d25b9b00ef71afe789ea6ed",
+		TxOutIndex: 1,
+		ValueSat:   123456789,
+		To:         "1Q74qRud8bXUn6FMtXWZwJa5pj56s3mdyf",
+	}
+
+	chainTransaction := transaction.toChain()
+	assert.Equal(t, database.ChainBitcoin, chainTransaction.Chain)
+	assert.Equal(t, transaction.Hash, chainTransaction.Hash)
+	assert.Equal(t, 1, chainTransaction.Index)
+	assert.Equal(t, transaction.To, chainTransaction.To)
+	assert.Equal(t, big.NewInt(123456789), chainTransaction.Value)
+	assert.Equal(t, uint8(8), chainTransaction.Decimals)
+	assert.Equal(t, queue.AssetCodeBTC, chainTransaction.AssetCode)
+	assert.Equal(t, "1.2345679", chainTransaction.Amount)
+}
@@ -0,0 +1,83 @@
+package bitcoin
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/stellar/go/services/bifrost/chains"
+	"github.com/stellar/go/services/bifrost/database"
+)
+
+// DefaultMaxBlockLag is the number of blocks a node can be behind the best
+// node before it's considered stale.
+const DefaultMaxBlockLag = 1
+
+// FailoverClient implements Client using many bitcoin-core nodes. Requests are
+// sent to the node selected by Failover. Nodes returning errors are marked
+// unhealthy so the next request is sent to another node.
+type FailoverClient struct {
+	Nodes    []Client
+	Failover *chains.Failover
+
+	mutex       sync.Mutex
+	blockSource string
+}
+
+// NewFailoverClient creates FailoverClient sending requests to `clients`.
+// `hosts` are the names of nodes used in logs.
+func NewFailoverClient(hosts []string, clients []Client) *FailoverClient {
+	c := &FailoverClient{Nodes: clients}
+	c.Failover = &chains.Failover{
+		Chain:  database.ChainBitcoin,
+		Nodes:  hosts,
+		MaxLag: DefaultMaxBlockLag,
+		Height: func(node int) (uint64, error) {
+			count, err := c.Nodes[node].GetBlockCount()
+			return uint64(count), err
+		},
+	}
+	return c
+}
+
+func (c *FailoverClient) GetBlockCount() (int64, error) {
+	node := c.Failover.Current()
+	count, err := c.Nodes[node].GetBlockCount()
+	if err != nil {
+		c.Failover.ReportError(node, err)
+	}
+	return count, err
+}
+
+func (c *FailoverClient) GetBlockHash(blockHeight int64) (*chainhash.Hash, error) {
+	node := c.Failover.Current()
+	hash, err := c.Nodes[node].GetBlockHash(blockHeight)
+	// Block not existing yet is not a node failure. Stale nodes are detected by
+	// Failover health checks.
+	if err != nil && !strings.Contains(err.Error(), "Block height out of range") {
+		c.Failover.ReportError(node, err)
+	}
+	return hash, err
+}
+
+func (c *FailoverClient) GetBlock(blockHash *chainhash.Hash) (*wire.MsgBlock, error) {
+	node := c.Failover.Current()
+	block, err := c.Nodes[node].GetBlock(blockHash)
+	if err != nil {
+		c.Failover.ReportError(node, err)
+		return nil, err
+	}
+
+	c.mutex.Lock()
+	c.blockSource = c.Failover.NodeName(node)
+	c.mutex.Unlock()
+	return block, nil
+}
+
+// BlockSource implements chains.BlockSource.
+func (c *FailoverClient) BlockSource() string {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.blockSource
+}
@@ -0,0 +1,57 @@
+package bitcoin
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/btcsuite/btcd/chaincfg/chainhash"
+	"github.com/btcsuite/btcd/wire"
+	"github.com/stretchr/testify/assert"
+)
+
+type testClient struct {
+	blockCount int64
+	err        error
+}
+
+func (c *testClient) GetBlockCount() (int64, error) {
+	return c.blockCount, c.err
+}
+
+func (c *testClient) GetBlockHash(blockHeight int64) (*chainhash.Hash, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+	if blockHeight > c.blockCount {
+		return nil, errors.New("-8: Block height out of range")
+	}
+	return &chainhash.Hash{}, nil
+}
+
+func (c *testClient) GetBlock(blockHash *chainhash.Hash) (*wire.MsgBlock, error) {
+	return &wire.MsgBlock{}, c.err
+}
+
+func TestFailoverClient(t *testing.T) {
+	node1 := &testClient{blockCount: 100}
+	node2 := &testClient{blockCount: 100}
+	client := NewFailoverClient([]string{"node1", "node2"}, []Client{node1, node2})
+
+	_, err := client.GetBlock(&chainhash.Hash{})
+	assert.NoError(t, err)
+	assert.Equal(t, "node1", client.BlockSource())
+
+	// Block not existing yet doesn't change the node
+	_, err = client.GetBlockHash(101)
+	assert.Error(t, err)
+	assert.Equal(t, 0, client.Failover.Current())
+
+	node1.err = errors.New("connection refused")
+	_, err = client.GetBlockHash(100)
+	assert.Error(t, err)
+	assert.Equal(t, 1, client.Failover.Current())
+
+	_, err = client.GetBlock(&chainhash.Hash{})
+	assert.NoError(t, err)
+	assert.Equal(t, "node2", client.BlockSource())
+}
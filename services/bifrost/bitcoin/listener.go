@@ -7,7 +7,10 @@ import (
 	"github.com/btcsuite/btcd/chaincfg"
 	"github.com/btcsuite/btcd/txscript"
 	"github.com/btcsuite/btcd/wire"
+	"github.com/stellar/go/services/bifrost/chains"
 	"github.com/stellar/go/services/bifrost/common"
+	"github.com/stellar/go/services/bifrost/database"
+	"github.com/stellar/go/services/bifrost/metrics"
 	"github.com/stellar/go/support/errors"
 	"github.com/stellar/go/support/log"
 )
@@ -16,6 +19,39 @@ func (l *Listener) Start() error {
 	l.log = common.CreateLogger("BitcoinListener")
 	l.log.Info("BitcoinListener starting")
 
+	err := l.init()
+	if err != nil {
+		return err
+	}
+
+	blockNumber, err := l.Storage.GetBlockToProcess(database.ChainBitcoin)
+	if err != nil {
+		err = errors.Wrap(err, "Error getting bitcoin block to process from DB")
+		l.log.Error(err)
+		return err
+	}
+
+	if blockNumber == 0 {
+		blockNumberTmp, err := l.Client.GetBlockCount()
+		if err != nil {
+			err = errors.Wrap(err, "Error getting the block count from bitcoin-core")
+			l.log.Error(err)
+			return err
+		}
+		blockNumber = uint64(blockNumberTmp)
+	}
+
+	go l.processBlocks(blockNumber)
+	return nil
+}
+
+// init sets defaults and checks if bitcoin-core is connected to the
+// configured network.
+func (l *Listener) init() error {
+	if l.Confirmations == 0 {
+		l.Confirmations = 1
+	}
+
 	genesisBlockHash, err := l.Client.GetBlockHash(0)
 	if err != nil {
 		return errors.Wrap(err, "Error getting genesis block")
@@ -31,44 +67,70 @@ func (l *Listener) Start() error {
 		return errors.New("Invalid genesis hash")
 	}
 
-	blockNumber, err := l.Storage.GetBitcoinBlockToProcess()
+	return nil
+}
+
+// Rescan processes blocks from `fromBlock` to `toBlock` (inclusive) again and
+// streams their transactions with the required number of confirmations. It
+// returns error if any block fails to process or `toBlock` doesn't have the
+// required number of confirmations yet. The last processed block is not
+// changed.
+func (l *Listener) Rescan(fromBlock, toBlock uint64) error {
+	l.log = common.CreateLogger("BitcoinListener")
+	l.log.WithFields(log.F{"fromBlock": fromBlock, "toBlock": toBlock}).Info("BitcoinListener rescanning")
+
+	err := l.init()
 	if err != nil {
-		err = errors.Wrap(err, "Error getting bitcoin block to process from DB")
-		l.log.Error(err)
 		return err
 	}
 
-	if blockNumber == 0 {
-		blockNumberTmp, err := l.Client.GetBlockCount()
+	blockCount, err := l.Client.GetBlockCount()
+	if err != nil {
+		return errors.Wrap(err, "Error getting the block count from bitcoin-core")
+	}
+
+	if toBlock+l.Confirmations-1 > uint64(blockCount) {
+		return errors.Errorf("Block %d doesn't have %d confirmations yet", toBlock, l.Confirmations)
+	}
+
+	for blockNumber := fromBlock; blockNumber <= toBlock; blockNumber++ {
+		block, err := l.getBlock(blockNumber)
 		if err != nil {
-			err = errors.Wrap(err, "Error getting the block count from bitcoin-core")
-			l.log.Error(err)
 			return err
 		}
-		blockNumber = uint64(blockNumberTmp)
+
+		if block == nil {
+			return errors.Errorf("Block %d not found", blockNumber)
+		}
+
+		err = l.processBlock(block, blockNumber, l.Confirmations)
+		if err != nil {
+			return errors.Wrapf(err, "Error processing block %d", blockNumber)
+		}
 	}
 
-	go l.processBlocks(blockNumber)
 	return nil
 }
 
 func (l *Listener) processBlocks(blockNumber uint64) {
-	l.log.Infof("Starting from block %d", blockNumber)
+	l.log.WithField("confirmations", l.Confirmations).Infof("Starting from block %d", blockNumber)
 
 	// Time when last new block has been seen
 	lastBlockSeen := time.Now()
 	missingBlockWarningLogged := false
 
 	for {
-		block, err := l.getBlock(blockNumber)
+		// Block giving `blockNumber` block the required number of confirmations
+		newBlockNumber := blockNumber + l.Confirmations - 1
+		newBlock, err := l.getBlock(newBlockNumber)
 		if err != nil {
-			l.log.WithFields(log.F{"err": err, "blockNumber": blockNumber}).Error("Error getting block")
+			l.log.WithFields(log.F{"err": err, "blockNumber": newBlockNumber}).Error("Error getting block")
 			time.Sleep(time.Second)
 			continue
 		}
 
 		// Block doesn't exist yet
-		if block == nil {
+		if newBlock == nil {
 			if time.Since(lastBlockSeen) > 20*time.Minute && !missingBlockWarningLogged {
 				l.log.Warn("No new block in more than 20 minutes")
 				missingBlockWarningLogged = true
@@ -82,15 +144,34 @@ func (l *Listener) processBlocks(blockNumber uint64) {
 		lastBlockSeen = time.Now()
 		missingBlockWarningLogged = false
 
-		err = l.processBlock(block)
+		block := newBlock
+		if l.Confirmations > 1 {
+			err = l.processNewBlock(newBlock, newBlockNumber)
+			if err != nil {
+				l.log.WithFields(log.F{"err": err, "blockHash": newBlock.Header.BlockHash().String()}).Error("Error processing new block")
+				time.Sleep(time.Second)
+				continue
+			}
+
+			block, err = l.getBlock(blockNumber)
+			if err != nil || block == nil {
+				l.log.WithFields(log.F{"err": err, "blockNumber": blockNumber}).Error("Error getting block")
+				time.Sleep(time.Second)
+				continue
+			}
+		}
+
+		err = l.processBlock(block, blockNumber, l.Confirmations)
 		if err != nil {
 			l.log.WithFields(log.F{"err": err, "blockHash": block.Header.BlockHash().String()}).Error("Error processing block")
 			time.Sleep(time.Second)
 			continue
 		}
 
+		metrics.BlockProcessed(database.ChainBitcoin, blockNumber)
+
 		// Persist block number
-		err = l.Storage.SaveLastProcessedBitcoinBlock(blockNumber)
+		err = l.Storage.SaveLastProcessedBlock(database.ChainBitcoin, blockNumber)
 		if err != nil {
 			l.log.WithField("err", err).Error("Error saving last processed block")
 			time.Sleep(time.Second)
@@ -103,6 +184,20 @@ func (l *Listener) processBlocks(blockNumber uint64) {
 	}
 }
 
+// processNewBlock streams transactions of a new block with one confirmation
+// and calls BlockHandler so confirmations of pending transactions are updated.
+func (l *Listener) processNewBlock(block *wire.MsgBlock, blockNumber uint64) error {
+	err := l.processBlock(block, blockNumber, 1)
+	if err != nil {
+		return err
+	}
+
+	if l.BlockHandler != nil {
+		return l.BlockHandler(blockNumber)
+	}
+	return nil
+}
+
 // getBlock returns (nil, nil) if block has not been found (not exists yet)
 func (l *Listener) getBlock(blockNumber uint64) (*wire.MsgBlock, error) {
 	blockHeight := int64(blockNumber)
@@ -127,14 +222,19 @@ func (l *Listener) getBlock(blockNumber uint64) (*wire.MsgBlock, error) {
 	return block, nil
 }
 
-func (l *Listener) processBlock(block *wire.MsgBlock) error {
+func (l *Listener) processBlock(block *wire.MsgBlock, blockNumber, confirmations uint64) error {
 	transactions := block.Transactions
 
 	localLog := l.log.WithFields(log.F{
-		"blockHash":    block.Header.BlockHash().String(),
-		"blockTime":    block.Header.Timestamp,
-		"transactions": len(transactions),
+		"blockHash":     block.Header.BlockHash().String(),
+		"blockNumber":   blockNumber,
+		"confirmations": confirmations,
+		"blockTime":     block.Header.Timestamp,
+		"transactions":  len(transactions),
 	})
+	if source, ok := l.Client.(chains.BlockSource); ok {
+		localLog = localLog.WithField("node", source.BlockSource())
+	}
 	localLog.Info("Processing block")
 
 	for _, transaction := range transactions {
@@ -162,10 +262,12 @@ func (l *Listener) processBlock(block *wire.MsgBlock) error {
 			}
 
 			handlerTransaction := Transaction{
-				Hash:       transaction.TxHash().String(),
-				TxOutIndex: index,
-				ValueSat:   output.Value,
-				To:         addresses[0].EncodeAddress(),
+				Hash:          transaction.TxHash().String(),
+				TxOutIndex:    index,
+				ValueSat:      output.Value,
+				To:            addresses[0].EncodeAddress(),
+				BlockNumber:   blockNumber,
+				Confirmations: confirmations,
 			}
 
 			err = l.TransactionHandler(handlerTransaction)
@@ -6,6 +6,8 @@ import (
 	"github.com/btcsuite/btcd/chaincfg"
 	"github.com/btcsuite/btcd/chaincfg/chainhash"
 	"github.com/btcsuite/btcd/wire"
+	"github.com/btcsuite/btcutil"
+	"github.com/stellar/go/services/bifrost/database"
 	"github.com/stellar/go/support/errors"
 	"github.com/stellar/go/support/log"
 	"github.com/tyler-smith/go-bip32"
@@ -20,17 +22,21 @@ var (
 
 // Listener listens for transactions using bitcoin-core RPC. It calls TransactionHandler for each new
 // transactions. It will reprocess the block if TransactionHandler returns error. It will
-// start from the block number returned from Storage.GetBitcoinBlockToProcess or the latest block
+// start from the block number returned from Storage.GetBlockToProcess or the latest block
 // if it returned 0. Transactions can be processed more than once, it's TransactionHandler
 // responsibility to ignore duplicates.
 // Listener tracks only P2PKH payments.
 // You can run multiple Listeners if Storage is implemented correctly.
 type Listener struct {
-	Enabled            bool
 	Client             Client  `inject:""`
 	Storage            Storage `inject:""`
 	TransactionHandler TransactionHandler
 	Testnet            bool
+	// Confirmations is the number of confirmations required to accept a transaction.
+	// If it's larger than 1, transactions are also streamed when their block is seen
+	// and BlockHandler is called for each new block. Default value is 1.
+	Confirmations uint64
+	BlockHandler  func(blockNumber uint64) error
 
 	chainParams *chaincfg.Params
 	log         *log.Entry
@@ -42,15 +48,28 @@ type Client interface {
 	GetBlock(blockHash *chainhash.Hash) (*wire.MsgBlock, error)
 }
 
+// WalletClient sends payments from the bitcoin-core wallet. Payments are signed
+// by bitcoin-core so Bifrost never has access to private keys.
+type WalletClient interface {
+	SendToAddress(address btcutil.Address, amount btcutil.Amount) (*chainhash.Hash, error)
+}
+
+// RawTransactionClient gets transactions from bitcoin-core. It's used to find
+// the sender of deposits to refund. Transactions not in the wallet can be found
+// only if bitcoin-core is run with `txindex=1`.
+type RawTransactionClient interface {
+	GetRawTransaction(txHash *chainhash.Hash) (*btcutil.Tx, error)
+}
+
 // Storage is an interface that must be implemented by an object using
 // persistent storage.
 type Storage interface {
-	// GetBitcoinBlockToProcess gets the number of Bitcoin block to process. `0` means the
+	// GetBlockToProcess gets the number of `chain` block to process. `0` means the
 	// processing should start from the current block.
-	GetBitcoinBlockToProcess() (uint64, error)
-	// SaveLastProcessedBitcoinBlock should update the number of the last processed Bitcoin
+	GetBlockToProcess(chain database.Chain) (uint64, error)
+	// SaveLastProcessedBlock should update the number of the last processed `chain`
 	// block. It should only update the block if block > current block in atomic transaction.
-	SaveLastProcessedBitcoinBlock(block uint64) error
+	SaveLastProcessedBlock(chain database.Chain, block uint64) error
 }
 
 type TransactionHandler func(transaction Transaction) error
@@ -61,6 +80,10 @@ type Transaction struct {
 	// Value in sats
 	ValueSat int64
 	To       string
+	// BlockNumber is the height of block containing the transaction.
+	BlockNumber uint64
+	// Confirmations is the number of blocks confirming the transaction.
+	Confirmations uint64
 }
 
 type AddressGenerator struct {
@@ -0,0 +1,67 @@
+package chains
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/stellar/go/support/errors"
+	"github.com/tyler-smith/go-bip32"
+)
+
+// DerivePublicKey deserializes `masterPublicKey` (BIP-32 extended public key)
+// and derives its child key using `derivationPath`. Receiving addresses are
+// derived from the returned key using the address index.
+//
+// `derivationPath` is relative to the master public key, ex. `0` or `m/0/1`.
+// Empty path (or `m`) returns the master public key. Hardened indexes (`0'`)
+// can't be derived from public keys: export the extended public key of the
+// last hardened level from your wallet instead.
+func DerivePublicKey(masterPublicKey, derivationPath string) (*bip32.Key, error) {
+	key, err := bip32.B58Deserialize(masterPublicKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error deserializing master public key")
+	}
+
+	if key.IsPrivate {
+		return nil, errors.New("Key is not a master public key")
+	}
+
+	path, err := ParseDerivationPath(derivationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, index := range path {
+		key, err = key.NewChildKey(index)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Error deriving child key %d", index)
+		}
+	}
+
+	return key, nil
+}
+
+// ParseDerivationPath parses non-hardened BIP-32 derivation path relative to
+// the master public key, ex. `0/1` or `m/0/1`.
+func ParseDerivationPath(path string) ([]uint32, error) {
+	path = strings.TrimPrefix(strings.TrimPrefix(path, "m"), "/")
+	if path == "" {
+		return nil, nil
+	}
+
+	components := strings.Split(path, "/")
+	indexes := make([]uint32, len(components))
+	for i, component := range components {
+		if strings.HasSuffix(component, "'") || strings.HasSuffix(component, "h") {
+			return nil, errors.Errorf("Hardened index %s can't be derived from public key", component)
+		}
+
+		index, err := strconv.ParseUint(component, 10, 32)
+		if err != nil || index >= uint64(bip32.FirstHardenedChild) {
+			return nil, errors.Errorf("Invalid derivation path index: %s", component)
+		}
+		indexes[i] = uint32(index)
+	}
+
+	return indexes, nil
+}
@@ -0,0 +1,59 @@
+package chains
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tyler-smith/go-bip32"
+)
+
+const testMasterPublicKey = "xpub6ExUcpBZP8LfA1q6asykSFvkeask9Jmhs3fjBNovyk3iqr8q2bN6PryKKCvLLkMs1u2667wJnoM5LRQc3JcsGbQAhjUqJavxhtdk363GbP2"
+
+func TestParseDerivationPath(t *testing.T) {
+	tests := []struct {
+		path     string
+		expected []uint32
+	}{
+		{"", nil},
+		{"m", nil},
+		{"0", []uint32{0}},
+		{"m/0/1", []uint32{0, 1}},
+		{"2/2147483647", []uint32{2, 2147483647}},
+	}
+
+	for _, test := range tests {
+		path, err := ParseDerivationPath(test.path)
+		require.NoError(t, err, test.path)
+		assert.Equal(t, test.expected, path, test.path)
+	}
+
+	for _, path := range []string{"0'", "m/44h", "a", "0//1", "2147483648", "-1"} {
+		_, err := ParseDerivationPath(path)
+		assert.Error(t, err, path)
+	}
+}
+
+func TestDerivePublicKey(t *testing.T) {
+	masterKey, err := bip32.B58Deserialize(testMasterPublicKey)
+	require.NoError(t, err)
+
+	key, err := DerivePublicKey(testMasterPublicKey, "")
+	require.NoError(t, err)
+	assert.Equal(t, masterKey.String(), key.String())
+
+	expected, err := masterKey.NewChildKey(1)
+	require.NoError(t, err)
+	expected, err = expected.NewChildKey(5)
+	require.NoError(t, err)
+
+	key, err = DerivePublicKey(testMasterPublicKey, "m/1/5")
+	require.NoError(t, err)
+	assert.Equal(t, expected.String(), key.String())
+
+	_, err = DerivePublicKey(testMasterPublicKey, "m/1'")
+	assert.Error(t, err)
+
+	_, err = DerivePublicKey("xprv9s21ZrQH143K2Cfj4mDZBcEecBmJmawReGwwoAou2zZzG45bM6cFPJSvobVTCB55L6Ld2y8RzC61CpvadeAnhws3CHsMFhNjozBKGNgucYm", "")
+	assert.Error(t, err)
+}
@@ -0,0 +1,165 @@
+package chains
+
+import (
+	"sync"
+	"time"
+
+	"github.com/stellar/go/services/bifrost/common"
+	"github.com/stellar/go/services/bifrost/database"
+	"github.com/stellar/go/services/bifrost/metrics"
+	"github.com/stellar/go/support/errors"
+	"github.com/stellar/go/support/log"
+)
+
+// DefaultCheckInterval is the default interval of nodes health checks.
+const DefaultCheckInterval = 15 * time.Second
+
+// BlockSource is implemented by clients sending requests to one of many nodes.
+// BlockSource returns the name of the node the last block was received from.
+type BlockSource interface {
+	BlockSource() string
+}
+
+// Failover tracks health of the RPC nodes of a chain and selects the node
+// requests are sent to. A node is unhealthy if a request to it failed or its
+// latest block is more than MaxLag blocks behind the best node. Nodes are
+// checked every CheckInterval so unhealthy nodes are used again when they
+// recover. The first healthy node in Nodes order is selected so primary node
+// is preferred. The best block number is reported in metrics as chain head.
+type Failover struct {
+	Chain database.Chain
+	// Nodes are the names of nodes (ex. host) used in logs.
+	Nodes []string
+	// Height returns the number of the latest block of `node`.
+	Height        func(node int) (uint64, error)
+	MaxLag        uint64
+	CheckInterval time.Duration
+
+	mutex   sync.Mutex
+	healthy []bool
+	current int
+	log     *log.Entry
+}
+
+// Start checks health of the nodes and starts checking it periodically in a
+// background goroutine.
+func (f *Failover) Start() error {
+	if len(f.Nodes) == 0 {
+		return errors.New("At least one node is required")
+	}
+
+	f.init()
+	f.check()
+	go f.checkPeriodically()
+	return nil
+}
+
+func (f *Failover) init() {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if f.log != nil {
+		return
+	}
+
+	f.log = common.CreateLogger("Failover").WithField("chain", f.Chain)
+	f.healthy = make([]bool, len(f.Nodes))
+	for i := range f.healthy {
+		f.healthy[i] = true
+	}
+
+	if f.CheckInterval == 0 {
+		f.CheckInterval = DefaultCheckInterval
+	}
+}
+
+// Current returns the index of the selected node.
+func (f *Failover) Current() int {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+	return f.current
+}
+
+// NodeName returns the name of `node`.
+func (f *Failover) NodeName(node int) string {
+	return f.Nodes[node]
+}
+
+// ReportError marks `node` as unhealthy after a failed request and selects
+// another node.
+func (f *Failover) ReportError(node int, err error) {
+	f.init()
+
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if !f.healthy[node] {
+		return
+	}
+
+	f.log.WithFields(log.F{"err": err, "node": f.Nodes[node]}).Warn("Request to node failed, marking node unhealthy")
+	f.healthy[node] = false
+	f.selectNode()
+}
+
+func (f *Failover) checkPeriodically() {
+	for {
+		time.Sleep(f.CheckInterval)
+		f.check()
+	}
+}
+
+// check updates health of all nodes using their latest block numbers.
+func (f *Failover) check() {
+	heights := make([]uint64, len(f.Nodes))
+	errs := make([]error, len(f.Nodes))
+
+	var best uint64
+	for i := range f.Nodes {
+		heights[i], errs[i] = f.Height(i)
+		if errs[i] == nil && heights[i] > best {
+			best = heights[i]
+		}
+	}
+
+	if best > 0 {
+		metrics.ChainHead(f.Chain, best)
+	}
+
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	for i, node := range f.Nodes {
+		localLog := f.log.WithFields(log.F{"node": node, "height": heights[i], "bestHeight": best})
+
+		healthy := errs[i] == nil && best-heights[i] <= f.MaxLag
+		if healthy != f.healthy[i] {
+			if healthy {
+				localLog.Info("Node is healthy")
+			} else if errs[i] != nil {
+				localLog.WithField("err", errs[i]).Warn("Node is unhealthy")
+			} else {
+				localLog.Warn("Node is unhealthy, stale block height")
+			}
+		}
+		f.healthy[i] = healthy
+	}
+
+	f.selectNode()
+}
+
+// selectNode selects the first healthy node. It keeps the current node if all
+// nodes are unhealthy. Must be called with mutex locked.
+func (f *Failover) selectNode() {
+	for i := range f.Nodes {
+		if f.healthy[i] {
+			if i != f.current {
+				f.log.WithFields(log.F{"from": f.Nodes[f.current], "to": f.Nodes[i]}).Warn("Switching node")
+				f.current = i
+			}
+			return
+		}
+	}
+
+	f.log.WithField("node", f.Nodes[f.current]).Error("All nodes are unhealthy")
+}
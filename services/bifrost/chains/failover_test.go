@@ -0,0 +1,59 @@
+package chains
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFailoverReportError(t *testing.T) {
+	failover := &Failover{Nodes: []string{"node1", "node2", "node3"}}
+	assert.Equal(t, 0, failover.Current())
+
+	failover.ReportError(0, errors.New("connection refused"))
+	assert.Equal(t, 1, failover.Current())
+
+	failover.ReportError(1, errors.New("connection refused"))
+	assert.Equal(t, 2, failover.Current())
+
+	// All nodes unhealthy, keep the current one
+	failover.ReportError(2, errors.New("connection refused"))
+	assert.Equal(t, 2, failover.Current())
+}
+
+func TestFailoverCheck(t *testing.T) {
+	heights := []uint64{100, 105, 105}
+	errs := []error{nil, nil, nil}
+
+	failover := &Failover{
+		Nodes:  []string{"node1", "node2", "node3"},
+		MaxLag: 2,
+		Height: func(node int) (uint64, error) {
+			return heights[node], errs[node]
+		},
+	}
+	failover.init()
+
+	// node1 is stale
+	failover.check()
+	assert.Equal(t, 1, failover.Current())
+	assert.Equal(t, "node2", failover.NodeName(failover.Current()))
+
+	// node1 caught up, primary node is preferred
+	heights[0] = 104
+	failover.check()
+	assert.Equal(t, 0, failover.Current())
+
+	errs[0] = errors.New("connection refused")
+	failover.check()
+	assert.Equal(t, 1, failover.Current())
+
+	// Errors are not taken into account when computing the best height
+	errs[0] = nil
+	heights = []uint64{105, 0, 0}
+	errs[1] = errors.New("connection refused")
+	errs[2] = errors.New("connection refused")
+	failover.check()
+	assert.Equal(t, 0, failover.Current())
+}
@@ -0,0 +1,192 @@
+package chains
+
+import (
+	"math/big"
+	"sort"
+	"sync"
+
+	"github.com/stellar/go/services/bifrost/common"
+	"github.com/stellar/go/services/bifrost/config"
+	"github.com/stellar/go/services/bifrost/database"
+	"github.com/stellar/go/services/bifrost/queue"
+	"github.com/stellar/go/support/errors"
+)
+
+// ChainAdapter connects Bifrost to a chain payments are received on. Each
+// adapter derives the receiving addresses of the chain and streams the
+// transactions of new blocks to the server, which handles them the same way
+// for every chain.
+type ChainAdapter interface {
+	// Start connects to the chain node, checks it's connected to the configured
+	// network and starts processing blocks in a background goroutine.
+	Start() error
+	// StreamTransactions sets the handler that transactions of processed blocks
+	// are streamed to. It must be called before Start. A block is reprocessed
+	// if the handler returns error so transactions can be streamed more than
+	// once. If more than one confirmation is required, transactions are streamed
+	// when their block is seen (with one confirmation) and again when they reach
+	// the required number of confirmations.
+	StreamTransactions(handler TransactionHandler)
+	// StreamBlocks sets the handler that numbers of new blocks are streamed to,
+	// after transactions of the block are streamed as seen. It's used to track
+	// the confirmations of pending transactions. It must be called before Start.
+	StreamBlocks(handler BlockHandler)
+	// ValidateAddress returns an error if `address` is not a valid address in
+	// the chain network.
+	ValidateAddress(address string) error
+	// DeriveAddress derives the receiving address with the given BIP-32 `index`.
+	DeriveAddress(index uint32) (string, error)
+	// MinimumValue returns the minimum value of transaction paying `assetCode`
+	// accepted by Bifrost in the base unit of the asset (satoshi, wei). It
+	// returns nil if the asset is not accepted.
+	MinimumValue(assetCode queue.AssetCode) *big.Int
+}
+
+// Withdrawer is implemented by chain adapters supporting withdrawals: payments
+// of bridged assets back to the issuing account paid out in the chain. Payouts
+// are signed by the wallet of the chain node so Bifrost never has access to
+// private keys.
+type Withdrawer interface {
+	// DecodeMemoAddress decodes the withdrawal address from Stellar hash memo
+	// containing raw address bytes, right-aligned.
+	DecodeMemoAddress(memo []byte) (string, error)
+	// Withdraw sends `amount` (Stellar amount) of `assetCode` to `address` and
+	// returns the hash of the chain transaction.
+	Withdraw(address string, assetCode queue.AssetCode, amount string) (string, error)
+}
+
+// Refunder is implemented by chain adapters able to find the address a deposit
+// was sent from, so deposits that can't be issued in Stellar can be refunded.
+// Refunds are paid out using Withdrawer.
+type Refunder interface {
+	// RefundAddress returns the address deposit `transactionID` (Transaction.Hash)
+	// was sent from.
+	RefundAddress(transactionID string) (string, error)
+}
+
+// Rescanner is implemented by chain adapters able to process historical blocks
+// again, ex. to find deposits missed during downtime.
+type Rescanner interface {
+	// Rescan streams transactions of blocks from `fromBlock` to `toBlock`
+	// (inclusive) to the handler set with StreamTransactions, with the required
+	// number of confirmations. It returns when all blocks are processed.
+	Rescan(fromBlock, toBlock uint64) error
+}
+
+// GapLimiter is implemented by chain adapters limiting the number of
+// consecutive generated addresses without deposits, so funds can be found by
+// wallet software restoring the keys.
+type GapLimiter interface {
+	// GapLimit returns the maximum number of consecutive generated addresses
+	// without deposits, 0 if not limited.
+	GapLimit() uint32
+}
+
+type TransactionHandler func(transaction Transaction) error
+
+type BlockHandler func(chain database.Chain, blockNumber uint64) error
+
+// Transaction is a payment to `To` address streamed by a ChainAdapter.
+type Transaction struct {
+	Chain database.Chain
+	// Hash identifies the payment in the chain. It's the transaction hash, with
+	// the log index for payments in token transfer events.
+	Hash string
+	// Index of the transaction output paying `To`, for chains with more than
+	// one output per transaction.
+	Index int
+	To    string
+	// Value in the base unit of the asset (satoshi, wei).
+	Value *big.Int
+	// Decimals is the number of decimals of the asset, Value is the amount
+	// multiplied by 10^Decimals.
+	Decimals uint8
+	// AssetCode is the code of the asset issued in Stellar for the payment.
+	AssetCode queue.AssetCode
+	// Amount is Value in the unit of AssetCode, with Stellar precision.
+	Amount string
+	// BlockNumber is the number of block containing the transaction.
+	BlockNumber uint64
+	// Confirmations is the number of blocks confirming the transaction,
+	// including the block containing it.
+	Confirmations uint64
+	// RequiredConfirmations is the number of confirmations required to accept
+	// the transaction.
+	RequiredConfirmations uint64
+}
+
+// Confirmed returns true if transaction has the required number of confirmations.
+func (t Transaction) Confirmed() bool {
+	return t.Confirmations >= t.RequiredConfirmations
+}
+
+// ToStellarAmount converts `value` in the base unit of asset with `decimals`
+// decimals to Stellar amount.
+func ToStellarAmount(value *big.Int, decimals uint8) string {
+	unit := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(decimals)), nil)
+	amount := new(big.Rat).SetFrac(value, unit)
+	return amount.FloatString(common.StellarAmountPrecision)
+}
+
+// Factory creates the adapter of a chain from the Bifrost config. It should
+// return nil adapter and no error if the chain is not configured.
+type Factory func(cfg *config.Config, db database.Database) (ChainAdapter, error)
+
+var (
+	factories     = map[database.Chain]Factory{}
+	factoriesLock sync.Mutex
+)
+
+// Register registers the adapter factory of `chain`. It is meant to be called
+// from the init function of the package implementing the adapter. It panics if
+// `chain` is already registered.
+func Register(chain database.Chain, factory Factory) {
+	factoriesLock.Lock()
+	defer factoriesLock.Unlock()
+
+	if _, exists := factories[chain]; exists {
+		panic("chain already registered: " + string(chain))
+	}
+	factories[chain] = factory
+}
+
+// Names returns the sorted names of registered chains.
+func Names() []database.Chain {
+	factoriesLock.Lock()
+	defer factoriesLock.Unlock()
+
+	sorted := make([]string, 0, len(factories))
+	for chain := range factories {
+		sorted = append(sorted, string(chain))
+	}
+	sort.Strings(sorted)
+
+	names := make([]database.Chain, len(sorted))
+	for i, chain := range sorted {
+		names[i] = database.Chain(chain)
+	}
+	return names
+}
+
+// New creates the adapters of all registered chains that are configured in
+// `cfg`.
+func New(cfg *config.Config, db database.Database) (map[database.Chain]ChainAdapter, error) {
+	adapters := map[database.Chain]ChainAdapter{}
+
+	for _, chain := range Names() {
+		factoriesLock.Lock()
+		factory := factories[chain]
+		factoriesLock.Unlock()
+
+		adapter, err := factory(cfg, db)
+		if err != nil {
+			return nil, errors.Wrap(err, "Error creating "+string(chain)+" adapter")
+		}
+
+		if adapter != nil {
+			adapters[chain] = adapter
+		}
+	}
+
+	return adapters, nil
+}
@@ -0,0 +1,27 @@
+package chains
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToStellarAmount(t *testing.T) {
+	tests := []struct {
+		value                 int64
+		decimals              uint8
+		expectedStellarAmount string
+	}{
+		{1, 0, "1.0000000"},
+		{1, 8, "0.0000000"},
+		{10, 8, "0.0000001"},
+		{123456789, 8, "1.2345679"},
+		{1500000, 6, "1.5000000"},
+	}
+
+	for _, test := range tests {
+		amount := ToStellarAmount(big.NewInt(test.value), test.decimals)
+		assert.Equal(t, test.expectedStellarAmount, amount)
+	}
+}
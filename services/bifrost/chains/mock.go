@@ -0,0 +1,89 @@
+package chains
+
+import (
+	"math/big"
+
+	"github.com/stellar/go/services/bifrost/queue"
+	"github.com/stretchr/testify/mock"
+)
+
+// MockAdapter is a mockable chain adapter.
+type MockAdapter struct {
+	mock.Mock
+}
+
+func (m *MockAdapter) Start() error {
+	a := m.Called()
+	return a.Error(0)
+}
+
+func (m *MockAdapter) StreamTransactions(handler TransactionHandler) {
+	m.Called(handler)
+}
+
+func (m *MockAdapter) StreamBlocks(handler BlockHandler) {
+	m.Called(handler)
+}
+
+func (m *MockAdapter) ValidateAddress(address string) error {
+	a := m.Called(address)
+	return a.Error(0)
+}
+
+func (m *MockAdapter) DeriveAddress(index uint32) (string, error) {
+	a := m.Called(index)
+	return a.String(0), a.Error(1)
+}
+
+func (m *MockAdapter) MinimumValue(assetCode queue.AssetCode) *big.Int {
+	a := m.Called(assetCode)
+	if a.Get(0) == nil {
+		return nil
+	}
+	return a.Get(0).(*big.Int)
+}
+
+// MockWithdrawer is a mockable chain adapter supporting withdrawals.
+type MockWithdrawer struct {
+	MockAdapter
+}
+
+func (m *MockWithdrawer) DecodeMemoAddress(memo []byte) (string, error) {
+	a := m.Called(memo)
+	return a.String(0), a.Error(1)
+}
+
+func (m *MockWithdrawer) Withdraw(address string, assetCode queue.AssetCode, amount string) (string, error) {
+	a := m.Called(address, assetCode, amount)
+	return a.String(0), a.Error(1)
+}
+
+// MockRefunder is a mockable chain adapter supporting withdrawals and refunds.
+type MockRefunder struct {
+	MockWithdrawer
+}
+
+func (m *MockRefunder) RefundAddress(transactionID string) (string, error) {
+	a := m.Called(transactionID)
+	return a.String(0), a.Error(1)
+}
+
+// MockRescanner is a mockable chain adapter able to rescan blocks.
+type MockRescanner struct {
+	MockAdapter
+}
+
+func (m *MockRescanner) Rescan(fromBlock, toBlock uint64) error {
+	a := m.Called(fromBlock, toBlock)
+	return a.Error(0)
+}
+
+// MockGapLimiter is a mockable chain adapter with address gap limit.
+type MockGapLimiter struct {
+	MockAdapter
+}
+
+func (m *MockGapLimiter) GapLimit() uint32 {
+	a := m.Called()
+	return a.Get(0).(uint32)
+}
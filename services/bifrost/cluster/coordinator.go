@@ -0,0 +1,179 @@
+package cluster
+
+import (
+	"os"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/stellar/go/services/bifrost/common"
+	"github.com/stellar/go/support/errors"
+	"github.com/stellar/go/support/log"
+)
+
+// Start starts acquiring shards of the transactions queue.
+func (c *Coordinator) Start() error {
+	c.init()
+	c.log.Info("Coordinator starting")
+
+	if c.Shards <= 0 {
+		return errors.New("Shards must be greater than 0")
+	}
+
+	if c.MaxShards < 0 || c.MaxShards > c.Shards {
+		return errors.New("MaxShards must be between 0 and Shards")
+	}
+
+	if c.MaxShards == 0 {
+		c.MaxShards = c.Shards
+	}
+
+	go c.manageShards()
+	return nil
+}
+
+func (c *Coordinator) init() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.log != nil {
+		return
+	}
+
+	c.log = common.CreateLogger("Coordinator")
+	c.shardLocks = make(map[int]Lock)
+
+	if c.CheckInterval == 0 {
+		c.CheckInterval = DefaultCheckInterval
+	}
+
+	if c.LostLeadership == nil {
+		c.LostLeadership = func(task string) {
+			os.Exit(-1)
+		}
+	}
+}
+
+// Lead runs `start` when this instance becomes the leader of `task`. It doesn't
+// block: until the lock of `task` is acquired the instance is a standby trying
+// to acquire it every CheckInterval. If `start` returns error the lock is
+// released so other instance can take over. If the lock is lost while the task
+// is running LostLeadership is called.
+func (c *Coordinator) Lead(task string, start func() error) {
+	c.init()
+	go c.lead(task, start)
+}
+
+func (c *Coordinator) lead(task string, start func() error) {
+	localLog := c.log.WithField("task", task)
+	localLog.Info("Waiting for leadership")
+
+	var lock Lock
+	for {
+		var err error
+		lock, err = c.Storage.TryLock(task)
+		if err != nil {
+			localLog.WithField("err", err).Error("Error acquiring lock")
+		} else if lock != nil {
+			break
+		}
+
+		time.Sleep(c.CheckInterval)
+	}
+
+	localLog.Info("Became leader, starting task")
+	err := start()
+	if err != nil {
+		localLog.WithField("err", err).Error("Error starting task, releasing leadership")
+		c.release(lock, localLog)
+		return
+	}
+
+	for {
+		time.Sleep(c.CheckInterval)
+
+		err := lock.Check()
+		if err != nil {
+			// The task keeps running: it must be stopped before other instance
+			// becomes the leader and runs it too.
+			localLog.WithField("err", err).Error("Lost leadership")
+			c.LostLeadership(task)
+			return
+		}
+	}
+}
+
+// HeldShards returns shards of the transactions queue held by this instance,
+// sorted.
+func (c *Coordinator) HeldShards() []int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	shards := make([]int, 0, len(c.shardLocks))
+	for shard := range c.shardLocks {
+		shards = append(shards, shard)
+	}
+	sort.Ints(shards)
+	return shards
+}
+
+func (c *Coordinator) manageShards() {
+	for {
+		c.checkShards()
+		c.acquireShards()
+		time.Sleep(c.CheckInterval)
+	}
+}
+
+// checkShards forgets shards which locks are no longer held.
+func (c *Coordinator) checkShards() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	for shard, lock := range c.shardLocks {
+		err := lock.Check()
+		if err != nil {
+			localLog := c.log.WithField("shard", shard)
+			localLog.WithField("err", err).Error("Lost shard")
+			c.release(lock, localLog)
+			delete(c.shardLocks, shard)
+		}
+	}
+}
+
+// acquireShards tries to acquire locks of shards not held by any instance until
+// MaxShards shards are held.
+func (c *Coordinator) acquireShards() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	for shard := 0; shard < c.Shards && len(c.shardLocks) < c.MaxShards; shard++ {
+		if _, held := c.shardLocks[shard]; held {
+			continue
+		}
+
+		lock, err := c.Storage.TryLock(shardLockName(shard))
+		if err != nil {
+			c.log.WithFields(log.F{"err": err, "shard": shard}).Error("Error acquiring shard lock")
+			return
+		}
+
+		if lock == nil {
+			continue
+		}
+
+		c.log.WithField("shard", shard).Info("Acquired shard")
+		c.shardLocks[shard] = lock
+	}
+}
+
+func (c *Coordinator) release(lock Lock, localLog *log.Entry) {
+	err := lock.Release()
+	if err != nil {
+		localLog.WithField("err", err).Error("Error releasing lock")
+	}
+}
+
+func shardLockName(shard int) string {
+	return "transactions_queue_shard_" + strconv.Itoa(shard)
+}
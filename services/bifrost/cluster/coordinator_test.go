@@ -0,0 +1,119 @@
+package cluster
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCoordinatorShards(t *testing.T) {
+	storage := &MockStorage{}
+	coordinator := &Coordinator{Storage: storage, Shards: 4, MaxShards: 2}
+	coordinator.init()
+
+	lock0 := &MockLock{}
+	lock2 := &MockLock{}
+	lock3 := &MockLock{}
+
+	// Shard 1 is held by other instance
+	storage.On("TryLock", "transactions_queue_shard_0").Return(lock0, nil).Once()
+	storage.On("TryLock", "transactions_queue_shard_1").Return(nil, nil)
+	storage.On("TryLock", "transactions_queue_shard_2").Return(lock2, nil).Once()
+
+	coordinator.acquireShards()
+	assert.Equal(t, []int{0, 2}, coordinator.HeldShards())
+	// MaxShards reached
+	storage.AssertNotCalled(t, "TryLock", "transactions_queue_shard_3")
+
+	// Connection holding shard 0 lock lost
+	lock0.On("Check").Return(errors.New("connection reset"))
+	lock0.On("Release").Return(nil)
+	lock2.On("Check").Return(nil)
+
+	coordinator.checkShards()
+	assert.Equal(t, []int{2}, coordinator.HeldShards())
+
+	// Shard 0 taken over by other instance
+	storage.On("TryLock", "transactions_queue_shard_0").Return(nil, nil)
+	storage.On("TryLock", "transactions_queue_shard_3").Return(lock3, nil).Once()
+
+	coordinator.acquireShards()
+	assert.Equal(t, []int{2, 3}, coordinator.HeldShards())
+}
+
+func TestCoordinatorLead(t *testing.T) {
+	storage := &MockStorage{}
+	coordinator := &Coordinator{Storage: storage, CheckInterval: time.Millisecond}
+
+	lock := &MockLock{}
+	lock.On("Check").Return(nil)
+
+	// Other instance is the leader
+	storage.On("TryLock", "ingestion_bitcoin").Return(nil, nil).Twice()
+	storage.On("TryLock", "ingestion_bitcoin").Return(lock, nil).Once()
+
+	started := make(chan bool)
+	coordinator.Lead("ingestion_bitcoin", func() error {
+		started <- true
+		return nil
+	})
+
+	select {
+	case <-started:
+	case <-time.After(time.Second):
+		t.Fatal("Task not started")
+	}
+
+	storage.AssertNumberOfCalls(t, "TryLock", 3)
+}
+
+func TestCoordinatorLeadStartError(t *testing.T) {
+	storage := &MockStorage{}
+	coordinator := &Coordinator{Storage: storage, CheckInterval: time.Millisecond}
+
+	released := make(chan bool)
+	lock := &MockLock{}
+	lock.On("Release").Return(nil).Run(func(mock.Arguments) {
+		released <- true
+	})
+	storage.On("TryLock", "ingestion_bitcoin").Return(lock, nil).Once()
+
+	coordinator.Lead("ingestion_bitcoin", func() error {
+		return errors.New("Invalid genesis hash")
+	})
+
+	select {
+	case <-released:
+	case <-time.After(time.Second):
+		t.Fatal("Lock not released")
+	}
+}
+
+func TestCoordinatorLeadLostLeadership(t *testing.T) {
+	storage := &MockStorage{}
+	lost := make(chan string)
+	coordinator := &Coordinator{
+		Storage:        storage,
+		CheckInterval:  time.Millisecond,
+		LostLeadership: func(task string) { lost <- task },
+	}
+
+	lock := &MockLock{}
+	lock.On("Check").Return(nil).Once()
+	lock.On("Check").Return(errors.New("Connection lost"))
+	storage.On("TryLock", "ingestion_bitcoin").Return(lock, nil).Once()
+
+	coordinator.Lead("ingestion_bitcoin", func() error {
+		return nil
+	})
+
+	select {
+	case task := <-lost:
+		assert.Equal(t, "ingestion_bitcoin", task)
+	case <-time.After(time.Second):
+		t.Fatal("Lost leadership not handled")
+	}
+}
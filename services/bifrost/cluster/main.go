@@ -0,0 +1,63 @@
+package cluster
+
+import (
+	"sync"
+	"time"
+
+	"github.com/stellar/go/support/log"
+)
+
+// DefaultCheckInterval is the default interval between attempts to acquire
+// locks of tasks and shards.
+const DefaultCheckInterval = 10 * time.Second
+
+// Coordinator coordinates many Bifrost instances using the same database, so
+// a single stopped instance doesn't halt deposits.
+//
+// Tasks that must be run by one instance only (ex. blocks ingestion) are run by
+// the leader: the instance holding the lock of a task. Other instances wait and
+// take over when the leader stops.
+//
+// Stellar submission is split into Shards by Stellar account. An instance pools
+// transactions of the shards it holds locks of (up to MaxShards) and submits
+// them using its own channel account. Shards of a stopped instance are taken
+// over by other instances.
+type Coordinator struct {
+	Storage Storage `inject:""`
+	// Shards is the number of shards of the transactions queue. It must be the
+	// same in all instances.
+	Shards int
+	// MaxShards is the maximum number of shards held by this instance. Default
+	// value is Shards.
+	MaxShards int
+	// CheckInterval is the interval between attempts to acquire locks. Default
+	// value is DefaultCheckInterval.
+	CheckInterval time.Duration
+	// LostLeadership is called when this instance loses the lock of a task it
+	// leads. Tasks can't be stopped once started so the default value exits the
+	// process: it can be restarted as a standby while other instance becomes the
+	// leader.
+	LostLeadership func(task string)
+
+	mutex      sync.Mutex
+	shardLocks map[int]Lock
+	log        *log.Entry
+}
+
+// Storage is an interface that must be implemented by an object using
+// persistent storage shared by all instances.
+type Storage interface {
+	// TryLock acquires exclusive lock `name`. It returns nil if the lock is held
+	// by other instance. The lock must be released when Release is called or
+	// when the instance holding it stops.
+	TryLock(name string) (Lock, error)
+}
+
+// Lock is an exclusive lock acquired using Storage.TryLock.
+type Lock interface {
+	// Check returns error if the lock is no longer held (ex. connection to the
+	// database was lost).
+	Check() error
+	// Release releases the lock.
+	Release() error
+}
@@ -0,0 +1,33 @@
+package cluster
+
+import (
+	"github.com/stretchr/testify/mock"
+)
+
+// MockStorage is a mockable cluster storage.
+type MockStorage struct {
+	mock.Mock
+}
+
+func (m *MockStorage) TryLock(name string) (Lock, error) {
+	a := m.Called(name)
+	if a.Get(0) == nil {
+		return nil, a.Error(1)
+	}
+	return a.Get(0).(Lock), a.Error(1)
+}
+
+// MockLock is a mockable lock.
+type MockLock struct {
+	mock.Mock
+}
+
+func (m *MockLock) Check() error {
+	a := m.Called()
+	return a.Error(0)
+}
+
+func (m *MockLock) Release() error {
+	a := m.Called()
+	return a.Error(0)
+}
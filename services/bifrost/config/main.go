@@ -1,11 +1,19 @@
 package config
 
 type Config struct {
-	Port                           int             `valid:"required"`
-	UsingProxy                     bool            `valid:"optional" toml:"using_proxy"`
-	Bitcoin                        *bitcoinConfig  `valid:"optional" toml:"bitcoin"`
-	Ethereum                       *ethereumConfig `valid:"optional" toml:"ethereum"`
-	AccessControlAllowOriginHeader string          `valid:"optional" toml:"access-control-allow-origin-header"`
+	Port                           int                `valid:"required"`
+	UsingProxy                     bool               `valid:"optional" toml:"using_proxy"`
+	Bitcoin                        *bitcoinConfig     `valid:"optional" toml:"bitcoin"`
+	Ethereum                       *ethereumConfig    `valid:"optional" toml:"ethereum"`
+	AccessControlAllowOriginHeader string             `valid:"optional" toml:"access-control-allow-origin-header"`
+	Dust                           *DustConfig        `valid:"optional" toml:"dust"`
+	Withdrawals                    *WithdrawalsConfig `valid:"optional" toml:"withdrawals"`
+	Refunds                        *RefundsConfig     `valid:"optional" toml:"refunds"`
+	Webhooks                       *WebhooksConfig    `valid:"optional" toml:"webhooks"`
+	Admin                          *AdminConfig       `valid:"optional" toml:"admin"`
+	Cluster                        *ClusterConfig     `valid:"optional" toml:"cluster"`
+	Price                          *PriceConfig       `valid:"optional" toml:"price"`
+	KYC                            *KYCConfig         `valid:"optional" toml:"kyc"`
 
 	Stellar struct {
 		Horizon           string `valid:"required" toml:"horizon"`
@@ -24,32 +32,149 @@ type Config struct {
 		SignerSecretKey string `valid:"required" toml:"signer_secret_key"`
 		// StartingBalance is the starting amount of XLM for newly created accounts.
 		// Default value is 41. Increase it if you need Data records / other custom entities on new account.
-		StartingBalance  string `valid:"optional,numeric" toml:"starting_balance"`
+		StartingBalance string `valid:"optional,numeric" toml:"starting_balance"`
+		// SkipAccountCreation disables creating accounts: recipients must create
+		// their accounts and Bifrost waits for them.
+		SkipAccountCreation bool `valid:"optional" toml:"skip_account_creation"`
+		// TrustlineTimeout is the maximum time of waiting for the recipient to add
+		// the trust line (and create the account if SkipAccountCreation is set), ex.
+		// `72h`. When it passes the deposit is marked to be refunded. Default is no
+		// timeout.
+		TrustlineTimeout string `valid:"optional" toml:"trustline_timeout"`
 	} `valid:"required" toml:"stellar"`
 	Database struct {
-		Type string `valid:"matches(^postgres$)"`
-		DSN  string `valid:"required"`
+		// Type is `postgres`, `sqlite` or `memory` (in-memory SQLite database).
+		Type string `valid:"matches(^(postgres|sqlite|memory)$)"`
+		// DSN is required by `postgres` and `sqlite` databases.
+		DSN string `valid:"optional"`
 	} `valid:"required"`
 }
 
 type bitcoinConfig struct {
 	MasterPublicKey string `valid:"required" toml:"master_public_key"`
+	// DerivationPath is the non-hardened BIP-32 path of the key addresses are
+	// derived from, relative to MasterPublicKey, ex. `0`. Default is empty:
+	// addresses are children of MasterPublicKey.
+	DerivationPath string `valid:"optional" toml:"derivation_path"`
+	// GapLimit is the maximum number of consecutive generated addresses without
+	// deposits. Wallet software restoring the keys stops looking for funds after
+	// its gap limit, usually 20. Default is 0: no limit.
+	GapLimit uint32 `valid:"optional" toml:"gap_limit"`
 	// Minimum value of transaction accepted by Bifrost in BTC.
 	// Everything below will be ignored.
 	MinimumValueBtc string `valid:"required" toml:"minimum_value_btc"`
-	// Host only
-	RpcServer string `valid:"required" toml:"rpc_server"`
-	RpcUser   string `valid:"optional" toml:"rpc_user"`
-	RpcPass   string `valid:"optional" toml:"rpc_pass"`
-	Testnet   bool   `valid:"optional" toml:"testnet"`
+	// Host only. Many hosts can be set, see RPCServers.
+	RpcServer RPCServers `valid:"required" toml:"rpc_server"`
+	RpcUser   string     `valid:"optional" toml:"rpc_user"`
+	RpcPass   string     `valid:"optional" toml:"rpc_pass"`
+	Testnet   bool       `valid:"optional" toml:"testnet"`
+	// Confirmations is the number of confirmations required to accept a
+	// transaction. Default value is 1.
+	Confirmations uint64 `valid:"optional" toml:"confirmations"`
 }
 
 type ethereumConfig struct {
 	NetworkID       string `valid:"required,int" toml:"network_id"`
 	MasterPublicKey string `valid:"required" toml:"master_public_key"`
+	// DerivationPath is the non-hardened BIP-32 path of the key addresses are
+	// derived from, relative to MasterPublicKey, ex. `0`. Default is empty:
+	// addresses are children of MasterPublicKey.
+	DerivationPath string `valid:"optional" toml:"derivation_path"`
+	// GapLimit is the maximum number of consecutive generated addresses without
+	// deposits. Wallet software restoring the keys stops looking for funds after
+	// its gap limit, usually 20. Default is 0: no limit.
+	GapLimit uint32 `valid:"optional" toml:"gap_limit"`
 	// Minimum value of transaction accepted by Bifrost in ETH.
 	// Everything below will be ignored.
 	MinimumValueEth string `valid:"required" toml:"minimum_value_eth"`
-	// Host only
-	RpcServer string `valid:"required" toml:"rpc_server"`
+	// Host only. Many hosts can be set, see RPCServers.
+	RpcServer RPCServers `valid:"required" toml:"rpc_server"`
+	// WSServer is the host of geth websocket RPC server. When it's set new blocks
+	// are detected using `newHeads` subscription instead of polling.
+	WSServer string `valid:"optional" toml:"ws_server"`
+	// Confirmations is the number of confirmations required to accept a
+	// transaction. Default value is 1.
+	Confirmations uint64 `valid:"optional" toml:"confirmations"`
+	// WithdrawalAccount is the account withdrawals are sent from. It must be
+	// unlocked in the node: Bifrost never has access to its private key.
+	WithdrawalAccount string `valid:"optional" toml:"withdrawal_account"`
+	// Tokens are ERC-20 tokens accepted by Bifrost.
+	Tokens []EthereumTokenConfig `valid:"optional" toml:"tokens"`
+}
+
+type EthereumTokenConfig struct {
+	// Address of the token contract.
+	ContractAddress string `valid:"required" toml:"contract_address"`
+	// AssetCode is the code of Stellar asset issued for the token.
+	AssetCode string `valid:"required" toml:"asset_code"`
+	// Decimals of the token, as returned by `decimals()` of the contract.
+	Decimals uint8 `valid:"optional" toml:"decimals"`
+	// Minimum value of transfer accepted by Bifrost in token units.
+	// Everything below will be ignored.
+	MinimumValue string `valid:"required" toml:"minimum_value"`
+}
+
+type DustConfig struct {
+	// Policy for payments below minimum value of all chains: `ignore` (default),
+	// `accumulate` or `refund`.
+	Policy string `valid:"optional" toml:"policy"`
+	// ChainPolicies overrides Policy for chains, ex. `bitcoin = "refund"`.
+	ChainPolicies map[string]string `valid:"optional" toml:"chain_policies"`
+}
+
+type WithdrawalsConfig struct {
+	// ManualApproval requires withdrawals to be approved with `bifrost withdrawals
+	// approve` before they are sent.
+	ManualApproval bool `valid:"optional" toml:"manual_approval"`
+}
+
+type RefundsConfig struct {
+	// ManualApproval requires refunds of deposits that can't be issued to be
+	// approved with `bifrost refunds approve` before they are sent.
+	ManualApproval bool `valid:"optional" toml:"manual_approval"`
+}
+
+type WebhooksConfig struct {
+	// URLs webhooks are sent to.
+	URLs []string `valid:"required" toml:"urls"`
+	// Secret is the key of HMAC-SHA256 signature of requests body.
+	Secret string `valid:"required" toml:"secret"`
+	// MaxAttempts is the number of delivery attempts after which a webhook is
+	// marked failed. Default value is 10.
+	MaxAttempts int `valid:"optional" toml:"max_attempts"`
+}
+
+type AdminConfig struct {
+	// Token authenticates admin API requests. It must be sent in
+	// `Authorization: Bearer <token>` header.
+	Token string `valid:"required" toml:"token"`
+}
+
+type ClusterConfig struct {
+	// Shards is the number of shards of the transactions queue. It must be the
+	// same in all instances.
+	Shards int `valid:"required" toml:"shards"`
+	// MaxShards is the maximum number of shards held by an instance. Default
+	// value is Shards.
+	MaxShards int `valid:"optional" toml:"max_shards"`
+}
+
+type PriceConfig struct {
+	// Oracle returning exchange rates: `static` (Rates) or `http` (URL).
+	Oracle string `valid:"matches(^(static|http)$)" toml:"oracle"`
+	// Rates are the amounts of token issued per unit of deposited asset, ex.
+	// `BTC = "10000"`. Used by `static` oracle.
+	Rates map[string]string `valid:"optional" toml:"rates"`
+	// URL of the price feed used by `http` oracle.
+	URL string `valid:"optional" toml:"url"`
+	// CacheTTL is the time rates fetched by `http` oracle are used for, ex.
+	// `30s`. Default value is 1 minute.
+	CacheTTL string `valid:"optional" toml:"cache_ttl"`
+}
+
+type KYCConfig struct {
+	// URL of the endpoint approving deposits before the asset is issued.
+	URL string `valid:"required" toml:"url"`
+	// Secret is the key of HMAC-SHA256 signature of requests body.
+	Secret string `valid:"required" toml:"secret"`
 }
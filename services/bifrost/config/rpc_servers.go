@@ -0,0 +1,33 @@
+package config
+
+import (
+	"github.com/stellar/go/support/errors"
+)
+
+// RPCServers is a list of RPC servers of a chain node. It can be set to a
+// single host (`rpc_server = "localhost:8545"`) or a list of hosts
+// (`rpc_server = ["node1:8545", "node2:8545"]`). Requests are sent to the first
+// healthy server.
+type RPCServers []string
+
+// UnmarshalTOML implements toml.Unmarshaler.
+func (r *RPCServers) UnmarshalTOML(data interface{}) error {
+	switch value := data.(type) {
+	case string:
+		*r = RPCServers{value}
+	case []interface{}:
+		servers := make(RPCServers, len(value))
+		for i, server := range value {
+			host, ok := server.(string)
+			if !ok {
+				return errors.New("rpc_server must be a string or a list of strings")
+			}
+			servers[i] = host
+		}
+		*r = servers
+	default:
+		return errors.New("rpc_server must be a string or a list of strings")
+	}
+
+	return nil
+}
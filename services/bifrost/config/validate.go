@@ -0,0 +1,131 @@
+package config
+
+import (
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+
+	supportConfig "github.com/stellar/go/support/config"
+	"github.com/tyler-smith/go-bip32"
+)
+
+const (
+	bitcoinDecimals  = 8
+	ethereumDecimals = 18
+	// maxAssetCodeLength is the maximum length of Stellar asset code.
+	maxAssetCodeLength = 12
+)
+
+// Validate checks values that can't be validated using struct tags: at least
+// one chain must be enabled, master public keys must be BIP-32 extended public
+// keys and minimum values positive amounts. It returns
+// *supportConfig.InvalidConfigError with the problem of each invalid field,
+// keyed by its TOML path.
+func (c *Config) Validate() error {
+	problems := map[string]string{}
+
+	if c.Bitcoin == nil && c.Ethereum == nil {
+		problems["bitcoin, ethereum"] = "at least one chain section must be set"
+	}
+
+	if c.Bitcoin != nil {
+		validateMasterPublicKey(problems, "bitcoin.master_public_key", c.Bitcoin.MasterPublicKey)
+		validateAmount(problems, "bitcoin.minimum_value_btc", c.Bitcoin.MinimumValueBtc, bitcoinDecimals)
+	}
+
+	if c.Ethereum != nil {
+		validateMasterPublicKey(problems, "ethereum.master_public_key", c.Ethereum.MasterPublicKey)
+		validateAmount(problems, "ethereum.minimum_value_eth", c.Ethereum.MinimumValueEth, ethereumDecimals)
+
+		for _, token := range c.Ethereum.Tokens {
+			field := "ethereum.tokens." + token.AssetCode
+			if len(token.AssetCode) > maxAssetCodeLength {
+				problems[field+".asset_code"] = "must be at most 12 characters long"
+			}
+			validateAmount(problems, field+".minimum_value", token.MinimumValue, int(token.Decimals))
+		}
+	}
+
+	if c.Database.Type != "memory" && c.Database.DSN == "" {
+		problems["database.dsn"] = "required by " + c.Database.Type + " database"
+	}
+
+	validateDuration(problems, "stellar.trustline_timeout", c.Stellar.TrustlineTimeout)
+
+	if c.Cluster != nil {
+		if c.Cluster.Shards <= 0 {
+			problems["cluster.shards"] = "must be greater than 0"
+		}
+		if c.Cluster.MaxShards < 0 || c.Cluster.MaxShards > c.Cluster.Shards {
+			problems["cluster.max_shards"] = "must be between 0 and shards"
+		}
+	}
+
+	if c.Price != nil {
+		switch c.Price.Oracle {
+		case "static":
+			if len(c.Price.Rates) == 0 {
+				problems["price.rates"] = "required by static oracle"
+			}
+			for assetCode, rate := range c.Price.Rates {
+				value, ok := new(big.Rat).SetString(rate)
+				if !ok || value.Sign() <= 0 {
+					problems["price.rates."+assetCode] = "must be a positive decimal number"
+				}
+			}
+		case "http":
+			if c.Price.URL == "" {
+				problems["price.url"] = "required by http oracle"
+			}
+			validateDuration(problems, "price.cache_ttl", c.Price.CacheTTL)
+		}
+	}
+
+	if len(problems) > 0 {
+		return &supportConfig.InvalidConfigError{InvalidFields: problems}
+	}
+	return nil
+}
+
+func validateMasterPublicKey(problems map[string]string, field, value string) {
+	key, err := bip32.B58Deserialize(value)
+	if err != nil {
+		problems[field] = "invalid BIP-32 extended key: " + err.Error()
+		return
+	}
+
+	if key.IsPrivate {
+		problems[field] = "must be a public key, never put private keys in the config"
+	}
+}
+
+// validateAmount checks if `value` is a positive decimal number with at most
+// `decimals` decimals.
+func validateAmount(problems map[string]string, field, value string, decimals int) {
+	amount, ok := new(big.Rat).SetString(value)
+	if !ok || strings.ContainsAny(value, "eE/") {
+		problems[field] = "must be a decimal number, ex. 0.01"
+		return
+	}
+
+	if amount.Sign() <= 0 {
+		problems[field] = "must be greater than 0"
+		return
+	}
+
+	if i := strings.Index(value, "."); i >= 0 && len(value)-i-1 > decimals {
+		problems[field] = "must have at most " + strconv.Itoa(decimals) + " decimals"
+	}
+}
+
+func validateDuration(problems map[string]string, field, value string) {
+	if value == "" {
+		return
+	}
+
+	_, err := time.ParseDuration(value)
+	if err != nil {
+		problems[field] = "must be a duration, ex. 72h or 30s"
+	}
+}
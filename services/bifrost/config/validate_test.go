@@ -0,0 +1,95 @@
+package config
+
+import (
+	"testing"
+
+	supportConfig "github.com/stellar/go/support/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testMasterPublicKey = "xpub6DxSCdWu6jKqr4isjo7bsPeDD6s3J4YVQV1JSHZg12Eagdqnf7XX4fxqyW2sLhUoFWutL7tAELU2LiGZrEXtjVbvYptvTX5Eoa4Mamdjm9u"
+
+func validConfig() Config {
+	cfg := Config{
+		Bitcoin: &bitcoinConfig{
+			MasterPublicKey: testMasterPublicKey,
+			MinimumValueBtc: "0.0001",
+		},
+	}
+	cfg.Database.Type = "memory"
+	return cfg
+}
+
+func invalidFields(t *testing.T, cfg Config) map[string]string {
+	err := cfg.Validate()
+	require.Error(t, err)
+	require.IsType(t, &supportConfig.InvalidConfigError{}, err)
+	return err.(*supportConfig.InvalidConfigError).InvalidFields
+}
+
+func TestValidate(t *testing.T) {
+	cfg := validConfig()
+	assert.NoError(t, cfg.Validate())
+}
+
+func TestValidateNoChains(t *testing.T) {
+	cfg := validConfig()
+	cfg.Bitcoin = nil
+	assert.Contains(t, invalidFields(t, cfg), "bitcoin, ethereum")
+}
+
+func TestValidateMasterPublicKey(t *testing.T) {
+	cfg := validConfig()
+	cfg.Bitcoin.MasterPublicKey = "invalid"
+	assert.Contains(t, invalidFields(t, cfg), "bitcoin.master_public_key")
+
+	cfg.Bitcoin.MasterPublicKey = "xprv9s21ZrQH143K2Cfj4mDZBcEecBmJmawReGwwoAou2zZzG45bM6cFPJSvobVTCB55L6Ld2y8RzC61CpvadeAnhws3CHsMFhNjozBKGNgucYm"
+	fields := invalidFields(t, cfg)
+	assert.Equal(t, "must be a public key, never put private keys in the config", fields["bitcoin.master_public_key"])
+}
+
+func TestValidateMinimumValue(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected string
+	}{
+		{"abc", "must be a decimal number, ex. 0.01"},
+		{"1e-3", "must be a decimal number, ex. 0.01"},
+		{"0", "must be greater than 0"},
+		{"-1", "must be greater than 0"},
+		{"0.000000001", "must have at most 8 decimals"},
+	}
+
+	for _, test := range tests {
+		cfg := validConfig()
+		cfg.Bitcoin.MinimumValueBtc = test.value
+		assert.Equal(t, test.expected, invalidFields(t, cfg)["bitcoin.minimum_value_btc"], test.value)
+	}
+}
+
+func TestValidateTokens(t *testing.T) {
+	cfg := validConfig()
+	cfg.Ethereum = &ethereumConfig{
+		MasterPublicKey: testMasterPublicKey,
+		MinimumValueEth: "0.00001",
+		Tokens: []EthereumTokenConfig{
+			{AssetCode: "OMG", Decimals: 2, MinimumValue: "0.001"},
+		},
+	}
+
+	fields := invalidFields(t, cfg)
+	assert.Equal(t, "must have at most 2 decimals", fields["ethereum.tokens.OMG.minimum_value"])
+}
+
+func TestValidateDatabase(t *testing.T) {
+	cfg := validConfig()
+	cfg.Database.Type = "postgres"
+	assert.Contains(t, invalidFields(t, cfg), "database.dsn")
+}
+
+func TestValidateCluster(t *testing.T) {
+	cfg := validConfig()
+	cfg.Cluster = &ClusterConfig{Shards: 4, MaxShards: 5}
+	assert.Contains(t, invalidFields(t, cfg), "cluster.max_shards")
+}
@@ -0,0 +1,90 @@
+package database
+
+import (
+	"hash/fnv"
+
+	"github.com/stellar/go/services/bifrost/cluster"
+	"github.com/stellar/go/support/db"
+	"github.com/stellar/go/support/errors"
+)
+
+// advisoryLock is a Postgres transaction-level advisory lock. It's held by an
+// open transaction so it's released when the connection is closed, ex. when
+// the instance holding it stops.
+type advisoryLock struct {
+	session *db.Session
+}
+
+func (l *advisoryLock) Check() error {
+	_, err := l.session.ExecRaw("SELECT 1")
+	return err
+}
+
+func (l *advisoryLock) Release() error {
+	return l.session.Rollback()
+}
+
+// sqliteLock is a lock held by a single instance using SQLite database.
+type sqliteLock struct {
+	database *SQLDatabase
+	name     string
+}
+
+func (l *sqliteLock) Check() error {
+	return nil
+}
+
+func (l *sqliteLock) Release() error {
+	l.database.locksMutex.Lock()
+	defer l.database.locksMutex.Unlock()
+	delete(l.database.locks, l.name)
+	return nil
+}
+
+// TryLock implements cluster.Storage interface. In Postgres it's using advisory
+// locks. SQLite databases are used by a single instance so locks are held in
+// memory.
+func (d *SQLDatabase) TryLock(name string) (cluster.Lock, error) {
+	if d.isSQLite() {
+		d.locksMutex.Lock()
+		defer d.locksMutex.Unlock()
+
+		if d.locks == nil {
+			d.locks = make(map[string]bool)
+		}
+
+		if d.locks[name] {
+			return nil, nil
+		}
+
+		d.locks[name] = true
+		return &sqliteLock{database: d, name: name}, nil
+	}
+
+	session := d.session.Clone()
+	err := session.Begin()
+	if err != nil {
+		return nil, errors.Wrap(err, "Error starting a new transaction")
+	}
+
+	var acquired bool
+	err = session.GetRaw(&acquired, "SELECT pg_try_advisory_xact_lock(?)", lockKey(name))
+	if err != nil {
+		session.Rollback()
+		return nil, errors.Wrap(err, "Error acquiring advisory lock")
+	}
+
+	if !acquired {
+		session.Rollback()
+		return nil, nil
+	}
+
+	return &advisoryLock{session: session}, nil
+}
+
+// lockKey returns the key of advisory lock `name`.
+func lockKey(name string) int64 {
+	hash := fnv.New64a()
+	hash.Write([]byte(name))
+	return int64(hash.Sum64())
+}
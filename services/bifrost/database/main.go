@@ -1,21 +1,40 @@
 package database
 
 import (
+	"math/big"
+	"sync"
 	"time"
 
+	"github.com/stellar/go/services/bifrost/cluster"
+	"github.com/stellar/go/services/bifrost/queue"
+	"github.com/stellar/go/services/bifrost/sse"
+	"github.com/stellar/go/services/bifrost/webhooks"
 	"github.com/stellar/go/support/db"
 	"github.com/stellar/go/support/errors"
 )
 
+const (
+	// TypePostgres is a Postgres database. Schema is created using migrations.
+	TypePostgres = "postgres"
+	// TypeSQLite is a SQLite database file. Schema is created on Open.
+	TypeSQLite = "sqlite"
+	// TypeMemory is an in-memory SQLite database for tests and demo. Data is lost
+	// when Bifrost stops.
+	TypeMemory = "memory"
+)
+
 type Chain string
 
 // Scan implements database/sql.Scanner interface
 func (s *Chain) Scan(src interface{}) error {
-	value, ok := src.([]byte)
-	if !ok {
+	switch value := src.(type) {
+	case []byte:
+		*s = Chain(value)
+	case string:
+		*s = Chain(value)
+	default:
 		return errors.New("Cannot convert value to Chain")
 	}
-	*s = Chain(value)
 	return nil
 }
 
@@ -34,6 +53,8 @@ type Database interface {
 	// GetAssociationByStellarPublicKey searches for previously saved Bitcoin/Ethereum-Stellar association.
 	// Should return nil if not found.
 	GetAssociationByStellarPublicKey(stellarPublicKey string) (*AddressAssociation, error)
+	// ListAssociations returns address associations, newest first.
+	ListAssociations(limit, offset uint64) ([]AddressAssociation, error)
 	// AddProcessedTransaction adds a transaction to database as processed. This
 	// should return `true` and no error if transaction processing has already started/finished.
 	AddProcessedTransaction(chain Chain, transactionID, receivingAddress string) (alreadyProcessing bool, err error)
@@ -41,6 +62,100 @@ type Database interface {
 	// derivation and then increments it. This operation must be atomic so this function
 	// should never return the same value more than once.
 	IncrementAddressIndex(chain Chain) (uint32, error)
+	// CountUnusedAddresses returns the number of `chain` addresses generated
+	// after the last address that received a transaction.
+	CountUnusedAddresses(chain Chain) (uint32, error)
+	// IsAddressUsed returns true if `chain` address received a transaction.
+	IsAddressUsed(chain Chain, address string) (bool, error)
+
+	// GetBlockToProcess gets the number of `chain` block to process. `0` means the
+	// processing should start from the current block.
+	GetBlockToProcess(chain Chain) (uint64, error)
+	// SaveLastProcessedBlock should update the number of the last processed `chain`
+	// block. It should only update the block if block > current block in atomic transaction.
+	SaveLastProcessedBlock(chain Chain, block uint64) error
+
+	// AddPendingTransaction adds a transaction waiting for the required number of
+	// confirmations. It should return false and no error if transaction already exists.
+	AddPendingTransaction(transaction PendingTransaction) (bool, error)
+	// UpdatePendingConfirmations updates confirmations of `chain` pending transactions
+	// after `blockNumber` block is seen and returns updated transactions. Pending
+	// transactions that should have been confirmed before `blockNumber` block (removed
+	// by chain reorganization) are marked as PendingTransactionStateDropped.
+	UpdatePendingConfirmations(chain Chain, blockNumber uint64) ([]PendingTransaction, error)
+	// ConfirmPendingTransaction marks pending transaction as PendingTransactionStateConfirmed.
+	// It returns false if transaction has never been pending.
+	ConfirmPendingTransaction(chain Chain, transactionID string) (bool, error)
+
+	// AddDust adds a payment below minimum accepted value to the dust ledger.
+	AddDust(dust Dust) error
+	// CreditDust sums values of DustStateAccumulated dust of `assetCode` paid to `address`.
+	// If the sum reaches `threshold` the dust is marked as DustStateCredited and the sum
+	// is returned. Otherwise it returns nil. This operation must be atomic.
+	CreditDust(chain Chain, address, assetCode string, threshold *big.Int) (*big.Int, error)
+	// GetDustReport returns the number and total value of dust payments grouped by
+	// chain, asset code and state.
+	GetDustReport() ([]DustReport, error)
+
+	// AddWithdrawal adds a withdrawal to database. It should return nil if withdrawal
+	// with the same PaymentID already exists.
+	AddWithdrawal(withdrawal Withdrawal) error
+	// GetWithdrawals returns withdrawals in `state` ordered by creation time.
+	GetWithdrawals(state WithdrawalState) ([]Withdrawal, error)
+	// ApproveWithdrawal changes the state of WithdrawalStatePendingApproval withdrawal
+	// to WithdrawalStateApproved. It returns false if withdrawal is not pending approval.
+	ApproveWithdrawal(paymentID string) (bool, error)
+	// ClaimWithdrawal returns the oldest WithdrawalStateApproved withdrawal and changes
+	// its state to WithdrawalStateSending. Returns nil if no withdrawals found. This
+	// operation must be atomic so a withdrawal is never sent more than once.
+	ClaimWithdrawal() (*Withdrawal, error)
+	// FinishWithdrawal changes the state of WithdrawalStateSending withdrawal to
+	// WithdrawalStateSent or WithdrawalStateFailed.
+	FinishWithdrawal(paymentID string, state WithdrawalState, chainTransactionID, errorMessage string) error
+	// GetWithdrawalsCursor returns the paging token of the last processed payment to
+	// the issuing account. Should return empty string if not found.
+	GetWithdrawalsCursor() (string, error)
+	// SaveWithdrawalsCursor saves the paging token of the last processed payment.
+	SaveWithdrawalsCursor(cursor string) error
+
+	// AddIssuance adds an issuance of transaction added to the transactions queue.
+	// It should return nil if issuance of the transaction already exists.
+	AddIssuance(issuance Issuance) error
+	// GetIssuance returns issuance of `transactionID`. Should return nil if not found.
+	GetIssuance(transactionID string) (*Issuance, error)
+	// GetIssuances returns issuances in `state` (all issuances if empty), newest first.
+	GetIssuances(state IssuanceState, limit, offset uint64) ([]Issuance, error)
+	// FinishIssuance changes the state of IssuanceStateQueued issuance to
+	// IssuanceStateDelivered, IssuanceStateFailed, IssuanceStateRefundPending or
+	// IssuanceStateRejected.
+	FinishIssuance(transactionID string, state IssuanceState, errorMessage string) error
+	// RetryIssuance changes the state of IssuanceStateFailed,
+	// IssuanceStateRefundPending or IssuanceStateRejected issuance to
+	// IssuanceStateQueued. It returns false if issuance is in other state.
+	RetryIssuance(transactionID string) (bool, error)
+	// ResolveIssuance changes the state of IssuanceStateFailed, IssuanceStateQueued,
+	// IssuanceStateRefundPending or IssuanceStateRejected issuance to
+	// IssuanceStateResolved. It returns false if issuance is in other state.
+	ResolveIssuance(transactionID string) (bool, error)
+
+	// AddRefund adds refund of the deposit of IssuanceStateFailed,
+	// IssuanceStateRefundPending or IssuanceStateRejected issuance and changes
+	// the issuance state to IssuanceStateRefunded in a single transaction. It
+	// returns false if issuance is in other state.
+	AddRefund(refund Refund) (bool, error)
+	// GetRefunds returns refunds in `state` ordered by creation time.
+	GetRefunds(state RefundState) ([]Refund, error)
+	// ApproveRefund changes the state of RefundStatePendingApproval or
+	// RefundStateFailed refund to RefundStateApproved. It returns false if refund
+	// is in other state.
+	ApproveRefund(transactionID string) (bool, error)
+	// ClaimRefund returns the oldest RefundStateApproved refund and changes its
+	// state to RefundStateSending. Returns nil if no refunds found. This operation
+	// must be atomic so a refund is never sent more than once.
+	ClaimRefund() (*Refund, error)
+	// FinishRefund changes the state of RefundStateSending refund to
+	// RefundStateSent or RefundStateFailed.
+	FinishRefund(transactionID string, state RefundState, chainTransactionID, errorMessage string) error
 
 	// ResetBlockCounters changes last processed bitcoin and ethereum block to default value.
 	// Used in stress tests.
@@ -50,8 +165,25 @@ type Database interface {
 	AddRecoveryTransaction(sourceAccount string, txEnvelope string) error
 }
 
-type PostgresDatabase struct {
+// Storage is implemented by database backends. Besides Database it's used as
+// transactions queue, SSE events storage, webhooks storage and cluster locks
+// storage.
+type Storage interface {
+	Database
+	queue.ShardedQueue
+	cluster.Storage
+	sse.Storage
+	webhooks.Storage
+}
+
+// SQLDatabase implements Storage using Postgres or SQLite.
+type SQLDatabase struct {
 	session *db.Session
+	dbType  string
+
+	// locks are locks held in SQLite databases, used by a single instance.
+	locks      map[string]bool
+	locksMutex sync.Mutex
 }
 
 type AddressAssociation struct {
@@ -63,3 +195,189 @@ type AddressAssociation struct {
 	StellarPublicKey string    `db:"stellar_public_key"`
 	CreatedAt        time.Time `db:"created_at"`
 }
+
+type DustState string
+
+const (
+	// DustStateIgnored is a dust payment ignored by Bifrost.
+	DustStateIgnored DustState = "ignored"
+	// DustStateAccumulated is a dust payment waiting for the address to receive
+	// enough dust to reach the minimum value.
+	DustStateAccumulated DustState = "accumulated"
+	// DustStateCredited is an accumulated dust payment that has been added to
+	// the transactions queue.
+	DustStateCredited DustState = "credited"
+	// DustStateRefundPending is a dust payment that should be refunded.
+	DustStateRefundPending DustState = "refund_pending"
+)
+
+// Dust is a payment below minimum value accepted by Bifrost.
+type Dust struct {
+	Chain         Chain  `db:"chain"`
+	TransactionID string `db:"transaction_id"`
+	Address       string `db:"address"`
+	AssetCode     string `db:"asset_code"`
+	// Value in the base unit of the asset.
+	Value     string    `db:"value"`
+	Decimals  uint8     `db:"decimals"`
+	State     DustState `db:"state"`
+	CreatedAt time.Time `db:"created_at"`
+}
+
+// DustReport is the number and total value of dust payments with the same
+// chain, asset code and state.
+type DustReport struct {
+	Chain     Chain     `db:"chain"`
+	AssetCode string    `db:"asset_code"`
+	State     DustState `db:"state"`
+	Decimals  uint8     `db:"decimals"`
+	Count     int64     `db:"count"`
+	// Value in the base unit of the asset.
+	Value string `db:"value"`
+}
+
+type WithdrawalState string
+
+const (
+	// WithdrawalStatePendingApproval is a withdrawal waiting for operator's approval.
+	WithdrawalStatePendingApproval WithdrawalState = "pending_approval"
+	// WithdrawalStateApproved is a withdrawal waiting to be sent.
+	WithdrawalStateApproved WithdrawalState = "approved"
+	// WithdrawalStateSending is a withdrawal being sent. If Bifrost stops while
+	// sending, the withdrawal remains in this state and must be checked manually.
+	WithdrawalStateSending WithdrawalState = "sending"
+	// WithdrawalStateSent is a withdrawal paid out in the chain.
+	WithdrawalStateSent WithdrawalState = "sent"
+	// WithdrawalStateFailed is a withdrawal that could not be sent.
+	WithdrawalStateFailed WithdrawalState = "failed"
+)
+
+// Withdrawal is a payment of bridged asset back to the issuing account paid out
+// in the chain.
+type Withdrawal struct {
+	// PaymentID is the ID of Stellar payment operation.
+	PaymentID        string `db:"payment_id"`
+	StellarPublicKey string `db:"stellar_public_key"`
+	Chain            Chain  `db:"chain"`
+	Address          string `db:"address"`
+	AssetCode        string `db:"asset_code"`
+	// Amount in the unit of AssetCode, with Stellar precision.
+	Amount             string          `db:"amount"`
+	State              WithdrawalState `db:"state"`
+	ChainTransactionID string          `db:"chain_transaction_id"`
+	Error              string          `db:"error"`
+	CreatedAt          time.Time       `db:"created_at"`
+	UpdatedAt          time.Time       `db:"updated_at"`
+}
+
+type IssuanceState string
+
+const (
+	// IssuanceStateQueued is an issuance waiting in the transactions queue or
+	// being processed by StellarAccountConfigurator.
+	IssuanceStateQueued IssuanceState = "queued"
+	// IssuanceStateDelivered is an issuance with asset sent to the Stellar account.
+	IssuanceStateDelivered IssuanceState = "delivered"
+	// IssuanceStateFailed is an issuance that could not be sent. It can be retried
+	// using the admin API.
+	IssuanceStateFailed IssuanceState = "failed"
+	// IssuanceStateRefundPending is an issuance to an account which was not
+	// created or had no trust line before the timeout or was merged. The
+	// deposit must be refunded.
+	IssuanceStateRefundPending IssuanceState = "refund_pending"
+	// IssuanceStateRejected is an issuance rejected by the KYC approval
+	// endpoint. It can be retried using the admin API once approved.
+	IssuanceStateRejected IssuanceState = "rejected"
+	// IssuanceStateRefunded is an issuance with the deposit refunded in the
+	// chain instead, see Refund.
+	IssuanceStateRefunded IssuanceState = "refunded"
+	// IssuanceStateResolved is an issuance marked as resolved manually by operator.
+	IssuanceStateResolved IssuanceState = "resolved"
+)
+
+// Issuance is the Stellar side of transaction processing: sending the asset to
+// the Stellar account associated with the receiving address.
+type Issuance struct {
+	Chain            Chain  `db:"chain"`
+	TransactionID    string `db:"transaction_id"`
+	Address          string `db:"address"`
+	StellarPublicKey string `db:"stellar_public_key"`
+	AssetCode        string `db:"asset_code"`
+	// Amount in the unit of AssetCode, with Stellar precision.
+	Amount string `db:"amount"`
+	// DepositAssetCode is the code of the deposited asset. It's different than
+	// AssetCode when deposits are issued at the exchange rate. Empty for
+	// issuances added before refunds were supported.
+	DepositAssetCode string `db:"deposit_asset_code"`
+	// DepositAmount in the unit of DepositAssetCode, with Stellar precision.
+	DepositAmount string        `db:"deposit_amount"`
+	State         IssuanceState `db:"state"`
+	Error         string        `db:"error"`
+	CreatedAt     time.Time     `db:"created_at"`
+	UpdatedAt     time.Time     `db:"updated_at"`
+}
+
+type RefundState string
+
+const (
+	// RefundStatePendingApproval is a refund waiting for operator's approval.
+	RefundStatePendingApproval RefundState = "pending_approval"
+	// RefundStateApproved is a refund waiting to be sent.
+	RefundStateApproved RefundState = "approved"
+	// RefundStateSending is a refund being sent. If Bifrost stops while sending,
+	// the refund remains in this state and must be checked manually.
+	RefundStateSending RefundState = "sending"
+	// RefundStateSent is a refund paid out in the chain.
+	RefundStateSent RefundState = "sent"
+	// RefundStateFailed is a refund that could not be sent. It can be approved
+	// again to retry.
+	RefundStateFailed RefundState = "failed"
+)
+
+// Refund is a payment of deposit that could not be issued in Stellar back to
+// the address it was sent from.
+type Refund struct {
+	// TransactionID is the ID of the refunded deposit (and its issuance).
+	TransactionID string `db:"transaction_id"`
+	Chain         Chain  `db:"chain"`
+	// Address the deposit was sent from, the refund is sent to.
+	Address   string `db:"address"`
+	AssetCode string `db:"asset_code"`
+	// Amount in the unit of AssetCode, with Stellar precision.
+	Amount             string      `db:"amount"`
+	State              RefundState `db:"state"`
+	ChainTransactionID string      `db:"chain_transaction_id"`
+	Error              string      `db:"error"`
+	CreatedAt          time.Time   `db:"created_at"`
+	UpdatedAt          time.Time   `db:"updated_at"`
+}
+
+type PendingTransactionState string
+
+const (
+	// PendingTransactionStatePending is a transaction waiting for confirmations.
+	PendingTransactionStatePending PendingTransactionState = "pending"
+	// PendingTransactionStateConfirmed is a transaction with the required number
+	// of confirmations.
+	PendingTransactionStateConfirmed PendingTransactionState = "confirmed"
+	// PendingTransactionStateDropped is a transaction removed from the chain
+	// before reaching the required number of confirmations.
+	PendingTransactionStateDropped PendingTransactionState = "dropped"
+)
+
+// PendingTransaction is a transaction to associated address waiting for the
+// required number of confirmations.
+type PendingTransaction struct {
+	Chain         Chain  `db:"chain"`
+	TransactionID string `db:"transaction_id"`
+	Address       string `db:"address"`
+	AssetCode     string `db:"asset_code"`
+	// Amount in the unit of AssetCode, with Stellar precision.
+	Amount                string                  `db:"amount"`
+	BlockNumber           uint64                  `db:"block_number"`
+	Confirmations         uint64                  `db:"confirmations"`
+	RequiredConfirmations uint64                  `db:"required_confirmations"`
+	State                 PendingTransactionState `db:"state"`
+	CreatedAt             time.Time               `db:"created_at"`
+	UpdatedAt             time.Time               `db:"updated_at"`
+}
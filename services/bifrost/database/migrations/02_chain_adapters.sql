@@ -0,0 +1,5 @@
+/* Chains are registered by adapters so they cannot be listed in an enum. */
+ALTER TABLE address_association ALTER COLUMN chain TYPE varchar(32);
+ALTER TABLE processed_transaction ALTER COLUMN chain TYPE varchar(32);
+
+DROP TYPE chain;
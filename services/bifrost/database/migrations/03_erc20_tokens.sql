@@ -0,0 +1,8 @@
+/* Token transfers are identified by "0x"+hash+"-"+log index. */
+ALTER TABLE processed_transaction ALTER COLUMN transaction_id TYPE varchar(100);
+ALTER TABLE transactions_queue ALTER COLUMN transaction_id TYPE varchar(100);
+
+/* ERC-20 tokens are issued with up to 12 characters long asset codes. */
+ALTER TABLE transactions_queue ALTER COLUMN asset_code TYPE varchar(12);
+ALTER TABLE transactions_queue DROP CONSTRAINT valid_asset_code;
+ALTER TABLE transactions_queue ADD CONSTRAINT valid_asset_code CHECK (char_length(asset_code) BETWEEN 1 AND 12);
@@ -0,0 +1,17 @@
+/* Payments below minimum accepted value */
+CREATE TABLE dust_ledger (
+  chain varchar(32) NOT NULL,
+  transaction_id varchar(100) NOT NULL,
+  address varchar(42) NOT NULL,
+  asset_code varchar(12) NOT NULL,
+  /* Value in the base unit of the asset (satoshi, wei) */
+  value numeric(78,0) NOT NULL,
+  decimals smallint NOT NULL,
+  state varchar(20) NOT NULL,
+  created_at timestamp NOT NULL,
+  PRIMARY KEY (chain, transaction_id),
+  CONSTRAINT valid_value CHECK (value >= 0),
+  CONSTRAINT valid_state CHECK (state IN ('ignored', 'accumulated', 'credited', 'refund_pending'))
+);
+
+CREATE INDEX dust_ledger_address_index ON dust_ledger (chain, address, asset_code, state);
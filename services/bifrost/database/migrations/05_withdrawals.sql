@@ -0,0 +1,19 @@
+/* Payments of bridged assets back to the issuing account paid out in the chain */
+CREATE TABLE withdrawal (
+  /* ID of Stellar payment operation */
+  payment_id varchar(32) NOT NULL,
+  stellar_public_key varchar(56) NOT NULL,
+  chain varchar(32) NOT NULL,
+  address varchar(42) NOT NULL,
+  asset_code varchar(12) NOT NULL,
+  amount varchar(30) NOT NULL,
+  state varchar(20) NOT NULL,
+  chain_transaction_id varchar(100) NOT NULL DEFAULT '',
+  error text NOT NULL DEFAULT '',
+  created_at timestamp NOT NULL,
+  updated_at timestamp NOT NULL,
+  PRIMARY KEY (payment_id),
+  CONSTRAINT valid_state CHECK (state IN ('pending_approval', 'approved', 'sending', 'sent', 'failed'))
+);
+
+CREATE INDEX withdrawal_state_index ON withdrawal (state, created_at);
@@ -0,0 +1,16 @@
+/* Webhooks retry queue */
+CREATE TABLE webhook_delivery (
+  id bigserial,
+  url text NOT NULL,
+  event varchar(32) NOT NULL,
+  payload text NOT NULL,
+  state varchar(20) NOT NULL,
+  attempts integer NOT NULL DEFAULT 0,
+  next_attempt_at timestamp NOT NULL,
+  last_error text NOT NULL DEFAULT '',
+  created_at timestamp NOT NULL,
+  PRIMARY KEY (id),
+  CONSTRAINT valid_state CHECK (state IN ('pending', 'delivered', 'failed'))
+);
+
+CREATE INDEX webhook_delivery_pending_index ON webhook_delivery (state, next_attempt_at);
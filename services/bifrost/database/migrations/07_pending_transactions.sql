@@ -0,0 +1,26 @@
+/* Transactions waiting for the required number of confirmations */
+CREATE TABLE pending_transaction (
+  chain varchar(32) NOT NULL,
+  transaction_id varchar(100) NOT NULL,
+  address varchar(42) NOT NULL,
+  asset_code varchar(12) NOT NULL,
+  amount varchar(30) NOT NULL,
+  block_number bigint NOT NULL,
+  confirmations bigint NOT NULL,
+  required_confirmations bigint NOT NULL,
+  state varchar(20) NOT NULL,
+  created_at timestamp NOT NULL,
+  updated_at timestamp NOT NULL,
+  PRIMARY KEY (chain, transaction_id),
+  CONSTRAINT valid_state CHECK (state IN ('pending', 'confirmed', 'dropped'))
+);
+
+CREATE INDEX pending_transaction_state_index ON pending_transaction (chain, state, block_number);
+
+/* `transaction_pending` events are broadcasted after each new block so they
+   cannot be listed in an enum or be unique per address. */
+ALTER TABLE broadcasted_event ALTER COLUMN event TYPE varchar(32);
+DROP TYPE event;
+
+ALTER TABLE broadcasted_event DROP CONSTRAINT broadcasted_event_address_event_key;
+CREATE UNIQUE INDEX broadcasted_event_address_event_key ON broadcasted_event (address, event) WHERE event <> 'transaction_pending';
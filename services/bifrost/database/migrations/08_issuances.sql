@@ -0,0 +1,18 @@
+/* Stellar side of transactions processing, listed and retried using the admin API */
+CREATE TABLE issuance (
+  chain varchar(32) NOT NULL,
+  transaction_id varchar(100) NOT NULL,
+  address varchar(42) NOT NULL,
+  stellar_public_key varchar(56) NOT NULL,
+  asset_code varchar(12) NOT NULL,
+  amount varchar(30) NOT NULL,
+  state varchar(20) NOT NULL,
+  error text NOT NULL DEFAULT '',
+  created_at timestamp NOT NULL,
+  updated_at timestamp NOT NULL,
+  PRIMARY KEY (transaction_id),
+  CONSTRAINT valid_state CHECK (state IN ('queued', 'delivered', 'failed', 'resolved'))
+);
+
+CREATE INDEX issuance_state_index ON issuance (state, created_at);
+CREATE INDEX address_association_created_at_index ON address_association (created_at);
@@ -0,0 +1,3 @@
+/* Shard key of the Stellar account, transactions queue shard is `shard_key % shards` */
+/* Transactions queued before the upgrade are in shard 0 */
+ALTER TABLE transactions_queue ADD COLUMN shard_key bigint NOT NULL DEFAULT 0;
@@ -0,0 +1,3 @@
+/* Issuances to accounts without trust line are refunded */
+ALTER TABLE issuance DROP CONSTRAINT valid_state;
+ALTER TABLE issuance ADD CONSTRAINT valid_state CHECK (state IN ('queued', 'delivered', 'failed', 'refund_pending', 'resolved'));
@@ -0,0 +1,3 @@
+/* Issuances rejected by the KYC approval endpoint */
+ALTER TABLE issuance DROP CONSTRAINT valid_state;
+ALTER TABLE issuance ADD CONSTRAINT valid_state CHECK (state IN ('queued', 'delivered', 'failed', 'refund_pending', 'rejected', 'resolved'));
@@ -0,0 +1,24 @@
+/* Deposits that could not be issued in Stellar refunded to the address they were sent from */
+ALTER TABLE issuance ADD COLUMN deposit_asset_code varchar(12) NOT NULL DEFAULT '';
+ALTER TABLE issuance ADD COLUMN deposit_amount varchar(30) NOT NULL DEFAULT '';
+ALTER TABLE issuance DROP CONSTRAINT valid_state;
+ALTER TABLE issuance ADD CONSTRAINT valid_state CHECK (state IN ('queued', 'delivered', 'failed', 'refund_pending', 'rejected', 'refunded', 'resolved'));
+
+CREATE TABLE refund (
+  /* ID of the refunded deposit */
+  transaction_id varchar(100) NOT NULL,
+  chain varchar(32) NOT NULL,
+  /* Address the deposit was sent from */
+  address varchar(42) NOT NULL,
+  asset_code varchar(12) NOT NULL,
+  amount varchar(30) NOT NULL,
+  state varchar(20) NOT NULL,
+  chain_transaction_id varchar(100) NOT NULL DEFAULT '',
+  error text NOT NULL DEFAULT '',
+  created_at timestamp NOT NULL,
+  updated_at timestamp NOT NULL,
+  PRIMARY KEY (transaction_id),
+  CONSTRAINT valid_state CHECK (state IN ('pending_approval', 'approved', 'sending', 'sent', 'failed'))
+);
+
+CREATE INDEX refund_state_index ON refund (state, created_at);
@@ -1,6 +1,8 @@
 package database
 
 import (
+	"math/big"
+
 	"github.com/stretchr/testify/mock"
 )
 
@@ -27,6 +29,11 @@ func (m *MockDatabase) GetAssociationByStellarPublicKey(stellarPublicKey string)
 	return a.Get(0).(*AddressAssociation), a.Error(1)
 }
 
+func (m *MockDatabase) ListAssociations(limit, offset uint64) ([]AddressAssociation, error) {
+	a := m.Called(limit, offset)
+	return a.Get(0).([]AddressAssociation), a.Error(1)
+}
+
 func (m *MockDatabase) AddProcessedTransaction(chain Chain, transactionID, receivingAddress string) (alreadyProcessing bool, err error) {
 	a := m.Called(chain, transactionID, receivingAddress)
 	return a.Get(0).(bool), a.Error(1)
@@ -37,6 +44,59 @@ func (m *MockDatabase) IncrementAddressIndex(chain Chain) (uint32, error) {
 	return a.Get(0).(uint32), a.Error(1)
 }
 
+func (m *MockDatabase) CountUnusedAddresses(chain Chain) (uint32, error) {
+	a := m.Called(chain)
+	return a.Get(0).(uint32), a.Error(1)
+}
+
+func (m *MockDatabase) IsAddressUsed(chain Chain, address string) (bool, error) {
+	a := m.Called(chain, address)
+	return a.Get(0).(bool), a.Error(1)
+}
+
+func (m *MockDatabase) GetBlockToProcess(chain Chain) (uint64, error) {
+	a := m.Called(chain)
+	return a.Get(0).(uint64), a.Error(1)
+}
+
+func (m *MockDatabase) SaveLastProcessedBlock(chain Chain, block uint64) error {
+	a := m.Called(chain, block)
+	return a.Error(0)
+}
+
+func (m *MockDatabase) AddPendingTransaction(transaction PendingTransaction) (bool, error) {
+	a := m.Called(transaction)
+	return a.Get(0).(bool), a.Error(1)
+}
+
+func (m *MockDatabase) UpdatePendingConfirmations(chain Chain, blockNumber uint64) ([]PendingTransaction, error) {
+	a := m.Called(chain, blockNumber)
+	return a.Get(0).([]PendingTransaction), a.Error(1)
+}
+
+func (m *MockDatabase) ConfirmPendingTransaction(chain Chain, transactionID string) (bool, error) {
+	a := m.Called(chain, transactionID)
+	return a.Get(0).(bool), a.Error(1)
+}
+
+func (m *MockDatabase) AddDust(dust Dust) error {
+	a := m.Called(dust)
+	return a.Error(0)
+}
+
+func (m *MockDatabase) CreditDust(chain Chain, address, assetCode string, threshold *big.Int) (*big.Int, error) {
+	a := m.Called(chain, address, assetCode, threshold)
+	if a.Get(0) == nil {
+		return nil, a.Error(1)
+	}
+	return a.Get(0).(*big.Int), a.Error(1)
+}
+
+func (m *MockDatabase) GetDustReport() ([]DustReport, error) {
+	a := m.Called()
+	return a.Get(0).([]DustReport), a.Error(1)
+}
+
 func (m *MockDatabase) ResetBlockCounters() error {
 	a := m.Called()
 	return a.Error(0)
@@ -46,3 +106,102 @@ func (m *MockDatabase) AddRecoveryTransaction(sourceAccount string, txEnvelope s
 	a := m.Called(sourceAccount, txEnvelope)
 	return a.Error(0)
 }
+
+func (m *MockDatabase) AddIssuance(issuance Issuance) error {
+	a := m.Called(issuance)
+	return a.Error(0)
+}
+
+func (m *MockDatabase) GetIssuance(transactionID string) (*Issuance, error) {
+	a := m.Called(transactionID)
+	if a.Get(0) == nil {
+		return nil, a.Error(1)
+	}
+	return a.Get(0).(*Issuance), a.Error(1)
+}
+
+func (m *MockDatabase) GetIssuances(state IssuanceState, limit, offset uint64) ([]Issuance, error) {
+	a := m.Called(state, limit, offset)
+	return a.Get(0).([]Issuance), a.Error(1)
+}
+
+func (m *MockDatabase) FinishIssuance(transactionID string, state IssuanceState, errorMessage string) error {
+	a := m.Called(transactionID, state, errorMessage)
+	return a.Error(0)
+}
+
+func (m *MockDatabase) RetryIssuance(transactionID string) (bool, error) {
+	a := m.Called(transactionID)
+	return a.Get(0).(bool), a.Error(1)
+}
+
+func (m *MockDatabase) ResolveIssuance(transactionID string) (bool, error) {
+	a := m.Called(transactionID)
+	return a.Get(0).(bool), a.Error(1)
+}
+
+func (m *MockDatabase) AddRefund(refund Refund) (bool, error) {
+	a := m.Called(refund)
+	return a.Get(0).(bool), a.Error(1)
+}
+
+func (m *MockDatabase) GetRefunds(state RefundState) ([]Refund, error) {
+	a := m.Called(state)
+	return a.Get(0).([]Refund), a.Error(1)
+}
+
+func (m *MockDatabase) ApproveRefund(transactionID string) (bool, error) {
+	a := m.Called(transactionID)
+	return a.Get(0).(bool), a.Error(1)
+}
+
+func (m *MockDatabase) ClaimRefund() (*Refund, error) {
+	a := m.Called()
+	if a.Get(0) == nil {
+		return nil, a.Error(1)
+	}
+	return a.Get(0).(*Refund), a.Error(1)
+}
+
+func (m *MockDatabase) FinishRefund(transactionID string, state RefundState, chainTransactionID, errorMessage string) error {
+	a := m.Called(transactionID, state, chainTransactionID, errorMessage)
+	return a.Error(0)
+}
+
+func (m *MockDatabase) AddWithdrawal(withdrawal Withdrawal) error {
+	a := m.Called(withdrawal)
+	return a.Error(0)
+}
+
+func (m *MockDatabase) GetWithdrawals(state WithdrawalState) ([]Withdrawal, error) {
+	a := m.Called(state)
+	return a.Get(0).([]Withdrawal), a.Error(1)
+}
+
+func (m *MockDatabase) ApproveWithdrawal(paymentID string) (bool, error) {
+	a := m.Called(paymentID)
+	return a.Get(0).(bool), a.Error(1)
+}
+
+func (m *MockDatabase) ClaimWithdrawal() (*Withdrawal, error) {
+	a := m.Called()
+	if a.Get(0) == nil {
+		return nil, a.Error(1)
+	}
+	return a.Get(0).(*Withdrawal), a.Error(1)
+}
+
+func (m *MockDatabase) FinishWithdrawal(paymentID string, state WithdrawalState, chainTransactionID, errorMessage string) error {
+	a := m.Called(paymentID, state, chainTransactionID, errorMessage)
+	return a.Error(0)
+}
+
+func (m *MockDatabase) GetWithdrawalsCursor() (string, error) {
+	a := m.Called()
+	return a.String(0), a.Error(1)
+}
+
+func (m *MockDatabase) SaveWithdrawalsCursor(cursor string) error {
+	a := m.Called(cursor)
+	return a.Error(0)
+}
@@ -0,0 +1,1186 @@
+package database
+
+import (
+	"database/sql"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/stellar/go/services/bifrost/queue"
+	"github.com/stellar/go/services/bifrost/sse"
+	"github.com/stellar/go/services/bifrost/webhooks"
+	"github.com/stellar/go/support/db"
+	"github.com/stellar/go/support/errors"
+)
+
+const (
+	// Keys of chain counters in key_value_store are prefixed with the chain name.
+	addressIndexKeySuffix = "_address_index"
+	lastBlockKeySuffix    = "_last_block"
+
+	withdrawalsCursorKey = "withdrawals_cursor"
+
+	addressAssociationTableName   = "address_association"
+	broadcastedEventTableName     = "broadcasted_event"
+	dustLedgerTableName           = "dust_ledger"
+	issuanceTableName             = "issuance"
+	keyValueStoreTableName        = "key_value_store"
+	pendingTransactionTableName   = "pending_transaction"
+	processedTransactionTableName = "processed_transaction"
+	transactionsQueueTableName    = "transactions_queue"
+	recoveryTransactionTableName  = "recovery_transaction"
+	refundTableName               = "refund"
+	webhookDeliveryTableName      = "webhook_delivery"
+	withdrawalTableName           = "withdrawal"
+)
+
+type keyValueStoreRow struct {
+	Key   string `db:"key"`
+	Value string `db:"value"`
+}
+
+type broadcastedEventRow struct {
+	ID      int64  `db:"id"`
+	Address string `db:"address"`
+	Event   string `db:"event"`
+	Data    string `db:"data"`
+}
+
+func (b *broadcastedEventRow) toSSE() sse.Event {
+	return sse.Event{
+		ID:      b.ID,
+		Address: b.Address,
+		Event:   sse.AddressEvent(b.Event),
+		Data:    b.Data,
+	}
+}
+
+type transactionsQueueRow struct {
+	TransactionID    string          `db:"transaction_id"`
+	AssetCode        queue.AssetCode `db:"asset_code"`
+	Amount           string          `db:"amount"`
+	StellarPublicKey string          `db:"stellar_public_key"`
+	ShardKey         int64           `db:"shard_key"`
+}
+
+type processedTransactionRow struct {
+	Chain            Chain     `db:"chain"`
+	TransactionID    string    `db:"transaction_id"`
+	ReceivingAddress string    `db:"receiving_address"`
+	CreatedAt        time.Time `db:"created_at"`
+}
+
+type recoveryTransactionRow struct {
+	Source      string `db:"source"`
+	EnvelopeXDR string `db:"envelope_xdr"`
+}
+
+func fromQueueTransaction(tx queue.Transaction) *transactionsQueueRow {
+	return &transactionsQueueRow{
+		TransactionID:    tx.TransactionID,
+		AssetCode:        tx.AssetCode,
+		Amount:           tx.Amount,
+		StellarPublicKey: tx.StellarPublicKey,
+		ShardKey:         int64(queue.ShardKey(tx.StellarPublicKey)),
+	}
+}
+
+var _ Storage = &SQLDatabase{}
+
+func isDuplicateError(err error) bool {
+	return strings.Contains(err.Error(), "duplicate key value violates unique constraint") ||
+		// SQLite
+		strings.Contains(err.Error(), "UNIQUE constraint failed")
+}
+
+func (r *transactionsQueueRow) toQueueTransaction() *queue.Transaction {
+	return &queue.Transaction{
+		TransactionID:    r.TransactionID,
+		AssetCode:        r.AssetCode,
+		Amount:           r.Amount,
+		StellarPublicKey: r.StellarPublicKey,
+	}
+}
+
+// Open connects to `dbType` database (TypePostgres, TypeSQLite or TypeMemory)
+// at `dsn`. `dsn` of TypeMemory database is ignored. Schema of SQLite databases
+// is created if it doesn't exist.
+func (d *SQLDatabase) Open(dbType, dsn string) error {
+	var err error
+	d.dbType = dbType
+
+	switch dbType {
+	case TypePostgres:
+		d.session, err = db.Open("postgres", dsn)
+		if err != nil {
+			return err
+		}
+		return nil
+	case TypeSQLite:
+	case TypeMemory:
+		dsn = ":memory:"
+	default:
+		return errors.New("Invalid database type: " + dbType)
+	}
+
+	d.session, err = db.Open("sqlite3", dsn)
+	if err != nil {
+		return errors.Wrap(err, "Error opening SQLite database (Bifrost must be built with cgo)")
+	}
+
+	// SQLite doesn't support `SELECT ... FOR UPDATE`, a single connection makes
+	// transactions serial. It also keeps the in-memory database alive.
+	d.session.DB.SetMaxOpenConns(1)
+
+	_, err = d.session.ExecRaw(sqliteSchema)
+	if err != nil {
+		return errors.Wrap(err, "Error creating SQLite schema")
+	}
+
+	return nil
+}
+
+func (d *SQLDatabase) isSQLite() bool {
+	return d.dbType == TypeSQLite || d.dbType == TypeMemory
+}
+
+// forUpdate returns `FOR UPDATE` suffix locking selected rows. It's empty in
+// SQLite where transactions are serial.
+func (d *SQLDatabase) forUpdate() string {
+	if d.isSQLite() {
+		return ""
+	}
+	return "FOR UPDATE"
+}
+
+func (d *SQLDatabase) getTable(name string, session *db.Session) *db.Table {
+	if session == nil {
+		session = d.session
+	}
+
+	return &db.Table{
+		Name:    name,
+		Session: session,
+	}
+}
+
+func (d *SQLDatabase) CreateAddressAssociation(chain Chain, stellarAddress, address string, addressIndex uint32) error {
+	addressAssociationTable := d.getTable(addressAssociationTableName, nil)
+
+	association := &AddressAssociation{
+		Chain:            chain,
+		AddressIndex:     addressIndex,
+		Address:          address,
+		StellarPublicKey: stellarAddress,
+		CreatedAt:        time.Now(),
+	}
+
+	_, err := addressAssociationTable.Insert(association).Exec()
+	return err
+}
+
+func (d *SQLDatabase) GetAssociationByChainAddress(chain Chain, address string) (*AddressAssociation, error) {
+	addressAssociationTable := d.getTable(addressAssociationTableName, nil)
+	row := &AddressAssociation{}
+	where := map[string]interface{}{"address": address, "chain": chain}
+	err := addressAssociationTable.Get(row, where).Exec()
+	if err != nil {
+		switch errors.Cause(err) {
+		case sql.ErrNoRows:
+			return nil, nil
+		default:
+			return nil, errors.Wrap(err, "Error getting addressAssociation from DB")
+		}
+	}
+
+	return row, nil
+}
+
+func (d *SQLDatabase) GetAssociationByStellarPublicKey(stellarPublicKey string) (*AddressAssociation, error) {
+	addressAssociationTable := d.getTable(addressAssociationTableName, nil)
+	row := &AddressAssociation{}
+	where := map[string]interface{}{"stellar_public_key": stellarPublicKey}
+	err := addressAssociationTable.Get(row, where).Exec()
+	if err != nil {
+		switch errors.Cause(err) {
+		case sql.ErrNoRows:
+			return nil, nil
+		default:
+			return nil, errors.Wrap(err, "Error getting addressAssociation from DB")
+		}
+	}
+
+	return row, nil
+}
+
+func (d *SQLDatabase) ListAssociations(limit, offset uint64) ([]AddressAssociation, error) {
+	addressAssociationTable := d.getTable(addressAssociationTableName, nil)
+	rows := []AddressAssociation{}
+	// `1=1`, see getEventsLastID.
+	err := addressAssociationTable.Select(&rows, "1=1").OrderBy("created_at DESC").Limit(limit).Offset(offset).Exec()
+	if err != nil {
+		return nil, errors.Wrap(err, "Error getting addressAssociations from DB")
+	}
+
+	return rows, nil
+}
+
+func (d *SQLDatabase) AddProcessedTransaction(chain Chain, transactionID, receivingAddress string) (bool, error) {
+	processedTransactionTable := d.getTable(processedTransactionTableName, nil)
+	processedTransaction := processedTransactionRow{chain, transactionID, receivingAddress, time.Now()}
+	_, err := processedTransactionTable.Insert(processedTransaction).Exec()
+	if err != nil && isDuplicateError(err) {
+		return true, nil
+	}
+	return false, err
+}
+
+func (d *SQLDatabase) CountUnusedAddresses(chain Chain) (uint32, error) {
+	row := struct {
+		Count uint32 `db:"count"`
+	}{}
+	err := d.session.GetRaw(
+		&row,
+		"SELECT count(*) AS count FROM "+addressAssociationTableName+" WHERE chain = ? AND address_index > COALESCE(("+
+			"SELECT max(a.address_index) FROM "+addressAssociationTableName+" a JOIN "+processedTransactionTableName+" p"+
+			" ON p.chain = a.chain AND p.receiving_address = a.address WHERE a.chain = ?"+
+			"), -1)",
+		chain, chain,
+	)
+	if err != nil {
+		return 0, errors.Wrap(err, "Error counting unused addresses")
+	}
+
+	return row.Count, nil
+}
+
+func (d *SQLDatabase) IsAddressUsed(chain Chain, address string) (bool, error) {
+	row := struct {
+		Count int64 `db:"count"`
+	}{}
+	err := d.session.GetRaw(
+		&row,
+		"SELECT count(*) AS count FROM "+processedTransactionTableName+" WHERE chain = ? AND receiving_address = ?",
+		chain, address,
+	)
+	if err != nil {
+		return false, errors.Wrap(err, "Error counting address transactions")
+	}
+
+	return row.Count > 0, nil
+}
+
+func (d *SQLDatabase) AddPendingTransaction(transaction PendingTransaction) (bool, error) {
+	pendingTransactionTable := d.getTable(pendingTransactionTableName, nil)
+	_, err := pendingTransactionTable.Insert(transaction).Exec()
+	if err != nil {
+		if isDuplicateError(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+func (d *SQLDatabase) UpdatePendingConfirmations(chain Chain, blockNumber uint64) ([]PendingTransaction, error) {
+	session := d.session.Clone()
+
+	err := session.Begin()
+	if err != nil {
+		return nil, errors.Wrap(err, "Error starting a new transaction")
+	}
+	defer session.Rollback()
+
+	now := time.Now()
+
+	// Transactions should have been confirmed when the block giving them the
+	// required number of confirmations was seen.
+	_, err = session.ExecRaw(
+		"UPDATE "+pendingTransactionTableName+" SET state = ?, updated_at = ? "+
+			"WHERE chain = ? AND state = ? AND block_number + required_confirmations <= ?",
+		PendingTransactionStateDropped, now, chain, PendingTransactionStatePending, blockNumber,
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error marking pending transactions as dropped")
+	}
+
+	// Transactions with confirmations changed by `blockNumber` block. They are
+	// selected and updated separately because SQLite doesn't support `RETURNING`.
+	where := "chain = ? AND state = ? AND block_number <= ? AND confirmations < ? - block_number + 1"
+
+	rows := []PendingTransaction{}
+	err = session.SelectRaw(
+		&rows,
+		"SELECT * FROM "+pendingTransactionTableName+" WHERE "+where+" "+d.forUpdate(),
+		chain, PendingTransactionStatePending, blockNumber, blockNumber,
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error getting pending transactions")
+	}
+
+	_, err = session.ExecRaw(
+		"UPDATE "+pendingTransactionTableName+" SET confirmations = ? - block_number + 1, updated_at = ? WHERE "+where,
+		blockNumber, now, chain, PendingTransactionStatePending, blockNumber, blockNumber,
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error updating pending transactions confirmations")
+	}
+
+	for i := range rows {
+		rows[i].Confirmations = blockNumber - rows[i].BlockNumber + 1
+		rows[i].UpdatedAt = now
+	}
+
+	err = session.Commit()
+	if err != nil {
+		return nil, errors.Wrap(err, "Error commiting a transaction")
+	}
+
+	return rows, nil
+}
+
+func (d *SQLDatabase) ConfirmPendingTransaction(chain Chain, transactionID string) (bool, error) {
+	result, err := d.session.ExecRaw(
+		"UPDATE "+pendingTransactionTableName+" SET state = ?, confirmations = required_confirmations, updated_at = ? "+
+			"WHERE chain = ? AND transaction_id = ?",
+		PendingTransactionStateConfirmed, time.Now(), chain, transactionID,
+	)
+	if err != nil {
+		return false, errors.Wrap(err, "Error confirming pending transaction")
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, errors.Wrap(err, "Error getting the number of confirmed transactions")
+	}
+
+	return affected > 0, nil
+}
+
+func (d *SQLDatabase) AddDust(dust Dust) error {
+	dustLedgerTable := d.getTable(dustLedgerTableName, nil)
+	_, err := dustLedgerTable.Insert(dust).Exec()
+	if err != nil && isDuplicateError(err) {
+		return nil
+	}
+	return err
+}
+
+func (d *SQLDatabase) CreditDust(chain Chain, address, assetCode string, threshold *big.Int) (*big.Int, error) {
+	session := d.session.Clone()
+	dustLedgerTable := d.getTable(dustLedgerTableName, session)
+
+	err := session.Begin()
+	if err != nil {
+		return nil, errors.Wrap(err, "Error starting a new transaction")
+	}
+	defer session.Rollback()
+
+	where := map[string]interface{}{
+		"chain":      chain,
+		"address":    address,
+		"asset_code": assetCode,
+		"state":      DustStateAccumulated,
+	}
+
+	rows := []Dust{}
+	err = dustLedgerTable.Select(&rows, where).Suffix(d.forUpdate()).Exec()
+	if err != nil {
+		return nil, errors.Wrap(err, "Error getting accumulated dust from DB")
+	}
+
+	total := new(big.Int)
+	for _, row := range rows {
+		value, ok := new(big.Int).SetString(row.Value, 10)
+		if !ok {
+			return nil, errors.New("Invalid dust value: " + row.Value)
+		}
+		total.Add(total, value)
+	}
+
+	if total.Cmp(threshold) < 0 {
+		return nil, nil
+	}
+
+	// TODO: something's wrong with db.Table.Update(). Setting the first argument does not work as expected.
+	_, err = dustLedgerTable.Update(nil, where).Set("state", DustStateCredited).Exec()
+	if err != nil {
+		return nil, errors.Wrap(err, "Error marking dust as credited")
+	}
+
+	err = session.Commit()
+	if err != nil {
+		return nil, errors.Wrap(err, "Error commiting a transaction")
+	}
+
+	return total, nil
+}
+
+func (d *SQLDatabase) GetDustReport() ([]DustReport, error) {
+	rows := []DustReport{}
+	err := d.session.SelectRaw(
+		&rows,
+		"SELECT chain, asset_code, state, decimals, count(*) AS count, CAST(sum(value) AS text) AS value FROM "+dustLedgerTableName+
+			" GROUP BY chain, asset_code, state, decimals ORDER BY chain, asset_code, state",
+	)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error getting dust report from DB")
+	}
+
+	return rows, nil
+}
+
+func (d *SQLDatabase) AddWithdrawal(withdrawal Withdrawal) error {
+	withdrawalTable := d.getTable(withdrawalTableName, nil)
+	_, err := withdrawalTable.Insert(withdrawal).Exec()
+	if err != nil && isDuplicateError(err) {
+		return nil
+	}
+	return err
+}
+
+func (d *SQLDatabase) GetWithdrawals(state WithdrawalState) ([]Withdrawal, error) {
+	withdrawalTable := d.getTable(withdrawalTableName, nil)
+	rows := []Withdrawal{}
+	err := withdrawalTable.Select(&rows, map[string]interface{}{"state": state}).OrderBy("created_at ASC").Exec()
+	if err != nil {
+		return nil, errors.Wrap(err, "Error getting withdrawals from DB")
+	}
+
+	return rows, nil
+}
+
+func (d *SQLDatabase) ApproveWithdrawal(paymentID string) (bool, error) {
+	withdrawalTable := d.getTable(withdrawalTableName, nil)
+
+	where := map[string]interface{}{"payment_id": paymentID, "state": WithdrawalStatePendingApproval}
+	// TODO: something's wrong with db.Table.Update(). Setting the first argument does not work as expected.
+	result, err := withdrawalTable.Update(nil, where).
+		Set("state", WithdrawalStateApproved).
+		Set("updated_at", time.Now()).
+		Exec()
+	if err != nil {
+		return false, errors.Wrap(err, "Error approving withdrawal")
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, errors.Wrap(err, "Error getting the number of approved withdrawals")
+	}
+
+	return affected == 1, nil
+}
+
+func (d *SQLDatabase) ClaimWithdrawal() (*Withdrawal, error) {
+	row := Withdrawal{}
+
+	session := d.session.Clone()
+	withdrawalTable := d.getTable(withdrawalTableName, session)
+
+	err := session.Begin()
+	if err != nil {
+		return nil, errors.Wrap(err, "Error starting a new transaction")
+	}
+	defer session.Rollback()
+
+	err = withdrawalTable.Get(&row, map[string]interface{}{"state": WithdrawalStateApproved}).OrderBy("created_at ASC").Suffix(d.forUpdate()).Exec()
+	if err != nil {
+		switch errors.Cause(err) {
+		case sql.ErrNoRows:
+			return nil, nil
+		default:
+			return nil, errors.Wrap(err, "Error getting withdrawal from DB")
+		}
+	}
+
+	row.State = WithdrawalStateSending
+	row.UpdatedAt = time.Now()
+
+	// TODO: something's wrong with db.Table.Update(). Setting the first argument does not work as expected.
+	_, err = withdrawalTable.Update(nil, map[string]interface{}{"payment_id": row.PaymentID}).
+		Set("state", row.State).
+		Set("updated_at", row.UpdatedAt).
+		Exec()
+	if err != nil {
+		return nil, errors.Wrap(err, "Error setting withdrawal as sending")
+	}
+
+	err = session.Commit()
+	if err != nil {
+		return nil, errors.Wrap(err, "Error commiting a transaction")
+	}
+
+	return &row, nil
+}
+
+func (d *SQLDatabase) FinishWithdrawal(paymentID string, state WithdrawalState, chainTransactionID, errorMessage string) error {
+	withdrawalTable := d.getTable(withdrawalTableName, nil)
+
+	where := map[string]interface{}{"payment_id": paymentID, "state": WithdrawalStateSending}
+	// TODO: something's wrong with db.Table.Update(). Setting the first argument does not work as expected.
+	_, err := withdrawalTable.Update(nil, where).
+		Set("state", state).
+		Set("chain_transaction_id", chainTransactionID).
+		Set("error", errorMessage).
+		Set("updated_at", time.Now()).
+		Exec()
+	if err != nil {
+		return errors.Wrap(err, "Error updating withdrawal")
+	}
+
+	return nil
+}
+
+func (d *SQLDatabase) AddIssuance(issuance Issuance) error {
+	issuanceTable := d.getTable(issuanceTableName, nil)
+	_, err := issuanceTable.Insert(issuance).Exec()
+	if err != nil && isDuplicateError(err) {
+		return nil
+	}
+	return err
+}
+
+func (d *SQLDatabase) GetIssuance(transactionID string) (*Issuance, error) {
+	issuanceTable := d.getTable(issuanceTableName, nil)
+	row := &Issuance{}
+	err := issuanceTable.Get(row, map[string]interface{}{"transaction_id": transactionID}).Exec()
+	if err != nil {
+		switch errors.Cause(err) {
+		case sql.ErrNoRows:
+			return nil, nil
+		default:
+			return nil, errors.Wrap(err, "Error getting issuance from DB")
+		}
+	}
+
+	return row, nil
+}
+
+func (d *SQLDatabase) GetIssuances(state IssuanceState, limit, offset uint64) ([]Issuance, error) {
+	issuanceTable := d.getTable(issuanceTableName, nil)
+
+	// `1=1`, see getEventsLastID.
+	var where interface{} = "1=1"
+	if state != "" {
+		where = map[string]interface{}{"state": state}
+	}
+
+	rows := []Issuance{}
+	err := issuanceTable.Select(&rows, where).OrderBy("created_at DESC").Limit(limit).Offset(offset).Exec()
+	if err != nil {
+		return nil, errors.Wrap(err, "Error getting issuances from DB")
+	}
+
+	return rows, nil
+}
+
+func (d *SQLDatabase) FinishIssuance(transactionID string, state IssuanceState, errorMessage string) error {
+	issuanceTable := d.getTable(issuanceTableName, nil)
+
+	where := map[string]interface{}{"transaction_id": transactionID, "state": IssuanceStateQueued}
+	// TODO: something's wrong with db.Table.Update(). Setting the first argument does not work as expected.
+	_, err := issuanceTable.Update(nil, where).
+		Set("state", state).
+		Set("error", errorMessage).
+		Set("updated_at", time.Now()).
+		Exec()
+	if err != nil {
+		return errors.Wrap(err, "Error updating issuance")
+	}
+
+	return nil
+}
+
+func (d *SQLDatabase) RetryIssuance(transactionID string) (bool, error) {
+	return d.changeIssuanceState(
+		transactionID,
+		[]IssuanceState{IssuanceStateFailed, IssuanceStateRefundPending, IssuanceStateRejected},
+		IssuanceStateQueued,
+	)
+}
+
+func (d *SQLDatabase) ResolveIssuance(transactionID string) (bool, error) {
+	return d.changeIssuanceState(
+		transactionID,
+		[]IssuanceState{IssuanceStateFailed, IssuanceStateQueued, IssuanceStateRefundPending, IssuanceStateRejected},
+		IssuanceStateResolved,
+	)
+}
+
+// changeIssuanceState changes the state of issuance in one of `from` states to
+// `to`. It returns false if issuance is in other state.
+func (d *SQLDatabase) changeIssuanceState(transactionID string, from []IssuanceState, to IssuanceState) (bool, error) {
+	issuanceTable := d.getTable(issuanceTableName, nil)
+
+	where := map[string]interface{}{"transaction_id": transactionID, "state": from}
+	// TODO: something's wrong with db.Table.Update(). Setting the first argument does not work as expected.
+	result, err := issuanceTable.Update(nil, where).
+		Set("state", to).
+		Set("updated_at", time.Now()).
+		Exec()
+	if err != nil {
+		return false, errors.Wrap(err, "Error updating issuance")
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, errors.Wrap(err, "Error getting the number of updated issuances")
+	}
+
+	return affected == 1, nil
+}
+
+func (d *SQLDatabase) AddRefund(refund Refund) (bool, error) {
+	session := d.session.Clone()
+	issuanceTable := d.getTable(issuanceTableName, session)
+	refundTable := d.getTable(refundTableName, session)
+
+	err := session.Begin()
+	if err != nil {
+		return false, errors.Wrap(err, "Error starting a new transaction")
+	}
+	defer session.Rollback()
+
+	where := map[string]interface{}{
+		"transaction_id": refund.TransactionID,
+		"state":          []IssuanceState{IssuanceStateFailed, IssuanceStateRefundPending, IssuanceStateRejected},
+	}
+	// TODO: something's wrong with db.Table.Update(). Setting the first argument does not work as expected.
+	result, err := issuanceTable.Update(nil, where).
+		Set("state", IssuanceStateRefunded).
+		Set("updated_at", time.Now()).
+		Exec()
+	if err != nil {
+		return false, errors.Wrap(err, "Error updating issuance")
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, errors.Wrap(err, "Error getting the number of updated issuances")
+	}
+
+	if affected != 1 {
+		return false, nil
+	}
+
+	_, err = refundTable.Insert(refund).Exec()
+	if err != nil {
+		return false, errors.Wrap(err, "Error adding refund")
+	}
+
+	err = session.Commit()
+	if err != nil {
+		return false, errors.Wrap(err, "Error commiting a transaction")
+	}
+
+	return true, nil
+}
+
+func (d *SQLDatabase) GetRefunds(state RefundState) ([]Refund, error) {
+	refundTable := d.getTable(refundTableName, nil)
+	rows := []Refund{}
+	err := refundTable.Select(&rows, map[string]interface{}{"state": state}).OrderBy("created_at ASC").Exec()
+	if err != nil {
+		return nil, errors.Wrap(err, "Error getting refunds from DB")
+	}
+
+	return rows, nil
+}
+
+func (d *SQLDatabase) ApproveRefund(transactionID string) (bool, error) {
+	refundTable := d.getTable(refundTableName, nil)
+
+	where := map[string]interface{}{
+		"transaction_id": transactionID,
+		"state":          []RefundState{RefundStatePendingApproval, RefundStateFailed},
+	}
+	// TODO: something's wrong with db.Table.Update(). Setting the first argument does not work as expected.
+	result, err := refundTable.Update(nil, where).
+		Set("state", RefundStateApproved).
+		Set("updated_at", time.Now()).
+		Exec()
+	if err != nil {
+		return false, errors.Wrap(err, "Error approving refund")
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, errors.Wrap(err, "Error getting the number of approved refunds")
+	}
+
+	return affected == 1, nil
+}
+
+func (d *SQLDatabase) ClaimRefund() (*Refund, error) {
+	row := Refund{}
+
+	session := d.session.Clone()
+	refundTable := d.getTable(refundTableName, session)
+
+	err := session.Begin()
+	if err != nil {
+		return nil, errors.Wrap(err, "Error starting a new transaction")
+	}
+	defer session.Rollback()
+
+	err = refundTable.Get(&row, map[string]interface{}{"state": RefundStateApproved}).OrderBy("created_at ASC").Suffix(d.forUpdate()).Exec()
+	if err != nil {
+		switch errors.Cause(err) {
+		case sql.ErrNoRows:
+			return nil, nil
+		default:
+			return nil, errors.Wrap(err, "Error getting refund from DB")
+		}
+	}
+
+	row.State = RefundStateSending
+	row.UpdatedAt = time.Now()
+
+	// TODO: something's wrong with db.Table.Update(). Setting the first argument does not work as expected.
+	_, err = refundTable.Update(nil, map[string]interface{}{"transaction_id": row.TransactionID}).
+		Set("state", row.State).
+		Set("updated_at", row.UpdatedAt).
+		Exec()
+	if err != nil {
+		return nil, errors.Wrap(err, "Error setting refund as sending")
+	}
+
+	err = session.Commit()
+	if err != nil {
+		return nil, errors.Wrap(err, "Error commiting a transaction")
+	}
+
+	return &row, nil
+}
+
+func (d *SQLDatabase) FinishRefund(transactionID string, state RefundState, chainTransactionID, errorMessage string) error {
+	refundTable := d.getTable(refundTableName, nil)
+
+	where := map[string]interface{}{"transaction_id": transactionID, "state": RefundStateSending}
+	// TODO: something's wrong with db.Table.Update(). Setting the first argument does not work as expected.
+	_, err := refundTable.Update(nil, where).
+		Set("state", state).
+		Set("chain_transaction_id", chainTransactionID).
+		Set("error", errorMessage).
+		Set("updated_at", time.Now()).
+		Exec()
+	if err != nil {
+		return errors.Wrap(err, "Error updating refund")
+	}
+
+	return nil
+}
+
+func (d *SQLDatabase) GetWithdrawalsCursor() (string, error) {
+	keyValueStore := d.getTable(keyValueStoreTableName, nil)
+	row := keyValueStoreRow{}
+
+	err := keyValueStore.Get(&row, map[string]interface{}{"key": withdrawalsCursorKey}).Exec()
+	if err != nil {
+		if errors.Cause(err) == sql.ErrNoRows {
+			return "", nil
+		}
+		return "", errors.Wrap(err, "Error getting `"+withdrawalsCursorKey+"` from DB")
+	}
+
+	return row.Value, nil
+}
+
+func (d *SQLDatabase) SaveWithdrawalsCursor(cursor string) error {
+	query := "INSERT INTO " + keyValueStoreTableName + " (key, value) VALUES (?, ?) ON CONFLICT (key) DO UPDATE SET value = EXCLUDED.value"
+	if d.isSQLite() {
+		query = "INSERT OR REPLACE INTO " + keyValueStoreTableName + " (key, value) VALUES (?, ?)"
+	}
+
+	_, err := d.session.ExecRaw(query, withdrawalsCursorKey, cursor)
+	if err != nil {
+		return errors.Wrap(err, "Error updating `"+withdrawalsCursorKey+"`")
+	}
+	return nil
+}
+
+func (d *SQLDatabase) IncrementAddressIndex(chain Chain) (uint32, error) {
+	key := string(chain) + addressIndexKeySuffix
+	row := keyValueStoreRow{}
+
+	session := d.session.Clone()
+	keyValueStore := d.getTable(keyValueStoreTableName, session)
+
+	err := session.Begin()
+	if err != nil {
+		return 0, errors.Wrap(err, "Error starting a new transaction")
+	}
+	defer session.Rollback()
+
+	err = d.ensureKey(session, key)
+	if err != nil {
+		return 0, err
+	}
+
+	err = keyValueStore.Get(&row, map[string]interface{}{"key": key}).Suffix(d.forUpdate()).Exec()
+	if err != nil {
+		return 0, errors.Wrap(err, "Error getting `"+key+"` from DB")
+	}
+
+	// TODO check for overflows - should we create a new account 1'?
+	index, err := strconv.ParseUint(row.Value, 10, 32)
+	if err != nil {
+		return 0, errors.Wrap(err, "Error converting `"+key+"` value to uint32")
+	}
+
+	index++
+
+	// TODO: something's wrong with db.Table.Update(). Setting the first argument does not work as expected.
+	_, err = keyValueStore.Update(nil, map[string]interface{}{"key": key}).Set("value", index).Exec()
+	if err != nil {
+		return 0, errors.Wrap(err, "Error updating `"+key+"`")
+	}
+
+	err = session.Commit()
+	if err != nil {
+		return 0, errors.Wrap(err, "Error commiting a transaction")
+	}
+
+	return uint32(index), nil
+}
+
+// ensureKey inserts `key` with `0` value to key_value_store if it doesn't
+// exist yet so counters of newly registered chains don't need a migration.
+func (d *SQLDatabase) ensureKey(session *db.Session, key string) error {
+	query := "INSERT INTO " + keyValueStoreTableName + " (key, value) VALUES (?, '0') ON CONFLICT DO NOTHING"
+	if d.isSQLite() {
+		query = "INSERT OR IGNORE INTO " + keyValueStoreTableName + " (key, value) VALUES (?, '0')"
+	}
+
+	_, err := session.ExecRaw(query, key)
+	if err != nil {
+		return errors.Wrap(err, "Error inserting `"+key+"`")
+	}
+	return nil
+}
+
+func (d *SQLDatabase) ResetBlockCounters() error {
+	keyValueStore := d.getTable(keyValueStoreTableName, nil)
+
+	_, err := keyValueStore.Update(nil, "key LIKE ?", "%"+lastBlockKeySuffix).Set("value", 0).Exec()
+	if err != nil {
+		return errors.Wrap(err, "Error reseting last block keys")
+	}
+
+	return nil
+}
+
+func (d *SQLDatabase) GetBlockToProcess(chain Chain) (uint64, error) {
+	key := string(chain) + lastBlockKeySuffix
+	keyValueStore := d.getTable(keyValueStoreTableName, nil)
+	row := keyValueStoreRow{}
+
+	err := keyValueStore.Get(&row, map[string]interface{}{"key": key}).Exec()
+	if err != nil {
+		if errors.Cause(err) == sql.ErrNoRows {
+			// Chain has never been processed
+			return 0, nil
+		}
+		return 0, errors.Wrap(err, "Error getting `"+key+"` from DB")
+	}
+
+	block, err := strconv.ParseUint(row.Value, 10, 64)
+	if err != nil {
+		return 0, errors.Wrap(err, "Error converting `"+key+"` value to uint64")
+	}
+
+	// If set, `block` is the last processed block so we need to start processing from the next one.
+	if block > 0 {
+		block++
+	}
+	return block, nil
+}
+
+func (d *SQLDatabase) SaveLastProcessedBlock(chain Chain, block uint64) error {
+	key := string(chain) + lastBlockKeySuffix
+	row := keyValueStoreRow{}
+
+	session := d.session.Clone()
+	keyValueStore := d.getTable(keyValueStoreTableName, session)
+
+	err := session.Begin()
+	if err != nil {
+		return errors.Wrap(err, "Error starting a new transaction")
+	}
+	defer session.Rollback()
+
+	err = d.ensureKey(session, key)
+	if err != nil {
+		return err
+	}
+
+	err = keyValueStore.Get(&row, map[string]interface{}{"key": key}).Suffix(d.forUpdate()).Exec()
+	if err != nil {
+		return errors.Wrap(err, "Error getting `"+key+"` from DB")
+	}
+
+	lastBlock, err := strconv.ParseUint(row.Value, 10, 64)
+	if err != nil {
+		return errors.Wrap(err, "Error converting `"+key+"` value to uint32")
+	}
+
+	if block > lastBlock {
+		// TODO: something's wrong with db.Table.Update(). Setting the first argument does not work as expected.
+		_, err = keyValueStore.Update(nil, map[string]interface{}{"key": key}).Set("value", block).Exec()
+		if err != nil {
+			return errors.Wrap(err, "Error updating `"+key+"`")
+		}
+	}
+
+	err = session.Commit()
+	if err != nil {
+		return errors.Wrap(err, "Error commiting a transaction")
+	}
+
+	return nil
+}
+
+// QueueAdd implements queue.Queue interface. If element already exists in a queue, it should
+// return nil.
+func (d *SQLDatabase) QueueAdd(tx queue.Transaction) error {
+	transactionsQueueTable := d.getTable(transactionsQueueTableName, nil)
+	transactionQueue := fromQueueTransaction(tx)
+	_, err := transactionsQueueTable.Insert(transactionQueue).Exec()
+	if err != nil {
+		if isDuplicateError(err) {
+			return nil
+		}
+	}
+	return err
+}
+
+// QueuePool receives and removes the head of this queue. Returns nil if no elements found.
+// QueuePool implements queue.Queue interface.
+func (d *SQLDatabase) QueuePool() (*queue.Transaction, error) {
+	return d.queuePool(map[string]interface{}{"pooled": false})
+}
+
+// QueuePoolShards receives and removes the head of `shards` of this queue split
+// into `total` shards. Returns nil if no elements found.
+// QueuePoolShards implements queue.ShardedQueue interface.
+func (d *SQLDatabase) QueuePoolShards(total int, shards []int) (*queue.Transaction, error) {
+	if len(shards) == 0 {
+		return nil, nil
+	}
+
+	placeholders := make([]string, len(shards))
+	args := []interface{}{false, total}
+	for i, shard := range shards {
+		placeholders[i] = "?"
+		args = append(args, shard)
+	}
+
+	where := "pooled = ? AND shard_key % ? IN (" + strings.Join(placeholders, ", ") + ")"
+	return d.queuePool(where, args...)
+}
+
+func (d *SQLDatabase) queuePool(where interface{}, args ...interface{}) (*queue.Transaction, error) {
+	row := transactionsQueueRow{}
+
+	session := d.session.Clone()
+	transactionsQueueTable := d.getTable(transactionsQueueTableName, session)
+
+	err := session.Begin()
+	if err != nil {
+		return nil, errors.Wrap(err, "Error starting a new transaction")
+	}
+	defer session.Rollback()
+
+	err = transactionsQueueTable.Get(&row, where, args...).OrderBy("id ASC").Suffix(d.forUpdate()).Exec()
+	if err != nil {
+		switch errors.Cause(err) {
+		case sql.ErrNoRows:
+			return nil, nil
+		default:
+			return nil, errors.Wrap(err, "Error getting transaction from a queue")
+		}
+	}
+
+	// TODO: something's wrong with db.Table.Update(). Setting the first argument does not work as expected.
+	updateWhere := map[string]interface{}{"transaction_id": row.TransactionID, "asset_code": row.AssetCode}
+	_, err = transactionsQueueTable.Update(nil, updateWhere).Set("pooled", true).Exec()
+	if err != nil {
+		return nil, errors.Wrap(err, "Error setting transaction as pooled in a queue")
+	}
+
+	err = session.Commit()
+	if err != nil {
+		return nil, errors.Wrap(err, "Error commiting a transaction")
+	}
+
+	return row.toQueueTransaction(), nil
+}
+
+// AddEvent adds a new server-sent event to the storage.
+func (d *SQLDatabase) AddEvent(event sse.Event) error {
+	broadcastedEventTable := d.getTable(broadcastedEventTableName, nil)
+	_, err := broadcastedEventTable.Insert(event).Exec()
+	if err != nil {
+		if isDuplicateError(err) {
+			return nil
+		}
+	}
+	return err
+}
+
+// GetEventsSinceID returns all events since `id`. Used to load and publish
+// all broadcasted events.
+// It returns the last event ID, list of events or error.
+// If `id` is equal `-1`:
+//    * it should return the last event ID and empty list if at least one
+//      event has been broadcasted.
+//    * it should return `0` if no events have been broadcasted.
+func (d *SQLDatabase) GetEventsSinceID(id int64) (int64, []sse.Event, error) {
+	if id == -1 {
+		lastID, err := d.getEventsLastID()
+		return lastID, nil, err
+	}
+
+	broadcastedEventTable := d.getTable(broadcastedEventTableName, nil)
+	rows := []broadcastedEventRow{}
+	err := broadcastedEventTable.Select(&rows, "id > ?", id).Exec()
+	if err != nil {
+		switch errors.Cause(err) {
+		case sql.ErrNoRows:
+			return 0, nil, nil
+		default:
+			return 0, nil, errors.Wrap(err, "Error getting broadcastedEvent's from DB")
+		}
+	}
+
+	var lastID int64
+	returnRows := make([]sse.Event, len(rows))
+	if len(rows) > 0 {
+		lastID = rows[len(rows)-1].ID
+		for i, event := range rows {
+			returnRows[i] = event.toSSE()
+		}
+	}
+
+	return lastID, returnRows, nil
+}
+
+// GetAddressEventsSinceID returns events of `address` with ID greater than
+// `id`, oldest first.
+func (d *SQLDatabase) GetAddressEventsSinceID(address string, id int64) ([]sse.Event, error) {
+	broadcastedEventTable := d.getTable(broadcastedEventTableName, nil)
+	rows := []broadcastedEventRow{}
+	err := broadcastedEventTable.Select(&rows, "address = ? AND id > ?", address, id).OrderBy("id ASC").Exec()
+	if err != nil {
+		return nil, errors.Wrap(err, "Error getting address events from DB")
+	}
+
+	events := make([]sse.Event, len(rows))
+	for i, event := range rows {
+		events[i] = event.toSSE()
+	}
+
+	return events, nil
+}
+
+// Returns the last event ID from broadcasted_event table.
+func (d *SQLDatabase) getEventsLastID() (int64, error) {
+	row := struct {
+		ID int64 `db:"id"`
+	}{}
+	broadcastedEventTable := d.getTable(broadcastedEventTableName, nil)
+	// TODO: `1=1`. We should be able to get row without WHERE clause.
+	// When it's set to nil: `pq: syntax error at or near "ORDER""`
+	err := broadcastedEventTable.Get(&row, "1=1").OrderBy("id DESC").Exec()
+	if err != nil {
+		switch errors.Cause(err) {
+		case sql.ErrNoRows:
+			return 0, nil
+		default:
+			return 0, errors.Wrap(err, "Error getting events last ID")
+		}
+	}
+
+	return row.ID, nil
+}
+
+// AddWebhookDelivery implements webhooks.Storage interface.
+func (d *SQLDatabase) AddWebhookDelivery(delivery webhooks.Delivery) error {
+	_, err := d.session.ExecRaw(
+		"INSERT INTO "+webhookDeliveryTableName+
+			" (url, event, payload, state, attempts, next_attempt_at, last_error, created_at) VALUES (?, ?, ?, ?, ?, ?, ?, ?)",
+		delivery.URL, delivery.Event, delivery.Payload, delivery.State,
+		delivery.Attempts, delivery.NextAttemptAt, delivery.LastError, delivery.CreatedAt,
+	)
+	if err != nil {
+		return errors.Wrap(err, "Error adding webhook delivery")
+	}
+	return nil
+}
+
+// GetWebhookDelivery implements webhooks.Storage interface.
+func (d *SQLDatabase) GetWebhookDelivery(lease time.Duration) (*webhooks.Delivery, error) {
+	row := webhooks.Delivery{}
+
+	session := d.session.Clone()
+	webhookDeliveryTable := d.getTable(webhookDeliveryTableName, session)
+
+	err := session.Begin()
+	if err != nil {
+		return nil, errors.Wrap(err, "Error starting a new transaction")
+	}
+	defer session.Rollback()
+
+	now := time.Now()
+	err = webhookDeliveryTable.Get(&row, "state = ? AND next_attempt_at <= ?", webhooks.DeliveryStatePending, now).
+		OrderBy("next_attempt_at ASC").
+		Suffix(d.forUpdate()).
+		Exec()
+	if err != nil {
+		switch errors.Cause(err) {
+		case sql.ErrNoRows:
+			return nil, nil
+		default:
+			return nil, errors.Wrap(err, "Error getting webhook delivery from DB")
+		}
+	}
+
+	// TODO: something's wrong with db.Table.Update(). Setting the first argument does not work as expected.
+	_, err = webhookDeliveryTable.Update(nil, map[string]interface{}{"id": row.ID}).Set("next_attempt_at", now.Add(lease)).Exec()
+	if err != nil {
+		return nil, errors.Wrap(err, "Error postponing webhook delivery")
+	}
+
+	err = session.Commit()
+	if err != nil {
+		return nil, errors.Wrap(err, "Error commiting a transaction")
+	}
+
+	return &row, nil
+}
+
+// UpdateWebhookDelivery implements webhooks.Storage interface.
+func (d *SQLDatabase) UpdateWebhookDelivery(delivery webhooks.Delivery) error {
+	webhookDeliveryTable := d.getTable(webhookDeliveryTableName, nil)
+
+	// TODO: something's wrong with db.Table.Update(). Setting the first argument does not work as expected.
+	_, err := webhookDeliveryTable.Update(nil, map[string]interface{}{"id": delivery.ID}).
+		Set("state", delivery.State).
+		Set("attempts", delivery.Attempts).
+		Set("next_attempt_at", delivery.NextAttemptAt).
+		Set("last_error", delivery.LastError).
+		Exec()
+	if err != nil {
+		return errors.Wrap(err, "Error updating webhook delivery")
+	}
+	return nil
+}
+
+func (d *SQLDatabase) AddRecoveryTransaction(sourceAccount string, txEnvelope string) error {
+	recoveryTransactionTable := d.getTable(recoveryTransactionTableName, nil)
+	recoveryTransaction := recoveryTransactionRow{Source: sourceAccount, EnvelopeXDR: txEnvelope}
+
+	_, err := recoveryTransactionTable.Insert(recoveryTransaction).Exec()
+	return err
+}
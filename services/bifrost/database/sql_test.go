@@ -0,0 +1,424 @@
+// SQLite driver is available only in cgo builds
+// +build cgo
+
+package database
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stellar/go/services/bifrost/queue"
+	"github.com/stellar/go/services/bifrost/sse"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func openMemoryDatabase(t *testing.T) *SQLDatabase {
+	d := &SQLDatabase{}
+	err := d.Open(TypeMemory, "")
+	require.NoError(t, err)
+	return d
+}
+
+func TestMemoryAddressIndexAndBlocks(t *testing.T) {
+	d := openMemoryDatabase(t)
+
+	index, err := d.IncrementAddressIndex(ChainBitcoin)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(1), index)
+
+	index, err = d.IncrementAddressIndex(ChainBitcoin)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(2), index)
+
+	block, err := d.GetBlockToProcess(ChainEthereum)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(0), block)
+
+	require.NoError(t, d.SaveLastProcessedBlock(ChainEthereum, 100))
+	// Lower block is ignored
+	require.NoError(t, d.SaveLastProcessedBlock(ChainEthereum, 99))
+
+	block, err = d.GetBlockToProcess(ChainEthereum)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(101), block)
+}
+
+func TestMemoryAssociationsAndProcessedTransactions(t *testing.T) {
+	d := openMemoryDatabase(t)
+
+	stellarPublicKey := "GDULKYRRVOMASFMXBYD4BYFRSHAKQDREEVVP2TMH2CER3DW2KATIOASB"
+	address := "1Q74qRud8bXUn6FMtXWZwJa5pj56s3mdyf"
+	require.NoError(t, d.CreateAddressAssociation(ChainBitcoin, stellarPublicKey, address, 1))
+
+	association, err := d.GetAssociationByChainAddress(ChainBitcoin, address)
+	require.NoError(t, err)
+	require.NotNil(t, association)
+	assert.Equal(t, ChainBitcoin, association.Chain)
+	assert.Equal(t, stellarPublicKey, association.StellarPublicKey)
+
+	associations, err := d.ListAssociations(10, 0)
+	require.NoError(t, err)
+	assert.Len(t, associations, 1)
+
+	processed, err := d.AddProcessedTransaction(ChainBitcoin, "hash", address)
+	require.NoError(t, err)
+	assert.False(t, processed)
+
+	processed, err = d.AddProcessedTransaction(ChainBitcoin, "hash", address)
+	require.NoError(t, err)
+	assert.True(t, processed)
+}
+
+func TestMemoryQueue(t *testing.T) {
+	d := openMemoryDatabase(t)
+
+	transaction := queue.Transaction{
+		TransactionID:    "hash",
+		AssetCode:        queue.AssetCodeBTC,
+		Amount:           "1.0000000",
+		StellarPublicKey: "GDULKYRRVOMASFMXBYD4BYFRSHAKQDREEVVP2TMH2CER3DW2KATIOASB",
+	}
+	require.NoError(t, d.QueueAdd(transaction))
+	// Duplicates are ignored
+	require.NoError(t, d.QueueAdd(transaction))
+
+	pooled, err := d.QueuePool()
+	require.NoError(t, err)
+	require.NotNil(t, pooled)
+	assert.Equal(t, transaction, *pooled)
+
+	pooled, err = d.QueuePool()
+	require.NoError(t, err)
+	assert.Nil(t, pooled)
+}
+
+func TestMemoryQueueShards(t *testing.T) {
+	d := openMemoryDatabase(t)
+
+	transaction := queue.Transaction{
+		TransactionID:    "hash",
+		AssetCode:        queue.AssetCodeBTC,
+		Amount:           "1.0000000",
+		StellarPublicKey: "GDULKYRRVOMASFMXBYD4BYFRSHAKQDREEVVP2TMH2CER3DW2KATIOASB",
+	}
+	require.NoError(t, d.QueueAdd(transaction))
+
+	shard := int(queue.ShardKey(transaction.StellarPublicKey) % 4)
+	otherShard := (shard + 1) % 4
+
+	pooled, err := d.QueuePoolShards(4, []int{otherShard})
+	require.NoError(t, err)
+	assert.Nil(t, pooled)
+
+	pooled, err = d.QueuePoolShards(4, []int{otherShard, shard})
+	require.NoError(t, err)
+	require.NotNil(t, pooled)
+	assert.Equal(t, transaction, *pooled)
+}
+
+func TestMemoryLocks(t *testing.T) {
+	d := openMemoryDatabase(t)
+
+	lock, err := d.TryLock("ingestion_bitcoin")
+	require.NoError(t, err)
+	require.NotNil(t, lock)
+	assert.NoError(t, lock.Check())
+
+	// Already held
+	otherLock, err := d.TryLock("ingestion_bitcoin")
+	require.NoError(t, err)
+	assert.Nil(t, otherLock)
+
+	require.NoError(t, lock.Release())
+
+	lock, err = d.TryLock("ingestion_bitcoin")
+	require.NoError(t, err)
+	assert.NotNil(t, lock)
+}
+
+func TestMemoryPendingTransactions(t *testing.T) {
+	d := openMemoryDatabase(t)
+
+	now := time.Now()
+	added, err := d.AddPendingTransaction(PendingTransaction{
+		Chain:                 ChainBitcoin,
+		TransactionID:         "hash",
+		Address:               "1Q74qRud8bXUn6FMtXWZwJa5pj56s3mdyf",
+		AssetCode:             "BTC",
+		Amount:                "1.0000000",
+		BlockNumber:           100,
+		Confirmations:         1,
+		RequiredConfirmations: 6,
+		State:                 PendingTransactionStatePending,
+		CreatedAt:             now,
+		UpdatedAt:             now,
+	})
+	require.NoError(t, err)
+	assert.True(t, added)
+
+	updated, err := d.UpdatePendingConfirmations(ChainBitcoin, 102)
+	require.NoError(t, err)
+	require.Len(t, updated, 1)
+	assert.Equal(t, uint64(3), updated[0].Confirmations)
+
+	// Already updated
+	updated, err = d.UpdatePendingConfirmations(ChainBitcoin, 102)
+	require.NoError(t, err)
+	assert.Len(t, updated, 0)
+
+	confirmed, err := d.ConfirmPendingTransaction(ChainBitcoin, "hash")
+	require.NoError(t, err)
+	assert.True(t, confirmed)
+}
+
+func TestMemoryDust(t *testing.T) {
+	d := openMemoryDatabase(t)
+
+	for _, transactionID := range []string{"hash1", "hash2"} {
+		require.NoError(t, d.AddDust(Dust{
+			Chain:         ChainBitcoin,
+			TransactionID: transactionID,
+			Address:       "1Q74qRud8bXUn6FMtXWZwJa5pj56s3mdyf",
+			AssetCode:     "BTC",
+			Value:         "600",
+			Decimals:      8,
+			State:         DustStateAccumulated,
+			CreatedAt:     time.Now(),
+		}))
+	}
+
+	total, err := d.CreditDust(ChainBitcoin, "1Q74qRud8bXUn6FMtXWZwJa5pj56s3mdyf", "BTC", big.NewInt(1000))
+	require.NoError(t, err)
+	require.NotNil(t, total)
+	assert.Equal(t, "1200", total.String())
+
+	report, err := d.GetDustReport()
+	require.NoError(t, err)
+	require.Len(t, report, 1)
+	assert.Equal(t, DustStateCredited, report[0].State)
+	assert.Equal(t, int64(2), report[0].Count)
+	assert.Equal(t, "1200", report[0].Value)
+}
+
+func TestMemoryIssuanceRefund(t *testing.T) {
+	d := openMemoryDatabase(t)
+
+	now := time.Now()
+	require.NoError(t, d.AddIssuance(Issuance{
+		Chain:            ChainBitcoin,
+		TransactionID:    "hash",
+		Address:          "1Q74qRud8bXUn6FMtXWZwJa5pj56s3mdyf",
+		StellarPublicKey: "GDULKYRRVOMASFMXBYD4BYFRSHAKQDREEVVP2TMH2CER3DW2KATIOASB",
+		AssetCode:        "BTC",
+		Amount:           "1.0000000",
+		State:            IssuanceStateQueued,
+		CreatedAt:        now,
+		UpdatedAt:        now,
+	}))
+
+	require.NoError(t, d.FinishIssuance("hash", IssuanceStateRefundPending, "Account or trust line not created before timeout"))
+
+	issuances, err := d.GetIssuances(IssuanceStateRefundPending, 10, 0)
+	require.NoError(t, err)
+	require.Len(t, issuances, 1)
+	assert.Equal(t, "hash", issuances[0].TransactionID)
+
+	// Trust line added later
+	retried, err := d.RetryIssuance("hash")
+	require.NoError(t, err)
+	assert.True(t, retried)
+
+	require.NoError(t, d.FinishIssuance("hash", IssuanceStateRefundPending, "Account or trust line not created before timeout"))
+
+	// Refunded by operator
+	resolved, err := d.ResolveIssuance("hash")
+	require.NoError(t, err)
+	assert.True(t, resolved)
+}
+
+func TestMemoryIssuanceRejected(t *testing.T) {
+	d := openMemoryDatabase(t)
+
+	now := time.Now()
+	require.NoError(t, d.AddIssuance(Issuance{
+		Chain:            ChainBitcoin,
+		TransactionID:    "hash",
+		Address:          "1Q74qRud8bXUn6FMtXWZwJa5pj56s3mdyf",
+		StellarPublicKey: "GDULKYRRVOMASFMXBYD4BYFRSHAKQDREEVVP2TMH2CER3DW2KATIOASB",
+		AssetCode:        "BTC",
+		Amount:           "1.0000000",
+		State:            IssuanceStateQueued,
+		CreatedAt:        now,
+		UpdatedAt:        now,
+	}))
+
+	require.NoError(t, d.FinishIssuance("hash", IssuanceStateRejected, "KYC not completed"))
+
+	issuance, err := d.GetIssuance("hash")
+	require.NoError(t, err)
+	assert.Equal(t, IssuanceStateRejected, issuance.State)
+	assert.Equal(t, "KYC not completed", issuance.Error)
+
+	// KYC completed later
+	retried, err := d.RetryIssuance("hash")
+	require.NoError(t, err)
+	assert.True(t, retried)
+}
+
+func TestMemoryEvents(t *testing.T) {
+	d := openMemoryDatabase(t)
+
+	require.NoError(t, d.AddEvent(sse.Event{Address: "address1", Event: sse.TransactionReceivedAddressEvent}))
+	require.NoError(t, d.AddEvent(sse.Event{Address: "address2", Event: sse.TransactionReceivedAddressEvent}))
+	require.NoError(t, d.AddEvent(sse.Event{Address: "address1", Event: sse.AccountCreatedAddressEvent}))
+	require.NoError(t, d.AddEvent(sse.Event{Address: "address1", Event: sse.AccountCreditedAddressEvent, Data: `{"amount":"1"}`}))
+
+	lastID, events, err := d.GetEventsSinceID(0)
+	require.NoError(t, err)
+	require.Len(t, events, 4)
+	assert.Equal(t, lastID, events[3].ID)
+
+	// Client received the first event of address1 and reconnected
+	events, err = d.GetAddressEventsSinceID("address1", events[0].ID)
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+	assert.Equal(t, sse.AccountCreatedAddressEvent, events[0].Event)
+	assert.Equal(t, sse.AccountCreditedAddressEvent, events[1].Event)
+	assert.True(t, events[0].ID < events[1].ID)
+
+	events, err = d.GetAddressEventsSinceID("address1", lastID)
+	require.NoError(t, err)
+	assert.Len(t, events, 0)
+}
+
+func TestMemoryUnusedAddresses(t *testing.T) {
+	d := openMemoryDatabase(t)
+
+	addresses := []string{
+		"1Q74qRud8bXUn6FMtXWZwJa5pj56s3mdyf",
+		"1CSauQLNjb3RVQN34bDZAnmKuHScsP3xuC",
+		"17HCcV6BseYXaZaBXAPZqtCGQTJB9ZKsYS",
+	}
+	stellarPublicKeys := []string{
+		"GDULKYRRVOMASFMXBYD4BYFRSHAKQDREEVVP2TMH2CER3DW2KATIOASB",
+		"GBQ5ZGKBPKPDCVZKV4FQPZ5QXBWZQYSXVDHNTJTTEGLWLY6WJSKWOKQN",
+		"GCDGPFKW2LUJS2ESKAS42HGOKC6VWOKEJ44TQ3ZXZAMD4ZM5FVHJHPJS",
+	}
+	for i := range addresses {
+		require.NoError(t, d.CreateAddressAssociation(ChainBitcoin, stellarPublicKeys[i], addresses[i], uint32(i)))
+	}
+
+	unused, err := d.CountUnusedAddresses(ChainBitcoin)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(3), unused)
+
+	_, err = d.AddProcessedTransaction(ChainBitcoin, "hash", addresses[1])
+	require.NoError(t, err)
+
+	unused, err = d.CountUnusedAddresses(ChainBitcoin)
+	require.NoError(t, err)
+	assert.Equal(t, uint32(1), unused)
+
+	used, err := d.IsAddressUsed(ChainBitcoin, addresses[1])
+	require.NoError(t, err)
+	assert.True(t, used)
+
+	used, err = d.IsAddressUsed(ChainBitcoin, addresses[2])
+	require.NoError(t, err)
+	assert.False(t, used)
+}
+
+func TestMemoryRefunds(t *testing.T) {
+	d := openMemoryDatabase(t)
+
+	now := time.Now()
+	require.NoError(t, d.AddIssuance(Issuance{
+		Chain:            ChainBitcoin,
+		TransactionID:    "hash",
+		Address:          "1Q74qRud8bXUn6FMtXWZwJa5pj56s3mdyf",
+		StellarPublicKey: "GDULKYRRVOMASFMXBYD4BYFRSHAKQDREEVVP2TMH2CER3DW2KATIOASB",
+		AssetCode:        "TOKEN",
+		Amount:           "10000.0000000",
+		DepositAssetCode: "BTC",
+		DepositAmount:    "1.0000000",
+		State:            IssuanceStateQueued,
+		CreatedAt:        now,
+		UpdatedAt:        now,
+	}))
+
+	refund := Refund{
+		TransactionID: "hash",
+		Chain:         ChainBitcoin,
+		Address:       "17HCcV6BseYXaZaBXAPZqtCGQTJB9ZKsYS",
+		AssetCode:     "BTC",
+		Amount:        "1.0000000",
+		State:         RefundStatePendingApproval,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+
+	// Issuance is still queued
+	added, err := d.AddRefund(refund)
+	require.NoError(t, err)
+	assert.False(t, added)
+
+	require.NoError(t, d.FinishIssuance("hash", IssuanceStateRefundPending, "Account merged before the asset was sent"))
+
+	added, err = d.AddRefund(refund)
+	require.NoError(t, err)
+	assert.True(t, added)
+
+	issuance, err := d.GetIssuance("hash")
+	require.NoError(t, err)
+	assert.Equal(t, IssuanceStateRefunded, issuance.State)
+	assert.Equal(t, "BTC", issuance.DepositAssetCode)
+	assert.Equal(t, "1.0000000", issuance.DepositAmount)
+
+	// Refunded issuances can't be retried
+	retried, err := d.RetryIssuance("hash")
+	require.NoError(t, err)
+	assert.False(t, retried)
+
+	// Not approved yet
+	claimed, err := d.ClaimRefund()
+	require.NoError(t, err)
+	assert.Nil(t, claimed)
+
+	approved, err := d.ApproveRefund("hash")
+	require.NoError(t, err)
+	assert.True(t, approved)
+
+	claimed, err = d.ClaimRefund()
+	require.NoError(t, err)
+	require.NotNil(t, claimed)
+	assert.Equal(t, "17HCcV6BseYXaZaBXAPZqtCGQTJB9ZKsYS", claimed.Address)
+	assert.Equal(t, RefundStateSending, claimed.State)
+
+	require.NoError(t, d.FinishRefund("hash", RefundStateFailed, "", "Insufficient funds"))
+
+	refunds, err := d.GetRefunds(RefundStateFailed)
+	require.NoError(t, err)
+	require.Len(t, refunds, 1)
+	assert.Equal(t, "Insufficient funds", refunds[0].Error)
+
+	// Failed refunds can be approved again
+	approved, err = d.ApproveRefund("hash")
+	require.NoError(t, err)
+	assert.True(t, approved)
+
+	claimed, err = d.ClaimRefund()
+	require.NoError(t, err)
+	require.NotNil(t, claimed)
+	require.NoError(t, d.FinishRefund("hash", RefundStateSent, "109fa1c369680c2f27643fdd160620d010851a376d25b9b00ef71afe789ea6ed", ""))
+
+	refunds, err = d.GetRefunds(RefundStateSent)
+	require.NoError(t, err)
+	require.Len(t, refunds, 1)
+	assert.Equal(t, "109fa1c369680c2f27643fdd160620d010851a376d25b9b00ef71afe789ea6ed", refunds[0].ChainTransactionID)
+
+	approved, err = d.ApproveRefund("hash")
+	require.NoError(t, err)
+	assert.False(t, approved)
+}
@@ -0,0 +1,156 @@
+package database
+
+// sqliteSchema is the schema of SQLite databases, equivalent to the schema
+// created by Postgres migrations in `database/migrations`. Update it when
+// adding a migration.
+const sqliteSchema = `
+CREATE TABLE IF NOT EXISTS address_association (
+  chain varchar(32) NOT NULL,
+  address_index bigint NOT NULL,
+  address varchar(42) NOT NULL UNIQUE,
+  stellar_public_key varchar(56) NOT NULL UNIQUE,
+  created_at timestamp NOT NULL,
+  PRIMARY KEY (chain, address_index, address, stellar_public_key),
+  CONSTRAINT valid_address_index CHECK (address_index >= 0)
+);
+
+CREATE INDEX IF NOT EXISTS address_association_created_at_index ON address_association (created_at);
+
+CREATE TABLE IF NOT EXISTS key_value_store (
+  key varchar(255) NOT NULL,
+  value varchar(255) NOT NULL,
+  PRIMARY KEY (key)
+);
+
+CREATE TABLE IF NOT EXISTS processed_transaction (
+  chain varchar(32) NOT NULL,
+  transaction_id varchar(100) NOT NULL,
+  receiving_address varchar(42) NOT NULL,
+  created_at timestamp NOT NULL,
+  PRIMARY KEY (chain, transaction_id)
+);
+
+CREATE TABLE IF NOT EXISTS transactions_queue (
+  id integer PRIMARY KEY AUTOINCREMENT,
+  transaction_id varchar(100) NOT NULL,
+  asset_code varchar(12) NOT NULL,
+  amount varchar(20) NOT NULL,
+  stellar_public_key varchar(56) NOT NULL,
+  pooled boolean NOT NULL DEFAULT 0,
+  shard_key bigint NOT NULL DEFAULT 0,
+  UNIQUE (transaction_id, asset_code)
+);
+
+CREATE TABLE IF NOT EXISTS broadcasted_event (
+  id integer PRIMARY KEY AUTOINCREMENT,
+  address varchar(42) NOT NULL,
+  event varchar(32) NOT NULL,
+  data varchar(255) NOT NULL
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS broadcasted_event_address_event_key ON broadcasted_event (address, event) WHERE event <> 'transaction_pending';
+
+CREATE TABLE IF NOT EXISTS recovery_transaction (
+  source varchar(56) NOT NULL,
+  envelope_xdr text NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS source_index ON recovery_transaction (source);
+
+CREATE TABLE IF NOT EXISTS dust_ledger (
+  chain varchar(32) NOT NULL,
+  transaction_id varchar(100) NOT NULL,
+  address varchar(42) NOT NULL,
+  asset_code varchar(12) NOT NULL,
+  /* Text so values larger than 64-bit integers are not rounded */
+  value text NOT NULL,
+  decimals smallint NOT NULL,
+  state varchar(20) NOT NULL,
+  created_at timestamp NOT NULL,
+  PRIMARY KEY (chain, transaction_id)
+);
+
+CREATE INDEX IF NOT EXISTS dust_ledger_address_index ON dust_ledger (chain, address, asset_code, state);
+
+CREATE TABLE IF NOT EXISTS withdrawal (
+  payment_id varchar(32) NOT NULL,
+  stellar_public_key varchar(56) NOT NULL,
+  chain varchar(32) NOT NULL,
+  address varchar(42) NOT NULL,
+  asset_code varchar(12) NOT NULL,
+  amount varchar(30) NOT NULL,
+  state varchar(20) NOT NULL,
+  chain_transaction_id varchar(100) NOT NULL DEFAULT '',
+  error text NOT NULL DEFAULT '',
+  created_at timestamp NOT NULL,
+  updated_at timestamp NOT NULL,
+  PRIMARY KEY (payment_id)
+);
+
+CREATE INDEX IF NOT EXISTS withdrawal_state_index ON withdrawal (state, created_at);
+
+CREATE TABLE IF NOT EXISTS webhook_delivery (
+  id integer PRIMARY KEY AUTOINCREMENT,
+  url text NOT NULL,
+  event varchar(32) NOT NULL,
+  payload text NOT NULL,
+  state varchar(20) NOT NULL,
+  attempts integer NOT NULL DEFAULT 0,
+  next_attempt_at timestamp NOT NULL,
+  last_error text NOT NULL DEFAULT '',
+  created_at timestamp NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS webhook_delivery_pending_index ON webhook_delivery (state, next_attempt_at);
+
+CREATE TABLE IF NOT EXISTS pending_transaction (
+  chain varchar(32) NOT NULL,
+  transaction_id varchar(100) NOT NULL,
+  address varchar(42) NOT NULL,
+  asset_code varchar(12) NOT NULL,
+  amount varchar(30) NOT NULL,
+  block_number bigint NOT NULL,
+  confirmations bigint NOT NULL,
+  required_confirmations bigint NOT NULL,
+  state varchar(20) NOT NULL,
+  created_at timestamp NOT NULL,
+  updated_at timestamp NOT NULL,
+  PRIMARY KEY (chain, transaction_id)
+);
+
+CREATE INDEX IF NOT EXISTS pending_transaction_state_index ON pending_transaction (chain, state, block_number);
+
+CREATE TABLE IF NOT EXISTS issuance (
+  chain varchar(32) NOT NULL,
+  transaction_id varchar(100) NOT NULL,
+  address varchar(42) NOT NULL,
+  stellar_public_key varchar(56) NOT NULL,
+  asset_code varchar(12) NOT NULL,
+  amount varchar(30) NOT NULL,
+  deposit_asset_code varchar(12) NOT NULL DEFAULT '',
+  deposit_amount varchar(30) NOT NULL DEFAULT '',
+  state varchar(20) NOT NULL,
+  error text NOT NULL DEFAULT '',
+  created_at timestamp NOT NULL,
+  updated_at timestamp NOT NULL,
+  PRIMARY KEY (transaction_id)
+);
+
+CREATE INDEX IF NOT EXISTS issuance_state_index ON issuance (state, created_at);
+
+CREATE TABLE IF NOT EXISTS refund (
+  transaction_id varchar(100) NOT NULL,
+  chain varchar(32) NOT NULL,
+  address varchar(42) NOT NULL,
+  asset_code varchar(12) NOT NULL,
+  amount varchar(30) NOT NULL,
+  state varchar(20) NOT NULL,
+  chain_transaction_id varchar(100) NOT NULL DEFAULT '',
+  error text NOT NULL DEFAULT '',
+  created_at timestamp NOT NULL,
+  updated_at timestamp NOT NULL,
+  PRIMARY KEY (transaction_id)
+);
+
+CREATE INDEX IF NOT EXISTS refund_state_index ON refund (state, created_at);
+`
@@ -0,0 +1,368 @@
+package ethereum
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+	"time"
+
+	ethereumCommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stellar/go/services/bifrost/chains"
+	"github.com/stellar/go/services/bifrost/config"
+	"github.com/stellar/go/services/bifrost/database"
+	"github.com/stellar/go/services/bifrost/queue"
+	"github.com/stellar/go/support/errors"
+)
+
+func init() {
+	chains.Register(database.ChainEthereum, NewAdapter)
+}
+
+// Adapter implements chains.ChainAdapter using Listener and AddressGenerator,
+// and chains.Withdrawer and chains.Refunder using RPC. If Failover is set, it's started before
+// Listener to check health of the nodes.
+type Adapter struct {
+	Listener         *Listener
+	AddressGenerator *AddressGenerator
+	RPC              RPCClient
+	Failover         *chains.Failover
+	// WithdrawalAccount is the account unlocked in geth withdrawals are sent
+	// from. Withdrawals are not supported if it's nil.
+	WithdrawalAccount *ethereumCommon.Address
+
+	minimumValueWei *big.Int
+	gapLimit        uint32
+}
+
+// NewAdapter creates Ethereum adapter from `[ethereum]` config section. It
+// implements chains.Factory.
+func NewAdapter(cfg *config.Config, db database.Database) (chains.ChainAdapter, error) {
+	if cfg.Ethereum == nil {
+		return nil, nil
+	}
+
+	minimumValueWei, err := EthToWei(cfg.Ethereum.MinimumValueEth)
+	if err != nil {
+		return nil, errors.Wrap(err, "Invalid minimum accepted Ethereum transaction value")
+	}
+
+	if minimumValueWei.Cmp(new(big.Int)) == 0 {
+		return nil, errors.New("Minimum accepted Ethereum transaction value must be larger than 0")
+	}
+
+	clients := make([]NodeClient, len(cfg.Ethereum.RpcServer))
+	var withdrawalRPC RPCClient
+	for i, host := range cfg.Ethereum.RpcServer {
+		rpcClient, err := rpc.Dial("http://" + host)
+		if err != nil {
+			return nil, errors.Wrap(err, "Error connecting to geth: "+host)
+		}
+
+		clients[i] = ethclient.NewClient(rpcClient)
+		// Withdrawals are sent using the first node only, WithdrawalAccount is
+		// unlocked in this node.
+		if i == 0 {
+			withdrawalRPC = rpcClient
+		}
+	}
+	failoverClient := NewFailoverClient(cfg.Ethereum.RpcServer, clients)
+
+	var heads HeadSubscriber
+	if cfg.Ethereum.WSServer != "" {
+		heads, err = ethclient.Dial("ws://" + cfg.Ethereum.WSServer)
+		if err != nil {
+			return nil, errors.Wrap(err, "Error connecting to geth websocket: "+cfg.Ethereum.WSServer)
+		}
+	}
+
+	var withdrawalAccount *ethereumCommon.Address
+	if cfg.Ethereum.WithdrawalAccount != "" {
+		if !ethereumCommon.IsHexAddress(cfg.Ethereum.WithdrawalAccount) {
+			return nil, errors.New("Invalid withdrawal account: " + cfg.Ethereum.WithdrawalAccount)
+		}
+		address := ethereumCommon.HexToAddress(cfg.Ethereum.WithdrawalAccount)
+		withdrawalAccount = &address
+	}
+
+	addressGenerator, err := NewAddressGenerator(cfg.Ethereum.MasterPublicKey, cfg.Ethereum.DerivationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	tokens, err := newTokens(cfg.Ethereum.Tokens)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Adapter{
+		Listener: &Listener{
+			Client:        failoverClient,
+			Storage:       db,
+			Heads:         heads,
+			NetworkID:     cfg.Ethereum.NetworkID,
+			Tokens:        tokens,
+			Confirmations: cfg.Ethereum.Confirmations,
+		},
+		AddressGenerator:  addressGenerator,
+		RPC:               withdrawalRPC,
+		Failover:          failoverClient.Failover,
+		WithdrawalAccount: withdrawalAccount,
+		minimumValueWei:   minimumValueWei,
+		gapLimit:          cfg.Ethereum.GapLimit,
+	}, nil
+}
+
+func (a *Adapter) Start() error {
+	if a.Failover != nil {
+		err := a.Failover.Start()
+		if err != nil {
+			return errors.Wrap(err, "Error starting failover")
+		}
+	}
+	return a.Listener.Start()
+}
+
+func (a *Adapter) Rescan(fromBlock, toBlock uint64) error {
+	if a.Failover != nil {
+		err := a.Failover.Start()
+		if err != nil {
+			return errors.Wrap(err, "Error starting failover")
+		}
+	}
+	return a.Listener.Rescan(fromBlock, toBlock)
+}
+
+func (a *Adapter) StreamTransactions(handler chains.TransactionHandler) {
+	a.Listener.TransactionHandler = func(transaction Transaction) error {
+		chainTransaction := transaction.toChain()
+		chainTransaction.RequiredConfirmations = a.Listener.Confirmations
+		return handler(chainTransaction)
+	}
+}
+
+func (a *Adapter) StreamBlocks(handler chains.BlockHandler) {
+	a.Listener.BlockHandler = func(blockNumber uint64) error {
+		return handler(database.ChainEthereum, blockNumber)
+	}
+}
+
+func (a *Adapter) ValidateAddress(address string) error {
+	if !ethereumCommon.IsHexAddress(address) {
+		return errors.New("Invalid Ethereum address")
+	}
+	return nil
+}
+
+func (a *Adapter) DeriveAddress(index uint32) (string, error) {
+	return a.AddressGenerator.Generate(index)
+}
+
+// GapLimit implements chains.GapLimiter.
+func (a *Adapter) GapLimit() uint32 {
+	return a.gapLimit
+}
+
+func (a *Adapter) MinimumValue(assetCode queue.AssetCode) *big.Int {
+	if assetCode == queue.AssetCodeETH {
+		return a.minimumValueWei
+	}
+
+	token := a.token(assetCode)
+	if token == nil {
+		return nil
+	}
+	return token.MinimumValue
+}
+
+// DecodeMemoAddress decodes address from the last 20 bytes of `memo`.
+func (a *Adapter) DecodeMemoAddress(memo []byte) (string, error) {
+	if len(memo) != 32 {
+		return "", errors.New("Invalid memo length")
+	}
+
+	for _, b := range memo[:12] {
+		if b != 0 {
+			return "", errors.New("Memo must be padded with zeros")
+		}
+	}
+
+	return ethereumCommon.BytesToAddress(memo[12:]).Hex(), nil
+}
+
+func (a *Adapter) Withdraw(address string, assetCode queue.AssetCode, amount string) (string, error) {
+	if a.WithdrawalAccount == nil {
+		return "", errors.New("Withdrawal account not configured")
+	}
+
+	if !ethereumCommon.IsHexAddress(address) {
+		return "", errors.New("Invalid Ethereum address")
+	}
+	to := ethereumCommon.HexToAddress(address)
+
+	args := map[string]interface{}{"from": a.WithdrawalAccount}
+
+	if assetCode == queue.AssetCodeETH {
+		valueWei, err := EthToWei(amount)
+		if err != nil {
+			return "", errors.Wrap(err, "Invalid amount")
+		}
+		args["to"] = to
+		args["value"] = (*hexutil.Big)(valueWei)
+	} else {
+		token := a.token(assetCode)
+		if token == nil {
+			return "", errors.New("Asset not supported: " + string(assetCode))
+		}
+
+		value, err := ToBaseUnit(amount, token.Decimals)
+		if err != nil {
+			return "", errors.Wrap(err, "Invalid amount")
+		}
+
+		data := append([]byte{}, transferMethodID...)
+		data = append(data, ethereumCommon.LeftPadBytes(to.Bytes(), 32)...)
+		data = append(data, ethereumCommon.LeftPadBytes(value.Bytes(), 32)...)
+		args["to"] = token.ContractAddress
+		args["data"] = hexutil.Bytes(data)
+	}
+
+	ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(30*time.Second))
+	defer cancel()
+
+	var hash ethereumCommon.Hash
+	err := a.RPC.CallContext(ctx, &hash, "eth_sendTransaction", args)
+	if err != nil {
+		return "", errors.Wrap(err, "Error sending transaction")
+	}
+
+	return hash.Hex(), nil
+}
+
+// RefundAddress returns the sender of ETH transaction or the `from` address of
+// token `Transfer` event (transaction ID with the log index).
+func (a *Adapter) RefundAddress(transactionID string) (string, error) {
+	ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(5*time.Second))
+	defer cancel()
+
+	separator := strings.LastIndex(transactionID, "-")
+	if separator == -1 {
+		var transaction struct {
+			From *ethereumCommon.Address `json:"from"`
+		}
+		err := a.RPC.CallContext(ctx, &transaction, "eth_getTransactionByHash", transactionID)
+		if err != nil {
+			return "", errors.Wrap(err, "Error getting transaction")
+		}
+
+		if transaction.From == nil {
+			return "", errors.New("Transaction not found")
+		}
+		return transaction.From.Hex(), nil
+	}
+
+	logIndex, err := strconv.ParseUint(transactionID[separator+1:], 10, 32)
+	if err != nil {
+		return "", errors.Wrap(err, "Invalid log index")
+	}
+
+	var receipt struct {
+		Logs []struct {
+			Topics []ethereumCommon.Hash `json:"topics"`
+			Index  hexutil.Uint          `json:"logIndex"`
+		} `json:"logs"`
+	}
+	err = a.RPC.CallContext(ctx, &receipt, "eth_getTransactionReceipt", transactionID[:separator])
+	if err != nil {
+		return "", errors.Wrap(err, "Error getting transaction receipt")
+	}
+
+	for _, eventLog := range receipt.Logs {
+		if uint64(eventLog.Index) != logIndex {
+			continue
+		}
+
+		if len(eventLog.Topics) != 3 || eventLog.Topics[0] != transferEventTopic {
+			return "", errors.New("Log is not a `Transfer` event")
+		}
+		return ethereumCommon.BytesToAddress(eventLog.Topics[1].Bytes()).Hex(), nil
+	}
+
+	return "", errors.New("Transfer event not found")
+}
+
+func (a *Adapter) token(assetCode queue.AssetCode) *Token {
+	for i := range a.Listener.Tokens {
+		if a.Listener.Tokens[i].AssetCode == assetCode {
+			return &a.Listener.Tokens[i]
+		}
+	}
+	return nil
+}
+
+// newTokens validates `[[ethereum.tokens]]` config sections.
+func newTokens(configs []config.EthereumTokenConfig) ([]Token, error) {
+	tokens := make([]Token, len(configs))
+	assetCodes := map[string]bool{string(queue.AssetCodeETH): true}
+
+	for i, tokenConfig := range configs {
+		if !ethereumCommon.IsHexAddress(tokenConfig.ContractAddress) {
+			return nil, errors.New("Invalid token contract address: " + tokenConfig.ContractAddress)
+		}
+
+		if len(tokenConfig.AssetCode) == 0 || len(tokenConfig.AssetCode) > 12 {
+			return nil, errors.New("Invalid token asset code: " + tokenConfig.AssetCode)
+		}
+
+		if assetCodes[tokenConfig.AssetCode] {
+			return nil, errors.New("Duplicate token asset code: " + tokenConfig.AssetCode)
+		}
+		assetCodes[tokenConfig.AssetCode] = true
+
+		minimumValue, err := ToBaseUnit(tokenConfig.MinimumValue, tokenConfig.Decimals)
+		if err != nil {
+			return nil, errors.Wrap(err, "Invalid minimum accepted "+tokenConfig.AssetCode+" transfer value")
+		}
+
+		if minimumValue.Cmp(new(big.Int)) == 0 {
+			return nil, errors.New("Minimum accepted " + tokenConfig.AssetCode + " transfer value must be larger than 0")
+		}
+
+		tokens[i] = Token{
+			ContractAddress: ethereumCommon.HexToAddress(tokenConfig.ContractAddress),
+			AssetCode:       queue.AssetCode(tokenConfig.AssetCode),
+			Decimals:        tokenConfig.Decimals,
+			MinimumValue:    minimumValue,
+		}
+	}
+
+	return tokens, nil
+}
+
+func (t Transaction) toChain() chains.Transaction {
+	transaction := chains.Transaction{
+		Chain:         database.ChainEthereum,
+		Hash:          t.Hash,
+		To:            t.To,
+		Value:         t.ValueWei,
+		Decimals:      18,
+		AssetCode:     queue.AssetCodeETH,
+		Amount:        t.ValueToStellar(),
+		BlockNumber:   t.BlockNumber,
+		Confirmations: t.Confirmations,
+	}
+
+	if t.Token != nil {
+		// A single transaction can emit many transfer events
+		transaction.Hash = fmt.Sprintf("%s-%d", t.Hash, t.LogIndex)
+		transaction.Index = int(t.LogIndex)
+		transaction.Decimals = t.Token.Decimals
+		transaction.AssetCode = t.Token.AssetCode
+	}
+
+	return transaction
+}
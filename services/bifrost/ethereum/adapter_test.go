@@ -0,0 +1,251 @@
+package ethereum
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"testing"
+
+	ethereumCommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stellar/go/services/bifrost/config"
+	"github.com/stellar/go/services/bifrost/database"
+	"github.com/stellar/go/services/bifrost/queue"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAdapterValidateAddress(t *testing.T) {
+	adapter := &Adapter{}
+
+	assert.NoError(t, adapter.ValidateAddress("0x80D3ee1268DC1A2d1b9E73D49050083E75Ef7c2D"))
+	// Bitcoin address
+	assert.Error(t, adapter.ValidateAddress("1Q74qRud8bXUn6FMtXWZwJa5pj56s3mdyf"))
+	assert.Error(t, adapter.ValidateAddress(""))
+}
+
+type testRPCClient struct {
+	method string
+	args   map[string]interface{}
+}
+
+func (c *testRPCClient) CallContext(ctx context.Context, result interface{}, method string, args ...interface{}) error {
+	c.method = method
+	c.args = args[0].(map[string]interface{})
+	*result.(*ethereumCommon.Hash) = ethereumCommon.HexToHash("0x0a190d17ba0405bce37fafd3a7a7bef51264ea4083ffae3b2de90ed61ee5264e")
+	return nil
+}
+
+func TestAdapterDecodeMemoAddress(t *testing.T) {
+	adapter := &Adapter{}
+
+	memo := make([]byte, 32)
+	copy(memo[12:], ethereumCommon.HexToAddress("0x80D3ee1268DC1A2d1b9E73D49050083E75Ef7c2D").Bytes())
+
+	address, err := adapter.DecodeMemoAddress(memo)
+	assert.NoError(t, err)
+	assert.Equal(t, "0x80D3ee1268DC1A2d1b9E73D49050083E75Ef7c2D", address)
+
+	memo[0] = 1
+	_, err = adapter.DecodeMemoAddress(memo)
+	assert.Error(t, err)
+
+	_, err = adapter.DecodeMemoAddress(memo[1:])
+	assert.Error(t, err)
+}
+
+func TestAdapterWithdraw(t *testing.T) {
+	from := ethereumCommon.HexToAddress("0x5F2F6d0a0d0B7a5EEfbd1e0B8FCBBa50fDEa2Dd4")
+	contract := ethereumCommon.HexToAddress("0xd26114cd6EE289AccF82350c8d8487fedB8A0C07")
+	to := "0x80D3ee1268DC1A2d1b9E73D49050083E75Ef7c2D"
+	client := &testRPCClient{}
+	adapter := &Adapter{
+		Listener: &Listener{
+			Tokens: []Token{{ContractAddress: contract, AssetCode: "OMG", Decimals: 18}},
+		},
+		RPC: client,
+	}
+
+	_, err := adapter.Withdraw(to, queue.AssetCodeETH, "1.5")
+	assert.Error(t, err)
+
+	adapter.WithdrawalAccount = &from
+
+	hash, err := adapter.Withdraw(to, queue.AssetCodeETH, "1.5")
+	assert.NoError(t, err)
+	assert.Equal(t, "0x0a190d17ba0405bce37fafd3a7a7bef51264ea4083ffae3b2de90ed61ee5264e", hash)
+	assert.Equal(t, "eth_sendTransaction", client.method)
+	assert.Equal(t, &from, client.args["from"])
+	assert.Equal(t, ethereumCommon.HexToAddress(to), client.args["to"])
+	assert.Equal(t, "0x14d1120d7b160000", client.args["value"].(*hexutil.Big).String())
+
+	_, err = adapter.Withdraw(to, "OMG", "2")
+	assert.NoError(t, err)
+	assert.Equal(t, contract, client.args["to"])
+	assert.Equal(
+		t,
+		"0xa9059cbb"+
+			"00000000000000000000000080d3ee1268dc1a2d1b9e73d49050083e75ef7c2d"+
+			"0000000000000000000000000000000000000000000000001bc16d674ec80000",
+		client.args["data"].(hexutil.Bytes).String(),
+	)
+	assert.Nil(t, client.args["value"])
+
+	_, err = adapter.Withdraw(to, "XYZ", "2")
+	assert.Error(t, err)
+
+	_, err = adapter.Withdraw("1Q74qRud8bXUn6FMtXWZwJa5pj56s3mdyf", queue.AssetCodeETH, "2")
+	assert.Error(t, err)
+}
+
+type testReceiptRPCClient struct {
+	responses map[string]string
+}
+
+func (c *testReceiptRPCClient) CallContext(ctx context.Context, result interface{}, method string, args ...interface{}) error {
+	response, ok := c.responses[method+" "+args[0].(string)]
+	if !ok {
+		return errors.New("not found")
+	}
+	return json.Unmarshal([]byte(response), result)
+}
+
+func TestAdapterRefundAddress(t *testing.T) {
+	hash := "0x0a190d17ba0405bce37fafd3a7a7bef51264ea4083ffae3b2de90ed61ee5264e"
+	adapter := &Adapter{
+		RPC: &testReceiptRPCClient{
+			responses: map[string]string{
+				"eth_getTransactionByHash " + hash: `{"hash":"` + hash + `","from":"0x5f2f6d0a0d0b7a5eefbd1e0b8fcbba50fdea2dd4"}`,
+				"eth_getTransactionReceipt " + hash: `{"logs":[
+					{"logIndex":"0x3","topics":["0x0000000000000000000000000000000000000000000000000000000000000001"]},
+					{"logIndex":"0x4","topics":[
+						"0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef",
+						"0x00000000000000000000000080d3ee1268dc1a2d1b9e73d49050083e75ef7c2d",
+						"0x0000000000000000000000005f2f6d0a0d0b7a5eefbd1e0b8fcbba50fdea2dd4"
+					]}
+				]}`,
+				"eth_getTransactionByHash 0x00": `null`,
+			},
+		},
+	}
+
+	address, err := adapter.RefundAddress(hash)
+	assert.NoError(t, err)
+	assert.Equal(t, "0x5F2F6d0a0d0B7a5EEfbd1e0B8FCBBa50fDEa2Dd4", address)
+
+	address, err = adapter.RefundAddress(hash + "-4")
+	assert.NoError(t, err)
+	assert.Equal(t, "0x80D3ee1268DC1A2d1b9E73D49050083E75Ef7c2D", address)
+
+	// Not a transfer event
+	_, err = adapter.RefundAddress(hash + "-3")
+	assert.Error(t, err)
+
+	_, err = adapter.RefundAddress(hash + "-5")
+	assert.Error(t, err)
+
+	_, err = adapter.RefundAddress("0x00")
+	assert.Error(t, err)
+}
+
+func TestTransactionToChain(t *testing.T) {
+	transaction := Transaction{
+		Hash:     "0x0a190d17ba0405bce37fafd3a7a7bef51264ea4083ffae3b2de90ed61ee5264e",
+		ValueWei: big.NewInt(1000000000000000000),
+		To:       "0x80D3ee1268DC1A2d1b9E73D49050083E75Ef7c2D",
+	}
+
+	chainTransaction := transaction.toChain()
+	assert.Equal(t, database.ChainEthereum, chainTransaction.Chain)
+	assert.Equal(t, transaction.Hash, chainTransaction.Hash)
+	assert.Equal(t, transaction.To, chainTransaction.To)
+	assert.Equal(t, transaction.ValueWei, chainTransaction.Value)
+	assert.Equal(t, uint8(18), chainTransaction.Decimals)
+	assert.Equal(t, queue.AssetCodeETH, chainTransaction.AssetCode)
+	assert.Equal(t, "1.0000000", chainTransaction.Amount)
+}
+
+func TestTokenTransactionToChain(t *testing.T) {
+	token := &Token{
+		ContractAddress: ethereumCommon.HexToAddress("0xd26114cd6EE289AccF82350c8d8487fedB8A0C07"),
+		AssetCode:       "OMG",
+		Decimals:        18,
+		MinimumValue:    big.NewInt(1),
+	}
+	transaction := Transaction{
+		Hash:     "0x0a190d17ba0405bce37fafd3a7a7bef51264ea4083ffae3b2de90ed61ee5264e",
+		ValueWei: big.NewInt(2000000000000000000),
+		To:       "0x80D3ee1268DC1A2d1b9E73D49050083E75Ef7c2D",
+		Token:    token,
+		LogIndex: 3,
+	}
+
+	chainTransaction := transaction.toChain()
+	assert.Equal(t, database.ChainEthereum, chainTransaction.Chain)
+	assert.Equal(t, transaction.Hash+"-3", chainTransaction.Hash)
+	assert.Equal(t, 3, chainTransaction.Index)
+	assert.Equal(t, queue.AssetCode("OMG"), chainTransaction.AssetCode)
+	assert.Equal(t, "2.0000000", chainTransaction.Amount)
+}
+
+func TestAdapterMinimumValue(t *testing.T) {
+	tokens, err := newTokens([]config.EthereumTokenConfig{
+		{
+			ContractAddress: "0xd26114cd6EE289AccF82350c8d8487fedB8A0C07",
+			AssetCode:       "OMG",
+			Decimals:        18,
+			MinimumValue:    "0.5",
+		},
+		{
+			ContractAddress: "0xdAC17F958D2ee523a2206206994597C13D831ec7",
+			AssetCode:       "USDT",
+			Decimals:        6,
+			MinimumValue:    "10",
+		},
+	})
+	assert.NoError(t, err)
+
+	adapter := &Adapter{
+		Listener:        &Listener{Tokens: tokens},
+		minimumValueWei: big.NewInt(1000),
+	}
+	assert.Equal(t, big.NewInt(1000), adapter.MinimumValue(queue.AssetCodeETH))
+	assert.Equal(t, big.NewInt(500000000000000000), adapter.MinimumValue("OMG"))
+	assert.Equal(t, big.NewInt(10000000), adapter.MinimumValue("USDT"))
+	assert.Nil(t, adapter.MinimumValue(queue.AssetCodeBTC))
+}
+
+func TestNewTokensInvalid(t *testing.T) {
+	tests := []struct {
+		token         config.EthereumTokenConfig
+		expectedError string
+	}{
+		{
+			config.EthereumTokenConfig{ContractAddress: "0x1", AssetCode: "OMG", Decimals: 18, MinimumValue: "1"},
+			"Invalid token contract address",
+		},
+		{
+			config.EthereumTokenConfig{ContractAddress: "0xd26114cd6EE289AccF82350c8d8487fedB8A0C07", AssetCode: "TOOLONGASSETCODE", Decimals: 18, MinimumValue: "1"},
+			"Invalid token asset code",
+		},
+		{
+			config.EthereumTokenConfig{ContractAddress: "0xd26114cd6EE289AccF82350c8d8487fedB8A0C07", AssetCode: "ETH", Decimals: 18, MinimumValue: "1"},
+			"Duplicate token asset code",
+		},
+		{
+			config.EthereumTokenConfig{ContractAddress: "0xd26114cd6EE289AccF82350c8d8487fedB8A0C07", AssetCode: "OMG", Decimals: 0, MinimumValue: "0.5"},
+			"Invalid minimum accepted OMG transfer value",
+		},
+		{
+			config.EthereumTokenConfig{ContractAddress: "0xd26114cd6EE289AccF82350c8d8487fedB8A0C07", AssetCode: "OMG", Decimals: 18, MinimumValue: "0"},
+			"must be larger than 0",
+		},
+	}
+
+	for _, test := range tests {
+		_, err := newTokens([]config.EthereumTokenConfig{test.token})
+		if assert.Error(t, err) {
+			assert.Contains(t, err.Error(), test.expectedError)
+		}
+	}
+}
@@ -4,21 +4,20 @@ import (
 	ethereumCommon "github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/haltingstate/secp256k1-go"
+	"github.com/stellar/go/services/bifrost/chains"
 	"github.com/stellar/go/support/errors"
-	"github.com/tyler-smith/go-bip32"
 )
 
-func NewAddressGenerator(masterPublicKeyString string) (*AddressGenerator, error) {
-	deserializedMasterPublicKey, err := bip32.B58Deserialize(masterPublicKeyString)
+// NewAddressGenerator creates AddressGenerator deriving addresses from
+// `masterPublicKeyString` child key at `derivationPath`, see
+// chains.DerivePublicKey.
+func NewAddressGenerator(masterPublicKeyString, derivationPath string) (*AddressGenerator, error) {
+	publicKey, err := chains.DerivePublicKey(masterPublicKeyString, derivationPath)
 	if err != nil {
-		return nil, errors.Wrap(err, "Error deserializing master public key")
+		return nil, err
 	}
 
-	if deserializedMasterPublicKey.IsPrivate {
-		return nil, errors.New("Key is not a master public key")
-	}
-
-	return &AddressGenerator{deserializedMasterPublicKey}, nil
+	return &AddressGenerator{publicKey}, nil
 }
 
 func (g *AddressGenerator) Generate(index uint32) (string, error) {
@@ -0,0 +1,99 @@
+package ethereum
+
+import (
+	"context"
+	"math/big"
+	"sync"
+	"time"
+
+	goethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stellar/go/services/bifrost/chains"
+	"github.com/stellar/go/services/bifrost/database"
+)
+
+// DefaultMaxBlockLag is the number of blocks a node can be behind the best
+// node before it's considered stale.
+const DefaultMaxBlockLag = 5
+
+// NodeClient is a Client of a single geth node.
+type NodeClient interface {
+	Client
+	HeaderByNumber(ctx context.Context, number *big.Int) (*types.Header, error)
+}
+
+// FailoverClient implements Client using many geth nodes. Requests are sent to
+// the node selected by Failover. Nodes returning errors are marked unhealthy so
+// the next request is sent to another node.
+type FailoverClient struct {
+	Nodes    []NodeClient
+	Failover *chains.Failover
+
+	mutex       sync.Mutex
+	blockSource string
+}
+
+// NewFailoverClient creates FailoverClient sending requests to `clients`.
+// `hosts` are the names of nodes used in logs.
+func NewFailoverClient(hosts []string, clients []NodeClient) *FailoverClient {
+	c := &FailoverClient{Nodes: clients}
+	c.Failover = &chains.Failover{
+		Chain:  database.ChainEthereum,
+		Nodes:  hosts,
+		MaxLag: DefaultMaxBlockLag,
+		Height: func(node int) (uint64, error) {
+			ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(5*time.Second))
+			defer cancel()
+
+			header, err := c.Nodes[node].HeaderByNumber(ctx, nil)
+			if err != nil {
+				return 0, err
+			}
+			return header.Number.Uint64(), nil
+		},
+	}
+	return c
+}
+
+func (c *FailoverClient) NetworkID(ctx context.Context) (*big.Int, error) {
+	node := c.Failover.Current()
+	id, err := c.Nodes[node].NetworkID(ctx)
+	if err != nil {
+		c.Failover.ReportError(node, err)
+	}
+	return id, err
+}
+
+func (c *FailoverClient) BlockByNumber(ctx context.Context, number *big.Int) (*types.Block, error) {
+	node := c.Failover.Current()
+	block, err := c.Nodes[node].BlockByNumber(ctx, number)
+	if err != nil {
+		// Block not existing yet is not a node failure. Stale nodes are detected
+		// by Failover health checks.
+		if err.Error() != "not found" {
+			c.Failover.ReportError(node, err)
+		}
+		return nil, err
+	}
+
+	c.mutex.Lock()
+	c.blockSource = c.Failover.NodeName(node)
+	c.mutex.Unlock()
+	return block, nil
+}
+
+func (c *FailoverClient) FilterLogs(ctx context.Context, query goethereum.FilterQuery) ([]types.Log, error) {
+	node := c.Failover.Current()
+	logs, err := c.Nodes[node].FilterLogs(ctx, query)
+	if err != nil {
+		c.Failover.ReportError(node, err)
+	}
+	return logs, err
+}
+
+// BlockSource implements chains.BlockSource.
+func (c *FailoverClient) BlockSource() string {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.blockSource
+}
@@ -0,0 +1,79 @@
+package ethereum
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stellar/go/support/errors"
+)
+
+const (
+	// headsResubscribeInterval is the time between attempts to subscribe to new
+	// heads when the subscription failed.
+	headsResubscribeInterval = 10 * time.Second
+	// headsPollInterval is the maximum time of waiting for a new head before
+	// checking for a new block anyway.
+	headsPollInterval = 15 * time.Second
+)
+
+// subscribeHeads keeps Heads subscription active, resubscribing when it fails.
+func (l *Listener) subscribeHeads() {
+	for {
+		err := l.streamHeads()
+		atomic.StoreInt32(&l.headsSubscribed, 0)
+		l.log.WithField("err", err).Warn("New heads subscription failed, polling for new blocks")
+		time.Sleep(headsResubscribeInterval)
+	}
+}
+
+// streamHeads subscribes to new heads and notifies processBlocks about every
+// new head until the subscription fails.
+func (l *Listener) streamHeads() error {
+	headers := make(chan *types.Header)
+
+	// Context cancels the subscribe request only, not the subscription.
+	ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(5*time.Second))
+	defer cancel()
+
+	subscription, err := l.Heads.SubscribeNewHead(ctx, headers)
+	if err != nil {
+		return errors.Wrap(err, "Error subscribing to new heads")
+	}
+	defer subscription.Unsubscribe()
+
+	atomic.StoreInt32(&l.headsSubscribed, 1)
+	l.log.Info("Subscribed to new heads")
+
+	for {
+		select {
+		case header := <-headers:
+			l.log.WithField("blockNumber", header.Number).Debug("Received new head")
+			select {
+			case l.newHeads <- struct{}{}:
+			default:
+				// processBlocks has not received the previous head yet
+			}
+		case err := <-subscription.Err():
+			if err == nil {
+				err = errors.New("Subscription closed")
+			}
+			return err
+		}
+	}
+}
+
+// waitForBlock waits before checking if a new block exists: until a new head
+// is received if subscribed to new heads, 1 second otherwise.
+func (l *Listener) waitForBlock() {
+	if atomic.LoadInt32(&l.headsSubscribed) == 0 {
+		time.Sleep(1 * time.Second)
+		return
+	}
+
+	select {
+	case <-l.newHeads:
+	case <-time.After(headsPollInterval):
+	}
+}
@@ -0,0 +1,71 @@
+package ethereum
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	goethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stellar/go/services/bifrost/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testSubscription struct {
+	err chan error
+}
+
+func (s *testSubscription) Unsubscribe() {}
+
+func (s *testSubscription) Err() <-chan error {
+	return s.err
+}
+
+type testHeadSubscriber struct {
+	headers      chan<- *types.Header
+	subscription *testSubscription
+}
+
+func (h *testHeadSubscriber) SubscribeNewHead(ctx context.Context, ch chan<- *types.Header) (goethereum.Subscription, error) {
+	h.headers = ch
+	return h.subscription, nil
+}
+
+func TestStreamHeads(t *testing.T) {
+	heads := &testHeadSubscriber{subscription: &testSubscription{err: make(chan error)}}
+	listener := &Listener{
+		Heads:    heads,
+		newHeads: make(chan struct{}, 1),
+		log:      common.CreateLogger("EthereumListener"),
+	}
+
+	done := make(chan error)
+	go func() {
+		done <- listener.streamHeads()
+	}()
+
+	// Wait for subscription
+	for atomic.LoadInt32(&listener.headsSubscribed) == 0 {
+		time.Sleep(time.Millisecond)
+	}
+
+	heads.headers <- &types.Header{Number: big.NewInt(100)}
+	select {
+	case <-listener.newHeads:
+	case <-time.After(time.Second):
+		t.Fatal("New head not received")
+	}
+
+	// Notifications are not blocking when processBlocks is busy
+	heads.headers <- &types.Header{Number: big.NewInt(101)}
+	heads.headers <- &types.Header{Number: big.NewInt(102)}
+
+	heads.subscription.err <- errors.New("connection closed")
+	err := <-done
+	require.Error(t, err)
+	assert.Equal(t, "connection closed", err.Error())
+}
@@ -5,24 +5,49 @@ import (
 	"math/big"
 	"time"
 
+	goethereum "github.com/ethereum/go-ethereum"
+	ethereumCommon "github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stellar/go/services/bifrost/chains"
 	"github.com/stellar/go/services/bifrost/common"
+	"github.com/stellar/go/services/bifrost/database"
+	"github.com/stellar/go/services/bifrost/metrics"
 	"github.com/stellar/go/support/errors"
 	"github.com/stellar/go/support/log"
 )
 
-func (l *Listener) Start(rpcServer string) error {
+func (l *Listener) Start() error {
 	l.log = common.CreateLogger("EthereumListener")
 	l.log.Info("EthereumListener starting")
 
-	blockNumber, err := l.Storage.GetEthereumBlockToProcess()
+	blockNumber, err := l.Storage.GetBlockToProcess(database.ChainEthereum)
 	if err != nil {
 		err = errors.Wrap(err, "Error getting ethereum block to process from DB")
 		l.log.Error(err)
 		return err
 	}
 
-	// Check if connected to correct network
+	err = l.init()
+	if err != nil {
+		return err
+	}
+
+	if l.Heads != nil {
+		l.newHeads = make(chan struct{}, 1)
+		go l.subscribeHeads()
+	}
+
+	go l.processBlocks(blockNumber)
+	return nil
+}
+
+// init sets defaults and checks if geth is connected to the configured
+// network.
+func (l *Listener) init() error {
+	if l.Confirmations == 0 {
+		l.Confirmations = 1
+	}
+
 	ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(5*time.Second))
 	defer cancel()
 	id, err := l.Client.NetworkID(ctx)
@@ -36,37 +61,80 @@ func (l *Listener) Start(rpcServer string) error {
 		return errors.Errorf("Invalid network ID (have=%s, want=%s)", id.String(), l.NetworkID)
 	}
 
-	go l.processBlocks(blockNumber)
+	return nil
+}
+
+// Rescan processes blocks from `fromBlock` to `toBlock` (inclusive) again and
+// streams their transactions with the required number of confirmations. It
+// returns error if any block fails to process or `toBlock` doesn't have the
+// required number of confirmations yet. The last processed block is not
+// changed.
+func (l *Listener) Rescan(fromBlock, toBlock uint64) error {
+	l.log = common.CreateLogger("EthereumListener")
+	l.log.WithFields(log.F{"fromBlock": fromBlock, "toBlock": toBlock}).Info("EthereumListener rescanning")
+
+	err := l.init()
+	if err != nil {
+		return err
+	}
+
+	latestBlock, err := l.getBlock(0)
+	if err != nil {
+		return err
+	}
+
+	if latestBlock == nil || toBlock+l.Confirmations-1 > latestBlock.NumberU64() {
+		return errors.Errorf("Block %d doesn't have %d confirmations yet", toBlock, l.Confirmations)
+	}
+
+	for blockNumber := fromBlock; blockNumber <= toBlock; blockNumber++ {
+		block, err := l.getBlock(blockNumber)
+		if err != nil {
+			return err
+		}
+
+		if block == nil {
+			return errors.Errorf("Block %d not found", blockNumber)
+		}
+
+		err = l.processBlock(block, l.Confirmations)
+		if err != nil {
+			return errors.Wrapf(err, "Error processing block %d", blockNumber)
+		}
+	}
+
 	return nil
 }
 
 func (l *Listener) processBlocks(blockNumber uint64) {
 	if blockNumber == 0 {
 		l.log.Info("Starting from the latest block")
-	} else {
-		l.log.Infof("Starting from block %d", blockNumber)
+		blockNumber = l.latestBlockNumber()
 	}
+	l.log.WithField("confirmations", l.Confirmations).Infof("Starting from block %d", blockNumber)
 
 	// Time when last new block has been seen
 	lastBlockSeen := time.Now()
 	noBlockWarningLogged := false
 
 	for {
-		block, err := l.getBlock(blockNumber)
+		// Block giving `blockNumber` block the required number of confirmations
+		newBlockNumber := blockNumber + l.Confirmations - 1
+		newBlock, err := l.getBlock(newBlockNumber)
 		if err != nil {
-			l.log.WithFields(log.F{"err": err, "blockNumber": blockNumber}).Error("Error getting block")
+			l.log.WithFields(log.F{"err": err, "blockNumber": newBlockNumber}).Error("Error getting block")
 			time.Sleep(1 * time.Second)
 			continue
 		}
 
 		// Block doesn't exist yet
-		if block == nil {
+		if newBlock == nil {
 			if time.Since(lastBlockSeen) > 3*time.Minute && !noBlockWarningLogged {
 				l.log.Warn("No new block in more than 3 minutes")
 				noBlockWarningLogged = true
 			}
 
-			time.Sleep(1 * time.Second)
+			l.waitForBlock()
 			continue
 		}
 
@@ -74,23 +142,69 @@ func (l *Listener) processBlocks(blockNumber uint64) {
 		lastBlockSeen = time.Now()
 		noBlockWarningLogged = false
 
-		err = l.processBlock(block)
+		block := newBlock
+		if l.Confirmations > 1 {
+			err = l.processNewBlock(newBlock)
+			if err != nil {
+				l.log.WithFields(log.F{"err": err, "blockNumber": newBlockNumber}).Error("Error processing new block")
+				time.Sleep(1 * time.Second)
+				continue
+			}
+
+			block, err = l.getBlock(blockNumber)
+			if err != nil || block == nil {
+				l.log.WithFields(log.F{"err": err, "blockNumber": blockNumber}).Error("Error getting block")
+				time.Sleep(1 * time.Second)
+				continue
+			}
+		}
+
+		err = l.processBlock(block, l.Confirmations)
 		if err != nil {
 			l.log.WithFields(log.F{"err": err, "blockNumber": block.NumberU64()}).Error("Error processing block")
 			time.Sleep(1 * time.Second)
 			continue
 		}
 
+		metrics.BlockProcessed(database.ChainEthereum, blockNumber)
+
 		// Persist block number
-		err = l.Storage.SaveLastProcessedEthereumBlock(blockNumber)
+		err = l.Storage.SaveLastProcessedBlock(database.ChainEthereum, blockNumber)
 		if err != nil {
 			l.log.WithField("err", err).Error("Error saving last processed block")
 			time.Sleep(1 * time.Second)
 			// We continue to the next block
 		}
 
-		blockNumber = block.NumberU64() + 1
+		blockNumber++
+	}
+}
+
+// latestBlockNumber returns the number of the latest block, retrying on errors.
+func (l *Listener) latestBlockNumber() uint64 {
+	for {
+		block, err := l.getBlock(0)
+		if err == nil && block != nil {
+			return block.NumberU64()
+		}
+
+		l.log.WithField("err", err).Error("Error getting the latest block")
+		time.Sleep(1 * time.Second)
+	}
+}
+
+// processNewBlock streams transactions of a new block with one confirmation
+// and calls BlockHandler so confirmations of pending transactions are updated.
+func (l *Listener) processNewBlock(block *types.Block) error {
+	err := l.processBlock(block, 1)
+	if err != nil {
+		return err
+	}
+
+	if l.BlockHandler != nil {
+		return l.BlockHandler(block.NumberU64())
 	}
+	return nil
 }
 
 // getBlock returns (nil, nil) if block has not been found (not exists yet)
@@ -117,15 +231,19 @@ func (l *Listener) getBlock(blockNumber uint64) (*types.Block, error) {
 	return block, nil
 }
 
-func (l *Listener) processBlock(block *types.Block) error {
+func (l *Listener) processBlock(block *types.Block, confirmations uint64) error {
 	transactions := block.Transactions()
 	blockTime := time.Unix(block.Time().Int64(), 0)
 
 	localLog := l.log.WithFields(log.F{
-		"blockNumber":  block.NumberU64(),
-		"blockTime":    blockTime,
-		"transactions": len(transactions),
+		"blockNumber":   block.NumberU64(),
+		"blockTime":     blockTime,
+		"confirmations": confirmations,
+		"transactions":  len(transactions),
 	})
+	if source, ok := l.Client.(chains.BlockSource); ok {
+		localLog = localLog.WithField("node", source.BlockSource())
+	}
 	localLog.Info("Processing block")
 
 	for _, transaction := range transactions {
@@ -136,9 +254,11 @@ func (l *Listener) processBlock(block *types.Block) error {
 		}
 
 		tx := Transaction{
-			Hash:     transaction.Hash().Hex(),
-			ValueWei: transaction.Value(),
-			To:       to.Hex(),
+			Hash:          transaction.Hash().Hex(),
+			ValueWei:      transaction.Value(),
+			To:            to.Hex(),
+			BlockNumber:   block.NumberU64(),
+			Confirmations: confirmations,
 		}
 		err := l.TransactionHandler(tx)
 		if err != nil {
@@ -146,7 +266,62 @@ func (l *Listener) processBlock(block *types.Block) error {
 		}
 	}
 
+	if len(l.Tokens) > 0 {
+		err := l.processTokenTransfers(block, confirmations)
+		if err != nil {
+			return errors.Wrap(err, "Error processing token transfers")
+		}
+	}
+
 	localLog.Info("Processed block")
 
 	return nil
 }
+
+// processTokenTransfers calls TransactionHandler for each `Transfer` event
+// of Tokens contracts emitted in the block.
+func (l *Listener) processTokenTransfers(block *types.Block, confirmations uint64) error {
+	tokens := map[ethereumCommon.Address]*Token{}
+	addresses := make([]ethereumCommon.Address, len(l.Tokens))
+	for i := range l.Tokens {
+		tokens[l.Tokens[i].ContractAddress] = &l.Tokens[i]
+		addresses[i] = l.Tokens[i].ContractAddress
+	}
+
+	ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(5*time.Second))
+	defer cancel()
+
+	logs, err := l.Client.FilterLogs(ctx, goethereum.FilterQuery{
+		FromBlock: block.Number(),
+		ToBlock:   block.Number(),
+		Addresses: addresses,
+		Topics:    [][]ethereumCommon.Hash{{transferEventTopic}},
+	})
+	if err != nil {
+		return errors.Wrap(err, "Error getting logs from geth")
+	}
+
+	for _, eventLog := range logs {
+		token, ok := tokens[eventLog.Address]
+		// `Transfer` events of ERC-721 tokens have 4 topics (indexed token ID)
+		if !ok || eventLog.Removed || len(eventLog.Topics) != 3 || len(eventLog.Data) != 32 {
+			continue
+		}
+
+		tx := Transaction{
+			Hash:          eventLog.TxHash.Hex(),
+			ValueWei:      new(big.Int).SetBytes(eventLog.Data),
+			To:            ethereumCommon.BytesToAddress(eventLog.Topics[2].Bytes()).Hex(),
+			Token:         token,
+			LogIndex:      eventLog.Index,
+			BlockNumber:   block.NumberU64(),
+			Confirmations: confirmations,
+		}
+		err := l.TransactionHandler(tx)
+		if err != nil {
+			return errors.Wrap(err, "Error processing token transfer")
+		}
+	}
+
+	return nil
+}
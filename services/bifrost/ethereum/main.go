@@ -4,7 +4,11 @@ import (
 	"context"
 	"math/big"
 
+	goethereum "github.com/ethereum/go-ethereum"
+	ethereumCommon "github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stellar/go/services/bifrost/database"
+	"github.com/stellar/go/services/bifrost/queue"
 	"github.com/stellar/go/support/errors"
 	"github.com/stellar/go/support/log"
 	"github.com/tyler-smith/go-bip32"
@@ -15,49 +19,102 @@ var (
 	eighteen = big.NewInt(18)
 	// weiInEth = 10^18
 	weiInEth = new(big.Rat).SetInt(new(big.Int).Exp(ten, eighteen, nil))
+
+	// transferEventTopic is the topic of ERC-20 `Transfer(address,address,uint256)` event.
+	transferEventTopic = ethereumCommon.HexToHash("0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef")
+	// transferMethodID is the ID of ERC-20 `transfer(address,uint256)` method.
+	transferMethodID = []byte{0xa9, 0x05, 0x9c, 0xbb}
 )
 
 // Listener listens for transactions using geth RPC. It calls TransactionHandler for each new
 // transactions. It will reprocess the block if TransactionHandler returns error. It will
-// start from the block number returned from Storage.GetEthereumBlockToProcess or the latest block
+// start from the block number returned from Storage.GetBlockToProcess or the latest block
 // if it returned 0. Transactions can be processed more than once, it's TransactionHandler
 // responsibility to ignore duplicates.
 // You can run multiple Listeners if Storage is implemented correctly.
 // Listener ignores contract creation transactions.
+// If Tokens are set, Listener also calls TransactionHandler for each ERC-20 `Transfer`
+// event emitted by the tokens contracts.
+// If Heads is set, Listener subscribes to new block headers and checks for a new block
+// as soon as it's received instead of polling every second. When the subscription
+// fails Listener falls back to polling and subscribes again.
 // Listener requires geth 1.7.0.
 type Listener struct {
-	Enabled            bool
 	Client             Client  `inject:""`
 	Storage            Storage `inject:""`
+	Heads              HeadSubscriber
 	NetworkID          string
+	Tokens             []Token
 	TransactionHandler TransactionHandler
+	// Confirmations is the number of confirmations required to accept a transaction.
+	// If it's larger than 1, transactions are also streamed when their block is seen
+	// and BlockHandler is called for each new block. Default value is 1.
+	Confirmations uint64
+	BlockHandler  func(blockNumber uint64) error
 
-	log *log.Entry
+	// newHeads receives a value when a new block header is received from Heads.
+	newHeads chan struct{}
+	// headsSubscribed is 1 when Heads subscription is active.
+	headsSubscribed int32
+	log             *log.Entry
 }
 
 type Client interface {
 	NetworkID(ctx context.Context) (*big.Int, error)
 	BlockByNumber(ctx context.Context, number *big.Int) (*types.Block, error)
+	FilterLogs(ctx context.Context, query goethereum.FilterQuery) ([]types.Log, error)
+}
+
+// HeadSubscriber subscribes to new block headers using `eth_subscribe("newHeads")`.
+// It's implemented by ethclient.Client connected over websocket.
+type HeadSubscriber interface {
+	SubscribeNewHead(ctx context.Context, ch chan<- *types.Header) (goethereum.Subscription, error)
+}
+
+// RPCClient calls geth RPC methods not available in Client. It's used to send
+// withdrawals using `eth_sendTransaction` so transactions are signed by geth
+// and Bifrost never has access to private keys.
+type RPCClient interface {
+	CallContext(ctx context.Context, result interface{}, method string, args ...interface{}) error
 }
 
 // Storage is an interface that must be implemented by an object using
 // persistent storage.
 type Storage interface {
-	// GetEthereumBlockToProcess gets the number of Ethereum block to process. `0` means the
+	// GetBlockToProcess gets the number of `chain` block to process. `0` means the
 	// processing should start from the current block.
-	GetEthereumBlockToProcess() (uint64, error)
-	// SaveLastProcessedEthereumBlock should update the number of the last processed Ethereum
+	GetBlockToProcess(chain database.Chain) (uint64, error)
+	// SaveLastProcessedBlock should update the number of the last processed `chain`
 	// block. It should only update the block if block > current block in atomic transaction.
-	SaveLastProcessedEthereumBlock(block uint64) error
+	SaveLastProcessedBlock(chain database.Chain, block uint64) error
 }
 
 type TransactionHandler func(transaction Transaction) error
 
 type Transaction struct {
 	Hash string
-	// Value in Wei
+	// Value in Wei or in the base unit of Token if set
 	ValueWei *big.Int
 	To       string
+	// Token is the ERC-20 token transferred, nil for ETH payments.
+	Token *Token
+	// LogIndex is the index of the token `Transfer` event log in the block.
+	LogIndex uint
+	// BlockNumber is the number of block containing the transaction.
+	BlockNumber uint64
+	// Confirmations is the number of blocks confirming the transaction.
+	Confirmations uint64
+}
+
+// Token is an ERC-20 token accepted by Bifrost.
+type Token struct {
+	ContractAddress ethereumCommon.Address
+	// AssetCode is the code of Stellar asset issued for the token.
+	AssetCode queue.AssetCode
+	// Decimals is the number of decimals of token amounts, `decimals()` of the contract.
+	Decimals uint8
+	// MinimumValue of transfer accepted by Bifrost in the base unit of the token.
+	MinimumValue *big.Int
 }
 
 type AddressGenerator struct {
@@ -65,19 +122,30 @@ type AddressGenerator struct {
 }
 
 func EthToWei(eth string) (*big.Int, error) {
+	return ToBaseUnit(eth, 18)
+}
+
+// ToBaseUnit converts `value` to the base unit of currency with `decimals`
+// decimals (Wei for ETH).
+func ToBaseUnit(value string, decimals uint8) (*big.Int, error) {
 	valueRat := new(big.Rat)
-	_, ok := valueRat.SetString(eth)
+	_, ok := valueRat.SetString(value)
 	if !ok {
 		return nil, errors.New("Could not convert to *big.Rat")
 	}
 
-	// Calculate value in Wei
-	valueRat.Mul(valueRat, weiInEth)
+	// Calculate value in the base unit
+	valueRat.Mul(valueRat, baseUnits(decimals))
 
 	// Ensure denominator is equal `1`
 	if valueRat.Denom().Cmp(big.NewInt(1)) != 0 {
-		return nil, errors.New("Invalid precision, is value smaller than 1 Wei?")
+		return nil, errors.New("Invalid precision, is value smaller than 1 base unit (Wei)?")
 	}
 
 	return valueRat.Num(), nil
 }
+
+// baseUnits returns 10^decimals.
+func baseUnits(decimals uint8) *big.Rat {
+	return new(big.Rat).SetInt(new(big.Int).Exp(ten, big.NewInt(int64(decimals)), nil))
+}
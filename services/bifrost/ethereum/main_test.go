@@ -41,3 +41,31 @@ func TestEthToWei(t *testing.T) {
 		}
 	}
 }
+
+func TestToBaseUnit(t *testing.T) {
+	tests := []struct {
+		amount         string
+		decimals       uint8
+		expectedAmount *big.Int
+		expectedError  string
+	}{
+		{"1.5", 0, nil, "Invalid precision"},
+		{"0.0000001", 6, nil, "Invalid precision"},
+
+		{"2", 0, big.NewInt(2), ""},
+		{"1.5", 6, big.NewInt(1500000), ""},
+		{"0.000001", 6, big.NewInt(1), ""},
+		{"1", 18, big.NewInt(1000000000000000000), ""},
+	}
+
+	for _, test := range tests {
+		returnedAmount, err := ToBaseUnit(test.amount, test.decimals)
+		if test.expectedError != "" {
+			assert.Error(t, err)
+			assert.Contains(t, err.Error(), test.expectedError)
+		} else {
+			assert.NoError(t, err)
+			assert.Equal(t, 0, returnedAmount.Cmp(test.expectedAmount))
+		}
+	}
+}
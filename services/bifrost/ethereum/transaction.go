@@ -7,7 +7,12 @@ import (
 )
 
 func (t Transaction) ValueToStellar() string {
-	valueEth := new(big.Rat)
-	valueEth.Quo(new(big.Rat).SetInt(t.ValueWei), weiInEth)
-	return valueEth.FloatString(common.StellarAmountPrecision)
+	unit := weiInEth
+	if t.Token != nil {
+		unit = baseUnits(t.Token.Decimals)
+	}
+
+	value := new(big.Rat)
+	value.Quo(new(big.Rat).SetInt(t.ValueWei), unit)
+	return value.FloatString(common.StellarAmountPrecision)
 }
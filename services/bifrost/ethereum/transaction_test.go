@@ -26,3 +26,25 @@ func TestTransactionAmount(t *testing.T) {
 		assert.Equal(t, test.expectedStellarAmount, amount)
 	}
 }
+
+func TestTokenTransactionAmount(t *testing.T) {
+	tests := []struct {
+		decimals              uint8
+		amount                string
+		expectedStellarAmount string
+	}{
+		{0, "1", "1.0000000"},
+		{6, "1", "0.0000010"},
+		{6, "1234567", "1.2345670"},
+		{8, "123456789", "1.2345679"},
+		{18, "1000000000000000000", "1.0000000"},
+	}
+
+	for _, test := range tests {
+		bigAmount, ok := new(big.Int).SetString(test.amount, 10)
+		assert.True(t, ok)
+		transaction := Transaction{ValueWei: bigAmount, Token: &Token{Decimals: test.decimals}}
+		amount := transaction.ValueToStellar()
+		assert.Equal(t, test.expectedStellarAmount, amount)
+	}
+}
@@ -0,0 +1,49 @@
+package kyc
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/stellar/go/services/bifrost/webhooks"
+	"github.com/stellar/go/support/errors"
+)
+
+func (a *HTTPApprover) Approve(deposit Deposit) (*Decision, error) {
+	client := a.HTTP
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+
+	payload, err := json.Marshal(deposit)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error marshalling deposit")
+	}
+
+	req, err := http.NewRequest(http.MethodPost, a.URL, bytes.NewReader(payload))
+	if err != nil {
+		return nil, errors.Wrap(err, "Error creating request")
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Bifrost-Signature", "sha256="+webhooks.Sign(a.Secret, payload))
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error sending request")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("Invalid response status: %d", resp.StatusCode)
+	}
+
+	var decision Decision
+	err = json.NewDecoder(resp.Body).Decode(&decision)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error decoding response")
+	}
+
+	return &decision, nil
+}
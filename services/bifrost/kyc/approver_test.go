@@ -0,0 +1,54 @@
+package kyc
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stellar/go/services/bifrost/webhooks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testDeposit = Deposit{
+	Chain:            "bitcoin",
+	TransactionID:    "109fa1c369680c2f27643fdd160620d010851a376d25b9b00ef71afe789ea6ed",
+	Address:          "1Q74qRud8bXUn6FMtXWZwJa5pj56s3mdyf",
+	StellarPublicKey: "GDULKYRRVOMASFMXBYD4BYFRSHAKQDREEVVP2TMH2CER3DW2KATIOASB",
+	AssetCode:        "BTC",
+	Amount:           "1.0000000",
+}
+
+func TestHTTPApprover(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := ioutil.ReadAll(r.Body)
+		require.NoError(t, err)
+		assert.Equal(t, "sha256="+webhooks.Sign("secret", body), r.Header.Get("X-Bifrost-Signature"))
+
+		var deposit Deposit
+		require.NoError(t, json.Unmarshal(body, &deposit))
+		assert.Equal(t, testDeposit, deposit)
+
+		w.Write([]byte(`{"approved": false, "reason": "KYC not completed"}`))
+	}))
+	defer server.Close()
+
+	approver := &HTTPApprover{URL: server.URL, Secret: "secret"}
+	decision, err := approver.Approve(testDeposit)
+	require.NoError(t, err)
+	assert.False(t, decision.Approved)
+	assert.Equal(t, "KYC not completed", decision.Reason)
+}
+
+func TestHTTPApproverError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	approver := &HTTPApprover{URL: server.URL, Secret: "secret"}
+	_, err := approver.Approve(testDeposit)
+	assert.Error(t, err)
+}
@@ -0,0 +1,51 @@
+package kyc
+
+import (
+	"net/http"
+)
+
+// Approver decides if the asset can be issued for a confirmed deposit. It's
+// used by regulated anchors to check the identity of the recipient before
+// issuing.
+type Approver interface {
+	// Approve returns the decision of the operator about `deposit`. It returns
+	// error if the decision can't be made at the moment, ex. the endpoint is
+	// down.
+	Approve(deposit Deposit) (*Decision, error)
+}
+
+// Deposit is a confirmed deposit sent to the approval endpoint.
+type Deposit struct {
+	Chain            string `json:"chain"`
+	TransactionID    string `json:"transaction_id"`
+	Address          string `json:"address"`
+	StellarPublicKey string `json:"stellar_public_key"`
+	// AssetCode and Amount of the Stellar asset that will be issued.
+	AssetCode string `json:"asset_code"`
+	Amount    string `json:"amount"`
+}
+
+// Decision is the response of the approval endpoint.
+type Decision struct {
+	Approved bool `json:"approved"`
+	// Reason of rejection, saved in the issuance.
+	Reason string `json:"reason"`
+}
+
+// HTTPApprover sends deposits to URL in POST request with JSON encoded
+// Deposit body. The endpoint must respond with `200 OK` and JSON encoded
+// Decision. Other responses are errors: the deposit is not issued and can be
+// retried using the admin API.
+//
+// The request body is signed using HMAC-SHA256 with Secret, the same way as
+// webhooks. The hex encoded signature is sent in `X-Bifrost-Signature` header as
+// `sha256=<signature>`.
+type HTTPApprover struct {
+	URL    string
+	Secret string
+	HTTP   HTTPClient
+}
+
+type HTTPClient interface {
+	Do(req *http.Request) (*http.Response, error)
+}
@@ -0,0 +1,18 @@
+package kyc
+
+import (
+	"github.com/stretchr/testify/mock"
+)
+
+// MockApprover is a mockable approver.
+type MockApprover struct {
+	mock.Mock
+}
+
+func (m *MockApprover) Approve(deposit Deposit) (*Decision, error) {
+	a := m.Called(deposit)
+	if a.Get(0) == nil {
+		return nil, a.Error(1)
+	}
+	return a.Get(0).(*Decision), a.Error(1)
+}
@@ -5,25 +5,29 @@ package main
 
 import (
 	"fmt"
+	"math/big"
 	"net/http"
 	"os"
 	"time"
 
 	"github.com/btcsuite/btcd/chaincfg"
-	"github.com/btcsuite/btcd/rpcclient"
-	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/facebookgo/inject"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 	"github.com/stellar/go/clients/horizon"
 	"github.com/stellar/go/services/bifrost/bitcoin"
+	"github.com/stellar/go/services/bifrost/chains"
+	"github.com/stellar/go/services/bifrost/cluster"
 	"github.com/stellar/go/services/bifrost/config"
 	"github.com/stellar/go/services/bifrost/database"
 	"github.com/stellar/go/services/bifrost/ethereum"
+	"github.com/stellar/go/services/bifrost/kyc"
+	"github.com/stellar/go/services/bifrost/price"
 	"github.com/stellar/go/services/bifrost/server"
 	"github.com/stellar/go/services/bifrost/sse"
 	"github.com/stellar/go/services/bifrost/stellar"
 	"github.com/stellar/go/services/bifrost/stress"
+	"github.com/stellar/go/services/bifrost/webhooks"
 	supportConfig "github.com/stellar/go/support/config"
 	"github.com/stellar/go/support/errors"
 	"github.com/stellar/go/support/log"
@@ -83,7 +87,7 @@ This command will create 3 server.Server's listening on ports 8000-8002.`,
 		ethereumClient := &stress.RandomEthereumClient{}
 		ethereumClient.Start(ethereumAccounts)
 
-		db, err := createDatabase(cfg.Database.DSN)
+		db, err := createDatabase(cfg.Database.Type, cfg.Database.DSN)
 		if err != nil {
 			log.WithField("err", err).Error("Error connecting to database")
 			os.Exit(-1)
@@ -112,8 +116,14 @@ This command will create 3 server.Server's listening on ports 8000-8002.`,
 				cfg.Stellar.SignerSecretKey = signers[i]
 				server := createServer(cfg, true)
 				// Replace clients in listeners with random transactions generators
-				server.BitcoinListener.Client = bitcoinClient
-				server.EthereumListener.Client = ethereumClient
+				if adapter, ok := server.Adapters[database.ChainBitcoin].(*bitcoin.Adapter); ok {
+					adapter.Listener.Client = bitcoinClient
+					adapter.Failover = nil
+				}
+				if adapter, ok := server.Adapters[database.ChainEthereum].(*ethereum.Adapter); ok {
+					adapter.Listener.Client = ethereumClient
+					adapter.Failover = nil
+				}
 				err := server.Start()
 				if err != nil {
 					log.WithField("err", err).Error("Error starting the server")
@@ -154,6 +164,24 @@ This command will create 3 server.Server's listening on ports 8000-8002.`,
 	},
 }
 
+var checkConfigCmd = &cobra.Command{
+	Use:   "check-config",
+	Short: "Validates the config file and exits",
+	Run: func(cmd *cobra.Command, args []string) {
+		cfgPath := rootCmd.PersistentFlags().Lookup("config").Value.String()
+		cfg := readConfig(cfgPath)
+
+		if cfg.Bitcoin != nil {
+			fmt.Println("Bitcoin: enabled")
+		}
+		if cfg.Ethereum != nil {
+			fmt.Printf("Ethereum: enabled (%d tokens)\n", len(cfg.Ethereum.Tokens))
+		}
+		fmt.Println("Database:", cfg.Database.Type)
+		fmt.Println("Config is valid")
+	},
+}
+
 var checkKeysCmd = &cobra.Command{
 	Use:   "check-keys",
 	Short: "Displays a few public keys derived using master public keys",
@@ -167,7 +195,7 @@ var checkKeysCmd = &cobra.Command{
 
 		fmt.Println("Bitcoin MainNet:")
 		if cfg.Bitcoin != nil && cfg.Bitcoin.MasterPublicKey != "" {
-			bitcoinAddressGenerator, err := bitcoin.NewAddressGenerator(cfg.Bitcoin.MasterPublicKey, &chaincfg.MainNetParams)
+			bitcoinAddressGenerator, err := bitcoin.NewAddressGenerator(cfg.Bitcoin.MasterPublicKey, cfg.Bitcoin.DerivationPath, &chaincfg.MainNetParams)
 			if err != nil {
 				log.Error(err)
 				os.Exit(-1)
@@ -187,7 +215,7 @@ var checkKeysCmd = &cobra.Command{
 
 		fmt.Println("Ethereum:")
 		if cfg.Ethereum != nil && cfg.Ethereum.MasterPublicKey != "" {
-			ethereumAddressGenerator, err := ethereum.NewAddressGenerator(cfg.Ethereum.MasterPublicKey)
+			ethereumAddressGenerator, err := ethereum.NewAddressGenerator(cfg.Ethereum.MasterPublicKey, cfg.Ethereum.DerivationPath)
 			if err != nil {
 				log.Error(err)
 				os.Exit(-1)
@@ -207,6 +235,340 @@ var checkKeysCmd = &cobra.Command{
 	},
 }
 
+// defaultAddressesGapLimit is the number of consecutive addresses without
+// deposits after which `addresses` command stops when gap_limit is not set.
+const defaultAddressesGapLimit = 20
+
+var addressesCmd = &cobra.Command{
+	Use:   "addresses",
+	Short: "Lists derived addresses with their Stellar accounts and deposits",
+	Long: `Lists addresses derived using the master public key and derivation path of a
+chain, with the Stellar account each address was generated for and whether it
+received a deposit. Use it to audit generated addresses and check the keys.
+Without --count addresses are listed until gap limit (gap_limit in the config,
+20 by default) consecutive addresses without deposits are found, the same way
+wallet software looks for funds when restoring keys.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		cfgPath := rootCmd.PersistentFlags().Lookup("config").Value.String()
+		chain, _ := cmd.PersistentFlags().GetString("chain")
+		start, _ := cmd.PersistentFlags().GetUint32("start")
+		count, _ := cmd.PersistentFlags().GetUint32("count")
+		cfg := readConfig(cfgPath)
+
+		var derive func(index uint32) (string, error)
+		gapLimit := uint32(defaultAddressesGapLimit)
+
+		switch database.Chain(chain) {
+		case database.ChainBitcoin:
+			if cfg.Bitcoin == nil {
+				log.Error("Bitcoin is not configured")
+				os.Exit(-1)
+			}
+
+			chainParams := &chaincfg.MainNetParams
+			if cfg.Bitcoin.Testnet {
+				chainParams = &chaincfg.TestNet3Params
+			}
+
+			generator, err := bitcoin.NewAddressGenerator(cfg.Bitcoin.MasterPublicKey, cfg.Bitcoin.DerivationPath, chainParams)
+			if err != nil {
+				log.Error(err)
+				os.Exit(-1)
+			}
+			derive = generator.Generate
+			if cfg.Bitcoin.GapLimit > 0 {
+				gapLimit = cfg.Bitcoin.GapLimit
+			}
+		case database.ChainEthereum:
+			if cfg.Ethereum == nil {
+				log.Error("Ethereum is not configured")
+				os.Exit(-1)
+			}
+
+			generator, err := ethereum.NewAddressGenerator(cfg.Ethereum.MasterPublicKey, cfg.Ethereum.DerivationPath)
+			if err != nil {
+				log.Error(err)
+				os.Exit(-1)
+			}
+			derive = generator.Generate
+			if cfg.Ethereum.GapLimit > 0 {
+				gapLimit = cfg.Ethereum.GapLimit
+			}
+		default:
+			log.Error("--chain must be bitcoin or ethereum")
+			os.Exit(-1)
+		}
+
+		db, err := createDatabase(cfg.Database.Type, cfg.Database.DSN)
+		if err != nil {
+			log.WithField("err", err).Error("Error connecting to database")
+			os.Exit(-1)
+		}
+
+		fmt.Printf("%-6s %-42s %-5s %s\n", "INDEX", "ADDRESS", "USED", "STELLAR_ACCOUNT")
+		unused := uint32(0)
+		for index := start; ; index++ {
+			if count > 0 && index >= start+count {
+				break
+			}
+
+			if count == 0 && unused >= gapLimit {
+				fmt.Printf("Found %d consecutive addresses without deposits\n", gapLimit)
+				break
+			}
+
+			address, err := derive(index)
+			if err != nil {
+				fmt.Println("Error generating address", index)
+				continue
+			}
+
+			association, err := db.GetAssociationByChainAddress(database.Chain(chain), address)
+			if err != nil {
+				log.WithField("err", err).Error("Error getting address association")
+				os.Exit(-1)
+			}
+
+			used, err := db.IsAddressUsed(database.Chain(chain), address)
+			if err != nil {
+				log.WithField("err", err).Error("Error checking address deposits")
+				os.Exit(-1)
+			}
+
+			stellarPublicKey := "-"
+			if association != nil {
+				stellarPublicKey = association.StellarPublicKey
+			}
+
+			if used {
+				unused = 0
+			} else {
+				unused++
+			}
+
+			fmt.Printf("%-6d %-42s %-5t %s\n", index, address, used, stellarPublicKey)
+		}
+	},
+}
+
+var dustReportCmd = &cobra.Command{
+	Use:   "dust-report",
+	Short: "Displays number and total value of payments below minimum value",
+	Run: func(cmd *cobra.Command, args []string) {
+		cfgPath := rootCmd.PersistentFlags().Lookup("config").Value.String()
+		cfg := readConfig(cfgPath)
+
+		db, err := createDatabase(cfg.Database.Type, cfg.Database.DSN)
+		if err != nil {
+			log.WithField("err", err).Error("Error connecting to database")
+			os.Exit(-1)
+		}
+
+		report, err := db.GetDustReport()
+		if err != nil {
+			log.WithField("err", err).Error("Error getting dust report")
+			os.Exit(-1)
+		}
+
+		if len(report) == 0 {
+			fmt.Println("No dust payments...")
+			return
+		}
+
+		fmt.Printf("%-10s %-12s %-15s %8s %s\n", "CHAIN", "ASSET", "STATE", "COUNT", "AMOUNT")
+		for _, row := range report {
+			value, ok := new(big.Int).SetString(row.Value, 10)
+			if !ok {
+				log.WithField("value", row.Value).Error("Invalid dust value")
+				os.Exit(-1)
+			}
+
+			fmt.Printf(
+				"%-10s %-12s %-15s %8d %s\n",
+				row.Chain, row.AssetCode, row.State, row.Count, chains.ToStellarAmount(value, row.Decimals),
+			)
+		}
+	},
+}
+
+var withdrawalsCmd = &cobra.Command{
+	Use:   "withdrawals",
+	Short: "Manages withdrawals",
+}
+
+var withdrawalsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Displays withdrawals in a given state (pending_approval by default)",
+	Run: func(cmd *cobra.Command, args []string) {
+		cfgPath := rootCmd.PersistentFlags().Lookup("config").Value.String()
+		state, _ := cmd.PersistentFlags().GetString("state")
+		cfg := readConfig(cfgPath)
+
+		db, err := createDatabase(cfg.Database.Type, cfg.Database.DSN)
+		if err != nil {
+			log.WithField("err", err).Error("Error connecting to database")
+			os.Exit(-1)
+		}
+
+		withdrawals, err := db.GetWithdrawals(database.WithdrawalState(state))
+		if err != nil {
+			log.WithField("err", err).Error("Error getting withdrawals")
+			os.Exit(-1)
+		}
+
+		if len(withdrawals) == 0 {
+			fmt.Println("No withdrawals...")
+			return
+		}
+
+		fmt.Printf("%-20s %-10s %-12s %-20s %-42s %s\n", "PAYMENT ID", "CHAIN", "ASSET", "AMOUNT", "ADDRESS", "CHAIN TRANSACTION / ERROR")
+		for _, withdrawal := range withdrawals {
+			fmt.Printf(
+				"%-20s %-10s %-12s %-20s %-42s %s%s\n",
+				withdrawal.PaymentID, withdrawal.Chain, withdrawal.AssetCode, withdrawal.Amount,
+				withdrawal.Address, withdrawal.ChainTransactionID, withdrawal.Error,
+			)
+		}
+	},
+}
+
+var withdrawalsApproveCmd = &cobra.Command{
+	Use:   "approve [payment ID]...",
+	Short: "Approves withdrawals pending approval",
+	Run: func(cmd *cobra.Command, args []string) {
+		cfgPath := rootCmd.PersistentFlags().Lookup("config").Value.String()
+		cfg := readConfig(cfgPath)
+
+		if len(args) == 0 {
+			log.Error("At least one payment ID is required")
+			os.Exit(-1)
+		}
+
+		db, err := createDatabase(cfg.Database.Type, cfg.Database.DSN)
+		if err != nil {
+			log.WithField("err", err).Error("Error connecting to database")
+			os.Exit(-1)
+		}
+
+		for _, paymentID := range args {
+			approved, err := db.ApproveWithdrawal(paymentID)
+			if err != nil {
+				log.WithFields(log.F{"err": err, "payment_id": paymentID}).Error("Error approving withdrawal")
+				os.Exit(-1)
+			}
+
+			if approved {
+				fmt.Println("Approved", paymentID)
+			} else {
+				fmt.Println("Not pending approval", paymentID)
+			}
+		}
+	},
+}
+
+var refundsCmd = &cobra.Command{
+	Use:   "refunds",
+	Short: "Manages refunds of deposits that can't be issued",
+}
+
+var refundsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "Displays refunds in a given state (pending_approval by default)",
+	Run: func(cmd *cobra.Command, args []string) {
+		cfgPath := rootCmd.PersistentFlags().Lookup("config").Value.String()
+		state, _ := cmd.PersistentFlags().GetString("state")
+		cfg := readConfig(cfgPath)
+
+		db, err := createDatabase(cfg.Database.Type, cfg.Database.DSN)
+		if err != nil {
+			log.WithField("err", err).Error("Error connecting to database")
+			os.Exit(-1)
+		}
+
+		refunds, err := db.GetRefunds(database.RefundState(state))
+		if err != nil {
+			log.WithField("err", err).Error("Error getting refunds")
+			os.Exit(-1)
+		}
+
+		if len(refunds) == 0 {
+			fmt.Println("No refunds...")
+			return
+		}
+
+		fmt.Printf("%-66s %-10s %-12s %-20s %-42s %s\n", "TRANSACTION ID", "CHAIN", "ASSET", "AMOUNT", "ADDRESS", "CHAIN TRANSACTION / ERROR")
+		for _, refund := range refunds {
+			fmt.Printf(
+				"%-66s %-10s %-12s %-20s %-42s %s%s\n",
+				refund.TransactionID, refund.Chain, refund.AssetCode, refund.Amount,
+				refund.Address, refund.ChainTransactionID, refund.Error,
+			)
+		}
+	},
+}
+
+var refundsApproveCmd = &cobra.Command{
+	Use:   "approve [transaction ID]...",
+	Short: "Approves refunds pending approval or failed refunds",
+	Run: func(cmd *cobra.Command, args []string) {
+		cfgPath := rootCmd.PersistentFlags().Lookup("config").Value.String()
+		cfg := readConfig(cfgPath)
+
+		if len(args) == 0 {
+			log.Error("At least one transaction ID is required")
+			os.Exit(-1)
+		}
+
+		db, err := createDatabase(cfg.Database.Type, cfg.Database.DSN)
+		if err != nil {
+			log.WithField("err", err).Error("Error connecting to database")
+			os.Exit(-1)
+		}
+
+		for _, transactionID := range args {
+			approved, err := db.ApproveRefund(transactionID)
+			if err != nil {
+				log.WithFields(log.F{"err": err, "transaction_id": transactionID}).Error("Error approving refund")
+				os.Exit(-1)
+			}
+
+			if approved {
+				fmt.Println("Approved", transactionID)
+			} else {
+				fmt.Println("Not pending approval or failed", transactionID)
+			}
+		}
+	},
+}
+
+var resyncCmd = &cobra.Command{
+	Use:   "resync",
+	Short: "Rescans blocks and processes deposits missed during downtime",
+	Long: `Rescans blocks of a chain for transactions to generated addresses and adds
+the ones not processed yet to the transactions queue. Already processed transactions
+are skipped so it's safe to rescan the same blocks many times. Queued transactions
+are sent to Stellar by the running server.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		cfgPath := rootCmd.PersistentFlags().Lookup("config").Value.String()
+		chain, _ := cmd.PersistentFlags().GetString("chain")
+		fromBlock, _ := cmd.PersistentFlags().GetUint64("from-block")
+		toBlock, _ := cmd.PersistentFlags().GetUint64("to-block")
+		cfg := readConfig(cfgPath)
+
+		if chain == "" || !cmd.PersistentFlags().Lookup("from-block").Changed || !cmd.PersistentFlags().Lookup("to-block").Changed {
+			log.Error("--chain, --from-block and --to-block are required")
+			os.Exit(-1)
+		}
+
+		server := createServer(cfg, false)
+		err := server.Resync(database.Chain(chain), fromBlock, toBlock)
+		if err != nil {
+			log.WithField("err", err).Error("Error resyncing blocks")
+			os.Exit(-1)
+		}
+	},
+}
+
 var versionCmd = &cobra.Command{
 	Use:   "version",
 	Short: "Print the version number",
@@ -223,15 +585,39 @@ func init() {
 	rootCmd.PersistentFlags().Bool("debug", false, "debug mode")
 	rootCmd.PersistentFlags().StringP("config", "c", "bifrost.cfg", "config file path")
 
+	rootCmd.AddCommand(addressesCmd)
+	rootCmd.AddCommand(checkConfigCmd)
 	rootCmd.AddCommand(checkKeysCmd)
+	rootCmd.AddCommand(dustReportCmd)
+	rootCmd.AddCommand(refundsCmd)
+	rootCmd.AddCommand(resyncCmd)
 	rootCmd.AddCommand(serverCmd)
 	rootCmd.AddCommand(stressTestCmd)
 	rootCmd.AddCommand(versionCmd)
+	rootCmd.AddCommand(withdrawalsCmd)
+
+	withdrawalsCmd.AddCommand(withdrawalsApproveCmd)
+	withdrawalsCmd.AddCommand(withdrawalsListCmd)
+
+	refundsCmd.AddCommand(refundsApproveCmd)
+	refundsCmd.AddCommand(refundsListCmd)
 
 	stressTestCmd.PersistentFlags().IntP("users-per-second", "u", 2, "users per second")
 
+	addressesCmd.PersistentFlags().String("chain", "", "chain of addresses (bitcoin or ethereum)")
+	addressesCmd.PersistentFlags().Uint32P("start", "s", 0, "starting address index")
+	addressesCmd.PersistentFlags().Uint32P("count", "l", 0, "how many addresses list (0 lists until gap limit is reached)")
+
 	checkKeysCmd.PersistentFlags().Uint32P("start", "s", 0, "starting address index")
 	checkKeysCmd.PersistentFlags().Uint32P("count", "l", 10, "how many addresses generate")
+
+	resyncCmd.PersistentFlags().String("chain", "", "chain to rescan (bitcoin or ethereum)")
+	resyncCmd.PersistentFlags().Uint64("from-block", 0, "first block to rescan")
+	resyncCmd.PersistentFlags().Uint64("to-block", 0, "last block to rescan")
+
+	withdrawalsListCmd.PersistentFlags().StringP("state", "s", string(database.WithdrawalStatePendingApproval), "withdrawals state")
+
+	refundsListCmd.PersistentFlags().StringP("state", "s", string(database.RefundStatePendingApproval), "refunds state")
 }
 
 func main() {
@@ -242,10 +628,16 @@ func readConfig(cfgPath string) config.Config {
 	var cfg config.Config
 
 	err := supportConfig.Read(cfgPath, &cfg)
+	if err == nil {
+		err = cfg.Validate()
+	}
 	if err != nil {
 		switch cause := errors.Cause(err).(type) {
 		case *supportConfig.InvalidConfigError:
 			log.Error("config file: ", cause)
+			for field, problem := range cause.InvalidFields {
+				log.Errorf("  %s: %s", field, problem)
+			}
 		default:
 			log.Error(err)
 		}
@@ -258,115 +650,91 @@ func readConfig(cfgPath string) config.Config {
 	return cfg
 }
 
-func createDatabase(dsn string) (*database.PostgresDatabase, error) {
-	db := &database.PostgresDatabase{}
-	err := db.Open(dsn)
+func createDatabase(dbType, dsn string) (*database.SQLDatabase, error) {
+	if dsn == "" && dbType != database.TypeMemory {
+		return nil, errors.New("Database DSN is required")
+	}
+
+	db := &database.SQLDatabase{}
+	err := db.Open(dbType, dsn)
 	if err != nil {
 		return nil, err
 	}
 	return db, err
 }
 
+func createPriceOracle(cfg config.PriceConfig) (price.Oracle, error) {
+	switch cfg.Oracle {
+	case "static":
+		return price.NewStaticOracle(cfg.Rates)
+	case "http":
+		if cfg.URL == "" {
+			return nil, errors.New("Price feed URL is required")
+		}
+
+		oracle := &price.HTTPOracle{URL: cfg.URL}
+		if cfg.CacheTTL != "" {
+			var err error
+			oracle.CacheTTL, err = time.ParseDuration(cfg.CacheTTL)
+			if err != nil {
+				return nil, errors.Wrap(err, "Invalid cache_ttl")
+			}
+		}
+		return oracle, nil
+	default:
+		return nil, errors.New("Invalid price oracle: " + cfg.Oracle)
+	}
+}
+
 func createServer(cfg config.Config, stressTest bool) *server.Server {
 	var g inject.Graph
 
-	db, err := createDatabase(cfg.Database.DSN)
+	db, err := createDatabase(cfg.Database.Type, cfg.Database.DSN)
 	if err != nil {
 		log.WithField("err", err).Error("Error connecting to database")
 		os.Exit(-1)
 	}
 
-	server := &server.Server{}
-
-	bitcoinClient := &rpcclient.Client{}
-	bitcoinListener := &bitcoin.Listener{}
-	bitcoinAddressGenerator := &bitcoin.AddressGenerator{}
-
-	ethereumClient := &ethclient.Client{}
-	ethereumListener := &ethereum.Listener{}
-	ethereumAddressGenerator := &ethereum.AddressGenerator{}
-
-	if !stressTest {
-		// Configure real clients
+	if stressTest {
+		// Random transactions generators use testnets
 		if cfg.Bitcoin != nil {
-			connConfig := &rpcclient.ConnConfig{
-				Host:         cfg.Bitcoin.RpcServer,
-				User:         cfg.Bitcoin.RpcUser,
-				Pass:         cfg.Bitcoin.RpcPass,
-				HTTPPostMode: true,
-				DisableTLS:   true,
-			}
-			bitcoinClient, err = rpcclient.New(connConfig, nil)
-			if err != nil {
-				log.WithField("err", err).Error("Error connecting to bitcoin-core")
-				os.Exit(-1)
-			}
-
-			bitcoinListener.Enabled = true
-			bitcoinListener.Testnet = cfg.Bitcoin.Testnet
-			server.MinimumValueBtc = cfg.Bitcoin.MinimumValueBtc
-
-			var chainParams *chaincfg.Params
-			if cfg.Bitcoin.Testnet {
-				chainParams = &chaincfg.TestNet3Params
-			} else {
-				chainParams = &chaincfg.MainNetParams
-			}
-			bitcoinAddressGenerator, err = bitcoin.NewAddressGenerator(cfg.Bitcoin.MasterPublicKey, chainParams)
-			if err != nil {
-				log.Error(err)
-				os.Exit(-1)
-			}
+			cfg.Bitcoin.Testnet = true
 		}
-
 		if cfg.Ethereum != nil {
-			ethereumClient, err = ethclient.Dial("http://" + cfg.Ethereum.RpcServer)
-			if err != nil {
-				log.WithField("err", err).Error("Error connecting to geth")
-				os.Exit(-1)
-			}
-
-			ethereumListener.Enabled = true
-			ethereumListener.NetworkID = cfg.Ethereum.NetworkID
-			server.MinimumValueEth = cfg.Ethereum.MinimumValueEth
-
-			ethereumAddressGenerator, err = ethereum.NewAddressGenerator(cfg.Ethereum.MasterPublicKey)
-			if err != nil {
-				log.Error(err)
-				os.Exit(-1)
-			}
-		}
-	} else {
-		bitcoinListener.Enabled = true
-		bitcoinListener.Testnet = true
-		bitcoinAddressGenerator, err = bitcoin.NewAddressGenerator(cfg.Bitcoin.MasterPublicKey, &chaincfg.TestNet3Params)
-		if err != nil {
-			log.Error(err)
-			os.Exit(-1)
+			cfg.Ethereum.NetworkID = "3"
 		}
+	}
 
-		ethereumListener.Enabled = true
-		ethereumListener.NetworkID = "3"
-		ethereumAddressGenerator, err = ethereum.NewAddressGenerator(cfg.Ethereum.MasterPublicKey)
-		if err != nil {
-			log.Error(err)
-			os.Exit(-1)
-		}
+	adapters, err := chains.New(&cfg, db)
+	if err != nil {
+		log.WithField("err", err).Error("Error creating chain adapters")
+		os.Exit(-1)
 	}
 
+	server := &server.Server{Adapters: adapters}
+
 	stellarAccountConfigurator := &stellar.AccountConfigurator{
-		NetworkPassphrase: cfg.Stellar.NetworkPassphrase,
-		IssuerPublicKey:   cfg.Stellar.IssuerPublicKey,
-		SignerSecretKey:   cfg.Stellar.SignerSecretKey,
-		NeedsAuthorize:    cfg.Stellar.NeedsAuthorize,
-		TokenAssetCode:    cfg.Stellar.TokenAssetCode,
-		StartingBalance:   cfg.Stellar.StartingBalance,
+		NetworkPassphrase:   cfg.Stellar.NetworkPassphrase,
+		IssuerPublicKey:     cfg.Stellar.IssuerPublicKey,
+		SignerSecretKey:     cfg.Stellar.SignerSecretKey,
+		NeedsAuthorize:      cfg.Stellar.NeedsAuthorize,
+		TokenAssetCode:      cfg.Stellar.TokenAssetCode,
+		StartingBalance:     cfg.Stellar.StartingBalance,
+		SkipAccountCreation: cfg.Stellar.SkipAccountCreation,
 	}
 
 	if cfg.Stellar.StartingBalance == "" {
 		stellarAccountConfigurator.StartingBalance = "41"
 	}
 
+	if cfg.Stellar.TrustlineTimeout != "" {
+		stellarAccountConfigurator.TrustlineTimeout, err = time.ParseDuration(cfg.Stellar.TrustlineTimeout)
+		if err != nil {
+			log.WithField("err", err).Error("Invalid trustline_timeout")
+			os.Exit(-1)
+		}
+	}
+
 	horizonClient := &horizon.Client{
 		URL: cfg.Stellar.Horizon,
 		HTTP: &http.Client{
@@ -376,20 +744,56 @@ func createServer(cfg config.Config, stressTest bool) *server.Server {
 
 	sseServer := &sse.Server{}
 
-	err = g.Provide(
-		&inject.Object{Value: bitcoinAddressGenerator},
-		&inject.Object{Value: bitcoinClient},
-		&inject.Object{Value: bitcoinListener},
-		&inject.Object{Value: &cfg},
-		&inject.Object{Value: db},
-		&inject.Object{Value: ethereumAddressGenerator},
-		&inject.Object{Value: ethereumClient},
-		&inject.Object{Value: ethereumListener},
-		&inject.Object{Value: horizonClient},
-		&inject.Object{Value: server},
-		&inject.Object{Value: sseServer},
-		&inject.Object{Value: stellarAccountConfigurator},
-	)
+	objects := []*inject.Object{
+		{Value: &cfg},
+		{Value: db},
+		{Value: horizonClient},
+		{Value: server},
+		{Value: sseServer},
+		{Value: stellarAccountConfigurator},
+	}
+
+	if cfg.Withdrawals != nil {
+		server.WithdrawalListener = &stellar.WithdrawalListener{
+			IssuerPublicKey: cfg.Stellar.IssuerPublicKey,
+		}
+		objects = append(objects, &inject.Object{Value: server.WithdrawalListener})
+	}
+
+	if cfg.Webhooks != nil {
+		notifier := &webhooks.Notifier{
+			URLs:        cfg.Webhooks.URLs,
+			Secret:      cfg.Webhooks.Secret,
+			MaxAttempts: cfg.Webhooks.MaxAttempts,
+		}
+		server.Webhooks = notifier
+		objects = append(objects, &inject.Object{Value: notifier})
+	}
+
+	if cfg.Price != nil {
+		server.PriceOracle, err = createPriceOracle(*cfg.Price)
+		if err != nil {
+			log.WithField("err", err).Error("Error creating price oracle")
+			os.Exit(-1)
+		}
+	}
+
+	if cfg.KYC != nil {
+		server.KYC = &kyc.HTTPApprover{
+			URL:    cfg.KYC.URL,
+			Secret: cfg.KYC.Secret,
+		}
+	}
+
+	if cfg.Cluster != nil {
+		server.Cluster = &cluster.Coordinator{
+			Shards:    cfg.Cluster.Shards,
+			MaxShards: cfg.Cluster.MaxShards,
+		}
+		objects = append(objects, &inject.Object{Value: server.Cluster})
+	}
+
+	err = g.Provide(objects...)
 	if err != nil {
 		log.WithField("err", err).Error("Error providing objects to injector")
 		os.Exit(-1)
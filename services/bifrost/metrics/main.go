@@ -0,0 +1,157 @@
+// Package metrics collects Bifrost metrics and serves them in the Prometheus
+// text exposition format so operators can alert on a stalled bridge.
+package metrics
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	gometrics "github.com/rcrowley/go-metrics"
+	"github.com/stellar/go/services/bifrost/database"
+)
+
+var (
+	transactionsQueued    = gometrics.NewCounter()
+	transactionsDelivered = gometrics.NewCounter()
+	transactionsFailed    = gometrics.NewCounter()
+	stellarSubmissions    = gometrics.NewTimer()
+
+	chainsMutex sync.Mutex
+	chains      = map[database.Chain]*chainMetrics{}
+)
+
+var prometheusQuantiles = []float64{0.5, 0.75, 0.95, 0.99, 0.999}
+
+type chainMetrics struct {
+	blocksProcessed    gometrics.Counter
+	lastProcessedBlock gometrics.Gauge
+	headBlock          gometrics.Gauge
+	addressesGenerated gometrics.Counter
+}
+
+func forChain(chain database.Chain) *chainMetrics {
+	chainsMutex.Lock()
+	defer chainsMutex.Unlock()
+
+	metrics, exists := chains[chain]
+	if !exists {
+		metrics = &chainMetrics{
+			blocksProcessed:    gometrics.NewCounter(),
+			lastProcessedBlock: gometrics.NewGauge(),
+			headBlock:          gometrics.NewGauge(),
+			addressesGenerated: gometrics.NewCounter(),
+		}
+		chains[chain] = metrics
+	}
+	return metrics
+}
+
+// BlockProcessed records that all transactions of `chain` block have been processed.
+func BlockProcessed(chain database.Chain, blockNumber uint64) {
+	metrics := forChain(chain)
+	metrics.blocksProcessed.Inc(1)
+	metrics.lastProcessedBlock.Update(int64(blockNumber))
+}
+
+// ChainHead records the number of the latest block of `chain` seen in nodes.
+func ChainHead(chain database.Chain, blockNumber uint64) {
+	forChain(chain).headBlock.Update(int64(blockNumber))
+}
+
+// AddressGenerated records a new `chain` address.
+func AddressGenerated(chain database.Chain) {
+	forChain(chain).addressesGenerated.Inc(1)
+}
+
+// TransactionQueued records a transaction added to the transactions queue.
+func TransactionQueued() {
+	transactionsQueued.Inc(1)
+}
+
+// TransactionDelivered records a transaction with asset sent to Stellar account.
+func TransactionDelivered() {
+	transactionsDelivered.Inc(1)
+}
+
+// TransactionFailed records a transaction with failed issuance.
+func TransactionFailed() {
+	transactionsFailed.Inc(1)
+}
+
+// StellarSubmission records the duration of Stellar transaction submission
+// started at `start`.
+func StellarSubmission(start time.Time) {
+	stellarSubmissions.UpdateSince(start)
+}
+
+// Handler serves metrics in the Prometheus text exposition format.
+func Handler(w http.ResponseWriter, r *http.Request) {
+	var buf bytes.Buffer
+
+	chainsMutex.Lock()
+	names := []string{}
+	for chain := range chains {
+		names = append(names, string(chain))
+	}
+	chainsMutex.Unlock()
+	sort.Strings(names)
+
+	writeChainMetric(&buf, "bifrost_blocks_processed_total", "counter", names, func(m *chainMetrics) int64 {
+		return m.blocksProcessed.Count()
+	})
+	writeChainMetric(&buf, "bifrost_last_processed_block", "gauge", names, func(m *chainMetrics) int64 {
+		return m.lastProcessedBlock.Value()
+	})
+	writeChainMetric(&buf, "bifrost_chain_head_block", "gauge", names, func(m *chainMetrics) int64 {
+		return m.headBlock.Value()
+	})
+	writeChainMetric(&buf, "bifrost_chain_head_lag_blocks", "gauge", names, func(m *chainMetrics) int64 {
+		head, last := m.headBlock.Value(), m.lastProcessedBlock.Value()
+		// Unknown before the first block is processed and the head is checked
+		if head == 0 || last == 0 || head < last {
+			return 0
+		}
+		return head - last
+	})
+	writeChainMetric(&buf, "bifrost_addresses_generated_total", "counter", names, func(m *chainMetrics) int64 {
+		return m.addressesGenerated.Count()
+	})
+
+	writeCounter(&buf, "bifrost_transactions_queued_total", transactionsQueued)
+	writeCounter(&buf, "bifrost_transactions_delivered_total", transactionsDelivered)
+	writeCounter(&buf, "bifrost_transactions_failed_total", transactionsFailed)
+	writeTimer(&buf, "bifrost_stellar_submission_duration_seconds", stellarSubmissions)
+
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	w.Write(buf.Bytes())
+}
+
+// writeChainMetric writes the value of metric of each chain labeled by chain name.
+func writeChainMetric(buf *bytes.Buffer, name, metricType string, names []string, value func(*chainMetrics) int64) {
+	fmt.Fprintf(buf, "# TYPE %s %s\n", name, metricType)
+	for _, chain := range names {
+		fmt.Fprintf(buf, "%s{chain=%q} %d\n", name, chain, value(forChain(database.Chain(chain))))
+	}
+}
+
+func writeCounter(buf *bytes.Buffer, name string, counter gometrics.Counter) {
+	fmt.Fprintf(buf, "# TYPE %s counter\n", name)
+	fmt.Fprintf(buf, "%s %d\n", name, counter.Count())
+}
+
+// writeTimer writes the samples of timer, in seconds, as a summary.
+func writeTimer(buf *bytes.Buffer, name string, timer gometrics.Timer) {
+	t := timer.Snapshot()
+	ps := t.Percentiles(prometheusQuantiles)
+
+	fmt.Fprintf(buf, "# TYPE %s summary\n", name)
+	for i, q := range prometheusQuantiles {
+		fmt.Fprintf(buf, "%s{quantile=\"%g\"} %g\n", name, q, ps[i]/float64(time.Second))
+	}
+	fmt.Fprintf(buf, "%s_sum %g\n", name, float64(t.Sum())/float64(time.Second))
+	fmt.Fprintf(buf, "%s_count %d\n", name, t.Count())
+}
@@ -0,0 +1,37 @@
+package metrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stellar/go/services/bifrost/database"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandler(t *testing.T) {
+	BlockProcessed(database.ChainBitcoin, 100)
+	BlockProcessed(database.ChainBitcoin, 101)
+	ChainHead(database.ChainBitcoin, 104)
+	AddressGenerated(database.ChainEthereum)
+	TransactionQueued()
+	TransactionFailed()
+	StellarSubmission(time.Now().Add(-time.Second))
+
+	recorder := httptest.NewRecorder()
+	Handler(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	body := recorder.Body.String()
+	assert.Contains(t, body, "# TYPE bifrost_blocks_processed_total counter\n")
+	assert.Contains(t, body, "bifrost_blocks_processed_total{chain=\"bitcoin\"} 2\n")
+	assert.Contains(t, body, "bifrost_last_processed_block{chain=\"bitcoin\"} 101\n")
+	assert.Contains(t, body, "bifrost_chain_head_lag_blocks{chain=\"bitcoin\"} 3\n")
+	// Head and processed block unknown
+	assert.Contains(t, body, "bifrost_chain_head_lag_blocks{chain=\"ethereum\"} 0\n")
+	assert.Contains(t, body, "bifrost_addresses_generated_total{chain=\"ethereum\"} 1\n")
+	assert.Contains(t, body, "bifrost_transactions_queued_total 1\n")
+	assert.Contains(t, body, "bifrost_transactions_delivered_total 0\n")
+	assert.Contains(t, body, "bifrost_transactions_failed_total 1\n")
+	assert.Contains(t, body, "bifrost_stellar_submission_duration_seconds_count 1\n")
+}
@@ -0,0 +1,71 @@
+package price
+
+import (
+	"math/big"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/stellar/go/services/bifrost/common"
+	"github.com/stellar/go/services/bifrost/queue"
+	"github.com/stellar/go/support/errors"
+)
+
+// DefaultCacheTTL is the default time rates fetched by HTTPOracle are used for.
+const DefaultCacheTTL = time.Minute
+
+// Oracle returns exchange rates used to compute the amount of Stellar asset
+// issued for deposits at deposit time.
+type Oracle interface {
+	// Rate returns the amount of Stellar asset issued per unit of deposited
+	// `assetCode`. It returns error if the rate is not known.
+	Rate(assetCode queue.AssetCode) (*big.Rat, error)
+}
+
+// StaticOracle returns rates from the config.
+type StaticOracle struct {
+	Rates map[queue.AssetCode]*big.Rat
+}
+
+// HTTPOracle fetches rates from a price feed at URL. The feed must return a
+// JSON object with decimal rates of asset codes as strings, ex.
+// `{"BTC": "10000.5", "ETH": "500"}`. Rates are cached for CacheTTL.
+type HTTPOracle struct {
+	URL      string
+	CacheTTL time.Duration
+	HTTP     HTTPClient
+
+	mutex     sync.Mutex
+	rates     map[queue.AssetCode]*big.Rat
+	fetchedAt time.Time
+}
+
+type HTTPClient interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// Convert returns `amount` (Stellar amount) multiplied by `rate`. The result is
+// truncated to Stellar precision so more than `rate` is never issued.
+func Convert(amount string, rate *big.Rat) (string, error) {
+	value, ok := new(big.Rat).SetString(amount)
+	if !ok {
+		return "", errors.New("Invalid amount: " + amount)
+	}
+
+	value.Mul(value, rate)
+
+	unit := new(big.Int).Exp(big.NewInt(10), big.NewInt(common.StellarAmountPrecision), nil)
+	units := new(big.Int).Mul(value.Num(), unit)
+	units.Quo(units, value.Denom())
+
+	return new(big.Rat).SetFrac(units, unit).FloatString(common.StellarAmountPrecision), nil
+}
+
+// parseRate parses a positive decimal rate.
+func parseRate(assetCode, rate string) (*big.Rat, error) {
+	value, ok := new(big.Rat).SetString(rate)
+	if !ok || value.Sign() <= 0 {
+		return nil, errors.Errorf("Invalid %s rate: %s", assetCode, rate)
+	}
+	return value, nil
+}
@@ -0,0 +1,86 @@
+package price
+
+import (
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stellar/go/services/bifrost/queue"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConvert(t *testing.T) {
+	tests := []struct {
+		amount   string
+		rate     string
+		expected string
+	}{
+		{"1.0000000", "1", "1.0000000"},
+		{"1.5000000", "10000", "15000.0000000"},
+		{"0.0000001", "0.5", "0.0000000"},
+		// Truncated
+		{"1.0000000", "0.33333339", "0.3333333"},
+	}
+
+	for _, test := range tests {
+		rate, _ := new(big.Rat).SetString(test.rate)
+		amount, err := Convert(test.amount, rate)
+		require.NoError(t, err)
+		assert.Equal(t, test.expected, amount)
+	}
+
+	_, err := Convert("invalid", big.NewRat(1, 1))
+	assert.Error(t, err)
+}
+
+func TestStaticOracle(t *testing.T) {
+	oracle, err := NewStaticOracle(map[string]string{"BTC": "10000", "ETH": "500.5"})
+	require.NoError(t, err)
+
+	rate, err := oracle.Rate(queue.AssetCodeETH)
+	require.NoError(t, err)
+	assert.Equal(t, "500.5000000", rate.FloatString(7))
+
+	_, err = oracle.Rate("OMG")
+	assert.Error(t, err)
+
+	_, err = NewStaticOracle(map[string]string{"BTC": "-1"})
+	assert.Error(t, err)
+}
+
+func TestHTTPOracle(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Write([]byte(`{"BTC": "10000.5", "ETH": "500"}`))
+	}))
+	defer server.Close()
+
+	oracle := &HTTPOracle{URL: server.URL}
+
+	rate, err := oracle.Rate(queue.AssetCodeBTC)
+	require.NoError(t, err)
+	assert.Equal(t, "10000.5000000", rate.FloatString(7))
+
+	// Cached
+	rate, err = oracle.Rate(queue.AssetCodeETH)
+	require.NoError(t, err)
+	assert.Equal(t, "500.0000000", rate.FloatString(7))
+	assert.Equal(t, 1, requests)
+
+	_, err = oracle.Rate("OMG")
+	assert.Error(t, err)
+}
+
+func TestHTTPOracleError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	oracle := &HTTPOracle{URL: server.URL}
+	_, err := oracle.Rate(queue.AssetCodeBTC)
+	assert.Error(t, err)
+}
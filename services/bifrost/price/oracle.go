@@ -0,0 +1,96 @@
+package price
+
+import (
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"time"
+
+	"github.com/stellar/go/services/bifrost/queue"
+	"github.com/stellar/go/support/errors"
+)
+
+// NewStaticOracle creates StaticOracle from decimal `rates` of asset codes.
+func NewStaticOracle(rates map[string]string) (*StaticOracle, error) {
+	oracle := &StaticOracle{Rates: map[queue.AssetCode]*big.Rat{}}
+	for assetCode, rate := range rates {
+		value, err := parseRate(assetCode, rate)
+		if err != nil {
+			return nil, err
+		}
+		oracle.Rates[queue.AssetCode(assetCode)] = value
+	}
+	return oracle, nil
+}
+
+func (o *StaticOracle) Rate(assetCode queue.AssetCode) (*big.Rat, error) {
+	rate, exists := o.Rates[assetCode]
+	if !exists {
+		return nil, errors.New("No rate for asset: " + string(assetCode))
+	}
+	return rate, nil
+}
+
+func (o *HTTPOracle) Rate(assetCode queue.AssetCode) (*big.Rat, error) {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	cacheTTL := o.CacheTTL
+	if cacheTTL == 0 {
+		cacheTTL = DefaultCacheTTL
+	}
+
+	if o.rates == nil || time.Since(o.fetchedAt) > cacheTTL {
+		rates, err := o.fetchRates()
+		if err != nil {
+			return nil, errors.Wrap(err, "Error fetching rates")
+		}
+		o.rates = rates
+		o.fetchedAt = time.Now()
+	}
+
+	rate, exists := o.rates[assetCode]
+	if !exists {
+		return nil, errors.New("No rate for asset: " + string(assetCode))
+	}
+	return rate, nil
+}
+
+func (o *HTTPOracle) fetchRates() (map[queue.AssetCode]*big.Rat, error) {
+	client := o.HTTP
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+
+	req, err := http.NewRequest(http.MethodGet, o.URL, nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error creating request")
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error sending request")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("Invalid response status: %d", resp.StatusCode)
+	}
+
+	var body map[string]string
+	err = json.NewDecoder(resp.Body).Decode(&body)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error decoding response")
+	}
+
+	rates := map[queue.AssetCode]*big.Rat{}
+	for assetCode, rate := range body {
+		value, err := parseRate(assetCode, rate)
+		if err != nil {
+			return nil, err
+		}
+		rates[queue.AssetCode(assetCode)] = value
+	}
+
+	return rates, nil
+}
@@ -1,5 +1,9 @@
 package queue
 
+import (
+	"hash/fnv"
+)
+
 type AssetCode string
 
 const (
@@ -34,4 +38,22 @@ type Queue interface {
 	QueuePool() (*Transaction, error)
 }
 
+// ShardedQueue is a Queue split into shards by Stellar account so transactions
+// of each shard can be pooled by a different Bifrost instance.
+type ShardedQueue interface {
+	Queue
+	// QueuePoolShards works like QueuePool but returns only transactions in
+	// `shards` when the queue is split into `total` shards. Transaction's shard
+	// is `ShardKey(StellarPublicKey) % total`.
+	QueuePoolShards(total int, shards []int) (*Transaction, error)
+}
+
+// ShardKey returns the key used to assign transactions to `stellarPublicKey`
+// to shards.
+func ShardKey(stellarPublicKey string) uint32 {
+	hash := fnv.New32a()
+	hash.Write([]byte(stellarPublicKey))
+	return hash.Sum32()
+}
+
 type SQSFiFo struct{}
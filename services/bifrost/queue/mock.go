@@ -18,3 +18,8 @@ func (m *MockQueue) QueuePool() (*Transaction, error) {
 	a := m.Called()
 	return a.Get(0).(*Transaction), a.Error(1)
 }
+
+func (m *MockQueue) QueuePoolShards(total int, shards []int) (*Transaction, error) {
+	a := m.Called(total, shards)
+	return a.Get(0).(*Transaction), a.Error(1)
+}
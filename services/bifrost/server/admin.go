@@ -0,0 +1,306 @@
+package server
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/stellar/go/services/bifrost/database"
+	"github.com/stellar/go/services/bifrost/queue"
+	"github.com/stellar/go/support/log"
+)
+
+const (
+	// adminDefaultLimit is the default number of records returned by admin API
+	// list endpoints.
+	adminDefaultLimit = 100
+	// adminMaxLimit is the maximum number of records returned by admin API list
+	// endpoints.
+	adminMaxLimit = 1000
+)
+
+type AdminAddress struct {
+	Chain            string    `json:"chain"`
+	Address          string    `json:"address"`
+	AddressIndex     uint32    `json:"address_index"`
+	StellarPublicKey string    `json:"stellar_public_key"`
+	CreatedAt        time.Time `json:"created_at"`
+}
+
+type AdminTransaction struct {
+	Chain            string    `json:"chain"`
+	TransactionID    string    `json:"transaction_id"`
+	Address          string    `json:"address"`
+	StellarPublicKey string    `json:"stellar_public_key"`
+	AssetCode        string    `json:"asset_code"`
+	Amount           string    `json:"amount"`
+	DepositAssetCode string    `json:"deposit_asset_code"`
+	DepositAmount    string    `json:"deposit_amount"`
+	State            string    `json:"state"`
+	Error            string    `json:"error"`
+	CreatedAt        time.Time `json:"created_at"`
+	UpdatedAt        time.Time `json:"updated_at"`
+}
+
+type AdminAddressesResponse struct {
+	Addresses []AdminAddress `json:"addresses"`
+}
+
+type AdminTransactionsResponse struct {
+	Transactions []AdminTransaction `json:"transactions"`
+}
+
+// AdminHandler returns `handler` authenticated with the admin token sent in
+// `Authorization: Bearer <token>` header.
+func (s *Server) AdminHandler(handler http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		authorization := r.Header.Get("Authorization")
+		if s.Config.Admin == nil || !strings.HasPrefix(authorization, "Bearer ") {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		token := strings.TrimPrefix(authorization, "Bearer ")
+		if subtle.ConstantTimeCompare([]byte(token), []byte(s.Config.Admin.Token)) != 1 {
+			log.WithField("ip", r.RemoteAddr).Warn("Invalid admin token")
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		handler(w, r)
+	}
+}
+
+// HandlerAdminAddresses lists generated addresses, newest first.
+func (s *Server) HandlerAdminAddresses(w http.ResponseWriter, r *http.Request) {
+	limit, offset, ok := adminPage(r)
+	if !ok {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	associations, err := s.Database.ListAssociations(limit, offset)
+	if err != nil {
+		log.WithField("err", err).Error("Error listing address associations")
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	response := AdminAddressesResponse{Addresses: make([]AdminAddress, len(associations))}
+	for i, association := range associations {
+		response.Addresses[i] = AdminAddress{
+			Chain:            string(association.Chain),
+			Address:          association.Address,
+			AddressIndex:     association.AddressIndex,
+			StellarPublicKey: association.StellarPublicKey,
+			CreatedAt:        association.CreatedAt,
+		}
+	}
+
+	writeAdminResponse(w, response)
+}
+
+// HandlerAdminTransactions lists transactions added to the transactions queue
+// with the state of their issuance, newest first. Transactions can be filtered
+// by `state` query param.
+func (s *Server) HandlerAdminTransactions(w http.ResponseWriter, r *http.Request) {
+	limit, offset, ok := adminPage(r)
+	if !ok {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	state := database.IssuanceState(r.URL.Query().Get("state"))
+	switch state {
+	case "",
+		database.IssuanceStateQueued,
+		database.IssuanceStateDelivered,
+		database.IssuanceStateFailed,
+		database.IssuanceStateRefundPending,
+		database.IssuanceStateRejected,
+		database.IssuanceStateRefunded,
+		database.IssuanceStateResolved:
+	default:
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	issuances, err := s.Database.GetIssuances(state, limit, offset)
+	if err != nil {
+		log.WithField("err", err).Error("Error getting issuances")
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	response := AdminTransactionsResponse{Transactions: make([]AdminTransaction, len(issuances))}
+	for i, issuance := range issuances {
+		response.Transactions[i] = adminTransaction(issuance)
+	}
+
+	writeAdminResponse(w, response)
+}
+
+// HandlerAdminRetryTransaction retries failed, refund pending or rejected
+// issuance of `transaction_id` transaction.
+func (s *Server) HandlerAdminRetryTransaction(w http.ResponseWriter, r *http.Request) {
+	issuance, ok := s.adminIssuance(w, r)
+	if !ok {
+		return
+	}
+
+	retried, err := s.Database.RetryIssuance(issuance.TransactionID)
+	if err != nil {
+		log.WithField("err", err).Error("Error retrying issuance")
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if !retried {
+		w.WriteHeader(http.StatusConflict)
+		return
+	}
+
+	log.WithField("transactionID", issuance.TransactionID).Info("Retrying issuance")
+	go s.configureAccount(queue.Transaction{
+		TransactionID:    issuance.TransactionID,
+		AssetCode:        queue.AssetCode(issuance.AssetCode),
+		Amount:           issuance.Amount,
+		StellarPublicKey: issuance.StellarPublicKey,
+	})
+
+	issuance.State = database.IssuanceStateQueued
+	writeAdminResponse(w, adminTransaction(*issuance))
+}
+
+// HandlerAdminResolveTransaction marks failed, queued, refund pending or
+// rejected issuance of `transaction_id` transaction as resolved manually.
+func (s *Server) HandlerAdminResolveTransaction(w http.ResponseWriter, r *http.Request) {
+	issuance, ok := s.adminIssuance(w, r)
+	if !ok {
+		return
+	}
+
+	resolved, err := s.Database.ResolveIssuance(issuance.TransactionID)
+	if err != nil {
+		log.WithField("err", err).Error("Error resolving issuance")
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if !resolved {
+		w.WriteHeader(http.StatusConflict)
+		return
+	}
+
+	log.WithField("transactionID", issuance.TransactionID).Info("Issuance resolved manually")
+	issuance.State = database.IssuanceStateResolved
+	writeAdminResponse(w, adminTransaction(*issuance))
+}
+
+// HandlerAdminRefundTransaction refunds the deposit of failed, refund pending
+// or rejected issuance of `transaction_id` transaction to the address it was
+// sent from. Refunds requested using the admin API don't require approval.
+func (s *Server) HandlerAdminRefundTransaction(w http.ResponseWriter, r *http.Request) {
+	issuance, ok := s.adminIssuance(w, r)
+	if !ok {
+		return
+	}
+
+	refund, err := s.refundIssuance(*issuance, database.RefundStateApproved)
+	if err != nil {
+		log.WithFields(log.F{"err": err, "transactionID": issuance.TransactionID}).Error("Error refunding issuance")
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if refund == nil {
+		w.WriteHeader(http.StatusConflict)
+		return
+	}
+
+	log.WithField("refund", refund).Info("Refund added manually")
+	issuance.State = database.IssuanceStateRefunded
+	writeAdminResponse(w, adminTransaction(*issuance))
+}
+
+// adminIssuance returns issuance of `transaction_id` form value. It writes
+// error response and returns false if issuance cannot be loaded.
+func (s *Server) adminIssuance(w http.ResponseWriter, r *http.Request) (*database.Issuance, bool) {
+	transactionID := r.PostFormValue("transaction_id")
+	if transactionID == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		return nil, false
+	}
+
+	issuance, err := s.Database.GetIssuance(transactionID)
+	if err != nil {
+		log.WithField("err", err).Error("Error getting issuance")
+		w.WriteHeader(http.StatusInternalServerError)
+		return nil, false
+	}
+
+	if issuance == nil {
+		w.WriteHeader(http.StatusNotFound)
+		return nil, false
+	}
+
+	return issuance, true
+}
+
+// adminPage returns `limit` and `offset` query params. It returns false if
+// params are invalid.
+func adminPage(r *http.Request) (uint64, uint64, bool) {
+	limit := uint64(adminDefaultLimit)
+	offset := uint64(0)
+
+	query := r.URL.Query()
+	if value := query.Get("limit"); value != "" {
+		var err error
+		limit, err = strconv.ParseUint(value, 10, 64)
+		if err != nil || limit == 0 || limit > adminMaxLimit {
+			return 0, 0, false
+		}
+	}
+
+	if value := query.Get("offset"); value != "" {
+		var err error
+		offset, err = strconv.ParseUint(value, 10, 64)
+		if err != nil {
+			return 0, 0, false
+		}
+	}
+
+	return limit, offset, true
+}
+
+func adminTransaction(issuance database.Issuance) AdminTransaction {
+	return AdminTransaction{
+		Chain:            string(issuance.Chain),
+		TransactionID:    issuance.TransactionID,
+		Address:          issuance.Address,
+		StellarPublicKey: issuance.StellarPublicKey,
+		AssetCode:        issuance.AssetCode,
+		Amount:           issuance.Amount,
+		DepositAssetCode: issuance.DepositAssetCode,
+		DepositAmount:    issuance.DepositAmount,
+		State:            string(issuance.State),
+		Error:            issuance.Error,
+		CreatedAt:        issuance.CreatedAt,
+		UpdatedAt:        issuance.UpdatedAt,
+	}
+}
+
+func writeAdminResponse(w http.ResponseWriter, response interface{}) {
+	responseBytes, err := json.Marshal(response)
+	if err != nil {
+		log.WithField("err", err).Error("Error encoding JSON")
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Write(responseBytes)
+}
@@ -0,0 +1,112 @@
+// Skip this test file in Go <1.8 because it's using http.Server.Shutdown
+// +build go1.8
+
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/stellar/go/services/bifrost/config"
+	"github.com/stellar/go/services/bifrost/database"
+)
+
+func (suite *RailTestSuite) adminRequest(method, target string, form url.Values, token string) *httptest.ResponseRecorder {
+	suite.Server.Config.Admin = &config.AdminConfig{Token: "secret"}
+
+	var request *http.Request
+	if form != nil {
+		request = httptest.NewRequest(method, target, strings.NewReader(form.Encode()))
+		request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	} else {
+		request = httptest.NewRequest(method, target, nil)
+	}
+	request.Header.Set("Authorization", "Bearer "+token)
+
+	recorder := httptest.NewRecorder()
+	handlers := map[string]http.HandlerFunc{
+		"/admin/addresses":            suite.Server.HandlerAdminAddresses,
+		"/admin/transactions":         suite.Server.HandlerAdminTransactions,
+		"/admin/transactions/resolve": suite.Server.HandlerAdminResolveTransaction,
+	}
+	suite.Server.AdminHandler(handlers[request.URL.Path])(recorder, request)
+	return recorder
+}
+
+func (suite *RailTestSuite) TestAdminInvalidToken() {
+	recorder := suite.adminRequest(http.MethodGet, "/admin/addresses", nil, "invalid")
+	suite.Assert().Equal(http.StatusUnauthorized, recorder.Code)
+	suite.MockDatabase.AssertNotCalled(suite.T(), "ListAssociations")
+}
+
+func (suite *RailTestSuite) TestAdminTransactions() {
+	issuance := database.Issuance{
+		Chain:            database.ChainBitcoin,
+		TransactionID:    "109fa1c369680c2f27643fdd160620d010851a376d25b9b00ef71afe789ea6ed",
+		Address:          "1Q74qRud8bXUn6FMtXWZwJa5pj56s3mdyf",
+		StellarPublicKey: "GDULKYRRVOMASFMXBYD4BYFRSHAKQDREEVVP2TMH2CER3DW2KATIOASB",
+		AssetCode:        "BTC",
+		Amount:           "1.0000000",
+		State:            database.IssuanceStateFailed,
+		Error:            "Error sending asset to account",
+		CreatedAt:        time.Now(),
+		UpdatedAt:        time.Now(),
+	}
+	suite.MockDatabase.
+		On("GetIssuances", database.IssuanceStateFailed, uint64(10), uint64(20)).
+		Return([]database.Issuance{issuance}, nil)
+
+	recorder := suite.adminRequest(http.MethodGet, "/admin/transactions?state=failed&limit=10&offset=20", nil, "secret")
+	suite.Require().Equal(http.StatusOK, recorder.Code)
+
+	var response AdminTransactionsResponse
+	err := json.Unmarshal(recorder.Body.Bytes(), &response)
+	suite.Require().NoError(err)
+	suite.Require().Len(response.Transactions, 1)
+	suite.Assert().Equal(issuance.TransactionID, response.Transactions[0].TransactionID)
+	suite.Assert().Equal("failed", response.Transactions[0].State)
+	suite.Assert().Equal(issuance.Error, response.Transactions[0].Error)
+}
+
+func (suite *RailTestSuite) TestAdminTransactionsInvalidParams() {
+	recorder := suite.adminRequest(http.MethodGet, "/admin/transactions?state=unknown", nil, "secret")
+	suite.Assert().Equal(http.StatusBadRequest, recorder.Code)
+
+	recorder = suite.adminRequest(http.MethodGet, "/admin/transactions?limit=5000", nil, "secret")
+	suite.Assert().Equal(http.StatusBadRequest, recorder.Code)
+}
+
+func (suite *RailTestSuite) TestAdminResolveTransaction() {
+	issuance := &database.Issuance{
+		TransactionID: "109fa1c369680c2f27643fdd160620d010851a376d25b9b00ef71afe789ea6ed",
+		State:         database.IssuanceStateFailed,
+	}
+	suite.MockDatabase.On("GetIssuance", issuance.TransactionID).Return(issuance, nil)
+	suite.MockDatabase.On("ResolveIssuance", issuance.TransactionID).Return(true, nil).Once()
+
+	form := url.Values{"transaction_id": {issuance.TransactionID}}
+	recorder := suite.adminRequest(http.MethodPost, "/admin/transactions/resolve", form, "secret")
+	suite.Require().Equal(http.StatusOK, recorder.Code)
+
+	var response AdminTransaction
+	err := json.Unmarshal(recorder.Body.Bytes(), &response)
+	suite.Require().NoError(err)
+	suite.Assert().Equal("resolved", response.State)
+
+	// Already resolved
+	suite.MockDatabase.On("ResolveIssuance", issuance.TransactionID).Return(false, nil).Once()
+	recorder = suite.adminRequest(http.MethodPost, "/admin/transactions/resolve", form, "secret")
+	suite.Assert().Equal(http.StatusConflict, recorder.Code)
+}
+
+func (suite *RailTestSuite) TestAdminResolveTransactionNotFound() {
+	suite.MockDatabase.On("GetIssuance", "unknown").Return(nil, nil)
+
+	form := url.Values{"transaction_id": {"unknown"}}
+	recorder := suite.adminRequest(http.MethodPost, "/admin/transactions/resolve", form, "secret")
+	suite.Assert().Equal(http.StatusNotFound, recorder.Code)
+}
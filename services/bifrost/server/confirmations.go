@@ -0,0 +1,81 @@
+package server
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/stellar/go/services/bifrost/chains"
+	"github.com/stellar/go/services/bifrost/database"
+	"github.com/stellar/go/services/bifrost/sse"
+	"github.com/stellar/go/services/bifrost/webhooks"
+	"github.com/stellar/go/support/errors"
+	"github.com/stellar/go/support/log"
+)
+
+// onPendingTransaction adds a transaction to associated address without the
+// required number of confirmations to pending transactions and broadcasts its
+// confirmations to address stream.
+func (s *Server) onPendingTransaction(transaction chains.Transaction, association *database.AddressAssociation) error {
+	localLog := s.log.WithFields(log.F{"transaction": transaction, "rail": transaction.Chain})
+
+	pendingTransaction := database.PendingTransaction{
+		Chain:                 transaction.Chain,
+		TransactionID:         transaction.Hash,
+		Address:               transaction.To,
+		AssetCode:             string(transaction.AssetCode),
+		Amount:                transaction.Amount,
+		BlockNumber:           transaction.BlockNumber,
+		Confirmations:         transaction.Confirmations,
+		RequiredConfirmations: transaction.RequiredConfirmations,
+		State:                 database.PendingTransactionStatePending,
+		CreatedAt:             time.Now(),
+		UpdatedAt:             time.Now(),
+	}
+
+	added, err := s.Database.AddPendingTransaction(pendingTransaction)
+	if err != nil {
+		return errors.Wrap(err, "Error adding pending transaction")
+	}
+
+	if !added {
+		localLog.Debug("Transaction already pending, skipping")
+		return nil
+	}
+	localLog.Info("Transaction waiting for confirmations")
+
+	s.broadcastConfirmations(pendingTransaction)
+	s.notify(webhooks.TransactionSeenEvent, transactionWebhookData(transaction, association.StellarPublicKey, transaction.Amount))
+	return nil
+}
+
+// onNewBlock updates confirmations of pending transactions of `chain` and
+// broadcasts them to address streams.
+func (s *Server) onNewBlock(chain database.Chain, blockNumber uint64) error {
+	transactions, err := s.Database.UpdatePendingConfirmations(chain, blockNumber)
+	if err != nil {
+		return errors.Wrap(err, "Error updating pending transactions confirmations")
+	}
+
+	for _, transaction := range transactions {
+		s.broadcastConfirmations(transaction)
+	}
+	return nil
+}
+
+func (s *Server) broadcastConfirmations(transaction database.PendingTransaction) {
+	data := map[string]interface{}{
+		"transactionId":         transaction.TransactionID,
+		"assetCode":             transaction.AssetCode,
+		"amount":                transaction.Amount,
+		"confirmations":         transaction.Confirmations,
+		"requiredConfirmations": transaction.RequiredConfirmations,
+	}
+
+	j, err := json.Marshal(data)
+	if err != nil {
+		s.log.WithField("data", data).Error("Error marshalling json")
+		return
+	}
+
+	s.SSEServer.BroadcastEvent(transaction.Address, sse.TransactionPendingAddressEvent, j)
+}
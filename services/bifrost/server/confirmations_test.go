@@ -0,0 +1,142 @@
+// Skip this test file in Go <1.8 because it's using http.Server.Shutdown
+// +build go1.8
+
+package server
+
+import (
+	"math/big"
+	"time"
+
+	"github.com/stellar/go/services/bifrost/chains"
+	"github.com/stellar/go/services/bifrost/database"
+	"github.com/stellar/go/services/bifrost/queue"
+	"github.com/stellar/go/services/bifrost/sse"
+	"github.com/stellar/go/services/bifrost/webhooks"
+	"github.com/stretchr/testify/mock"
+)
+
+func (suite *RailTestSuite) pendingTransaction(confirmations uint64) (chains.Transaction, *database.AddressAssociation) {
+	transaction := chains.Transaction{
+		Chain:                 database.ChainBitcoin,
+		Hash:                  "109fa1c369680c2f27643fdd160620d010851a376d25b9b00ef71afe789ea6ed",
+		To:                    "1Q74qRud8bXUn6FMtXWZwJa5pj56s3mdyf",
+		Value:                 big.NewInt(100000000),
+		Decimals:              8,
+		AssetCode:             queue.AssetCodeBTC,
+		Amount:                "1.0000000",
+		BlockNumber:           500000,
+		Confirmations:         confirmations,
+		RequiredConfirmations: 6,
+	}
+	association := &database.AddressAssociation{
+		Chain:            database.ChainBitcoin,
+		AddressIndex:     1,
+		Address:          "1Q74qRud8bXUn6FMtXWZwJa5pj56s3mdyf",
+		StellarPublicKey: "GDULKYRRVOMASFMXBYD4BYFRSHAKQDREEVVP2TMH2CER3DW2KATIOASB",
+		CreatedAt:        time.Now(),
+	}
+	suite.MockAdapter.On("MinimumValue", queue.AssetCodeBTC).Return(big.NewInt(100000000)) // 1 BTC
+	suite.MockDatabase.
+		On("GetAssociationByChainAddress", database.ChainBitcoin, transaction.To).
+		Return(association, nil)
+	return transaction, association
+}
+
+func (suite *RailTestSuite) TestPendingTransaction() {
+	mockNotifier := &webhooks.MockNotifier{}
+	suite.Server.Webhooks = mockNotifier
+
+	transaction, association := suite.pendingTransaction(1)
+	suite.MockDatabase.
+		On("AddPendingTransaction", mock.AnythingOfType("database.PendingTransaction")).
+		Return(true, nil).
+		Run(func(args mock.Arguments) {
+			pending := args.Get(0).(database.PendingTransaction)
+			suite.Assert().Equal(transaction.Hash, pending.TransactionID)
+			suite.Assert().Equal(transaction.To, pending.Address)
+			suite.Assert().Equal(uint64(500000), pending.BlockNumber)
+			suite.Assert().Equal(uint64(1), pending.Confirmations)
+			suite.Assert().Equal(uint64(6), pending.RequiredConfirmations)
+			suite.Assert().Equal(database.PendingTransactionStatePending, pending.State)
+		})
+	suite.MockSSEServer.
+		On("BroadcastEvent", transaction.To, sse.TransactionPendingAddressEvent, mock.AnythingOfType("[]uint8")).
+		Run(func(args mock.Arguments) {
+			suite.Assert().JSONEq(
+				`{"transactionId":"109fa1c369680c2f27643fdd160620d010851a376d25b9b00ef71afe789ea6ed","assetCode":"BTC","amount":"1.0000000","confirmations":1,"requiredConfirmations":6}`,
+				string(args.Get(2).([]byte)),
+			)
+		})
+	mockNotifier.On("Notify", webhooks.TransactionSeenEvent, transactionWebhookData(transaction, association.StellarPublicKey, "1.0000000")).Once()
+	suite.MockDatabase.AssertNotCalled(suite.T(), "AddProcessedTransaction")
+	suite.MockQueue.AssertNotCalled(suite.T(), "QueueAdd")
+
+	err := suite.Server.onNewTransaction(transaction)
+	suite.Require().NoError(err)
+	mockNotifier.AssertExpectations(suite.T())
+}
+
+func (suite *RailTestSuite) TestPendingTransactionAlreadyPending() {
+	transaction, _ := suite.pendingTransaction(1)
+	suite.MockDatabase.
+		On("AddPendingTransaction", mock.AnythingOfType("database.PendingTransaction")).
+		Return(false, nil)
+	suite.MockSSEServer.AssertNotCalled(suite.T(), "BroadcastEvent")
+
+	err := suite.Server.onNewTransaction(transaction)
+	suite.Require().NoError(err)
+}
+
+func (suite *RailTestSuite) TestPendingTransactionConfirmed() {
+	mockNotifier := &webhooks.MockNotifier{}
+	suite.Server.Webhooks = mockNotifier
+
+	transaction, association := suite.pendingTransaction(6)
+	suite.MockDatabase.
+		On("ConfirmPendingTransaction", database.ChainBitcoin, transaction.Hash).
+		Return(true, nil)
+	suite.MockDatabase.
+		On("AddProcessedTransaction", database.ChainBitcoin, transaction.Hash, transaction.To).
+		Return(false, nil)
+	suite.MockDatabase.
+		On("AddIssuance", mock.AnythingOfType("database.Issuance")).
+		Return(nil)
+	suite.MockQueue.
+		On("QueueAdd", mock.AnythingOfType("queue.Transaction")).
+		Return(nil)
+	suite.MockSSEServer.
+		On("BroadcastEvent", transaction.To, sse.TransactionReceivedAddressEvent, []byte(nil))
+	// Transaction has already been seen as pending
+	mockNotifier.On("Notify", webhooks.ConfirmationsReachedEvent, transactionWebhookData(transaction, association.StellarPublicKey, "1.0000000")).Once()
+
+	err := suite.Server.onNewTransaction(transaction)
+	suite.Require().NoError(err)
+	mockNotifier.AssertExpectations(suite.T())
+}
+
+func (suite *RailTestSuite) TestNewBlock() {
+	pending := []database.PendingTransaction{
+		{
+			Chain:                 database.ChainBitcoin,
+			TransactionID:         "109fa1c369680c2f27643fdd160620d010851a376d25b9b00ef71afe789ea6ed",
+			Address:               "1Q74qRud8bXUn6FMtXWZwJa5pj56s3mdyf",
+			AssetCode:             "BTC",
+			Amount:                "1.0000000",
+			BlockNumber:           500000,
+			Confirmations:         3,
+			RequiredConfirmations: 6,
+			State:                 database.PendingTransactionStatePending,
+		},
+	}
+	suite.MockDatabase.
+		On("UpdatePendingConfirmations", database.ChainBitcoin, uint64(500002)).
+		Return(pending, nil)
+	suite.MockSSEServer.
+		On("BroadcastEvent", "1Q74qRud8bXUn6FMtXWZwJa5pj56s3mdyf", sse.TransactionPendingAddressEvent, mock.AnythingOfType("[]uint8")).
+		Run(func(args mock.Arguments) {
+			suite.Assert().Contains(string(args.Get(2).([]byte)), `"confirmations":3`)
+		})
+
+	err := suite.Server.onNewBlock(database.ChainBitcoin, 500002)
+	suite.Require().NoError(err)
+}
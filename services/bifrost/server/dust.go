@@ -0,0 +1,141 @@
+package server
+
+import (
+	"math/big"
+	"time"
+
+	"github.com/stellar/go/services/bifrost/chains"
+	"github.com/stellar/go/services/bifrost/database"
+	"github.com/stellar/go/services/bifrost/queue"
+	"github.com/stellar/go/services/bifrost/sse"
+	"github.com/stellar/go/services/bifrost/webhooks"
+	"github.com/stellar/go/support/errors"
+	"github.com/stellar/go/support/log"
+)
+
+// DustPolicy defines what happens with payments below minimum value of
+// the chain adapter (dust).
+type DustPolicy string
+
+const (
+	// DustPolicyIgnore ignores dust, it is only recorded in the dust ledger.
+	DustPolicyIgnore DustPolicy = "ignore"
+	// DustPolicyAccumulate sums dust paid to the address until it reaches
+	// minimum value and then processes the sum as a single payment.
+	DustPolicyAccumulate DustPolicy = "accumulate"
+	// DustPolicyRefund marks dust to be refunded by the operator.
+	DustPolicyRefund DustPolicy = "refund"
+)
+
+var dustStates = map[DustPolicy]database.DustState{
+	DustPolicyIgnore:     database.DustStateIgnored,
+	DustPolicyAccumulate: database.DustStateAccumulated,
+	DustPolicyRefund:     database.DustStateRefundPending,
+}
+
+// validateDustPolicies checks if the dust policies in config are valid.
+func (s *Server) validateDustPolicies() error {
+	if s.Config.Dust == nil {
+		return nil
+	}
+
+	policies := map[string]string{"default": s.Config.Dust.Policy}
+	for chain, policy := range s.Config.Dust.ChainPolicies {
+		if _, exists := s.Adapters[database.Chain(chain)]; !exists {
+			return errors.New("Dust policy set for not configured chain: " + chain)
+		}
+		policies[chain] = policy
+	}
+
+	for chain, policy := range policies {
+		if policy == "" {
+			continue
+		}
+		if _, valid := dustStates[DustPolicy(policy)]; !valid {
+			return errors.Errorf("Invalid %s dust policy: %s", chain, policy)
+		}
+	}
+
+	return nil
+}
+
+// dustPolicy returns the dust policy of `chain`.
+func (s *Server) dustPolicy(chain database.Chain) DustPolicy {
+	if s.Config == nil || s.Config.Dust == nil {
+		return DustPolicyIgnore
+	}
+
+	if policy, exists := s.Config.Dust.ChainPolicies[string(chain)]; exists && policy != "" {
+		return DustPolicy(policy)
+	}
+
+	if s.Config.Dust.Policy != "" {
+		return DustPolicy(s.Config.Dust.Policy)
+	}
+
+	return DustPolicyIgnore
+}
+
+// onDust adds a payment below `minimumValue` to associated address to the
+// dust ledger. If the chain dust policy is DustPolicyAccumulate and the
+// accumulated value reaches `minimumValue`, the sum is added to the
+// transactions queue.
+func (s *Server) onDust(transaction chains.Transaction, association *database.AddressAssociation, minimumValue *big.Int) error {
+	policy := s.dustPolicy(transaction.Chain)
+	localLog := s.log.WithFields(log.F{"transaction": transaction, "rail": transaction.Chain, "dustPolicy": policy})
+
+	dust := database.Dust{
+		Chain:         transaction.Chain,
+		TransactionID: transaction.Hash,
+		Address:       transaction.To,
+		AssetCode:     string(transaction.AssetCode),
+		Value:         transaction.Value.String(),
+		Decimals:      transaction.Decimals,
+		State:         dustStates[policy],
+		CreatedAt:     time.Now(),
+	}
+
+	err := s.Database.AddDust(dust)
+	if err != nil {
+		return errors.Wrap(err, "Error adding dust to the ledger")
+	}
+
+	switch policy {
+	case DustPolicyIgnore:
+		localLog.Info("Value is below minimum required amount, ignored")
+		return nil
+	case DustPolicyRefund:
+		localLog.Warn("Value is below minimum required amount, refund pending")
+		return nil
+	}
+
+	total, err := s.Database.CreditDust(transaction.Chain, transaction.To, string(transaction.AssetCode), minimumValue)
+	if err != nil {
+		return errors.Wrap(err, "Error crediting accumulated dust")
+	}
+
+	if total == nil {
+		localLog.Info("Value is below minimum required amount, accumulated")
+		return nil
+	}
+
+	// The transaction reaching minimum value represents all accumulated dust
+	// in the queue. It's never added to the queue otherwise.
+	queueTx := queue.Transaction{
+		TransactionID:    transaction.Hash,
+		AssetCode:        transaction.AssetCode,
+		Amount:           chains.ToStellarAmount(total, transaction.Decimals),
+		StellarPublicKey: association.StellarPublicKey,
+	}
+
+	err = s.queueTransaction(transaction.Chain, transaction.To, queueTx)
+	if err != nil {
+		return errors.Wrap(err, "Error adding accumulated dust to the processing queue")
+	}
+	localLog.WithField("amount", queueTx.Amount).Info("Accumulated dust added to transaction queue")
+
+	s.notify(webhooks.ConfirmationsReachedEvent, transactionWebhookData(transaction, association.StellarPublicKey, queueTx.Amount))
+
+	s.SSEServer.BroadcastEvent(transaction.To, sse.TransactionReceivedAddressEvent, nil)
+	return nil
+}
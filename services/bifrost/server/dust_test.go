@@ -0,0 +1,133 @@
+// Skip this test file in Go <1.8 because it's using http.Server.Shutdown
+// +build go1.8
+
+package server
+
+import (
+	"math/big"
+	"time"
+
+	"github.com/stellar/go/services/bifrost/chains"
+	"github.com/stellar/go/services/bifrost/config"
+	"github.com/stellar/go/services/bifrost/database"
+	"github.com/stellar/go/services/bifrost/queue"
+	"github.com/stellar/go/services/bifrost/sse"
+	"github.com/stretchr/testify/mock"
+)
+
+func (suite *RailTestSuite) dustTransaction() (chains.Transaction, *database.AddressAssociation) {
+	transaction := chains.Transaction{
+		Chain:     database.ChainBitcoin,
+		Hash:      "109fa1c369680c2f27643fdd160620d010851a376d25b9b00ef71afe789ea6ed",
+		Index:     0,
+		To:        "1Q74qRud8bXUn6FMtXWZwJa5pj56s3mdyf",
+		Value:     big.NewInt(50000000), // 0.5 BTC
+		Decimals:  8,
+		AssetCode: queue.AssetCodeBTC,
+		Amount:    "0.5000000",
+	}
+	association := &database.AddressAssociation{
+		Chain:            database.ChainBitcoin,
+		AddressIndex:     1,
+		Address:          "1Q74qRud8bXUn6FMtXWZwJa5pj56s3mdyf",
+		StellarPublicKey: "GDULKYRRVOMASFMXBYD4BYFRSHAKQDREEVVP2TMH2CER3DW2KATIOASB",
+		CreatedAt:        time.Now(),
+	}
+	suite.MockAdapter.On("MinimumValue", queue.AssetCodeBTC).Return(big.NewInt(100000000)) // 1 BTC
+	suite.MockDatabase.
+		On("GetAssociationByChainAddress", database.ChainBitcoin, transaction.To).
+		Return(association, nil)
+	suite.MockDatabase.
+		On("AddProcessedTransaction", database.ChainBitcoin, transaction.Hash, transaction.To).
+		Return(false, nil)
+	return transaction, association
+}
+
+func (suite *RailTestSuite) expectDust(transaction chains.Transaction, state database.DustState) {
+	suite.MockDatabase.
+		On("AddDust", mock.AnythingOfType("database.Dust")).
+		Return(nil).
+		Run(func(args mock.Arguments) {
+			dust := args.Get(0).(database.Dust)
+			suite.Assert().Equal(transaction.Chain, dust.Chain)
+			suite.Assert().Equal(transaction.Hash, dust.TransactionID)
+			suite.Assert().Equal(transaction.To, dust.Address)
+			suite.Assert().Equal("BTC", dust.AssetCode)
+			suite.Assert().Equal("50000000", dust.Value)
+			suite.Assert().Equal(uint8(8), dust.Decimals)
+			suite.Assert().Equal(state, dust.State)
+		})
+}
+
+func (suite *RailTestSuite) TestDustIgnored() {
+	transaction, _ := suite.dustTransaction()
+	suite.expectDust(transaction, database.DustStateIgnored)
+	suite.MockDatabase.AssertNotCalled(suite.T(), "CreditDust")
+	suite.MockQueue.AssertNotCalled(suite.T(), "QueueAdd")
+	err := suite.Server.onNewTransaction(transaction)
+	suite.Require().NoError(err)
+}
+
+func (suite *RailTestSuite) TestDustRefund() {
+	suite.Server.Config.Dust = &config.DustConfig{
+		Policy:        "accumulate",
+		ChainPolicies: map[string]string{"bitcoin": "refund"},
+	}
+	transaction, _ := suite.dustTransaction()
+	suite.expectDust(transaction, database.DustStateRefundPending)
+	suite.MockDatabase.AssertNotCalled(suite.T(), "CreditDust")
+	suite.MockQueue.AssertNotCalled(suite.T(), "QueueAdd")
+	err := suite.Server.onNewTransaction(transaction)
+	suite.Require().NoError(err)
+}
+
+func (suite *RailTestSuite) TestDustAccumulated() {
+	suite.Server.Config.Dust = &config.DustConfig{Policy: "accumulate"}
+	transaction, _ := suite.dustTransaction()
+	suite.expectDust(transaction, database.DustStateAccumulated)
+	suite.MockDatabase.
+		On("CreditDust", database.ChainBitcoin, transaction.To, "BTC", big.NewInt(100000000)).
+		Return(nil, nil)
+	suite.MockQueue.AssertNotCalled(suite.T(), "QueueAdd")
+	err := suite.Server.onNewTransaction(transaction)
+	suite.Require().NoError(err)
+}
+
+func (suite *RailTestSuite) TestDustAccumulatedCredited() {
+	suite.Server.Config.Dust = &config.DustConfig{Policy: "accumulate"}
+	transaction, association := suite.dustTransaction()
+	suite.expectDust(transaction, database.DustStateAccumulated)
+	suite.MockDatabase.
+		On("CreditDust", database.ChainBitcoin, transaction.To, "BTC", big.NewInt(100000000)).
+		Return(big.NewInt(120000000), nil)
+	suite.MockDatabase.
+		On("AddIssuance", mock.AnythingOfType("database.Issuance")).
+		Return(nil)
+	suite.MockQueue.
+		On("QueueAdd", mock.AnythingOfType("queue.Transaction")).
+		Return(nil).
+		Run(func(args mock.Arguments) {
+			queueTransaction := args.Get(0).(queue.Transaction)
+			suite.Assert().Equal(transaction.Hash, queueTransaction.TransactionID)
+			suite.Assert().Equal(queue.AssetCodeBTC, queueTransaction.AssetCode)
+			suite.Assert().Equal("1.2000000", queueTransaction.Amount)
+			suite.Assert().Equal(association.StellarPublicKey, queueTransaction.StellarPublicKey)
+		})
+	suite.MockSSEServer.
+		On("BroadcastEvent", transaction.To, sse.TransactionReceivedAddressEvent, []byte(nil))
+	err := suite.Server.onNewTransaction(transaction)
+	suite.Require().NoError(err)
+}
+
+func (suite *RailTestSuite) TestValidateDustPolicies() {
+	suite.Require().NoError(suite.Server.validateDustPolicies())
+
+	suite.Server.Config.Dust = &config.DustConfig{Policy: "accumulate"}
+	suite.Require().NoError(suite.Server.validateDustPolicies())
+
+	suite.Server.Config.Dust = &config.DustConfig{Policy: "burn"}
+	suite.Require().Error(suite.Server.validateDustPolicies())
+
+	suite.Server.Config.Dust = &config.DustConfig{ChainPolicies: map[string]string{"ethereum": "refund"}}
+	suite.Require().Error(suite.Server.validateDustPolicies())
+}
@@ -1,16 +1,18 @@
 package server
 
 import (
-	"math/big"
 	"net/http"
 
-	"github.com/stellar/go/services/bifrost/bitcoin"
+	"github.com/stellar/go/services/bifrost/chains"
+	"github.com/stellar/go/services/bifrost/cluster"
 	"github.com/stellar/go/services/bifrost/config"
 	"github.com/stellar/go/services/bifrost/database"
-	"github.com/stellar/go/services/bifrost/ethereum"
+	"github.com/stellar/go/services/bifrost/kyc"
+	"github.com/stellar/go/services/bifrost/price"
 	"github.com/stellar/go/services/bifrost/queue"
 	"github.com/stellar/go/services/bifrost/sse"
 	"github.com/stellar/go/services/bifrost/stellar"
+	"github.com/stellar/go/services/bifrost/webhooks"
 	"github.com/stellar/go/support/log"
 )
 
@@ -19,23 +21,35 @@ import (
 const ProtocolVersion int = 1
 
 type Server struct {
-	BitcoinListener            *bitcoin.Listener            `inject:""`
-	BitcoinAddressGenerator    *bitcoin.AddressGenerator    `inject:""`
 	Config                     *config.Config               `inject:""`
 	Database                   database.Database            `inject:""`
-	EthereumListener           *ethereum.Listener           `inject:""`
-	EthereumAddressGenerator   *ethereum.AddressGenerator   `inject:""`
 	StellarAccountConfigurator *stellar.AccountConfigurator `inject:""`
 	TransactionsQueue          queue.Queue                  `inject:""`
 	SSEServer                  sse.ServerInterface          `inject:""`
 
-	MinimumValueBtc string
-	MinimumValueEth string
+	// Adapters are the adapters of configured chains, created using
+	// chains.New.
+	Adapters map[database.Chain]chains.ChainAdapter
+	// WithdrawalListener streams withdrawals. It's nil if withdrawals are not
+	// configured.
+	WithdrawalListener *stellar.WithdrawalListener
+	// Webhooks sends webhooks of deposit lifecycle events. It's nil if webhooks
+	// are not configured.
+	Webhooks webhooks.NotifierInterface
+	// Cluster coordinates many instances using the same database: blocks are
+	// ingested by the leader and the transactions queue is sharded. It's nil if
+	// cluster is not configured.
+	Cluster *cluster.Coordinator
+	// PriceOracle returns exchange rates of deposited assets. When it's set
+	// deposits are issued as token (TokenAssetCode) at the current rate. It's
+	// nil if price is not configured: deposited assets are issued 1:1.
+	PriceOracle price.Oracle
+	// KYC approves deposits before the asset is issued. It's nil if kyc is not
+	// configured: all deposits are issued.
+	KYC kyc.Approver
 
-	minimumValueSat int64
-	minimumValueWei *big.Int
-	httpServer      *http.Server
-	log             *log.Entry
+	httpServer *http.Server
+	log        *log.Entry
 }
 
 type GenerateAddressResponse struct {
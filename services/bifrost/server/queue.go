@@ -2,6 +2,16 @@ package server
 
 import (
 	"time"
+
+	"github.com/stellar/go/services/bifrost/common"
+	"github.com/stellar/go/services/bifrost/database"
+	"github.com/stellar/go/services/bifrost/kyc"
+	"github.com/stellar/go/services/bifrost/metrics"
+	"github.com/stellar/go/services/bifrost/price"
+	"github.com/stellar/go/services/bifrost/queue"
+	"github.com/stellar/go/services/bifrost/stellar"
+	"github.com/stellar/go/support/errors"
+	"github.com/stellar/go/support/log"
 )
 
 // poolTransactionsQueue pools transactions queue which contains only processed and
@@ -10,7 +20,7 @@ func (s *Server) poolTransactionsQueue() {
 	s.log.Info("Started pooling transactions queue")
 
 	for {
-		transaction, err := s.TransactionsQueue.QueuePool()
+		transaction, err := s.poolTransaction()
 		if err != nil {
 			s.log.WithField("err", err).Error("Error pooling transactions queue")
 			time.Sleep(time.Second)
@@ -23,10 +33,170 @@ func (s *Server) poolTransactionsQueue() {
 		}
 
 		s.log.WithField("transaction", transaction).Info("Received transaction from transactions queue")
-		go s.StellarAccountConfigurator.ConfigureAccount(
-			transaction.StellarPublicKey,
-			string(transaction.AssetCode),
-			transaction.Amount,
-		)
+		go s.configureAccount(*transaction)
+	}
+}
+
+// poolTransaction pools a transaction from the transactions queue. If Cluster
+// is configured only shards held by this instance are pooled.
+func (s *Server) poolTransaction() (*queue.Transaction, error) {
+	if s.Cluster == nil {
+		return s.TransactionsQueue.QueuePool()
+	}
+
+	// Checked in Start
+	shardedQueue := s.TransactionsQueue.(queue.ShardedQueue)
+	return shardedQueue.QueuePoolShards(s.Cluster.Shards, s.Cluster.HeldShards())
+}
+
+// queueTransaction adds `transaction` to the transactions queue and records its
+// issuance so it can be inspected using the admin API. If PriceOracle is set,
+// the token is issued at the current rate instead of the deposited asset.
+func (s *Server) queueTransaction(chain database.Chain, address string, transaction queue.Transaction) error {
+	// Deposited asset and amount are recorded so the deposit can be refunded.
+	deposit := transaction
+	if s.PriceOracle != nil {
+		var err error
+		transaction, err = s.convertTransaction(transaction)
+		if err != nil {
+			return err
+		}
+	}
+
+	now := time.Now()
+	err := s.Database.AddIssuance(database.Issuance{
+		Chain:            chain,
+		TransactionID:    transaction.TransactionID,
+		Address:          address,
+		StellarPublicKey: transaction.StellarPublicKey,
+		AssetCode:        string(transaction.AssetCode),
+		Amount:           transaction.Amount,
+		DepositAssetCode: string(deposit.AssetCode),
+		DepositAmount:    deposit.Amount,
+		State:            database.IssuanceStateQueued,
+		CreatedAt:        now,
+		UpdatedAt:        now,
+	})
+	if err != nil {
+		return errors.Wrap(err, "Error adding issuance")
+	}
+
+	err = s.TransactionsQueue.QueueAdd(transaction)
+	if err != nil {
+		return err
+	}
+
+	metrics.TransactionQueued()
+	return nil
+}
+
+// convertTransaction returns `transaction` issuing the token at the rate
+// returned by PriceOracle.
+func (s *Server) convertTransaction(transaction queue.Transaction) (queue.Transaction, error) {
+	rate, err := s.PriceOracle.Rate(transaction.AssetCode)
+	if err != nil {
+		return transaction, errors.Wrap(err, "Error getting rate")
+	}
+
+	amount, err := price.Convert(transaction.Amount, rate)
+	if err != nil {
+		return transaction, errors.Wrap(err, "Error converting amount")
+	}
+
+	s.log.WithFields(log.F{
+		"transactionID": transaction.TransactionID,
+		"assetCode":     transaction.AssetCode,
+		"amount":        transaction.Amount,
+		"rate":          rate.FloatString(common.StellarAmountPrecision),
+		"tokenAmount":   amount,
+	}).Info("Converted transaction amount")
+
+	transaction.AssetCode = queue.AssetCode(s.Config.Stellar.TokenAssetCode)
+	transaction.Amount = amount
+	return transaction, nil
+}
+
+// configureAccount configures Stellar account of `transaction` using
+// StellarAccountConfigurator and saves the result in the issuance. If KYC is
+// set, the deposit must be approved first. If Refunds are configured, deposits
+// that can't be issued (the account was not created or merged) are refunded.
+func (s *Server) configureAccount(transaction queue.Transaction) {
+	if s.KYC != nil {
+		decision, err := s.approveTransaction(transaction)
+		if err != nil {
+			metrics.TransactionFailed()
+			s.finishIssuance(transaction.TransactionID, database.IssuanceStateFailed, err.Error())
+			return
+		}
+
+		if !decision.Approved {
+			s.finishIssuance(transaction.TransactionID, database.IssuanceStateRejected, decision.Reason)
+			return
+		}
+	}
+
+	state := database.IssuanceStateDelivered
+	errorMessage := ""
+
+	err := s.StellarAccountConfigurator.ConfigureAccount(
+		transaction.StellarPublicKey,
+		string(transaction.AssetCode),
+		transaction.Amount,
+	)
+	if err == stellar.ErrTrustlineTimeout || err == stellar.ErrAccountMerged {
+		state = database.IssuanceStateRefundPending
+		errorMessage = err.Error()
+		metrics.TransactionFailed()
+	} else if err != nil {
+		state = database.IssuanceStateFailed
+		errorMessage = err.Error()
+		metrics.TransactionFailed()
+	} else {
+		metrics.TransactionDelivered()
+	}
+
+	s.finishIssuance(transaction.TransactionID, state, errorMessage)
+
+	if state == database.IssuanceStateRefundPending && s.Config.Refunds != nil {
+		s.refundPendingIssuance(transaction.TransactionID)
+	}
+}
+
+// approveTransaction sends the deposit of `transaction` to the KYC approval
+// endpoint.
+func (s *Server) approveTransaction(transaction queue.Transaction) (*kyc.Decision, error) {
+	issuance, err := s.Database.GetIssuance(transaction.TransactionID)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error getting issuance")
+	}
+
+	if issuance == nil {
+		return nil, errors.New("Issuance not found")
+	}
+
+	decision, err := s.KYC.Approve(kyc.Deposit{
+		Chain:            string(issuance.Chain),
+		TransactionID:    transaction.TransactionID,
+		Address:          issuance.Address,
+		StellarPublicKey: transaction.StellarPublicKey,
+		AssetCode:        string(transaction.AssetCode),
+		Amount:           transaction.Amount,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "Error getting KYC approval")
+	}
+
+	s.log.WithFields(log.F{
+		"transactionID": transaction.TransactionID,
+		"approved":      decision.Approved,
+		"reason":        decision.Reason,
+	}).Info("Received KYC decision")
+	return decision, nil
+}
+
+func (s *Server) finishIssuance(transactionID string, state database.IssuanceState, errorMessage string) {
+	err := s.Database.FinishIssuance(transactionID, state, errorMessage)
+	if err != nil {
+		s.log.WithFields(log.F{"err": err, "transactionID": transactionID}).Error("Error saving issuance state")
 	}
 }
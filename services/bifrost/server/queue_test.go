@@ -0,0 +1,132 @@
+// Skip this test file in Go <1.8 because it's using http.Server.Shutdown
+// +build go1.8
+
+package server
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/stellar/go/services/bifrost/chains"
+	"github.com/stellar/go/services/bifrost/database"
+	"github.com/stellar/go/services/bifrost/kyc"
+	"github.com/stellar/go/services/bifrost/price"
+	"github.com/stellar/go/services/bifrost/queue"
+	"github.com/stretchr/testify/mock"
+)
+
+func (suite *RailTestSuite) TestQueueTransactionPrice() {
+	suite.Server.Config.Stellar.TokenAssetCode = "TOKE"
+	suite.Server.PriceOracle = &price.StaticOracle{
+		Rates: map[queue.AssetCode]*big.Rat{queue.AssetCodeBTC: big.NewRat(10000, 1)},
+	}
+
+	transaction := queue.Transaction{
+		TransactionID:    "109fa1c369680c2f27643fdd160620d010851a376d25b9b00ef71afe789ea6ed",
+		AssetCode:        queue.AssetCodeBTC,
+		Amount:           "1.5000000",
+		StellarPublicKey: "GDULKYRRVOMASFMXBYD4BYFRSHAKQDREEVVP2TMH2CER3DW2KATIOASB",
+	}
+	suite.MockDatabase.
+		On("AddIssuance", mock.AnythingOfType("database.Issuance")).
+		Return(nil).
+		Run(func(args mock.Arguments) {
+			issuance := args.Get(0).(database.Issuance)
+			suite.Assert().Equal("TOKE", issuance.AssetCode)
+			suite.Assert().Equal("15000.0000000", issuance.Amount)
+			suite.Assert().Equal("BTC", issuance.DepositAssetCode)
+			suite.Assert().Equal("1.5000000", issuance.DepositAmount)
+		})
+	suite.MockQueue.
+		On("QueueAdd", mock.AnythingOfType("queue.Transaction")).
+		Return(nil).
+		Run(func(args mock.Arguments) {
+			queueTransaction := args.Get(0).(queue.Transaction)
+			suite.Assert().Equal(queue.AssetCode("TOKE"), queueTransaction.AssetCode)
+			suite.Assert().Equal("15000.0000000", queueTransaction.Amount)
+			suite.Assert().Equal(transaction.StellarPublicKey, queueTransaction.StellarPublicKey)
+		})
+
+	err := suite.Server.queueTransaction(database.ChainBitcoin, "1Q74qRud8bXUn6FMtXWZwJa5pj56s3mdyf", transaction)
+	suite.Require().NoError(err)
+}
+
+func (suite *RailTestSuite) TestNewTransactionPriceUnknown() {
+	suite.Server.PriceOracle = &price.StaticOracle{Rates: map[queue.AssetCode]*big.Rat{}}
+
+	transaction := chains.Transaction{
+		Chain:                 database.ChainBitcoin,
+		Hash:                  "109fa1c369680c2f27643fdd160620d010851a376d25b9b00ef71afe789ea6ed",
+		To:                    "1Q74qRud8bXUn6FMtXWZwJa5pj56s3mdyf",
+		Value:                 big.NewInt(100000000),
+		AssetCode:             queue.AssetCodeBTC,
+		Amount:                "1.0000000",
+		Confirmations:         1,
+		RequiredConfirmations: 1,
+	}
+	suite.MockAdapter.On("MinimumValue", queue.AssetCodeBTC).Return(big.NewInt(100000000))
+	suite.MockDatabase.
+		On("GetAssociationByChainAddress", database.ChainBitcoin, transaction.To).
+		Return(&database.AddressAssociation{StellarPublicKey: "GDULKYRRVOMASFMXBYD4BYFRSHAKQDREEVVP2TMH2CER3DW2KATIOASB"}, nil)
+
+	// Transaction is not marked as processed so the block is processed again
+	err := suite.Server.onNewTransaction(transaction)
+	suite.Assert().Error(err)
+	suite.MockDatabase.AssertNotCalled(suite.T(), "AddProcessedTransaction", database.ChainBitcoin, transaction.Hash, transaction.To)
+}
+
+func (suite *RailTestSuite) TestConfigureAccountKYCRejected() {
+	mockApprover := &kyc.MockApprover{}
+	suite.Server.KYC = mockApprover
+
+	transaction := queue.Transaction{
+		TransactionID:    "109fa1c369680c2f27643fdd160620d010851a376d25b9b00ef71afe789ea6ed",
+		AssetCode:        queue.AssetCodeBTC,
+		Amount:           "1.0000000",
+		StellarPublicKey: "GDULKYRRVOMASFMXBYD4BYFRSHAKQDREEVVP2TMH2CER3DW2KATIOASB",
+	}
+	suite.MockDatabase.
+		On("GetIssuance", transaction.TransactionID).
+		Return(&database.Issuance{Chain: database.ChainBitcoin, Address: "1Q74qRud8bXUn6FMtXWZwJa5pj56s3mdyf"}, nil)
+	mockApprover.
+		On("Approve", kyc.Deposit{
+			Chain:            "bitcoin",
+			TransactionID:    transaction.TransactionID,
+			Address:          "1Q74qRud8bXUn6FMtXWZwJa5pj56s3mdyf",
+			StellarPublicKey: transaction.StellarPublicKey,
+			AssetCode:        "BTC",
+			Amount:           "1.0000000",
+		}).
+		Return(&kyc.Decision{Approved: false, Reason: "KYC not completed"}, nil)
+	suite.MockDatabase.
+		On("FinishIssuance", transaction.TransactionID, database.IssuanceStateRejected, "KYC not completed").
+		Return(nil)
+
+	// StellarAccountConfigurator is not set so the test panics if the asset is issued
+	suite.Server.configureAccount(transaction)
+	mockApprover.AssertExpectations(suite.T())
+}
+
+func (suite *RailTestSuite) TestConfigureAccountKYCError() {
+	mockApprover := &kyc.MockApprover{}
+	suite.Server.KYC = mockApprover
+
+	transaction := queue.Transaction{
+		TransactionID:    "109fa1c369680c2f27643fdd160620d010851a376d25b9b00ef71afe789ea6ed",
+		AssetCode:        queue.AssetCodeBTC,
+		Amount:           "1.0000000",
+		StellarPublicKey: "GDULKYRRVOMASFMXBYD4BYFRSHAKQDREEVVP2TMH2CER3DW2KATIOASB",
+	}
+	suite.MockDatabase.
+		On("GetIssuance", transaction.TransactionID).
+		Return(&database.Issuance{Chain: database.ChainBitcoin, Address: "1Q74qRud8bXUn6FMtXWZwJa5pj56s3mdyf"}, nil)
+	mockApprover.
+		On("Approve", mock.AnythingOfType("kyc.Deposit")).
+		Return(nil, errors.New("Invalid response status: 503"))
+	suite.MockDatabase.
+		On("FinishIssuance", transaction.TransactionID, database.IssuanceStateFailed, "Error getting KYC approval: Invalid response status: 503").
+		Return(nil)
+
+	suite.Server.configureAccount(transaction)
+	mockApprover.AssertExpectations(suite.T())
+}
@@ -0,0 +1,113 @@
+package server
+
+import (
+	"github.com/stellar/go/services/bifrost/chains"
+	"github.com/stellar/go/services/bifrost/queue"
+	"github.com/stellar/go/services/bifrost/sse"
+	"github.com/stellar/go/services/bifrost/webhooks"
+	"github.com/stellar/go/support/errors"
+	"github.com/stellar/go/support/log"
+)
+
+// onNewTransaction checks if transaction streamed by a chain adapter is valid
+// and adds it to the transactions queue for StellarAccountConfigurator to consume.
+//
+// Transaction added to transactions queue should be in a format described in
+// queue.Transaction (especialy amounts). Pooling service should not have to deal with any
+// conversions.
+//
+// This is very unlikely but it's possible that a single Bitcoin transaction will have more
+// than one output going to bifrost account. Then only first output will be processed.
+// Because it's very unlikely that this will happen and it's not a security issue this
+// will be fixed in a future release.
+func (s *Server) onNewTransaction(transaction chains.Transaction) error {
+	localLog := s.log.WithFields(log.F{"transaction": transaction, "rail": transaction.Chain})
+	localLog.Debug("Processing transaction")
+
+	adapter, exists := s.Adapters[transaction.Chain]
+	if !exists {
+		return errors.New("No adapter for chain: " + string(transaction.Chain))
+	}
+
+	// Let's check if tx is valid first.
+
+	minimumValue := adapter.MinimumValue(transaction.AssetCode)
+	if minimumValue == nil {
+		localLog.Debug("Asset is not accepted, skipping")
+		return nil
+	}
+
+	addressAssociation, err := s.Database.GetAssociationByChainAddress(transaction.Chain, transaction.To)
+	if err != nil {
+		return errors.Wrap(err, "Error getting association")
+	}
+
+	if addressAssociation == nil {
+		localLog.Debug("Associated address not found, skipping")
+		return nil
+	}
+
+	if !transaction.Confirmed() {
+		return s.onPendingTransaction(transaction, addressAssociation)
+	}
+
+	// Make sure the rate is known before the transaction is marked as processed,
+	// otherwise the block is processed again.
+	if s.PriceOracle != nil {
+		_, err = s.PriceOracle.Rate(transaction.AssetCode)
+		if err != nil {
+			return errors.Wrap(err, "Error getting rate")
+		}
+	}
+
+	// Transactions are seen as pending first if more than one confirmation is required.
+	seen := false
+	if transaction.RequiredConfirmations > 1 {
+		seen, err = s.Database.ConfirmPendingTransaction(transaction.Chain, transaction.Hash)
+		if err != nil {
+			return errors.Wrap(err, "Error confirming pending transaction")
+		}
+	}
+
+	// Add transaction as processing.
+	processed, err := s.Database.AddProcessedTransaction(transaction.Chain, transaction.Hash, transaction.To)
+	if err != nil {
+		return err
+	}
+
+	if processed {
+		localLog.Debug("Transaction already processed, skipping")
+		return nil
+	}
+
+	if !seen {
+		s.notify(webhooks.TransactionSeenEvent, transactionWebhookData(transaction, addressAssociation.StellarPublicKey, transaction.Amount))
+	}
+
+	// Check if value is above minimum required
+	if transaction.Value.Cmp(minimumValue) < 0 {
+		return s.onDust(transaction, addressAssociation, minimumValue)
+	}
+
+	// Add tx to the processing queue
+	queueTx := queue.Transaction{
+		TransactionID: transaction.Hash,
+		AssetCode:     transaction.AssetCode,
+		// Amount in the base unit of currency.
+		Amount:           transaction.Amount,
+		StellarPublicKey: addressAssociation.StellarPublicKey,
+	}
+
+	err = s.queueTransaction(transaction.Chain, transaction.To, queueTx)
+	if err != nil {
+		return errors.Wrap(err, "Error adding transaction to the processing queue")
+	}
+	localLog.Info("Transaction added to transaction queue")
+
+	s.notify(webhooks.ConfirmationsReachedEvent, transactionWebhookData(transaction, addressAssociation.StellarPublicKey, transaction.Amount))
+
+	// Broadcast event to address stream
+	s.SSEServer.BroadcastEvent(transaction.To, sse.TransactionReceivedAddressEvent, nil)
+	localLog.Info("Transaction processed successfully")
+	return nil
+}
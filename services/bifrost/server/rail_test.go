@@ -0,0 +1,241 @@
+// Skip this test file in Go <1.8 because it's using http.Server.Shutdown
+// +build go1.8
+
+package server
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stellar/go/services/bifrost/chains"
+	"github.com/stellar/go/services/bifrost/config"
+	"github.com/stellar/go/services/bifrost/database"
+	"github.com/stellar/go/services/bifrost/queue"
+	"github.com/stellar/go/services/bifrost/sse"
+	"github.com/stellar/go/services/bifrost/webhooks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type RailTestSuite struct {
+	suite.Suite
+	Server        *Server
+	MockAdapter   *chains.MockAdapter
+	MockDatabase  *database.MockDatabase
+	MockQueue     *queue.MockQueue
+	MockSSEServer *sse.MockServer
+}
+
+func (suite *RailTestSuite) SetupTest() {
+	suite.MockAdapter = &chains.MockAdapter{}
+	suite.MockDatabase = &database.MockDatabase{}
+	suite.MockQueue = &queue.MockQueue{}
+	suite.MockSSEServer = &sse.MockServer{}
+
+	suite.Server = &Server{
+		Adapters: map[database.Chain]chains.ChainAdapter{
+			database.ChainBitcoin: suite.MockAdapter,
+		},
+		Config:            &config.Config{},
+		Database:          suite.MockDatabase,
+		TransactionsQueue: suite.MockQueue,
+		SSEServer:         suite.MockSSEServer,
+	}
+	suite.Server.initLogger()
+}
+
+func (suite *RailTestSuite) TearDownTest() {
+	suite.MockAdapter.AssertExpectations(suite.T())
+	suite.MockDatabase.AssertExpectations(suite.T())
+	suite.MockQueue.AssertExpectations(suite.T())
+	suite.MockSSEServer.AssertExpectations(suite.T())
+}
+
+func (suite *RailTestSuite) TestAssociationNotExist() {
+	transaction := chains.Transaction{
+		Chain:     database.ChainBitcoin,
+		Hash:      "109fa1c369680c2f27643fdd160620d010851a376d25b9b00ef71afe789ea6ed",
+		Index:     0,
+		To:        "1Q74qRud8bXUn6FMtXWZwJa5pj56s3mdyf",
+		Value:     big.NewInt(100000000),
+		AssetCode: queue.AssetCodeBTC,
+		Amount:    "1.0000000",
+	}
+	suite.MockAdapter.On("MinimumValue", queue.AssetCodeBTC).Return(big.NewInt(100000000)) // 1 BTC
+	suite.MockDatabase.
+		On("GetAssociationByChainAddress", database.ChainBitcoin, "1Q74qRud8bXUn6FMtXWZwJa5pj56s3mdyf").
+		Return(nil, nil)
+	suite.MockDatabase.AssertNotCalled(suite.T(), "AddProcessedTransaction")
+	suite.MockQueue.AssertNotCalled(suite.T(), "QueueAdd")
+	err := suite.Server.onNewTransaction(transaction)
+	suite.Require().NoError(err)
+}
+
+func (suite *RailTestSuite) TestAssociationAlreadyProcessed() {
+	transaction := chains.Transaction{
+		Chain:     database.ChainBitcoin,
+		Hash:      "109fa1c369680c2f27643fdd160620d010851a376d25b9b00ef71afe789ea6ed",
+		Index:     0,
+		To:        "1Q74qRud8bXUn6FMtXWZwJa5pj56s3mdyf",
+		Value:     big.NewInt(100000000),
+		AssetCode: queue.AssetCodeBTC,
+		Amount:    "1.0000000",
+	}
+	suite.MockAdapter.On("MinimumValue", queue.AssetCodeBTC).Return(big.NewInt(100000000)) // 1 BTC
+	association := &database.AddressAssociation{
+		Chain:            database.ChainBitcoin,
+		AddressIndex:     1,
+		Address:          "1Q74qRud8bXUn6FMtXWZwJa5pj56s3mdyf",
+		StellarPublicKey: "GDULKYRRVOMASFMXBYD4BYFRSHAKQDREEVVP2TMH2CER3DW2KATIOASB",
+		CreatedAt:        time.Now(),
+	}
+	suite.MockDatabase.
+		On("GetAssociationByChainAddress", database.ChainBitcoin, transaction.To).
+		Return(association, nil)
+	suite.MockDatabase.
+		On("AddProcessedTransaction", database.ChainBitcoin, transaction.Hash, transaction.To).
+		Return(true, nil)
+	suite.MockQueue.AssertNotCalled(suite.T(), "QueueAdd")
+	err := suite.Server.onNewTransaction(transaction)
+	suite.Require().NoError(err)
+}
+
+func (suite *RailTestSuite) TestAssociationSuccess() {
+	transaction := chains.Transaction{
+		Chain:     database.ChainBitcoin,
+		Hash:      "109fa1c369680c2f27643fdd160620d010851a376d25b9b00ef71afe789ea6ed",
+		Index:     0,
+		To:        "1Q74qRud8bXUn6FMtXWZwJa5pj56s3mdyf",
+		Value:     big.NewInt(100000000),
+		AssetCode: queue.AssetCodeBTC,
+		Amount:    "1.0000000",
+	}
+	suite.MockAdapter.On("MinimumValue", queue.AssetCodeBTC).Return(big.NewInt(100000000)) // 1 BTC
+	association := &database.AddressAssociation{
+		Chain:            database.ChainBitcoin,
+		AddressIndex:     1,
+		Address:          "1Q74qRud8bXUn6FMtXWZwJa5pj56s3mdyf",
+		StellarPublicKey: "GDULKYRRVOMASFMXBYD4BYFRSHAKQDREEVVP2TMH2CER3DW2KATIOASB",
+		CreatedAt:        time.Now(),
+	}
+	suite.MockDatabase.
+		On("GetAssociationByChainAddress", database.ChainBitcoin, transaction.To).
+		Return(association, nil)
+	suite.MockDatabase.
+		On("AddProcessedTransaction", database.ChainBitcoin, transaction.Hash, transaction.To).
+		Return(false, nil)
+	suite.MockDatabase.
+		On("AddIssuance", mock.AnythingOfType("database.Issuance")).
+		Return(nil).
+		Run(func(args mock.Arguments) {
+			issuance := args.Get(0).(database.Issuance)
+			suite.Assert().Equal(database.ChainBitcoin, issuance.Chain)
+			suite.Assert().Equal(transaction.Hash, issuance.TransactionID)
+			suite.Assert().Equal(transaction.To, issuance.Address)
+			suite.Assert().Equal(association.StellarPublicKey, issuance.StellarPublicKey)
+			suite.Assert().Equal("1.0000000", issuance.Amount)
+			suite.Assert().Equal("1.0000000", issuance.DepositAmount)
+			suite.Assert().Equal(database.IssuanceStateQueued, issuance.State)
+		})
+	suite.MockQueue.
+		On("QueueAdd", mock.AnythingOfType("queue.Transaction")).
+		Return(nil).
+		Run(func(args mock.Arguments) {
+			queueTransaction := args.Get(0).(queue.Transaction)
+			suite.Assert().Equal(transaction.Hash, queueTransaction.TransactionID)
+			suite.Assert().Equal("BTC", string(queue.AssetCodeBTC))
+			suite.Assert().Equal(queue.AssetCodeBTC, queueTransaction.AssetCode)
+			suite.Assert().Equal("1.0000000", queueTransaction.Amount)
+			suite.Assert().Equal(association.StellarPublicKey, queueTransaction.StellarPublicKey)
+		})
+	suite.MockSSEServer.
+		On("BroadcastEvent", transaction.To, sse.TransactionReceivedAddressEvent, []byte(nil))
+	err := suite.Server.onNewTransaction(transaction)
+	suite.Require().NoError(err)
+}
+
+func (suite *RailTestSuite) TestAssociationSuccessWebhooks() {
+	mockNotifier := &webhooks.MockNotifier{}
+	suite.Server.Webhooks = mockNotifier
+
+	transaction := chains.Transaction{
+		Chain:     database.ChainBitcoin,
+		Hash:      "109fa1c369680c2f27643fdd160620d010851a376d25b9b00ef71afe789ea6ed",
+		Index:     0,
+		To:        "1Q74qRud8bXUn6FMtXWZwJa5pj56s3mdyf",
+		Value:     big.NewInt(100000000),
+		AssetCode: queue.AssetCodeBTC,
+		Amount:    "1.0000000",
+	}
+	suite.MockAdapter.On("MinimumValue", queue.AssetCodeBTC).Return(big.NewInt(100000000)) // 1 BTC
+	association := &database.AddressAssociation{
+		Chain:            database.ChainBitcoin,
+		AddressIndex:     1,
+		Address:          "1Q74qRud8bXUn6FMtXWZwJa5pj56s3mdyf",
+		StellarPublicKey: "GDULKYRRVOMASFMXBYD4BYFRSHAKQDREEVVP2TMH2CER3DW2KATIOASB",
+		CreatedAt:        time.Now(),
+	}
+	suite.MockDatabase.
+		On("GetAssociationByChainAddress", database.ChainBitcoin, transaction.To).
+		Return(association, nil)
+	suite.MockDatabase.
+		On("AddProcessedTransaction", database.ChainBitcoin, transaction.Hash, transaction.To).
+		Return(false, nil)
+	suite.MockDatabase.
+		On("AddIssuance", mock.AnythingOfType("database.Issuance")).
+		Return(nil)
+	suite.MockQueue.
+		On("QueueAdd", mock.AnythingOfType("queue.Transaction")).
+		Return(nil)
+	suite.MockSSEServer.
+		On("BroadcastEvent", transaction.To, sse.TransactionReceivedAddressEvent, []byte(nil))
+
+	data := map[string]string{
+		"chain":              "bitcoin",
+		"transaction_id":     transaction.Hash,
+		"address":            transaction.To,
+		"asset_code":         "BTC",
+		"amount":             "1.0000000",
+		"stellar_public_key": association.StellarPublicKey,
+	}
+	mockNotifier.On("Notify", webhooks.TransactionSeenEvent, data).Once()
+	mockNotifier.On("Notify", webhooks.ConfirmationsReachedEvent, data).Once()
+
+	err := suite.Server.onNewTransaction(transaction)
+	suite.Require().NoError(err)
+	mockNotifier.AssertExpectations(suite.T())
+}
+
+func (suite *RailTestSuite) TestUnknownChain() {
+	transaction := chains.Transaction{
+		Chain: database.Chain("litecoin"),
+		Hash:  "109fa1c369680c2f27643fdd160620d010851a376d25b9b00ef71afe789ea6ed",
+		To:    "LQ74qRud8bXUn6FMtXWZwJa5pj56s3mdyf",
+		Value: big.NewInt(100000000),
+	}
+	suite.MockDatabase.AssertNotCalled(suite.T(), "AddProcessedTransaction")
+	suite.MockQueue.AssertNotCalled(suite.T(), "QueueAdd")
+	err := suite.Server.onNewTransaction(transaction)
+	suite.Require().Error(err)
+}
+
+func (suite *RailTestSuite) TestAssetNotAccepted() {
+	transaction := chains.Transaction{
+		Chain:     database.ChainBitcoin,
+		Hash:      "109fa1c369680c2f27643fdd160620d010851a376d25b9b00ef71afe789ea6ed",
+		To:        "1Q74qRud8bXUn6FMtXWZwJa5pj56s3mdyf",
+		Value:     big.NewInt(100000000),
+		AssetCode: queue.AssetCode("USDT"),
+		Amount:    "1.0000000",
+	}
+	suite.MockAdapter.On("MinimumValue", transaction.AssetCode).Return(nil)
+	suite.MockDatabase.AssertNotCalled(suite.T(), "GetAssociationByChainAddress")
+	suite.MockQueue.AssertNotCalled(suite.T(), "QueueAdd")
+	err := suite.Server.onNewTransaction(transaction)
+	suite.Require().NoError(err)
+}
+
+func TestRailTestSuite(t *testing.T) {
+	suite.Run(t, new(RailTestSuite))
+}
@@ -0,0 +1,160 @@
+package server
+
+import (
+	"time"
+
+	"github.com/stellar/go/services/bifrost/chains"
+	"github.com/stellar/go/services/bifrost/database"
+	"github.com/stellar/go/services/bifrost/queue"
+	"github.com/stellar/go/support/errors"
+)
+
+// refundPendingIssuance adds refund of the deposit of issuance marked refund
+// pending. Refunds are sent by processRefunds once approved: right away or
+// after operator's approval if manual approval is enabled. If the refund can't
+// be added the issuance remains refund pending so it can be refunded using the
+// admin API.
+func (s *Server) refundPendingIssuance(transactionID string) {
+	localLog := s.log.WithField("transactionID", transactionID)
+
+	issuance, err := s.Database.GetIssuance(transactionID)
+	if err != nil {
+		localLog.WithField("err", err).Error("Error getting issuance to refund")
+		return
+	}
+
+	if issuance == nil {
+		localLog.Error("Issuance to refund not found")
+		return
+	}
+
+	state := database.RefundStateApproved
+	if s.Config.Refunds.ManualApproval {
+		state = database.RefundStatePendingApproval
+	}
+
+	refund, err := s.refundIssuance(*issuance, state)
+	if err != nil {
+		localLog.WithField("err", err).Error("Error adding refund")
+		return
+	}
+
+	if refund == nil {
+		localLog.Info("Issuance is not refund pending anymore, skipping refund")
+		return
+	}
+
+	localLog.WithField("refund", refund).Info("Refund added")
+}
+
+// refundIssuance adds refund of the deposit of `issuance` to the address it was
+// sent from and marks the issuance refunded. It returns nil refund if issuance
+// can't be refunded in its current state.
+func (s *Server) refundIssuance(issuance database.Issuance, state database.RefundState) (*database.Refund, error) {
+	adapter := s.Adapters[issuance.Chain]
+	refunder, ok := adapter.(chains.Refunder)
+	if !ok {
+		return nil, errors.New("No adapter supporting refunds for chain: " + string(issuance.Chain))
+	}
+
+	if _, ok := adapter.(chains.Withdrawer); !ok {
+		return nil, errors.New("No adapter supporting withdrawals for chain: " + string(issuance.Chain))
+	}
+
+	assetCode, amount := issuance.DepositAssetCode, issuance.DepositAmount
+	// Issuances added before refunds were supported
+	if assetCode == "" {
+		assetCode, amount = issuance.AssetCode, issuance.Amount
+	}
+
+	if adapter.MinimumValue(queue.AssetCode(assetCode)) == nil {
+		return nil, errors.New("Deposited asset not accepted in the chain: " + assetCode)
+	}
+
+	address, err := refunder.RefundAddress(issuance.TransactionID)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error getting refund address")
+	}
+
+	now := time.Now()
+	refund := database.Refund{
+		TransactionID: issuance.TransactionID,
+		Chain:         issuance.Chain,
+		Address:       address,
+		AssetCode:     assetCode,
+		Amount:        amount,
+		State:         state,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+
+	added, err := s.Database.AddRefund(refund)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error adding refund")
+	}
+
+	if !added {
+		return nil, nil
+	}
+
+	return &refund, nil
+}
+
+// processRefunds sends approved refunds using the chain adapters.
+func (s *Server) processRefunds() {
+	s.log.Info("Started processing refunds")
+
+	for {
+		refund, err := s.Database.ClaimRefund()
+		if err != nil {
+			s.log.WithField("err", err).Error("Error claiming refund")
+			time.Sleep(time.Second)
+			continue
+		}
+
+		if refund == nil {
+			time.Sleep(time.Second)
+			continue
+		}
+
+		s.sendRefund(refund)
+	}
+}
+
+func (s *Server) sendRefund(refund *database.Refund) {
+	localLog := s.log.WithField("refund", refund)
+	localLog.Info("Sending refund")
+
+	state := database.RefundStateSent
+	var chainTransactionID, errorMessage string
+
+	withdrawer, ok := s.Adapters[refund.Chain].(chains.Withdrawer)
+	if ok {
+		var err error
+		chainTransactionID, err = withdrawer.Withdraw(refund.Address, queue.AssetCode(refund.AssetCode), refund.Amount)
+		if err != nil {
+			state = database.RefundStateFailed
+			errorMessage = err.Error()
+		}
+	} else {
+		state = database.RefundStateFailed
+		errorMessage = "No adapter supporting withdrawals for chain: " + string(refund.Chain)
+	}
+
+	for {
+		err := s.Database.FinishRefund(refund.TransactionID, state, chainTransactionID, errorMessage)
+		if err == nil {
+			break
+		}
+		// Refund is already sent so we can't give up saving the result.
+		localLog.WithField("err", err).Error("Error saving refund result, retrying")
+		time.Sleep(time.Second)
+	}
+
+	if state == database.RefundStateFailed {
+		localLog.WithField("err", errorMessage).Error("Refund failed")
+		return
+	}
+
+	localLog.WithField("chain_transaction_id", chainTransactionID).Info("Refund sent")
+}
@@ -0,0 +1,161 @@
+// Skip this test file in Go <1.8 because it's using http.Server.Shutdown
+// +build go1.8
+
+package server
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/stellar/go/services/bifrost/chains"
+	"github.com/stellar/go/services/bifrost/config"
+	"github.com/stellar/go/services/bifrost/database"
+	"github.com/stellar/go/services/bifrost/queue"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type RefundTestSuite struct {
+	suite.Suite
+	Server       *Server
+	MockRefunder *chains.MockRefunder
+	MockDatabase *database.MockDatabase
+}
+
+func (suite *RefundTestSuite) SetupTest() {
+	suite.MockRefunder = &chains.MockRefunder{}
+	suite.MockDatabase = &database.MockDatabase{}
+
+	suite.Server = &Server{
+		Adapters: map[database.Chain]chains.ChainAdapter{
+			database.ChainBitcoin: suite.MockRefunder,
+		},
+		Config:   &config.Config{Refunds: &config.RefundsConfig{}},
+		Database: suite.MockDatabase,
+	}
+	suite.Server.initLogger()
+}
+
+func (suite *RefundTestSuite) TearDownTest() {
+	suite.MockRefunder.AssertExpectations(suite.T())
+	suite.MockDatabase.AssertExpectations(suite.T())
+}
+
+func (suite *RefundTestSuite) issuance() *database.Issuance {
+	return &database.Issuance{
+		Chain:            database.ChainBitcoin,
+		TransactionID:    "109fa1c369680c2f27643fdd160620d010851a376d25b9b00ef71afe789ea6ed",
+		Address:          "1Q74qRud8bXUn6FMtXWZwJa5pj56s3mdyf",
+		StellarPublicKey: "GDULKYRRVOMASFMXBYD4BYFRSHAKQDREEVVP2TMH2CER3DW2KATIOASB",
+		AssetCode:        "TOKE",
+		Amount:           "15000.0000000",
+		DepositAssetCode: "BTC",
+		DepositAmount:    "1.5000000",
+		State:            database.IssuanceStateRefundPending,
+	}
+}
+
+func (suite *RefundTestSuite) expectRefund(state database.RefundState, added bool) {
+	suite.MockDatabase.
+		On("AddRefund", mock.AnythingOfType("database.Refund")).
+		Return(added, nil).
+		Run(func(args mock.Arguments) {
+			refund := args.Get(0).(database.Refund)
+			suite.Assert().Equal("109fa1c369680c2f27643fdd160620d010851a376d25b9b00ef71afe789ea6ed", refund.TransactionID)
+			suite.Assert().Equal(database.ChainBitcoin, refund.Chain)
+			suite.Assert().Equal("17HCcV6BseYXaZaBXAPZqtCGQTJB9ZKsYS", refund.Address)
+			suite.Assert().Equal("BTC", refund.AssetCode)
+			suite.Assert().Equal("1.5000000", refund.Amount)
+			suite.Assert().Equal(state, refund.State)
+		})
+}
+
+func (suite *RefundTestSuite) TestRefundPendingIssuance() {
+	issuance := suite.issuance()
+	suite.MockDatabase.On("GetIssuance", issuance.TransactionID).Return(issuance, nil)
+	suite.MockRefunder.On("MinimumValue", queue.AssetCodeBTC).Return(big.NewInt(1))
+	suite.MockRefunder.On("RefundAddress", issuance.TransactionID).Return("17HCcV6BseYXaZaBXAPZqtCGQTJB9ZKsYS", nil)
+	suite.expectRefund(database.RefundStateApproved, true)
+	suite.Server.refundPendingIssuance(issuance.TransactionID)
+}
+
+func (suite *RefundTestSuite) TestRefundPendingIssuanceManualApproval() {
+	suite.Server.Config.Refunds.ManualApproval = true
+	issuance := suite.issuance()
+	suite.MockDatabase.On("GetIssuance", issuance.TransactionID).Return(issuance, nil)
+	suite.MockRefunder.On("MinimumValue", queue.AssetCodeBTC).Return(big.NewInt(1))
+	suite.MockRefunder.On("RefundAddress", issuance.TransactionID).Return("17HCcV6BseYXaZaBXAPZqtCGQTJB9ZKsYS", nil)
+	suite.expectRefund(database.RefundStatePendingApproval, true)
+	suite.Server.refundPendingIssuance(issuance.TransactionID)
+}
+
+func (suite *RefundTestSuite) TestRefundIssuanceNotRefundable() {
+	issuance := suite.issuance()
+	suite.MockRefunder.On("MinimumValue", queue.AssetCodeBTC).Return(big.NewInt(1))
+	suite.MockRefunder.On("RefundAddress", issuance.TransactionID).Return("17HCcV6BseYXaZaBXAPZqtCGQTJB9ZKsYS", nil)
+	suite.expectRefund(database.RefundStateApproved, false)
+
+	refund, err := suite.Server.refundIssuance(*issuance, database.RefundStateApproved)
+	suite.Require().NoError(err)
+	suite.Assert().Nil(refund)
+}
+
+func (suite *RefundTestSuite) TestRefundIssuanceAddressError() {
+	issuance := suite.issuance()
+	suite.MockRefunder.On("MinimumValue", queue.AssetCodeBTC).Return(big.NewInt(1))
+	suite.MockRefunder.On("RefundAddress", issuance.TransactionID).Return("", errors.New("No such mempool or blockchain transaction"))
+
+	_, err := suite.Server.refundIssuance(*issuance, database.RefundStateApproved)
+	suite.Assert().Error(err)
+	suite.MockDatabase.AssertNotCalled(suite.T(), "AddRefund")
+}
+
+func (suite *RefundTestSuite) TestRefundIssuanceChainNotSupported() {
+	issuance := suite.issuance()
+	issuance.Chain = database.ChainEthereum
+
+	_, err := suite.Server.refundIssuance(*issuance, database.RefundStateApproved)
+	suite.Assert().Error(err)
+	suite.MockDatabase.AssertNotCalled(suite.T(), "AddRefund")
+}
+
+func (suite *RefundTestSuite) TestSendRefund() {
+	refund := &database.Refund{
+		TransactionID: "109fa1c369680c2f27643fdd160620d010851a376d25b9b00ef71afe789ea6ed",
+		Chain:         database.ChainBitcoin,
+		Address:       "17HCcV6BseYXaZaBXAPZqtCGQTJB9ZKsYS",
+		AssetCode:     "BTC",
+		Amount:        "1.5000000",
+		State:         database.RefundStateSending,
+	}
+	suite.MockRefunder.
+		On("Withdraw", refund.Address, queue.AssetCodeBTC, refund.Amount).
+		Return("a1a6bd2e3ef1ec4e0c94c1f5d8d0e8d4c6a3b3c0c7a0f6e0d2b1e3f4a5b6c7d8", nil)
+	suite.MockDatabase.
+		On("FinishRefund", refund.TransactionID, database.RefundStateSent, "a1a6bd2e3ef1ec4e0c94c1f5d8d0e8d4c6a3b3c0c7a0f6e0d2b1e3f4a5b6c7d8", "").
+		Return(nil)
+	suite.Server.sendRefund(refund)
+}
+
+func (suite *RefundTestSuite) TestSendRefundFailed() {
+	refund := &database.Refund{
+		TransactionID: "109fa1c369680c2f27643fdd160620d010851a376d25b9b00ef71afe789ea6ed",
+		Chain:         database.ChainBitcoin,
+		Address:       "17HCcV6BseYXaZaBXAPZqtCGQTJB9ZKsYS",
+		AssetCode:     "BTC",
+		Amount:        "1.5000000",
+		State:         database.RefundStateSending,
+	}
+	suite.MockRefunder.
+		On("Withdraw", refund.Address, queue.AssetCodeBTC, refund.Amount).
+		Return("", errors.New("Insufficient funds"))
+	suite.MockDatabase.
+		On("FinishRefund", refund.TransactionID, database.RefundStateFailed, "", "Insufficient funds").
+		Return(nil)
+	suite.Server.sendRefund(refund)
+}
+
+func TestRefundTestSuite(t *testing.T) {
+	suite.Run(t, new(RefundTestSuite))
+}
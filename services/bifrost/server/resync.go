@@ -0,0 +1,47 @@
+package server
+
+import (
+	"github.com/stellar/go/services/bifrost/chains"
+	"github.com/stellar/go/services/bifrost/database"
+	"github.com/stellar/go/support/errors"
+	"github.com/stellar/go/support/log"
+)
+
+// Resync rescans `chain` blocks from `fromBlock` to `toBlock` (inclusive) and
+// processes transactions to generated addresses missed during downtime. It's
+// safe to resync blocks processed before: already processed transactions are
+// skipped. Transactions are added to the transactions queue and submitted to
+// Stellar by the running server.
+func (s *Server) Resync(chain database.Chain, fromBlock, toBlock uint64) error {
+	s.initLogger()
+
+	if fromBlock > toBlock {
+		return errors.New("fromBlock must not be greater than toBlock")
+	}
+
+	err := s.validateDustPolicies()
+	if err != nil {
+		return err
+	}
+
+	adapter, exists := s.Adapters[chain]
+	if !exists {
+		return errors.New("Chain not configured: " + string(chain))
+	}
+
+	rescanner, ok := adapter.(chains.Rescanner)
+	if !ok {
+		return errors.New("Chain doesn't support rescanning blocks: " + string(chain))
+	}
+
+	s.log.WithFields(log.F{"chain": chain, "fromBlock": fromBlock, "toBlock": toBlock}).Info("Resyncing blocks")
+	adapter.StreamTransactions(s.onNewTransaction)
+
+	err = rescanner.Rescan(fromBlock, toBlock)
+	if err != nil {
+		return errors.Wrap(err, "Error rescanning blocks")
+	}
+
+	s.log.Info("Resync finished")
+	return nil
+}
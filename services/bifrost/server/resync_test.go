@@ -0,0 +1,49 @@
+// Skip this test file in Go <1.8 because it's using http.Server.Shutdown
+// +build go1.8
+
+package server
+
+import (
+	"errors"
+
+	"github.com/stellar/go/services/bifrost/chains"
+	"github.com/stellar/go/services/bifrost/database"
+	"github.com/stretchr/testify/mock"
+)
+
+func (suite *RailTestSuite) TestResync() {
+	rescanner := &chains.MockRescanner{}
+	suite.Server.Adapters[database.ChainEthereum] = rescanner
+
+	rescanner.On("StreamTransactions", mock.AnythingOfType("chains.TransactionHandler")).Once()
+	rescanner.On("Rescan", uint64(100), uint64(200)).Return(nil).Once()
+
+	err := suite.Server.Resync(database.ChainEthereum, 100, 200)
+	suite.Require().NoError(err)
+	rescanner.AssertExpectations(suite.T())
+}
+
+func (suite *RailTestSuite) TestResyncError() {
+	rescanner := &chains.MockRescanner{}
+	suite.Server.Adapters[database.ChainEthereum] = rescanner
+
+	rescanner.On("StreamTransactions", mock.AnythingOfType("chains.TransactionHandler")).Once()
+	rescanner.On("Rescan", uint64(100), uint64(200)).Return(errors.New("Block 200 doesn't have 6 confirmations yet")).Once()
+
+	err := suite.Server.Resync(database.ChainEthereum, 100, 200)
+	suite.Assert().Error(err)
+}
+
+func (suite *RailTestSuite) TestResyncInvalid() {
+	// Not configured
+	err := suite.Server.Resync(database.ChainEthereum, 100, 200)
+	suite.Assert().Error(err)
+
+	// MockAdapter doesn't support rescanning
+	err = suite.Server.Resync(database.ChainBitcoin, 100, 200)
+	suite.Assert().Error(err)
+
+	suite.Server.Adapters[database.ChainEthereum] = &chains.MockRescanner{}
+	err = suite.Server.Resync(database.ChainEthereum, 200, 100)
+	suite.Assert().Error(err)
+}
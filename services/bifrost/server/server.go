@@ -6,7 +6,6 @@ package server
 import (
 	"context"
 	"encoding/json"
-	"math/big"
 	"net/http"
 	"os"
 	"os/signal"
@@ -14,10 +13,12 @@ import (
 
 	"github.com/go-chi/chi/middleware"
 	"github.com/stellar/go/keypair"
-	"github.com/stellar/go/services/bifrost/bitcoin"
+	"github.com/stellar/go/services/bifrost/chains"
 	"github.com/stellar/go/services/bifrost/common"
 	"github.com/stellar/go/services/bifrost/database"
-	"github.com/stellar/go/services/bifrost/ethereum"
+	"github.com/stellar/go/services/bifrost/metrics"
+	"github.com/stellar/go/services/bifrost/queue"
+	"github.com/stellar/go/services/bifrost/webhooks"
 	"github.com/stellar/go/support/errors"
 	"github.com/stellar/go/support/http/server"
 	"github.com/stellar/go/support/log"
@@ -29,58 +30,76 @@ func (s *Server) Start() error {
 	s.log.Info("Server starting")
 
 	// Register callbacks
-	s.BitcoinListener.TransactionHandler = s.onNewBitcoinTransaction
-	s.EthereumListener.TransactionHandler = s.onNewEthereumTransaction
 	s.StellarAccountConfigurator.OnAccountCreated = s.onStellarAccountCreated
 	s.StellarAccountConfigurator.OnAccountCredited = s.onStellarAccountCredited
 
-	if !s.BitcoinListener.Enabled && !s.EthereumListener.Enabled {
-		return errors.New("At least one listener (BitcoinListener or EthereumListener) must be enabled")
+	if len(s.Adapters) == 0 {
+		return errors.New("At least one chain (bitcoin or ethereum) must be configured")
 	}
 
-	if s.BitcoinListener.Enabled {
-		var err error
-		s.minimumValueSat, err = bitcoin.BtcToSat(s.MinimumValueBtc)
-		if err != nil {
-			return errors.Wrap(err, "Invalid minimum accepted Bitcoin transaction value: "+s.MinimumValueBtc)
-		}
+	err := s.validateDustPolicies()
+	if err != nil {
+		return err
+	}
 
-		if s.minimumValueSat == 0 {
-			return errors.New("Minimum accepted Bitcoin transaction value must be larger than 0")
+	if s.Cluster != nil {
+		if _, ok := s.TransactionsQueue.(queue.ShardedQueue); !ok {
+			return errors.New("Transactions queue must support shards when cluster is configured")
 		}
 
-		err = s.BitcoinListener.Start()
+		err = s.Cluster.Start()
 		if err != nil {
-			return errors.Wrap(err, "Error starting BitcoinListener")
+			return errors.Wrap(err, "Error starting Coordinator")
 		}
-	} else {
-		s.log.Warn("BitcoinListener disabled")
 	}
 
-	if s.EthereumListener.Enabled {
-		var err error
-		s.minimumValueWei, err = ethereum.EthToWei(s.MinimumValueEth)
-		if err != nil {
-			return errors.Wrap(err, "Invalid minimum accepted Ethereum transaction value")
-		}
+	for chain, adapter := range s.Adapters {
+		adapter.StreamTransactions(s.onNewTransaction)
+		adapter.StreamBlocks(s.onNewBlock)
 
-		if s.minimumValueWei.Cmp(new(big.Int)) == 0 {
-			return errors.New("Minimum accepted Ethereum transaction value must be larger than 0")
+		if s.Cluster != nil {
+			// Blocks are ingested by the leader only
+			s.Cluster.Lead("ingestion_"+string(chain), adapter.Start)
+			continue
 		}
 
-		err = s.EthereumListener.Start(s.Config.Ethereum.RpcServer)
+		err := adapter.Start()
 		if err != nil {
-			return errors.Wrap(err, "Error starting EthereumListener")
+			return errors.Wrap(err, "Error starting "+string(chain)+" adapter")
 		}
-	} else {
-		s.log.Warn("EthereumListener disabled")
 	}
 
-	err := s.StellarAccountConfigurator.Start()
+	err = s.StellarAccountConfigurator.Start()
 	if err != nil {
 		return errors.Wrap(err, "Error starting StellarAccountConfigurator")
 	}
 
+	if s.WithdrawalListener != nil {
+		s.WithdrawalListener.WithdrawalHandler = s.onWithdrawal
+
+		if s.Cluster != nil {
+			s.Cluster.Lead("withdrawals", s.WithdrawalListener.Start)
+		} else {
+			err = s.WithdrawalListener.Start()
+			if err != nil {
+				return errors.Wrap(err, "Error starting StellarWithdrawalListener")
+			}
+		}
+
+		go s.processWithdrawals()
+	}
+
+	if s.Config.Refunds != nil {
+		go s.processRefunds()
+	}
+
+	if s.Webhooks != nil {
+		err = s.Webhooks.Start()
+		if err != nil {
+			return errors.Wrap(err, "Error starting WebhooksNotifier")
+		}
+	}
+
 	err = s.SSEServer.StartPublishing()
 	if err != nil {
 		return errors.Wrap(err, "Error starting SSE Server")
@@ -120,10 +139,22 @@ func (s *Server) startHTTPServer() {
 	server.AddBasicMiddleware(muxConfig)
 
 	muxConfig.Route(http.MethodGet, "/events", s.HandlerEvents)
-	muxConfig.Route(http.MethodPost, "/generate-bitcoin-address", s.HandlerGenerateBitcoinAddress)
-	muxConfig.Route(http.MethodPost, "/generate-ethereum-address", s.HandlerGenerateEthereumAddress)
+	muxConfig.Route(http.MethodGet, "/metrics", metrics.Handler)
+	for chain := range s.Adapters {
+		muxConfig.Route(http.MethodPost, "/generate-"+string(chain)+"-address", s.HandlerGenerateAddress(chain))
+	}
 	muxConfig.Route(http.MethodPost, "/recovery-transaction", s.HandlerRecoveryTransaction)
 
+	if s.Config.Admin != nil {
+		muxConfig.Route(http.MethodGet, "/admin/addresses", s.AdminHandler(s.HandlerAdminAddresses))
+		muxConfig.Route(http.MethodGet, "/admin/transactions", s.AdminHandler(s.HandlerAdminTransactions))
+		muxConfig.Route(http.MethodPost, "/admin/transactions/retry", s.AdminHandler(s.HandlerAdminRetryTransaction))
+		muxConfig.Route(http.MethodPost, "/admin/transactions/resolve", s.AdminHandler(s.HandlerAdminResolveTransaction))
+		if s.Config.Refunds != nil {
+			muxConfig.Route(http.MethodPost, "/admin/transactions/refund", s.AdminHandler(s.HandlerAdminRefundTransaction))
+		}
+	}
+
 	r := server.NewRouter(muxConfig)
 
 	server.Serve(r, s.Config.Port, nil)
@@ -134,18 +165,15 @@ func (s *Server) HandlerEvents(w http.ResponseWriter, r *http.Request) {
 	// This is required to restart a stream after server restart or failure.
 	address := r.URL.Query().Get("stream")
 	if !s.SSEServer.StreamExists(address) {
-		var chain database.Chain
-
 		if len(address) == 0 {
 			w.WriteHeader(http.StatusBadRequest)
 			return
 		}
 
-		if address[0] == '0' {
-			chain = database.ChainEthereum
-		} else {
-			// 1 or m, n in testnet
-			chain = database.ChainBitcoin
+		chain, exists := s.addressChain(address)
+		if !exists {
+			w.WriteHeader(http.StatusBadRequest)
+			return
 		}
 
 		association, err := s.Database.GetAssociationByChainAddress(chain, address)
@@ -163,12 +191,37 @@ func (s *Server) HandlerEvents(w http.ResponseWriter, r *http.Request) {
 	s.SSEServer.HTTPHandler(w, r)
 }
 
-func (s *Server) HandlerGenerateBitcoinAddress(w http.ResponseWriter, r *http.Request) {
-	s.handlerGenerateAddress(w, r, database.ChainBitcoin)
+// gapLimitReached returns true if a new `chain` address would exceed the gap
+// limit of the adapter: the number of consecutive addresses without deposits.
+func (s *Server) gapLimitReached(chain database.Chain) (bool, error) {
+	limiter, ok := s.Adapters[chain].(chains.GapLimiter)
+	if !ok || limiter.GapLimit() == 0 {
+		return false, nil
+	}
+
+	unused, err := s.Database.CountUnusedAddresses(chain)
+	if err != nil {
+		return false, err
+	}
+
+	return unused >= limiter.GapLimit(), nil
+}
+
+// addressChain returns the chain of the adapter that validates `address`.
+func (s *Server) addressChain(address string) (database.Chain, bool) {
+	for chain, adapter := range s.Adapters {
+		if adapter.ValidateAddress(address) == nil {
+			return chain, true
+		}
+	}
+	return "", false
 }
 
-func (s *Server) HandlerGenerateEthereumAddress(w http.ResponseWriter, r *http.Request) {
-	s.handlerGenerateAddress(w, r, database.ChainEthereum)
+// HandlerGenerateAddress returns the handler generating `chain` addresses.
+func (s *Server) HandlerGenerateAddress(chain database.Chain) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		s.handlerGenerateAddress(w, r, chain)
+	}
 }
 
 func (s *Server) handlerGenerateAddress(w http.ResponseWriter, r *http.Request, chain database.Chain) {
@@ -182,26 +235,27 @@ func (s *Server) handlerGenerateAddress(w http.ResponseWriter, r *http.Request,
 		return
 	}
 
-	index, err := s.Database.IncrementAddressIndex(chain)
+	gapLimitReached, err := s.gapLimitReached(chain)
 	if err != nil {
-		log.WithField("err", err).Error("Error incrementing address index")
+		log.WithField("err", err).Error("Error checking address gap limit")
 		w.WriteHeader(http.StatusInternalServerError)
 		return
 	}
 
-	var address string
+	if gapLimitReached {
+		log.WithField("chain", chain).Error("Address gap limit reached, no new addresses until deposits are received")
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
 
-	switch chain {
-	case database.ChainBitcoin:
-		address, err = s.BitcoinAddressGenerator.Generate(index)
-	case database.ChainEthereum:
-		address, err = s.EthereumAddressGenerator.Generate(index)
-	default:
-		log.WithField("chain", chain).Error("Invalid chain")
+	index, err := s.Database.IncrementAddressIndex(chain)
+	if err != nil {
+		log.WithField("err", err).Error("Error incrementing address index")
 		w.WriteHeader(http.StatusInternalServerError)
 		return
 	}
 
+	address, err := s.Adapters[chain].DeriveAddress(index)
 	if err != nil {
 		log.WithFields(log.F{"err": err, "index": index}).Error("Error generating address")
 		w.WriteHeader(http.StatusInternalServerError)
@@ -221,9 +275,17 @@ func (s *Server) handlerGenerateAddress(w http.ResponseWriter, r *http.Request,
 		return
 	}
 
+	metrics.AddressGenerated(chain)
+
 	// Create SSE stream
 	s.SSEServer.CreateStream(address)
 
+	s.notify(webhooks.AddressGeneratedEvent, map[string]string{
+		"chain":              string(chain),
+		"address":            address,
+		"stellar_public_key": stellarPublicKey,
+	})
+
 	response := GenerateAddressResponse{
 		ProtocolVersion: ProtocolVersion,
 		Chain:           string(chain),
@@ -0,0 +1,54 @@
+// Skip this test file in Go <1.8 because it's using http.Server.Shutdown
+// +build go1.8
+
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+
+	"github.com/stellar/go/services/bifrost/chains"
+	"github.com/stellar/go/services/bifrost/database"
+)
+
+func generateAddressRequest() *http.Request {
+	form := url.Values{"stellar_public_key": {"GDULKYRRVOMASFMXBYD4BYFRSHAKQDREEVVP2TMH2CER3DW2KATIOASB"}}
+	r := httptest.NewRequest(http.MethodPost, "/generate-bitcoin-address", strings.NewReader(form.Encode()))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return r
+}
+
+func (suite *RailTestSuite) TestGenerateAddressGapLimitReached() {
+	limiter := &chains.MockGapLimiter{}
+	suite.Server.Adapters[database.ChainBitcoin] = limiter
+
+	limiter.On("GapLimit").Return(uint32(20))
+	suite.MockDatabase.On("CountUnusedAddresses", database.ChainBitcoin).Return(uint32(20), nil)
+
+	w := httptest.NewRecorder()
+	suite.Server.handlerGenerateAddress(w, generateAddressRequest(), database.ChainBitcoin)
+	suite.Assert().Equal(http.StatusServiceUnavailable, w.Code)
+	suite.MockDatabase.AssertNotCalled(suite.T(), "IncrementAddressIndex", database.ChainBitcoin)
+}
+
+func (suite *RailTestSuite) TestGenerateAddressGapLimitNotReached() {
+	limiter := &chains.MockGapLimiter{}
+	suite.Server.Adapters[database.ChainBitcoin] = limiter
+
+	limiter.On("GapLimit").Return(uint32(20))
+	suite.MockDatabase.On("CountUnusedAddresses", database.ChainBitcoin).Return(uint32(19), nil)
+
+	reached, err := suite.Server.gapLimitReached(database.ChainBitcoin)
+	suite.Require().NoError(err)
+	suite.Assert().False(reached)
+
+	// No limit
+	limiter.ExpectedCalls = nil
+	limiter.On("GapLimit").Return(uint32(0))
+
+	reached, err = suite.Server.gapLimitReached(database.ChainBitcoin)
+	suite.Require().NoError(err)
+	suite.Assert().False(reached)
+}
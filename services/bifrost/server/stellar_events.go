@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 
 	"github.com/stellar/go/services/bifrost/sse"
+	"github.com/stellar/go/services/bifrost/webhooks"
 )
 
 func (s *Server) onStellarAccountCreated(destination string) {
@@ -19,6 +20,11 @@ func (s *Server) onStellarAccountCreated(destination string) {
 	}
 
 	s.SSEServer.BroadcastEvent(association.Address, sse.AccountCreatedAddressEvent, nil)
+	s.notify(webhooks.AccountCreatedEvent, map[string]string{
+		"chain":              string(association.Chain),
+		"address":            association.Address,
+		"stellar_public_key": destination,
+	})
 }
 
 func (s *Server) onStellarAccountCredited(destination, assetCode, amount string) {
@@ -44,4 +50,11 @@ func (s *Server) onStellarAccountCredited(destination, assetCode, amount string)
 	}
 
 	s.SSEServer.BroadcastEvent(association.Address, sse.AccountCreditedAddressEvent, j)
+	s.notify(webhooks.AssetDeliveredEvent, map[string]string{
+		"chain":              string(association.Chain),
+		"address":            association.Address,
+		"stellar_public_key": destination,
+		"asset_code":         assetCode,
+		"amount":             amount,
+	})
 }
@@ -0,0 +1,27 @@
+package server
+
+import (
+	"github.com/stellar/go/services/bifrost/chains"
+	"github.com/stellar/go/services/bifrost/webhooks"
+)
+
+// notify sends `event` webhook if webhooks are configured.
+func (s *Server) notify(event webhooks.Event, data map[string]string) {
+	if s.Webhooks == nil {
+		return
+	}
+	s.Webhooks.Notify(event, data)
+}
+
+// transactionWebhookData returns data of transaction events. `amount` can be
+// different than transaction amount for accumulated dust.
+func transactionWebhookData(transaction chains.Transaction, stellarPublicKey, amount string) map[string]string {
+	return map[string]string{
+		"chain":              string(transaction.Chain),
+		"transaction_id":     transaction.Hash,
+		"address":            transaction.To,
+		"asset_code":         string(transaction.AssetCode),
+		"amount":             amount,
+		"stellar_public_key": stellarPublicKey,
+	}
+}
@@ -0,0 +1,145 @@
+package server
+
+import (
+	"encoding/base64"
+	"time"
+
+	"github.com/stellar/go/clients/horizon"
+	"github.com/stellar/go/services/bifrost/chains"
+	"github.com/stellar/go/services/bifrost/database"
+	"github.com/stellar/go/services/bifrost/queue"
+	"github.com/stellar/go/support/errors"
+	"github.com/stellar/go/support/log"
+)
+
+// onWithdrawal adds a withdrawal streamed by StellarWithdrawalListener to the
+// database. Withdrawals are sent by processWithdrawals once approved: right away
+// or after operator's approval if manual approval is enabled. Withdrawals with
+// invalid address are added as failed so they can be refunded manually.
+func (s *Server) onWithdrawal(payment horizon.Payment) error {
+	localLog := s.log.WithFields(log.F{"id": payment.ID, "from": payment.From, "asset_code": payment.AssetCode})
+	localLog.Debug("Processing withdrawal")
+
+	assetCode := queue.AssetCode(payment.AssetCode)
+	chain, withdrawer := s.withdrawer(assetCode)
+	if withdrawer == nil {
+		localLog.Debug("Asset is not withdrawable, skipping")
+		return nil
+	}
+
+	withdrawal := database.Withdrawal{
+		PaymentID:        payment.ID,
+		StellarPublicKey: payment.From,
+		Chain:            chain,
+		AssetCode:        payment.AssetCode,
+		Amount:           payment.Amount,
+		State:            database.WithdrawalStateApproved,
+		CreatedAt:        time.Now(),
+		UpdatedAt:        time.Now(),
+	}
+
+	if s.Config.Withdrawals != nil && s.Config.Withdrawals.ManualApproval {
+		withdrawal.State = database.WithdrawalStatePendingApproval
+	}
+
+	address, err := s.decodeWithdrawalAddress(withdrawer, payment.Memo.Value)
+	if err != nil {
+		localLog.WithField("err", err).Warn("Invalid withdrawal address")
+		withdrawal.State = database.WithdrawalStateFailed
+		withdrawal.Error = err.Error()
+	}
+	withdrawal.Address = address
+
+	err = s.Database.AddWithdrawal(withdrawal)
+	if err != nil {
+		return errors.Wrap(err, "Error adding withdrawal")
+	}
+
+	localLog.WithField("state", withdrawal.State).Info("Withdrawal added")
+	return nil
+}
+
+// withdrawer returns the adapter supporting withdrawals of `assetCode`.
+func (s *Server) withdrawer(assetCode queue.AssetCode) (database.Chain, chains.Withdrawer) {
+	for chain, adapter := range s.Adapters {
+		if adapter.MinimumValue(assetCode) == nil {
+			continue
+		}
+
+		withdrawer, ok := adapter.(chains.Withdrawer)
+		if !ok {
+			return "", nil
+		}
+		return chain, withdrawer
+	}
+
+	return "", nil
+}
+
+func (s *Server) decodeWithdrawalAddress(withdrawer chains.Withdrawer, memo string) (string, error) {
+	memoBytes, err := base64.StdEncoding.DecodeString(memo)
+	if err != nil {
+		return "", errors.Wrap(err, "Error decoding memo")
+	}
+
+	return withdrawer.DecodeMemoAddress(memoBytes)
+}
+
+// processWithdrawals sends approved withdrawals using the chain adapters.
+func (s *Server) processWithdrawals() {
+	s.log.Info("Started processing withdrawals")
+
+	for {
+		withdrawal, err := s.Database.ClaimWithdrawal()
+		if err != nil {
+			s.log.WithField("err", err).Error("Error claiming withdrawal")
+			time.Sleep(time.Second)
+			continue
+		}
+
+		if withdrawal == nil {
+			time.Sleep(time.Second)
+			continue
+		}
+
+		s.sendWithdrawal(withdrawal)
+	}
+}
+
+func (s *Server) sendWithdrawal(withdrawal *database.Withdrawal) {
+	localLog := s.log.WithField("withdrawal", withdrawal)
+	localLog.Info("Sending withdrawal")
+
+	state := database.WithdrawalStateSent
+	var chainTransactionID, errorMessage string
+
+	withdrawer, ok := s.Adapters[withdrawal.Chain].(chains.Withdrawer)
+	if ok {
+		var err error
+		chainTransactionID, err = withdrawer.Withdraw(withdrawal.Address, queue.AssetCode(withdrawal.AssetCode), withdrawal.Amount)
+		if err != nil {
+			state = database.WithdrawalStateFailed
+			errorMessage = err.Error()
+		}
+	} else {
+		state = database.WithdrawalStateFailed
+		errorMessage = "No adapter supporting withdrawals for chain: " + string(withdrawal.Chain)
+	}
+
+	for {
+		err := s.Database.FinishWithdrawal(withdrawal.PaymentID, state, chainTransactionID, errorMessage)
+		if err == nil {
+			break
+		}
+		// Withdrawal is already sent so we can't give up saving the result.
+		localLog.WithField("err", err).Error("Error saving withdrawal result, retrying")
+		time.Sleep(time.Second)
+	}
+
+	if state == database.WithdrawalStateFailed {
+		localLog.WithField("err", errorMessage).Error("Withdrawal failed")
+		return
+	}
+
+	localLog.WithField("chain_transaction_id", chainTransactionID).Info("Withdrawal sent")
+}
@@ -0,0 +1,161 @@
+// Skip this test file in Go <1.8 because it's using http.Server.Shutdown
+// +build go1.8
+
+package server
+
+import (
+	"encoding/base64"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/stellar/go/clients/horizon"
+	"github.com/stellar/go/services/bifrost/chains"
+	"github.com/stellar/go/services/bifrost/config"
+	"github.com/stellar/go/services/bifrost/database"
+	"github.com/stellar/go/services/bifrost/queue"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type WithdrawalTestSuite struct {
+	suite.Suite
+	Server         *Server
+	MockWithdrawer *chains.MockWithdrawer
+	MockDatabase   *database.MockDatabase
+}
+
+func (suite *WithdrawalTestSuite) SetupTest() {
+	suite.MockWithdrawer = &chains.MockWithdrawer{}
+	suite.MockDatabase = &database.MockDatabase{}
+
+	suite.Server = &Server{
+		Adapters: map[database.Chain]chains.ChainAdapter{
+			database.ChainEthereum: suite.MockWithdrawer,
+		},
+		Config:   &config.Config{Withdrawals: &config.WithdrawalsConfig{}},
+		Database: suite.MockDatabase,
+	}
+	suite.Server.initLogger()
+}
+
+func (suite *WithdrawalTestSuite) TearDownTest() {
+	suite.MockWithdrawer.AssertExpectations(suite.T())
+	suite.MockDatabase.AssertExpectations(suite.T())
+}
+
+func (suite *WithdrawalTestSuite) payment() horizon.Payment {
+	payment := horizon.Payment{
+		ID:          "12884905985",
+		Type:        "payment",
+		PagingToken: "12884905985",
+		From:        "GDULKYRRVOMASFMXBYD4BYFRSHAKQDREEVVP2TMH2CER3DW2KATIOASB",
+		To:          "GD4I7AFSLZGTDL34TQLWJOM2NHLIIOEKD5RHHZUW54HERBLSIRKUOXRR",
+		AssetType:   "credit_alphanum4",
+		AssetCode:   "ETH",
+		AssetIssuer: "GD4I7AFSLZGTDL34TQLWJOM2NHLIIOEKD5RHHZUW54HERBLSIRKUOXRR",
+		Amount:      "1.5000000",
+	}
+	payment.Memo.Type = "hash"
+	payment.Memo.Value = base64.StdEncoding.EncodeToString(make([]byte, 32))
+	return payment
+}
+
+func (suite *WithdrawalTestSuite) expectWithdrawal(state database.WithdrawalState, address string) {
+	suite.MockDatabase.
+		On("AddWithdrawal", mock.AnythingOfType("database.Withdrawal")).
+		Return(nil).
+		Run(func(args mock.Arguments) {
+			withdrawal := args.Get(0).(database.Withdrawal)
+			suite.Assert().Equal("12884905985", withdrawal.PaymentID)
+			suite.Assert().Equal("GDULKYRRVOMASFMXBYD4BYFRSHAKQDREEVVP2TMH2CER3DW2KATIOASB", withdrawal.StellarPublicKey)
+			suite.Assert().Equal(database.ChainEthereum, withdrawal.Chain)
+			suite.Assert().Equal(address, withdrawal.Address)
+			suite.Assert().Equal("ETH", withdrawal.AssetCode)
+			suite.Assert().Equal("1.5000000", withdrawal.Amount)
+			suite.Assert().Equal(state, withdrawal.State)
+		})
+}
+
+func (suite *WithdrawalTestSuite) TestAssetNotWithdrawable() {
+	payment := suite.payment()
+	payment.AssetCode = "XYZ"
+	suite.MockWithdrawer.On("MinimumValue", queue.AssetCode("XYZ")).Return(nil)
+	err := suite.Server.onWithdrawal(payment)
+	suite.Require().NoError(err)
+	suite.MockDatabase.AssertNotCalled(suite.T(), "AddWithdrawal")
+}
+
+func (suite *WithdrawalTestSuite) TestWithdrawalApproved() {
+	payment := suite.payment()
+	suite.MockWithdrawer.On("MinimumValue", queue.AssetCodeETH).Return(big.NewInt(1))
+	suite.MockWithdrawer.
+		On("DecodeMemoAddress", make([]byte, 32)).
+		Return("0x80D3ee1268DC1A2d1b9E73D49050083E75Ef7c2D", nil)
+	suite.expectWithdrawal(database.WithdrawalStateApproved, "0x80D3ee1268DC1A2d1b9E73D49050083E75Ef7c2D")
+	err := suite.Server.onWithdrawal(payment)
+	suite.Require().NoError(err)
+}
+
+func (suite *WithdrawalTestSuite) TestWithdrawalPendingApproval() {
+	suite.Server.Config.Withdrawals.ManualApproval = true
+	payment := suite.payment()
+	suite.MockWithdrawer.On("MinimumValue", queue.AssetCodeETH).Return(big.NewInt(1))
+	suite.MockWithdrawer.
+		On("DecodeMemoAddress", make([]byte, 32)).
+		Return("0x80D3ee1268DC1A2d1b9E73D49050083E75Ef7c2D", nil)
+	suite.expectWithdrawal(database.WithdrawalStatePendingApproval, "0x80D3ee1268DC1A2d1b9E73D49050083E75Ef7c2D")
+	err := suite.Server.onWithdrawal(payment)
+	suite.Require().NoError(err)
+}
+
+func (suite *WithdrawalTestSuite) TestWithdrawalInvalidAddress() {
+	payment := suite.payment()
+	suite.MockWithdrawer.On("MinimumValue", queue.AssetCodeETH).Return(big.NewInt(1))
+	suite.MockWithdrawer.
+		On("DecodeMemoAddress", make([]byte, 32)).
+		Return("", errors.New("Memo must be padded with zeros"))
+	suite.expectWithdrawal(database.WithdrawalStateFailed, "")
+	err := suite.Server.onWithdrawal(payment)
+	suite.Require().NoError(err)
+}
+
+func (suite *WithdrawalTestSuite) TestSendWithdrawal() {
+	withdrawal := &database.Withdrawal{
+		PaymentID: "12884905985",
+		Chain:     database.ChainEthereum,
+		Address:   "0x80D3ee1268DC1A2d1b9E73D49050083E75Ef7c2D",
+		AssetCode: "ETH",
+		Amount:    "1.5000000",
+		State:     database.WithdrawalStateSending,
+	}
+	suite.MockWithdrawer.
+		On("Withdraw", withdrawal.Address, queue.AssetCodeETH, withdrawal.Amount).
+		Return("0x0a190d17ba0405bce37fafd3a7a7bef51264ea4083ffae3b2de90ed61ee5264e", nil)
+	suite.MockDatabase.
+		On("FinishWithdrawal", withdrawal.PaymentID, database.WithdrawalStateSent, "0x0a190d17ba0405bce37fafd3a7a7bef51264ea4083ffae3b2de90ed61ee5264e", "").
+		Return(nil)
+	suite.Server.sendWithdrawal(withdrawal)
+}
+
+func (suite *WithdrawalTestSuite) TestSendWithdrawalFailed() {
+	withdrawal := &database.Withdrawal{
+		PaymentID: "12884905985",
+		Chain:     database.ChainEthereum,
+		Address:   "0x80D3ee1268DC1A2d1b9E73D49050083E75Ef7c2D",
+		AssetCode: "ETH",
+		Amount:    "1.5000000",
+		State:     database.WithdrawalStateSending,
+	}
+	suite.MockWithdrawer.
+		On("Withdraw", withdrawal.Address, queue.AssetCodeETH, withdrawal.Amount).
+		Return("", errors.New("insufficient funds"))
+	suite.MockDatabase.
+		On("FinishWithdrawal", withdrawal.PaymentID, database.WithdrawalStateFailed, "", "insufficient funds").
+		Return(nil)
+	suite.Server.sendWithdrawal(withdrawal)
+}
+
+func TestWithdrawalTestSuite(t *testing.T) {
+	suite.Run(t, new(WithdrawalTestSuite))
+}
@@ -12,6 +12,7 @@ import (
 type AddressEvent string
 
 const (
+	TransactionPendingAddressEvent  AddressEvent = "transaction_pending"
 	TransactionReceivedAddressEvent AddressEvent = "transaction_received"
 	AccountCreatedAddressEvent      AddressEvent = "account_created"
 	AccountCreditedAddressEvent     AddressEvent = "account_credited"
@@ -35,6 +36,9 @@ type ServerInterface interface {
 }
 
 type Event struct {
+	// ID is the ID of the event assigned by Storage. IDs are monotonically
+	// increasing and are sent as SSE event IDs so clients can resume streams.
+	ID      int64        `db:"-"`
 	Address string       `db:"address"`
 	Event   AddressEvent `db:"event"`
 	Data    string       `db:"data"`
@@ -58,4 +62,7 @@ type Storage interface {
 	//      event has been broadcasted.
 	//    * it should return 0 if no events have been broadcasted.
 	GetEventsSinceID(id int64) (int64, []Event, error)
+	// GetAddressEventsSinceID returns events of `address` with ID greater than
+	// `id`, oldest first. Used to replay events missed by reconnecting clients.
+	GetAddressEventsSinceID(address string, id int64) ([]Event, error)
 }
@@ -32,3 +32,23 @@ func (m *MockServer) StreamExists(address string) bool {
 func (m *MockServer) HTTPHandler(w http.ResponseWriter, r *http.Request) {
 	m.Called(w, r)
 }
+
+// MockStorage is a mockable events storage.
+type MockStorage struct {
+	mock.Mock
+}
+
+func (m *MockStorage) AddEvent(event Event) error {
+	a := m.Called(event)
+	return a.Error(0)
+}
+
+func (m *MockStorage) GetEventsSinceID(id int64) (int64, []Event, error) {
+	a := m.Called(id)
+	return a.Get(0).(int64), a.Get(1).([]Event), a.Error(2)
+}
+
+func (m *MockStorage) GetAddressEventsSinceID(address string, id int64) ([]Event, error) {
+	a := m.Called(address, id)
+	return a.Get(0).([]Event), a.Error(1)
+}
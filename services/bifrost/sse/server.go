@@ -1,16 +1,22 @@
 package sse
 
 import (
+	"fmt"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/r3labs/sse"
 	"github.com/stellar/go/services/bifrost/common"
+	"github.com/stellar/go/support/errors"
 	"github.com/stellar/go/support/log"
 )
 
 func (s *Server) init() {
 	s.eventsServer = sse.New()
+	// Events are replayed from Storage in HTTPHandler. AutoReplay would also
+	// replace IDs of published events with indexes of the in-memory log.
+	s.eventsServer.AutoReplay = false
 	s.lastID = -1
 	s.log = common.CreateLogger("SSEServer")
 }
@@ -55,7 +61,7 @@ func (s *Server) StartPublishing() error {
 			}
 
 			for _, event := range events {
-				s.publishEvent(event.Address, event.Event, []byte(event.Data))
+				s.publishEvent(event)
 			}
 
 			s.lastID = lastID
@@ -65,24 +71,20 @@ func (s *Server) StartPublishing() error {
 	return nil
 }
 
-func (s *Server) publishEvent(address string, event AddressEvent, data []byte) {
+func (s *Server) publishEvent(event Event) {
 	s.initOnce.Do(s.init)
 
 	// Create SSE stream if not exists
-	if !s.eventsServer.StreamExists(address) {
-		s.eventsServer.CreateStream(address)
+	if !s.eventsServer.StreamExists(event.Address) {
+		s.eventsServer.CreateStream(event.Address)
 	}
 
 	// github.com/r3labs/sse does not send new lines - TODO create PR
-	if data == nil {
-		data = []byte("{}\n")
-	} else {
-		data = append(data, byte('\n'))
-	}
+	data := []byte(eventData(event) + "\n")
 
-	s.eventsServer.Publish(address, &sse.Event{
-		ID:    []byte(event),
-		Event: []byte(event),
+	s.eventsServer.Publish(event.Address, &sse.Event{
+		ID:    []byte(strconv.FormatInt(event.ID, 10)),
+		Event: []byte(event.Event),
 		Data:  data,
 	})
 }
@@ -97,7 +99,63 @@ func (s *Server) StreamExists(address string) bool {
 	return s.eventsServer.StreamExists(address)
 }
 
+// HTTPHandler streams events of `stream` query param address. If the client
+// reconnects with `Last-Event-ID` header, events it missed are replayed from
+// Storage first.
 func (s *Server) HTTPHandler(w http.ResponseWriter, r *http.Request) {
 	s.initOnce.Do(s.init)
+
+	lastEventID := r.Header.Get("Last-Event-ID")
+	if lastEventID != "" {
+		id, err := strconv.ParseInt(lastEventID, 10, 64)
+		if err == nil {
+			err = s.replayEvents(w, r.URL.Query().Get("stream"), id)
+			if err != nil {
+				s.log.WithFields(log.F{"err": err, "lastEventID": id}).Error("Error replaying events")
+			}
+		}
+
+		// Events are replayed from Storage, IDs of eventsServer are not the same.
+		r.Header.Del("Last-Event-ID")
+	}
+
 	s.eventsServer.HTTPHandler(w, r)
 }
+
+// replayEvents writes events of `address` with ID greater than `lastEventID`
+// to `w`.
+func (s *Server) replayEvents(w http.ResponseWriter, address string, lastEventID int64) error {
+	events, err := s.Storage.GetAddressEventsSinceID(address, lastEventID)
+	if err != nil {
+		return errors.Wrap(err, "Error getting events")
+	}
+
+	if len(events) == 0 {
+		return nil
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+
+	for _, event := range events {
+		_, err = fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", event.ID, event.Event, eventData(event))
+		if err != nil {
+			return errors.Wrap(err, "Error writing event")
+		}
+	}
+
+	if flusher, ok := w.(http.Flusher); ok {
+		flusher.Flush()
+	}
+
+	s.log.WithFields(log.F{"address": address, "count": len(events)}).Info("Replayed events")
+	return nil
+}
+
+func eventData(event Event) string {
+	if event.Data == "" {
+		return "{}"
+	}
+	return event.Data
+}
@@ -0,0 +1,97 @@
+package sse
+
+import (
+	"bufio"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReplayEvents(t *testing.T) {
+	storage := &MockStorage{}
+	server := &Server{Storage: storage}
+	server.initOnce.Do(server.init)
+
+	storage.On("GetAddressEventsSinceID", "1Q74qRud8bXUn6FMtXWZwJa5pj56s3mdyf", int64(10)).Return([]Event{
+		{ID: 12, Address: "1Q74qRud8bXUn6FMtXWZwJa5pj56s3mdyf", Event: AccountCreatedAddressEvent},
+		{ID: 15, Address: "1Q74qRud8bXUn6FMtXWZwJa5pj56s3mdyf", Event: AccountCreditedAddressEvent, Data: `{"amount":"1"}`},
+	}, nil)
+
+	w := httptest.NewRecorder()
+	err := server.replayEvents(w, "1Q74qRud8bXUn6FMtXWZwJa5pj56s3mdyf", 10)
+	require.NoError(t, err)
+
+	assert.Equal(t, "text/event-stream", w.Header().Get("Content-Type"))
+	assert.Equal(t,
+		"id: 12\nevent: account_created\ndata: {}\n\n"+
+			"id: 15\nevent: account_credited\ndata: {\"amount\":\"1\"}\n\n",
+		w.Body.String(),
+	)
+	storage.AssertExpectations(t)
+}
+
+func TestReplayEventsNone(t *testing.T) {
+	storage := &MockStorage{}
+	server := &Server{Storage: storage}
+	server.initOnce.Do(server.init)
+
+	storage.On("GetAddressEventsSinceID", "1Q74qRud8bXUn6FMtXWZwJa5pj56s3mdyf", int64(15)).Return([]Event{}, nil)
+
+	w := httptest.NewRecorder()
+	err := server.replayEvents(w, "1Q74qRud8bXUn6FMtXWZwJa5pj56s3mdyf", 15)
+	require.NoError(t, err)
+	assert.Equal(t, "", w.Body.String())
+}
+
+func TestHTTPHandlerReconnect(t *testing.T) {
+	address := "1Q74qRud8bXUn6FMtXWZwJa5pj56s3mdyf"
+	storage := &MockStorage{}
+	server := &Server{Storage: storage}
+	server.CreateStream(address)
+
+	httpServer := httptest.NewServer(http.HandlerFunc(server.HTTPHandler))
+	defer httpServer.Close()
+
+	go func() {
+		// Wait for the client to subscribe.
+		time.Sleep(100 * time.Millisecond)
+		server.publishEvent(Event{ID: 42, Address: address, Event: AccountCreatedAddressEvent})
+	}()
+
+	assert.Equal(t, "id: 42", readEventID(t, httpServer.URL+"?stream="+address, ""))
+
+	storage.On("GetAddressEventsSinceID", address, int64(42)).Return([]Event{
+		{ID: 43, Address: address, Event: AccountCreditedAddressEvent, Data: `{"amount":"1"}`},
+	}, nil)
+
+	assert.Equal(t, "id: 43", readEventID(t, httpServer.URL+"?stream="+address, "42"))
+	storage.AssertExpectations(t)
+}
+
+// readEventID connects to the stream at `url` and returns the ID line of the
+// first event received.
+func readEventID(t *testing.T, url, lastEventID string) string {
+	req, err := http.NewRequest("GET", url, nil)
+	require.NoError(t, err)
+	if lastEventID != "" {
+		req.Header.Set("Last-Event-ID", lastEventID)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		if strings.HasPrefix(scanner.Text(), "id: ") {
+			return scanner.Text()
+		}
+	}
+	require.NoError(t, scanner.Err())
+	return ""
+}
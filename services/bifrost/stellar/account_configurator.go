@@ -61,9 +61,12 @@ func (ac *AccountConfigurator) logStats() {
 }
 
 // ConfigureAccount configures a new account that participated in ICO.
-// * First it creates a new account.
+// * First it creates a new account (or waits for it if SkipAccountCreation is set).
 // * Once a trusline exists, it credits it with received number of ETH or BTC.
-func (ac *AccountConfigurator) ConfigureAccount(destination, assetCode, amount string) {
+// It returns error if sending the asset failed, ErrTrustlineTimeout if the
+// recipient didn't create the account or add the trust line before TrustlineTimeout
+// or ErrAccountMerged if the account was merged.
+func (ac *AccountConfigurator) ConfigureAccount(destination, assetCode, amount string) error {
 	localLog := ac.log.WithFields(log.F{
 		"destination": destination,
 		"assetCode":   assetCode,
@@ -81,6 +84,12 @@ func (ac *AccountConfigurator) ConfigureAccount(destination, assetCode, amount s
 		ac.processingCountMutex.Unlock()
 	}()
 
+	// Deadline of the recipient creating the account and adding the trust line
+	var deadline time.Time
+	if ac.TrustlineTimeout > 0 {
+		deadline = time.Now().Add(ac.TrustlineTimeout)
+	}
+
 	// Check if account exists. If it is, skip creating it.
 	for {
 		_, exists, err := ac.getAccount(destination)
@@ -94,6 +103,16 @@ func (ac *AccountConfigurator) ConfigureAccount(destination, assetCode, amount s
 			break
 		}
 
+		if ac.SkipAccountCreation {
+			if !deadline.IsZero() && time.Now().After(deadline) {
+				localLog.Warn("Account not created before timeout, deposit must be refunded")
+				return ErrTrustlineTimeout
+			}
+
+			time.Sleep(2 * time.Second)
+			continue
+		}
+
 		localLog.WithField("destination", destination).Info("Creating Stellar account")
 		err = ac.createAccount(destination)
 		if err != nil {
@@ -111,17 +130,27 @@ func (ac *AccountConfigurator) ConfigureAccount(destination, assetCode, amount s
 
 	// Wait for trust line to be created...
 	for {
-		account, err := ac.Horizon.LoadAccount(destination)
+		account, exists, err := ac.getAccount(destination)
 		if err != nil {
 			localLog.WithField("err", err).Error("Error loading account to check trustline")
 			time.Sleep(2 * time.Second)
 			continue
 		}
 
+		if !exists {
+			localLog.Warn("Account merged, deposit must be refunded")
+			return ErrAccountMerged
+		}
+
 		if ac.trustlineExists(account, assetCode) {
 			break
 		}
 
+		if !deadline.IsZero() && time.Now().After(deadline) {
+			localLog.Warn("Trust line not added before timeout, deposit must be refunded")
+			return ErrTrustlineTimeout
+		}
+
 		time.Sleep(2 * time.Second)
 	}
 
@@ -139,8 +168,12 @@ func (ac *AccountConfigurator) ConfigureAccount(destination, assetCode, amount s
 	localLog.Info("Sending token")
 	err := ac.sendToken(destination, assetCode, amount)
 	if err != nil {
+		if isNoDestinationError(err) {
+			localLog.Warn("Account merged, deposit must be refunded")
+			return ErrAccountMerged
+		}
 		localLog.WithField("err", err).Error("Error sending asset to account")
-		return
+		return errors.Wrap(err, "Error sending asset to account")
 	}
 
 	if ac.OnAccountCredited != nil {
@@ -148,6 +181,7 @@ func (ac *AccountConfigurator) ConfigureAccount(destination, assetCode, amount s
 	}
 
 	localLog.Info("Account successully configured")
+	return nil
 }
 
 func (ac *AccountConfigurator) getAccount(account string) (horizon.Account, bool, error) {
@@ -163,6 +197,27 @@ func (ac *AccountConfigurator) getAccount(account string) (horizon.Account, bool
 	return hAccount, true, nil
 }
 
+// isNoDestinationError returns true if transaction failed because the
+// destination account doesn't exist (`op_no_destination`).
+func isNoDestinationError(err error) bool {
+	herr, ok := errors.Cause(err).(*horizon.Error)
+	if !ok {
+		return false
+	}
+
+	resultCodes, err := herr.ResultCodes()
+	if err != nil {
+		return false
+	}
+
+	for _, code := range resultCodes.OperationCodes {
+		if code == "op_no_destination" {
+			return true
+		}
+	}
+	return false
+}
+
 func (ac *AccountConfigurator) trustlineExists(account horizon.Account, assetCode string) bool {
 	for _, balance := range account.Balances {
 		if balance.Asset.Issuer == ac.IssuerPublicKey && balance.Asset.Code == assetCode {
@@ -2,11 +2,21 @@ package stellar
 
 import (
 	"sync"
+	"time"
 
 	"github.com/stellar/go/clients/horizon"
+	"github.com/stellar/go/support/errors"
 	"github.com/stellar/go/support/log"
 )
 
+// ErrTrustlineTimeout is returned by ConfigureAccount when the recipient didn't
+// create the account or add the trust line before TrustlineTimeout.
+var ErrTrustlineTimeout = errors.New("Account or trust line not created before timeout")
+
+// ErrAccountMerged is returned by ConfigureAccount when the recipient account
+// was merged before the asset was sent.
+var ErrAccountMerged = errors.New("Account merged before the asset was sent")
+
 // AccountConfigurator is responsible for configuring new Stellar accounts that
 // participate in ICO.
 type AccountConfigurator struct {
@@ -17,6 +27,13 @@ type AccountConfigurator struct {
 	NeedsAuthorize    bool
 	TokenAssetCode    string
 	StartingBalance   string
+	// SkipAccountCreation disables creating (pre-funding) accounts: accounts
+	// must be created by the recipients.
+	SkipAccountCreation bool
+	// TrustlineTimeout is the maximum time of waiting for the recipient to create
+	// the account (if SkipAccountCreation is set) and add the trust line. When it
+	// passes ConfigureAccount returns ErrTrustlineTimeout. Zero means no timeout.
+	TrustlineTimeout  time.Duration
 	OnAccountCreated  func(destination string)
 	OnAccountCredited func(destination string, assetCode string, amount string)
 
@@ -27,3 +44,27 @@ type AccountConfigurator struct {
 	processingCountMutex sync.Mutex
 	log                  *log.Entry
 }
+
+// WithdrawalListener streams payments of assets issued by IssuerPublicKey back
+// to the issuing account with hash memo (withdrawals) and calls WithdrawalHandler
+// for each of them. It retries a payment until WithdrawalHandler returns no error.
+// It saves the paging token of handled payments in Storage so it resumes from the
+// last handled payment after restart.
+type WithdrawalListener struct {
+	Horizon           horizon.ClientInterface `inject:""`
+	Storage           WithdrawalStorage       `inject:""`
+	IssuerPublicKey   string
+	WithdrawalHandler func(payment horizon.Payment) error
+
+	log *log.Entry
+}
+
+// WithdrawalStorage is an interface that must be implemented by an object using
+// persistent storage.
+type WithdrawalStorage interface {
+	// GetWithdrawalsCursor returns the paging token of the last processed payment to
+	// the issuing account. Should return empty string if not found.
+	GetWithdrawalsCursor() (string, error)
+	// SaveWithdrawalsCursor saves the paging token of the last processed payment.
+	SaveWithdrawalsCursor(cursor string) error
+}
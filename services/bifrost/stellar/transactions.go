@@ -2,9 +2,11 @@ package stellar
 
 import (
 	"strconv"
+	"time"
 
 	"github.com/stellar/go/build"
 	"github.com/stellar/go/clients/horizon"
+	"github.com/stellar/go/services/bifrost/metrics"
 	"github.com/stellar/go/support/errors"
 	"github.com/stellar/go/support/log"
 )
@@ -76,7 +78,9 @@ func (ac *AccountConfigurator) submitTransaction(mutators ...build.TransactionMu
 	localLog := log.WithField("tx", tx)
 	localLog.Info("Submitting transaction")
 
+	start := time.Now()
 	_, err = ac.Horizon.SubmitTransaction(tx)
+	metrics.StellarSubmission(start)
 	if err != nil {
 		fields := log.F{"err": err}
 		if err, ok := err.(*horizon.Error); ok {
@@ -0,0 +1,101 @@
+package stellar
+
+import (
+	"time"
+
+	"github.com/stellar/go/clients/horizon"
+	"github.com/stellar/go/keypair"
+	"github.com/stellar/go/services/bifrost/common"
+	"github.com/stellar/go/support/errors"
+	"github.com/stellar/go/support/log"
+	"golang.org/x/net/context"
+)
+
+func (l *WithdrawalListener) Start() error {
+	l.log = common.CreateLogger("StellarWithdrawalListener")
+	l.log.Info("StellarWithdrawalListener starting")
+
+	_, err := keypair.Parse(l.IssuerPublicKey)
+	if err != nil || (err == nil && l.IssuerPublicKey[0] != 'G') {
+		err = errors.Wrap(err, "Invalid IssuerPublicKey")
+		l.log.Error(err)
+		return err
+	}
+
+	if l.WithdrawalHandler == nil {
+		return errors.New("WithdrawalHandler not set")
+	}
+
+	go l.stream()
+	return nil
+}
+
+func (l *WithdrawalListener) stream() {
+	for {
+		cursorValue, err := l.Storage.GetWithdrawalsCursor()
+		if err != nil {
+			l.log.WithField("err", err).Error("Error getting withdrawals cursor")
+			time.Sleep(time.Second)
+			continue
+		}
+
+		if cursorValue == "" {
+			// Withdrawals have never been processed
+			cursorValue = "now"
+		}
+
+		cursor := horizon.Cursor(cursorValue)
+		l.log.WithField("cursor", cursor).Info("Streaming payments")
+
+		err = l.Horizon.StreamPaymentsWithOptions(
+			context.Background(),
+			l.IssuerPublicKey,
+			&cursor,
+			horizon.PaymentStreamOptions{ResolveMemos: true},
+			l.onPayment,
+		)
+		if err != nil {
+			l.log.WithField("err", err).Error("Error streaming payments")
+		}
+
+		time.Sleep(time.Second)
+	}
+}
+
+func (l *WithdrawalListener) onPayment(payment horizon.Payment) {
+	if !l.isWithdrawal(payment) {
+		return
+	}
+
+	localLog := l.log.WithFields(log.F{"id": payment.ID, "from": payment.From})
+	localLog.Info("Processing withdrawal")
+
+	for {
+		err := l.WithdrawalHandler(payment)
+		if err == nil {
+			break
+		}
+		localLog.WithField("err", err).Error("Error processing withdrawal, retrying")
+		time.Sleep(time.Second)
+	}
+
+	err := l.Storage.SaveWithdrawalsCursor(payment.PagingToken)
+	if err != nil {
+		// Withdrawal will be processed again after restart, handler ignores duplicates.
+		localLog.WithField("err", err).Error("Error saving withdrawals cursor")
+	}
+}
+
+// isWithdrawal returns true if payment is a payment of asset issued by the issuing
+// account back to the issuing account with hash memo.
+func (l *WithdrawalListener) isWithdrawal(payment horizon.Payment) bool {
+	if payment.Type != "payment" && payment.Type != "path_payment" {
+		return false
+	}
+
+	return payment.To == l.IssuerPublicKey &&
+		payment.From != l.IssuerPublicKey &&
+		payment.AssetType != "native" &&
+		payment.AssetIssuer == l.IssuerPublicKey &&
+		payment.Memo.Type == "hash"
+}
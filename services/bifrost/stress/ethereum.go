@@ -7,6 +7,7 @@ import (
 	"math/rand"
 	"time"
 
+	goethereum "github.com/ethereum/go-ethereum"
 	ethereumCommon "github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/stellar/go/services/bifrost/common"
@@ -60,6 +61,11 @@ func (c *RandomEthereumClient) BlockByNumber(ctx context.Context, number *big.In
 	return block, nil
 }
 
+// FilterLogs returns no logs, random transactions generator doesn't transfer tokens.
+func (c *RandomEthereumClient) FilterLogs(ctx context.Context, query goethereum.FilterQuery) ([]types.Log, error) {
+	return nil, nil
+}
+
 func (g *RandomEthereumClient) generateBlocks() {
 	for {
 		// Generate 50-200 txs
@@ -0,0 +1,100 @@
+package webhooks
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/stellar/go/support/log"
+)
+
+// Event is a deposit lifecycle event webhooks are sent for.
+type Event string
+
+const (
+	// AddressGeneratedEvent is sent when a new address is generated for a Stellar account.
+	AddressGeneratedEvent Event = "address_generated"
+	// TransactionSeenEvent is sent when a payment to a generated address is found in a block.
+	TransactionSeenEvent Event = "transaction_seen"
+	// ConfirmationsReachedEvent is sent when a payment is accepted and added to the transactions queue.
+	ConfirmationsReachedEvent Event = "confirmations_reached"
+	// AccountCreatedEvent is sent when a Stellar account is created.
+	AccountCreatedEvent Event = "account_created"
+	// AssetDeliveredEvent is sent when a Stellar account is credited with purchased asset.
+	AssetDeliveredEvent Event = "asset_delivered"
+)
+
+// Notifier sends webhooks to all URLs. Webhooks are added to the persistent retry
+// queue in Storage and delivered by a background goroutine, so they are delivered
+// even if a receiver is down or Bifrost is restarted. Failed deliveries are retried
+// with exponential backoff up to MaxAttempts times.
+//
+// Each request body is signed using HMAC-SHA256 with Secret. The hex encoded signature
+// is sent in `X-Bifrost-Signature` header as `sha256=<signature>`.
+type Notifier struct {
+	Storage     Storage `inject:""`
+	URLs        []string
+	Secret      string
+	MaxAttempts int
+	HTTP        HTTPClient
+
+	log *log.Entry
+}
+
+type NotifierInterface interface {
+	// Notify adds `event` webhook with `data` to the retry queue of every URL.
+	Notify(event Event, data interface{})
+	// Start starts delivering queued webhooks.
+	Start() error
+}
+
+type HTTPClient interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+type DeliveryState string
+
+const (
+	DeliveryStatePending   DeliveryState = "pending"
+	DeliveryStateDelivered DeliveryState = "delivered"
+	// DeliveryStateFailed is a delivery that failed MaxAttempts times.
+	DeliveryStateFailed DeliveryState = "failed"
+)
+
+// Delivery is a webhook sent to a single URL.
+type Delivery struct {
+	ID    int64  `db:"id"`
+	URL   string `db:"url"`
+	Event Event  `db:"event"`
+	// Payload is JSON encoded Payload.
+	Payload       string        `db:"payload"`
+	State         DeliveryState `db:"state"`
+	Attempts      int           `db:"attempts"`
+	NextAttemptAt time.Time     `db:"next_attempt_at"`
+	LastError     string        `db:"last_error"`
+	CreatedAt     time.Time     `db:"created_at"`
+}
+
+// Payload is the body of webhook request.
+type Payload struct {
+	// ID is the same for all deliveries and attempts of the webhook. Receivers
+	// should use it to ignore duplicates.
+	ID        string      `json:"id"`
+	Event     Event       `json:"event"`
+	CreatedAt time.Time   `json:"created_at"`
+	Data      interface{} `json:"data"`
+}
+
+// Storage is the persistent retry queue of deliveries. It can be shared by
+// many Bifrost servers.
+type Storage interface {
+	// AddWebhookDelivery adds a new delivery to the queue.
+	AddWebhookDelivery(delivery Delivery) error
+	// GetWebhookDelivery returns the oldest pending delivery with NextAttemptAt in
+	// the past and postpones its NextAttemptAt by `lease` so it's not returned again
+	// while it's being delivered. Returns nil if no deliveries found. This operation
+	// must be atomic.
+	GetWebhookDelivery(lease time.Duration) (*Delivery, error)
+	// UpdateWebhookDelivery updates State, Attempts, NextAttemptAt and LastError of
+	// delivery.
+	UpdateWebhookDelivery(delivery Delivery) error
+}
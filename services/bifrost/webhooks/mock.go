@@ -0,0 +1,19 @@
+package webhooks
+
+import (
+	"github.com/stretchr/testify/mock"
+)
+
+// MockNotifier is a mockable webhooks notifier.
+type MockNotifier struct {
+	mock.Mock
+}
+
+func (m *MockNotifier) Notify(event Event, data interface{}) {
+	m.Called(event, data)
+}
+
+func (m *MockNotifier) Start() error {
+	a := m.Called()
+	return a.Error(0)
+}
@@ -0,0 +1,197 @@
+package webhooks
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/stellar/go/services/bifrost/common"
+	"github.com/stellar/go/support/errors"
+	"github.com/stellar/go/support/log"
+)
+
+const (
+	defaultMaxAttempts = 10
+	// deliveryLease is the time after which a delivery claimed by a server that
+	// stopped while delivering it is retried.
+	deliveryLease = time.Minute
+	maxBackoff    = time.Hour
+)
+
+func (n *Notifier) Start() error {
+	n.initLogger()
+	n.log.Info("WebhooksNotifier starting")
+
+	if len(n.URLs) == 0 {
+		return errors.New("At least one webhook URL is required")
+	}
+
+	if n.Secret == "" {
+		return errors.New("Webhooks secret is required")
+	}
+
+	if n.MaxAttempts == 0 {
+		n.MaxAttempts = defaultMaxAttempts
+	}
+
+	if n.HTTP == nil {
+		n.HTTP = &http.Client{Timeout: 10 * time.Second}
+	}
+
+	go n.deliverQueue()
+	return nil
+}
+
+func (n *Notifier) initLogger() {
+	if n.log == nil {
+		n.log = common.CreateLogger("WebhooksNotifier")
+	}
+}
+
+func (n *Notifier) Notify(event Event, data interface{}) {
+	n.initLogger()
+	localLog := n.log.WithField("event", event)
+
+	id, err := randomID()
+	if err != nil {
+		localLog.WithField("err", err).Error("Error generating webhook ID")
+		return
+	}
+
+	now := time.Now()
+	payload, err := json.Marshal(Payload{ID: id, Event: event, CreatedAt: now, Data: data})
+	if err != nil {
+		localLog.WithField("err", err).Error("Error marshalling webhook payload")
+		return
+	}
+
+	for _, url := range n.URLs {
+		delivery := Delivery{
+			URL:           url,
+			Event:         event,
+			Payload:       string(payload),
+			State:         DeliveryStatePending,
+			NextAttemptAt: now,
+			CreatedAt:     now,
+		}
+
+		err = n.Storage.AddWebhookDelivery(delivery)
+		if err != nil {
+			localLog.WithFields(log.F{"err": err, "url": url}).Error("Error adding webhook delivery")
+		}
+	}
+}
+
+func (n *Notifier) deliverQueue() {
+	for {
+		delivery, err := n.Storage.GetWebhookDelivery(deliveryLease)
+		if err != nil {
+			n.log.WithField("err", err).Error("Error getting webhook delivery")
+			time.Sleep(time.Second)
+			continue
+		}
+
+		if delivery == nil {
+			time.Sleep(time.Second)
+			continue
+		}
+
+		n.attempt(delivery)
+
+		err = n.Storage.UpdateWebhookDelivery(*delivery)
+		if err != nil {
+			n.log.WithFields(log.F{"err": err, "id": delivery.ID}).Error("Error updating webhook delivery")
+		}
+	}
+}
+
+// attempt sends `delivery` and updates its state.
+func (n *Notifier) attempt(delivery *Delivery) {
+	localLog := n.log.WithFields(log.F{"id": delivery.ID, "event": delivery.Event, "url": delivery.URL})
+
+	delivery.Attempts++
+	err := n.send(delivery)
+	if err == nil {
+		delivery.State = DeliveryStateDelivered
+		delivery.LastError = ""
+		localLog.Info("Webhook delivered")
+		return
+	}
+
+	delivery.LastError = err.Error()
+	if delivery.Attempts >= n.MaxAttempts {
+		delivery.State = DeliveryStateFailed
+		localLog.WithField("err", err).Error("Webhook delivery failed")
+		return
+	}
+
+	delivery.NextAttemptAt = time.Now().Add(backoff(delivery.Attempts))
+	localLog.WithFields(log.F{"err": err, "attempts": delivery.Attempts}).Warn("Error delivering webhook, will retry")
+}
+
+func (n *Notifier) send(delivery *Delivery) error {
+	payload := []byte(delivery.Payload)
+
+	req, err := http.NewRequest(http.MethodPost, delivery.URL, bytes.NewReader(payload))
+	if err != nil {
+		return errors.Wrap(err, "Error creating request")
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Bifrost-Event", string(delivery.Event))
+	req.Header.Set("X-Bifrost-Delivery", strconv.FormatInt(delivery.ID, 10))
+	req.Header.Set("X-Bifrost-Signature", "sha256="+Sign(n.Secret, payload))
+
+	resp, err := n.HTTP.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "Error sending request")
+	}
+	defer resp.Body.Close()
+	io.Copy(ioutil.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("Invalid response status code: %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// Sign returns hex encoded HMAC-SHA256 of `payload` using `secret`. Receivers
+// can use it to verify `X-Bifrost-Signature` header.
+func Sign(secret string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// backoff returns the time to wait before the next attempt after `attempts`
+// failed attempts: 2^attempts seconds, up to maxBackoff.
+func backoff(attempts int) time.Duration {
+	if attempts > 12 {
+		return maxBackoff
+	}
+
+	d := time.Duration(1<<uint(attempts)) * time.Second
+	if d > maxBackoff {
+		return maxBackoff
+	}
+	return d
+}
+
+func randomID() (string, error) {
+	raw := make([]byte, 16)
+	_, err := rand.Read(raw)
+	if err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(raw), nil
+}
@@ -0,0 +1,137 @@
+package webhooks
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type mockStorage struct {
+	mock.Mock
+}
+
+func (m *mockStorage) AddWebhookDelivery(delivery Delivery) error {
+	a := m.Called(delivery)
+	return a.Error(0)
+}
+
+func (m *mockStorage) GetWebhookDelivery(lease time.Duration) (*Delivery, error) {
+	a := m.Called(lease)
+	if a.Get(0) == nil {
+		return nil, a.Error(1)
+	}
+	return a.Get(0).(*Delivery), a.Error(1)
+}
+
+func (m *mockStorage) UpdateWebhookDelivery(delivery Delivery) error {
+	a := m.Called(delivery)
+	return a.Error(0)
+}
+
+func TestSign(t *testing.T) {
+	// echo -n '{"event":"account_created"}' | openssl dgst -sha256 -hmac secret
+	assert.Equal(t, "9084d4f2f2bdb85da84c0b5a4c10c43d4cd348a8a780b61599c09702f8799218", Sign("secret", []byte(`{"event":"account_created"}`)))
+	assert.NotEqual(t, Sign("secret", []byte("a")), Sign("other", []byte("a")))
+}
+
+func TestNotify(t *testing.T) {
+	storage := &mockStorage{}
+	notifier := &Notifier{
+		Storage: storage,
+		URLs:    []string{"http://example.com/a", "http://example.com/b"},
+		Secret:  "secret",
+	}
+
+	var ids []string
+	storage.
+		On("AddWebhookDelivery", mock.AnythingOfType("webhooks.Delivery")).
+		Return(nil).
+		Twice().
+		Run(func(args mock.Arguments) {
+			delivery := args.Get(0).(Delivery)
+			assert.Equal(t, AccountCreatedEvent, delivery.Event)
+			assert.Equal(t, DeliveryStatePending, delivery.State)
+
+			var payload struct {
+				ID    string            `json:"id"`
+				Event Event             `json:"event"`
+				Data  map[string]string `json:"data"`
+			}
+			require.NoError(t, json.Unmarshal([]byte(delivery.Payload), &payload))
+			assert.Equal(t, AccountCreatedEvent, payload.Event)
+			assert.Equal(t, "GDULKYRRVOMASFMXBYD4BYFRSHAKQDREEVVP2TMH2CER3DW2KATIOASB", payload.Data["stellar_public_key"])
+			ids = append(ids, payload.ID)
+		})
+
+	notifier.Notify(AccountCreatedEvent, map[string]string{
+		"stellar_public_key": "GDULKYRRVOMASFMXBYD4BYFRSHAKQDREEVVP2TMH2CER3DW2KATIOASB",
+	})
+
+	storage.AssertExpectations(t)
+	require.Len(t, ids, 2)
+	// Receivers use ID to ignore duplicates, it's the same for all URLs.
+	assert.Equal(t, ids[0], ids[1])
+}
+
+func TestAttemptDelivered(t *testing.T) {
+	payload := `{"event":"account_created"}`
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		assert.Equal(t, payload, string(body))
+		assert.Equal(t, "account_created", r.Header.Get("X-Bifrost-Event"))
+		assert.Equal(t, "7", r.Header.Get("X-Bifrost-Delivery"))
+		assert.Equal(t, "sha256="+Sign("secret", body), r.Header.Get("X-Bifrost-Signature"))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	notifier := &Notifier{Secret: "secret", MaxAttempts: 3, HTTP: http.DefaultClient}
+	notifier.initLogger()
+
+	delivery := &Delivery{ID: 7, URL: server.URL, Event: AccountCreatedEvent, Payload: payload, State: DeliveryStatePending}
+	notifier.attempt(delivery)
+
+	assert.Equal(t, DeliveryStateDelivered, delivery.State)
+	assert.Equal(t, 1, delivery.Attempts)
+	assert.Equal(t, "", delivery.LastError)
+}
+
+func TestAttemptRetry(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	notifier := &Notifier{Secret: "secret", MaxAttempts: 3, HTTP: http.DefaultClient}
+	notifier.initLogger()
+
+	delivery := &Delivery{ID: 7, URL: server.URL, Event: AccountCreatedEvent, Payload: "{}", State: DeliveryStatePending}
+
+	notifier.attempt(delivery)
+	assert.Equal(t, DeliveryStatePending, delivery.State)
+	assert.Equal(t, 1, delivery.Attempts)
+	assert.Equal(t, "Invalid response status code: 500", delivery.LastError)
+	assert.True(t, delivery.NextAttemptAt.After(time.Now().Add(time.Second)))
+
+	notifier.attempt(delivery)
+	assert.Equal(t, DeliveryStatePending, delivery.State)
+
+	notifier.attempt(delivery)
+	assert.Equal(t, DeliveryStateFailed, delivery.State)
+	assert.Equal(t, 3, delivery.Attempts)
+}
+
+func TestBackoff(t *testing.T) {
+	assert.Equal(t, 2*time.Second, backoff(1))
+	assert.Equal(t, 32*time.Second, backoff(5))
+	assert.Equal(t, time.Hour, backoff(12))
+	assert.Equal(t, time.Hour, backoff(100))
+}
@@ -8,7 +8,7 @@ import (
 	"github.com/stellar/go/strkey"
 )
 
-func initFriendbot(friendbotSecret string, networkPassphrase string, horizonURL string, startingBalance string) *internal.Bot {
+func initFriendbot(friendbotSecret string, networkPassphrase string, horizonURL string, startingBalance string, channelSecrets []string) *internal.Bot {
 	if friendbotSecret == "" || networkPassphrase == "" || horizonURL == "" || startingBalance == "" {
 		return nil
 	}
@@ -16,8 +16,15 @@ func initFriendbot(friendbotSecret string, networkPassphrase string, horizonURL
 	// ensure its a seed if its not blank
 	strkey.MustDecode(strkey.VersionByteSeed, friendbotSecret)
 
+	var channels []*internal.Channel
+	for _, secret := range channelSecrets {
+		strkey.MustDecode(strkey.VersionByteSeed, secret)
+		channels = append(channels, &internal.Channel{Secret: secret})
+	}
+
 	return &internal.Bot{
-		Secret: friendbotSecret,
+		Channels: channels,
+		Secret:   friendbotSecret,
 		Horizon: &horizon.Client{
 			URL:  horizonURL,
 			HTTP: http.DefaultClient,
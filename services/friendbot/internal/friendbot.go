@@ -18,14 +18,37 @@ type Bot struct {
 	Network         string
 	StartingBalance string
 
+	// Channels are the accounts whose sequence numbers the funding
+	// transactions consume, while the bot's account funds the new accounts,
+	// so that as many transactions as channels can be submitted in parallel
+	// without racing for the sequence number of the bot's account.  The
+	// transactions consume the sequence number of the bot's account when
+	// there are no channels.
+	Channels []*Channel
+
 	// uninitialized
 	sequence             uint64
 	forceRefreshSequence bool
 	lock                 sync.Mutex
+	initChannels         sync.Once
+	freeChannels         chan *Channel
+}
+
+// Channel is a channel account of the bot.
+type Channel struct {
+	Secret string
+
+	// uninitialized
+	sequence             uint64
+	forceRefreshSequence bool
 }
 
 // Pay funds the account at `destAddress`
 func (bot *Bot) Pay(destAddress string) (*horizon.TransactionSuccess, error) {
+	if len(bot.Channels) > 0 {
+		return bot.payWithChannel(destAddress)
+	}
+
 	channel := make(chan interface{})
 	shouldReadChannel, result, err := bot.lockedPay(channel, destAddress)
 	if !shouldReadChannel {
@@ -140,6 +163,89 @@ func (bot *Bot) refreshSequence() error {
 	return nil
 }
 
+// payWithChannel funds the account at `destAddress` with a transaction
+// consuming the sequence number of the first free channel, waiting for one to
+// be free if none is.
+func (bot *Bot) payWithChannel(destAddress string) (*horizon.TransactionSuccess, error) {
+	bot.initChannels.Do(func() {
+		bot.freeChannels = make(chan *Channel, len(bot.Channels))
+		for _, ch := range bot.Channels {
+			bot.freeChannels <- ch
+		}
+	})
+
+	ch := <-bot.freeChannels
+	defer func() { bot.freeChannels <- ch }()
+
+	if ch.sequence == 0 || ch.forceRefreshSequence {
+		err := bot.refreshChannelSequence(ch)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	signed, err := bot.makeChannelTx(ch, destAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := bot.Horizon.SubmitTransaction(signed)
+	if err != nil {
+		// the sequence number of the channel is unknown once a submission
+		// failed, since the transaction may or may not have consumed it
+		ch.forceRefreshSequence = true
+		return nil, err
+	}
+	return &result, nil
+}
+
+// makeChannelTx builds the transaction funding `destAddress` from the bot's
+// account with the next sequence number of `ch`, signed by both.
+func (bot *Bot) makeChannelTx(ch *Channel, destAddress string) (string, error) {
+	txn, err := b.Transaction(
+		b.SourceAccount{AddressOrSeed: ch.Secret},
+		b.Sequence{Sequence: ch.sequence + 1},
+		b.Network{Passphrase: bot.Network},
+		b.CreateAccount(
+			b.SourceAccount{AddressOrSeed: bot.Secret},
+			b.Destination{AddressOrSeed: destAddress},
+			b.NativeAmount{Amount: bot.StartingBalance},
+		),
+	)
+
+	if err != nil {
+		return "", errors.Wrap(err, "Error building a transaction")
+	}
+
+	txs, err := txn.Sign(ch.Secret, bot.Secret)
+	if err != nil {
+		return "", errors.Wrap(err, "Error signing a transaction")
+	}
+
+	base64, err := txs.Base64()
+	if err == nil {
+		ch.sequence++
+	}
+	return base64, err
+}
+
+// refreshChannelSequence refreshes the sequence of `ch` from its account
+func (bot *Bot) refreshChannelSequence(ch *Channel) error {
+	account, err := bot.Horizon.LoadAccount(keypair.MustParse(ch.Secret).Address())
+	if err != nil {
+		return err
+	}
+
+	seq, err := strconv.ParseInt(account.Sequence, 10, 64)
+	if err != nil {
+		return err
+	}
+
+	ch.sequence = uint64(seq)
+	ch.forceRefreshSequence = false
+	return nil
+}
+
 func (bot *Bot) address() string {
 	kp := keypair.MustParse(bot.Secret)
 	return kp.Address()
@@ -1,6 +1,7 @@
 package internal
 
 import (
+	"net"
 	"net/http"
 	"net/url"
 
@@ -13,6 +14,16 @@ import (
 // FriendbotHandler causes an account at `Address` to be created.
 type FriendbotHandler struct {
 	Friendbot *Bot
+
+	// Hooks check the requests against abuse, in order, before their account
+	// is funded.
+	Hooks []Hook
+
+	// IPLimiter limits the fundings per IP address of the client, and
+	// AccountLimiter the fundings per account.  Fundings are not limited
+	// when nil.
+	IPLimiter      *Limiter
+	AccountLimiter *Limiter
 }
 
 // Handle is a method that implements http.HandlerFunc
@@ -38,12 +49,35 @@ func (handler *FriendbotHandler) doHandle(r *http.Request) (*horizon.Transaction
 		return nil, err
 	}
 
+	for _, hook := range handler.Hooks {
+		err = hook.Check(r)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	address, err := handler.loadAddress(r)
 	if err != nil {
 		return nil, problem.MakeInvalidFieldProblem("addr", err)
 	}
 
-	return handler.loadResult(address)
+	// fundings rejected by the other limiter, or that fail, don't count
+	// against the limits
+	ip := remoteIP(r)
+	if !handler.IPLimiter.Allow(ip) {
+		return nil, &rateLimitedProblem
+	}
+	if !handler.AccountLimiter.Allow(address) {
+		handler.IPLimiter.Cancel(ip)
+		return nil, &rateLimitedProblem
+	}
+
+	result, err := handler.loadResult(address)
+	if err != nil {
+		handler.IPLimiter.Cancel(ip)
+		handler.AccountLimiter.Cancel(address)
+	}
+	return result, err
 }
 
 func (handler *FriendbotHandler) checkEnabled() error {
@@ -80,3 +114,20 @@ func (handler *FriendbotHandler) loadResult(address string) (*horizon.Transactio
 	}
 	return result, err
 }
+
+// remoteIP returns the IP address of the client of r.
+func remoteIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// rateLimitedProblem is rendered when a request is beyond the funding limits.
+var rateLimitedProblem = problem.P{
+	Type:   "rate_limit_exceeded",
+	Title:  "Rate Limit Exceeded",
+	Status: http.StatusTooManyRequests,
+	Detail: "The funding limit of this client or account is reached. Please try again later.",
+}
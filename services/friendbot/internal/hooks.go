@@ -0,0 +1,96 @@
+package internal
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+
+	"github.com/stellar/go/support/errors"
+	"github.com/stellar/go/support/render/problem"
+)
+
+// Hook checks the funding requests against abuse before the bot funds their
+// account.  A hook rejects a request by returning an error, rendered as a
+// problem to the client.
+type Hook interface {
+	Check(r *http.Request) error
+}
+
+// HeaderHook requires the requests to carry the header Name with the value
+// Value, e.g. a header set by a proxy that filters abusive clients.
+type HeaderHook struct {
+	Name  string
+	Value string
+}
+
+var _ Hook = &HeaderHook{}
+
+// Check implements Hook.
+func (hook *HeaderHook) Check(r *http.Request) error {
+	if r.Header.Get(hook.Name) != hook.Value {
+		return &rejectedProblem
+	}
+	return nil
+}
+
+// CaptchaHook requires the requests to carry a captcha response, in their
+// Field parameter, that the captcha service verifies.  The service is any
+// verifying the responses like reCAPTCHA does: the `secret` and `response`
+// are posted to VerifyURL, which responds with a JSON object whose `success`
+// property tells whether the response is valid.
+type CaptchaHook struct {
+	VerifyURL string
+	Secret    string
+	Field     string
+	HTTP      *http.Client
+}
+
+var _ Hook = &CaptchaHook{}
+
+// Check implements Hook.
+func (hook *CaptchaHook) Check(r *http.Request) error {
+	response := r.Form.Get(hook.Field)
+	if response == "" {
+		return problem.MakeInvalidFieldProblem(hook.Field, errors.New("captcha response required"))
+	}
+
+	client := hook.HTTP
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	form := url.Values{
+		"secret":   []string{hook.Secret},
+		"response": []string{response},
+	}
+	if ip := remoteIP(r); ip != "" {
+		form.Set("remoteip", ip)
+	}
+
+	resp, err := client.PostForm(hook.VerifyURL, form)
+	if err != nil {
+		return errors.Wrap(err, "verifying captcha failed")
+	}
+	defer resp.Body.Close()
+
+	var verification struct {
+		Success bool `json:"success"`
+	}
+	err = json.NewDecoder(resp.Body).Decode(&verification)
+	if err != nil {
+		return errors.Wrap(err, "decoding captcha verification failed")
+	}
+
+	if !verification.Success {
+		return problem.MakeInvalidFieldProblem(hook.Field, errors.New("invalid captcha response"))
+	}
+	return nil
+}
+
+// rejectedProblem is rendered when a hook rejects a request.
+var rejectedProblem = problem.P{
+	Type:   "funding_rejected",
+	Title:  "Funding Rejected",
+	Status: http.StatusForbidden,
+	Detail: "Friendbot rejected this request to protect the network from abuse.",
+}
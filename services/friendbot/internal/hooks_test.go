@@ -0,0 +1,51 @@
+package internal
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stellar/go/support/render/problem"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHeaderHook(t *testing.T) {
+	hook := &HeaderHook{Name: "X-Verified", Value: "yes"}
+
+	r := httptest.NewRequest("GET", "/?addr=GABC", nil)
+	assert.Equal(t, &rejectedProblem, hook.Check(r))
+
+	r.Header.Set("X-Verified", "yes")
+	assert.NoError(t, hook.Check(r))
+}
+
+func TestCaptchaHook(t *testing.T) {
+	verifier := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.FormValue("secret") == "secret" && r.FormValue("response") == "valid" {
+			w.Write([]byte(`{"success": true}`))
+			return
+		}
+		w.Write([]byte(`{"success": false}`))
+	}))
+	defer verifier.Close()
+
+	hook := &CaptchaHook{VerifyURL: verifier.URL, Secret: "secret", Field: "captcha"}
+	check := func(response string) error {
+		r := httptest.NewRequest("GET", "/?captcha="+url.QueryEscape(response), nil)
+		r.ParseForm()
+		return hook.Check(r)
+	}
+
+	assert.NoError(t, check("valid"))
+
+	err := check("invalid")
+	if assert.IsType(t, &problem.P{}, err) {
+		assert.Equal(t, http.StatusBadRequest, err.(*problem.P).Status)
+	}
+
+	err = check("")
+	if assert.IsType(t, &problem.P{}, err) {
+		assert.Equal(t, "captcha", err.(*problem.P).Extras["invalid_field"])
+	}
+}
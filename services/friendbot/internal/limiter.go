@@ -0,0 +1,85 @@
+package internal
+
+import (
+	"sync"
+	"time"
+)
+
+// Limiter limits the number of fundings per key, such as the IP address of
+// the client or the funded account, to Max within each Window.  A nil Limiter
+// allows every funding.
+type Limiter struct {
+	Max    int
+	Window time.Duration
+
+	// uninitialized
+	lock    sync.Mutex
+	windows map[string]*limitWindow
+	cleaned time.Time
+}
+
+// limitWindow counts the fundings of a key since the window started.
+type limitWindow struct {
+	start time.Time
+	count int
+}
+
+// Allow records a funding for `key`, reporting whether it is within the
+// limit.  Fundings beyond the limit are not recorded.
+func (l *Limiter) Allow(key string) bool {
+	if l == nil {
+		return true
+	}
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	now := time.Now()
+	if l.windows == nil {
+		l.windows = map[string]*limitWindow{}
+	}
+
+	// forget the windows that ended once per window, so that the keys seen
+	// once don't accumulate
+	if now.Sub(l.cleaned) >= l.Window {
+		l.clean(now)
+		l.cleaned = now
+	}
+
+	w, ok := l.windows[key]
+	if !ok || now.Sub(w.start) >= l.Window {
+		w = &limitWindow{start: now}
+		l.windows[key] = w
+	}
+
+	if w.count >= l.Max {
+		return false
+	}
+	w.count++
+	return true
+}
+
+// Cancel forgets a funding for `key` recorded by Allow, such as a funding
+// rejected by another limiter or that failed.
+func (l *Limiter) Cancel(key string) {
+	if l == nil {
+		return
+	}
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	w, ok := l.windows[key]
+	if ok && w.count > 0 {
+		w.count--
+	}
+}
+
+// clean removes the windows that ended.
+func (l *Limiter) clean(now time.Time) {
+	for key, w := range l.windows {
+		if now.Sub(w.start) >= l.Window {
+			delete(l.windows, key)
+		}
+	}
+}
@@ -0,0 +1,34 @@
+package internal
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLimiter(t *testing.T) {
+	l := &Limiter{Max: 2, Window: 50 * time.Millisecond}
+
+	assert.True(t, l.Allow("a"))
+	assert.True(t, l.Allow("a"))
+	assert.False(t, l.Allow("a"))
+	assert.True(t, l.Allow("b"))
+
+	// the limit resets with the window
+	time.Sleep(60 * time.Millisecond)
+	assert.True(t, l.Allow("a"))
+	assert.Len(t, l.windows, 1)
+
+	// cancelled fundings don't count
+	assert.True(t, l.Allow("a"))
+	l.Cancel("a")
+	assert.True(t, l.Allow("a"))
+	assert.False(t, l.Allow("a"))
+	l.Cancel("c")
+
+	// a nil limiter allows everything
+	var nilLimiter *Limiter
+	assert.True(t, nilLimiter.Allow("a"))
+	nilLimiter.Cancel("a")
+}
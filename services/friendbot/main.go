@@ -5,8 +5,10 @@ import (
 	"net/http"
 	"os"
 	"runtime"
+	"time"
 
 	"github.com/go-chi/chi"
+	"github.com/go-chi/chi/middleware"
 	"github.com/pkg/errors"
 	"github.com/rs/cors"
 	"github.com/spf13/cobra"
@@ -25,6 +27,33 @@ type Config struct {
 	HorizonURL        string            `toml:"horizon_url" valid:"required"`
 	StartingBalance   string            `toml:"starting_balance" valid:"required"`
 	TLS               *server.TLSConfig `valid:"optional"`
+	ChannelSecrets    []string          `toml:"channel_secrets" valid:"optional"`
+	TrustProxy        bool              `toml:"trust_proxy" valid:"optional"`
+	Limits            *LimitsConfig     `toml:"limits" valid:"optional"`
+	Captcha           *CaptchaConfig    `toml:"captcha" valid:"optional"`
+	RequiredHeader    *HeaderConfig     `toml:"required_header" valid:"optional"`
+}
+
+// LimitsConfig configures the limits of the fundings per IP address and per
+// account within each window, e.g. "24h".  Fundings are not limited by a
+// limit of 0.
+type LimitsConfig struct {
+	PerIP      int    `toml:"per_ip" valid:"optional"`
+	PerAccount int    `toml:"per_account" valid:"optional"`
+	Window     string `toml:"window" valid:"required"`
+}
+
+// CaptchaConfig configures the captcha service verifying the captcha
+// responses the requests carry in their `captcha` parameter.
+type CaptchaConfig struct {
+	VerifyURL string `toml:"verify_url" valid:"required"`
+	Secret    string `toml:"secret" valid:"required"`
+}
+
+// HeaderConfig configures a header the requests must carry.
+type HeaderConfig struct {
+	Name  string `toml:"name" valid:"required"`
+	Value string `toml:"value" valid:"required"`
 }
 
 func main() {
@@ -58,18 +87,27 @@ func run(cmd *cobra.Command, args []string) {
 		os.Exit(1)
 	}
 
-	fb := initFriendbot(cfg.FriendbotSecret, cfg.NetworkPassphrase, cfg.HorizonURL, cfg.StartingBalance)
-	router := initRouter(fb)
+	fb := initFriendbot(cfg.FriendbotSecret, cfg.NetworkPassphrase, cfg.HorizonURL, cfg.StartingBalance, cfg.ChannelSecrets)
+	handler, err := initHandler(cfg, fb)
+	if err != nil {
+		log.Error(err)
+		os.Exit(1)
+	}
+	router := initRouter(handler, cfg.TrustProxy)
 	registerProblems()
 
 	server.Serve(router, cfg.Port, cfg.TLS)
 }
 
-func initRouter(fb *internal.Bot) *chi.Mux {
+func initRouter(handler *internal.FriendbotHandler, trustProxy bool) *chi.Mux {
 	routerConfig := server.EmptyConfig()
 
 	// middleware
 	server.AddBasicMiddleware(routerConfig)
+	if trustProxy {
+		// the funding limits apply to the clients of the proxy
+		routerConfig.Middleware(middleware.RealIP)
+	}
 	c := cors.New(cors.Options{
 		AllowedOrigins: []string{"*"},
 		AllowedHeaders: []string{"*"},
@@ -79,7 +117,6 @@ func initRouter(fb *internal.Bot) *chi.Mux {
 	})
 
 	// endpoints
-	handler := &internal.FriendbotHandler{Friendbot: fb}
 	routerConfig.Route(http.MethodGet, "/", http.HandlerFunc(handler.Handle))
 	routerConfig.Route(http.MethodPost, "/", http.HandlerFunc(handler.Handle))
 	// not found handler
@@ -90,6 +127,41 @@ func initRouter(fb *internal.Bot) *chi.Mux {
 	return server.NewRouter(routerConfig)
 }
 
+func initHandler(cfg Config, fb *internal.Bot) (*internal.FriendbotHandler, error) {
+	handler := &internal.FriendbotHandler{Friendbot: fb}
+
+	if cfg.RequiredHeader != nil {
+		handler.Hooks = append(handler.Hooks, &internal.HeaderHook{
+			Name:  cfg.RequiredHeader.Name,
+			Value: cfg.RequiredHeader.Value,
+		})
+	}
+
+	if cfg.Captcha != nil {
+		handler.Hooks = append(handler.Hooks, &internal.CaptchaHook{
+			VerifyURL: cfg.Captcha.VerifyURL,
+			Secret:    cfg.Captcha.Secret,
+			Field:     "captcha",
+		})
+	}
+
+	if cfg.Limits != nil {
+		window, err := time.ParseDuration(cfg.Limits.Window)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid limits window")
+		}
+
+		if cfg.Limits.PerIP > 0 {
+			handler.IPLimiter = &internal.Limiter{Max: cfg.Limits.PerIP, Window: window}
+		}
+		if cfg.Limits.PerAccount > 0 {
+			handler.AccountLimiter = &internal.Limiter{Max: cfg.Limits.PerAccount, Window: window}
+		}
+	}
+
+	return handler, nil
+}
+
 func registerProblems() {
 	problem.RegisterError(sql.ErrNoRows, problem.NotFound)
 }
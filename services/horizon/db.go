@@ -11,6 +11,7 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	"github.com/stellar/go/services/horizon/internal/db2/schema"
+	"github.com/stellar/go/services/horizon/internal/export"
 	"github.com/stellar/go/services/horizon/internal/ingest"
 	hlog "github.com/stellar/go/services/horizon/internal/log"
 	"github.com/stellar/go/support/db"
@@ -30,6 +31,18 @@ var dbBackfillCmd = &cobra.Command{
 
 		i := ingestSystem()
 		i.SkipCursorUpdate = true
+
+		workers, err := cmd.Flags().GetInt("workers")
+		if err != nil {
+			log.Fatal(err)
+		}
+		rangeSize, err := cmd.Flags().GetInt("range-size")
+		if err != nil {
+			log.Fatal(err)
+		}
+		i.ReingestWorkers = workers
+		i.ReingestRangeSize = int32(rangeSize)
+
 		parsed, err := strconv.ParseUint(args[0], 10, 32)
 		if err != nil {
 			log.Fatal(err)
@@ -58,6 +71,72 @@ var dbClearCmd = &cobra.Command{
 	},
 }
 
+var dbExportCmd = &cobra.Command{
+	Use:   "export [FIRST] [LAST]",
+	Short: "exports the history of a range of ledgers to flat files",
+	Long:  "export writes the ledgers, transactions and operations of the ledgers from FIRST to LAST as partitions of newline-delimited JSON or CSV files, to a directory or to a s3://bucket/prefix url",
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) != 2 {
+			cmd.Usage()
+			os.Exit(1)
+		}
+
+		initConfig()
+		hlog.DefaultLogger.Logger.Level = config.LogLevel
+
+		first, err := strconv.ParseInt(args[0], 10, 32)
+		if err != nil {
+			log.Fatal(err)
+		}
+		last, err := strconv.ParseInt(args[1], 10, 32)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		format, err := cmd.Flags().GetString("format")
+		if err != nil {
+			log.Fatal(err)
+		}
+		dest, err := cmd.Flags().GetString("dest")
+		if err != nil {
+			log.Fatal(err)
+		}
+		partitionSize, err := cmd.Flags().GetInt("partition-size")
+		if err != nil {
+			log.Fatal(err)
+		}
+		tables, err := cmd.Flags().GetStringSlice("tables")
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		store, err := export.NewStore(dest)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		hdb, err := db.Open("postgres", config.DatabaseURL)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		e := &export.Exporter{
+			HorizonDB:     hdb,
+			Store:         store,
+			Format:        format,
+			PartitionSize: int32(partitionSize),
+			Tables:        tables,
+		}
+
+		written, err := e.Export(int32(first), int32(last))
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		hlog.WithField("files", written).Info("export complete")
+	},
+}
+
 var dbInitCmd = &cobra.Command{
 	Use:   "init",
 	Short: "install schema",
@@ -78,44 +157,134 @@ var dbInitCmd = &cobra.Command{
 }
 
 var dbMigrateCmd = &cobra.Command{
-	Use:   "migrate [up|down|redo] [COUNT]",
+	Use:   "migrate [up|down|redo|status|plan] [COUNT]",
 	Short: "migrate schema",
-	Long:  "performs a schema migration command",
+	Long: `performs a schema migration command:
+
+  up [COUNT]           applies COUNT pending migrations, or all of them
+  down COUNT           reverts the last COUNT applied migrations
+  redo [COUNT]         reverts then reapplies the last COUNT migrations, or the last one
+  status               lists the migrations, applied or pending
+  plan up|down [COUNT] prints the sql up or down would run, without running it
+
+Each migration runs in its own transaction, and horizon instances migrating
+the same database concurrently wait for each other.`,
 	Run: func(cmd *cobra.Command, args []string) {
 
-		// Allow invokations with 1 or 2 args.  All other args counts are erroneous.
-		if len(args) < 1 || len(args) > 2 {
+		// Allow invokations with 1 to 3 args.  All other args counts are erroneous.
+		if len(args) < 1 || len(args) > 3 {
 			cmd.Usage()
 			os.Exit(1)
 		}
 
-		dir := schema.MigrateDir(args[0])
-		count := 0
-
-		// If a second arg is present, parse it to an int and use it as the count
-		// argument to the migration call.
-		if len(args) == 2 {
-			var err error
-			count, err = strconv.Atoi(args[1])
-			if err != nil {
-				log.Println(err)
+		db, err := sql.Open("postgres", viper.GetString("db-url"))
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		switch args[0] {
+		case "status":
+			if len(args) != 1 {
 				cmd.Usage()
 				os.Exit(1)
 			}
+
+			migrationStatus(db)
+			return
+		case "plan":
+			if len(args) < 2 {
+				cmd.Usage()
+				os.Exit(1)
+			}
+
+			dir := schema.MigrateDir(args[1])
+			migrationPlan(db, dir, migrationCount(cmd, args[2:]))
+			return
 		}
 
-		db, err := sql.Open("postgres", viper.GetString("db-url"))
-		if err != nil {
-			log.Fatal(err)
+		if len(args) > 2 {
+			cmd.Usage()
+			os.Exit(1)
 		}
 
-		_, err = schema.Migrate(db, dir, count)
+		dir := schema.MigrateDir(args[0])
+		count := migrationCount(cmd, args[1:])
+
+		// reverting every migration drops the whole schema, so it has to be
+		// asked for explicitly
+		if dir == schema.MigrateDown && count == 0 {
+			log.Println("down requires a COUNT of migrations to revert")
+			cmd.Usage()
+			os.Exit(1)
+		}
+
+		n, err := schema.Migrate(db, dir, count)
 		if err != nil {
 			log.Fatal(err)
 		}
+
+		log.Printf("applied %d migrations", n)
 	},
 }
 
+// migrationCount parses the optional COUNT argument of the migrate command,
+// returning 0 when absent.
+func migrationCount(cmd *cobra.Command, args []string) int {
+	if len(args) == 0 {
+		return 0
+	}
+
+	count, err := strconv.Atoi(args[0])
+	if err != nil || count < 0 {
+		log.Println("invalid COUNT:", args[0])
+		cmd.Usage()
+		os.Exit(1)
+	}
+
+	return count
+}
+
+// migrationStatus prints the status of each migration of the schema.
+func migrationStatus(db *sql.DB) {
+	status, err := schema.Status(db)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	for _, s := range status {
+		switch {
+		case s.Unknown:
+			fmt.Printf("%-40s applied %s (unknown to this version)\n", s.ID, s.AppliedAt.Format(time.RFC3339))
+		case s.AppliedAt != nil:
+			fmt.Printf("%-40s applied %s\n", s.ID, s.AppliedAt.Format(time.RFC3339))
+		default:
+			fmt.Printf("%-40s pending\n", s.ID)
+		}
+	}
+}
+
+// migrationPlan prints the sql of the migrations that migrating in direction
+// `dir` would run.
+func migrationPlan(db *sql.DB, dir schema.MigrateDir, count int) {
+	planned, err := schema.Plan(db, dir, count)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	if len(planned) == 0 {
+		fmt.Println("-- no migrations to run")
+		return
+	}
+
+	for _, m := range planned {
+		fmt.Printf("-- %s %s\n", dir, m.Id)
+		for _, q := range m.Queries {
+			fmt.Println(q)
+		}
+		fmt.Println()
+	}
+}
+
 var dbReapCmd = &cobra.Command{
 	Use:   "reap",
 	Short: "reaps (i.e. removes) any reapable history data",
@@ -156,7 +325,7 @@ var dbRebaseCmd = &cobra.Command{
 var dbReingestCmd = &cobra.Command{
 	Use:   "reingest",
 	Short: "imports all data",
-	Long:  "reingest runs the ingestion pipeline over every ledger",
+	Long:  "reingest runs the ingestion pipeline over every ledger, over the outdated ledgers with `reingest outdated`, or over the ledgers missing from the history database with `reingest gaps`",
 	Run: func(cmd *cobra.Command, args []string) {
 		initConfig()
 		hlog.DefaultLogger.Logger.Level = config.LogLevel
@@ -206,10 +375,43 @@ func init() {
 	dbCmd.AddCommand(dbInitCmd)
 	dbCmd.AddCommand(dbBackfillCmd)
 	dbCmd.AddCommand(dbClearCmd)
+	dbCmd.AddCommand(dbExportCmd)
 	dbCmd.AddCommand(dbMigrateCmd)
 	dbCmd.AddCommand(dbReapCmd)
 	dbCmd.AddCommand(dbReingestCmd)
 	dbCmd.AddCommand(dbRebaseCmd)
+
+	dbExportCmd.Flags().String(
+		"format",
+		export.FormatJSON,
+		"the format of the exported files: json (one object per line) or csv",
+	)
+	dbExportCmd.Flags().String(
+		"dest",
+		"export",
+		"the directory, or s3://bucket/prefix url, the files are written to",
+	)
+	dbExportCmd.Flags().Int(
+		"partition-size",
+		export.DefaultPartitionSize,
+		"the number of ledgers of each exported file",
+	)
+	dbExportCmd.Flags().StringSlice(
+		"tables",
+		nil,
+		"the tables exported, among ledgers, transactions and operations (default all)",
+	)
+
+	dbReingestCmd.Flags().Int(
+		"workers",
+		1,
+		"the number of workers reingesting ranges of ledgers concurrently",
+	)
+	dbReingestCmd.Flags().Int(
+		"range-size",
+		ingest.DefaultReingestRangeSize,
+		"the number of ledgers a worker reingests in a single transaction",
+	)
 }
 
 func ingestSystem() *ingest.System {
@@ -243,6 +445,11 @@ func reingest(i *ingest.System, args []string) (int, error) {
 		return count, err
 	}
 
+	if len(args) == 1 && args[0] == "gaps" {
+		count, err := i.ReingestGaps()
+		return count, err
+	}
+
 	for idx, arg := range args {
 		seq, err := strconv.Atoi(arg)
 		if err != nil {
@@ -1,18 +1,22 @@
 package horizon
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
 	"net/url"
 	"strings"
 
 	"github.com/stellar/go/services/horizon/internal/actions"
+	"github.com/stellar/go/services/horizon/internal/db2"
 	"github.com/stellar/go/services/horizon/internal/db2/core"
 	"github.com/stellar/go/services/horizon/internal/db2/history"
 	"github.com/stellar/go/services/horizon/internal/httpx"
-	"github.com/stellar/go/services/horizon/internal/ledger"
 	"github.com/stellar/go/services/horizon/internal/log"
 	"github.com/stellar/go/services/horizon/internal/render/problem"
+	"github.com/stellar/go/services/horizon/internal/resource/operations"
 	"github.com/stellar/go/services/horizon/internal/toid"
+	"github.com/stellar/go/xdr"
 	"github.com/zenazn/goji/web"
 )
 
@@ -44,7 +48,7 @@ func (action *Action) CoreQ() *core.Q {
 // horizon's database.
 func (action *Action) HistoryQ() *history.Q {
 	if action.hq == nil {
-		action.hq = &history.Q{Session: action.App.HorizonSession(action.Ctx)}
+		action.hq = &history.Q{Session: action.App.HorizonReadSession(action.Ctx)}
 	}
 
 	return action.hq
@@ -63,6 +67,12 @@ func (action *Action) Prepare(c web.C, w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// GetPageQuery returns the page query requested by the client, whose limit may
+// be up to the max page size of the app.
+func (action *Action) GetPageQuery() db2.PageQuery {
+	return action.Base.GetPageQuery(action.App.MaxPageSize())
+}
+
 // ValidateCursorAsDefault ensures that the cursor parameter is valid in the way
 // it is normally used, i.e. it is either the string "now" or a string of
 // numerals that can be parsed as an int64.
@@ -78,6 +88,85 @@ func (action *Action) ValidateCursorAsDefault() {
 	action.GetInt64(actions.ParamCursor)
 }
 
+// GetOperationTypes parses the `operation_type` parameter, a comma separated
+// list of the names of the operation types records should be filtered by,
+// such as "payment" or "manage_offer".  It returns nil when no type is
+// provided.
+func (action *Action) GetOperationTypes() []xdr.OperationType {
+	if action.Err != nil {
+		return nil
+	}
+
+	raw := action.GetString("operation_type")
+	if raw == "" {
+		return nil
+	}
+
+	var types []xdr.OperationType
+	for _, name := range strings.Split(raw, ",") {
+		name = strings.TrimSpace(name)
+		typ, ok := operationTypesByName[name]
+		if !ok {
+			action.SetInvalidField(
+				"operation_type",
+				fmt.Errorf("unknown operation type %q", name),
+			)
+			return nil
+		}
+		types = append(types, typ)
+	}
+
+	return types
+}
+
+// operationTypesByName maps the names of the operation types used in
+// horizon's JSON responses to the types they represent.
+var operationTypesByName = map[string]xdr.OperationType{}
+
+func init() {
+	for typ, name := range operations.TypeNames {
+		operationTypesByName[name] = typ
+	}
+}
+
+// GetJoinTransactions parses the `join` parameter, returning true when the
+// transaction of each record should be embedded in it.  "transactions" is the
+// only join supported.
+func (action *Action) GetJoinTransactions() bool {
+	if action.Err != nil {
+		return false
+	}
+
+	switch action.GetString("join") {
+	case "":
+		return false
+	case "transactions":
+		return true
+	default:
+		action.SetInvalidField("join", errors.New(`only "transactions" is supported`))
+		return false
+	}
+}
+
+// GetEmbed parses the `embed` parameter, returning true when the records
+// named `name`, the only ones the action can embed, should be embedded in its
+// resource.
+func (action *Action) GetEmbed(name string) bool {
+	if action.Err != nil {
+		return false
+	}
+
+	switch action.GetString("embed") {
+	case "":
+		return false
+	case name:
+		return true
+	default:
+		action.SetInvalidField("embed", fmt.Errorf("only %q is supported", name))
+		return false
+	}
+}
+
 // ValidateCursorWithinHistory compares the requested page of data against the
 // ledger state of the history database.  In the event that the cursor is
 // guaranteed to return no results, we return a 410 GONE http response.
@@ -116,7 +205,7 @@ func (action *Action) ValidateCursorWithinHistory() {
 		return
 	}
 
-	elder := toid.New(ledger.CurrentState().HistoryElder, 0, 0)
+	elder := toid.New(action.App.ledgerState.CurrentState().HistoryElder, 0, 0)
 
 	if cursor <= elder.ToInt64() {
 		action.Err = &problem.BeforeHistory
@@ -130,7 +219,7 @@ func (action *Action) EnsureHistoryFreshness() {
 	}
 
 	if action.App.IsHistoryStale() {
-		ls := ledger.CurrentState()
+		ls := action.App.ledgerState.CurrentState()
 		err := problem.StaleHistory
 		err.Extras = map[string]interface{}{
 			"history_latest_ledger": ls.HistoryLatest,
@@ -143,13 +232,14 @@ func (action *Action) EnsureHistoryFreshness() {
 // FullURL returns the full url for this request
 func (action *Action) FullURL() *url.URL {
 	result := action.baseURL()
-	result.Path = action.R.URL.Path
+	result.Path += action.R.URL.Path
 	result.RawQuery = action.R.URL.RawQuery
 	return result
 }
 
 // baseURL returns the base url for this request, defined as a url containing
-// the Host and Scheme portions of the request uri.
+// the Host and Scheme portions of the request uri, and the path prefix of the
+// network it belongs to.
 func (action *Action) baseURL() *url.URL {
 	return httpx.BaseURL(action.Ctx)
 }
@@ -5,7 +5,9 @@ import (
 
 	gctx "github.com/goji/context"
 
+	"github.com/stellar/go/services/horizon/internal/log"
 	"github.com/stellar/go/services/horizon/internal/render"
+	"github.com/stellar/go/services/horizon/internal/render/hal"
 	hProblem "github.com/stellar/go/services/horizon/internal/render/problem"
 	"github.com/stellar/go/services/horizon/internal/render/sse"
 	"github.com/stellar/go/support/render/problem"
@@ -50,20 +52,57 @@ func (base *Base) Execute(action interface{}) {
 			goto NotAcceptable
 		}
 
+		// buffer the response to tag immutable resources for conditional
+		// requests, and to filter its fields, if the client requested only
+		// some of them
+		w := base.W
+		cw := hal.NewConditionalWriter(w, base.R)
+		base.W = cw
+		var fw *hal.FieldsWriter
+		if fields := hal.RequestedFields(base.R); len(fields) > 0 {
+			fw = hal.NewFieldsWriter(cw, fields)
+			base.W = fw
+		}
+
 		action.JSON()
+		base.W = w
+
+		if base.Err != nil && base.Ctx.Err() == context.DeadlineExceeded {
+			// the error was caused by the request timing out, see
+			// horizon.timeoutMiddleware
+			base.Err = &hProblem.Timeout
+		}
 
 		if base.Err != nil {
 			problem.Render(base.Ctx, base.W, base.Err)
 			return
 		}
 
+		if fw != nil {
+			err := fw.Finish()
+			if err != nil {
+				log.Ctx(base.Ctx).WithStack(err).Error(err)
+			}
+		}
+
+		err := cw.Finish()
+		if err != nil {
+			log.Ctx(base.Ctx).WithStack(err).Error(err)
+		}
+
 	case render.MimeEventStream:
 		action, ok := action.(SSE)
 		if !ok {
 			goto NotAcceptable
 		}
 
+		if sse.Draining() {
+			problem.Render(base.Ctx, base.W, hProblem.Draining)
+			return
+		}
+
 		stream := sse.NewStream(base.Ctx, base.W, base.R)
+		defer stream.Close()
 
 		for {
 			action.SSE(stream)
@@ -84,6 +123,11 @@ func (base *Base) Execute(action interface{}) {
 				return
 			}
 
+			if sse.Draining() {
+				stream.Restarting()
+				return
+			}
+
 			select {
 			case <-base.Ctx.Done():
 				return
@@ -100,6 +144,55 @@ func (base *Base) Execute(action interface{}) {
 
 		action.Raw()
 
+		if base.Err != nil {
+			problem.Render(base.Ctx, base.W, base.Err)
+			return
+		}
+	case render.MimeCSV:
+		action, ok := action.(JSON)
+		if !ok {
+			goto NotAcceptable
+		}
+
+		// buffer the json response to render it as csv
+		w := base.W
+		cw := hal.NewCSVWriter(w)
+		base.W = cw
+
+		action.JSON()
+		base.W = w
+
+		if base.Err != nil {
+			problem.Render(base.Ctx, base.W, base.Err)
+			return
+		}
+
+		err := cw.Finish()
+		if err != nil {
+			log.Ctx(base.Ctx).WithStack(err).Error(err)
+		}
+	case render.MimeXDR:
+		action, ok := action.(XDR)
+
+		if !ok {
+			goto NotAcceptable
+		}
+
+		action.XDR()
+
+		if base.Err != nil {
+			problem.Render(base.Ctx, base.W, base.Err)
+			return
+		}
+	case render.MimeText:
+		action, ok := action.(Text)
+
+		if !ok {
+			goto NotAcceptable
+		}
+
+		action.Text()
+
 		if base.Err != nil {
 			problem.Render(base.Ctx, base.W, base.Err)
 			return
@@ -27,6 +27,11 @@ const (
 	ParamLimit = "limit"
 )
 
+// offsetParams are the query string params of offset pagination, which
+// horizon does not support: pages are found by cursor, so that deep pages
+// don't scan the rows of the pages before them.
+var offsetParams = []string{"offset", "page", "skip"}
+
 // GetCursor retrieves a string from either the URLParams, form or query string.
 // This method uses the priority (URLParams, Form, Query).
 func (base *Base) GetCursor(name string) string {
@@ -37,7 +42,7 @@ func (base *Base) GetCursor(name string) string {
 	cursor := base.GetString(name)
 
 	if cursor == "now" {
-		tid := toid.AfterLedger(ledger.CurrentState().HistoryLatest)
+		tid := toid.AfterLedger(ledger.FromContext(base.Ctx).CurrentState().HistoryLatest)
 		cursor = tid.String()
 	}
 
@@ -123,6 +128,29 @@ func (base *Base) GetInt32(name string) int32 {
 	return int32(asI64)
 }
 
+// GetBool retrieves a bool from the action parameter of the given name.
+// Populates err if the value is not a valid bool
+func (base *Base) GetBool(name string) bool {
+	if base.Err != nil {
+		return false
+	}
+
+	asStr := base.GetString(name)
+
+	if asStr == "" {
+		return false
+	}
+
+	asBool, err := strconv.ParseBool(asStr)
+
+	if err != nil {
+		base.SetInvalidField(name, err)
+		return false
+	}
+
+	return asBool
+}
+
 // GetLimit retrieves a uint64 limit from the action parameter of the given
 // name. Populates err if the value is not a valid limit.  Uses the provided
 // default value if the limit parameter is a blank string.
@@ -156,21 +184,29 @@ func (base *Base) GetLimit(name string, def uint64, max uint64) uint64 {
 }
 
 // GetPageQuery is a helper that returns a new db.PageQuery struct initialized
-// using the results from a call to GetPagingParams()
-func (base *Base) GetPageQuery() db2.PageQuery {
+// using the results from a call to GetPagingParams().  Clients may request up
+// to `maxLimit` records per page.
+func (base *Base) GetPageQuery(maxLimit uint64) db2.PageQuery {
 	if base.Err != nil {
 		return db2.PageQuery{}
 	}
 
+	for _, name := range offsetParams {
+		if base.GetString(name) != "" {
+			base.Err = &hProblem.UnsupportedPagination
+			return db2.PageQuery{}
+		}
+	}
+
 	cursor := base.GetCursor(ParamCursor)
 	order := base.GetString(ParamOrder)
-	limit := base.GetLimit(ParamLimit, db2.DefaultPageSize, db2.MaxPageSize)
+	limit := base.GetLimit(ParamLimit, db2.DefaultPageSize, maxLimit)
 
 	if base.Err != nil {
 		return db2.PageQuery{}
 	}
 
-	r, err := db2.NewPageQuery(cursor, order, limit)
+	r, err := db2.NewPageQueryWithMax(cursor, order, limit, maxLimit)
 
 	if err != nil {
 		base.Err = err
@@ -7,7 +7,9 @@ import (
 	"net/url"
 	"testing"
 
+	"github.com/stellar/go/services/horizon/internal/db2"
 	"github.com/stellar/go/services/horizon/internal/ledger"
+	hProblem "github.com/stellar/go/services/horizon/internal/render/problem"
 	"github.com/stellar/go/services/horizon/internal/test"
 	"github.com/stellar/go/services/horizon/internal/toid"
 	"github.com/stellar/go/support/render/problem"
@@ -105,6 +107,31 @@ func TestGetCursor(t *testing.T) {
 	}
 }
 
+func TestGetBool(t *testing.T) {
+	tt := test.Start(t)
+	defer tt.Finish()
+	action := makeTestAction()
+
+	result := action.GetBool("blank")
+	tt.Assert.NoError(action.Err)
+	tt.Assert.False(result)
+
+	result = action.GetBool("true")
+	tt.Assert.NoError(action.Err)
+	tt.Assert.True(result)
+
+	result = action.GetBool("zero")
+	tt.Assert.NoError(action.Err)
+	tt.Assert.False(result)
+
+	_ = action.GetBool("two")
+	if tt.Assert.IsType(&problem.P{}, action.Err) {
+		p := action.Err.(*problem.P)
+		tt.Assert.Equal("bad_request", p.Type)
+		tt.Assert.Equal("two", p.Extras["invalid_field"])
+	}
+}
+
 func TestGetInt32(t *testing.T) {
 	tt := test.Start(t)
 	defer tt.Finish()
@@ -228,7 +255,7 @@ func TestGetPageQuery(t *testing.T) {
 	action := makeTestAction()
 
 	// happy path
-	pq := action.GetPageQuery()
+	pq := action.GetPageQuery(db2.MaxPageSize)
 	tt.Assert.NoError(action.Err)
 	tt.Assert.Equal("hello", pq.Cursor)
 	tt.Assert.Equal(uint64(2), pq.Limit)
@@ -238,13 +265,30 @@ func TestGetPageQuery(t *testing.T) {
 	action = makeAction("/?limit=foo", nil)
 	_ = action.GetLimit("limit", 1, 200)
 	tt.Assert.Error(action.Err)
-	_ = action.GetPageQuery()
+	_ = action.GetPageQuery(db2.MaxPageSize)
 	tt.Assert.Error(action.Err)
 
 	// regression: https://github.com/stellar/go/services/horizon/internal/issues/372
 	// (limit of 0 turns into 10)
 	makeAction("/?limit=0", nil)
-	_ = action.GetPageQuery()
+	_ = action.GetPageQuery(db2.MaxPageSize)
+	tt.Assert.Error(action.Err)
+
+	// offset pagination is not supported
+	for _, path := range []string{"/?offset=100", "/?page=3", "/?skip=20&limit=10"} {
+		action = makeAction(path, nil)
+		_ = action.GetPageQuery(db2.MaxPageSize)
+		tt.Assert.Equal(&hProblem.UnsupportedPagination, action.Err, path)
+	}
+
+	// the max limit is configurable
+	action = makeAction("/?limit=500", nil)
+	pq = action.GetPageQuery(500)
+	if tt.Assert.NoError(action.Err) {
+		tt.Assert.Equal(uint64(500), pq.Limit)
+	}
+	action = makeAction("/?limit=501", nil)
+	_ = action.GetPageQuery(500)
 	tt.Assert.Error(action.Err)
 }
 
@@ -290,6 +334,7 @@ func testURLParams() map[string]string {
 		"blank":                "",
 		"zero":                 "0",
 		"two":                  "2",
+		"true":                 "true",
 		"32min":                fmt.Sprint(math.MinInt32),
 		"32max":                fmt.Sprint(math.MaxInt32),
 		"64min":                fmt.Sprint(math.MinInt64),
@@ -14,6 +14,18 @@ type Raw interface {
 	Raw()
 }
 
+// Text implementors can respond to a request whose response type was
+// negotiated to be MimeText.
+type Text interface {
+	Text()
+}
+
+// XDR implementors can respond to a request whose response type was
+// negotiated to be MimeXDR.
+type XDR interface {
+	XDR()
+}
+
 // SSE implementors can respond to a request whose response type was negotiated
 // to be MimeEventStream.
 type SSE interface {
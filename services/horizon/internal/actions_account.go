@@ -1,16 +1,24 @@
 package horizon
 
 import (
+	"errors"
+	"strings"
+
+	"github.com/stellar/go/services/horizon/internal/db2"
 	"github.com/stellar/go/services/horizon/internal/db2/core"
 	"github.com/stellar/go/services/horizon/internal/db2/history"
+	hHal "github.com/stellar/go/services/horizon/internal/render/hal"
+	"github.com/stellar/go/services/horizon/internal/render/sse"
 	"github.com/stellar/go/services/horizon/internal/resource"
+	"github.com/stellar/go/strkey"
 	"github.com/stellar/go/support/render/hal"
-	"github.com/stellar/go/services/horizon/internal/render/sse"
+	"github.com/stellar/go/xdr"
 )
 
 // This file contains the actions:
 //
 // AccountShowAction: details for single account (including stellar-core state)
+// AccountIndexAction: pages of the accounts of a signer or trusting an asset
 
 // AccountShowAction renders a account summary found by its address.
 type AccountShowAction struct {
@@ -102,3 +110,136 @@ func (action *AccountShowAction) loadResource() {
 		action.HistoryRecord,
 	)
 }
+
+// AccountIndexAction renders a page of the accounts a key is a signer of, or
+// of the accounts trusting an asset, as indexed during ingestion.
+type AccountIndexAction struct {
+	Action
+	Signer    string
+	Asset     *xdr.Asset
+	PageQuery db2.PageQuery
+	Addresses []string
+	Records   []resource.Account
+	Page      hHal.Page
+}
+
+// JSON is a method for actions.JSON
+func (action *AccountIndexAction) JSON() {
+	action.Do(
+		action.loadParams,
+		action.loadRecords,
+		action.loadPage,
+		func() {
+			hal.Render(action.W, action.Page)
+		},
+	)
+}
+
+func (action *AccountIndexAction) loadParams() {
+	action.PageQuery = action.GetPageQuery()
+	if action.Err != nil {
+		return
+	}
+
+	signer := action.GetString("signer")
+	asset := action.GetString("asset")
+
+	switch {
+	case signer != "" && asset != "":
+		action.SetInvalidField("signer", errors.New("signer and asset cannot both be set"))
+	case signer != "":
+		action.Signer = signer
+		action.Err = validateSigner(signer)
+		if action.Err != nil {
+			action.SetInvalidField("signer", action.Err)
+		}
+	case asset != "":
+		action.Asset, action.Err = parseAssetParam(asset)
+		if action.Err != nil {
+			action.SetInvalidField("asset", action.Err)
+		}
+	default:
+		action.SetInvalidField("signer", errors.New("either signer or asset is required"))
+	}
+}
+
+func (action *AccountIndexAction) loadRecords() {
+	if action.Asset != nil {
+		action.Err = action.HistoryQ().
+			AccountsByAsset(&action.Addresses, *action.Asset, action.PageQuery)
+	} else {
+		action.Err = action.HistoryQ().
+			AccountsBySigner(&action.Addresses, action.Signer, action.PageQuery)
+	}
+	if action.Err != nil {
+		return
+	}
+
+	for _, address := range action.Addresses {
+		show := AccountShowAction{Action: action.Action, Address: address}
+		show.loadRecord()
+
+		// the index may lag behind stellar-core, which knows accounts merged
+		// since the last ingestion
+		if action.CoreQ().NoRows(show.Err) {
+			continue
+		}
+
+		show.loadResource()
+		if show.Err != nil {
+			action.Err = show.Err
+			return
+		}
+		action.Records = append(action.Records, show.Resource)
+	}
+}
+
+func (action *AccountIndexAction) loadPage() {
+	for _, record := range action.Records {
+		action.Page.Add(record)
+	}
+
+	action.Page.FullURL = action.FullURL()
+	action.Page.Limit = action.PageQuery.Limit
+	action.Page.Cursor = action.PageQuery.Cursor
+	action.Page.Order = action.PageQuery.Order
+	action.Page.PopulateLinks()
+}
+
+// validateSigner returns an error unless `signer` is the strkey of a signer
+// key: an account id, a pre-authorized transaction hash or a hash(x).
+func validateSigner(signer string) error {
+	version, err := strkey.Version(signer)
+	if err != nil {
+		return err
+	}
+
+	switch version {
+	case strkey.VersionByteAccountID, strkey.VersionByteHashTx, strkey.VersionByteHashX:
+		_, err = strkey.Decode(version, signer)
+		return err
+	default:
+		return errors.New("not a signer key")
+	}
+}
+
+// parseAssetParam parses an asset formatted as `code:issuer`.
+func parseAssetParam(param string) (*xdr.Asset, error) {
+	parts := strings.Split(param, ":")
+	if len(parts) != 2 || parts[0] == "" || len(parts[0]) > maxAssetCodeLength {
+		return nil, errors.New("asset must be formatted as code:issuer")
+	}
+
+	var issuer xdr.AccountId
+	err := issuer.SetAddress(parts[1])
+	if err != nil {
+		return nil, err
+	}
+
+	var asset xdr.Asset
+	err = asset.SetCredit(parts[0], issuer)
+	if err != nil {
+		return nil, err
+	}
+	return &asset, nil
+}
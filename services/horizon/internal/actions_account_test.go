@@ -56,3 +56,62 @@ func TestAccountActions_ShowRegressions(t *testing.T) {
 	ht.Assert.Equal(200, w.Code)
 
 }
+
+func TestAccountActions_Index(t *testing.T) {
+	ht := StartHTTPTest(t, "base")
+	defer ht.Finish()
+
+	signer := "GCXKG6RN4ONIEPCMNFB732A436Z5PNDSRLGWK7GBLCMQLIFO4S7EYWVU"
+	issuer := "GA5WBPYA5Y4WAEHXWR2UKO2UO4BUGHUQ74EUPKON2QHV4WRHOIRNKKH2"
+	accounts := []string{
+		"GBRPYHIL2CI3FNQ4BXLFMNDLFJUNPU2HY3ZMFSHONUCEOASW7QC7OX2H",
+		"GBXGQJWVLWOYHFLVTKWV5FGHA3LNYY2JQKM7OAJAUEQFU6LPCSEFVXON",
+		// merged since the last ingestion
+		"GDRZSU7BG6WAQQPHRSKNQ6FTFZAJFNE5NGA2IR7OB5M6XEE6HYL6TCZS",
+	}
+
+	hdb := ht.HorizonSession()
+	for _, account := range accounts {
+		_, err := hdb.ExecRaw(
+			`INSERT INTO accounts_signers (account, signer, weight) VALUES (?, ?, 1)`,
+			account, signer,
+		)
+		ht.Require.NoError(err)
+		_, err = hdb.ExecRaw(
+			`INSERT INTO accounts_trustlines (account, asset_type, asset_code, asset_issuer) VALUES (?, 1, 'USD', ?)`,
+			account, issuer,
+		)
+		ht.Require.NoError(err)
+	}
+
+	w := ht.Get("/accounts?signer=" + signer)
+	if ht.Assert.Equal(200, w.Code) {
+		var records []resource.Account
+		ht.UnmarshalPage(w.Body, &records)
+		if ht.Assert.Len(records, 2) {
+			ht.Assert.Equal(accounts[0], records[0].AccountID)
+			ht.Assert.Equal(accounts[0], records[0].PagingToken())
+			ht.Assert.Equal(accounts[1], records[1].AccountID)
+		}
+	}
+
+	w = ht.Get("/accounts?asset=USD:" + issuer + "&cursor=" + accounts[0])
+	if ht.Assert.Equal(200, w.Code) {
+		ht.Assert.PageOf(1, w.Body)
+	}
+
+	w = ht.Get("/accounts?asset=EUR:" + issuer)
+	if ht.Assert.Equal(200, w.Code) {
+		ht.Assert.PageOf(0, w.Body)
+	}
+
+	// invalid requests
+	w = ht.Get("/accounts")
+	ht.Assert.Equal(400, w.Code)
+	w = ht.Get("/accounts?signer=" + signer + "&asset=USD:" + issuer)
+	ht.Assert.Equal(400, w.Code)
+	w = ht.Get("/accounts?signer=SBQHO2IMYKXAYJFCWGXC7YKLJD2EGDPSK3IUDHVJ6OOTTKLSCK6Z6POM")
+	ht.Assert.Equal(400, w.Code)
+	w = ht.Get("/accounts?asset=USD")
+	ht.Assert.Equal(400, w.Code)
+}
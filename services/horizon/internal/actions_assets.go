@@ -6,6 +6,7 @@ import (
 	"github.com/stellar/go/services/horizon/internal/db2"
 	"github.com/stellar/go/services/horizon/internal/db2/assets"
 	"github.com/stellar/go/services/horizon/internal/render/hal"
+	"github.com/stellar/go/services/horizon/internal/render/sse"
 	"github.com/stellar/go/services/horizon/internal/resource"
 	halRender "github.com/stellar/go/support/render/hal"
 )
@@ -38,6 +39,32 @@ func (action *AssetsAction) JSON() {
 	)
 }
 
+// SSE is a method for actions.SSE
+func (action *AssetsAction) SSE(stream sse.Stream) {
+	action.Setup(action.loadParams)
+	action.Do(
+		action.loadRecords,
+		func() {
+			stream.SetLimit(int(action.PagingParams.Limit))
+			records := action.Records[stream.SentCount():]
+
+			for _, record := range records {
+				var res resource.AssetStat
+				err := res.Populate(action.Ctx, record)
+				if err != nil {
+					stream.Err(err)
+					return
+				}
+
+				stream.Send(sse.Event{
+					ID:   res.PagingToken(),
+					Data: res,
+				})
+			}
+		},
+	)
+}
+
 func (action *AssetsAction) loadParams() {
 	action.AssetCode = action.GetString("asset_code")
 	if len(action.AssetCode) > maxAssetCodeLength {
@@ -1,11 +1,123 @@
 package horizon
 
 import (
+	"github.com/stellar/go/services/horizon/internal/db2"
 	"github.com/stellar/go/services/horizon/internal/db2/core"
-	"github.com/stellar/go/support/render/hal"
+	hHal "github.com/stellar/go/services/horizon/internal/render/hal"
 	"github.com/stellar/go/services/horizon/internal/render/sse"
+	"github.com/stellar/go/services/horizon/internal/resource"
+	"github.com/stellar/go/support/render/hal"
 )
 
+// This file contains the actions:
+//
+// DataIndexAction: pages of the data entries of an account
+// DataShowAction: the value of a single data entry of an account
+
+// DataIndexAction renders a page of the data entries of an account.  When
+// streaming, it sends the entries of the account, then the entries that are
+// added, changed or removed as ledgers close.
+type DataIndexAction struct {
+	Action
+	Address   string
+	PageQuery db2.PageQuery
+	Records   []core.AccountData
+	Page      hHal.Page
+
+	// sent holds the values of the entries last sent to a stream, by key.
+	sent map[string]string
+}
+
+// JSON is a method for actions.JSON
+func (action *DataIndexAction) JSON() {
+	action.Do(
+		action.loadParams,
+		action.loadRecords,
+		action.loadPage,
+		func() {
+			hal.Render(action.W, action.Page)
+		},
+	)
+}
+
+// SSE is a method for actions.SSE
+func (action *DataIndexAction) SSE(stream sse.Stream) {
+	action.Setup(action.loadParams)
+	action.Do(
+		action.loadAllRecords,
+		func() {
+			stream.SetLimit(int(action.PageQuery.Limit))
+			for _, record := range action.changedRecords() {
+				var res resource.AccountData
+				res.Populate(action.Ctx, record)
+				stream.Send(sse.Event{Data: res})
+			}
+		},
+	)
+}
+
+func (action *DataIndexAction) loadParams() {
+	action.PageQuery = action.GetPageQuery()
+	action.Address = action.GetAddress("account_id")
+}
+
+func (action *DataIndexAction) loadRecords() {
+	action.Err = action.CoreQ().DataByAddress(
+		&action.Records,
+		action.Address,
+		action.PageQuery,
+	)
+}
+
+func (action *DataIndexAction) loadAllRecords() {
+	action.Records = nil
+	action.Err = action.CoreQ().AllDataByAddress(&action.Records, action.Address)
+}
+
+func (action *DataIndexAction) loadPage() {
+	for _, record := range action.Records {
+		var res resource.AccountData
+		res.Populate(action.Ctx, record)
+		action.Page.Add(res)
+	}
+
+	action.Page.FullURL = action.FullURL()
+	action.Page.Limit = action.PageQuery.Limit
+	action.Page.Cursor = action.PageQuery.Cursor
+	action.Page.Order = action.PageQuery.Order
+	action.Page.PopulateLinks()
+}
+
+// changedRecords returns the records that were not sent to the stream yet or
+// whose value changed since, followed by the entries that were sent but have
+// been removed from the account, with an empty value.
+func (action *DataIndexAction) changedRecords() []core.AccountData {
+	if action.sent == nil {
+		action.sent = map[string]string{}
+	}
+
+	var changed []core.AccountData
+	current := map[string]bool{}
+	for _, record := range action.Records {
+		current[record.Key] = true
+		if value, ok := action.sent[record.Key]; ok && value == record.Value {
+			continue
+		}
+		action.sent[record.Key] = record.Value
+		changed = append(changed, record)
+	}
+
+	for key := range action.sent {
+		if current[key] {
+			continue
+		}
+		delete(action.sent, key)
+		changed = append(changed, core.AccountData{Accountid: action.Address, Key: key})
+	}
+
+	return changed
+}
+
 // DataShowAction renders a account summary found by its address.
 type DataShowAction struct {
 	Action
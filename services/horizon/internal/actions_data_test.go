@@ -3,11 +3,75 @@ package horizon
 import (
 	"encoding/base64"
 	"encoding/json"
+	"strings"
 	"testing"
 
+	"github.com/stellar/go/services/horizon/internal/db2/core"
+	"github.com/stellar/go/services/horizon/internal/resource"
 	"github.com/stellar/go/services/horizon/internal/test"
 )
 
+func TestDataActions_Index(t *testing.T) {
+	ht := StartHTTPTest(t, "kahuna")
+	defer ht.Finish()
+
+	prefix := "/accounts/GAYSCMKQY6EYLXOPTT6JPPOXDMVNBWITPTSZIVWW4LWARVBOTH5RTLAD"
+
+	w := ht.Get(prefix + "/data")
+	if ht.Assert.Equal(200, w.Code) {
+		ht.Assert.PageOf(2, w.Body)
+	}
+
+	w = ht.Get(prefix + "/data?limit=1&order=desc")
+	if ht.Assert.Equal(200, w.Code) {
+		var records []resource.AccountData
+		ht.UnmarshalPage(w.Body, &records)
+		if ht.Assert.Len(records, 1) {
+			ht.Assert.Equal("name1", records[0].Key)
+			ht.Assert.Equal("MDAwMA==", records[0].Value)
+			ht.Assert.Equal("name1", records[0].PagingToken())
+		}
+	}
+
+	w = ht.Get(prefix + "/data?cursor=name1")
+	if ht.Assert.Equal(200, w.Code) {
+		ht.Assert.PageOf(0, w.Body)
+	}
+
+	// streams end once limit entries are sent
+	w = ht.Get(prefix+"/data?limit=2", test.RequestHelperStreaming)
+	if ht.Assert.Equal(200, w.Code) {
+		body := w.Body.String()
+		ht.Assert.Contains(body, "event: open\n")
+		ht.Assert.Equal(2, strings.Count(body, `"key":`))
+	}
+
+	w = ht.Get("/accounts/foo/data")
+	ht.Assert.Equal(400, w.Code)
+}
+
+func TestDataIndexAction_changedRecords(t *testing.T) {
+	tt := test.Start(t)
+	defer tt.Finish()
+
+	action := &DataIndexAction{Address: "GAYSCMKQY6EYLXOPTT6JPPOXDMVNBWITPTSZIVWW4LWARVBOTH5RTLAD"}
+	keys := func(records []core.AccountData) []string {
+		var keys []string
+		for _, r := range records {
+			keys = append(keys, r.Key+"="+r.Value)
+		}
+		return keys
+	}
+
+	action.Records = []core.AccountData{{Key: "a", Value: "MQ=="}, {Key: "b", Value: "Mg=="}}
+	tt.Assert.Equal([]string{"a=MQ==", "b=Mg=="}, keys(action.changedRecords()))
+	tt.Assert.Len(action.changedRecords(), 0)
+
+	action.Records = []core.AccountData{{Key: "a", Value: "Mw=="}, {Key: "c", Value: "NA=="}}
+	tt.Assert.Equal([]string{"a=Mw==", "c=NA==", "b="}, keys(action.changedRecords()))
+	tt.Assert.Len(action.changedRecords(), 0)
+}
+
 func TestDataActions_Show(t *testing.T) {
 	ht := StartHTTPTest(t, "kahuna")
 	defer ht.Finish()
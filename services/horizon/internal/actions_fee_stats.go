@@ -0,0 +1,48 @@
+package horizon
+
+import (
+	"github.com/stellar/go/services/horizon/internal/db2/history"
+	"github.com/stellar/go/services/horizon/internal/resource"
+	"github.com/stellar/go/support/render/hal"
+)
+
+// This file contains the actions:
+//
+// FeeStatsAction: fee statistics of recent ledgers
+
+// feeStatsLedgers is the number of recent ledgers whose transactions are
+// aggregated by FeeStatsAction.
+const feeStatsLedgers = 5
+
+// FeeStatsAction renders the statistics of the fees per operation offered by
+// the transactions of the last few ledgers, so that clients can pick the fee
+// of their transactions depending on the load of the network.
+type FeeStatsAction struct {
+	Action
+	Latest history.Ledger
+	Record history.FeeStats
+}
+
+// JSON is a method for actions.JSON
+func (action *FeeStatsAction) JSON() {
+	action.Do(
+		action.EnsureHistoryFreshness,
+		action.loadRecords,
+		func() {
+			var res resource.FeeStats
+			res.Populate(action.Ctx, action.Record, action.Latest)
+			hal.Render(action.W, res)
+		},
+	)
+}
+
+func (action *FeeStatsAction) loadRecords() {
+	latest := action.App.ledgerState.CurrentState().HistoryLatest
+
+	action.Err = action.HistoryQ().LedgerBySequence(&action.Latest, latest)
+	if action.Err != nil {
+		return
+	}
+
+	action.Err = action.HistoryQ().FeeStats(&action.Record, latest, feeStatsLedgers)
+}
@@ -0,0 +1,28 @@
+package horizon
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stellar/go/services/horizon/internal/resource"
+)
+
+func TestFeeStatsActions_Show(t *testing.T) {
+	ht := StartHTTPTest(t, "base")
+	defer ht.Finish()
+
+	w := ht.Get("/fee_stats")
+
+	if ht.Assert.Equal(200, w.Code) {
+		var result resource.FeeStats
+		err := json.Unmarshal(w.Body.Bytes(), &result)
+		ht.Require.NoError(err)
+
+		ht.Assert.Equal(int32(3), result.LastLedger)
+		ht.Assert.Equal(int32(100), result.LastLedgerBaseFee)
+		ht.Assert.Equal(int32(100), result.MinAcceptedFee)
+		ht.Assert.Equal(int32(100), result.ModeAcceptedFee)
+		ht.Assert.Equal(int32(100), result.P50AcceptedFee)
+		ht.Assert.Equal(int32(100), result.P99AcceptedFee)
+	}
+}
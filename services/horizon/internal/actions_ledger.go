@@ -3,7 +3,6 @@ package horizon
 import (
 	"github.com/stellar/go/services/horizon/internal/db2"
 	"github.com/stellar/go/services/horizon/internal/db2/history"
-	"github.com/stellar/go/services/horizon/internal/ledger"
 	"github.com/stellar/go/services/horizon/internal/render/hal"
 	"github.com/stellar/go/services/horizon/internal/render/problem"
 	"github.com/stellar/go/services/horizon/internal/render/sse"
@@ -101,6 +100,7 @@ func (action *LedgerShowAction) JSON() {
 		func() {
 			var res resource.Ledger
 			res.Populate(action.Ctx, action.Record)
+			hal.Immutable(action.W, action.Record.ClosedAt)
 			halRender.Render(action.W, res)
 		},
 	)
@@ -111,12 +111,12 @@ func (action *LedgerShowAction) loadParams() {
 }
 
 func (action *LedgerShowAction) loadRecord() {
-	action.Err = action.HistoryQ().
-		LedgerBySequence(&action.Record, action.Sequence)
+	action.Err = action.App.historyCache.
+		LedgerBySequence(action.HistoryQ(), &action.Record, action.Sequence)
 }
 
 func (action *LedgerShowAction) verifyWithinHistory() {
-	if action.Sequence < ledger.CurrentState().HistoryElder {
+	if action.Sequence < action.App.ledgerState.CurrentState().HistoryElder {
 		action.Err = &problem.BeforeHistory
 	}
 }
@@ -2,6 +2,7 @@ package horizon
 
 import (
 	"encoding/json"
+	"net/http"
 	"testing"
 
 	"github.com/stellar/go/services/horizon/internal/resource"
@@ -39,6 +40,24 @@ func TestLedgerActions_Show(t *testing.T) {
 		ht.Assert.NotEmpty(result.HeaderXDR)
 	}
 
+	// conditional requests
+	etag := w.HeaderMap.Get("ETag")
+	ht.Assert.NotEmpty(etag)
+	ht.Assert.NotEmpty(w.HeaderMap.Get("Last-Modified"))
+
+	w = ht.Get("/ledgers/1", func(r *http.Request) {
+		r.Header.Set("If-None-Match", etag)
+	})
+	ht.Assert.Equal(304, w.Code)
+	ht.Assert.Empty(w.Body.Bytes())
+
+	// selecting fields changes the etag
+	w = ht.Get("/ledgers/1?fields=sequence", func(r *http.Request) {
+		r.Header.Set("If-None-Match", etag)
+	})
+	ht.Assert.Equal(200, w.Code)
+	ht.Assert.NotEqual(etag, w.HeaderMap.Get("ETag"))
+
 	// ledger higher than history
 	w = ht.Get("/ledgers/100")
 	ht.Assert.Equal(404, w.Code)
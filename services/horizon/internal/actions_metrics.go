@@ -1,6 +1,13 @@
 package horizon
 
 import (
+	"bytes"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
 	"github.com/rcrowley/go-metrics"
 	"github.com/stellar/go/services/horizon/internal/render/hal"
 	halRender "github.com/stellar/go/support/render/hal"
@@ -87,3 +94,103 @@ func (action *MetricsAction) LoadSnapshot() {
 	})
 
 }
+
+// Text is a method for actions.Text.  It renders the metrics in the
+// Prometheus text exposition format, for prometheus servers scraping horizon.
+func (action *MetricsAction) Text() {
+	var buf bytes.Buffer
+
+	names := []string{}
+	action.App.metrics.Each(func(name string, i interface{}) {
+		names = append(names, name)
+	})
+	sort.Strings(names)
+
+	for _, name := range names {
+		writePrometheusMetric(&buf, "horizon_"+prometheusName(name), "", action.App.metrics.Get(name))
+	}
+
+	// the requests of each route are timed as a single metric, labeled by route
+	routes := []string{}
+	action.App.web.routeTimers.Each(func(route string, i interface{}) {
+		routes = append(routes, route)
+	})
+	sort.Strings(routes)
+
+	if len(routes) > 0 {
+		fmt.Fprintln(&buf, "# TYPE horizon_route_request_duration_seconds summary")
+	}
+	for _, route := range routes {
+		parts := strings.SplitN(route, " ", 2)
+		labels := fmt.Sprintf("method=%q,route=%q", parts[0], parts[1])
+		writePrometheusTimer(&buf, "horizon_route_request_duration_seconds", labels, action.App.web.routeTimers.Get(route).(metrics.Timer))
+	}
+
+	action.W.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	action.W.Write(buf.Bytes())
+}
+
+var prometheusQuantiles = []float64{0.5, 0.75, 0.95, 0.99, 0.999}
+
+// prometheusName converts the name of a metric, e.g. "history.latest_ledger",
+// to a valid prometheus metric name.
+func prometheusName(name string) string {
+	return strings.Map(func(r rune) rune {
+		if ('a' <= r && r <= 'z') || ('A' <= r && r <= 'Z') || ('0' <= r && r <= '9') || r == '_' {
+			return r
+		}
+		return '_'
+	}, name)
+}
+
+// writePrometheusMetric writes metric, with its type, to w.  Meters are
+// written as counters of their events, and timers as summaries in seconds.
+func writePrometheusMetric(w io.Writer, name string, labels string, metric interface{}) {
+	switch metric := metric.(type) {
+	case metrics.Counter:
+		fmt.Fprintf(w, "# TYPE %s counter\n", name)
+		fmt.Fprintf(w, "%s %d\n", name, metric.Count())
+	case metrics.Gauge:
+		fmt.Fprintf(w, "# TYPE %s gauge\n", name)
+		fmt.Fprintf(w, "%s %d\n", name, metric.Value())
+	case metrics.GaugeFloat64:
+		fmt.Fprintf(w, "# TYPE %s gauge\n", name)
+		fmt.Fprintf(w, "%s %g\n", name, metric.Value())
+	case metrics.Meter:
+		fmt.Fprintf(w, "# TYPE %s_total counter\n", name)
+		fmt.Fprintf(w, "%s_total %d\n", name, metric.Count())
+	case metrics.Histogram:
+		h := metric.Snapshot()
+		ps := h.Percentiles(prometheusQuantiles)
+		fmt.Fprintf(w, "# TYPE %s summary\n", name)
+		for i, q := range prometheusQuantiles {
+			fmt.Fprintf(w, "%s{quantile=\"%g\"} %g\n", name, q, ps[i])
+		}
+		fmt.Fprintf(w, "%s_sum %d\n", name, h.Sum())
+		fmt.Fprintf(w, "%s_count %d\n", name, h.Count())
+	case metrics.Timer:
+		fmt.Fprintf(w, "# TYPE %s_seconds summary\n", name)
+		writePrometheusTimer(w, name+"_seconds", labels, metric)
+	}
+}
+
+// writePrometheusTimer writes the samples of timer, in seconds, to w.
+func writePrometheusTimer(w io.Writer, name string, labels string, timer metrics.Timer) {
+	t := timer.Snapshot()
+	ps := t.Percentiles(prometheusQuantiles)
+
+	prefix := ""
+	if labels != "" {
+		prefix = labels + ","
+	}
+
+	for i, q := range prometheusQuantiles {
+		fmt.Fprintf(w, "%s{%squantile=\"%g\"} %g\n", name, prefix, q, ps[i]/float64(time.Second))
+	}
+
+	if labels != "" {
+		labels = "{" + labels + "}"
+	}
+	fmt.Fprintf(w, "%s_sum%s %g\n", name, labels, float64(t.Sum())/float64(time.Second))
+	fmt.Fprintf(w, "%s_count%s %d\n", name, labels, t.Count())
+}
@@ -1,6 +1,9 @@
 package horizon
 
 import (
+	"strconv"
+
+	"github.com/stellar/go/services/horizon/internal/actions"
 	"github.com/stellar/go/services/horizon/internal/db2"
 	"github.com/stellar/go/services/horizon/internal/db2/core"
 	"github.com/stellar/go/services/horizon/internal/render/hal"
@@ -36,8 +39,8 @@ func (action *OffersByAccountAction) JSON() {
 
 // SSE is a method for actions.SSE
 func (action *OffersByAccountAction) SSE(stream sse.Stream) {
+	action.Setup(action.loadParams)
 	action.Do(
-		action.loadParams,
 		action.loadRecords,
 		func() {
 			stream.SetLimit(int(action.PageQuery.Limit))
@@ -53,6 +56,28 @@ func (action *OffersByAccountAction) SSE(stream sse.Stream) {
 func (action *OffersByAccountAction) loadParams() {
 	action.PageQuery = action.GetPageQuery()
 	action.Address = action.GetString("account_id")
+	action.loadNowCursor()
+}
+
+// loadNowCursor resolves the "now" cursor to the latest offer id, since the
+// history id GetCursor resolves it to is not comparable to offer ids.
+func (action *OffersByAccountAction) loadNowCursor() {
+	if action.Err != nil {
+		return
+	}
+
+	if action.GetString(actions.ParamCursor) != "now" || action.R.Header.Get("Last-Event-ID") != "" {
+		return
+	}
+
+	var id int64
+	action.Err = action.CoreQ().LatestOfferID(&id)
+
+	// a descending page from now includes the latest offer
+	if action.PageQuery.Order == db2.OrderDescending {
+		id++
+	}
+	action.PageQuery.Cursor = strconv.FormatInt(id, 10)
 }
 
 func (action *OffersByAccountAction) loadRecords() {
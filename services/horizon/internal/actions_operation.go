@@ -6,13 +6,14 @@ import (
 
 	"github.com/stellar/go/services/horizon/internal/db2"
 	"github.com/stellar/go/services/horizon/internal/db2/history"
-	"github.com/stellar/go/services/horizon/internal/ledger"
 	"github.com/stellar/go/services/horizon/internal/render/hal"
 	"github.com/stellar/go/services/horizon/internal/render/problem"
 	"github.com/stellar/go/services/horizon/internal/render/sse"
 	"github.com/stellar/go/services/horizon/internal/resource"
 	"github.com/stellar/go/services/horizon/internal/toid"
 	halRender "github.com/stellar/go/support/render/hal"
+	"github.com/stellar/go/support/time"
+	"github.com/stellar/go/xdr"
 )
 
 // This file contains the actions:
@@ -25,13 +26,18 @@ import (
 // transaction.
 type OperationIndexAction struct {
 	Action
-	LedgerFilter      int32
-	AccountFilter     string
-	TransactionFilter string
-	PagingParams      db2.PageQuery
-	Records           []history.Operation
-	Ledgers           history.LedgerCache
-	Page              hal.Page
+	LedgerFilter        int32
+	AccountFilter       string
+	TransactionFilter   string
+	TypeFilter          []xdr.OperationType
+	StartTimeFilter     time.Millis
+	EndTimeFilter       time.Millis
+	IncludeTransactions bool
+	PagingParams        db2.PageQuery
+	Records             []history.Operation
+	Ledgers             history.LedgerCache
+	Transactions        history.TransactionCache
+	Page                hal.Page
 }
 
 // JSON is a method for actions.JSON
@@ -42,6 +48,7 @@ func (action *OperationIndexAction) JSON() {
 		action.ValidateCursorWithinHistory,
 		action.loadRecords,
 		action.loadLedgers,
+		action.loadTransactions,
 		action.loadPage)
 	action.Do(func() {
 		halRender.Render(action.W, action.Page)
@@ -58,6 +65,7 @@ func (action *OperationIndexAction) SSE(stream sse.Stream) {
 	action.Do(
 		action.loadRecords,
 		action.loadLedgers,
+		action.loadTransactions,
 		func() {
 			stream.SetLimit(int(action.PagingParams.Limit))
 			records := action.Records[stream.SentCount():]
@@ -70,8 +78,7 @@ func (action *OperationIndexAction) SSE(stream sse.Stream) {
 					return
 				}
 
-				res, err := resource.NewOperation(action.Ctx, record, ledger)
-
+				res, err := action.newResource(record, ledger)
 				if err != nil {
 					stream.Err(err)
 					return
@@ -91,6 +98,10 @@ func (action *OperationIndexAction) loadParams() {
 	action.AccountFilter = action.GetString("account_id")
 	action.LedgerFilter = action.GetInt32("ledger_id")
 	action.TransactionFilter = action.GetString("tx_id")
+	action.TypeFilter = action.GetOperationTypes()
+	action.StartTimeFilter = action.GetTimeMillis("start_time")
+	action.EndTimeFilter = action.GetTimeMillis("end_time")
+	action.IncludeTransactions = action.GetJoinTransactions()
 	action.PagingParams = action.GetPageQuery()
 }
 
@@ -107,6 +118,11 @@ func (action *OperationIndexAction) loadRecords() {
 		ops.ForTransaction(action.TransactionFilter)
 	}
 
+	if len(action.TypeFilter) > 0 {
+		ops.OfTypes(action.TypeFilter...)
+	}
+
+	ops.ForTimeRange(action.StartTimeFilter, action.EndTimeFilter)
 	action.Err = ops.Page(action.PagingParams).Select(&action.Records)
 }
 
@@ -119,6 +135,45 @@ func (action *OperationIndexAction) loadLedgers() {
 	action.Err = action.Ledgers.Load(action.HistoryQ())
 }
 
+// loadTransactions populates the transaction cache for this action, when the
+// transactions are embedded in the records
+func (action *OperationIndexAction) loadTransactions() {
+	if !action.IncludeTransactions {
+		return
+	}
+
+	for _, op := range action.Records {
+		action.Transactions.Queue(op.TransactionID)
+	}
+
+	action.Err = action.Transactions.Load(action.HistoryQ())
+}
+
+// newResource creates the resource of record, embedding its transaction
+// when requested
+func (action *OperationIndexAction) newResource(
+	record history.Operation,
+	ledger history.Ledger,
+) (hal.Pageable, error) {
+	if !action.IncludeTransactions {
+		return resource.NewOperation(action.Ctx, record, ledger)
+	}
+
+	row, found := action.Transactions.Records[record.TransactionID]
+	if !found {
+		msg := fmt.Sprintf("could not find transaction data for id %d", record.TransactionID)
+		return nil, errors.New(msg)
+	}
+
+	var tx resource.Transaction
+	err := tx.Populate(action.Ctx, row)
+	if err != nil {
+		return nil, err
+	}
+
+	return resource.NewOperationWithTransaction(action.Ctx, record, ledger, tx)
+}
+
 func (action *OperationIndexAction) loadPage() {
 	for _, record := range action.Records {
 
@@ -130,7 +185,7 @@ func (action *OperationIndexAction) loadPage() {
 		}
 
 		var res hal.Pageable
-		res, action.Err = resource.NewOperation(action.Ctx, record, ledger)
+		res, action.Err = action.newResource(record, ledger)
 		if action.Err != nil {
 			return
 		}
@@ -147,26 +202,85 @@ func (action *OperationIndexAction) loadPage() {
 // OperationShowAction renders a ledger found by its sequence number.
 type OperationShowAction struct {
 	Action
-	ID       int64
-	Record   history.Operation
-	Ledger   history.Ledger
-	Resource interface{}
+	ID                int64
+	EmbedEffects      bool
+	Record            history.Operation
+	Ledger            history.Ledger
+	Resource          interface{}
+	EffectRecords     []history.Effect
+	EmbeddedResources []hal.Pageable
 }
 
 func (action *OperationShowAction) loadParams() {
 	action.ID = action.GetInt64("id")
+	action.EmbedEffects = action.GetEmbed("effects")
 }
 
 func (action *OperationShowAction) loadRecord() {
-	action.Err = action.HistoryQ().OperationByID(&action.Record, action.ID)
+	action.Err = action.App.historyCache.
+		OperationByID(action.HistoryQ(), &action.Record, action.ID)
 }
 
 func (action *OperationShowAction) loadLedger() {
-	action.Err = action.HistoryQ().LedgerBySequence(&action.Ledger, action.Record.LedgerSequence())
+	action.Err = action.App.historyCache.
+		LedgerBySequence(action.HistoryQ(), &action.Ledger, action.Record.LedgerSequence())
+}
+
+// loadEffects loads all the effects of the operation, page by page, when they
+// are embedded in its resource.
+func (action *OperationShowAction) loadEffects() {
+	if !action.EmbedEffects {
+		return
+	}
+
+	pq := db2.PageQuery{Order: db2.OrderAscending, Limit: action.App.MaxPageSize()}
+	for {
+		var records []history.Effect
+		action.Err = action.HistoryQ().Effects().
+			ForOperation(action.ID).
+			Page(pq).
+			Select(&records)
+		if action.Err != nil {
+			return
+		}
+
+		action.EffectRecords = append(action.EffectRecords, records...)
+		if uint64(len(records)) < pq.Limit {
+			return
+		}
+		pq.Cursor = records[len(records)-1].PagingToken()
+	}
 }
 
 func (action *OperationShowAction) loadResource() {
 	action.Resource, action.Err = resource.NewOperation(action.Ctx, action.Record, action.Ledger)
+	if action.Err != nil {
+		return
+	}
+
+	for _, record := range action.EffectRecords {
+		var res hal.Pageable
+		res, action.Err = resource.NewEffect(action.Ctx, record)
+		if action.Err != nil {
+			return
+		}
+		action.EmbeddedResources = append(action.EmbeddedResources, res)
+	}
+}
+
+// render renders the resource of the operation, with its effects when they
+// are embedded.
+func (action *OperationShowAction) render() {
+	if !action.EmbedEffects {
+		halRender.Render(action.W, action.Resource)
+		return
+	}
+
+	halRender.Render(action.W, hal.Embedded{
+		Resource: action.Resource,
+		Name:     "effects",
+		Records:  action.EmbeddedResources,
+	})
 }
 
 // JSON is a method for actions.JSON
@@ -177,16 +291,18 @@ func (action *OperationShowAction) JSON() {
 		action.verifyWithinHistory,
 		action.loadRecord,
 		action.loadLedger,
+		action.loadEffects,
 		action.loadResource,
 	)
 	action.Do(func() {
-		halRender.Render(action.W, action.Resource)
+		hal.Immutable(action.W, action.Ledger.ClosedAt)
+		action.render()
 	})
 }
 
 func (action *OperationShowAction) verifyWithinHistory() {
 	parsed := toid.Parse(action.ID)
-	if parsed.LedgerSequence < ledger.CurrentState().HistoryElder {
+	if parsed.LedgerSequence < action.App.ledgerState.CurrentState().HistoryElder {
 		action.Err = &problem.BeforeHistory
 	}
 }
@@ -2,10 +2,12 @@ package horizon
 
 import (
 	"encoding/json"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/stellar/go/services/horizon/internal/db2/history"
+	"github.com/stellar/go/services/horizon/internal/resource"
 	"github.com/stellar/go/services/horizon/internal/resource/operations"
 	"github.com/stellar/go/services/horizon/internal/test"
 )
@@ -74,6 +76,67 @@ func TestOperationActions_Index(t *testing.T) {
 	ht.Assert.Equal(404, w.Code)
 }
 
+func TestOperationActions_IndexOperationType(t *testing.T) {
+	ht := StartHTTPTest(t, "base")
+	defer ht.Finish()
+
+	w := ht.Get("/operations?operation_type=payment")
+	if ht.Assert.Equal(200, w.Code) {
+		ht.Assert.PageOf(1, w.Body)
+	}
+
+	w = ht.Get("/operations?operation_type=create_account")
+	if ht.Assert.Equal(200, w.Code) {
+		ht.Assert.PageOf(3, w.Body)
+	}
+
+	w = ht.Get("/operations?operation_type=payment,create_account")
+	if ht.Assert.Equal(200, w.Code) {
+		ht.Assert.PageOf(4, w.Body)
+	}
+
+	w = ht.Get("/operations?operation_type=manage_offer")
+	if ht.Assert.Equal(200, w.Code) {
+		ht.Assert.PageOf(0, w.Body)
+	}
+
+	// combined with other filters
+	w = ht.Get("/accounts/GCXKG6RN4ONIEPCMNFB732A436Z5PNDSRLGWK7GBLCMQLIFO4S7EYWVU/operations?operation_type=payment")
+	if ht.Assert.Equal(200, w.Code) {
+		ht.Assert.PageOf(1, w.Body)
+	}
+
+	w = ht.Get("/operations?operation_type=not_real")
+	ht.Assert.Equal(400, w.Code)
+}
+
+func TestOperationActions_IndexTimeRange(t *testing.T) {
+	ht := StartHTTPTest(t, "base")
+	defer ht.Finish()
+
+	// ledger 2 closed at 1518565410000, ledger 3 at 1518565411000
+	w := ht.Get("/operations?start_time=1518565411000")
+	if ht.Assert.Equal(200, w.Code) {
+		ht.Assert.PageOf(1, w.Body)
+	}
+
+	w = ht.Get("/operations?end_time=1518565411000")
+	if ht.Assert.Equal(200, w.Code) {
+		ht.Assert.PageOf(3, w.Body)
+	}
+
+	// combined with other filters
+	w = ht.Get("/accounts/GCXKG6RN4ONIEPCMNFB732A436Z5PNDSRLGWK7GBLCMQLIFO4S7EYWVU/operations?start_time=1518565411000")
+	if ht.Assert.Equal(200, w.Code) {
+		ht.Assert.PageOf(1, w.Body)
+	}
+
+	w = ht.Get("/operations?end_time=1518565410000")
+	if ht.Assert.Equal(200, w.Code) {
+		ht.Assert.PageOf(0, w.Body)
+	}
+}
+
 func TestOperationActions_Show(t *testing.T) {
 	ht := StartHTTPTest(t, "base")
 	defer ht.Finish()
@@ -136,3 +199,107 @@ func TestOperation_CreatedAt(t *testing.T) {
 
 	ht.Assert.WithinDuration(l.ClosedAt, records[0].LedgerCloseTime, 1*time.Second)
 }
+
+func TestOperationActions_JoinTransactions(t *testing.T) {
+	ht := StartHTTPTest(t, "base")
+	defer ht.Finish()
+
+	w := ht.Get("/ledgers/2/operations?join=transactions")
+	if ht.Assert.Equal(200, w.Code) {
+		var records []struct {
+			TransactionHash string               `json:"transaction_hash"`
+			Transaction     resource.Transaction `json:"transaction"`
+		}
+		ht.UnmarshalPage(w.Body, &records)
+		if ht.Assert.Len(records, 3) {
+			for _, record := range records {
+				ht.Assert.Equal(record.TransactionHash, record.Transaction.Hash)
+				ht.Assert.Equal(int32(2), record.Transaction.Ledger)
+			}
+		}
+	}
+
+	// transactions are only embedded when requested
+	w = ht.Get("/ledgers/2/operations")
+	if ht.Assert.Equal(200, w.Code) {
+		var records []map[string]interface{}
+		ht.UnmarshalPage(w.Body, &records)
+		for _, record := range records {
+			ht.Assert.NotContains(record, "transaction")
+		}
+	}
+
+	// unsupported join
+	w = ht.Get("/operations?join=effects")
+	ht.Assert.Equal(400, w.Code)
+}
+
+func TestOperationActions_EmbedEffects(t *testing.T) {
+	ht := StartHTTPTest(t, "base")
+	defer ht.Finish()
+
+	w := ht.Get("/operations/8589938689?embed=effects")
+	if ht.Assert.Equal(200, w.Code) {
+		var result struct {
+			operations.Base
+			Embedded struct {
+				Effects []struct {
+					PT string `json:"paging_token"`
+				} `json:"effects"`
+			} `json:"_embedded"`
+		}
+		err := json.Unmarshal(w.Body.Bytes(), &result)
+		ht.Require.NoError(err, "failed to parse body")
+		ht.Assert.Equal("8589938689", result.PT)
+		if ht.Assert.NotEmpty(result.Embedded.Effects) {
+			for _, effect := range result.Embedded.Effects {
+				ht.Assert.True(strings.HasPrefix(effect.PT, "8589938689-"), effect.PT)
+			}
+		}
+	}
+
+	// effects are only embedded when requested
+	w = ht.Get("/operations/8589938689")
+	if ht.Assert.Equal(200, w.Code) {
+		var result map[string]interface{}
+		err := json.Unmarshal(w.Body.Bytes(), &result)
+		ht.Require.NoError(err, "failed to parse body")
+		ht.Assert.NotContains(result, "_embedded")
+	}
+
+	// unsupported embed
+	w = ht.Get("/operations/8589938689?embed=transaction")
+	ht.Assert.Equal(400, w.Code)
+}
+
+func TestOperationActions_EmbedEffectsPages(t *testing.T) {
+	ht := StartHTTPTest(t, "base")
+	defer ht.Finish()
+
+	embeddedEffects := func() []string {
+		w := ht.Get("/operations/8589938689?embed=effects")
+		ht.Require.Equal(200, w.Code)
+
+		var result struct {
+			Embedded struct {
+				Effects []struct {
+					PT string `json:"paging_token"`
+				} `json:"effects"`
+			} `json:"_embedded"`
+		}
+		ht.Require.NoError(json.Unmarshal(w.Body.Bytes(), &result))
+
+		var pts []string
+		for _, effect := range result.Embedded.Effects {
+			pts = append(pts, effect.PT)
+		}
+		return pts
+	}
+
+	expected := embeddedEffects()
+	ht.Require.True(len(expected) > 1)
+
+	// every effect is embedded, even beyond the max page size
+	ht.App.config.MaxPageSize = 1
+	ht.Assert.Equal(expected, embeddedEffects())
+}
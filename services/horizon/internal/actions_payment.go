@@ -16,13 +16,15 @@ import (
 // filters
 type PaymentsIndexAction struct {
 	Action
-	LedgerFilter      int32
-	AccountFilter     string
-	TransactionFilter string
-	PagingParams      db2.PageQuery
-	Records           []history.Operation
-	Ledgers           history.LedgerCache
-	Page              hal.Page
+	LedgerFilter        int32
+	AccountFilter       string
+	TransactionFilter   string
+	IncludeTransactions bool
+	PagingParams        db2.PageQuery
+	Records             []history.Operation
+	Ledgers             history.LedgerCache
+	Transactions        history.TransactionCache
+	Page                hal.Page
 }
 
 // JSON is a method for actions.JSON
@@ -33,6 +35,7 @@ func (action *PaymentsIndexAction) JSON() {
 		action.ValidateCursorWithinHistory,
 		action.loadRecords,
 		action.loadLedgers,
+		action.loadTransactions,
 		action.loadPage,
 	)
 	action.Do(func() {
@@ -50,6 +53,7 @@ func (action *PaymentsIndexAction) SSE(stream sse.Stream) {
 	action.Do(
 		action.loadRecords,
 		action.loadLedgers,
+		action.loadTransactions,
 		func() {
 			stream.SetLimit(int(action.PagingParams.Limit))
 			records := action.Records[stream.SentCount():]
@@ -62,8 +66,7 @@ func (action *PaymentsIndexAction) SSE(stream sse.Stream) {
 					return
 				}
 
-				res, err := resource.NewOperation(action.Ctx, record, ledger)
-
+				res, err := action.newResource(record, ledger)
 				if err != nil {
 					stream.Err(err)
 					return
@@ -82,6 +85,7 @@ func (action *PaymentsIndexAction) loadParams() {
 	action.AccountFilter = action.GetString("account_id")
 	action.LedgerFilter = action.GetInt32("ledger_id")
 	action.TransactionFilter = action.GetString("tx_id")
+	action.IncludeTransactions = action.GetJoinTransactions()
 	action.PagingParams = action.GetPageQuery()
 }
 
@@ -110,6 +114,45 @@ func (action *PaymentsIndexAction) loadLedgers() {
 	action.Err = action.Ledgers.Load(action.HistoryQ())
 }
 
+// loadTransactions populates the transaction cache for this action, when the
+// transactions are embedded in the records
+func (action *PaymentsIndexAction) loadTransactions() {
+	if !action.IncludeTransactions {
+		return
+	}
+
+	for _, op := range action.Records {
+		action.Transactions.Queue(op.TransactionID)
+	}
+
+	action.Err = action.Transactions.Load(action.HistoryQ())
+}
+
+// newResource creates the resource of record, embedding its transaction
+// when requested
+func (action *PaymentsIndexAction) newResource(
+	record history.Operation,
+	ledger history.Ledger,
+) (hal.Pageable, error) {
+	if !action.IncludeTransactions {
+		return resource.NewOperation(action.Ctx, record, ledger)
+	}
+
+	row, found := action.Transactions.Records[record.TransactionID]
+	if !found {
+		msg := fmt.Sprintf("could not find transaction data for id %d", record.TransactionID)
+		return nil, errors.New(msg)
+	}
+
+	var tx resource.Transaction
+	err := tx.Populate(action.Ctx, row)
+	if err != nil {
+		return nil, err
+	}
+
+	return resource.NewOperationWithTransaction(action.Ctx, record, ledger, tx)
+}
+
 func (action *PaymentsIndexAction) loadPage() {
 	for _, record := range action.Records {
 		var res hal.Pageable
@@ -121,7 +164,7 @@ func (action *PaymentsIndexAction) loadPage() {
 			return
 		}
 
-		res, action.Err = resource.NewOperation(action.Ctx, record, ledger)
+		res, action.Err = action.newResource(record, ledger)
 		if action.Err != nil {
 			return
 		}
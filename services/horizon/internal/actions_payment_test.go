@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/stellar/go/services/horizon/internal/db2/history"
+	"github.com/stellar/go/services/horizon/internal/resource"
 	"github.com/stellar/go/services/horizon/internal/resource/operations"
 )
 
@@ -67,3 +68,26 @@ func TestPayment_CreatedAt(t *testing.T) {
 
 	ht.Assert.WithinDuration(l.ClosedAt, records[0].LedgerCloseTime, 1*time.Second)
 }
+
+func TestPaymentActions_JoinTransactions(t *testing.T) {
+	ht := StartHTTPTest(t, "base")
+	defer ht.Finish()
+
+	w := ht.Get("/ledgers/3/payments?join=transactions")
+	if ht.Assert.Equal(200, w.Code) {
+		var records []struct {
+			TransactionHash string               `json:"transaction_hash"`
+			Transaction     resource.Transaction `json:"transaction"`
+		}
+		ht.UnmarshalPage(w.Body, &records)
+		if ht.Assert.Len(records, 1) {
+			ht.Assert.Equal("cebb875a00ff6e1383aef0fd251a76f22c1f9ab2a2dffcb077855736ade2659a", records[0].Transaction.Hash)
+			ht.Assert.Equal(records[0].TransactionHash, records[0].Transaction.Hash)
+			ht.Assert.Equal(int32(100), records[0].Transaction.FeePaid)
+		}
+	}
+
+	// unsupported join
+	w = ht.Get("/payments?join=ledgers")
+	ht.Assert.Equal(400, w.Code)
+}
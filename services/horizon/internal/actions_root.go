@@ -1,7 +1,6 @@
 package horizon
 
 import (
-	"github.com/stellar/go/services/horizon/internal/ledger"
 	"github.com/stellar/go/services/horizon/internal/resource"
 	"github.com/stellar/go/support/render/hal"
 )
@@ -19,7 +18,7 @@ func (action *RootAction) JSON() {
 	var res resource.Root
 	res.Populate(
 		action.Ctx,
-		ledger.CurrentState(),
+		action.App.ledgerState.CurrentState(),
 		action.App.horizonVersion,
 		action.App.coreVersion,
 		action.App.networkPassphrase,
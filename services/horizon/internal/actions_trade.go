@@ -2,12 +2,12 @@ package horizon
 
 import (
 	"errors"
-	"fmt"
 	"strconv"
 
 	"github.com/stellar/go/services/horizon/internal/db2"
 	"github.com/stellar/go/services/horizon/internal/db2/history"
 	"github.com/stellar/go/services/horizon/internal/render/hal"
+	"github.com/stellar/go/services/horizon/internal/render/sse"
 	"github.com/stellar/go/services/horizon/internal/resource"
 	halRender "github.com/stellar/go/support/render/hal"
 	"github.com/stellar/go/support/time"
@@ -21,6 +21,7 @@ type TradeIndexAction struct {
 	CounterAssetFilter    xdr.Asset
 	HasCounterAssetFilter bool
 	OfferFilter           int64
+	AccountFilter         string
 	PagingParams          db2.PageQuery
 	Records               []history.Trade
 	Page                  hal.Page
@@ -39,12 +40,42 @@ func (action *TradeIndexAction) JSON() {
 	)
 }
 
+// SSE is a method for actions.SSE
+func (action *TradeIndexAction) SSE(stream sse.Stream) {
+	action.Setup(
+		action.EnsureHistoryFreshness,
+		action.loadParams,
+	)
+	action.Do(
+		action.loadRecords,
+		func() {
+			stream.SetLimit(int(action.PagingParams.Limit))
+			records := action.Records[stream.SentCount():]
+
+			for _, record := range records {
+				var res resource.Trade
+				err := res.Populate(action.Ctx, record)
+				if err != nil {
+					stream.Err(err)
+					return
+				}
+
+				stream.Send(sse.Event{
+					ID:   res.PagingToken(),
+					Data: res,
+				})
+			}
+		},
+	)
+}
+
 // loadParams sets action.Query from the request params
 func (action *TradeIndexAction) loadParams() {
 	action.PagingParams = action.GetPageQuery()
 	action.BaseAssetFilter, action.HasBaseAssetFilter = action.MaybeGetAsset("base_")
 	action.CounterAssetFilter, action.HasCounterAssetFilter = action.MaybeGetAsset("counter_")
 	action.OfferFilter = action.GetInt64("offer_id")
+	action.AccountFilter = action.GetString("account_id")
 }
 
 // loadRecords populates action.Records
@@ -77,6 +108,10 @@ func (action *TradeIndexAction) loadRecords() {
 		trades = trades.ForOffer(action.OfferFilter)
 	}
 
+	if action.AccountFilter != "" {
+		trades = trades.ForAccount(action.AccountFilter)
+	}
+
 	action.Err = trades.Page(action.PagingParams).Select(&action.Records)
 }
 
@@ -132,6 +167,13 @@ func (action *TradeAggregateIndexAction) loadParams() {
 	action.StartTimeFilter = action.GetTimeMillis("start_time")
 	action.EndTimeFilter = action.GetTimeMillis("end_time")
 	action.ResolutionFilter = action.GetInt64("resolution")
+	if action.Err != nil {
+		return
+	}
+
+	if !history.IsAllowedResolution(action.ResolutionFilter) {
+		action.SetInvalidField("resolution", errors.New("resolution is not allowed"))
+	}
 }
 
 // loadRecords populates action.Records
@@ -214,78 +256,3 @@ func (action *TradeAggregateIndexAction) loadPage() {
 		}
 	}
 }
-
-// TradeEffectIndexAction
-type TradeEffectIndexAction struct {
-	Action
-	AccountFilter string
-	PagingParams  db2.PageQuery
-	Records       []history.Effect
-	Ledgers       history.LedgerCache
-	Page          hal.Page
-}
-
-// JSON is a method for actions.JSON
-func (action *TradeEffectIndexAction) JSON() {
-	action.Do(
-		action.EnsureHistoryFreshness,
-		action.loadParams,
-		action.loadRecords,
-		action.loadLedgers,
-		action.loadPage,
-		func() {
-			halRender.Render(action.W, action.Page)
-		},
-	)
-}
-
-// loadLedgers populates the ledger cache for this action
-func (action *TradeEffectIndexAction) loadLedgers() {
-	if action.Err != nil {
-		return
-	}
-
-	for _, trade := range action.Records {
-		action.Ledgers.Queue(trade.LedgerSequence())
-	}
-
-	action.Err = action.Ledgers.Load(action.HistoryQ())
-}
-
-func (action *TradeEffectIndexAction) loadParams() {
-	action.AccountFilter = action.GetString("account_id")
-	action.PagingParams = action.GetPageQuery()
-}
-
-func (action *TradeEffectIndexAction) loadRecords() {
-	trades := action.HistoryQ().Effects().OfType(history.EffectTrade).ForAccount(action.AccountFilter)
-
-	action.Err = trades.Page(action.PagingParams).Select(&action.Records)
-}
-
-// loadPage populates action.Page
-func (action *TradeEffectIndexAction) loadPage() {
-	for _, record := range action.Records {
-		var res resource.TradeEffect
-
-		ledger, found := action.Ledgers.Records[record.LedgerSequence()]
-		if !found {
-			msg := fmt.Sprintf("could not find ledger data for sequence %d", record.LedgerSequence())
-			action.Err = errors.New(msg)
-			return
-		}
-
-		action.Err = res.PopulateFromEffect(action.Ctx, record, ledger)
-		if action.Err != nil {
-			return
-		}
-
-		action.Page.Add(res)
-	}
-
-	action.Page.FullURL = action.FullURL()
-	action.Page.Limit = action.PagingParams.Limit
-	action.Page.Cursor = action.PagingParams.Cursor
-	action.Page.Order = action.PagingParams.Order
-	action.Page.PopulateLinks()
-}
@@ -1,6 +1,7 @@
 package horizon
 
 import (
+	"net/http"
 	"net/url"
 	"strconv"
 	"strings"
@@ -87,6 +88,12 @@ func TestTradeActions_Index(t *testing.T) {
 	w = ht.Get("/accounts/GA5WBPYA5Y4WAEHXWR2UKO2UO4BUGHUQ74EUPKON2QHV4WRHOIRNKKH2/trades")
 	if ht.Assert.Equal(200, w.Code) {
 		ht.Assert.PageOf(1, w.Body)
+
+		records := []map[string]interface{}{}
+		ht.UnmarshalPage(w.Body, &records)
+
+		ht.Assert.Contains(records[0], "base_account")
+		ht.Assert.Contains(records[0], "counter_account")
 	}
 
 	w = ht.Get("/accounts/GCXKG6RN4ONIEPCMNFB732A436Z5PNDSRLGWK7GBLCMQLIFO4S7EYWVU/trades")
@@ -95,6 +102,30 @@ func TestTradeActions_Index(t *testing.T) {
 	}
 }
 
+func TestTradeActions_SSE(t *testing.T) {
+	ht := StartHTTPTest(t, "trades")
+	defer ht.Finish()
+
+	stream := func(r *http.Request) {
+		r.Header.Set("Accept", "text/event-stream")
+	}
+
+	// the stream ends once limit trades are sent
+	w := ht.Get("/trades?limit=1", stream)
+	if ht.Assert.Equal(200, w.Code) {
+		body := w.Body.String()
+		ht.Assert.Contains(body, "event: open\n")
+		ht.Assert.Equal(1, strings.Count(body, "id: "))
+	}
+
+	w = ht.Get("/accounts/GA5WBPYA5Y4WAEHXWR2UKO2UO4BUGHUQ74EUPKON2QHV4WRHOIRNKKH2/trades?limit=1", stream)
+	if ht.Assert.Equal(200, w.Code) {
+		body := w.Body.String()
+		ht.Assert.Contains(body, "event: open\n")
+		ht.Assert.Equal(1, strings.Count(body, "id: "))
+	}
+}
+
 // setAssetQuery adds an asset filter with a given prefix to a query
 func setAssetQuery(q *url.Values, prefix string, asset xdr.Asset) {
 	var assetType, assetCode, assetFilter string
@@ -157,7 +188,7 @@ func TestTradeActions_Aggregation(t *testing.T) {
 	//test illegal resolution
 	q.Add("resolution", strconv.FormatInt(hour/2, 10))
 	w := ht.GetWithParams(aggregationPath, q)
-	ht.Assert.Equal(500, w.Code)
+	ht.Assert.Equal(400, w.Code)
 
 	//test one bucket for all trades
 	q.Set("resolution", strconv.FormatInt(hour, 10))
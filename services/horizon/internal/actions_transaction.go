@@ -1,10 +1,14 @@
 package horizon
 
 import (
+	"bytes"
+	"encoding/json"
+	"mime"
 	"net/http"
 
 	"github.com/stellar/go/services/horizon/internal/db2"
 	"github.com/stellar/go/services/horizon/internal/db2/history"
+	"github.com/stellar/go/services/horizon/internal/render"
 	"github.com/stellar/go/services/horizon/internal/render/hal"
 	hProblem "github.com/stellar/go/services/horizon/internal/render/problem"
 	"github.com/stellar/go/services/horizon/internal/render/sse"
@@ -12,22 +16,27 @@ import (
 	"github.com/stellar/go/services/horizon/internal/txsub"
 	halRender "github.com/stellar/go/support/render/hal"
 	"github.com/stellar/go/support/render/problem"
+	"github.com/stellar/go/support/time"
 )
 
 // This file contains the actions:
 //
 // TransactionIndexAction: pages of transactions
 // TransactionShowAction: single transaction by sequence, by hash or id
+// TransactionStatusAction: status of a submitted transaction
+// TransactionCreateAction: submits a transaction
 
 // TransactionIndexAction renders a page of ledger resources, identified by
 // a normal page query.
 type TransactionIndexAction struct {
 	Action
-	LedgerFilter  int32
-	AccountFilter string
-	PagingParams  db2.PageQuery
-	Records       []history.Transaction
-	Page          hal.Page
+	LedgerFilter    int32
+	AccountFilter   string
+	StartTimeFilter time.Millis
+	EndTimeFilter   time.Millis
+	PagingParams    db2.PageQuery
+	Records         []history.Transaction
+	Page            hal.Page
 }
 
 // JSON is a method for actions.JSON
@@ -66,10 +75,35 @@ func (action *TransactionIndexAction) SSE(stream sse.Stream) {
 	)
 }
 
+// XDR is a method for actions.XDR.  It renders the envelope and the result
+// of each transaction of the page as a stream of xdr objects.
+func (action *TransactionIndexAction) XDR() {
+	action.Do(
+		action.EnsureHistoryFreshness,
+		action.loadParams,
+		action.ValidateCursorWithinHistory,
+		action.loadRecords,
+		func() {
+			var buf bytes.Buffer
+			for _, record := range action.Records {
+				action.Err = render.WriteXDRStream(&buf, record.TxEnvelope, record.TxResult)
+				if action.Err != nil {
+					return
+				}
+			}
+
+			action.W.Header().Set("Content-Type", render.MimeXDR)
+			action.W.Write(buf.Bytes())
+		},
+	)
+}
+
 func (action *TransactionIndexAction) loadParams() {
 	action.ValidateCursorAsDefault()
 	action.AccountFilter = action.GetString("account_id")
 	action.LedgerFilter = action.GetInt32("ledger_id")
+	action.StartTimeFilter = action.GetTimeMillis("start_time")
+	action.EndTimeFilter = action.GetTimeMillis("end_time")
 	action.PagingParams = action.GetPageQuery()
 }
 
@@ -84,6 +118,7 @@ func (action *TransactionIndexAction) loadRecords() {
 		txs.ForLedger(action.LedgerFilter)
 	}
 
+	txs.ForTimeRange(action.StartTimeFilter, action.EndTimeFilter)
 	action.Err = txs.Page(action.PagingParams).Select(&action.Records)
 }
 
@@ -104,21 +139,80 @@ func (action *TransactionIndexAction) loadPage() {
 // TransactionShowAction renders a ledger found by its sequence number.
 type TransactionShowAction struct {
 	Action
-	Hash     string
-	Record   history.Transaction
-	Resource resource.Transaction
+	Hash              string
+	EmbedOperations   bool
+	Record            history.Transaction
+	Resource          resource.Transaction
+	OperationRecords  []history.Operation
+	OperationLedger   history.Ledger
+	EmbeddedResources []hal.Pageable
 }
 
 func (action *TransactionShowAction) loadParams() {
 	action.Hash = action.GetString("id")
+	action.EmbedOperations = action.GetEmbed("operations")
 }
 
 func (action *TransactionShowAction) loadRecord() {
-	action.Err = action.HistoryQ().TransactionByHash(&action.Record, action.Hash)
+	action.Err = action.App.historyCache.
+		TransactionByHash(action.HistoryQ(), &action.Record, action.Hash)
+}
+
+// loadOperations loads all the operations of the transaction, page by page,
+// when they are embedded in its resource.
+func (action *TransactionShowAction) loadOperations() {
+	if !action.EmbedOperations {
+		return
+	}
+
+	pq := db2.PageQuery{Order: db2.OrderAscending, Limit: action.App.MaxPageSize()}
+	for {
+		var records []history.Operation
+		action.Err = action.HistoryQ().Operations().
+			ForTransaction(action.Record.TransactionHash).
+			Page(pq).
+			Select(&records)
+		if action.Err != nil {
+			return
+		}
+
+		action.OperationRecords = append(action.OperationRecords, records...)
+		if uint64(len(records)) < pq.Limit {
+			break
+		}
+		pq.Cursor = records[len(records)-1].PagingToken()
+	}
+
+	action.Err = action.App.historyCache.
+		LedgerBySequence(action.HistoryQ(), &action.OperationLedger, action.Record.LedgerSequence)
 }
 
 func (action *TransactionShowAction) loadResource() {
 	action.Resource.Populate(action.Ctx, action.Record)
+
+	for _, record := range action.OperationRecords {
+		var res hal.Pageable
+		res, action.Err = resource.NewOperation(action.Ctx, record, action.OperationLedger)
+		if action.Err != nil {
+			return
+		}
+		action.EmbeddedResources = append(action.EmbeddedResources, res)
+	}
+}
+
+// render renders the resource of the transaction, with its operations when
+// they are embedded.
+func (action *TransactionShowAction) render() {
+	if !action.EmbedOperations {
+		halRender.Render(action.W, action.Resource)
+		return
+	}
+
+	halRender.Render(action.W, hal.Embedded{
+		Resource: action.Resource,
+		Name:     "operations",
+		Records:  action.EmbeddedResources,
+	})
 }
 
 // JSON is a method for actions.JSON
@@ -127,24 +221,89 @@ func (action *TransactionShowAction) JSON() {
 		action.EnsureHistoryFreshness,
 		action.loadParams,
 		action.loadRecord,
+		action.loadOperations,
+		action.loadResource,
+		func() {
+			hal.Immutable(action.W, action.Record.LedgerCloseTime)
+			action.render()
+		},
+	)
+}
+
+// TransactionStatusAction renders the status of a transaction submitted to
+// this horizon instance, or found in the history of the network.
+type TransactionStatusAction struct {
+	Action
+	Hash     string
+	Resource resource.TransactionStatus
+}
+
+// JSON is a method for actions.JSON
+func (action *TransactionStatusAction) JSON() {
+	action.Do(
+		action.loadParams,
 		action.loadResource,
 		func() { halRender.Render(action.W, action.Resource) },
 	)
 }
 
+func (action *TransactionStatusAction) loadParams() {
+	action.Hash = action.GetString("id")
+}
+
+// loadResource looks the transaction up in the databases first, since their
+// results are authoritative, then in the asynchronous submissions.
+func (action *TransactionStatusAction) loadResource() {
+	r := action.App.submitter.Results.ResultByHash(action.Ctx, action.Hash)
+	if _, failed := r.Err.(*txsub.FailedTransactionError); r.Err == nil || failed {
+		action.Err = action.Resource.Populate(action.Ctx, action.Hash, r, true)
+		return
+	}
+
+	if r.Err != txsub.ErrNoResults {
+		action.Err = r.Err
+		return
+	}
+
+	r, done, ok := action.App.submitter.AsyncResult(action.Hash)
+	if !ok {
+		action.Err = &problem.NotFound
+		return
+	}
+
+	action.Err = action.Resource.Populate(action.Ctx, action.Hash, r, done)
+}
+
 // TransactionCreateAction submits a transaction to the stellar-core network
-// on behalf of the requesting client.
+// on behalf of the requesting client.  In async mode, the action responds as
+// soon as the transaction is queued for submission, with a link to its status.
 type TransactionCreateAction struct {
 	Action
 	TX       string
+	Async    bool
 	Result   txsub.Result
 	Resource resource.TransactionSuccess
+	Status   resource.TransactionStatus
 }
 
 // JSON format action handler
 func (action *TransactionCreateAction) JSON() {
+	action.Do(action.loadTX)
+
+	if action.Async {
+		action.Do(
+			action.submitAsync,
+			func() {
+				// the headers must be set before the status is written
+				action.W.Header().Set("Content-Type", "application/hal+json; charset=utf-8")
+				action.W.Header().Set("Location", action.Status.Links.Self.Href)
+				action.W.WriteHeader(http.StatusAccepted)
+				halRender.Render(action.W, action.Status)
+			})
+		return
+	}
+
 	action.Do(
-		action.loadTX,
 		action.loadResult,
 		action.loadResource,
 
@@ -153,9 +312,49 @@ func (action *TransactionCreateAction) JSON() {
 		})
 }
 
+// loadTX loads the transaction to submit and the submission mode, either from
+// a form or from a JSON body such as `{"tx": "...", "async": true}`.
 func (action *TransactionCreateAction) loadTX() {
-	action.ValidateBodyType()
-	action.TX = action.GetString("tx")
+	mt, _, _ := mime.ParseMediaType(action.R.Header.Get("Content-Type"))
+	if mt != render.MimeJSON {
+		action.ValidateBodyType()
+		action.TX = action.GetString("tx")
+		action.Async = action.GetBool("async")
+		return
+	}
+
+	var body struct {
+		TX    string `json:"tx"`
+		Async bool   `json:"async"`
+	}
+	err := json.NewDecoder(action.R.Body).Decode(&body)
+	if err != nil {
+		action.Err = &problem.BadRequest
+		return
+	}
+
+	action.TX = body.TX
+	action.Async = body.Async
+}
+
+// submitAsync submits the transaction in the background.  The submission is
+// bound to the context of the app, since it outlives the request.  It is
+// audited when accepted, then again with its final result.
+func (action *TransactionCreateAction) submitAsync() {
+	app, tx, ip := action.App, action.TX, remoteAddrIP(action.R)
+	hash, err := app.submitter.SubmitAsync(app.ctx, tx, func(r txsub.Result) {
+		app.audit.Record(tx, r, ip)
+	})
+	if err != nil {
+		action.Result = txsub.Result{Err: err, EnvelopeXDR: action.TX}
+		app.audit.Record(action.TX, action.Result, ip)
+		action.loadResource()
+		return
+	}
+
+	app.audit.RecordAccepted(action.TX, hash, ip)
+
+	action.Err = action.Status.Populate(action.Ctx, hash, txsub.Result{}, false)
 }
 
 func (action *TransactionCreateAction) loadResult() {
@@ -164,8 +363,10 @@ func (action *TransactionCreateAction) loadResult() {
 	select {
 	case result := <-submission:
 		action.Result = result
+		action.App.audit.Record(action.TX, result, remoteAddrIP(action.R))
 	case <-action.Ctx.Done():
 		action.Err = &hProblem.Timeout
+		action.App.audit.Record(action.TX, txsub.Result{Err: txsub.ErrCanceled}, remoteAddrIP(action.R))
 	}
 }
 
@@ -186,6 +387,29 @@ func (action *TransactionCreateAction) loadResource() {
 	}
 
 	switch err := action.Result.Err.(type) {
+	case *txsub.RejectedTransactionError:
+		rcr := resource.TransactionResultCodes{}
+		rcr.Populate(action.Ctx, err.FailedTransactionError)
+
+		extras := map[string]interface{}{
+			"envelope_xdr": action.Result.EnvelopeXDR,
+			"result_xdr":   err.ResultXDR,
+			"result_codes": rcr,
+		}
+		for k, v := range err.Extras {
+			extras[k] = v
+		}
+
+		action.Err = &problem.P{
+			Type:   "transaction_failed",
+			Title:  "Transaction Failed",
+			Status: http.StatusBadRequest,
+			Detail: "Horizon rejected the transaction before submitting it to the " +
+				"stellar network, since it would have failed: " + err.Reason + " " +
+				"The `extras.result_codes` field on this response contains the code " +
+				"the transaction would have failed with.",
+			Extras: extras,
+		}
 	case *txsub.FailedTransactionError:
 		rcr := resource.TransactionResultCodes{}
 		rcr.Populate(action.Ctx, err)
@@ -1,13 +1,20 @@
 package horizon
 
 import (
+	"encoding/binary"
+	"encoding/csv"
 	"encoding/json"
+	"io/ioutil"
+	"net/http"
 	"net/url"
+	"strings"
 	"testing"
 
 	"github.com/stellar/go/services/horizon/internal/resource"
+	"github.com/stellar/go/services/horizon/internal/resource/operations"
 	"github.com/stellar/go/services/horizon/internal/txsub"
 	"github.com/stellar/go/services/horizon/internal/txsub/sequence"
+	"github.com/stellar/go/xdr"
 )
 
 func TestTransactionActions_Show(t *testing.T) {
@@ -32,6 +39,36 @@ func TestTransactionActions_Show(t *testing.T) {
 	ht.Assert.Equal(404, w.Code)
 }
 
+func TestTransactionActions_EmbedOperations(t *testing.T) {
+	ht := StartHTTPTest(t, "base")
+	defer ht.Finish()
+
+	w := ht.Get("/transactions/2374e99349b9ef7dba9a5db3339b78fda8f34777b1af33ba468ad5c0df946d4d?embed=operations")
+	if ht.Assert.Equal(200, w.Code) {
+		var actual struct {
+			resource.Transaction
+			Embedded struct {
+				Operations []operations.Base `json:"operations"`
+			} `json:"_embedded"`
+		}
+		err := json.Unmarshal(w.Body.Bytes(), &actual)
+		ht.Require.NoError(err)
+
+		ht.Assert.Equal(
+			"2374e99349b9ef7dba9a5db3339b78fda8f34777b1af33ba468ad5c0df946d4d",
+			actual.Hash,
+		)
+		if ht.Assert.Len(actual.Embedded.Operations, 1) {
+			ht.Assert.Equal("8589938689", actual.Embedded.Operations[0].PT)
+			ht.Assert.Equal(actual.Hash, actual.Embedded.Operations[0].TransactionHash)
+		}
+	}
+
+	// unsupported embed
+	w = ht.Get("/transactions/2374e99349b9ef7dba9a5db3339b78fda8f34777b1af33ba468ad5c0df946d4d?embed=effects")
+	ht.Assert.Equal(400, w.Code)
+}
+
 func TestTransactionActions_Index(t *testing.T) {
 	ht := StartHTTPTest(t, "base")
 	defer ht.Finish()
@@ -87,6 +124,100 @@ func TestTransactionActions_Index(t *testing.T) {
 
 }
 
+func TestTransactionActions_IndexCSV(t *testing.T) {
+	ht := StartHTTPTest(t, "base")
+	defer ht.Finish()
+
+	w := ht.Get("/transactions", func(r *http.Request) {
+		r.Header.Set("Accept", "text/csv")
+	})
+	if ht.Assert.Equal(200, w.Code) {
+		ht.Assert.Equal("text/csv; charset=utf-8", w.Header().Get("Content-Type"))
+
+		rows, err := csv.NewReader(w.Body).ReadAll()
+		ht.Require.NoError(err)
+		if ht.Assert.Len(rows, 5) {
+			ht.Assert.Contains(rows[0], "hash")
+			ht.Assert.Contains(rows[0], "paging_token")
+			for _, col := range rows[0] {
+				ht.Assert.False(strings.HasPrefix(col, "_links"), col)
+			}
+		}
+	}
+}
+
+func TestTransactionActions_IndexXDR(t *testing.T) {
+	ht := StartHTTPTest(t, "base")
+	defer ht.Finish()
+
+	w := ht.Get("/transactions", func(r *http.Request) {
+		r.Header.Set("Accept", "application/xdr")
+	})
+	if ht.Assert.Equal(200, w.Code) {
+		ht.Assert.Equal("application/xdr", w.Header().Get("Content-Type"))
+
+		// the envelope and the result of each transaction
+		var objects [][]byte
+		body := w.Body.Bytes()
+		for len(body) >= 4 {
+			mark := binary.BigEndian.Uint32(body)
+			length := int(mark &^ 0x80000000)
+			ht.Require.True(mark&0x80000000 != 0)
+			ht.Require.True(len(body) >= 4+length)
+			objects = append(objects, body[4:4+length])
+			body = body[4+length:]
+		}
+		ht.Assert.Empty(body)
+
+		if ht.Assert.Len(objects, 8) {
+			var env xdr.TransactionEnvelope
+			err := xdr.SafeUnmarshal(objects[0], &env)
+			ht.Assert.NoError(err)
+		}
+	}
+
+	// xdr is only served for transactions
+	w = ht.Get("/ledgers", func(r *http.Request) {
+		r.Header.Set("Accept", "application/xdr")
+	})
+	ht.Assert.Equal(406, w.Code)
+}
+
+func TestTransactionActions_IndexTimeRange(t *testing.T) {
+	ht := StartHTTPTest(t, "base")
+	defer ht.Finish()
+
+	// ledger 2 closed at 1518565410000, ledger 3 at 1518565411000
+	w := ht.Get("/transactions?start_time=1518565411000")
+	if ht.Assert.Equal(200, w.Code) {
+		ht.Assert.PageOf(1, w.Body)
+	}
+
+	w = ht.Get("/transactions?end_time=1518565411000")
+	if ht.Assert.Equal(200, w.Code) {
+		ht.Assert.PageOf(3, w.Body)
+	}
+
+	w = ht.Get("/transactions?start_time=1518565410000&end_time=1518565412000")
+	if ht.Assert.Equal(200, w.Code) {
+		ht.Assert.PageOf(4, w.Body)
+	}
+
+	w = ht.Get("/transactions?start_time=1518565412000")
+	if ht.Assert.Equal(200, w.Code) {
+		ht.Assert.PageOf(0, w.Body)
+	}
+
+	// combined with other filters
+	w = ht.Get("/ledgers/2/transactions?start_time=1518565411000")
+	if ht.Assert.Equal(200, w.Code) {
+		ht.Assert.PageOf(0, w.Body)
+	}
+
+	w = ht.Get("/transactions?start_time=yesterday")
+	ht.Assert.Equal(400, w.Code)
+}
+
 func TestTransactionActions_Post(t *testing.T) {
 	ht := StartHTTPTest(t, "base")
 	defer ht.Finish()
@@ -106,3 +237,105 @@ func TestTransactionActions_Post(t *testing.T) {
 	w = ht.Post("/transactions", form)
 	ht.Assert.Equal(503, w.Code)
 }
+
+func TestTransactionActions_PostJSON(t *testing.T) {
+	ht := StartHTTPTest(t, "base")
+	defer ht.Finish()
+
+	body := `{"tx": "AAAAAGL8HQvQkbK2HA3WVjRrKmjX00fG8sLI7m0ERwJW/AX3AAAAZAAAAAAAAAABAAAAAAAAAAAAAAABAAAAAAAAAAAAAAAArqN6LeOagjxMaUP96Bzfs9e0corNZXzBWJkFoK7kvkwAAAAAO5rKAAAAAAAAAAABVvwF9wAAAECDzqvkQBQoNAJifPRXDoLhvtycT3lFPCQ51gkdsFHaBNWw05S/VhW0Xgkr0CBPE4NaFV2Kmcs3ZwLmib4TRrML"}`
+	jsonBody := func(body string) func(*http.Request) {
+		return func(r *http.Request) {
+			r.Header.Set("Content-Type", "application/json")
+			r.Body = ioutil.NopCloser(strings.NewReader(body))
+		}
+	}
+
+	// existing transaction
+	w := ht.Post("/transactions", nil, jsonBody(body))
+	ht.Assert.Equal(200, w.Code)
+
+	// malformed body
+	w = ht.Post("/transactions", nil, jsonBody(`{"tx":`))
+	ht.Assert.Equal(400, w.Code)
+}
+
+func TestTransactionActions_PostAsync(t *testing.T) {
+	ht := StartHTTPTest(t, "base")
+	defer ht.Finish()
+
+	hash := "2374e99349b9ef7dba9a5db3339b78fda8f34777b1af33ba468ad5c0df946d4d"
+	form := url.Values{
+		"tx":    []string{"AAAAAGL8HQvQkbK2HA3WVjRrKmjX00fG8sLI7m0ERwJW/AX3AAAAZAAAAAAAAAABAAAAAAAAAAAAAAABAAAAAAAAAAAAAAAArqN6LeOagjxMaUP96Bzfs9e0corNZXzBWJkFoK7kvkwAAAAAO5rKAAAAAAAAAAABVvwF9wAAAECDzqvkQBQoNAJifPRXDoLhvtycT3lFPCQ51gkdsFHaBNWw05S/VhW0Xgkr0CBPE4NaFV2Kmcs3ZwLmib4TRrML"},
+		"async": []string{"true"},
+	}
+
+	w := ht.Post("/transactions", form)
+	if ht.Assert.Equal(202, w.Code) {
+		var actual resource.TransactionStatus
+		err := json.Unmarshal(w.Body.Bytes(), &actual)
+		ht.Require.NoError(err)
+		ht.Assert.Equal(hash, actual.Hash)
+		ht.Assert.Equal(resource.TransactionPending, actual.Status)
+		ht.Assert.Equal(actual.Links.Self.Href, w.Header().Get("Location"))
+		ht.Assert.Contains(actual.Links.Self.Href, "/transactions/"+hash+"/status")
+	}
+
+	// the transaction is in the history of the network
+	w = ht.Get("/transactions/" + hash + "/status")
+	if ht.Assert.Equal(200, w.Code) {
+		var actual resource.TransactionStatus
+		err := json.Unmarshal(w.Body.Bytes(), &actual)
+		ht.Require.NoError(err)
+		ht.Assert.Equal(resource.TransactionApplied, actual.Status)
+		ht.Assert.Equal(int32(2), actual.Ledger)
+	}
+
+	// malformed transaction
+	form.Set("tx", "AAAA")
+	w = ht.Post("/transactions", form)
+	ht.Assert.Equal(400, w.Code)
+
+	// unknown transaction
+	w = ht.Get("/transactions/0000000000000000000000000000000000000000000000000000000000000000/status")
+	ht.Assert.Equal(404, w.Code)
+}
+
+func TestTransactionActions_Fields(t *testing.T) {
+	ht := StartHTTPTest(t, "base")
+	defer ht.Finish()
+
+	w := ht.Get("/transactions?fields=hash,ledger")
+	if ht.Assert.Equal(200, w.Code) {
+		var page struct {
+			Links    map[string]interface{} `json:"_links"`
+			Embedded struct {
+				Records []map[string]interface{} `json:"records"`
+			} `json:"_embedded"`
+		}
+		err := json.Unmarshal(w.Body.Bytes(), &page)
+		ht.Require.NoError(err)
+		ht.Assert.Contains(page.Links, "next")
+		ht.Require.Len(page.Embedded.Records, 4)
+		for _, record := range page.Embedded.Records {
+			ht.Assert.Len(record, 2)
+			ht.Assert.Contains(record, "hash")
+			ht.Assert.Contains(record, "ledger")
+		}
+	}
+
+	w = ht.Get("/transactions/2374e99349b9ef7dba9a5db3339b78fda8f34777b1af33ba468ad5c0df946d4d?fields=hash")
+	if ht.Assert.Equal(200, w.Code) {
+		var actual map[string]interface{}
+		err := json.Unmarshal(w.Body.Bytes(), &actual)
+		ht.Require.NoError(err)
+		ht.Assert.Equal(map[string]interface{}{
+			"hash": "2374e99349b9ef7dba9a5db3339b78fda8f34777b1af33ba468ad5c0df946d4d",
+		}, actual)
+	}
+
+	// errors are not filtered
+	w = ht.Get("/transactions/not_real?fields=hash")
+	if ht.Assert.Equal(404, w.Code) {
+		ht.Assert.Contains(w.Body.String(), "not_found")
+	}
+}
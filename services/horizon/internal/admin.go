@@ -0,0 +1,260 @@
+package horizon
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	gctx "github.com/goji/context"
+	"github.com/stellar/go/services/horizon/internal/ingest"
+	"github.com/stellar/go/services/horizon/internal/log"
+	"github.com/stellar/go/services/horizon/internal/render/sse"
+	"github.com/stellar/go/support/errors"
+	"github.com/stellar/go/support/render/hal"
+	"github.com/stellar/go/support/render/problem"
+	"github.com/zenazn/goji/web"
+	"github.com/zenazn/goji/web/middleware"
+)
+
+// This file contains the admin API, served on its own port when AdminPort is
+// configured.  Every request must carry the AdminToken as a bearer token:
+//
+//   POST /ingestion/reingest?from=100&to=200  reingests a range of ledgers
+//   GET  /ingestion                           reports the state of ingestion
+//   POST /ingestion/pause                     stops ingesting new ledgers
+//   POST /ingestion/resume                    resumes ingesting new ledgers
+//   POST /cache/flush                         empties the history cache
+//   GET  /txsub                               reports the submission queue
+//   POST /streams/drain?timeout=30s           ends the open streams
+//   POST /streams/resume                      accepts streams again
+
+// DefaultDrainTimeout is how long draining waits for the streams to end when
+// the request doesn't set a timeout.
+const DefaultDrainTimeout = 30 * time.Second
+
+// adminIngestion is the state of ingestion reported by the admin API.
+type adminIngestion struct {
+	Enabled       bool  `json:"enabled"`
+	Paused        bool  `json:"paused"`
+	CoreLatest    int32 `json:"core_latest"`
+	HistoryLatest int32 `json:"history_latest"`
+	HistoryElder  int32 `json:"history_elder"`
+
+	// the backfill of the ledgers missing from the history database
+	MissingLedgers int32          `json:"missing_ledgers"`
+	Backfilling    *adminGapRange `json:"backfilling"`
+	LastGapScan    *time.Time     `json:"last_gap_scan"`
+}
+
+// adminGapRange is the range of missing ledgers being backfilled.
+type adminGapRange struct {
+	Start int32 `json:"start"`
+	End   int32 `json:"end"`
+}
+
+// adminTxSub is the state of the submission queue reported by the admin API.
+type adminTxSub struct {
+	Open          int `json:"open"`
+	Buffered      int `json:"buffered"`
+	Held          int `json:"held"`
+	Accounts      int `json:"accounts"`
+	MaxQueueDepth int `json:"max_queue_depth"`
+}
+
+// IngestionPaused reports whether the ingestion of new ledgers is paused
+// through the admin API.
+func (a *App) IngestionPaused() bool {
+	return atomic.LoadInt32(&a.ingestionPaused) == 1
+}
+
+// SetIngestionPaused pauses or resumes the ingestion of new ledgers.
+func (a *App) SetIngestionPaused(paused bool) {
+	var v int32
+	if paused {
+		v = 1
+	}
+	atomic.StoreInt32(&a.ingestionPaused, v)
+}
+
+// serveAdmin serves the admin API.
+func (a *App) serveAdmin() {
+	addr := fmt.Sprintf(":%d", a.config.AdminPort)
+	log.Infof("Starting horizon admin API on %s", addr)
+
+	err := http.ListenAndServe(addr, a.adminRouter())
+	if err != nil {
+		log.Panic(err)
+	}
+}
+
+// adminRouter returns the router of the admin API.
+func (a *App) adminRouter() *web.Mux {
+	r := web.New()
+	r.Use(middleware.EnvInit)
+	r.Use(contextMiddleware(a.ctx))
+	r.Use(a.adminAuth)
+
+	r.Get("/ingestion", a.adminIngestion)
+	r.Post("/ingestion/reingest", a.adminReingest)
+	r.Post("/ingestion/pause", func(c web.C, w http.ResponseWriter, req *http.Request) {
+		a.SetIngestionPaused(true)
+		a.adminIngestion(c, w, req)
+	})
+	r.Post("/ingestion/resume", func(c web.C, w http.ResponseWriter, req *http.Request) {
+		a.SetIngestionPaused(false)
+		a.adminIngestion(c, w, req)
+	})
+	r.Post("/cache/flush", a.adminFlushCache)
+	r.Get("/txsub", a.adminTxSub)
+	r.Post("/streams/drain", a.adminDrainStreams)
+	r.Post("/streams/resume", a.adminResumeStreams)
+
+	r.Compile()
+	return r
+}
+
+// adminAuth rejects the requests without the admin token.
+func (a *App) adminAuth(c *web.C, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if a.config.AdminToken == "" ||
+			subtle.ConstantTimeCompare([]byte(token), []byte(a.config.AdminToken)) != 1 {
+			problem.Render(gctx.FromC(*c), w, problem.P{
+				Type:   "unauthorized",
+				Title:  "Unauthorized",
+				Status: http.StatusUnauthorized,
+				Detail: "The admin API requires the admin token in the Authorization header.",
+			})
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (a *App) adminIngestion(c web.C, w http.ResponseWriter, r *http.Request) {
+	ls := a.ledgerState.CurrentState()
+	res := adminIngestion{
+		Enabled:       a.ingester != nil,
+		Paused:        a.IngestionPaused(),
+		CoreLatest:    ls.CoreLatest,
+		HistoryLatest: ls.HistoryLatest,
+		HistoryElder:  ls.HistoryElder,
+	}
+
+	if a.ingester != nil {
+		gaps := a.ingester.GapProgress()
+		res.MissingLedgers = gaps.Missing
+		if gaps.BackfillStart != 0 {
+			res.Backfilling = &adminGapRange{Start: gaps.BackfillStart, End: gaps.BackfillEnd}
+		}
+		if !gaps.LastScan.IsZero() {
+			res.LastGapScan = &gaps.LastScan
+		}
+	}
+
+	hal.Render(w, res)
+}
+
+func (a *App) adminReingest(c web.C, w http.ResponseWriter, r *http.Request) {
+	ctx := gctx.FromC(c)
+
+	if a.ingester == nil {
+		problem.Render(ctx, w, problem.P{
+			Type:   "ingestion_disabled",
+			Title:  "Ingestion Disabled",
+			Status: http.StatusConflict,
+			Detail: "This horizon server doesn't ingest ledgers.  Start it with --ingest to reingest ledgers.",
+		})
+		return
+	}
+
+	from, err := strconv.ParseInt(r.URL.Query().Get("from"), 10, 32)
+	if err != nil || from < 1 {
+		problem.Render(ctx, w, problem.MakeInvalidFieldProblem("from", errors.New("must be a ledger sequence")))
+		return
+	}
+
+	to, err := strconv.ParseInt(r.URL.Query().Get("to"), 10, 32)
+	if err != nil || to < from || int32(to) > a.ledgerState.CurrentState().CoreLatest {
+		problem.Render(ctx, w, problem.MakeInvalidFieldProblem(
+			"to",
+			errors.New("must be a ledger sequence between from and the latest ledger of stellar-core"),
+		))
+		return
+	}
+
+	n, err := a.ingester.ReingestRangeSession(int32(from), int32(to))
+	if err == ingest.ErrSessionInProgress {
+		problem.Render(ctx, w, problem.P{
+			Type:   "ingestion_in_progress",
+			Title:  "Ingestion In Progress",
+			Status: http.StatusConflict,
+			Detail: "Ledgers are being ingested.  Try reingesting again once the ingestion session in progress ends.",
+		})
+		return
+	}
+
+	// the cached history of the reingested ledgers is stale, even when the
+	// reingestion failed part way through
+	a.historyCache.Purge()
+	if err != nil {
+		problem.Render(ctx, w, err)
+		return
+	}
+
+	a.UpdateLedgerState()
+	hal.Render(w, map[string]int{"ingested": n})
+}
+
+func (a *App) adminFlushCache(c web.C, w http.ResponseWriter, r *http.Request) {
+	a.historyCache.Purge()
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (a *App) adminTxSub(c web.C, w http.ResponseWriter, r *http.Request) {
+	ctx := gctx.FromC(c)
+	stats := a.submitter.SubmissionQueue.Stats()
+	hal.Render(w, adminTxSub{
+		Open:          len(a.submitter.Pending.Pending(ctx)),
+		Buffered:      stats.Buffered,
+		Held:          stats.Held,
+		Accounts:      stats.Accounts,
+		MaxQueueDepth: stats.MaxDepth,
+	})
+}
+
+// adminDrainStreams ends the open streams and rejects new ones, waiting up to
+// the timeout of the request for the streams to end.  It reports the number
+// of streams still open.
+func (a *App) adminDrainStreams(c web.C, w http.ResponseWriter, r *http.Request) {
+	ctx := gctx.FromC(c)
+
+	timeout := DefaultDrainTimeout
+	if t := r.URL.Query().Get("timeout"); t != "" {
+		var err error
+		timeout, err = time.ParseDuration(t)
+		if err != nil {
+			problem.Render(ctx, w, problem.MakeInvalidFieldProblem("timeout", err))
+			return
+		}
+	}
+
+	sse.Drain()
+	log.Info("draining streams")
+
+	deadline := time.Now().Add(timeout)
+	for sse.OpenStreamCount() > 0 && time.Now().Before(deadline) {
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	hal.Render(w, map[string]int64{"open_streams": sse.OpenStreamCount()})
+}
+
+func (a *App) adminResumeStreams(c web.C, w http.ResponseWriter, r *http.Request) {
+	sse.Resume()
+	w.WriteHeader(http.StatusNoContent)
+}
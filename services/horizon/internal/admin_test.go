@@ -0,0 +1,163 @@
+package horizon
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stellar/go/services/horizon/internal/render/sse"
+	"github.com/stellar/go/services/horizon/internal/test"
+)
+
+func TestAdminAPI(t *testing.T) {
+	tt := test.Start(t).Scenario("base")
+	defer tt.Finish()
+
+	config := NewTestConfig()
+	config.AdminToken = "secret"
+	config.HistoryCacheSize = 10
+	app, err := NewApp(config)
+	tt.Require.NoError(err)
+	defer app.Close()
+	app.UpdateLedgerState()
+
+	rh := test.NewRequestHelper(app.adminRouter())
+	auth := func(r *http.Request) {
+		r.Header.Set("Authorization", "Bearer secret")
+	}
+
+	// requests without the token are rejected
+	w := rh.Get("/ingestion")
+	tt.Assert.Equal(401, w.Code)
+	w = rh.Get("/ingestion", func(r *http.Request) {
+		r.Header.Set("Authorization", "Bearer wrong")
+	})
+	tt.Assert.Equal(401, w.Code)
+
+	// ingestion state
+	var ingestion adminIngestion
+	w = rh.Get("/ingestion", auth)
+	if tt.Assert.Equal(200, w.Code) {
+		tt.Require.NoError(json.Unmarshal(w.Body.Bytes(), &ingestion))
+		tt.Assert.False(ingestion.Enabled)
+		tt.Assert.False(ingestion.Paused)
+		tt.Assert.Equal(int32(3), ingestion.HistoryLatest)
+	}
+
+	w = rh.Post("/ingestion/pause", nil, auth)
+	if tt.Assert.Equal(200, w.Code) {
+		tt.Require.NoError(json.Unmarshal(w.Body.Bytes(), &ingestion))
+		tt.Assert.True(ingestion.Paused)
+		tt.Assert.True(app.IngestionPaused())
+	}
+
+	w = rh.Post("/ingestion/resume", nil, auth)
+	tt.Assert.Equal(200, w.Code)
+	tt.Assert.False(app.IngestionPaused())
+
+	// reingestion requires ingestion
+	w = rh.Post("/ingestion/reingest?from=1&to=3", nil, auth)
+	tt.Assert.Equal(409, w.Code)
+
+	// cache
+	w = rh.Post("/cache/flush", nil, auth)
+	tt.Assert.Equal(204, w.Code)
+
+	// txsub
+	var txsub adminTxSub
+	w = rh.Get("/txsub", auth)
+	if tt.Assert.Equal(200, w.Code) {
+		tt.Require.NoError(json.Unmarshal(w.Body.Bytes(), &txsub))
+		tt.Assert.Equal(0, txsub.Buffered)
+	}
+
+	// streams
+	defer sse.Resume()
+	w = rh.Post("/streams/drain?timeout=1s", nil, auth)
+	if tt.Assert.Equal(200, w.Code) {
+		tt.Assert.JSONEq(`{"open_streams": 0}`, w.Body.String())
+	}
+	tt.Assert.True(sse.Draining())
+
+	stream := NewRequestHelper(app).Get("/ledgers", func(r *http.Request) {
+		r.Header.Set("Accept", "text/event-stream")
+	})
+	tt.Assert.Equal(503, stream.Code)
+
+	w = rh.Post("/streams/resume", nil, auth)
+	tt.Assert.Equal(204, w.Code)
+	tt.Assert.False(sse.Draining())
+}
+
+func TestAdminAPI_Reingest(t *testing.T) {
+	tt := test.Start(t).Scenario("base")
+	defer tt.Finish()
+
+	config := NewTestConfig()
+	config.AdminToken = "secret"
+	config.Ingest = true
+	app, err := NewApp(config)
+	tt.Require.NoError(err)
+	defer app.Close()
+	app.UpdateLedgerState()
+
+	rh := test.NewRequestHelper(app.adminRouter())
+	auth := func(r *http.Request) {
+		r.Header.Set("Authorization", "Bearer secret")
+	}
+
+	w := rh.Post("/ingestion/reingest?from=0&to=3", nil, auth)
+	tt.Assert.Equal(400, w.Code)
+	w = rh.Post("/ingestion/reingest?from=3&to=2", nil, auth)
+	tt.Assert.Equal(400, w.Code)
+
+	w = rh.Post("/ingestion/reingest?from=2&to=3", nil, auth)
+	if tt.Assert.Equal(200, w.Code) {
+		tt.Assert.JSONEq(`{"ingested": 2}`, w.Body.String())
+	}
+}
+
+func TestAdminAPI_IngestionGaps(t *testing.T) {
+	tt := test.Start(t).Scenario("kahuna")
+	defer tt.Finish()
+
+	config := NewTestConfig()
+	config.AdminToken = "secret"
+	config.Ingest = true
+	app, err := NewApp(config)
+	tt.Require.NoError(err)
+	defer app.Close()
+	app.UpdateLedgerState()
+
+	rh := test.NewRequestHelper(app.adminRouter())
+	auth := func(r *http.Request) {
+		r.Header.Set("Authorization", "Bearer secret")
+	}
+
+	// no scan yet
+	var ingestion adminIngestion
+	w := rh.Get("/ingestion", auth)
+	if tt.Assert.Equal(200, w.Code) {
+		tt.Require.NoError(json.Unmarshal(w.Body.Bytes(), &ingestion))
+		tt.Assert.Equal(int32(0), ingestion.MissingLedgers)
+		tt.Assert.Nil(ingestion.Backfilling)
+		tt.Assert.Nil(ingestion.LastGapScan)
+	}
+
+	_, err = tt.HorizonSession().ExecRaw(
+		`DELETE FROM history_ledgers WHERE sequence BETWEEN 5 AND 9`,
+	)
+	tt.Require.NoError(err)
+	n, err := app.ingester.ReingestGaps()
+	tt.Require.NoError(err)
+	tt.Require.Equal(5, n)
+
+	ingestion = adminIngestion{}
+	w = rh.Get("/ingestion", auth)
+	if tt.Assert.Equal(200, w.Code) {
+		tt.Require.NoError(json.Unmarshal(w.Body.Bytes(), &ingestion))
+		tt.Assert.Equal(int32(0), ingestion.MissingLedgers)
+		tt.Assert.Nil(ingestion.Backfilling)
+		tt.Assert.NotNil(ingestion.LastGapScan)
+	}
+}
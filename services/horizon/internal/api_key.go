@@ -0,0 +1,123 @@
+package horizon
+
+import (
+	"sync"
+
+	"github.com/garyburd/redigo/redis"
+	"github.com/stellar/go/support/errors"
+)
+
+// DefaultAPIKeyPrefix is the prefix of the redis keys holding the API keys
+// of a RedisAPIKeyStore whose Prefix is not set.
+const DefaultAPIKeyPrefix = "api_key:"
+
+// APIKey holds the rate limits of an API key issued to a client of horizon.
+// Requests made with an API key are limited by these limits instead of the
+// limit shared by the requests of an IP address.
+type APIKey struct {
+	// RateLimit is the number of requests the key can make per hour.
+	RateLimit int
+
+	// Burst is the number of requests the key can make per second.  Bursts
+	// are not limited when zero.
+	Burst int
+}
+
+// APIKeyStore validates the API keys sent by clients.  Implementations must
+// be safe for concurrent use.
+type APIKeyStore interface {
+	// Get returns the API key identified by key, or nil if key is not a valid
+	// API key.
+	Get(key string) (*APIKey, error)
+}
+
+// MemoryAPIKeyStore is an APIKeyStore keeping the API keys in memory, for
+// applications embedding horizon and for tests.
+type MemoryAPIKeyStore struct {
+	mutex sync.RWMutex
+	keys  map[string]APIKey
+}
+
+// NewMemoryAPIKeyStore returns an empty MemoryAPIKeyStore.
+func NewMemoryAPIKeyStore() *MemoryAPIKeyStore {
+	return &MemoryAPIKeyStore{keys: map[string]APIKey{}}
+}
+
+// Get implements APIKeyStore
+func (s *MemoryAPIKeyStore) Get(key string) (*APIKey, error) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	apiKey, ok := s.keys[key]
+	if !ok {
+		return nil, nil
+	}
+	return &apiKey, nil
+}
+
+// Set issues key, or updates its limits.
+func (s *MemoryAPIKeyStore) Set(key string, apiKey APIKey) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.keys[key] = apiKey
+}
+
+// Delete revokes key.
+func (s *MemoryAPIKeyStore) Delete(key string) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	delete(s.keys, key)
+}
+
+// RedisAPIKeyStore is an APIKeyStore keeping the API keys in redis, so that
+// they are shared by the horizon instances of a deployment.  Each key is a
+// hash with `rate_limit` and `burst` fields, e.g.:
+//
+//   HSET api_key:<key> rate_limit 36000 burst 20
+type RedisAPIKeyStore struct {
+	Pool *redis.Pool
+
+	// Prefix is prepended to the API keys to form the redis keys.
+	// DefaultAPIKeyPrefix is used when empty.
+	Prefix string
+}
+
+// Get implements APIKeyStore
+func (s *RedisAPIKeyStore) Get(key string) (*APIKey, error) {
+	prefix := s.Prefix
+	if prefix == "" {
+		prefix = DefaultAPIKeyPrefix
+	}
+
+	c := s.Pool.Get()
+	defer c.Close()
+
+	values, err := redis.Values(c.Do("HMGET", prefix+key, "rate_limit", "burst"))
+	if err != nil {
+		return nil, errors.Wrap(err, "load api key failed")
+	}
+
+	// keys without a rate limit don't exist
+	if len(values) != 2 || values[0] == nil {
+		return nil, nil
+	}
+
+	var apiKey APIKey
+	apiKey.RateLimit, err = redis.Int(values[0], nil)
+	if err != nil {
+		return nil, errors.Wrap(err, "parse rate_limit failed")
+	}
+
+	if values[1] != nil {
+		apiKey.Burst, err = redis.Int(values[1], nil)
+		if err != nil {
+			return nil, errors.Wrap(err, "parse burst failed")
+		}
+	}
+
+	return &apiKey, nil
+}
+
+// ensure that the stores implement APIKeyStore
+var _ APIKeyStore = &MemoryAPIKeyStore{}
+var _ APIKeyStore = &RedisAPIKeyStore{}
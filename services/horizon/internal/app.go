@@ -12,8 +12,12 @@ import (
 	"github.com/stellar/go/support/app"
 
 	"github.com/garyburd/redigo/redis"
+	graphql "github.com/graph-gophers/graphql-go"
 	metrics "github.com/rcrowley/go-metrics"
 	"github.com/stellar/go/build"
+	"github.com/stellar/go/services/horizon/internal/audit"
+	"github.com/stellar/go/services/horizon/internal/cache"
+	"github.com/stellar/go/services/horizon/internal/db2"
 	"github.com/stellar/go/services/horizon/internal/db2/core"
 	"github.com/stellar/go/services/horizon/internal/db2/history"
 	"github.com/stellar/go/services/horizon/internal/ingest"
@@ -21,8 +25,8 @@ import (
 	"github.com/stellar/go/services/horizon/internal/log"
 	"github.com/stellar/go/services/horizon/internal/paths"
 	"github.com/stellar/go/services/horizon/internal/reap"
-	"github.com/stellar/go/services/horizon/internal/txsub"
 	"github.com/stellar/go/services/horizon/internal/render/sse"
+	"github.com/stellar/go/services/horizon/internal/txsub"
 	"github.com/stellar/go/support/db"
 	"golang.org/x/net/context"
 	"golang.org/x/net/http2"
@@ -34,6 +38,9 @@ type App struct {
 	config            Config
 	web               *Web
 	historyQ          *history.Q
+	historyReplica    *db2.Replica
+	historyCache      *cache.Cache
+	ingestionPaused   int32
 	coreQ             *core.Q
 	ctx               context.Context
 	cancel            func()
@@ -46,7 +53,10 @@ type App struct {
 	paths             paths.Finder
 	ingester          *ingest.System
 	reaper            *reap.System
+	ledgerState       *ledger.Tracker
+	audit             *audit.System
 	ticks             *time.Ticker
+	graphQLSchema     *graphql.Schema
 
 	// metrics
 	metrics                  metrics.Registry
@@ -56,6 +66,8 @@ type App struct {
 	coreLatestLedgerGauge    metrics.Gauge
 	coreConnGauge            metrics.Gauge
 	goroutineGauge           metrics.Gauge
+	ingestLagGauge           metrics.Gauge
+	streamGauge              metrics.Gauge
 }
 
 // NewApp constructs an new App instance from the provided config.
@@ -72,31 +84,56 @@ func NewApp(config Config) (*App, error) {
 // Serve starts the horizon web server, binding it to a socket, setting up
 // the shutdown signals.
 func (a *App) Serve() {
+	ServeNetworks(a)
+}
 
-	a.web.router.Compile()
-	http.Handle("/", a.web.router)
-
+// ServeNetworks starts the horizon web server of one or more apps, each
+// serving a network under its URLPrefix, binding it to a socket, setting up
+// the shutdown signals.  The port, TLS, shutdown and admin API configuration
+// of the first app apply to the server.
+func ServeNetworks(apps ...*App) {
+	a := apps[0]
 	addr := fmt.Sprintf(":%d", a.config.Port)
 
+	timeout := a.config.ShutdownTimeout
+	if timeout == 0 {
+		timeout = DefaultShutdownTimeout
+	}
+	// the deadline is set when the shutdown is initiated, in the goroutine of
+	// the signal handler
+	deadlines := make(chan time.Time, 1)
+
 	srv := &graceful.Server{
-		Timeout: 10 * time.Second,
+		Timeout: timeout,
 
 		Server: &http.Server{
 			Addr:    addr,
-			Handler: http.DefaultServeMux,
+			Handler: networksHandler(apps),
 		},
 
+		// requests in progress, including transaction submissions waiting
+		// for their result, are served until the timeout while the apps keep
+		// ticking
 		ShutdownInitiated: func() {
 			log.Info("received signal, gracefully stopping")
-			a.Close()
+			select {
+			case deadlines <- time.Now().Add(timeout):
+			default:
+			}
+			sse.Drain()
 		},
 	}
 
 	http2.ConfigureServer(srv.Server, nil)
 
-	log.Infof("Starting horizon on %s (ingest: %v)", addr, a.config.Ingest)
+	for _, app := range apps {
+		log.Infof("Starting horizon on %s%s (ingest: %v)", addr, app.config.URLPrefix, app.config.Ingest)
+		go app.run()
+	}
 
-	go a.run()
+	if a.config.AdminPort != 0 {
+		go a.serveAdmin()
+	}
 
 	var err error
 	if a.config.TLSCert != "" {
@@ -109,9 +146,55 @@ func (a *App) Serve() {
 		log.Panic(err)
 	}
 
+	var deadline time.Time
+	select {
+	case deadline = <-deadlines:
+	default:
+		deadline = time.Now().Add(timeout)
+	}
+	for _, app := range apps {
+		app.shutdown(deadline)
+	}
 	log.Info("stopped")
 }
 
+// networksHandler routes the requests to the apps serving the network of
+// their path prefix, stripping the prefix.
+func networksHandler(apps []*App) http.Handler {
+	mux := http.NewServeMux()
+	for _, app := range apps {
+		app.web.router.Compile()
+
+		prefix := app.config.URLPrefix
+		if prefix == "" {
+			mux.Handle("/", app.web.router)
+			continue
+		}
+		mux.Handle(prefix+"/", http.StripPrefix(prefix, app.web.router))
+	}
+	return mux
+}
+
+// shutdown closes the app once the web server has stopped, after waiting
+// until the deadline for the buffered transaction submissions to be submitted
+// and for the ingestion session in progress to commit the ledgers it
+// ingested.
+func (a *App) shutdown(deadline time.Time) {
+	for a.submitter.SubmissionQueue.Size() > 0 && time.Now().Before(deadline) {
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	if n := a.submitter.SubmissionQueue.Size(); n > 0 {
+		log.WithField("buffered", n).Warn("stopping with buffered transaction submissions")
+	}
+
+	if a.ingester != nil && !a.ingester.Shutdown(deadline.Sub(time.Now())) {
+		log.Warn("stopping before the ingestion session in progress ended")
+	}
+
+	a.Close()
+}
+
 // Close cancels the app and forces the closure of db connections
 func (a *App) Close() {
 	a.cancel()
@@ -119,6 +202,9 @@ func (a *App) Close() {
 
 	a.historyQ.Session.DB.Close()
 	a.coreQ.Session.DB.Close()
+	if a.historyReplica != nil {
+		a.historyReplica.Replica.Close()
+	}
 }
 
 // HistoryQ returns a helper object for performing sql queries against the
@@ -133,6 +219,18 @@ func (a *App) HorizonSession(ctx context.Context) *db.Session {
 	return &db.Session{DB: a.historyQ.Session.DB, Ctx: ctx}
 }
 
+// HorizonReadSession returns a new session that loads data from the read
+// replica of the horizon database, if one is configured and caught up with
+// ingestion, or else from the horizon database. The returned session is bound
+// to `ctx`.  Sessions that write, or that need the latest ingested data, use
+// HorizonSession.
+func (a *App) HorizonReadSession(ctx context.Context) *db.Session {
+	if a.historyReplica == nil {
+		return a.HorizonSession(ctx)
+	}
+	return a.historyReplica.Session(ctx)
+}
+
 // CoreSession returns a new session that loads data from the stellar core
 // database. The returned session is bound to `ctx`.
 func (a *App) CoreSession(ctx context.Context) *db.Session {
@@ -145,6 +243,15 @@ func (a *App) CoreQ() *core.Q {
 	return a.coreQ
 }
 
+// MaxPageSize returns the max number of records clients may request in a
+// single page.
+func (a *App) MaxPageSize() uint64 {
+	if a.config.MaxPageSize == 0 {
+		return db2.MaxPageSize
+	}
+	return uint64(a.config.MaxPageSize)
+}
+
 // IsHistoryStale returns true if the latest history ledger is more than
 // `StaleThreshold` ledgers behind the latest core ledger
 func (a *App) IsHistoryStale() bool {
@@ -152,7 +259,7 @@ func (a *App) IsHistoryStale() bool {
 		return false
 	}
 
-	ls := ledger.CurrentState()
+	ls := a.ledgerState.CurrentState()
 	return (ls.CoreLatest - ls.HistoryLatest) > int32(a.config.StaleThreshold)
 }
 
@@ -177,7 +284,8 @@ func (a *App) UpdateLedgerState() {
 		goto Failed
 	}
 
-	ledger.SetState(next)
+	a.ledgerState.SetState(next)
+	a.updateReplicaLag(next.HistoryLatest)
 	return
 
 Failed:
@@ -187,6 +295,38 @@ Failed:
 
 }
 
+// updateReplicaLag routes the reads of the horizon database to its read
+// replica while the replica is no more than `ReplicaMaxLag` ledgers behind
+// `historyLatest`, the latest ledger ingested into the horizon database.
+func (a *App) updateReplicaLag(historyLatest int32) {
+	if a.historyReplica == nil {
+		return
+	}
+
+	wasCaughtUp := a.historyReplica.CaughtUp()
+
+	var replicaLatest int32
+	q := &history.Q{Session: &db.Session{DB: a.historyReplica.Replica}}
+	err := q.LatestLedger(&replicaLatest)
+	if err != nil {
+		a.historyReplica.SetUnavailable()
+		log.WithField("err", err.Error()).
+			Warn("failed to load the latest ledger of the read replica, reading from the horizon database")
+		return
+	}
+
+	caughtUp := a.historyReplica.SetLatestLedgers(historyLatest, replicaLatest)
+	switch {
+	case caughtUp && !wasCaughtUp:
+		log.WithField("replica_latest", replicaLatest).
+			Info("read replica caught up, reading from the replica")
+	case !caughtUp && wasCaughtUp:
+		log.WithField("replica_latest", replicaLatest).
+			WithField("history_latest", historyLatest).
+			Warn("read replica lagging, reading from the horizon database")
+	}
+}
+
 // UpdateStellarCoreInfo updates the value of coreVersion and networkPassphrase
 // from the Stellar core API.
 func (a *App) UpdateStellarCoreInfo() {
@@ -218,11 +358,18 @@ func (a *App) UpdateStellarCoreInfo() {
 // db connections and ledger state
 func (a *App) UpdateMetrics() {
 	a.goroutineGauge.Update(int64(runtime.NumGoroutine()))
-	ls := ledger.CurrentState()
+	ls := a.ledgerState.CurrentState()
 	a.historyLatestLedgerGauge.Update(int64(ls.HistoryLatest))
 	a.historyElderLedgerGauge.Update(int64(ls.HistoryElder))
 	a.coreLatestLedgerGauge.Update(int64(ls.CoreLatest))
 
+	lag := int64(ls.CoreLatest) - int64(ls.HistoryLatest)
+	if lag < 0 {
+		lag = 0
+	}
+	a.ingestLagGauge.Update(lag)
+	a.streamGauge.Update(sse.OpenStreamCount())
+
 	a.horizonConnGauge.Update(int64(a.historyQ.Session.DB.Stats().OpenConnections))
 	a.coreConnGauge.Update(int64(a.coreQ.Session.DB.Stats().OpenConnections))
 }
@@ -244,12 +391,13 @@ func (a *App) Tick() {
 	go func() { a.UpdateStellarCoreInfo(); wg.Done() }()
 	wg.Wait()
 
-	if a.ingester != nil {
+	if a.ingester != nil && !a.IngestionPaused() {
 		go a.ingester.Tick()
 	}
 
-	wg.Add(2)
+	wg.Add(3)
 	go func() { a.reaper.Tick(); wg.Done() }()
+	go func() { a.audit.Tick(); wg.Done() }()
 	go func() { a.submitter.Tick(a.ctx); wg.Done() }()
 	wg.Wait()
 
@@ -2,7 +2,9 @@ package horizon
 
 import (
 	"net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/stellar/go/services/horizon/internal/test"
 	"github.com/stellar/go/services/horizon/internal/render/sse"
@@ -21,6 +23,32 @@ func TestNewApp(t *testing.T) {
 	})
 }
 
+func TestReadReplica(t *testing.T) {
+	tt := test.Start(t).Scenario("base")
+	defer tt.Finish()
+
+	// the test database stands in for its own replica
+	config := NewTestConfig()
+	config.ReplicaDatabaseURL = test.DatabaseURL()
+	app, err := NewApp(config)
+	tt.Require.NoError(err)
+	defer app.Close()
+
+	// reads go to the horizon database until the replica's lag is known
+	tt.Assert.False(app.historyReplica.CaughtUp())
+	tt.Assert.Equal(app.historyQ.Session.DB, app.HorizonReadSession(nil).DB)
+
+	app.UpdateLedgerState()
+	tt.Assert.True(app.historyReplica.CaughtUp())
+	tt.Assert.Equal(app.historyReplica.Replica, app.HorizonReadSession(nil).DB)
+	tt.Assert.Equal(app.historyQ.Session.DB, app.HorizonSession(nil).DB)
+
+	// a replica behind ingestion by more than the max lag is skipped
+	app.updateReplicaLag(3 + int32(config.ReplicaMaxLag) + 1)
+	tt.Assert.False(app.historyReplica.CaughtUp())
+	tt.Assert.Equal(app.historyQ.Session.DB, app.HorizonReadSession(nil).DB)
+}
+
 func TestGenericHTTPFeatures(t *testing.T) {
 	ht := StartHTTPTest(t, "base")
 	defer ht.Finish()
@@ -49,6 +77,76 @@ func TestGenericHTTPFeatures(t *testing.T) {
 	ht.Assert.Equal(200, w.Code)
 }
 
+func TestCORSConfig(t *testing.T) {
+	tt := test.Start(t).Scenario("base")
+	defer tt.Finish()
+
+	config := NewTestConfig()
+	config.CORSAllowedOrigins = []string{"https://*.example.com"}
+	config.CORSAllowedMethods = []string{"GET"}
+	config.CORSMaxAge = 10 * time.Minute
+	app, err := NewApp(config)
+	tt.Require.NoError(err)
+	defer app.Close()
+	rh := NewRequestHelper(app)
+
+	w := rh.Get("/", func(r *http.Request) {
+		r.Header.Set("Origin", "https://wallet.example.com")
+	})
+	if tt.Assert.Equal(200, w.Code) {
+		tt.Assert.Equal(
+			"https://wallet.example.com",
+			w.HeaderMap.Get("Access-Control-Allow-Origin"),
+		)
+	}
+
+	// other origins are not allowed
+	w = rh.Get("/", func(r *http.Request) {
+		r.Header.Set("Origin", "https://somewhere.com")
+	})
+	if tt.Assert.Equal(200, w.Code) {
+		tt.Assert.Empty(w.HeaderMap.Get("Access-Control-Allow-Origin"))
+	}
+
+	// preflight responses may be cached
+	w = rh.Get("/ledgers", func(r *http.Request) {
+		r.Method = "OPTIONS"
+		r.Header.Set("Origin", "https://wallet.example.com")
+		r.Header.Set("Access-Control-Request-Method", "GET")
+	})
+	tt.Assert.Equal("600", w.HeaderMap.Get("Access-Control-Max-Age"))
+	tt.Assert.Equal("GET", w.HeaderMap.Get("Access-Control-Allow-Methods"))
+}
+
+func TestRequestID(t *testing.T) {
+	ht := StartHTTPTest(t, "base")
+	defer ht.Finish()
+
+	// generated when missing
+	w := ht.Get("/")
+	if ht.Assert.Equal(200, w.Code) {
+		ht.Assert.NotEmpty(w.HeaderMap.Get(RequestIDHeader))
+	}
+
+	// propagated from the client
+	w = ht.Get("/ledgers/100000", func(r *http.Request) {
+		r.Header.Set(RequestIDHeader, "client-id-1")
+	})
+	if ht.Assert.Equal(404, w.Code) {
+		ht.Assert.Equal("client-id-1", w.HeaderMap.Get(RequestIDHeader))
+		ht.Assert.Contains(w.Body.String(), `"instance": "client-id-1"`)
+	}
+
+	// ids that could forge log lines are replaced
+	w = ht.Get("/", func(r *http.Request) {
+		r.Header.Set(RequestIDHeader, "bad\tid")
+	})
+	if ht.Assert.Equal(200, w.Code) {
+		ht.Assert.NotEqual("bad\tid", w.HeaderMap.Get(RequestIDHeader))
+		ht.Assert.NotEmpty(w.HeaderMap.Get(RequestIDHeader))
+	}
+}
+
 func TestMetrics(t *testing.T) {
 	ht := StartHTTPTest(t, "base")
 	defer ht.Finish()
@@ -69,6 +167,33 @@ func TestMetrics(t *testing.T) {
 	ht.Require.EqualValues(3, cl.Value())
 }
 
+func TestMetrics_Prometheus(t *testing.T) {
+	ht := StartHTTPTest(t, "base")
+	defer ht.Finish()
+
+	ht.App.UpdateMetrics()
+	ht.Get("/ledgers/1")
+
+	w := ht.Get("/metrics", func(r *http.Request) {
+		r.Header.Set("Accept", "text/plain;version=0.0.4;q=1,*/*;q=0.1")
+	})
+	if ht.Assert.Equal(200, w.Code) {
+		ht.Assert.Equal("text/plain; version=0.0.4", w.HeaderMap.Get("Content-Type"))
+
+		body := w.Body.String()
+		ht.Assert.Contains(body, "# TYPE horizon_history_latest_ledger gauge\nhorizon_history_latest_ledger 3\n")
+		ht.Assert.Contains(body, "horizon_history_ingest_lag 0\n")
+		ht.Assert.Contains(body, "# TYPE horizon_streams_open gauge\n")
+		ht.Assert.Contains(body, "# TYPE horizon_requests_total_seconds summary\n")
+		ht.Assert.Contains(body, `horizon_route_request_duration_seconds_count{method="GET",route="/ledgers/:id"} 1`)
+	}
+
+	// the JSON snapshot is still the default
+	w = ht.Get("/metrics")
+	ht.Assert.Equal(200, w.Code)
+	ht.Assert.Contains(w.Body.String(), `"history.latest_ledger"`)
+}
+
 func TestTick(t *testing.T) {
 	ht := StartHTTPTest(t, "base")
 	defer ht.Finish()
@@ -101,3 +226,46 @@ func TestTick(t *testing.T) {
 		t.FailNow()
 	}
 }
+
+func TestServeNetworks(t *testing.T) {
+	tt := test.Start(t).Scenario("base")
+	defer tt.Finish()
+
+	pubnet, err := NewApp(NewTestConfig())
+	tt.Require.NoError(err)
+	defer pubnet.Close()
+
+	config := NewTestConfig()
+	config.URLPrefix = "/testnet"
+	testnet, err := NewApp(config)
+	tt.Require.NoError(err)
+	defer testnet.Close()
+
+	// the ledger state of prefixed networks is tracked on its own
+	tt.Assert.Equal(int32(3), pubnet.ledgerState.CurrentState().HistoryLatest)
+	tt.Assert.Equal(int32(0), testnet.ledgerState.CurrentState().HistoryLatest)
+	testnet.UpdateLedgerState()
+	tt.Assert.Equal(int32(3), testnet.ledgerState.CurrentState().HistoryLatest)
+
+	handler := networksHandler([]*App{pubnet, testnet})
+	get := func(path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, httptest.NewRequest("GET", path, nil))
+		return w
+	}
+
+	w := get("/ledgers/1")
+	if tt.Assert.Equal(200, w.Code) {
+		tt.Assert.Contains(w.Body.String(), `"href": "http://example.com/ledgers/1"`)
+	}
+
+	w = get("/testnet/ledgers/1")
+	if tt.Assert.Equal(200, w.Code) {
+		tt.Assert.Contains(w.Body.String(), `"href": "http://example.com/testnet/ledgers/1"`)
+	}
+
+	w = get("/testnet/ledgers?limit=1")
+	if tt.Assert.Equal(200, w.Code) {
+		tt.Assert.Contains(w.Body.String(), `"href": "http://example.com/testnet/ledgers?`)
+	}
+}
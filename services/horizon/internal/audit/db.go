@@ -0,0 +1,53 @@
+package audit
+
+import (
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/stellar/go/support/db"
+)
+
+// DBSink records submissions to the audit_submissions table of the horizon
+// database.
+type DBSink struct {
+	Session *db.Session
+}
+
+var _ Sink = &DBSink{}
+var _ Expirer = &DBSink{}
+
+// Record inserts the submission.
+func (sink *DBSink) Record(s Submission) error {
+	sql := sq.Insert("audit_submissions").SetMap(map[string]interface{}{
+		"transaction_hash": nullString(s.TransactionHash),
+		"source_account":   nullString(s.SourceAccount),
+		"envelope_xdr":     s.EnvelopeXDR,
+		"result":           s.Result,
+		"result_xdr":       nullString(s.ResultXDR),
+		"client_ip":        nullString(s.ClientIP),
+		"submitted_at":     s.SubmittedAt,
+	})
+
+	_, err := sink.Session.Exec(sql)
+	return err
+}
+
+// DeleteBefore deletes the submissions recorded before `t`.
+func (sink *DBSink) DeleteBefore(t time.Time) (int64, error) {
+	sql := sq.Delete("audit_submissions").Where("submitted_at < ?", t)
+
+	result, err := sink.Session.Exec(sql)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+// nullString returns nil for empty strings, so that unknown values are
+// recorded as NULL.
+func nullString(s string) interface{} {
+	if s == "" {
+		return nil
+	}
+	return s
+}
@@ -0,0 +1,35 @@
+package audit
+
+import (
+	"github.com/stellar/go/services/horizon/internal/log"
+)
+
+// LogSink records submissions to the log of horizon, as info entries with the
+// fields of the submission.
+type LogSink struct {
+	// Log is the logger the submissions are recorded to.  The default logger
+	// is used when nil.
+	Log *log.Entry
+}
+
+var _ Sink = &LogSink{}
+
+// Record logs the submission.
+func (sink *LogSink) Record(s Submission) error {
+	l := sink.Log
+	if l == nil {
+		l = log.DefaultLogger
+	}
+
+	l.WithFields(log.F{
+		"audit":          "submission",
+		"hash":           s.TransactionHash,
+		"source_account": s.SourceAccount,
+		"envelope_xdr":   s.EnvelopeXDR,
+		"result":         s.Result,
+		"result_xdr":     s.ResultXDR,
+		"ip":             s.ClientIP,
+		"submitted_at":   s.SubmittedAt,
+	}).Info("transaction submitted")
+	return nil
+}
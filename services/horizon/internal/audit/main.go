@@ -0,0 +1,196 @@
+// Package audit records the transactions submitted to horizon, along with
+// their result and the client that submitted them, for compliance and abuse
+// investigations.  Submissions are recorded to a sink, either the
+// audit_submissions table of the horizon database or the log of horizon.
+package audit
+
+import (
+	"encoding/hex"
+	"time"
+
+	"github.com/stellar/go/network"
+	"github.com/stellar/go/services/horizon/internal/log"
+	"github.com/stellar/go/services/horizon/internal/txsub"
+	"github.com/stellar/go/xdr"
+)
+
+// DefaultInterval is the interval between the deletions of the expired
+// submissions of a system whose Interval is not set.
+const DefaultInterval = 1 * time.Hour
+
+// The results a submission is recorded with.
+const (
+	// ResultSuccess is the result of transactions applied to the ledger.
+	ResultSuccess = "success"
+	// ResultFailed is the result of transactions that failed when applied.
+	ResultFailed = "failed"
+	// ResultRejected is the result of transactions horizon rejected before
+	// submitting them, since they would have failed.
+	ResultRejected = "rejected"
+	// ResultMalformed is the result of envelopes that could not be decoded.
+	ResultMalformed = "malformed"
+	// ResultTimeout is the result of submissions whose result was not known
+	// before their timeout.
+	ResultTimeout = "timeout"
+	// ResultAccepted is the result of asynchronous submissions, recorded when
+	// queued for submission.  They are recorded again with their final result
+	// once known.
+	ResultAccepted = "accepted"
+	// ResultError is the result of submissions that failed for any other
+	// reason, such as stellar-core being unavailable.
+	ResultError = "error"
+)
+
+// Submission is the record of a transaction submitted to horizon.
+type Submission struct {
+	TransactionHash string    `db:"transaction_hash"`
+	SourceAccount   string    `db:"source_account"`
+	EnvelopeXDR     string    `db:"envelope_xdr"`
+	Result          string    `db:"result"`
+	ResultXDR       string    `db:"result_xdr"`
+	ClientIP        string    `db:"client_ip"`
+	SubmittedAt     time.Time `db:"submitted_at"`
+}
+
+// Sink is where submissions are recorded.
+type Sink interface {
+	Record(Submission) error
+}
+
+// Expirer is implemented by the sinks that can delete the submissions
+// recorded before a time.
+type Expirer interface {
+	DeleteBefore(time.Time) (int64, error)
+}
+
+// System records submissions to its sink and, when the sink is an Expirer,
+// deletes the submissions older than its retention.  A nil System records
+// nothing.
+type System struct {
+	Sink Sink
+
+	// NetworkPassphrase is the passphrase of the network the transactions are
+	// submitted to, needed to hash them.
+	NetworkPassphrase string
+
+	// Retention is how long submissions are kept.  They are kept forever when
+	// zero.
+	Retention time.Duration
+
+	// Interval is the interval between two deletions of the expired
+	// submissions.  DefaultInterval is used when zero.
+	Interval time.Duration
+
+	nextRun time.Time
+}
+
+// Record records the submission of the envelope `env` by the client at `ip`,
+// which ended with `r`.  The submission is logged when it can't be recorded,
+// since an audit failure must not fail the submission itself.
+func (sys *System) Record(env string, r txsub.Result, ip string) {
+	if sys == nil {
+		return
+	}
+
+	s := Submission{
+		TransactionHash: r.Hash,
+		Result:          ResultOf(r.Err),
+		ResultXDR:       r.ResultXDR,
+	}
+
+	switch err := r.Err.(type) {
+	case *txsub.FailedTransactionError:
+		s.ResultXDR = err.ResultXDR
+	case *txsub.RejectedTransactionError:
+		s.ResultXDR = err.ResultXDR
+	}
+
+	sys.record(s, env, ip)
+}
+
+// RecordAccepted records the asynchronous submission of the envelope `env`,
+// whose hash is `hash`, by the client at `ip`.  The result of asynchronous
+// submissions is not known yet when recorded: it is recorded with Record once
+// known.
+func (sys *System) RecordAccepted(env string, hash string, ip string) {
+	if sys == nil {
+		return
+	}
+
+	sys.record(Submission{TransactionHash: hash, Result: ResultAccepted}, env, ip)
+}
+
+// record completes the submission with the details of the envelope and the
+// client, then records it to the sink.
+func (sys *System) record(s Submission, env string, ip string) {
+	s.EnvelopeXDR = env
+	s.ClientIP = ip
+	s.SubmittedAt = time.Now().UTC()
+
+	var tx xdr.TransactionEnvelope
+	if xdr.SafeUnmarshalBase64(env, &tx) == nil {
+		s.SourceAccount = tx.Tx.SourceAccount.Address()
+		if s.TransactionHash == "" {
+			hash, err := network.HashTransaction(&tx.Tx, sys.NetworkPassphrase)
+			if err == nil {
+				s.TransactionHash = hex.EncodeToString(hash[:])
+			}
+		}
+	}
+
+	err := sys.Sink.Record(s)
+	if err != nil {
+		log.
+			WithField("hash", s.TransactionHash).
+			WithStack(err).
+			Errorf("failed to record submission: %s", err)
+	}
+}
+
+// ResultOf returns the result a submission that ended with `err` is recorded
+// with.
+func ResultOf(err error) string {
+	switch err.(type) {
+	case nil:
+		return ResultSuccess
+	case *txsub.FailedTransactionError:
+		return ResultFailed
+	case *txsub.RejectedTransactionError:
+		return ResultRejected
+	case *txsub.MalformedTransactionError:
+		return ResultMalformed
+	}
+
+	if err == txsub.ErrTimeout || err == txsub.ErrCanceled {
+		return ResultTimeout
+	}
+	return ResultError
+}
+
+// Tick deletes the expired submissions if it is the appropriate time.
+func (sys *System) Tick() {
+	if sys == nil || sys.Retention == 0 {
+		return
+	}
+
+	expirer, ok := sys.Sink.(Expirer)
+	if !ok || time.Now().Before(sys.nextRun) {
+		return
+	}
+
+	deleted, err := expirer.DeleteBefore(time.Now().UTC().Add(-sys.Retention))
+	if err != nil {
+		log.Errorf("failed to delete expired submissions: %s", err)
+	} else if deleted > 0 {
+		log.WithField("deleted", deleted).Info("deleted expired submissions")
+	}
+
+	sys.nextRun = time.Now().Add(sys.interval())
+}
+
+func (sys *System) interval() time.Duration {
+	if sys.Interval <= 0 {
+		return DefaultInterval
+	}
+	return sys.Interval
+}
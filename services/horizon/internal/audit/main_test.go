@@ -0,0 +1,126 @@
+package audit
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stellar/go/build"
+	"github.com/stellar/go/services/horizon/internal/db2/schema"
+	"github.com/stellar/go/services/horizon/internal/test"
+	"github.com/stellar/go/services/horizon/internal/txsub"
+)
+
+const (
+	envelope = "AAAAAGL8HQvQkbK2HA3WVjRrKmjX00fG8sLI7m0ERwJW/AX3AAAAZAAAAAAAAAABAAAAAAAAAAAAAAABAAAAAAAAAAAAAAAArqN6LeOagjxMaUP96Bzfs9e0corNZXzBWJkFoK7kvkwAAAAAO5rKAAAAAAAAAAABVvwF9wAAAECDzqvkQBQoNAJifPRXDoLhvtycT3lFPCQ51gkdsFHaBNWw05S/VhW0Xgkr0CBPE4NaFV2Kmcs3ZwLmib4TRrML"
+	hash     = "2374e99349b9ef7dba9a5db3339b78fda8f34777b1af33ba468ad5c0df946d4d"
+	source   = "GBRPYHIL2CI3FNQ4BXLFMNDLFJUNPU2HY3ZMFSHONUCEOASW7QC7OX2H"
+)
+
+// memorySink records submissions in memory.
+type memorySink struct {
+	submissions []Submission
+	err         error
+}
+
+func (sink *memorySink) Record(s Submission) error {
+	sink.submissions = append(sink.submissions, s)
+	return sink.err
+}
+
+func TestRecord(t *testing.T) {
+	tt := test.Start(t)
+	defer tt.Finish()
+
+	sink := &memorySink{}
+	sys := &System{Sink: sink, NetworkPassphrase: build.TestNetwork.Passphrase}
+
+	sys.Record(envelope, txsub.Result{}, "10.0.0.1")
+	sys.Record(envelope, txsub.Result{Err: &txsub.FailedTransactionError{ResultXDR: "AAAA"}}, "10.0.0.2")
+	sys.Record("AAAA", txsub.Result{Err: &txsub.MalformedTransactionError{EnvelopeXDR: "AAAA"}}, "10.0.0.3")
+	sys.RecordAccepted(envelope, hash, "10.0.0.4")
+
+	tt.Require.Len(sink.submissions, 4)
+
+	s := sink.submissions[0]
+	tt.Assert.Equal(hash, s.TransactionHash)
+	tt.Assert.Equal(source, s.SourceAccount)
+	tt.Assert.Equal(envelope, s.EnvelopeXDR)
+	tt.Assert.Equal(ResultSuccess, s.Result)
+	tt.Assert.Equal("10.0.0.1", s.ClientIP)
+	tt.Assert.WithinDuration(time.Now(), s.SubmittedAt, time.Minute)
+
+	tt.Assert.Equal(ResultFailed, sink.submissions[1].Result)
+	tt.Assert.Equal("AAAA", sink.submissions[1].ResultXDR)
+
+	tt.Assert.Equal(ResultMalformed, sink.submissions[2].Result)
+	tt.Assert.Empty(sink.submissions[2].TransactionHash)
+	tt.Assert.Empty(sink.submissions[2].SourceAccount)
+
+	tt.Assert.Equal(ResultAccepted, sink.submissions[3].Result)
+	tt.Assert.Equal(hash, sink.submissions[3].TransactionHash)
+
+	// failing sinks don't fail the submission
+	sink.err = errors.New("busted")
+	sys.Record(envelope, txsub.Result{}, "10.0.0.1")
+	tt.Assert.Len(sink.submissions, 5)
+
+	// a nil system records nothing
+	var nilSys *System
+	nilSys.Record(envelope, txsub.Result{}, "10.0.0.1")
+	nilSys.RecordAccepted(envelope, hash, "10.0.0.1")
+	nilSys.Tick()
+}
+
+func TestResultOf(t *testing.T) {
+	tt := test.Start(t)
+	defer tt.Finish()
+
+	tt.Assert.Equal(ResultSuccess, ResultOf(nil))
+	tt.Assert.Equal(ResultFailed, ResultOf(&txsub.FailedTransactionError{}))
+	tt.Assert.Equal(ResultRejected, ResultOf(&txsub.RejectedTransactionError{}))
+	tt.Assert.Equal(ResultMalformed, ResultOf(&txsub.MalformedTransactionError{}))
+	tt.Assert.Equal(ResultTimeout, ResultOf(txsub.ErrTimeout))
+	tt.Assert.Equal(ResultTimeout, ResultOf(txsub.ErrCanceled))
+	tt.Assert.Equal(ResultError, ResultOf(errors.New("busted")))
+}
+
+func TestDBSink(t *testing.T) {
+	tt := test.Start(t).Scenario("base")
+	defer tt.Finish()
+
+	db := tt.HorizonSession()
+	_, err := schema.Migrate(db.DB.DB, schema.MigrateUp, 0)
+	tt.Require.NoError(err)
+
+	sys := &System{
+		Sink:              &DBSink{Session: db},
+		NetworkPassphrase: build.TestNetwork.Passphrase,
+		Retention:         time.Hour,
+	}
+	sys.Record(envelope, txsub.Result{}, "10.0.0.1")
+
+	var recorded []Submission
+	err = db.SelectRaw(&recorded, `
+		SELECT transaction_hash, source_account, envelope_xdr, result,
+			COALESCE(result_xdr, '') AS result_xdr, client_ip, submitted_at
+		FROM audit_submissions`)
+	tt.Require.NoError(err)
+	if tt.Assert.Len(recorded, 1) {
+		tt.Assert.Equal(hash, recorded[0].TransactionHash)
+		tt.Assert.Equal(source, recorded[0].SourceAccount)
+		tt.Assert.Equal(ResultSuccess, recorded[0].Result)
+		tt.Assert.Equal("10.0.0.1", recorded[0].ClientIP)
+	}
+
+	// submissions within the retention are kept
+	sys.Tick()
+	var count int
+	err = db.GetRaw(&count, `SELECT COUNT(*) FROM audit_submissions`)
+	tt.Require.NoError(err)
+	tt.Assert.Equal(1, count)
+
+	deleted, err := sys.Sink.(Expirer).DeleteBefore(time.Now().UTC().Add(time.Minute))
+	tt.Require.NoError(err)
+	tt.Assert.Equal(int64(1), deleted)
+}
@@ -0,0 +1,171 @@
+// Package cache provides an in-process LRU cache of the records of the history
+// database that never change once ingested: ledgers, transactions by hash and
+// operations by id.  Records are never invalidated, but those of ledgers
+// reaped from the history database are no longer served.
+package cache
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/golang/groupcache/lru"
+	"github.com/rcrowley/go-metrics"
+	"github.com/stellar/go/services/horizon/internal/db2/history"
+	"github.com/stellar/go/services/horizon/internal/ledger"
+)
+
+// Cache is an LRU cache of history records.  A nil Cache loads every record
+// from the database.
+type Cache struct {
+	Metrics Metrics
+
+	// Ledger tracks the ledger state of the network of the cached records.
+	// The default tracker of the process is used when nil.
+	Ledger *ledger.Tracker
+
+	lock    sync.Mutex
+	lru     *lru.Cache
+	hits    int64
+	lookups int64
+}
+
+// Metrics are the metrics of the lookups of a cache.
+type Metrics struct {
+	HitMeter     metrics.Meter
+	MissMeter    metrics.Meter
+	HitRateGauge metrics.GaugeFloat64
+}
+
+// entry is a cached record, with the sequence of the ledger it belongs to.
+type entry struct {
+	ledger int32
+	record interface{}
+}
+
+// New returns a cache of at most `size` records.
+func New(size int) *Cache {
+	return &Cache{
+		Metrics: Metrics{
+			HitMeter:     metrics.NewMeter(),
+			MissMeter:    metrics.NewMeter(),
+			HitRateGauge: metrics.NewGaugeFloat64(),
+		},
+		lru: lru.New(size),
+	}
+}
+
+// LedgerBySequence loads the ledger `seq` into dest, from the cache if
+// possible.
+func (c *Cache) LedgerBySequence(q *history.Q, dest *history.Ledger, seq int32) error {
+	key := fmt.Sprintf("ledger:%d", seq)
+	if c.get(key, dest) {
+		return nil
+	}
+
+	err := q.LedgerBySequence(dest, seq)
+	if err != nil {
+		return err
+	}
+
+	c.add(key, dest.Sequence, *dest)
+	return nil
+}
+
+// TransactionByHash loads the transaction `hash` into dest, from the cache if
+// possible.
+func (c *Cache) TransactionByHash(q *history.Q, dest *history.Transaction, hash string) error {
+	key := "transaction:" + hash
+	if c.get(key, dest) {
+		return nil
+	}
+
+	err := q.TransactionByHash(dest, hash)
+	if err != nil {
+		return err
+	}
+
+	c.add(key, dest.LedgerSequence, *dest)
+	return nil
+}
+
+// OperationByID loads the operation `id` into dest, from the cache if
+// possible.
+func (c *Cache) OperationByID(q *history.Q, dest *history.Operation, id int64) error {
+	key := fmt.Sprintf("operation:%d", id)
+	if c.get(key, dest) {
+		return nil
+	}
+
+	err := q.OperationByID(dest, id)
+	if err != nil {
+		return err
+	}
+
+	c.add(key, dest.LedgerSequence(), *dest)
+	return nil
+}
+
+// Purge evicts every cached record.
+func (c *Cache) Purge() {
+	if c == nil {
+		return
+	}
+
+	c.lock.Lock()
+	c.lru = lru.New(c.lru.MaxEntries)
+	c.lock.Unlock()
+}
+
+// get copies the record cached under key into dest, a pointer to a record of
+// the same type, and reports whether it was found.  Records of ledgers older
+// than the elder ledger of the history database are evicted.
+func (c *Cache) get(key string, dest interface{}) bool {
+	if c == nil {
+		return false
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	value, ok := c.lru.Get(key)
+	if ok && value.(entry).ledger < c.Ledger.CurrentState().HistoryElder {
+		c.lru.Remove(key)
+		ok = false
+	}
+
+	c.lookups++
+	if ok {
+		c.hits++
+		c.Metrics.HitMeter.Mark(1)
+	} else {
+		c.Metrics.MissMeter.Mark(1)
+	}
+	c.Metrics.HitRateGauge.Update(float64(c.hits) / float64(c.lookups))
+
+	if !ok {
+		return false
+	}
+
+	switch dest := dest.(type) {
+	case *history.Ledger:
+		*dest = value.(entry).record.(history.Ledger)
+	case *history.Transaction:
+		*dest = value.(entry).record.(history.Transaction)
+	case *history.Operation:
+		*dest = value.(entry).record.(history.Operation)
+	default:
+		panic(fmt.Sprintf("cache: unexpected record type %T", dest))
+	}
+	return true
+}
+
+// add caches record under key.
+func (c *Cache) add(key string, seq int32, record interface{}) {
+	if c == nil {
+		return
+	}
+
+	c.lock.Lock()
+	c.lru.Add(key, entry{ledger: seq, record: record})
+	c.lock.Unlock()
+}
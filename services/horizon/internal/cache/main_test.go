@@ -0,0 +1,70 @@
+package cache
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stellar/go/services/horizon/internal/db2/history"
+	"github.com/stellar/go/services/horizon/internal/ledger"
+	"github.com/stellar/go/services/horizon/internal/test"
+	"github.com/stellar/go/support/db"
+)
+
+func TestCache(t *testing.T) {
+	tt := test.Start(t).Scenario("base")
+	defer tt.Finish()
+	q := &history.Q{Session: tt.HorizonSession()}
+	// queries through offline fail, so its lookups must hit the cache
+	offline := &history.Q{Session: &db.Session{}}
+
+	c := New(10)
+
+	var l history.Ledger
+	err := c.LedgerBySequence(q, &l, 3)
+	tt.Require.NoError(err)
+	tt.Assert.Equal(int32(3), l.Sequence)
+
+	var cached history.Ledger
+	err = c.LedgerBySequence(offline, &cached, 3)
+	tt.Require.NoError(err)
+	tt.Assert.Equal(l, cached)
+
+	var tx history.Transaction
+	err = c.TransactionByHash(q, &tx, "2374e99349b9ef7dba9a5db3339b78fda8f34777b1af33ba468ad5c0df946d4d")
+	tt.Require.NoError(err)
+
+	var cachedTx history.Transaction
+	err = c.TransactionByHash(offline, &cachedTx, tx.TransactionHash)
+	tt.Require.NoError(err)
+	tt.Assert.Equal(tx, cachedTx)
+
+	var op history.Operation
+	err = c.OperationByID(q, &op, 8589938689)
+	tt.Require.NoError(err)
+
+	var cachedOp history.Operation
+	err = c.OperationByID(offline, &cachedOp, op.ID)
+	tt.Require.NoError(err)
+	tt.Assert.Equal(op, cachedOp)
+
+	// missing records are not cached
+	err = c.LedgerBySequence(q, &l, 100000)
+	tt.Assert.Equal(sql.ErrNoRows, err)
+
+	tt.Assert.Equal(int64(3), c.Metrics.HitMeter.Count())
+	tt.Assert.Equal(int64(4), c.Metrics.MissMeter.Count())
+	tt.Assert.InDelta(3.0/7.0, c.Metrics.HitRateGauge.Value(), 0.001)
+
+	// records of reaped ledgers are no longer served
+	defer ledger.SetState(ledger.CurrentState())
+	ledger.SetState(ledger.State{HistoryElder: 4})
+	tt.Assert.Panics(func() {
+		c.LedgerBySequence(offline, &cached, 3)
+	})
+
+	// nil caches load every record
+	var none *Cache
+	err = none.LedgerBySequence(q, &l, 2)
+	tt.Require.NoError(err)
+	tt.Assert.Equal(int32(2), l.Sequence)
+}
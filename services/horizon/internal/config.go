@@ -1,10 +1,17 @@
 package horizon
 
 import (
+	"time"
+
 	"github.com/PuerkitoBio/throttled"
 	"github.com/sirupsen/logrus"
+	"github.com/stellar/go/services/horizon/internal/reap"
 )
 
+// DefaultShutdownTimeout is how long horizon waits to stop gracefully when
+// ShutdownTimeout is not configured.
+const DefaultShutdownTimeout = 10 * time.Second
+
 // Config is the configuration for horizon.  It get's populated by the
 // app's main function and is provided to NewApp.
 type Config struct {
@@ -40,4 +47,123 @@ type Config struct {
 	// SkipCursorUpdate causes the ingestor to skip reporting the "last imported
 	// ledger" state to stellar-core.
 	SkipCursorUpdate bool
+
+	// EnableWebSocket enables the /ws endpoint, which streams ledgers,
+	// transactions, operations and effects over WebSocket connections.
+	EnableWebSocket bool
+
+	// EnableAPIKeys causes the requests made with an API key to be limited by
+	// the limits of the key, loaded from redis, instead of by IP address.
+	EnableAPIKeys bool
+
+	// APIKeyStore, when set, validates the API keys instead of redis.
+	APIKeyStore APIKeyStore
+
+	// HistoryRetentionPolicies are the retention policies of the resources of
+	// the history database that are reaped earlier than their ledgers.
+	HistoryRetentionPolicies []reap.Policy
+
+	// HistoryReapInterval is the interval between two passes of the reaper.
+	// reap.DefaultInterval is used when zero.
+	HistoryReapInterval time.Duration
+
+	// EnableOrderBookGraph causes paths to be found in an in-memory graph of
+	// the order books, maintained by the ingestion system, instead of by
+	// querying the offers of stellar-core for each request.
+	EnableOrderBookGraph bool
+
+	// MaxPathLength is the maximum number of assets between the source and the
+	// destination assets of the paths found.  paths.DefaultMaxPathLength is
+	// used when zero.
+	MaxPathLength uint
+
+	// MaxPathResults is the maximum number of paths returned by a path
+	// finding request.  paths.DefaultMaxResults is used when zero.
+	MaxPathResults uint
+
+	// TxSubQueueSize is the maximum number of submissions buffered until their
+	// sequence number can be submitted.  sequence.DefaultMaxSize is used when
+	// zero.
+	TxSubQueueSize uint
+
+	// TxSubQueueTimeout is the time submissions with a future sequence number
+	// are held for without any transaction of their source account applying.
+	// sequence.DefaultTimeout is used when zero.
+	TxSubQueueTimeout time.Duration
+
+	// CORSAllowedOrigins are the origins allowed to make cross-origin
+	// requests, which may contain a `*` wildcard, e.g. `https://*.example.com`.
+	// Any origin is allowed when empty.
+	CORSAllowedOrigins []string
+
+	// CORSAllowedMethods are the methods allowed in cross-origin requests.
+	// GET and POST are allowed when empty.
+	CORSAllowedMethods []string
+
+	// CORSAllowedHeaders are the headers allowed in cross-origin requests.
+	// Any header is allowed when empty.
+	CORSAllowedHeaders []string
+
+	// CORSMaxAge is how long browsers may cache the responses to preflight
+	// requests.  Preflight responses are not cached when zero.
+	CORSMaxAge time.Duration
+
+	// EnableGraphQL enables the /graphql endpoint, which answers GraphQL
+	// queries for accounts, ledgers, transactions, operations and effects.
+	EnableGraphQL bool
+
+	// ReplicaDatabaseURL is the url of a read replica of the horizon
+	// database, to which the queries of requests are routed.  Ingestion, the
+	// reaper and transaction submission keep using DatabaseURL.
+	ReplicaDatabaseURL string
+
+	// ReplicaMaxLag is the number of ledgers the read replica may lag behind
+	// ingestion before queries are routed back to DatabaseURL.
+	ReplicaMaxLag uint
+
+	// HistoryCacheSize is the number of ledgers, transactions and operations
+	// kept in memory once loaded from the history database by their
+	// sequence, hash or id.  Records are not cached when zero.
+	HistoryCacheSize uint
+
+	// AdminPort is the port of the admin API, which is not served when zero.
+	AdminPort uint
+
+	// AdminToken is the bearer token authenticating the requests to the admin
+	// API.
+	AdminToken string
+
+	// ShutdownTimeout is how long horizon waits, once signaled to stop, for
+	// the requests in progress, the buffered transaction submissions and the
+	// ingestion session in progress to end.  DefaultShutdownTimeout is used
+	// when zero.
+	ShutdownTimeout time.Duration
+
+	// AuditSubmissions is where the submitted transactions are recorded for
+	// auditing: "db", to the audit_submissions table of the horizon database,
+	// or "log".  Submissions are not recorded when empty.
+	AuditSubmissions string
+
+	// AuditRetention is how long the submissions recorded to the horizon
+	// database are kept.  They are kept forever when zero.
+	AuditRetention time.Duration
+
+	// URLPrefix is the path prefix the network of the app is served under,
+	// e.g. "/testnet", when one process serves many networks.  Apps with a
+	// prefix track the ledger state of their network on their own, instead
+	// of in the default ledger state of the process.
+	URLPrefix string
+
+	// MaxPageSize is the maximum number of records clients may request in a
+	// single page.  db2.MaxPageSize is used when zero.
+	MaxPageSize uint
+
+	// RequestTimeout is how long requests may take before their database
+	// queries are cancelled.  Streams are never timed out, and requests are
+	// not timed out when zero.
+	RequestTimeout time.Duration
+
+	// RouteTimeouts overrides RequestTimeout for the routes it contains, keyed
+	// by pattern, e.g. "/paths".  A timeout of zero exempts its route.
+	RouteTimeouts map[string]time.Duration
 }
@@ -0,0 +1,63 @@
+// Package apiversion provides functions to support embedding and retrieving
+// the version of the API a request is served with from a go context tree.
+//
+// A version of the API fixes the operation and effect types its clients know
+// of.  When a protocol upgrade adds types, Latest is incremented and the new
+// types are recorded as introduced by it (see resource/operations and
+// resource/effects), so that clients pinned to an earlier version are served
+// them as unknown types, which they already handle, rather than breaking on
+// them.
+package apiversion
+
+import (
+	"fmt"
+	"strconv"
+
+	"golang.org/x/net/context"
+)
+
+// Latest is the latest version of the API, served to the requests that don't
+// ask for a specific one.
+const Latest = 1
+
+// Header is the header in which clients request a version of the API, and in
+// which horizon returns the version a response is served with.
+const Header = "X-API-Version"
+
+var key = 0
+
+// Context creates a context from the provided parent and the provided version
+// of the API.
+func Context(ctx context.Context, version int) context.Context {
+	return context.WithValue(ctx, &key, version)
+}
+
+// FromContext returns the version of the API set on the provided context,
+// defaulting to Latest if none has been set.
+func FromContext(ctx context.Context) int {
+	if ctx == nil {
+		return Latest
+	}
+
+	version, ok := ctx.Value(&key).(int)
+	if !ok {
+		return Latest
+	}
+
+	return version
+}
+
+// Parse parses the version of the API requested in `s`, returning Latest
+// when empty.
+func Parse(s string) (int, error) {
+	if s == "" {
+		return Latest, nil
+	}
+
+	version, err := strconv.Atoi(s)
+	if err != nil || version < 1 || version > Latest {
+		return 0, fmt.Errorf("unsupported api version %q, expected 1 to %d", s, Latest)
+	}
+
+	return version, nil
+}
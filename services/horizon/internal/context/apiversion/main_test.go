@@ -0,0 +1,33 @@
+package apiversion
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"golang.org/x/net/context"
+)
+
+func TestAPIVersion(t *testing.T) {
+	Convey("apiversion.FromContext", t, func() {
+		So(FromContext(nil), ShouldEqual, Latest)
+		So(FromContext(context.Background()), ShouldEqual, Latest)
+
+		ctx := Context(context.Background(), 1)
+		So(FromContext(ctx), ShouldEqual, 1)
+	})
+
+	Convey("apiversion.Parse", t, func() {
+		version, err := Parse("")
+		So(err, ShouldBeNil)
+		So(version, ShouldEqual, Latest)
+
+		version, err = Parse("1")
+		So(err, ShouldBeNil)
+		So(version, ShouldEqual, 1)
+
+		for _, s := range []string{"0", "-1", "v1", "1.0", "999"} {
+			_, err = Parse(s)
+			So(err, ShouldNotBeNil)
+		}
+	})
+}
@@ -4,8 +4,14 @@ import (
 	"encoding/base64"
 
 	sq "github.com/Masterminds/squirrel"
+	"github.com/stellar/go/services/horizon/internal/db2"
 )
 
+// PagingToken returns a suitable paging token for the AccountData
+func (ad AccountData) PagingToken() string {
+	return ad.Key
+}
+
 // Raw returns the decoded, raw value of the account data
 func (ad AccountData) Raw() ([]byte, error) {
 	return base64.StdEncoding.DecodeString(ad.Value)
@@ -26,6 +32,25 @@ func (q *Q) AllDataByAddress(dest interface{}, addy string) error {
 	return q.Select(dest, sql)
 }
 
+// DataByAddress loads a page of the data entries of `addy`, ordered by key.
+func (q *Q) DataByAddress(dest interface{}, addy string, pq db2.PageQuery) error {
+	sql := selectAccountData.Where("ad.accountid = ?", addy)
+
+	// keys are never empty, so that an empty cursor starts ascending pages
+	// from the first key, but must not bound descending pages
+	var err error
+	if pq.Order == db2.OrderDescending && pq.Cursor == "" {
+		sql = sql.Limit(pq.Limit).OrderBy("ad.dataname desc")
+	} else {
+		sql, err = pq.ApplyToUsingCursor(sql, "ad.dataname", pq.Cursor)
+		if err != nil {
+			return err
+		}
+	}
+
+	return q.Select(dest, sql)
+}
+
 var selectAccountData = sq.Select(
 	"ad.accountid",
 	"ad.dataname",
@@ -0,0 +1,35 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stellar/go/services/horizon/internal/db2"
+	"github.com/stellar/go/services/horizon/internal/test"
+)
+
+func TestDataByAddress(t *testing.T) {
+	tt := test.Start(t).Scenario("kahuna")
+	defer tt.Finish()
+	q := &Q{tt.CoreSession()}
+
+	const addy = "GAYSCMKQY6EYLXOPTT6JPPOXDMVNBWITPTSZIVWW4LWARVBOTH5RTLAD"
+
+	load := func(cursor, order string, limit uint64) []string {
+		var data []AccountData
+		err := q.DataByAddress(&data, addy, db2.MustPageQuery(cursor, order, limit))
+		tt.Require.NoError(err)
+
+		keys := make([]string, len(data))
+		for i, d := range data {
+			keys[i] = d.Key
+		}
+		return keys
+	}
+
+	tt.Assert.Equal([]string{"name ", "name1"}, load("", "asc", 10))
+	tt.Assert.Equal([]string{"name1", "name "}, load("", "desc", 10))
+	tt.Assert.Equal([]string{"name "}, load("", "asc", 1))
+	tt.Assert.Equal([]string{"name1"}, load("name ", "asc", 10))
+	tt.Assert.Equal([]string{"name "}, load("name1", "desc", 10))
+	tt.Assert.Len(load("name1", "asc", 10), 0)
+}
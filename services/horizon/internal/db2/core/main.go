@@ -126,6 +126,11 @@ type Trustline struct {
 	Flags     int32
 }
 
+// ValidationProvider implements `txsub.ValidationProvider`
+type ValidationProvider struct {
+	Q *Q
+}
+
 // AssetFromDB produces an xdr.Asset by combining the constituent type, code and
 // issuer, as often retrieved from the DB in 3 separate columns.
 func AssetFromDB(typ xdr.AssetType, code string, issuer string) (result xdr.Asset, err error) {
@@ -100,3 +100,18 @@ func (q *Q) OffersByAddress(dest interface{}, addy string, pq db2.PageQuery) err
 
 	return q.Select(dest, sql)
 }
+
+// LatestOfferID loads into `dest` the id of the latest offer created, or 0 if
+// there are no active offers.
+func (q *Q) LatestOfferID(dest *int64) error {
+	return q.GetRaw(dest, `SELECT COALESCE(MAX(offerid), 0) FROM offers`)
+}
+
+// AllOffers loads all the active offers, ordered by price.
+func (q *Q) AllOffers(dest interface{}) error {
+	sql := sq.Select("co.*").
+		From("offers co").
+		OrderBy("co.price asc", "co.offerid asc")
+
+	return q.Select(dest, sql)
+}
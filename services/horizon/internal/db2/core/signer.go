@@ -10,6 +10,12 @@ func (q *Q) SignersByAddress(dest interface{}, addy string) error {
 	return q.Select(dest, sql)
 }
 
+// SignersByAddresses loads all signer rows for the accounts of `addys`
+func (q *Q) SignersByAddresses(dest interface{}, addys []string) error {
+	sql := selectSigner.Where(sq.Eq{"accountid": addys})
+	return q.Select(dest, sql)
+}
+
 var selectSigner = sq.Select(
 	"si.accountid",
 	"si.publickey",
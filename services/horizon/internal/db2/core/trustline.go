@@ -43,6 +43,12 @@ func (q *Q) TrustlinesByAddress(dest interface{}, addy string) error {
 	return q.Select(dest, sql)
 }
 
+// TrustlinesByAddresses loads all trustlines for the accounts of `addys`
+func (q *Q) TrustlinesByAddresses(dest interface{}, addys []string) error {
+	sql := selectTrustline.Where(sq.Eq{"accountid": addys})
+	return q.Select(dest, sql)
+}
+
 // BalancesForAsset returns all the balances by asset type, code, issuer
 func (q *Q) BalancesForAsset(
 	assetType int32,
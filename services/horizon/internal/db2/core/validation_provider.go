@@ -0,0 +1,51 @@
+package core
+
+import (
+	sq "github.com/Masterminds/squirrel"
+	"github.com/stellar/go/xdr"
+)
+
+// ValidationProvider returns a new validation provider.
+func (q *Q) ValidationProvider() *ValidationProvider {
+	return &ValidationProvider{Q: q}
+}
+
+// BaseFee implements `txsub.ValidationProvider`
+func (vp *ValidationProvider) BaseFee() (int32, error) {
+	var header LedgerHeader
+	sql := sq.Select("clh.*").
+		From("ledgerheaders clh").
+		OrderBy("clh.ledgerseq DESC").
+		Limit(1)
+
+	err := vp.Q.Get(&header, sql)
+	if err != nil {
+		return 0, err
+	}
+
+	return int32(header.Data.BaseFee), nil
+}
+
+// Signers implements `txsub.ValidationProvider`
+func (vp *ValidationProvider) Signers(address string) (xdr.Thresholds, map[string]int32, error) {
+	var account Account
+	err := vp.Q.AccountByAddress(&account, address)
+	if err != nil {
+		return xdr.Thresholds{}, nil, err
+	}
+
+	var rows []Signer
+	err = vp.Q.SignersByAddress(&rows, address)
+	if err != nil {
+		return xdr.Thresholds{}, nil, err
+	}
+
+	signers := map[string]int32{
+		address: int32(account.Thresholds[0]),
+	}
+	for _, row := range rows {
+		signers[row.Publickey] = row.Weight
+	}
+
+	return account.Thresholds, signers, nil
+}
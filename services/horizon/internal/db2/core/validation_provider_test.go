@@ -0,0 +1,41 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stellar/go/services/horizon/internal/test"
+)
+
+func TestValidationProvider(t *testing.T) {
+	tt := test.Start(t).Scenario("base")
+	defer tt.Finish()
+	vp := (&Q{tt.CoreSession()}).ValidationProvider()
+
+	fee, err := vp.BaseFee()
+	if tt.Assert.NoError(err) {
+		tt.Assert.Equal(int32(100), fee)
+	}
+
+	address := "GBRPYHIL2CI3FNQ4BXLFMNDLFJUNPU2HY3ZMFSHONUCEOASW7QC7OX2H"
+	thresholds, signers, err := vp.Signers(address)
+	if tt.Assert.NoError(err) {
+		tt.Assert.Equal(byte(1), thresholds[0])
+		tt.Assert.Equal(map[string]int32{address: 1}, signers)
+	}
+
+	_, _, err = vp.Signers("GCYLTPOU7IVYHHA3XKQF4YB4W4ZWHFERMOQ7K47IWANKNBFBNJJNEOG5")
+	tt.Assert.Error(err)
+}
+
+func TestValidationProvider_Signers(t *testing.T) {
+	tt := test.Start(t).Scenario("kahuna")
+	defer tt.Finish()
+	vp := (&Q{tt.CoreSession()}).ValidationProvider()
+
+	address := "GDXFAGJCSCI4CK2YHK6YRLA6TKEXFRX7BMGVMQOBMLIEUJRJ5YQNLMIB"
+	_, signers, err := vp.Signers(address)
+	if tt.Assert.NoError(err) {
+		tt.Assert.Len(signers, 2)
+		tt.Assert.Equal(int32(1), signers["GD3E7HKMRNT6HGBGHBT6I6JE4N2S4W5KZ246TGJ4KQSXJ2P4BXCUPQMP"])
+	}
+}
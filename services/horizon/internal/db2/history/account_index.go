@@ -0,0 +1,59 @@
+package history
+
+import (
+	sq "github.com/Masterminds/squirrel"
+	"github.com/stellar/go/services/horizon/internal/db2"
+	"github.com/stellar/go/xdr"
+)
+
+// AccountsBySigner loads a page of the addresses of the accounts `signer` is
+// a signer of, from the `accounts_signers` table, ordered by address.  The
+// master key of an account is not one of its indexed signers.
+func (q *Q) AccountsBySigner(dest interface{}, signer string, pq db2.PageQuery) error {
+	sql := sq.Select("acs.account").
+		From("accounts_signers acs").
+		Where("acs.signer = ?", signer)
+
+	return q.selectAccountsPage(dest, sql, "acs.account", pq)
+}
+
+// AccountsByAsset loads a page of the addresses of the accounts trusting
+// `asset`, from the `accounts_trustlines` table, ordered by address.
+func (q *Q) AccountsByAsset(dest interface{}, asset xdr.Asset, pq db2.PageQuery) error {
+	var (
+		typ    xdr.AssetType
+		code   string
+		issuer string
+	)
+	err := asset.Extract(&typ, &code, &issuer)
+	if err != nil {
+		return err
+	}
+
+	sql := sq.Select("act.account").
+		From("accounts_trustlines act").
+		Where(sq.Eq{
+			"act.asset_type":   typ,
+			"act.asset_code":   code,
+			"act.asset_issuer": issuer,
+		})
+
+	return q.selectAccountsPage(dest, sql, "act.account", pq)
+}
+
+// selectAccountsPage loads the page `pq` of the addresses selected by `sql`.
+func (q *Q) selectAccountsPage(dest interface{}, sql sq.SelectBuilder, col string, pq db2.PageQuery) error {
+	// addresses are never empty, so that an empty cursor starts ascending pages
+	// from the first address, but must not bound descending pages
+	var err error
+	if pq.Order == db2.OrderDescending && pq.Cursor == "" {
+		sql = sql.Limit(pq.Limit).OrderBy(col + " desc")
+	} else {
+		sql, err = pq.ApplyToUsingCursor(sql, col, pq.Cursor)
+		if err != nil {
+			return err
+		}
+	}
+
+	return q.Select(dest, sql)
+}
@@ -0,0 +1,58 @@
+package history
+
+import (
+	"testing"
+
+	"github.com/stellar/go/services/horizon/internal/db2"
+	"github.com/stellar/go/services/horizon/internal/test"
+	"github.com/stellar/go/xdr"
+)
+
+func TestAccountIndexQueries(t *testing.T) {
+	tt := test.Start(t).Scenario("base")
+	defer tt.Finish()
+	q := &Q{tt.HorizonSession()}
+
+	signer := "GCXKG6RN4ONIEPCMNFB732A436Z5PNDSRLGWK7GBLCMQLIFO4S7EYWVU"
+	issuer := "GA5WBPYA5Y4WAEHXWR2UKO2UO4BUGHUQ74EUPKON2QHV4WRHOIRNKKH2"
+	for _, account := range []string{"GB", "GA", "GC"} {
+		_, err := q.ExecRaw(
+			`INSERT INTO accounts_signers (account, signer, weight) VALUES (?, ?, 1)`,
+			account, signer,
+		)
+		tt.Require.NoError(err)
+		_, err = q.ExecRaw(
+			`INSERT INTO accounts_trustlines (account, asset_type, asset_code, asset_issuer) VALUES (?, 1, 'USD', ?)`,
+			account, issuer,
+		)
+		tt.Require.NoError(err)
+	}
+
+	var accounts []string
+	err := q.AccountsBySigner(&accounts, signer, db2.MustPageQuery("", "asc", 2))
+	tt.Require.NoError(err)
+	tt.Assert.Equal([]string{"GA", "GB"}, accounts)
+
+	err = q.AccountsBySigner(&accounts, signer, db2.MustPageQuery("GB", "asc", 2))
+	tt.Require.NoError(err)
+	tt.Assert.Equal([]string{"GC"}, accounts)
+
+	err = q.AccountsBySigner(&accounts, signer, db2.MustPageQuery("", "desc", 10))
+	tt.Require.NoError(err)
+	tt.Assert.Equal([]string{"GC", "GB", "GA"}, accounts)
+
+	var issuerID xdr.AccountId
+	tt.Require.NoError(issuerID.SetAddress(issuer))
+
+	var usd, eur xdr.Asset
+	tt.Require.NoError(usd.SetCredit("USD", issuerID))
+	tt.Require.NoError(eur.SetCredit("EUR", issuerID))
+
+	err = q.AccountsByAsset(&accounts, usd, db2.MustPageQuery("GA", "asc", 10))
+	tt.Require.NoError(err)
+	tt.Assert.Equal([]string{"GB", "GC"}, accounts)
+
+	err = q.AccountsByAsset(&accounts, eur, db2.MustPageQuery("", "asc", 10))
+	tt.Require.NoError(err)
+	tt.Assert.Empty(accounts)
+}
@@ -27,6 +27,38 @@ func (q *Q) GetAssetIDs(assets []xdr.Asset) ([]int64, error) {
 	return ids, err
 }
 
+// GetAssetsForIssuer fetches the assets issued by the account issuer that are
+// recorded in the history_assets table
+func (q *Q) GetAssetsForIssuer(issuer string) ([]xdr.Asset, error) {
+	var issuerID xdr.AccountId
+	err := issuerID.SetAddress(issuer)
+	if err != nil {
+		return nil, err
+	}
+
+	sql := sq.Select("id", "asset_type", "asset_code", "asset_issuer").
+		From("history_assets").
+		Where(sq.Eq{"asset_issuer": issuer}).
+		OrderBy("id ASC")
+
+	var rows []Asset
+	err = q.Select(&rows, sql)
+	if err != nil {
+		return nil, err
+	}
+
+	assets := make([]xdr.Asset, 0, len(rows))
+	for _, row := range rows {
+		var asset xdr.Asset
+		err = asset.SetCredit(row.Code, issuerID)
+		if err != nil {
+			return nil, err
+		}
+		assets = append(assets, asset)
+	}
+	return assets, nil
+}
+
 // GetAssetID fetches the id for an Asset. If fetching multiple values, look at GetAssetIDs
 func (q *Q) GetAssetID(asset xdr.Asset) (id int64, err error) {
 	var (
@@ -148,6 +148,11 @@ func (q *EffectsQ) Page(page db2.PageQuery) *EffectsQ {
 		return q
 	}
 
+	q.Err = page.Validate()
+	if q.Err != nil {
+		return q
+	}
+
 	op, idx, err := page.CursorInt64Pair(db2.DefaultPairSep)
 	if err != nil {
 		q.Err = err
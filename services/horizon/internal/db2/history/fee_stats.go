@@ -0,0 +1,34 @@
+package history
+
+// FeeStats loads into `dest` the statistics of the fees per operation
+// offered by the transactions included in the `ledgers` ledgers ending with
+// the ledger `latest`, as well as the share of their capacity that was used.
+func (q *Q) FeeStats(dest *FeeStats, latest int32, ledgers int32) error {
+	return q.GetRaw(dest, `
+		SELECT
+			COALESCE(MIN(fees.fee), 0) AS min,
+			COALESCE(mode() WITHIN GROUP (ORDER BY fees.fee), 0) AS mode,
+			COALESCE(percentile_disc(0.10) WITHIN GROUP (ORDER BY fees.fee), 0) AS p10,
+			COALESCE(percentile_disc(0.20) WITHIN GROUP (ORDER BY fees.fee), 0) AS p20,
+			COALESCE(percentile_disc(0.30) WITHIN GROUP (ORDER BY fees.fee), 0) AS p30,
+			COALESCE(percentile_disc(0.40) WITHIN GROUP (ORDER BY fees.fee), 0) AS p40,
+			COALESCE(percentile_disc(0.50) WITHIN GROUP (ORDER BY fees.fee), 0) AS p50,
+			COALESCE(percentile_disc(0.60) WITHIN GROUP (ORDER BY fees.fee), 0) AS p60,
+			COALESCE(percentile_disc(0.70) WITHIN GROUP (ORDER BY fees.fee), 0) AS p70,
+			COALESCE(percentile_disc(0.80) WITHIN GROUP (ORDER BY fees.fee), 0) AS p80,
+			COALESCE(percentile_disc(0.90) WITHIN GROUP (ORDER BY fees.fee), 0) AS p90,
+			COALESCE(percentile_disc(0.95) WITHIN GROUP (ORDER BY fees.fee), 0) AS p95,
+			COALESCE(percentile_disc(0.99) WITHIN GROUP (ORDER BY fees.fee), 0) AS p99,
+			(
+				SELECT COALESCE(SUM(transaction_count)::float / NULLIF(SUM(max_tx_set_size), 0), 0)
+				FROM history_ledgers
+				WHERE sequence > $1 AND sequence <= $2
+			) AS capacity_usage
+		FROM (
+			SELECT fee_paid / operation_count AS fee
+			FROM history_transactions
+			WHERE ledger_sequence > $1 AND ledger_sequence <= $2
+			AND operation_count > 0
+		) AS fees
+	`, latest-ledgers, latest)
+}
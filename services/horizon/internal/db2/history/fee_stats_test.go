@@ -0,0 +1,31 @@
+package history
+
+import (
+	"testing"
+
+	"github.com/stellar/go/services/horizon/internal/test"
+)
+
+func TestFeeStatsQueries(t *testing.T) {
+	tt := test.Start(t).Scenario("base")
+	defer tt.Finish()
+	q := &Q{tt.HorizonSession()}
+
+	var stats FeeStats
+	err := q.FeeStats(&stats, 3, 5)
+	if tt.Assert.NoError(err) {
+		tt.Assert.Equal(int32(100), stats.Min)
+		tt.Assert.Equal(int32(100), stats.Mode)
+		tt.Assert.Equal(int32(100), stats.P10)
+		tt.Assert.Equal(int32(100), stats.P99)
+		tt.Assert.InDelta(4.0/20100.0, stats.CapacityUsage, 0.000001)
+	}
+
+	// ledgers without transactions
+	err = q.FeeStats(&stats, 1, 1)
+	if tt.Assert.NoError(err) {
+		tt.Assert.Equal(int32(0), stats.Min)
+		tt.Assert.Equal(int32(0), stats.P50)
+		tt.Assert.Equal(float64(0), stats.CapacityUsage)
+	}
+}
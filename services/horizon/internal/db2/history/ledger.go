@@ -2,10 +2,14 @@ package history
 
 import (
 	"fmt"
+	"math"
+	"time"
 
 	sq "github.com/Masterminds/squirrel"
 	"github.com/stellar/go/services/horizon/internal/db2"
+	"github.com/stellar/go/services/horizon/internal/toid"
 	"github.com/stellar/go/support/errors"
+	sTime "github.com/stellar/go/support/time"
 )
 
 // LedgerBySequence loads the single ledger at `seq` into `dest`
@@ -17,6 +21,86 @@ func (q *Q) LedgerBySequence(dest interface{}, seq int32) error {
 	return q.Get(dest, sql)
 }
 
+// LedgerGaps loads the ranges of ledgers missing between the elder and the
+// latest ledgers of the history database into `dest`, in ascending order.
+func (q *Q) LedgerGaps(dest interface{}) error {
+	return q.SelectRaw(dest, `
+		SELECT prev + 1 AS start_sequence, sequence - 1 AS end_sequence
+		FROM (
+			SELECT sequence, lag(sequence) OVER (ORDER BY sequence) AS prev
+			FROM history_ledgers
+		) hl
+		WHERE sequence - prev > 1
+		ORDER BY sequence ASC`)
+}
+
+// BrokenLedgerChain loads into `dest` the sequences of the ledgers from
+// `start` to `end`, inclusive, that are missing from the history database or
+// that don't follow the previous ledger, i.e. whose previous ledger hash is not
+// the hash of the previous ledger.  At most 1000 sequences are loaded.
+func (q *Q) BrokenLedgerChain(dest interface{}, start, end int32) error {
+	return q.SelectRaw(dest, `
+		SELECT s.sequence
+		FROM generate_series(?::int, ?::int) AS s(sequence)
+		LEFT JOIN history_ledgers hl ON hl.sequence = s.sequence
+		LEFT JOIN history_ledgers prev ON prev.sequence = s.sequence - 1
+		WHERE hl.sequence IS NULL
+		OR (s.sequence > ? AND prev.ledger_hash IS DISTINCT FROM hl.previous_ledger_hash)
+		ORDER BY s.sequence ASC
+		LIMIT 1000`, start, end, start)
+}
+
+// ElderLedgerClosedSince loads into `dest` the sequence of the oldest ledger
+// of the history database closed at or after `t`, or 0 if no ledger was closed
+// since then.
+func (q *Q) ElderLedgerClosedSince(dest *int32, t time.Time) error {
+	return q.GetRaw(dest, `
+		SELECT COALESCE(MIN(sequence), 0)
+		FROM history_ledgers
+		WHERE closed_at >= ?`, t.UTC())
+}
+
+// ledgerIDRange returns the range, from inclusive to exclusive, of the ids of
+// the records of the ledgers closed at or after `start` and before `end`.  A
+// nil `start` or `end` leaves the range unbounded on that side.  Since ledgers
+// close in sequence, the range is found through the index on their close time
+// and can then be matched against the primary keys of the history tables.
+func (q *Q) ledgerIDRange(start, end sTime.Millis) (from int64, to int64, err error) {
+	to = math.MaxInt64
+
+	if !start.IsNil() {
+		var seq int32
+		err = q.ElderLedgerClosedSince(&seq, start.ToTime())
+		if err != nil {
+			return 0, 0, errors.Wrap(err, "load start ledger failed")
+		}
+
+		// no ledger closed since start
+		if seq == 0 {
+			return 0, 0, nil
+		}
+
+		id := toid.ID{LedgerSequence: seq}
+		from = id.ToInt64()
+	}
+
+	if !end.IsNil() {
+		var seq int32
+		err = q.GetRaw(&seq, `
+			SELECT COALESCE(MAX(sequence), 0)
+			FROM history_ledgers
+			WHERE closed_at < ?`, end.ToTime())
+		if err != nil {
+			return 0, 0, errors.Wrap(err, "load end ledger failed")
+		}
+
+		id := toid.ID{LedgerSequence: seq + 1}
+		to = id.ToInt64()
+	}
+
+	return from, to, nil
+}
+
 // Ledgers provides a helper to filter rows from the `history_ledgers` table
 // with pre-defined filters.  See `LedgersQ` methods for the available filters.
 func (q *Q) Ledgers() *LedgersQ {
@@ -2,9 +2,13 @@ package history
 
 import (
 	"database/sql"
+	"math"
 	"testing"
+	"time"
 
 	"github.com/stellar/go/services/horizon/internal/test"
+	"github.com/stellar/go/services/horizon/internal/toid"
+	sTime "github.com/stellar/go/support/time"
 )
 
 func TestLedgerQueries(t *testing.T) {
@@ -44,3 +48,116 @@ func TestLedgerQueries(t *testing.T) {
 		tt.Assert.Contains(foundSeqs, int32(3))
 	}
 }
+
+func TestBrokenLedgerChain(t *testing.T) {
+	tt := test.Start(t).Scenario("base")
+	defer tt.Finish()
+	q := &Q{tt.HorizonSession()}
+
+	var broken []int32
+	err := q.BrokenLedgerChain(&broken, 1, 3)
+	if tt.Assert.NoError(err) {
+		tt.Assert.Len(broken, 0)
+	}
+
+	// sequences beyond the latest ledger are missing
+	err = q.BrokenLedgerChain(&broken, 2, 4)
+	if tt.Assert.NoError(err) {
+		tt.Assert.Equal([]int32{4}, broken)
+	}
+
+	_, err = tt.HorizonSession().ExecRaw(
+		`UPDATE history_ledgers SET previous_ledger_hash = 'x' WHERE sequence = 3`,
+	)
+	tt.Require.NoError(err)
+
+	err = q.BrokenLedgerChain(&broken, 1, 3)
+	if tt.Assert.NoError(err) {
+		tt.Assert.Equal([]int32{3}, broken)
+	}
+}
+
+func TestLedgerGaps(t *testing.T) {
+	tt := test.Start(t).Scenario("base")
+	defer tt.Finish()
+	q := &Q{tt.HorizonSession()}
+
+	var gaps []LedgerRange
+	err := q.LedgerGaps(&gaps)
+	if tt.Assert.NoError(err) {
+		tt.Assert.Len(gaps, 0)
+	}
+
+	_, err = tt.HorizonSession().ExecRaw(`DELETE FROM history_ledgers WHERE sequence = 2`)
+	tt.Require.NoError(err)
+
+	err = q.LedgerGaps(&gaps)
+	if tt.Assert.NoError(err) {
+		tt.Assert.Equal([]LedgerRange{{Start: 2, End: 2}}, gaps)
+		tt.Assert.EqualValues(1, gaps[0].Count())
+	}
+}
+
+func TestElderLedgerClosedSince(t *testing.T) {
+	tt := test.Start(t).Scenario("base")
+	defer tt.Finish()
+	q := &Q{tt.HorizonSession()}
+
+	var l Ledger
+	err := q.LedgerBySequence(&l, 2)
+	tt.Require.NoError(err)
+
+	var seq int32
+	err = q.ElderLedgerClosedSince(&seq, l.ClosedAt)
+	if tt.Assert.NoError(err) {
+		tt.Assert.Equal(int32(2), seq)
+	}
+
+	err = q.ElderLedgerClosedSince(&seq, l.ClosedAt.Add(time.Millisecond))
+	if tt.Assert.NoError(err) {
+		tt.Assert.Equal(int32(3), seq)
+	}
+
+	err = q.ElderLedgerClosedSince(&seq, l.ClosedAt.Add(time.Hour))
+	if tt.Assert.NoError(err) {
+		tt.Assert.Equal(int32(0), seq)
+	}
+}
+
+func TestLedgerIDRange(t *testing.T) {
+	tt := test.Start(t).Scenario("base")
+	defer tt.Finish()
+	q := &Q{tt.HorizonSession()}
+
+	var l Ledger
+	err := q.LedgerBySequence(&l, 3)
+	tt.Require.NoError(err)
+	closedAt := sTime.MillisFromSeconds(l.ClosedAt.Unix())
+
+	ledger3 := toid.ID{LedgerSequence: 3}
+	ledger4 := toid.ID{LedgerSequence: 4}
+
+	from, to, err := q.ledgerIDRange(closedAt, sTime.Millis(0))
+	if tt.Assert.NoError(err) {
+		tt.Assert.Equal(ledger3.ToInt64(), from)
+		tt.Assert.Equal(int64(math.MaxInt64), to)
+	}
+
+	from, to, err = q.ledgerIDRange(sTime.Millis(0), closedAt)
+	if tt.Assert.NoError(err) {
+		tt.Assert.Equal(int64(0), from)
+		tt.Assert.Equal(ledger3.ToInt64(), to)
+	}
+
+	from, to, err = q.ledgerIDRange(closedAt, closedAt+1)
+	if tt.Assert.NoError(err) {
+		tt.Assert.Equal(ledger3.ToInt64(), from)
+		tt.Assert.Equal(ledger4.ToInt64(), to)
+	}
+
+	// no ledger closed since the start
+	from, to, err = q.ledgerIDRange(closedAt+1, sTime.Millis(0))
+	if tt.Assert.NoError(err) {
+		tt.Assert.Equal(from, to)
+	}
+}
@@ -156,6 +156,25 @@ type EffectsQ struct {
 // `history_effects` table.
 type EffectType int
 
+// FeeStats is a row of data from the aggregation of the fees per operation
+// offered by the transactions of recent ledgers.  See `Q.FeeStats`.
+type FeeStats struct {
+	Min           int32   `db:"min"`
+	Mode          int32   `db:"mode"`
+	P10           int32   `db:"p10"`
+	P20           int32   `db:"p20"`
+	P30           int32   `db:"p30"`
+	P40           int32   `db:"p40"`
+	P50           int32   `db:"p50"`
+	P60           int32   `db:"p60"`
+	P70           int32   `db:"p70"`
+	P80           int32   `db:"p80"`
+	P90           int32   `db:"p90"`
+	P95           int32   `db:"p95"`
+	P99           int32   `db:"p99"`
+	CapacityUsage float64 `db:"capacity_usage"`
+}
+
 // Ledger is a row of data from the `history_ledgers` table
 type Ledger struct {
 	TotalOrderID
@@ -177,6 +196,17 @@ type Ledger struct {
 	LedgerHeaderXDR    null.String `db:"ledger_header"`
 }
 
+// LedgerRange is a range of ledger sequences, inclusive.
+type LedgerRange struct {
+	Start int32 `db:"start_sequence"`
+	End   int32 `db:"end_sequence"`
+}
+
+// Count returns the number of ledgers in the range.
+func (r LedgerRange) Count() int32 {
+	return r.End - r.Start + 1
+}
+
 // LedgerCache is a helper struct to load ledger data related to a batch of
 // sequences.
 type LedgerCache struct {
@@ -279,6 +309,15 @@ type Transaction struct {
 	UpdatedAt        time.Time   `db:"updated_at"`
 }
 
+// TransactionCache is a helper struct to load transaction data related to a
+// batch of operations.
+type TransactionCache struct {
+	Records map[int64]Transaction
+
+	lock   sync.Mutex
+	queued map[int64]struct{}
+}
+
 // TransactionsQ is a helper struct to aid in configuring queries that loads
 // slices of transaction structs.
 type TransactionsQ struct {
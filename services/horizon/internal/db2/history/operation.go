@@ -7,6 +7,7 @@ import (
 	"github.com/go-errors/errors"
 	"github.com/stellar/go/services/horizon/internal/db2"
 	"github.com/stellar/go/services/horizon/internal/toid"
+	"github.com/stellar/go/support/time"
 	"github.com/stellar/go/xdr"
 )
 
@@ -118,6 +119,35 @@ func (q *OperationsQ) OnlyPayments() *OperationsQ {
 	return q
 }
 
+// OfTypes filters the query being built to only include operations of one of
+// the provided types.
+func (q *OperationsQ) OfTypes(types ...xdr.OperationType) *OperationsQ {
+	q.sql = q.sql.Where(sq.Eq{"hop.type": types})
+	return q
+}
+
+// ForTimeRange filters the query to only operations in the ledgers closed at
+// or after `start` and before `end`, a nil time leaving the range unbounded on
+// its side.
+func (q *OperationsQ) ForTimeRange(start, end time.Millis) *OperationsQ {
+	if q.Err != nil {
+		return q
+	}
+
+	if start.IsNil() && end.IsNil() {
+		return q
+	}
+
+	from, to, err := q.parent.ledgerIDRange(start, end)
+	if err != nil {
+		q.Err = err
+		return q
+	}
+
+	q.sql = q.sql.Where("hop.id >= ? AND hop.id < ?", from, to)
+	return q
+}
+
 // Page specifies the paging constraints for the query being built by `q`.
 func (q *OperationsQ) Page(page db2.PageQuery) *OperationsQ {
 	if q.Err != nil {
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/stellar/go/services/horizon/internal/test"
+	"github.com/stellar/go/xdr"
 )
 
 func TestOperationQueries(t *testing.T) {
@@ -46,6 +47,23 @@ func TestOperationQueries(t *testing.T) {
 		tt.Assert.Len(ops, 1)
 	}
 
+	// type filter works
+	ops = []Operation{}
+	err = q.Operations().OfTypes(xdr.OperationTypePayment).Select(&ops)
+
+	if tt.Assert.NoError(err) {
+		tt.Assert.Len(ops, 1)
+	}
+
+	ops = []Operation{}
+	err = q.Operations().
+		OfTypes(xdr.OperationTypePayment, xdr.OperationTypeCreateAccount).
+		Select(&ops)
+
+	if tt.Assert.NoError(err) {
+		tt.Assert.Len(ops, 4)
+	}
+
 	// payment filter works
 	tt.Scenario("pathed_payment")
 	ops = []Operation{}
@@ -55,6 +55,23 @@ func (q *TradesQ) ForOffer(id int64) *TradesQ {
 	return q
 }
 
+// ForAccount filters the query results to the trades the account is a party
+// of, either as the owner of the offer or as the counterparty.
+func (q *TradesQ) ForAccount(aid string) *TradesQ {
+	var account Account
+	q.Err = q.parent.AccountByAddress(&account, aid)
+	if q.Err != nil {
+		return q
+	}
+
+	q.sql = q.sql.Where(
+		"(htrd.base_account_id = ? OR htrd.counter_account_id = ?)",
+		account.ID,
+		account.ID,
+	)
+	return q
+}
+
 //Filter by asset pair. This function is private to ensure that correct order and proper select statement are coupled
 func (q *TradesQ) forAssetPair(baseAssetId int64, counterAssetId int64) *TradesQ {
 	q.sql = q.sql.Where(sq.Eq{"base_asset_id": baseAssetId, "counter_asset_id": counterAssetId})
@@ -72,6 +89,11 @@ func (q *TradesQ) Page(page db2.PageQuery) *TradesQ {
 		return q
 	}
 
+	q.Err = page.Validate()
+	if q.Err != nil {
+		return q
+	}
+
 	op, idx, err := page.CursorInt64Pair(db2.DefaultPairSep)
 	if err != nil {
 		q.Err = err
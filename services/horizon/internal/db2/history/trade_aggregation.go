@@ -13,12 +13,20 @@ import (
 
 var AllowedResolutions = map[time.Duration]struct{}{
 	time.Minute:        {}, //1 minute
+	time.Minute * 5:    {}, //5 minutes
 	time.Minute * 15:   {}, //15 minutes
 	time.Hour:          {}, //1 hour
 	time.Hour * 24:     {}, //day
 	time.Hour * 24 * 7: {}, //week
 }
 
+// IsAllowedResolution returns true if trades can be aggregated in buckets of
+// resolution milliseconds.
+func IsAllowedResolution(resolution int64) bool {
+	_, ok := AllowedResolutions[time.Duration(resolution)*time.Millisecond]
+	return ok
+}
+
 // Trade aggregation represents an aggregation of trades from the trades table
 type TradeAggregation struct {
 	Timestamp     int64     `db:"timestamp"`
@@ -46,14 +54,16 @@ type TradeAggregationsQ struct {
 // GetTradeAggregationsQ initializes a TradeAggregationsQ query builder based on the required parameters
 func (q Q) GetTradeAggregationsQ(baseAssetId int64, counterAssetId int64, resolution int64, pagingParams db2.PageQuery) (*TradeAggregationsQ, error) {
 
-	//convert resolution to a duration struct
-	resolutionDuration := time.Duration(resolution)*time.Millisecond
-
 	//check if resolution allowed
-	if _, ok := AllowedResolutions[resolutionDuration]; !ok {
+	if !IsAllowedResolution(resolution) {
 		return &TradeAggregationsQ{}, errors.New("resolution is not allowed")
 	}
 
+	err := pagingParams.Validate()
+	if err != nil {
+		return &TradeAggregationsQ{}, err
+	}
+
 	return &TradeAggregationsQ{
 		baseAssetId:    baseAssetId,
 		counterAssetId: counterAssetId,
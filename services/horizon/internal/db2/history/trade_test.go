@@ -36,6 +36,17 @@ func TestTradeQueries(t *testing.T) {
 	err = q.Trades().Page(pq).Select(&pt)
 	tt.Assert.NoError(err)
 
+	// For an account
+	var forAccount []Trade
+	account := trades[0].BaseAccount
+	err = q.Trades().ForAccount(account).Select(&forAccount)
+	if tt.Assert.NoError(err) {
+		tt.Assert.NotEmpty(forAccount)
+		for _, trade := range forAccount {
+			tt.Assert.Contains([]string{trade.BaseAccount, trade.CounterAccount}, account)
+		}
+	}
+
 	// test for asset pairs
 	q.TradesForAssetPair(2, 3).Select(&trades)
 	tt.Assert.Len(trades, 0)
@@ -4,6 +4,8 @@ import (
 	sq "github.com/Masterminds/squirrel"
 	"github.com/stellar/go/services/horizon/internal/db2"
 	"github.com/stellar/go/services/horizon/internal/toid"
+	"github.com/stellar/go/support/errors"
+	"github.com/stellar/go/support/time"
 )
 
 // TransactionByHash is a query that loads a single row from the
@@ -16,6 +18,18 @@ func (q *Q) TransactionByHash(dest interface{}, hash string) error {
 	return q.Get(dest, sql)
 }
 
+// TransactionsByIDs loads the set of transactions identified by the ids
+// `ids` into `dest`.
+func (q *Q) TransactionsByIDs(dest interface{}, ids ...int64) error {
+	if len(ids) == 0 {
+		return errors.New("no id arguments provided")
+	}
+
+	sql := selectTransaction.Where(sq.Eq{"ht.id": ids})
+
+	return q.Select(dest, sql)
+}
+
 // Transactions provides a helper to filter rows from the `history_transactions`
 // table with pre-defined filters.  See `TransactionsQ` methods for the
 // available filters.
@@ -61,6 +75,28 @@ func (q *TransactionsQ) ForLedger(seq int32) *TransactionsQ {
 	return q
 }
 
+// ForTimeRange filters the query to only transactions in the ledgers closed at
+// or after `start` and before `end`, a nil time leaving the range unbounded on
+// its side.
+func (q *TransactionsQ) ForTimeRange(start, end time.Millis) *TransactionsQ {
+	if q.Err != nil {
+		return q
+	}
+
+	if start.IsNil() && end.IsNil() {
+		return q
+	}
+
+	from, to, err := q.parent.ledgerIDRange(start, end)
+	if err != nil {
+		q.Err = err
+		return q
+	}
+
+	q.sql = q.sql.Where("ht.id >= ? AND ht.id < ?", from, to)
+	return q
+}
+
 // Page specifies the paging constraints for the query being built by `q`.
 func (q *TransactionsQ) Page(page db2.PageQuery) *TransactionsQ {
 	if q.Err != nil {
@@ -0,0 +1,47 @@
+package history
+
+import (
+	"github.com/stellar/go/support/errors"
+)
+
+// Queue adds `id` to the load queue for the cache.
+func (tc *TransactionCache) Queue(id int64) {
+	tc.lock.Lock()
+
+	if tc.queued == nil {
+		tc.queued = map[int64]struct{}{}
+	}
+
+	tc.queued[id] = struct{}{}
+	tc.lock.Unlock()
+}
+
+// Load loads a batch of transactions identified by the queued ids, using `q`,
+// and populates the cache with the results
+func (tc *TransactionCache) Load(q *Q) error {
+	tc.lock.Lock()
+	defer tc.lock.Unlock()
+
+	if len(tc.queued) == 0 {
+		return nil
+	}
+
+	ids := make([]int64, 0, len(tc.queued))
+	for id := range tc.queued {
+		ids = append(ids, id)
+	}
+
+	var transactions []Transaction
+	err := q.TransactionsByIDs(&transactions, ids...)
+	if err != nil {
+		return errors.Wrap(err, "failed to load transaction batch")
+	}
+
+	tc.Records = map[int64]Transaction{}
+	for _, tx := range transactions {
+		tc.Records[tx.ID] = tx
+	}
+
+	tc.queued = nil
+	return nil
+}
@@ -0,0 +1,31 @@
+package history
+
+import (
+	"testing"
+
+	"github.com/stellar/go/services/horizon/internal/test"
+)
+
+func TestTransactionCache(t *testing.T) {
+	tt := test.Start(t).Scenario("base")
+	defer tt.Finish()
+	q := &Q{tt.HorizonSession()}
+
+	var tc TransactionCache
+	tc.Queue(8589938688)
+	tc.Queue(12884905984)
+
+	err := tc.Load(q)
+
+	if tt.Assert.NoError(err) {
+		tt.Assert.Len(tc.Records, 2)
+		tt.Assert.Equal(
+			"2374e99349b9ef7dba9a5db3339b78fda8f34777b1af33ba468ad5c0df946d4d",
+			tc.Records[8589938688].TransactionHash,
+		)
+		tt.Assert.Equal(
+			"cebb875a00ff6e1383aef0fd251a76f22c1f9ab2a2dffcb077855736ade2659a",
+			tc.Records[12884905984].TransactionHash,
+		)
+	}
+}
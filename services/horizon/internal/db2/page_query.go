@@ -14,7 +14,7 @@ import (
 const (
 	// DefaultPageSize is the default page size for db queries
 	DefaultPageSize = 10
-	// MaxPageSize is the max page size for db queries
+	// MaxPageSize is the default max page size for db queries
 	MaxPageSize = 200
 
 	// OrderAscending is used to indicate an ascending order in request params
@@ -40,6 +40,9 @@ var (
 	// ErrNotPageable is an error that occurs when the records provided to
 	// PageQuery.GetContinuations cannot be cast to Pageable
 	ErrNotPageable = errors.New("Records provided are not Pageable")
+	// ErrUnboundedPage is an error that occurs when a query is paged by a
+	// page query without a limit or an order, which would scan the whole table
+	ErrUnboundedPage = errors.New("Page has no limit or order")
 )
 
 // ApplyTo returns a new SelectBuilder after applying the paging effects of
@@ -64,6 +67,11 @@ func (p PageQuery) ApplyToUsingCursor(
 	col string,
 	cursor interface{},
 ) (sq.SelectBuilder, error) {
+	err := p.Validate()
+	if err != nil {
+		return sql, err
+	}
+
 	sql = sql.Limit(p.Limit)
 
 	switch p.Order {
@@ -82,6 +90,16 @@ func (p PageQuery) ApplyToUsingCursor(
 	return sql, nil
 }
 
+// Validate returns ErrUnboundedPage unless `p` pages by keyset, bounded by a
+// limit and ordered.  Every query of the history database is paged this way,
+// so that no page scans the rows of the pages before it.
+func (p PageQuery) Validate() error {
+	if p.Limit == 0 || (p.Order != OrderAscending && p.Order != OrderDescending) {
+		return ErrUnboundedPage
+	}
+	return nil
+}
+
 // Invert returns a new PageQuery whose order is reversed
 func (p PageQuery) Invert() PageQuery {
 	switch p.Order {
@@ -210,6 +228,18 @@ func NewPageQuery(
 	cursor string,
 	order string,
 	limit uint64,
+) (PageQuery, error) {
+	return NewPageQueryWithMax(cursor, order, limit, MaxPageSize)
+}
+
+// NewPageQueryWithMax behaves as NewPageQuery, but validates the limit against
+// `maxLimit` instead of MaxPageSize, for servers configured with another max
+// page size.
+func NewPageQueryWithMax(
+	cursor string,
+	order string,
+	limit uint64,
+	maxLimit uint64,
 ) (result PageQuery, err error) {
 
 	// Set order
@@ -230,7 +260,7 @@ func NewPageQuery(
 	case limit <= 0:
 		err = ErrInvalidLimit
 		return
-	case limit > MaxPageSize:
+	case limit > maxLimit:
 		err = ErrInvalidLimit
 		return
 	default:
@@ -4,6 +4,7 @@ import (
 	"math"
 	"testing"
 
+	sq "github.com/Masterminds/squirrel"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -47,6 +48,28 @@ func TestPageQuery(t *testing.T) {
 	_, err = NewPageQuery("", "", 201)
 	assert.Error(err)
 
+	// Configured max
+	p, err = NewPageQueryWithMax("", "", 500, 500)
+	require.NoError(err)
+	assert.Equal(uint64(500), p.Limit)
+	_, err = NewPageQueryWithMax("", "", 501, 500)
+	assert.Error(err)
+	_, err = NewPageQueryWithMax("", "", 100, 50)
+	assert.Error(err)
+
+}
+
+func TestPageQuery_Validate(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.NoError(MustPageQuery("", "asc", 10).Validate())
+	assert.NoError(MustPageQuery("", "desc", 1).Validate())
+	assert.Equal(ErrUnboundedPage, PageQuery{Order: "asc"}.Validate())
+	assert.Equal(ErrUnboundedPage, PageQuery{Limit: 10}.Validate())
+
+	// queries are never paged by an unbounded page
+	_, err := PageQuery{Order: "asc"}.ApplyTo(sq.Select("*").From("history_ledgers"), "id")
+	assert.Equal(ErrUnboundedPage, err)
 }
 
 func TestPageQuery_CursorInt64(t *testing.T) {
@@ -0,0 +1,58 @@
+package db2
+
+import (
+	"sync"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/stellar/go/support/db"
+	"golang.org/x/net/context"
+)
+
+// Replica routes the reads of a database to a read replica, falling back to
+// the primary database while the replica lags behind it by more than MaxLag
+// ledgers.  Until the lag of the replica is first known, reads go to the
+// primary database.
+type Replica struct {
+	Primary *sqlx.DB
+	Replica *sqlx.DB
+	MaxLag  uint
+
+	lock     sync.RWMutex
+	caughtUp bool
+}
+
+// SetLatestLedgers records the latest ledgers ingested into the primary
+// database and replicated to the replica, updating where reads are routed.
+// It reports whether the replica serves reads.
+func (r *Replica) SetLatestLedgers(primary, replica int32) bool {
+	caughtUp := int64(primary)-int64(replica) <= int64(r.MaxLag)
+
+	r.lock.Lock()
+	r.caughtUp = caughtUp
+	r.lock.Unlock()
+	return caughtUp
+}
+
+// SetUnavailable routes reads to the primary database, when the lag of the
+// replica cannot be found.
+func (r *Replica) SetUnavailable() {
+	r.lock.Lock()
+	r.caughtUp = false
+	r.lock.Unlock()
+}
+
+// CaughtUp reports whether the replica serves reads.
+func (r *Replica) CaughtUp() bool {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+	return r.caughtUp
+}
+
+// Session returns a new session bound to `ctx` that reads from the replica,
+// or from the primary database while the replica lags behind.
+func (r *Replica) Session(ctx context.Context) *db.Session {
+	if r.CaughtUp() {
+		return &db.Session{DB: r.Replica, Ctx: ctx}
+	}
+	return &db.Session{DB: r.Primary, Ctx: ctx}
+}
@@ -0,0 +1,33 @@
+package db2
+
+import (
+	"testing"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReplica(t *testing.T) {
+	assert := assert.New(t)
+
+	r := &Replica{Primary: &sqlx.DB{}, Replica: &sqlx.DB{}, MaxLag: 2}
+
+	// reads go to the primary until the lag of the replica is known
+	assert.False(r.CaughtUp())
+	assert.Equal(r.Primary, r.Session(nil).DB)
+
+	assert.True(r.SetLatestLedgers(10, 10))
+	assert.Equal(r.Replica, r.Session(nil).DB)
+
+	assert.True(r.SetLatestLedgers(10, 8))
+	assert.Equal(r.Replica, r.Session(nil).DB)
+
+	// lagging replicas fall back to the primary
+	assert.False(r.SetLatestLedgers(10, 7))
+	assert.Equal(r.Primary, r.Session(nil).DB)
+
+	assert.True(r.SetLatestLedgers(11, 11))
+	r.SetUnavailable()
+	assert.False(r.CaughtUp())
+	assert.Equal(r.Primary, r.Session(nil).DB)
+}
@@ -2,6 +2,10 @@
 // sources:
 // latest.sql
 // migrations/10_add_trades_price.sql
+// migrations/11_index_operations_by_type.sql
+// migrations/12_index_trades_by_account.sql
+// migrations/13_create_audit_submissions_table.sql
+// migrations/14_create_account_index_tables.sql
 // migrations/1_initial_schema.sql
 // migrations/2_index_participants_by_toid.sql
 // migrations/3_use_sequence_in_history_accounts.sql
@@ -79,7 +83,7 @@ func (fi bindataFileInfo) Sys() interface{} {
 	return nil
 }
 
-var _latestSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xcc\x5c\xed\x6e\xdb\xb8\xd2\xfe\x9f\xab\x20\x16\x05\xec\x00\x4e\xdf\xd8\x75\x9c\x34\xd9\x16\xf0\x3a\x6a\x6a\xac\x2b\x77\x6d\xf9\xed\x16\x45\x41\xd0\x12\x6d\xf3\x54\x12\x55\x8a\xee\x26\x7b\x70\xee\xfd\x40\x9f\xd6\x07\x29\x4a\xb6\xd2\x3d\xff\x1a\x6b\x34\xf3\x3c\xc3\x19\x0e\x39\xa4\x7a\x71\x71\x76\x71\x01\x3e\x52\x9f\x6f\x19\x5e\xfe\x31\x03\x16\xe2\x68\x8d\x7c\x0c\xac\xbd\xe3\x9d\x5d\x5c\x9c\x05\xcf\xef\xf7\x8e\x87\x2d\xb0\x61\xd4\x39\x08\xfc\xc0\xcc\x27\xd4\x05\xaf\x5f\x8e\x5e\x8e\x32\x52\xeb\x27\xe0\x6d\x61\xf0\x7a\x41\xe4\x6c\xa9\x19\xc0\xe7\x88\x63\x07\xbb\x1c\x72\xe2\x60\xba\xe7\xe0\x0d\xb8\xbc\x0b\x1f\xd9\xd4\xfc\x56\xfe\xd5\xb4\x49\x20\x8d\x5d\x93\x5a\xc4\xdd\x82\x37\xa0\xb3\x32\xde\xdd\x74\xee\x12\x75\xae\x85\x98\x05\x4d\xea\x6e\x28\x73\x88\xbb\x85\x3e\x67\xc4\xdd\xfa\xe0\x0d\xa0\x6e\xac\x63\x87\xcd\x6f\x70\xb3\x77\x4d\x4e\xa8\x0b\xd7\xd4\x22\x38\x78\xbe\x41\xb6\x8f\x73\x66\x1c\xe2\x42\x07\xfb\x3e\xda\x86\x02\x7f\x21\xe6\x12\x77\x7b\x17\x63\xc7\x88\x99\x3b\xe8\x21\xbe\x03\x6f\x80\xb7\x5f\xdb\xc4\xec\x05\x64\x4d\xc4\x91\x4d\x03\xb1\xc8\x9f\x3a\x72\xf0\x2d\xd8\x10\xe6\x73\x88\xb6\xdb\x2e\x72\x9f\xb0\x1d\xb2\xee\x81\xc3\xbf\xcf\xef\x80\xf1\xe4\xe1\x5b\xf0\x6e\xa5\x4f\x8c\xe9\x5c\xbf\x03\x4b\x73\x87\x1d\x74\x1b\xeb\xbe\x03\xf3\xbf\x5c\xcc\x6e\xc1\x45\x38\x10\x93\x85\x36\x36\xb4\x54\x5a\xad\x1f\x2c\x34\x63\xb5\xd0\x97\x99\xdf\xce\x00\x00\x60\x36\xd6\x1f\x56\xe3\x07\x0d\xf8\xdf\x6d\x30\xfd\xf0\x61\x65\x8c\x7f\x9b\x69\x60\x69\x2c\xa6\x13\x23\x94\x18\x2f\xc1\x0b\xf8\x02\x2c\xb5\x99\x36\x31\xc0\x8b\x7e\xf0\xd7\xdd\x59\x9e\x9e\x8d\x9e\x95\x9d\x4a\x7d\x6b\xe4\x06\x22\x72\x0e\x7a\x84\x1e\x23\x26\x0e\x21\xb8\x7b\x07\x33\x62\x7e\xf9\xda\x03\xe9\x3f\x4f\xe5\x57\xc3\x42\x4a\x31\xfd\xe9\x28\x86\xdd\x33\x00\x26\xe3\xa5\x06\x3e\xbd\xd7\x74\xf0\xa2\xff\xa5\xff\xf5\xff\x5e\xf4\xbf\x0c\xbe\xbe\x7d\x31\x08\xff\x3d\xf8\x32\xf8\x0a\x8c\xe8\x21\xd0\x66\x4b\x2d\x70\x8a\xa6\xdf\x9f\x0b\x3d\x43\xdc\xe7\xf6\x8c\xda\xc2\x73\x7b\xe6\xd7\x63\x3c\x13\xe6\x63\x57\x90\x01\xe3\x87\x87\x85\xf6\x30\x36\xb4\x7a\x8e\x48\xc5\xcb\x1a\x43\xc4\x00\x2c\x03\x5f\x05\xf3\x57\x32\x03\xf4\xa2\x9f\x8d\xcf\x1f\x35\xf0\x26\x9b\x11\xe7\xa2\xac\x6d\x15\x63\x51\x61\x01\x62\x92\xc6\xf5\x11\xa6\x89\xd1\x2d\x47\xd4\xd1\x28\x45\x4a\x0b\x48\x73\x09\x99\x87\x7b\x88\xb2\x32\xda\x24\x58\x5b\x45\x2b\x50\x5a\x44\x9b\x4d\x92\x4a\xb4\x41\xe5\xb2\xf0\x06\xed\x6d\x0e\x39\x5a\xdb\xd8\xf7\x90\x89\x83\x3a\xda\xb9\xcb\x3f\xfd\x8b\xf0\x1d\xa4\xc4\xca\x94\xc6\x1c\x57\xe4\xfb\x98\xc3\xa0\x82\xfb\x09\xc5\x30\xc1\xea\xd1\x8b\x72\x31\xa3\x23\x66\x44\x2c\xb0\x26\x5b\xe2\x72\xa0\xcf\x0d\xa0\xaf\x66\xb3\x88\x0e\x72\xe8\xde\xe5\xe2\x67\xee\xde\x81\xc8\x34\x03\x01\x1f\x10\x97\xe3\x2d\x66\x05\x91\x8d\x8d\xb6\x3e\xf0\x1d\x64\xdb\xe5\xf7\x39\x75\x6c\x60\xee\x10\x43\x26\xc7\x0c\xfc\x40\xec\x89\xb8\xdb\xee\x68\x78\x9e\x0a\x96\x87\x7a\x4b\x99\x07\x1d\xb2\x65\x28\x58\x45\x1c\xef\x82\x82\x9e\x83\x1b\x38\x7e\x2c\x39\xc1\xf3\x6c\x82\x2d\x88\x38\x08\x56\x46\x3e\x47\x8e\x07\x82\x71\x0a\xff\x04\x7f\x53\x17\x97\x81\xee\x88\xcf\x29\x7b\x4a\x3d\x04\x89\x05\x7d\xfc\x3d\x01\xbc\xd4\xfe\x58\x69\xfa\xa4\x26\xe6\x44\x5a\xa6\x35\x0e\xbd\xf1\xc2\x00\x9f\xa6\xc6\x7b\xd0\x0f\x7f\x98\xea\x93\x85\xf6\x41\xd3\x0d\xf0\xdb\xe7\xf8\x27\x7d\x0e\x3e\x4c\xf5\xff\x1f\xcf\x56\x5a\xfa\xf7\xf8\xcf\xc3\xdf\x93\xf1\xe4\xbd\x06\xfa\x2a\x32\x47\xbb\xbd\xa8\xa8\x14\x7e\xf7\xda\xbb\xf1\x6a\x66\x00\x17\x3f\xf2\x1f\xc8\xee\x76\x24\x8c\x3b\xb7\xb7\x0c\x6f\x4d\x1b\xf9\xfe\x79\x71\xb8\x2c\x8b\x61\xdf\x17\x87\x56\xc5\x40\x05\x49\xd1\x02\xb3\x50\xcd\x81\x97\x38\x31\xa2\x0c\xe4\x4f\x1e\x56\x64\x40\x56\xdc\xa4\x96\x48\xbc\x3f\x10\x8b\x13\xdf\xdf\x63\x26\x78\xe1\x6a\x54\x95\x61\x79\x22\x2d\x87\x6d\x56\xe7\x4f\x0b\xda\x2a\x22\x60\xfe\x49\xd7\xee\xc1\x6f\x9f\x15\x8c\xc6\x33\x43\x5b\x28\x08\xa5\xba\x0a\x8f\x5f\x12\x4b\x86\x0d\x6f\x36\xd8\x6c\x21\xea\x62\x3d\x71\xd8\x15\x72\x06\xca\x66\xf7\x44\x8e\x7a\x38\x9a\x07\xa5\x92\xbf\x50\x66\x61\xf6\x8b\x24\x9a\xc3\x38\x16\x3f\xb2\x30\x47\xc4\xf6\xc1\xbf\x7c\xea\xae\xe5\xc1\x66\x63\x6b\x8b\xd9\xe9\x7e\x88\xf5\xc4\x7e\xf0\xf1\xf7\x3d\x76\x4d\x19\xb6\x48\x18\xee\x90\xbf\xab\x95\x85\x1e\xc3\x3f\x08\xdd\xfb\x50\xf9\x62\xec\x16\x86\x5c\x1f\x45\xdb\xdd\x70\x20\x52\x1c\xc9\x2c\x77\x59\xb0\x70\x18\x88\x7a\xf2\xa6\x4d\x7d\x51\x61\x0a\x36\xef\x69\x6d\x2a\xbe\xc3\x30\xe2\xca\x97\x22\xd9\xbd\x67\xd5\x96\x4d\x43\x27\xfe\xd3\xf1\x28\xe3\x98\xc1\xa4\xff\x50\xe4\xd2\x2f\x2d\x07\x38\xb2\xa1\x49\x89\xeb\x8b\x63\x70\x83\x31\xf4\x28\xb5\xc5\x4f\xd7\xc8\xc7\x70\x83\x65\x63\x1d\x3e\x66\xd8\xc7\xec\x87\x4c\x24\x58\x7b\xf2\x47\x18\x2e\x8d\xc8\xdf\x32\x29\x8f\x51\x4e\x4d\x6a\x4b\x79\x15\xc7\x28\x09\x16\x8c\x2c\xcc\xc2\xe5\x85\x3c\x0d\x0e\xe3\xef\x21\xc6\x89\x49\x3c\xd4\x46\xb5\x15\xab\x55\xd5\xa8\xfa\xb3\x83\x7a\xbe\x69\x4a\xb9\xdd\xb2\x53\x69\xe3\x67\x95\xa1\x46\x44\x4f\x2c\x4b\x95\xb6\xca\x65\x4a\x2c\x5e\x51\xb6\xd2\x17\x5a\x8c\x4d\xd5\x56\x24\x3b\x9b\x4a\xb7\x2b\xc1\x4a\xdd\x8c\xa8\x84\x15\xeb\xc4\x82\x15\xfd\xe4\xd3\x3d\x0b\xf6\x78\x51\x74\x4b\x4a\x45\x92\xfe\x9d\xce\xed\x6d\x49\xa2\x46\x1e\x70\x86\x2c\x7c\xba\x3b\x23\x35\x85\x75\xc0\xa9\xf5\x3d\x9e\xc2\x8e\xa9\x36\x74\xb3\xc1\x4c\x6a\x36\x9c\x95\x55\xab\x94\x48\x28\x5a\xd2\x56\x8a\x54\xec\x55\x43\x0b\x98\x29\x6d\xa5\x72\x95\xe6\x52\xa9\x0a\x8b\x21\x24\xe2\x43\x1f\xdb\x36\x66\x60\x4d\xa9\x8d\x91\x9b\xd4\x10\x62\x62\xe8\xe6\xea\x65\xf4\x5b\xbe\x86\x4e\xe6\xfa\xd2\x58\x8c\xa7\xba\x51\x18\x5f\x98\x21\x0c\xc3\xc6\x3a\x98\xbc\xd7\x26\xbf\x83\x6e\x37\xeb\x8a\xb7\xe0\xf2\xfc\x5c\xa5\x4a\xf4\x7a\xc2\xfe\xd7\x92\x43\x6a\xe8\xcb\x39\xa7\xa0\xbe\xe0\xb9\x10\x60\x65\x4e\xa4\x29\xdf\x6a\x41\x94\x29\xae\x5b\x12\xeb\xcc\x45\xa7\x14\x45\x19\xbe\x76\xcb\xa2\xc2\xca\xcf\x2a\x8c\x0d\xc9\x9e\x58\x1a\x15\xd6\xca\xc5\x51\xf6\x42\x45\x79\xcc\xbc\xd2\x6a\xac\x26\xf1\x99\x85\x54\x7b\xf7\x12\x4f\xe2\x8a\x3d\x51\xdd\x0a\x5a\x5d\x0c\x85\xb2\x07\xd3\xf2\xe5\x3d\x92\xa6\x9e\x6c\x6b\xf4\x8f\x6c\x6e\xf8\x23\xc4\xee\x0f\x6c\x53\x0f\x8b\x1a\x86\xfc\x31\xd8\x6a\xec\x6d\x2e\x79\xe8\x60\x8e\x24\x8f\x02\x2f\xc8\x1e\xfb\x64\xeb\x22\xbe\x67\x58\xd4\xdb\x7a\x3d\x3a\xff\xf2\xf5\xb0\x0a\xf9\xf7\x7f\x44\xeb\x90\x2f\x5f\x8b\x7b\x1e\xec\x50\x49\x1b\xea\xa0\xcb\xa5\x2e\xae\x5c\xd5\x1c\x74\x95\xd5\xc4\xcc\x88\x83\xe1\x9a\xee\x5d\x2b\x6c\x15\xdf\x30\xe4\x6e\xab\x9a\xa6\x51\x13\x8d\x58\x49\xf6\xc4\x58\x6a\xa5\x7c\x94\x3e\x73\x7d\x56\xec\xc3\x80\xe8\xf9\x64\x3e\x5b\x7d\xd0\x83\x21\x5d\x6a\x46\x45\xc3\x31\xdb\xda\xc9\xb6\x1b\x9b\x2d\xf0\xdb\x23\x21\xd1\xdf\x88\x54\xe5\xc6\xa0\x0e\x49\x69\xe5\x6c\x8d\xa6\xd4\x42\x23\xa2\x8a\x69\x5e\x4c\xf5\x1e\x71\x04\x36\x94\x29\x8e\x5a\xc0\xfd\xd8\x18\x2b\xe8\x49\x54\x56\x1d\x5f\xd4\x51\x3b\xd5\x97\xda\xc2\x00\x53\xdd\x98\x97\x8e\x30\xc2\x82\xbb\x04\xdd\x4e\x1f\x12\x97\x70\x82\x6c\xe8\x87\xba\x5e\xfa\xdf\xed\x4e\x0f\x74\x06\x97\xfd\x9b\x8b\xcb\xc1\x45\xff\x15\xe8\x5f\xdd\x0e\xfb\xb7\x83\xc1\xcb\xc1\xeb\xe1\xf5\xe0\xf5\xc5\xe5\x4d\xe7\xfc\xae\x9e\xf6\x01\x24\xae\x85\x1f\xf3\x5e\x5d\x3f\x41\x4e\x89\x55\x65\xe9\x55\x7f\x38\x18\x0e\x9a\x58\x7a\x05\xf7\x3e\x4e\xab\x06\x24\x2e\x2c\x1e\x06\x54\xda\x1b\x5c\x8e\xfa\xa3\x26\xf6\x86\x10\x59\x16\x2c\x36\x78\x2a\x6d\x8c\x2e\xfb\xa3\x9b\x26\x36\xae\x60\x54\xa2\x92\xd5\x72\x78\x18\x58\x69\xe2\xe6\x7a\x78\x35\x6c\x62\x62\x94\x98\x88\x67\x30\xa5\x89\xe1\xe5\xf5\xf5\x75\x23\x4f\x5d\x43\x87\x5a\x64\xf3\x54\x9b\xc5\x70\x78\x75\x35\x68\x34\xf8\x37\xe1\x60\xa0\xed\x96\xe1\x2d\xe2\x94\x55\x8e\xf5\xf0\x6a\xf0\xfa\xe6\xaa\x99\xfa\xac\x93\xa2\x24\xaf\x41\x63\x74\x73\x39\xbc\x6e\x62\xe7\x75\x48\x23\x6a\xfe\xc1\x47\x8b\x55\x6a\xbf\x1e\x8d\x9a\xe5\x62\xff\x32\x54\x1f\x8f\x42\xb8\x85\xac\x34\x70\x33\xb8\xba\x7a\x15\x1b\x90\xcc\x50\x95\x47\x7d\x4d\x66\xbe\x46\xc7\xa0\xc1\x64\xae\xd0\x1b\x5f\x17\x39\xdc\xf4\x7a\xe9\xe3\xea\x23\xc2\x1e\xe8\xf7\xa2\x33\xf4\x1a\x74\xcb\xa7\x7f\x27\x90\xad\x3c\x71\x6a\x85\x6a\x6e\x71\xd2\x84\xa8\xe8\xc4\xe9\x84\x82\x56\x75\x80\xd3\x82\xda\x1a\x0d\xf1\xe3\x87\xa9\x59\x47\xb6\x8d\x61\xab\x5e\x7e\x35\x19\x46\x49\x07\xb6\x05\x97\x0b\x1a\x91\xed\x68\x55\xb7\x72\x8e\x1f\xca\xa6\x3d\x84\x36\x06\x53\xb5\xc4\x6c\x32\x9c\xd2\x8e\x41\x73\x97\x64\x2f\xf7\x64\x8b\x9b\xf7\x0d\x3f\x25\xaa\x0f\xdd\xbb\xa6\xab\xf4\x8c\xc6\xe8\x2e\xdf\xfd\x7d\xb6\x17\x58\x34\x08\x3e\x2e\xa6\x1f\xc6\x8b\xcf\xe0\x77\xed\x33\xe8\x12\x4b\x75\x9f\xa7\xf8\x77\x4b\xa8\x0b\x5a\x45\xc8\x45\x86\x95\xe8\x0b\xfb\xcb\xc2\xec\x7c\xb8\xb5\x01\x0f\xf7\x3d\x60\xf6\x72\x06\x6c\x85\x5d\xde\xac\x88\xdc\x51\xc0\xc0\x4a\x9f\xfe\xb1\xd2\x40\xf7\x20\xde\xcb\x5c\x5c\xe9\xe5\xae\x99\x34\x74\x4d\x3b\xc3\xda\x98\x78\xa3\x41\x95\xec\xb7\x15\x73\x79\xbb\xcc\xc4\x46\xaa\x98\x56\xc0\xaa\xcd\x5c\xba\x05\x57\x4e\x7d\xed\xb2\x97\x99\xa9\xe2\x5f\x09\x4d\xe9\x81\x28\xa4\xd7\x4f\x61\xb4\x27\x44\xa6\xfa\xbd\xf6\x67\xbd\xd6\x6d\x28\x9a\xd7\x02\xe6\x7a\x31\x19\x56\xcb\xa9\xfe\x00\xd6\x9c\x61\x9c\xcd\x2e\x39\x9a\x28\xc7\x4e\xc7\x13\x5f\x09\xab\x85\x48\x92\xd7\xeb\x74\x9d\x7d\x34\x9c\x83\x8a\x2c\x92\x5c\x9f\x3b\x8f\x27\x12\xee\x95\x1a\xc9\x22\x70\x3b\xe4\xef\x4e\x41\x16\xf6\xd3\x6b\xc1\x2a\x76\xe1\x45\x68\xa2\x65\xf1\x29\x78\x22\x0d\xf5\x10\x15\x5a\xfc\xbd\x72\x37\x5f\x98\xf2\x10\x07\xb1\x11\x3e\x3f\x02\x69\x5c\x25\x22\xc0\x05\x75\x59\xd8\xc9\x15\xb5\x1c\x62\xd1\x09\x75\x2f\x39\x8d\x96\x81\x3d\xb4\x1a\x4f\x84\x49\xac\xda\x00\x0f\xa7\x78\x3d\xe1\xb1\xba\x02\x34\xf5\xa0\xd7\x16\xee\x58\x57\x16\xba\xa4\x54\x1d\xc5\x44\x4c\x80\x3f\xb6\x47\x20\xd6\x25\x89\xe9\x23\x29\xe4\x8f\x64\xcb\x24\xa8\x17\x44\xe5\x8e\x1e\xc5\x21\x06\x7f\xd0\x71\xac\xf3\xab\x1d\x9d\xde\x2c\x0c\xa6\xea\xd3\x7d\x9d\x57\x97\x85\x9c\x5c\x93\xcc\x61\x14\x23\xca\xfa\xb5\x2d\x58\x25\x9d\xf5\xa6\x37\x11\x40\x1e\x0d\x09\x3f\x65\x58\x0f\x3a\x8e\x0f\x49\x55\xf8\x71\x66\x85\xb3\xe2\x66\x73\x42\x39\xc8\x69\x29\x60\xb5\x70\x01\x59\x72\xf3\x46\x8c\x25\xb9\x88\x61\x53\xfa\x6d\xef\x9d\x86\x28\xaf\x4b\x85\xab\x74\xa3\x44\x88\xcf\x43\x84\x85\xdf\xa1\xb6\x82\xb0\xa8\x4d\x85\x31\x77\x0b\xa6\x57\xba\x04\xd3\x2b\xdd\x88\x92\x90\x68\x21\x5b\x62\x3d\x2a\xc4\x0d\x6b\x52\xa0\xb5\x35\xef\x36\x70\xac\xd2\x6f\xd1\xd9\x52\xa9\xa3\x4b\x5d\x18\x7f\xd6\x71\xaa\x43\x95\x06\x72\xab\xe3\xe4\x33\x95\xfc\x7a\x34\x12\x6c\x80\xfd\xf4\x38\xa8\xd2\xad\x46\x2c\xc8\xb2\xbc\xc2\x78\xed\x13\xe8\x0b\xf6\xf6\x47\xc7\x43\xa5\x56\xe5\x62\x2b\x10\x52\x00\x8d\x2b\x57\xa0\x32\x0d\xa2\x96\xd0\x8a\x54\x2b\x8b\x66\xdd\x48\xce\x28\x6f\x3b\x18\x72\xaa\x8f\xa9\xf2\x72\x75\x85\x3b\xfc\xed\x3b\xba\xf4\x95\x80\x12\x7e\xe1\x85\xfa\x64\x32\x1f\x6d\x3c\x9b\xff\xb3\x1f\x86\xa8\x98\x64\x64\xeb\x93\x10\x7d\x82\xf2\x6c\x6c\x84\xdf\xbb\xa8\x68\x89\x5e\xaa\xcf\x2f\xd9\xba\x3e\x1b\xa7\xf4\x0e\x9a\x8a\x87\xb4\xc7\x90\x57\x7d\x38\x87\x79\x8e\xd4\x2e\x6a\x17\x6e\x3b\x9a\x26\x78\x5e\x69\x7e\xe1\xda\x52\x86\x57\x99\xa8\xc3\x41\xb1\x9a\xae\x34\xd6\x5e\xf9\x2a\x2b\xae\x85\x5d\x5d\xc4\xb2\x5b\x9c\xe7\x08\x9b\xb2\xfe\xa3\x37\x58\xe1\x22\x2e\x2d\xe4\x49\x5f\x07\xae\x29\xfd\x76\xb4\x97\x2b\x74\x2a\x97\x08\xdd\x6e\xf2\x81\xc6\xc5\xdb\xb7\xa0\xe3\x53\xdb\xca\x9c\x61\x74\x6e\x6f\x39\x7e\xe4\xe7\xe7\x3d\x20\x17\x34\xa9\x55\x4f\x30\xea\x80\xca\x45\xd7\x74\xbf\xdd\xf1\x5a\xe6\x73\xa2\xd5\x00\x72\xa2\x05\x08\xe7\xe0\xd3\x7b\x6d\xa1\x45\x41\x06\xde\x80\x57\xaf\x24\x3d\xe3\xf2\xf1\x1f\xb1\xe0\x26\xd3\x9c\x7f\xf7\xfb\xcf\x39\x04\x8c\xcd\x82\x77\xf3\x85\x36\x7d\xd0\xd3\xc6\x3b\x58\x68\xef\xb4\x85\xa6\x4f\xb4\x65\xa1\x17\x1d\x3e\x9d\xeb\x60\xf5\xf1\x3e\x08\x99\x85\x16\xfd\xcf\x21\xc1\x4f\xf7\xda\x4c\x33\x34\x30\x19\x2f\x27\xe3\x7b\xad\xfa\x4b\x1a\xf1\x17\x13\x69\xe3\xa8\x3d\x67\xe4\xed\x28\x8e\x26\x64\x48\xf2\xfe\x29\x48\x88\x9d\x15\x2f\xf4\x15\xe7\x38\x52\x4f\xc4\x5b\xd9\x7f\xdc\x0f\x59\x1c\x22\x2f\x24\x5d\x82\xea\x80\x69\xe6\x81\xf2\xd7\x40\xff\xa0\x1b\x24\x60\xf2\xbe\x28\x0b\xb5\x1c\x14\xc5\x16\xc7\xff\x82\x43\xe4\xa1\x51\xea\x21\xd5\x8d\x0e\xd9\x7f\xb2\x06\x4c\xea\x78\x36\xe6\x38\xe4\xf0\xdf\x00\x00\x00\xff\xff\xf1\x4e\x89\x14\x91\x4d\x00\x00")
+var _latestSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xcd\x5c\x69\x6f\xdb\x48\x12\xfd\x9e\x5f\xd1\x18\x04\xb0\x05\xc8\x59\x49\x96\xe4\x6b\x26\x80\x46\xa6\x1d\x21\x8a\x9c\xd1\xb1\x99\x60\x10\x10\x94\xd8\x92\xb9\xa1\x48\x86\xa4\x92\x78\x06\xfb\xdf\xb7\x78\x93\xcd\xbe\x28\xd2\xc9\xce\x87\x8c\xcd\x2e\xbe\x7a\x55\x5d\xdd\x55\x7d\xd0\x67\x67\x2f\xce\xce\xd0\x7b\xdb\xf3\x77\x2e\x5e\xfc\x31\x45\xba\xe6\x6b\x6b\xcd\xc3\x48\x3f\xec\x1d\x68\x7b\x11\xb4\xdf\xc2\xcf\x58\x47\x5b\xd7\xde\x67\x02\x5f\xb1\xeb\x19\xb6\x85\xae\x5e\x0d\x5f\x0d\x73\x52\xeb\x27\xe4\xec\xd4\xe0\x75\x42\xe4\xc5\x42\x59\x22\xcf\xd7\x7c\xbc\xc7\x96\xaf\xfa\xc6\x1e\xdb\x07\x1f\xfd\x86\x3a\x37\x61\x93\x69\x6f\x3e\x97\x9f\x6e\x4c\x23\x90\xc6\xd6\xc6\xd6\x0d\x6b\x07\x0d\x27\xab\xe5\xdd\xe5\xc9\x4d\x02\x67\xe9\x9a\xab\xab\x1b\xdb\xda\xda\xee\x1e\x24\x54\xcf\x77\xe1\x7f\x1e\x48\xda\x56\x8c\xf1\x88\x01\x7a\x7b\xb0\x36\x3e\xd0\x51\xd7\x80\x84\x83\xf6\xad\x66\x7a\xb8\xa0\x06\x00\xd4\x3d\xf6\x3c\x6d\x17\x0a\x7c\xd3\x5c\x0b\xb0\x6e\x62\xee\x58\x73\x37\x8f\xaa\xa3\xf9\x8f\xd0\xe6\x1c\xd6\xa6\xb1\x69\x07\xc6\x6e\xc0\x27\xa6\x1d\x88\x9d\x85\xfe\x9c\x69\x7b\x7c\x8d\xb6\x86\xeb\xf9\xaa\xb6\xdb\x9d\x6a\xd6\x13\x36\x43\xab\xdb\x28\xfb\xb9\x75\x83\x96\x4f\x0e\x08\xde\xad\x66\xe3\xe5\xe4\x61\x76\x83\x16\xc0\x74\xaf\x5d\xc7\xd8\x37\xe8\xe1\x9b\x85\xdd\x6b\x74\x16\x76\xc4\x78\xae\x8c\x96\x4a\x2a\x2d\xc6\x47\x73\x65\xb9\x9a\xcf\x16\xb9\x67\x2f\x10\xfc\x37\x1d\xcd\xee\x57\xa3\x7b\x05\x79\x5f\x4c\x34\x79\xf7\x6e\xb5\x1c\xfd\x3e\x55\xd0\x62\x39\x9f\x8c\x97\xa1\xc4\x68\x81\x5e\xaa\x2f\xd1\x42\x99\x2a\xe3\x25\x7a\xd9\x0d\x7e\x03\xeb\x0a\xe6\x99\xda\xb3\x5a\x27\x82\x6f\xcc\xb8\x1e\xcd\xb8\xbd\xf6\x5d\x75\x5c\x63\x83\x43\x0a\xd6\x61\x8f\xe1\x97\xbf\x3e\xb5\x51\xfa\x63\x5d\xfb\x24\x34\xa4\x26\xa6\x8f\x8e\xb2\xf0\x14\x9e\x8d\x47\x0b\x05\x7d\x78\xa3\xcc\xa0\x33\xff\xea\x7e\xfa\x17\xfc\xdb\xfb\xf4\xfa\x65\x2f\xfc\xb9\x07\x3f\xa3\x65\xd4\x88\x94\x29\x48\x82\x53\x94\xd9\x6d\x8b\xea\x19\x18\x21\xcf\xec\x19\xb1\x86\xe7\xf6\xcc\xaf\xc7\x78\x26\x1c\x8f\xa7\x94\x11\x30\xba\xbf\x9f\x2b\xf7\x60\xa3\x9c\x23\x52\xf1\x32\x62\xc8\x18\xa1\x45\xe0\xab\x60\xfe\x4a\x66\x80\x76\xf4\x78\xf9\xf1\xbd\x02\x8f\x73\x23\xa2\x45\x1b\xb5\x8d\x72\x24\x01\x09\x8a\xc9\x30\x96\x67\x98\x0e\x8c\xd3\x72\x44\x1d\xcd\x92\x06\x4a\x30\x2d\x0c\xc8\x22\xdd\x2c\xca\x5a\xcc\xe1\xd0\x28\x5b\x0a\x28\xc9\x36\x3f\x48\xb8\x6c\x83\xcc\xa5\xe3\xad\x76\x30\x21\xe7\x6a\x6b\x13\x7b\x8e\xb6\xc1\x41\x1e\x3d\xb9\x29\xb6\x7e\x33\xfc\x47\xd5\x36\xf4\x5c\x6a\x2c\xd8\xaa\x6d\x36\xf6\xc1\xf2\x3d\xd5\x33\x76\x40\xdf\x4b\xec\x0c\x47\x99\x9c\x8d\xd1\x80\x24\x81\x62\xdb\xe2\xc7\x90\xae\x35\x57\xdb\xf8\xd8\x45\x5f\x35\xf7\x09\xf2\xef\xe9\xb0\xdf\x42\xb3\x87\x25\x9a\xad\xa6\xd3\xc8\xd8\xe8\x4d\x29\xd1\x6f\xd8\xd8\x3d\xfa\xc8\xb0\x7c\xbc\x03\xc1\xa4\xb1\xdc\x97\x29\x2d\xdf\x3d\x78\xbe\x69\x58\xb8\x01\x13\x33\xac\x23\xac\xd4\x3c\x0f\x43\xaf\x01\x85\x12\xfd\xbc\x00\x94\x46\x98\x82\xd7\xed\xd1\xf1\x0c\xcf\x3b\x50\x7d\x37\x18\xb6\x78\xee\x09\x5f\x0e\x0a\xb8\x1a\x6e\xc9\x30\x62\x77\x18\x50\x31\x1a\x3b\xb0\x8e\xa4\xba\x0f\xbd\x44\x6d\x83\x08\x57\x13\x07\x33\x1c\xb3\x35\x35\x28\x01\xbd\xbd\x66\x9a\xe5\xf7\x7d\x7b\x6f\x0a\xfc\x4f\x31\xff\xa0\x1b\x40\xfd\xb0\xde\x83\xff\xa0\x8c\xac\xe1\x04\x12\x29\x76\x85\xef\x6a\x96\xa7\x45\x35\xea\xa3\xe6\x3d\xd2\x29\xc6\xf1\x6f\x1f\xdc\x60\xf0\xf3\x82\x29\x92\xc4\xd6\x57\x6c\xda\x0e\x56\xbf\xeb\x2e\xf2\xf1\x77\xd2\x19\x2e\xf6\x60\xf0\xd3\xc2\x67\xd8\xa2\x8a\xa6\x40\xd1\xc3\xb8\x72\x36\x1c\x2e\xdd\xc0\x58\xdf\xc7\xba\xaa\xf9\x28\xa8\xf1\x21\x04\x60\x81\x10\xcc\x38\x41\xb5\x1f\x3c\x41\x7f\xdb\x16\xe6\xf8\x7f\x67\xbb\x0e\xd4\xe7\x3b\x57\xf3\x6b\x79\x9f\xc0\xc9\xc2\x90\xe2\x1b\xcd\x71\xc0\x3a\x0a\xe9\x8c\x71\x99\xe8\xa3\xe1\xf9\xb6\xfb\x94\x46\xa8\x6a\xe8\xaa\x87\xbf\x24\x84\x17\xca\x1f\x2b\x65\x36\x96\xe4\x9c\x48\xb3\x50\xe3\x99\x7f\x34\x5f\xa2\x0f\x93\xe5\x1b\xd4\x0d\x1f\x4c\x66\xf0\xfa\x3b\x65\xb6\x44\xbf\x7f\x8c\x1f\xcd\x1e\xd0\xbb\xc9\xec\xdf\xa3\xe9\x4a\x49\x7f\x1f\xfd\x99\xfd\x3e\x1e\x8d\xdf\x28\xa8\x2b\x32\xe6\x68\xb7\x93\x40\xa5\xe1\x7f\xab\xdc\x8d\x56\xd3\x25\xb2\xa0\x1b\xbe\x6a\xe6\xe9\x09\xc3\xe2\x93\xeb\x6b\x17\xef\x36\x50\x58\x78\xa5\xe9\x4d\xd7\x21\x44\x3d\x7a\x20\x72\x3a\x2a\x98\x94\x1a\xb0\x2c\x84\xc9\xec\xe2\xcd\xd8\xe1\x94\x2e\x9f\x01\x7e\xcc\x04\x5f\x34\xa4\xe1\xb0\xcd\x63\xfe\xb0\xa0\xe5\x19\x82\x1e\x3e\xcc\x94\x5b\xd0\x25\xb0\x68\x34\x5d\x2a\x73\x81\x41\x29\x16\xd1\xfc\xca\xd0\x59\xdc\xf0\x76\x8b\x37\x0d\x44\x5d\x8c\x13\x87\x1d\x31\x66\x54\x56\x76\x4d\xe4\x20\x2f\x44\xf3\x20\x53\xf2\x17\xdb\xd5\xb1\xfb\x0b\x23\x9a\x39\xa5\x89\x8e\x7d\xcd\x30\x3d\xf4\x1f\xcf\xb6\xd6\xec\x60\x33\xb1\xbe\xab\x53\x4b\x12\x38\xb1\x1f\xa0\x4f\x0e\xd8\xda\xb0\xb8\x45\xc2\x9c\x24\x4b\xc8\x3b\x2e\xfe\x6a\xd8\x07\x4f\x15\xbe\xd8\x2e\x65\xf2\x28\x41\x27\x3c\x92\x59\xae\x43\x68\xc8\x3a\x42\x4e\x7e\x63\xda\x9e\x7c\x36\x8d\xdf\x71\xb1\x26\x4e\xc1\x91\xec\xc1\xd1\xa5\x65\xd3\xd0\x89\x7f\xdd\x3b\xb6\x0b\x6e\x51\x93\xed\x3f\xd2\x96\x6e\xa9\x1c\xf3\x35\x13\xec\x36\x20\x1b\x53\x63\x70\x8b\xb1\xea\xd8\xb6\x49\x6f\x0d\x76\x23\x55\x10\x61\xf4\x75\xd8\x0c\x69\x01\xbb\x5f\x59\x22\xc1\xd2\xcf\xff\xae\x86\xa5\xa9\xf1\x37\x4b\xca\x71\x6d\xdf\xde\xd8\x26\xd3\xae\x0e\x23\xca\xb0\x06\x23\x28\x2c\x2f\xd8\xc3\x20\xeb\x7f\x47\x73\x7d\x63\x63\x38\x5a\x13\xd9\x96\x0e\x2b\xca\x51\xf2\xb3\x83\x78\xbe\xa9\x6a\x72\xb3\x69\x87\xab\xe3\x47\xa5\xa1\x4a\x86\xd6\x4c\x4b\x5c\x5d\xe5\x34\x45\x17\xe7\xa4\xad\xf4\x85\x06\x63\x53\xb4\x14\xcc\xcf\xa6\xcc\xe5\x62\x50\xa9\x6f\x22\x53\xc2\x8c\x55\x33\x61\xc9\xaf\xb2\xd2\xe1\x7f\x02\x95\x69\x49\x42\x62\x1c\x80\x79\x3a\xae\xef\xce\x08\x86\xa8\x03\xea\xe6\xf7\x78\x0a\x3b\x26\xdb\xd8\x50\x98\xb8\x4c\xb5\xe1\xac\x2c\xaa\x52\x22\xa1\xa8\xa4\xe5\x8a\x70\xf6\x0a\x42\x0d\x40\x44\xa4\x2b\x95\xe3\xaa\x4b\xa5\x38\x1a\x43\x4a\x86\x07\x03\xce\x34\xc1\xa1\x6b\x48\x5c\x58\xb3\x92\x1c\x12\x6c\xd9\x59\x85\x7c\x19\x3d\x2b\xe6\xd0\xf1\xc3\x6c\xb1\x9c\x8f\x26\x30\x0b\x15\xfb\x57\xcd\x19\xac\x86\xe7\x5a\x08\xe6\x9e\xf1\x5b\x74\x7a\x9a\x77\xc5\x6b\xd4\x69\xb5\x44\x50\xb4\xd7\x13\xeb\x7f\x2d\x39\x44\x02\xaf\xe0\x1c\x02\x9e\xf0\x5c\x48\x90\x3b\x26\xd2\x21\xdf\x68\x42\x64\x01\xcb\xa6\x44\x99\xb9\xa8\x4e\x52\x64\xf1\x6b\x36\x2d\x0a\xb4\xfc\xa8\xc4\x58\xd1\xd8\x9a\xa9\x51\xa0\xad\x9c\x1c\x59\x2f\x70\xd2\x63\xee\x95\x46\x63\xb5\xda\x16\x21\x7d\x12\x17\xac\x89\x64\x33\x68\xa5\xfd\xeb\x78\x04\xa4\xaa\xd9\xe5\xbd\xc6\x1c\x7a\xac\xa5\xd1\x4f\x59\xdc\xc0\x32\x21\xd9\x50\xa5\x6d\x18\x42\x73\xbc\x9f\x4a\x6f\xdc\x43\x8d\xc1\x68\x0a\xbc\xc0\x6a\x0e\x4e\x3b\x34\xff\x00\xd0\x14\xb7\x5f\x0d\x5b\x7f\x7d\xca\xaa\x90\x7f\xfe\x4b\xab\x43\x40\x82\x58\xf3\xe0\xbd\xcd\xd8\x86\xca\xb0\x2c\x70\x03\xb7\xaa\xc9\xb0\xca\x30\xb1\x65\xe0\x4e\x75\x0d\x1d\xa7\x87\x5b\xf5\x97\x10\xc0\x3b\x2c\xda\x7b\x02\xaf\x27\xa3\x27\xe6\x22\x35\xe4\xa3\xe1\xf3\x30\x9b\x92\xfb\x30\x28\x6a\x1f\x3f\x4c\x57\xef\x66\x41\x97\x06\xc7\x5e\xec\x0d\xc7\xfc\xd6\x4e\x7e\xbb\xb1\x5a\x81\xdf\x9c\x11\x0c\xfc\x4a\x46\x71\x17\x06\x32\x46\x32\x33\x67\x63\x66\x32\x35\x54\x32\x54\x30\xcd\xd3\x4d\xbd\xd5\x60\xe0\x6d\x6d\x57\xe6\xa4\x13\xdd\x8e\x96\x23\x81\x8d\x22\x5c\xc6\x09\x63\x1d\x68\xc6\xe9\x5c\x1d\x48\xee\x89\x57\x0d\x60\xde\x51\x8e\x0c\xec\x64\xb6\x50\xa0\x36\x81\x12\xf4\xa1\x74\x9c\x13\x16\x1f\x0b\x74\x7a\xd2\x55\x0d\xcb\xf0\x0d\xcd\x54\xbd\x10\xeb\x95\xf7\xc5\x3c\x69\xa3\x93\x5e\xa7\x7b\x79\xd6\xe9\x9d\x75\xcf\x51\x77\x70\xdd\xef\x5e\xf7\x7a\xaf\x7a\x57\xfd\x8b\xde\xd5\x59\xe7\xf2\x04\x62\x42\x0a\xbd\x07\xe8\x3a\xfe\x5e\x8c\xb0\x35\x44\x9f\x6d\xe8\x3c\x4d\xe7\xdd\x7e\xaf\xdf\xab\xa2\xe9\x5c\x3d\x40\x61\x9e\x64\x50\x50\xab\x92\x07\x23\x5c\x7d\xbd\xce\xb0\x3b\xac\xa2\xaf\xaf\x6a\xba\xae\x92\x9b\x5d\x5c\x1d\xc3\x4e\x77\x78\x59\x45\xc7\x40\x8d\xd2\x75\xb2\x72\x08\xef\x25\x70\x55\x5c\x5e\xf4\x07\xfd\x2a\x2a\x86\x89\x8a\x78\x36\x17\xaa\xe8\x77\x2e\x2e\x2e\x2a\x79\xea\x42\xdd\xdb\xba\xb1\x7d\x92\xb6\xa2\xdf\x1f\x0c\x7a\x95\x3a\xff\x32\xec\x0c\x6d\xb7\x83\x39\x4b\x83\x4e\xe7\xf6\x75\x7f\xd0\xbb\xba\x1c\x54\x83\xcf\x3b\x29\x9a\x3d\x24\xcc\x18\x5e\x76\xfa\x17\x55\xf4\x5c\x85\x66\x44\x1b\xa1\xc1\xf1\x31\x17\xfd\x62\x38\xac\x36\x16\xbb\x9d\x10\x3e\xee\x85\x70\x39\xcd\x55\x70\xd9\x1b\x0c\xce\x2b\x29\xe8\xc6\xa3\x3d\xdb\xb2\x0a\xc7\x3a\xcc\x5a\x5c\x45\x57\x9d\x6e\xb7\x9a\xa2\x64\x5a\x49\x16\xfb\xe9\x20\xe7\xeb\xb9\x18\x0c\xbb\x95\xf4\x9c\xa7\x1d\x4f\xce\xf1\xe2\xee\x1f\x74\x06\xdd\x6e\xa5\xe1\xde\xed\xa7\xea\x92\x25\x71\x64\x65\x78\x1d\x89\xab\xab\xdb\xeb\x9c\x27\xe3\x9e\x91\x4d\xb8\x47\xd4\x55\xb2\x54\xa5\xe3\xfb\xa0\x08\x11\xe0\xc6\xb7\x0c\xb3\x0b\xc2\xaf\x60\x90\x71\x8f\xb6\xdb\xa8\xdb\x8e\xae\x5e\x49\x98\x5b\x3e\xb5\xae\x61\x2c\xf7\xa4\xb4\x11\x53\x0b\x45\x75\x15\x43\x69\x27\xa5\x35\x8a\x0f\xde\xc1\x63\x03\xb0\x12\x07\x39\xc7\x77\x53\xb5\x93\x84\x26\xba\x8d\xbf\x6c\xa8\xd2\x8d\x8c\x93\x83\x06\x5c\x4e\xd9\x40\x6f\x06\x55\xbc\x05\x79\x7c\x57\x56\xdd\xfb\x6a\xa2\x33\x45\x4b\xa3\x2a\xdd\xc9\xdc\xe9\xaa\xee\x92\xd2\x75\x50\xf2\x81\xea\x7c\xc6\x4f\x89\x92\x6c\xff\xb9\xea\x3a\x93\x84\x8d\x6e\x84\xdf\xde\xe6\xb7\xb4\xa9\xaa\xd1\xfb\xf9\xe4\xdd\x68\xfe\x11\xbd\x55\x3e\xa2\xd3\xa8\xa5\x9d\x88\xca\x5c\x23\xa5\x3d\x6b\xda\xa8\x0c\x99\x6b\x17\x41\xa0\x68\x5a\x76\xdb\xa8\x5d\xb8\x4a\xc4\x31\x36\x77\xa1\x33\x5f\x44\x36\x64\x5c\x86\x48\x35\x8a\x50\x58\x34\xc6\xd0\x45\x77\x08\xc9\xdf\x1b\x62\x4d\xa0\xd2\x98\xd3\x14\x0b\xd9\x13\x7b\x5a\x44\x66\xcd\xfa\x4e\xcd\xee\x98\xa9\xf9\x5e\x54\x1b\xb1\xae\xa8\x96\x66\xdc\x51\xc4\xd0\x6a\x36\x81\xa9\x8e\x16\x83\x81\x7c\x31\x1e\x2b\xba\xc6\xf9\x39\x86\x57\xea\x54\xc6\x1e\x9f\x20\x0f\x37\x6b\x19\x5d\x09\xcf\x52\x0e\x2d\x69\xcb\x99\xdb\x7e\xc2\xb4\xd5\xac\xf5\x2c\x35\x3c\xfb\xb9\xd4\x84\x1e\x28\x25\x9b\x6c\xbd\x97\x98\x35\x99\xdd\x2a\x7f\xca\x1d\x1e\x85\xa2\x3c\x4c\x30\xb7\x9c\x6b\x57\x8b\xc9\xec\x1e\xad\x7d\x17\x63\x18\x7b\xf2\x29\xad\x51\xae\x54\xd8\x02\xdd\x5c\x2e\x95\x63\x1c\xce\x16\x00\x16\x4c\x24\xc7\x13\xcc\xa3\x04\x7c\x88\x79\xa6\x48\x25\x9d\xb8\xd8\x6c\xa2\xe9\xab\x3e\x9f\xf8\x86\xaf\x14\x23\xc6\x94\x59\x5e\xf9\x03\x6e\x70\xa6\x78\x3c\x3b\x16\x62\xd8\x93\xa5\x8f\x1e\x0a\x54\xc9\x83\x4d\x39\xba\xc5\xfb\x39\xcd\x12\x27\xee\xfe\x88\x4d\x28\xbe\x20\x69\x40\xee\xbb\x88\x86\xe9\xe7\xbf\xb8\x90\x20\x9f\x13\x2f\x51\x6f\x60\xa8\x17\x87\x35\xf5\x84\x9b\x36\xae\xdb\xa5\x23\x64\x1a\xb9\x5a\x51\x9b\x8b\x51\x31\x2d\x61\x98\xae\x93\x8d\x85\x3a\x7c\x22\x04\x39\x46\xc4\xe1\x7e\xbb\x7c\x8e\x4f\x4d\xbc\x2a\x0e\x82\x24\x6c\x3f\x82\x69\x5c\xab\x45\x84\x09\xb8\x3c\xed\xe4\x72\x7a\x81\x31\xed\x6e\x5a\x3b\xb9\x87\xc6\x22\x9b\x1d\x32\xd6\xa4\x69\xe8\xd2\x04\xb3\xfb\x3b\x6d\x74\x04\x69\xdb\x51\x9d\xa6\x78\xc7\x58\x79\xea\x8c\x82\xf1\x28\x4b\xe8\x06\xf8\xdf\x9b\x33\x20\xc6\x62\xc4\xf4\x91\x26\x14\x2f\x63\x95\x8d\x00\xaf\x05\xa3\xdb\x3e\xca\x86\x98\x7c\x86\x71\xac\xf3\xf9\x8e\x4e\xbf\x29\x08\xb2\x7a\x7d\x5f\x17\xe1\xf2\x94\x93\x0f\x24\x0a\x1c\xe9\x8c\xf2\x7e\x6d\x8a\x56\x09\x53\x6e\x7a\xa3\x11\xf4\xa3\x2e\xf1\xeb\x74\x6b\x86\x71\x7c\x48\x8a\xc2\xcf\x77\xf5\x40\x49\xfe\xaa\x6b\x0d\xc2\x65\x30\x82\xb9\x4e\xd6\xc6\xc4\x1d\x5b\x26\x41\xe2\x8a\x6c\x6d\x8e\x04\x9e\x88\x66\xf9\x86\x2e\x93\x69\x78\xa7\xb8\x36\xbf\x10\x45\xc4\x2a\xb9\xbe\x4c\xe7\x92\x70\x36\x6d\xfb\xf3\xc1\xa9\xc7\xa8\x88\x25\xed\xad\xe4\x5a\x2e\x95\x9f\xa3\x19\x6e\xf8\xb7\x74\x1a\x61\x48\xa2\xc9\x05\x5e\x4c\xb0\x5d\xba\x49\xdc\x2e\x5d\x2b\x67\x18\xd1\xc0\xc4\x13\xe3\x88\x18\x57\x4c\xef\x01\x6a\x63\xde\xad\xe0\x58\xa1\xdf\xa2\x73\xd5\xd2\xf1\x22\xd8\x13\x7f\x1b\x5b\xd7\xa1\x42\x05\x85\x35\x69\xf2\xad\x6f\xb1\xb4\x8f\x04\x2b\x70\xaf\x1f\x07\x3c\x6c\x31\x63\xca\x28\x2b\x02\xc6\x65\x64\x80\x17\x6c\x56\x1e\x1d\x0f\x5c\x54\x61\xdd\x1a\x08\x09\x88\xc6\x45\x40\x00\x99\x06\x51\x43\x6c\x69\xd0\xc2\xfa\x43\x36\x92\x73\xe0\x4d\x07\x43\x01\xfa\x98\x82\x89\x0d\x47\x7c\x08\xd9\xbc\xa3\x4b\x9f\x5a\x0a\xe9\x13\x2f\xc8\x1b\x93\xfb\xf2\xf5\xd9\xfc\x9f\xff\xba\x56\x64\x49\x4e\x56\xde\x08\xda\x77\xbc\xcf\x66\x0d\xf5\xa3\x61\x91\x59\xb4\x97\xe4\xed\x4b\x76\x01\x9e\xcd\xa6\xf4\x22\xbf\xc8\x0e\xe6\x76\x4d\x11\x3a\x77\xa1\xea\x19\x86\x36\x89\x4e\x5d\xc1\x55\x1d\xe0\x45\xd0\xe2\x1a\xa0\xa1\x11\xce\x53\x21\x63\x83\x60\x61\xc2\x55\xd6\x5c\xfa\x2a\x03\x4b\x71\x17\x27\xb1\x32\xb0\xaa\x59\xfa\xb3\xb9\x3f\xc3\x97\xe5\xdf\x46\x42\xaf\xe7\x57\xbc\xcf\x11\xfa\x65\xfc\xa3\xd7\xdb\x61\x21\x9a\x16\x23\xc9\x36\x9f\xba\x86\x8a\xf5\x68\x87\x73\x30\x85\x65\xce\xe9\x69\xf2\xa5\xee\xd9\xeb\xd7\xe8\xc4\xb3\x4d\x3d\x77\xb0\x7c\x72\x7d\x1d\x7c\x40\xd3\x6a\xb5\x11\x5b\x30\x38\xa4\x91\x12\x8c\xce\x4e\xd8\xa2\x6b\xfb\xb0\x7b\xf4\xa5\xd4\x17\x44\xf9\x04\x0a\xa2\x04\x85\x56\xf0\xc7\x0a\xe7\x4a\x14\x68\xe8\x37\x74\x7e\x2e\x7d\x27\xc3\xd0\xd5\x6d\xee\xc4\xf4\xee\xed\x8f\xb9\x99\x11\xab\x45\x77\x0f\x73\x65\x72\x3f\x4b\x4f\x43\xd1\x5c\xb9\x03\x4b\x66\x63\x65\x41\x9c\x62\x85\xad\x10\x06\xab\xf7\xb7\x41\xc8\xcc\x95\xe8\x2f\x38\x06\x8f\x6e\x95\xa9\x02\x8f\xc6\xa3\xc5\x78\x74\xab\xf0\x3f\xa9\xa6\x7f\x3a\x9b\xee\x32\x34\xe7\x8c\xa2\x1e\xc1\x79\x31\x8b\x49\xd1\x3f\xe4\xde\x0d\xd5\x59\xf1\x62\x45\x70\xb8\xce\xf4\x44\xbc\x1c\xff\xe9\x7e\xc8\xf3\xa0\x79\x21\xd9\xe9\xe0\x07\x4c\x35\x0f\x94\x37\x9d\x7e\xa2\x1b\x18\x64\x8a\xbe\xa0\x6c\x93\x35\x1b\x14\xe4\x36\xcd\xff\x83\x43\xd8\xa1\x51\xda\x07\x93\x8d\x0e\xd6\x1f\xbb\x46\x1b\x7b\xef\x98\xd8\xc7\xa1\x0d\xff\x03\x17\xa7\xc2\xcd\x19\x5b\x00\x00")
 
 func latestSqlBytes() ([]byte, error) {
 	return bindataRead(
@@ -94,7 +98,7 @@ func latestSql() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "latest.sql", size: 19857, mode: os.FileMode(420), modTime: time.Unix(1518565282, 0)}
+	info := bindataFileInfo{name: "latest.sql", size: 23321, mode: os.FileMode(420), modTime: time.Unix(1792119506, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
@@ -119,6 +123,86 @@ func migrations10_add_trades_priceSql() (*asset, error) {
 	return a, nil
 }
 
+var _migrations11_index_operations_by_typeSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x95\x4e\xbb\x0e\x82\x30\x14\xdd\xfb\x15\x67\xd4\x68\xbf\x80\xc9\x08\x31\x2c\x40\x50\x12\xb7\xa6\xa4\x57\xec\x40\x2f\x69\x9b\x20\x7f\x6f\x61\xd0\xc1\xc9\xf1\xbc\x8f\x94\x38\x8c\x76\xf0\x3a\x12\xba\x49\x08\x29\xd1\xe8\x81\x02\xf8\x01\x9e\x28\xf1\x96\xdd\x86\x02\x8f\x84\xb8\x4c\x49\xd3\x9e\x12\xf4\x91\x0c\xfa\x05\xd6\x88\x73\x5b\x9c\x6e\x05\xca\x2a\x2f\xee\xb0\xce\xd0\x4b\x3d\x6d\x88\xec\x17\xf5\x2d\x51\xec\xd4\x9a\x57\xda\x19\x65\x0d\xea\x0a\xbf\x26\x74\xd7\xb2\xba\xa0\x8f\x9e\x08\xbb\xd5\x7e\x4c\x03\xfb\x6c\x7b\xf6\x79\x9a\xf3\xec\x84\xc8\xdb\xba\xf9\x6f\x33\x13\x6f\x17\x44\x7f\x7e\xf0\x00\x00\x00")
+
+func migrations11_index_operations_by_typeSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrations11_index_operations_by_typeSql,
+		"migrations/11_index_operations_by_type.sql",
+	)
+}
+
+func migrations11_index_operations_by_typeSql() (*asset, error) {
+	bytes, err := migrations11_index_operations_by_typeSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations/11_index_operations_by_type.sql", size: 240, mode: os.FileMode(420), modTime: time.Unix(1792115738, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _migrations12_index_trades_by_accountSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xd3\xd5\x55\xd0\xce\xcd\x4c\x2f\x4a\x2c\x49\x55\x08\x2d\xe0\xe2\x72\x0e\x72\x75\x0c\x71\x55\xf0\xf4\x73\x71\x8d\x50\xc8\x28\x29\x4a\x89\x4f\xaa\x8c\x4f\x4a\x2c\x4e\x8d\x4f\x4c\x4e\xce\x2f\xcd\x2b\x51\xf0\xf7\x53\xc8\xc8\x2c\x2e\xc9\x2f\xaa\x8c\x2f\x29\x4a\x4c\x49\x2d\x56\x08\x0d\xf6\xf4\x73\x57\x48\x2a\x29\x4a\x4d\x55\xd0\x40\x56\x1b\x9f\x99\xa2\x69\x8d\xdd\x48\xb0\x7c\x6a\x11\xb1\xa6\xa2\x29\x87\x18\xcc\xa5\x8b\xe4\x78\x97\xfc\xf2\x3c\x2e\x2e\x97\x20\xff\x00\x3c\x8e\xb7\xc6\xa6\x00\xcd\x6c\x6b\x2e\x00\xc9\xd9\xde\xe8\x14\x01\x00\x00")
+
+func migrations12_index_trades_by_accountSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrations12_index_trades_by_accountSql,
+		"migrations/12_index_trades_by_account.sql",
+	)
+}
+
+func migrations12_index_trades_by_accountSql() (*asset, error) {
+	bytes, err := migrations12_index_trades_by_accountSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations/12_index_trades_by_account.sql", size: 276, mode: os.FileMode(420), modTime: time.Unix(1792115794, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _migrations13_create_audit_submissions_tableSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8d\x52\xcb\x6e\x83\x30\x10\xbc\xfb\x2b\xf6\x08\x6a\x39\x54\xaa\x72\xc9\xc9\x05\xab\x41\x22\x10\x11\xd3\x26\xbd\x58\xc6\x58\x0d\x12\x31\x08\x9b\x3e\xfe\xbe\x10\x2a\x4a\x92\x26\x64\x8f\xeb\xd9\x9d\xf1\xcc\x3a\x0e\xdc\xed\xf3\xf7\x9a\x1b\x09\x49\x85\x90\x1b\x13\x4c\x09\x50\xfc\x14\x10\xe0\x4d\x96\x1b\xa6\x9b\x74\x9f\x6b\x9d\x97\x4a\x83\x85\xa0\x2d\x53\x73\xa5\xb9\x30\x6d\x8b\xed\xb8\xde\x75\x3d\x77\x81\x63\xec\x52\x12\xc3\x0b\x8e\xb7\x7e\xf8\x6c\xcd\x1e\xed\xfb\x03\x5c\x97\x4d\x2d\x24\xe3\x42\x94\x8d\x32\x00\x13\x70\xa9\x3e\x64\x51\x56\x92\x7d\x65\x35\xfc\x16\x25\x1b\x0a\x17\x2a\x8c\x28\x84\x49\x10\xf4\xd3\xb5\xd4\x4d\x61\x8e\x11\xe7\x64\x0f\x33\xfb\xca\xf4\x98\xb9\xe7\xee\x5f\x45\x91\x4b\x65\x58\x5e\x5d\xdd\xfd\xf7\xef\xce\x38\x63\x64\xc6\xf8\x20\x88\xfa\x4b\xb2\xa6\x78\xb9\x82\x57\x9f\x2e\xa2\x84\x1e\x3a\xf0\x16\x85\x64\x90\x82\xec\xf9\x10\x84\x1f\x7a\x64\x73\x1e\x04\x4b\xbf\x7b\xe7\xa3\xf0\x9f\x94\x92\x75\xab\x03\x52\x53\x4b\x09\xd6\x69\x58\xed\xf2\xe9\xdd\x27\x91\x4d\xb3\x1c\x0f\xdc\xc6\x31\xb6\xe7\x06\x86\x11\xbc\x33\xc8\x19\x5d\xae\x57\x7e\x2a\x84\xbc\x38\x5a\x5d\xbc\x5c\xc1\xb5\xe0\x99\x9c\xa3\x1f\x31\x10\xfa\x7b\xf2\x02\x00\x00")
+
+func migrations13_create_audit_submissions_tableSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrations13_create_audit_submissions_tableSql,
+		"migrations/13_create_audit_submissions_table.sql",
+	)
+}
+
+func migrations13_create_audit_submissions_tableSql() (*asset, error) {
+	bytes, err := migrations13_create_audit_submissions_tableSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations/13_create_audit_submissions_table.sql", size: 754, mode: os.FileMode(420), modTime: time.Unix(1792117341, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _migrations14_create_account_index_tablesSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8d\x52\x41\x6e\x83\x30\x10\xbc\xfb\x15\x7b\x04\x15\x0e\x8d\xda\x5c\x38\xb9\x60\x25\xa8\xd4\x44\xae\xa9\x9a\x13\x72\x88\x45\x91\x5a\x88\xb0\x51\x94\xdf\x97\x96\x92\x80\xc0\x15\x7b\xb2\xac\xd9\x99\x9d\xd9\x75\x5d\xb8\xfb\x2a\xf2\x5a\x68\x09\xc9\x09\x21\x9f\x11\xcc\x09\x70\xfc\x14\x11\x10\x59\x56\x35\xa5\x56\xa9\x2a\xf2\x52\xd6\x0a\x2c\x04\x6d\xfd\x7d\xff\x3c\xc1\xdf\x62\x86\x7d\x4e\x18\xbc\x61\xb6\x0f\xe9\xc6\x5a\x3f\xd8\x40\x63\x0e\x34\x89\x22\xe7\x17\xdf\x75\xc3\x62\xfc\x59\x16\xf9\x47\x47\x0f\x21\xe5\x64\xd3\xa2\xc7\x35\xc6\xef\x58\xf8\xd2\x92\xc1\x33\xd9\x83\xd5\x89\x39\xfd\x90\x36\xb2\xbd\xab\xab\x90\x06\xe4\x7d\xe2\x2a\x3d\x5c\xd2\xde\x52\x4c\xa7\xa6\x93\xd7\x76\x4c\x38\xe8\x5a\x4a\xb0\x7a\x5a\xcf\x14\x95\xae\x1b\xa5\x3f\x8b\x52\xce\xa5\xb5\x34\x01\xa1\x94\xd4\xa9\xbe\x9c\xe4\xf2\x14\xba\x9e\xac\x3a\x4a\x93\xce\xfd\x6a\x5e\xa7\x50\xaa\xe9\xf6\x33\xed\x79\x5c\xdb\xff\xa5\x7d\x13\x75\x46\x64\x8b\xf2\xbf\x45\x65\x5a\xc1\x20\x4c\xd3\x16\xdc\xc1\x01\x07\xd5\xb9\x44\x28\x60\xf1\xce\x74\xc0\x99\x50\x99\x38\x4a\x6f\x16\x34\x50\xbb\xe2\xbe\x01\x77\x4e\x2c\x4b\x20\x03\x00\x00")
+
+func migrations14_create_account_index_tablesSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrations14_create_account_index_tablesSql,
+		"migrations/14_create_account_index_tables.sql",
+	)
+}
+
+func migrations14_create_account_index_tablesSql() (*asset, error) {
+	bytes, err := migrations14_create_account_index_tablesSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations/14_create_account_index_tables.sql", size: 800, mode: os.FileMode(420), modTime: time.Unix(1792119506, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
 var _migrations1_initial_schemaSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xc4\x5a\x5f\x6f\xdb\xc8\x11\x7f\xf7\xa7\x18\xdc\x8b\x6c\xd4\x6a\x2f\xb8\xe2\x70\x95\xe1\x03\x14\x99\x69\x84\xca\x54\x22\x51\x4d\x82\xc3\x61\xb1\x22\x47\xd4\xd6\xe4\x2e\xb3\xbb\x74\xa4\x2b\xfa\xdd\x0b\x52\x24\xc5\xff\xa4\x1c\xc9\xf7\x28\xee\xec\xcc\xfc\x66\x66\x7f\x33\x5c\x6a\x38\x84\xbf\xf8\xcc\x95\x54\x23\xac\x82\xab\xe1\xf0\x6a\x38\x84\x0f\x42\x69\x57\xe2\xf2\xe3\x0c\x1c\xaa\xe9\x9a\x2a\x04\x27\xf4\xe3\xe5\xab\xa5\x61\x81\xd2\x54\xa3\x8f\x5c\x13\xcd\x7c\x14\xa1\x86\x7b\xf8\xf1\x2e\x5e\xf2\x84\xfd\x54\x7d\x6a\x7b\x2c\x92\x46\x6e\x0b\x87\x71\x17\xee\x61\xb0\xb2\xde\xfd\x32\xb8\x4b\xd5\x71\x87\x4a\x87\xd8\x82\x6f\x84\xf4\x19\x77\x89\xd2\x92\x71\x57\xc1\x3d\x08\x9e\xe8\xd8\xa2\xfd\x44\x36\x21\xb7\x35\x13\x9c\xac\x85\xc3\x30\x5a\xdf\x50\x4f\x61\xc1\x8c\xcf\x38\xf1\x51\x29\xea\xc6\x02\xdf\xa8\xe4\x8c\xbb\x77\x57\x09\x3c\x93\xfa\x38\x82\xc0\x0b\x5c\xf5\xd5\xbb\x03\x6b\x1f\xe0\x08\x8c\xcf\x96\x61\x2e\xa7\x73\xf3\x0e\x96\xf6\x16\x7d\x3a\x82\xe1\x1d\xcc\xbf\x71\x94\x23\x18\xc6\xc8\x27\x0b\x63\x6c\x19\x47\x49\x98\xbe\x03\x73\x6e\x81\xf1\x79\xba\xb4\x96\xa9\x42\xf8\x34\xb5\xde\xc3\x72\xf2\xde\x78\x1c\x43\xe0\x12\x9b\x6a\xea\x89\xc8\x7a\xc1\xfc\x51\x4b\xc9\x91\xc9\xfc\xf1\xd1\x30\xad\x16\x37\x0e\x02\x30\x37\xab\x4a\x60\xba\x84\xc1\x87\xd9\xdf\x02\x37\x4a\x5e\x20\x85\x8d\x4e\x28\xa9\x07\x1e\xe5\x6e\x48\x5d\x1c\x94\xfd\xd8\x2a\x2d\x24\x9e\x2f\x0a\x07\x7d\xc5\x20\x84\x6b\x8f\xd9\xcd\x01\x28\xba\xf0\x32\xfc\x89\xd9\x08\x7e\x54\xb2\xa0\xf7\x01\xc2\x46\x48\x88\x9e\x47\x15\xa7\x50\x2b\x10\x1b\xb8\x7e\xc2\xfd\x2d\x3c\x53\x2f\xc4\x1b\x08\x28\x93\x2a\x0e\x49\x5c\x86\x48\xa5\xbd\x25\x01\xd5\x5b\xb8\x4f\xbc\xbe\x2d\xa6\x30\x12\x73\x70\x43\x43\x4f\x13\x4d\xd7\x1e\xaa\x80\xda\x18\x95\xf3\xa0\xb4\xfa\x8d\xe9\x2d\x11\xcc\xc9\x55\x68\x31\xee\x2c\xf2\x6c\x4f\xa8\x6d\x8b\x90\x6b\x95\xc2\xb7\xc6\x6f\x67\xc6\x11\x7c\x12\xbb\x2c\x02\x77\x60\x65\x66\x47\xf9\x7c\xc4\xfb\x2a\x5a\xe1\xfa\x0a\x00\x80\x39\xb0\x66\x2e\xe3\x3a\xce\x94\xb9\x9a\xcd\x6e\xe3\xe7\xd4\x71\x24\x2a\x05\xf6\x96\x4a\x6a\x6b\x94\xf0\x4c\xe5\x9e\x71\xf7\xfa\xe7\xbf\xdf\x5c\xdd\x54\x6a\x25\xd1\x8e\x9b\x0d\xda\xe7\x76\x39\x51\x9a\x78\x5c\x02\x42\x9a\x10\xa4\x72\x22\x40\x49\x63\x5e\x68\x92\xfc\x41\x48\x07\xe5\x0f\xc0\xb8\x46\x17\x65\x69\x35\xae\x97\xfa\x25\x07\x35\x65\x9e\x82\xff\x28\xc1\xd7\xcd\x41\xf1\xd0\x71\x51\x9e\x39\x28\x89\xd2\x24\x28\x0a\xbf\x86\xc8\xed\x26\x47\x0f\xc2\x64\x4b\xd5\xb6\x3e\xa3\x25\xf9\x40\xe2\x33\x13\xa1\x22\x9d\x1b\x93\x18\x49\xca\x15\x3d\xb0\x6f\x9c\x95\xcc\x8f\x07\xe3\xdd\x78\x35\xb3\xe0\xc7\x92\x85\x63\x56\xfa\xc9\xdb\x9e\x50\xe8\x10\xaa\x21\xea\x20\x4a\x53\x3f\x80\xe8\x20\x45\xbd\x24\x7a\x02\x7f\x08\x8e\xe5\x3d\x12\xa9\xee\xdc\x74\x90\x0d\x03\xa7\xb7\x6c\x56\x47\xc9\x4f\x3f\x10\x52\xa3\x24\xcf\x28\x15\x13\xbc\x82\xe5\x4d\xb9\xa2\x84\xa6\x1e\xb1\x05\xe3\xaa\xbe\x20\x37\x88\x24\x10\xc2\xab\x5f\x8d\x9a\x2e\xd9\x60\x53\xae\xe3\x65\x89\x0a\xe5\x73\x93\x88\x4f\x77\x44\xef\x88\x42\x4d\x14\xfb\xa3\x2a\xd5\x5c\xca\xc7\xb4\x05\x54\x6a\x66\xb3\x80\x9e\x9d\xa1\xea\x6d\x1c\xf9\xaa\x1e\x53\xff\xe3\xde\x4d\x20\xa7\xe2\x27\xcc\x21\x0a\xbf\xa6\x61\x58\x1a\x1f\x57\x86\x39\x69\x89\x44\x1e\x7c\x2a\xdd\xcf\x46\x8c\x60\x69\x8d\x17\xd6\xa1\x91\xbe\x89\x1f\x4c\xcd\xc9\xc2\x88\x5b\xdf\xdb\x2f\xc9\x23\x73\x0e\x8f\x53\xf3\xdf\xe3\xd9\xca\xc8\x7e\x8f\x3f\x1f\x7f\x4f\xc6\x93\xf7\x06\xbc\x39\x0b\x50\x98\x7f\x32\x8d\x07\x78\xfb\xa5\x03\xf1\x78\x66\x19\x8b\x13\x01\x67\xba\x3b\xc4\xff\xca\x9c\x4e\x2c\x97\x2a\xd4\xae\x66\x9a\xa7\xc7\xc6\x86\x1b\x04\x1e\xb3\x0f\xb8\xe2\x7e\xf4\x9d\xed\xe8\xf0\x48\x89\x50\xda\x98\x96\x7a\x03\xf7\xa7\x3c\x35\x18\x8c\x46\x15\x89\x1e\x87\x22\x0f\xef\x72\xb4\xd0\x64\x25\x8e\x7d\x03\x2d\xd4\xed\xad\x4f\xc0\xf7\x90\x42\x93\x67\xe7\xa5\x85\x0e\x2b\xaf\x45\x0c\x27\x82\xfd\x4e\x6a\xe8\xb0\x56\x25\x87\xa6\x0d\x2d\xf4\x90\xdb\x72\xb9\x92\x4d\x29\x22\xef\x5f\xef\x71\x2c\x99\xc2\x3a\x86\xbc\xbe\x0c\xd2\x4e\x06\xb5\xb2\x47\xd3\xcd\xf3\x0a\x6d\x6c\xcd\x4d\xb3\xde\x9f\x32\xad\xe9\x1d\x41\xfe\x8c\x9e\x08\x10\x34\xee\x2a\x54\xbd\x8b\x66\xa7\xd0\xd3\x0d\x8b\x3e\x46\xaf\x90\xb5\x4b\x51\x14\x9a\x96\x15\x73\x39\xd5\xa1\xc4\xba\x37\xaa\x7f\xfc\x7c\xf3\xdb\xef\x47\x16\xfe\xef\xff\xea\x78\xf8\xb7\xdf\xcb\x43\x1c\xfa\x82\xc4\xdd\xa0\xca\xd9\x99\x2e\x2e\x38\xb6\xb2\xfa\x51\x57\x55\x4d\x82\x8c\xf9\x48\xd6\x22\xe4\x8e\x8a\x32\xf7\x8b\xa4\xdc\xc5\x98\x0c\xf3\x87\x89\x39\xe9\xd1\x49\x6c\xf7\x3a\xef\x87\xe3\x32\x37\x67\x5d\xdd\x1d\x0e\xf2\x93\xf9\x6c\xf5\x68\x46\x29\x8d\x5e\xa8\x53\x94\x1c\x77\xfa\x99\x7a\xd7\x83\x5e\x03\xc5\x60\x34\x92\xe8\xda\x1e\x55\xaa\xc2\xe8\x67\x43\xd1\xd8\xac\x4e\xc2\xd1\xc1\x7e\x6d\x48\x3a\x42\x11\x3c\xe1\xfe\x78\xad\x62\x2e\xad\xc5\x78\x6a\xb6\xa0\xad\x12\xde\x89\x09\x8c\x4b\x69\xfc\xf0\x90\xb3\xd6\xc7\x47\xf8\xb0\x98\x3e\x8e\x17\x5f\xe0\x5f\xc6\x17\xb8\x66\xce\xe9\x3d\xf8\x82\x48\x9b\x6c\xb6\x61\x6d\xf5\xb3\x13\xed\x3a\x1b\x50\x52\x48\x53\xf3\xc1\xf8\xfc\x82\x46\x15\xef\xcb\xe9\x83\xb9\x59\xdf\xb6\x56\xcb\xa9\xf9\x4f\x58\x6b\x89\x08\xd7\x89\xf0\x6d\xa5\x2f\xd4\x79\x1a\xb5\xb7\xb3\xb9\x19\xf7\xca\x5e\x3e\x96\x3b\x6c\x9d\x6b\x87\x86\x7a\x36\xe7\x0e\xea\xfa\xb9\x57\xea\xe5\xb7\xd5\xb6\x5d\x5b\xe3\x04\xc9\x7a\x7f\x58\xff\x5e\xb7\x57\xe6\xf4\xe3\x2a\xf5\xbe\xa4\x3b\x8f\x21\xbd\x76\x2b\xb8\x5f\xf7\x9a\x7d\x9b\xde\xa0\x35\x79\x7e\xa4\xd5\x73\xfa\xcc\x9c\xde\xde\x1e\xa7\xfa\xdb\xda\x8b\x82\x0e\x04\x22\x20\xc1\x45\x40\x24\x8a\xf3\x38\x1a\xfa\xdf\x8b\x60\x55\xd1\x64\x37\x7a\xeb\xfd\xd9\x01\x15\x75\xe7\x31\xa5\x77\x95\x05\x10\xf5\xee\xe5\x4f\xef\x45\x7c\xac\x18\xe8\x77\x6c\x6b\xbc\x65\xdc\xc1\x1d\x29\xdf\xab\x13\xc1\x49\x72\x79\x7e\x56\xd7\x3b\xad\xe5\x71\x64\x97\xfc\x45\xf6\x3e\x08\x9e\x00\xe4\xcc\xe1\x6f\x33\xd4\xed\x7e\x67\x0a\x12\x0a\x88\xf4\x45\x73\xf1\x79\xe8\xbd\xd5\x44\x27\x01\x45\x42\x1d\x5e\x27\x87\x23\x52\x99\x5d\x72\x5f\xc2\xf5\x3a\x3b\x9d\x87\x34\x93\xec\x0f\xe2\xa2\x35\x53\xb0\xf3\x12\x8a\x69\x56\x57\xba\xc5\xbf\x70\x0a\x2a\x1f\x0d\x3a\xb1\x94\x36\xf4\x47\x96\xfb\x86\xf3\x3a\x99\xc9\x7f\x34\xea\x82\x95\x93\xed\x8f\xa8\xee\xf3\xd4\xeb\x40\xab\xfd\x30\xd6\x85\xb1\x6e\x53\x7f\xb0\xe9\xa4\xf8\x3a\x00\xb3\x8b\x9e\x2e\x50\x8d\x93\x7f\x51\xf5\xf1\x8e\xfc\xe2\xdc\x50\x36\x55\x3b\x55\x9d\xca\x10\x45\xa5\xc5\x7b\xe4\x4b\x50\x44\x9b\xbd\x3e\x80\x8a\x3b\x4e\x03\x77\xa1\x9e\x59\xb5\xd2\x0b\x48\x5d\xe7\x8c\x87\x66\xbd\xbb\xd0\x34\x9e\x28\x6e\x18\x08\x5f\x38\x8f\x57\x13\xd2\x9c\x8f\xfc\xf8\x79\xf1\xe3\x52\x35\xf6\xe2\x49\x58\x4b\xea\x60\x36\x1b\xa5\xef\x92\x64\x2d\xc4\xd3\x79\x0a\xaa\xc5\x40\xe7\x08\x76\x7d\x9d\x7e\x17\x1b\xfe\xfa\x2b\x0c\x94\xf0\x1c\x42\x95\x42\x1d\x97\xe2\x60\x34\xd2\xb8\xd3\x37\x37\xb7\xd0\x2c\x68\x0b\xa7\x9f\x20\x53\x2a\x44\xd9\x2c\xba\x16\xa1\xbb\xd5\xbd\xcc\x17\x44\xdb\x1d\x28\x88\x96\x5c\xb8\x81\x4f\xef\x8d\x85\x71\x38\x4f\x70\x0f\x3f\xfd\x94\xcb\x5e\xd3\xbf\xf9\xc0\x16\x7e\xe0\xa1\xc6\x38\x13\xf9\x3f\x02\x3e\x88\x6f\xfc\xca\x91\x22\x80\xf8\x3f\x4e\xf5\xe5\x62\x53\x65\x53\x07\xef\x3a\x04\x8b\x07\xaa\x6d\x53\x8e\x23\x7a\x89\xf5\xd7\x9c\xb6\xb6\x36\x99\xb4\xaa\xda\x64\xb2\x37\x96\x4c\xe8\xff\x01\x00\x00\xff\xff\x5d\xb2\x1f\x7d\x3f\x29\x00\x00")
 
 func migrations1_initial_schemaSqlBytes() ([]byte, error) {
@@ -373,6 +457,10 @@ func AssetNames() []string {
 var _bindata = map[string]func() (*asset, error){
 	"latest.sql": latestSql,
 	"migrations/10_add_trades_price.sql": migrations10_add_trades_priceSql,
+	"migrations/11_index_operations_by_type.sql": migrations11_index_operations_by_typeSql,
+	"migrations/12_index_trades_by_account.sql": migrations12_index_trades_by_accountSql,
+	"migrations/13_create_audit_submissions_table.sql": migrations13_create_audit_submissions_tableSql,
+	"migrations/14_create_account_index_tables.sql": migrations14_create_account_index_tablesSql,
 	"migrations/1_initial_schema.sql": migrations1_initial_schemaSql,
 	"migrations/2_index_participants_by_toid.sql": migrations2_index_participants_by_toidSql,
 	"migrations/3_use_sequence_in_history_accounts.sql": migrations3_use_sequence_in_history_accountsSql,
@@ -428,6 +516,10 @@ var _bintree = &bintree{nil, map[string]*bintree{
 	"latest.sql": &bintree{latestSql, map[string]*bintree{}},
 	"migrations": &bintree{nil, map[string]*bintree{
 		"10_add_trades_price.sql": &bintree{migrations10_add_trades_priceSql, map[string]*bintree{}},
+		"11_index_operations_by_type.sql": &bintree{migrations11_index_operations_by_typeSql, map[string]*bintree{}},
+		"12_index_trades_by_account.sql": &bintree{migrations12_index_trades_by_accountSql, map[string]*bintree{}},
+		"13_create_audit_submissions_table.sql": &bintree{migrations13_create_audit_submissions_tableSql, map[string]*bintree{}},
+		"14_create_account_index_tables.sql": &bintree{migrations14_create_account_index_tablesSql, map[string]*bintree{}},
 		"1_initial_schema.sql": &bintree{migrations1_initial_schemaSql, map[string]*bintree{}},
 		"2_index_participants_by_toid.sql": &bintree{migrations2_index_participants_by_toidSql, map[string]*bintree{}},
 		"3_use_sequence_in_history_accounts.sql": &bintree{migrations3_use_sequence_in_history_accountsSql, map[string]*bintree{}},
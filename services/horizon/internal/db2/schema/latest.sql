@@ -96,6 +96,29 @@ SET default_tablespace = '';
 
 SET default_with_oids = false;
 
+--
+-- Name: accounts_signers; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE accounts_signers (
+    account character varying(64) NOT NULL,
+    signer character varying(64) NOT NULL,
+    weight integer NOT NULL
+);
+
+
+--
+-- Name: accounts_trustlines; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE accounts_trustlines (
+    account character varying(64) NOT NULL,
+    asset_type integer NOT NULL,
+    asset_code character varying(12) NOT NULL,
+    asset_issuer character varying(56) NOT NULL
+);
+
+
 --
 -- Name: asset_stats; Type: TABLE; Schema: public; Owner: -
 --
@@ -109,6 +132,21 @@ CREATE TABLE asset_stats (
 );
 
 
+--
+-- Name: audit_submissions; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE audit_submissions (
+    transaction_hash character varying(64),
+    source_account character varying(64),
+    envelope_xdr text NOT NULL,
+    result character varying(16) NOT NULL,
+    result_xdr text,
+    client_ip character varying(64),
+    submitted_at timestamp without time zone NOT NULL
+);
+
+
 --
 -- Name: gorp_migrations; Type: TABLE; Schema: public; Owner: -
 --
@@ -355,12 +393,30 @@ ALTER TABLE ONLY history_operation_participants ALTER COLUMN id SET DEFAULT next
 ALTER TABLE ONLY history_transaction_participants ALTER COLUMN id SET DEFAULT nextval('history_transaction_participants_id_seq'::regclass);
 
 
+--
+-- Data for Name: accounts_signers; Type: TABLE DATA; Schema: public; Owner: -
+--
+
+
+
+--
+-- Data for Name: accounts_trustlines; Type: TABLE DATA; Schema: public; Owner: -
+--
+
+
+
 --
 -- Data for Name: asset_stats; Type: TABLE DATA; Schema: public; Owner: -
 --
 
 
 
+--
+-- Data for Name: audit_submissions; Type: TABLE DATA; Schema: public; Owner: -
+--
+
+
+
 --
 -- Data for Name: gorp_migrations; Type: TABLE DATA; Schema: public; Owner: -
 --
@@ -376,6 +432,10 @@ INSERT INTO gorp_migrations VALUES ('8_add_aggregators.sql', '2018-02-13 15:41:2
 INSERT INTO gorp_migrations VALUES ('8_create_asset_stats_table.sql', '2018-02-13 15:41:22.468047-08');
 INSERT INTO gorp_migrations VALUES ('9_add_header_xdr.sql', '2018-02-13 15:41:22.476629-08');
 INSERT INTO gorp_migrations VALUES ('10_add_trades_price.sql', '2018-02-13 15:41:22.482553-08');
+INSERT INTO gorp_migrations VALUES ('11_index_operations_by_type.sql', '2018-02-13 15:41:22.490113-08');
+INSERT INTO gorp_migrations VALUES ('12_index_trades_by_account.sql', '2018-02-13 15:41:22.497561-08');
+INSERT INTO gorp_migrations VALUES ('13_create_audit_submissions_table.sql', '2018-02-13 15:41:22.505118-08');
+INSERT INTO gorp_migrations VALUES ('14_create_account_index_tables.sql', '2018-02-13 15:41:22.512034-08');
 
 
 --
@@ -460,6 +520,22 @@ SELECT pg_catalog.setval('history_transaction_participants_id_seq', 1, false);
 
 
 
+--
+-- Name: accounts_signers accounts_signers_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY accounts_signers
+    ADD CONSTRAINT accounts_signers_pkey PRIMARY KEY (signer, account);
+
+
+--
+-- Name: accounts_trustlines accounts_trustlines_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY accounts_trustlines
+    ADD CONSTRAINT accounts_trustlines_pkey PRIMARY KEY (asset_code, asset_issuer, account);
+
+
 --
 -- Name: asset_stats asset_stats_pkey; Type: CONSTRAINT; Schema: public; Owner: -
 --
@@ -508,6 +584,20 @@ ALTER TABLE ONLY history_transaction_participants
     ADD CONSTRAINT history_transaction_participants_pkey PRIMARY KEY (id);
 
 
+--
+-- Name: accounts_signers_by_account; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX accounts_signers_by_account ON accounts_signers USING btree (account);
+
+
+--
+-- Name: accounts_trustlines_by_account; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX accounts_trustlines_by_account ON accounts_trustlines USING btree (account);
+
+
 --
 -- Name: asset_by_code; Type: INDEX; Schema: public; Owner: -
 --
@@ -522,6 +612,27 @@ CREATE INDEX asset_by_code ON history_assets USING btree (asset_code);
 CREATE INDEX asset_by_issuer ON history_assets USING btree (asset_issuer);
 
 
+--
+-- Name: audit_submissions_by_hash; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX audit_submissions_by_hash ON audit_submissions USING btree (transaction_hash);
+
+
+--
+-- Name: audit_submissions_by_source_account; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX audit_submissions_by_source_account ON audit_submissions USING btree (source_account);
+
+
+--
+-- Name: audit_submissions_by_submitted_at; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX audit_submissions_by_submitted_at ON audit_submissions USING btree (submitted_at);
+
+
 --
 -- Name: by_account; Type: INDEX; Schema: public; Owner: -
 --
@@ -599,6 +710,20 @@ CREATE UNIQUE INDEX hs_transaction_by_id ON history_transactions USING btree (id
 CREATE INDEX htp_by_htid ON history_transaction_participants USING btree (history_transaction_id);
 
 
+--
+-- Name: htrd_by_base_account; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX htrd_by_base_account ON history_trades USING btree (base_account_id);
+
+
+--
+-- Name: htrd_by_counter_account; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX htrd_by_counter_account ON history_trades USING btree (counter_account_id);
+
+
 --
 -- Name: htrd_by_offer; Type: INDEX; Schema: public; Owner: -
 --
@@ -718,6 +843,13 @@ CREATE INDEX index_history_operations_on_transaction_id ON history_operations US
 CREATE INDEX index_history_operations_on_type ON history_operations USING btree (type);
 
 
+--
+-- Name: index_history_operations_on_type_and_id; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX index_history_operations_on_type_and_id ON history_operations USING btree (type, id);
+
+
 --
 -- Name: index_history_transactions_on_id; Type: INDEX; Schema: public; Owner: -
 --
@@ -3,6 +3,7 @@ package schema
 import (
 	"database/sql"
 	"errors"
+	"time"
 
 	migrate "github.com/rubenv/sql-migrate"
 	"github.com/stellar/go/support/db"
@@ -22,6 +23,11 @@ const (
 	MigrateRedo MigrateDir = "redo"
 )
 
+// lockID is the key of the postgres advisory lock held while migrating, so
+// that horizon instances deployed at the same time cannot race to migrate
+// the same database.
+const lockID = 0x686f72697a6f6e // "horizon"
+
 // Migrations represents all of the schema migration for horizon
 var Migrations migrate.MigrationSource = &migrate.AssetMigrationSource{
 	Asset:    Asset,
@@ -34,8 +40,11 @@ func Init(db *db.Session) error {
 	return db.ExecAll(string(MustAsset("latest.sql")))
 }
 
-// Migrate performs schema migration.  Migrations can occur in one of three
-// ways:
+// Migrate performs schema migration.  Each migration runs in its own
+// transaction, so that a failing migration leaves the schema at the version
+// of the last successful one, and the migrations are run holding an advisory
+// lock, so that concurrent calls wait for each other.  Migrations can occur
+// in one of three ways:
 //
 // - up: migrations are performed from the currently installed version upwards.
 // If count is 0, all unapplied migrations will be run.
@@ -47,6 +56,12 @@ func Init(db *db.Session) error {
 // upward back to the current version at the start of the process. If count is
 // 0, a count of 1 will be assumed.
 func Migrate(db *sql.DB, dir MigrateDir, count int) (int, error) {
+	unlock, err := lock(db)
+	if err != nil {
+		return 0, err
+	}
+	defer unlock()
+
 	switch dir {
 	case MigrateUp:
 		return migrate.ExecMax(db, "postgres", Migrations, migrate.Up, count)
@@ -68,3 +83,87 @@ func Migrate(db *sql.DB, dir MigrateDir, count int) (int, error) {
 		return 0, errors.New("Invalid migration direction")
 	}
 }
+
+// MigrationStatus is the status of a migration of the schema.
+type MigrationStatus struct {
+	ID string
+	// AppliedAt is when the migration was applied, or nil when it is pending.
+	AppliedAt *time.Time
+	// Unknown is true for the applied migrations this version of horizon
+	// doesn't know of, applied by a later version.
+	Unknown bool
+}
+
+// Status returns the status of each migration of the schema, in the order
+// they are applied.
+func Status(db *sql.DB) ([]MigrationStatus, error) {
+	migrations, err := Migrations.FindMigrations()
+	if err != nil {
+		return nil, err
+	}
+
+	records, err := migrate.GetMigrationRecords(db, "postgres")
+	if err != nil {
+		return nil, err
+	}
+
+	applied := map[string]time.Time{}
+	for _, r := range records {
+		applied[r.Id] = r.AppliedAt
+	}
+
+	var result []MigrationStatus
+	for _, m := range migrations {
+		status := MigrationStatus{ID: m.Id}
+		if at, ok := applied[m.Id]; ok {
+			status.AppliedAt = &at
+			delete(applied, m.Id)
+		}
+		result = append(result, status)
+	}
+
+	for _, r := range records {
+		if _, ok := applied[r.Id]; !ok {
+			continue
+		}
+		at := r.AppliedAt
+		result = append(result, MigrationStatus{ID: r.Id, AppliedAt: &at, Unknown: true})
+	}
+
+	return result, nil
+}
+
+// Plan returns the migrations Migrate would run in direction `dir`, with
+// their sql, without running them.  Redo cannot be planned.
+func Plan(db *sql.DB, dir MigrateDir, count int) ([]*migrate.PlannedMigration, error) {
+	var direction migrate.MigrationDirection
+	switch dir {
+	case MigrateUp:
+		direction = migrate.Up
+	case MigrateDown:
+		direction = migrate.Down
+	default:
+		return nil, errors.New("Invalid migration direction")
+	}
+
+	planned, _, err := migrate.PlanMigration(db, "postgres", Migrations, direction, count)
+	return planned, err
+}
+
+// lock blocks until it holds the migration lock, returning the func releasing
+// it.  The lock is held by a transaction left open meanwhile, so that it is
+// released even if the process dies.
+func lock(db *sql.DB) (func(), error) {
+	tx, err := db.Begin()
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = tx.Exec("SELECT pg_advisory_xact_lock($1)", lockID)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	return func() { tx.Rollback() }, nil
+}
@@ -1,11 +1,13 @@
 package schema
 
 import (
+	"sync"
 	"testing"
 
 	"github.com/stellar/go/support/db"
 	"github.com/stellar/go/support/db/dbtest"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestInit(t *testing.T) {
@@ -19,3 +21,69 @@ func TestInit(t *testing.T) {
 
 	assert.NoError(t, err)
 }
+
+func TestMigrate(t *testing.T) {
+	tdb := dbtest.Postgres(t)
+	defer tdb.Close()
+	conn := tdb.Open()
+	defer conn.Close()
+
+	migrations, err := Migrations.FindMigrations()
+	require.NoError(t, err)
+
+	// a new database has every migration pending
+	planned, err := Plan(conn.DB, MigrateUp, 0)
+	require.NoError(t, err)
+	assert.Len(t, planned, len(migrations))
+
+	status, err := Status(conn.DB)
+	require.NoError(t, err)
+	if assert.Len(t, status, len(migrations)) {
+		for _, s := range status {
+			assert.Nil(t, s.AppliedAt, s.ID)
+		}
+	}
+
+	// migrating up applies them, one at a time when run concurrently
+	var wg sync.WaitGroup
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := Migrate(conn.DB, MigrateUp, 0)
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+
+	status, err = Status(conn.DB)
+	require.NoError(t, err)
+	for _, s := range status {
+		assert.NotNil(t, s.AppliedAt, s.ID)
+		assert.False(t, s.Unknown, s.ID)
+	}
+
+	planned, err = Plan(conn.DB, MigrateUp, 0)
+	require.NoError(t, err)
+	assert.Empty(t, planned)
+
+	// planning down returns the sql of the last migration without running it
+	planned, err = Plan(conn.DB, MigrateDown, 1)
+	require.NoError(t, err)
+	if assert.Len(t, planned, 1) {
+		last := migrations[len(migrations)-1]
+		assert.Equal(t, last.Id, planned[0].Id)
+		assert.Equal(t, last.Down, planned[0].Queries)
+	}
+
+	n, err := Migrate(conn.DB, MigrateDown, 1)
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+
+	status, err = Status(conn.DB)
+	require.NoError(t, err)
+	assert.Nil(t, status[len(status)-1].AppliedAt)
+
+	_, err = Plan(conn.DB, MigrateRedo, 1)
+	assert.Error(t, err)
+}
@@ -0,0 +1,8 @@
+-- +migrate Up
+
+-- Pages of operations of some types are sorted by id
+CREATE INDEX index_history_operations_on_type_and_id ON history_operations USING btree (type, id);
+
+-- +migrate Down
+
+DROP INDEX index_history_operations_on_type_and_id;
@@ -0,0 +1,19 @@
+-- +migrate Up
+
+CREATE TABLE audit_submissions (
+    transaction_hash    CHARACTER VARYING(64),
+    source_account      CHARACTER VARYING(64),
+    envelope_xdr        TEXT                        NOT NULL,
+    result              CHARACTER VARYING(16)       NOT NULL,
+    result_xdr          TEXT,
+    client_ip           CHARACTER VARYING(64),
+    submitted_at        TIMESTAMP WITHOUT TIME ZONE NOT NULL
+);
+
+CREATE INDEX audit_submissions_by_hash ON audit_submissions USING btree (transaction_hash);
+CREATE INDEX audit_submissions_by_source_account ON audit_submissions USING btree (source_account);
+CREATE INDEX audit_submissions_by_submitted_at ON audit_submissions USING btree (submitted_at);
+
+-- +migrate Down
+
+DROP TABLE audit_submissions cascade;
@@ -0,0 +1,25 @@
+-- +migrate Up
+
+CREATE TABLE accounts_signers (
+    account     CHARACTER VARYING(64) NOT NULL,
+    signer      CHARACTER VARYING(64) NOT NULL,
+    weight      INTEGER               NOT NULL,
+    PRIMARY KEY (signer, account)
+);
+
+CREATE INDEX accounts_signers_by_account ON accounts_signers USING btree (account);
+
+CREATE TABLE accounts_trustlines (
+    account         CHARACTER VARYING(64) NOT NULL,
+    asset_type      INTEGER               NOT NULL,
+    asset_code      CHARACTER VARYING(12) NOT NULL,
+    asset_issuer    CHARACTER VARYING(56) NOT NULL,
+    PRIMARY KEY (asset_code, asset_issuer, account)
+);
+
+CREATE INDEX accounts_trustlines_by_account ON accounts_trustlines USING btree (account);
+
+-- +migrate Down
+
+DROP TABLE accounts_signers cascade;
+DROP TABLE accounts_trustlines cascade;
@@ -0,0 +1,66 @@
+package export
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"time"
+)
+
+// writeJSON writes the rows of the slice pointed to by `rows` to `w`, one JSON
+// object per line.
+func writeJSON(w io.Writer, rows interface{}) error {
+	enc := json.NewEncoder(w)
+	v := reflect.ValueOf(rows).Elem()
+	for i := 0; i < v.Len(); i++ {
+		err := enc.Encode(v.Index(i).Interface())
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// writeCSV writes the rows of the slice pointed to by `rows` to `w`, preceded
+// by a header row made of the `db` tags of the fields of the rows.
+func writeCSV(w io.Writer, rows interface{}) error {
+	out := csv.NewWriter(w)
+	v := reflect.ValueOf(rows).Elem()
+	typ := v.Type().Elem()
+
+	header := make([]string, typ.NumField())
+	for i := range header {
+		header[i] = typ.Field(i).Tag.Get("db")
+	}
+	err := out.Write(header)
+	if err != nil {
+		return err
+	}
+
+	record := make([]string, len(header))
+	for i := 0; i < v.Len(); i++ {
+		row := v.Index(i)
+		for j := range record {
+			record[j] = formatCSV(row.Field(j).Interface())
+		}
+
+		err = out.Write(record)
+		if err != nil {
+			return err
+		}
+	}
+
+	out.Flush()
+	return out.Error()
+}
+
+// formatCSV formats a field of a row as a CSV value.  Times are formatted as
+// RFC 3339, which most analytics tools parse as timestamps.
+func formatCSV(field interface{}) string {
+	if t, ok := field.(time.Time); ok {
+		return t.UTC().Format(time.RFC3339)
+	}
+	return fmt.Sprint(field)
+}
@@ -0,0 +1,135 @@
+// Package export writes the history of the horizon database to flat files, so
+// that analytics pipelines can process it without querying postgres.  The
+// ledgers, transactions and operations of a range of ledgers are written as
+// partitions of newline-delimited JSON or CSV files, to a directory or to an
+// S3 bucket.
+package export
+
+import (
+	"bytes"
+	"fmt"
+	"path"
+
+	"github.com/stellar/go/services/horizon/internal/log"
+	"github.com/stellar/go/support/db"
+	"github.com/stellar/go/support/errors"
+)
+
+// DefaultPartitionSize is the number of ledgers of a partition of an exporter
+// whose PartitionSize is not set.
+const DefaultPartitionSize = 1000
+
+// The formats the history can be exported to.
+const (
+	// FormatJSON writes a JSON object per line.
+	FormatJSON = "json"
+	// FormatCSV writes a CSV file with a header row, whose columns are named
+	// like those of the history tables.
+	FormatCSV = "csv"
+)
+
+// The tables of the history database that are exported.
+const (
+	Ledgers      = "ledgers"
+	Transactions = "transactions"
+	Operations   = "operations"
+)
+
+// Exporter writes the history of a range of ledgers to a store.  Each table is
+// written to its own directory of the store, with a file per partition of
+// PartitionSize ledgers named after the first and last ledgers of the
+// partition, such as `ledgers/1-1000.csv`.
+type Exporter struct {
+	HorizonDB *db.Session
+	Store     Store
+
+	// Format is the format of the files, FormatJSON or FormatCSV.
+	Format string
+
+	// PartitionSize is the number of ledgers of each partition.
+	// DefaultPartitionSize is used when zero.
+	PartitionSize int32
+
+	// Tables are the tables exported.  All of them are when empty.
+	Tables []string
+}
+
+// Export writes the history of the ledgers from `first` to `last`, inclusive.
+// It returns the number of partitions written.
+func (e *Exporter) Export(first int32, last int32) (int, error) {
+	if first < 1 || last < first {
+		return 0, errors.Errorf("invalid ledger range: %d-%d", first, last)
+	}
+
+	if e.Format != FormatJSON && e.Format != FormatCSV {
+		return 0, errors.Errorf("unknown format: %s", e.Format)
+	}
+
+	tables := e.Tables
+	if len(tables) == 0 {
+		tables = []string{Ledgers, Transactions, Operations}
+	}
+
+	for _, table := range tables {
+		if _, ok := queries[table]; !ok {
+			return 0, errors.Errorf("unknown table: %s", table)
+		}
+	}
+
+	size := e.PartitionSize
+	if size <= 0 {
+		size = DefaultPartitionSize
+	}
+
+	written := 0
+	for start := first; start <= last; start += size {
+		end := start + size - 1
+		if end > last || end < start {
+			end = last
+		}
+
+		for _, table := range tables {
+			err := e.exportPartition(table, start, end)
+			if err != nil {
+				return written, errors.Wrapf(err, "failed to export %s of ledgers %d-%d", table, start, end)
+			}
+			written++
+		}
+
+		log.
+			WithField("first", start).
+			WithField("last", end).
+			Info("exported partition")
+
+		if end == last {
+			break
+		}
+	}
+
+	return written, nil
+}
+
+// exportPartition writes the rows of `table` of the ledgers from `start` to
+// `end` to the store.
+func (e *Exporter) exportPartition(table string, start int32, end int32) error {
+	q := queries[table]
+	rows := q.rows()
+	err := e.HorizonDB.SelectRaw(rows, q.sql, start, end)
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	switch e.Format {
+	case FormatJSON:
+		err = writeJSON(&buf, rows)
+	case FormatCSV:
+		err = writeCSV(&buf, rows)
+	}
+	if err != nil {
+		return err
+	}
+
+	name := path.Join(table, fmt.Sprintf("%d-%d.%s", start, end, e.Format))
+	return e.Store.Put(name, &buf)
+}
@@ -0,0 +1,85 @@
+package export
+
+import (
+	"bufio"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stellar/go/services/horizon/internal/test"
+)
+
+func TestExport(t *testing.T) {
+	tt := test.Start(t).Scenario("base")
+	defer tt.Finish()
+
+	dir, err := ioutil.TempDir("", "horizon-export")
+	tt.Require.NoError(err)
+	defer os.RemoveAll(dir)
+
+	e := &Exporter{
+		HorizonDB:     tt.HorizonSession(),
+		Store:         &FileStore{Dir: dir},
+		Format:        FormatJSON,
+		PartitionSize: 2,
+	}
+
+	// the base scenario has ledgers 1 to 3
+	written, err := e.Export(1, 3)
+	tt.Require.NoError(err)
+	tt.Assert.Equal(6, written)
+
+	var ledgers []LedgerRow
+	for _, name := range []string{"1-2.json", "3-3.json"} {
+		f, err := os.Open(filepath.Join(dir, Ledgers, name))
+		tt.Require.NoError(err)
+
+		lines := bufio.NewScanner(f)
+		for lines.Scan() {
+			var l LedgerRow
+			tt.Require.NoError(json.Unmarshal(lines.Bytes(), &l))
+			ledgers = append(ledgers, l)
+		}
+		f.Close()
+	}
+	if tt.Assert.Len(ledgers, 3) {
+		tt.Assert.Equal(int32(1), ledgers[0].Sequence)
+		tt.Assert.Equal(int32(3), ledgers[2].Sequence)
+	}
+
+	e.Format = FormatCSV
+	e.Tables = []string{Transactions}
+	written, err = e.Export(1, 3)
+	tt.Require.NoError(err)
+	tt.Assert.Equal(2, written)
+
+	csv, err := ioutil.ReadFile(filepath.Join(dir, Transactions, "3-3.csv"))
+	tt.Require.NoError(err)
+	tt.Assert.True(strings.HasPrefix(string(csv), "id,transaction_hash,ledger_sequence,"))
+
+	// invalid exports
+	_, err = e.Export(3, 1)
+	tt.Assert.Error(err)
+	e.Tables = []string{"effects"}
+	_, err = e.Export(1, 3)
+	tt.Assert.Error(err)
+}
+
+func TestNewStore(t *testing.T) {
+	tt := test.Start(t)
+	defer tt.Finish()
+
+	s, err := NewStore("/tmp/export")
+	tt.Require.NoError(err)
+	tt.Assert.Equal(&FileStore{Dir: "/tmp/export"}, s)
+
+	s, err = NewStore("file:///tmp/export")
+	tt.Require.NoError(err)
+	tt.Assert.Equal(&FileStore{Dir: "/tmp/export"}, s)
+
+	_, err = NewStore("ftp://example.com/export")
+	tt.Assert.Error(err)
+}
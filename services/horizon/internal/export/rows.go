@@ -0,0 +1,104 @@
+package export
+
+import (
+	"time"
+)
+
+// LedgerRow is an exported row of the `history_ledgers` table.
+type LedgerRow struct {
+	ID                 int64     `db:"id" json:"id"`
+	Sequence           int32     `db:"sequence" json:"sequence"`
+	LedgerHash         string    `db:"ledger_hash" json:"ledger_hash"`
+	PreviousLedgerHash string    `db:"previous_ledger_hash" json:"previous_ledger_hash"`
+	TransactionCount   int32     `db:"transaction_count" json:"transaction_count"`
+	OperationCount     int32     `db:"operation_count" json:"operation_count"`
+	ClosedAt           time.Time `db:"closed_at" json:"closed_at"`
+	TotalCoins         int64     `db:"total_coins" json:"total_coins"`
+	FeePool            int64     `db:"fee_pool" json:"fee_pool"`
+	BaseFee            int32     `db:"base_fee" json:"base_fee"`
+	BaseReserve        int32     `db:"base_reserve" json:"base_reserve"`
+	MaxTxSetSize       int32     `db:"max_tx_set_size" json:"max_tx_set_size"`
+	ProtocolVersion    int32     `db:"protocol_version" json:"protocol_version"`
+}
+
+// TransactionRow is an exported row of the `history_transactions` table.
+type TransactionRow struct {
+	ID               int64     `db:"id" json:"id"`
+	TransactionHash  string    `db:"transaction_hash" json:"transaction_hash"`
+	LedgerSequence   int32     `db:"ledger_sequence" json:"ledger_sequence"`
+	LedgerCloseTime  time.Time `db:"ledger_close_time" json:"ledger_close_time"`
+	ApplicationOrder int32     `db:"application_order" json:"application_order"`
+	Account          string    `db:"account" json:"account"`
+	AccountSequence  string    `db:"account_sequence" json:"account_sequence"`
+	FeePaid          int32     `db:"fee_paid" json:"fee_paid"`
+	OperationCount   int32     `db:"operation_count" json:"operation_count"`
+	MemoType         string    `db:"memo_type" json:"memo_type"`
+	Memo             string    `db:"memo" json:"memo"`
+	TxEnvelope       string    `db:"tx_envelope" json:"tx_envelope"`
+	TxResult         string    `db:"tx_result" json:"tx_result"`
+	TxMeta           string    `db:"tx_meta" json:"tx_meta"`
+}
+
+// OperationRow is an exported row of the `history_operations` table.
+type OperationRow struct {
+	ID               int64     `db:"id" json:"id"`
+	TransactionID    int64     `db:"transaction_id" json:"transaction_id"`
+	TransactionHash  string    `db:"transaction_hash" json:"transaction_hash"`
+	LedgerSequence   int32     `db:"ledger_sequence" json:"ledger_sequence"`
+	LedgerCloseTime  time.Time `db:"ledger_close_time" json:"ledger_close_time"`
+	ApplicationOrder int32     `db:"application_order" json:"application_order"`
+	Type             int32     `db:"type" json:"type"`
+	SourceAccount    string    `db:"source_account" json:"source_account"`
+	Details          string    `db:"details" json:"details"`
+}
+
+// query is the query of the rows of an exported table, given the first and
+// last ledgers of a partition.
+type query struct {
+	sql  string
+	rows func() interface{}
+}
+
+var queries = map[string]query{
+	Ledgers: {
+		sql: `
+			SELECT
+				hl.id, hl.sequence, hl.ledger_hash,
+				COALESCE(hl.previous_ledger_hash, '') AS previous_ledger_hash,
+				hl.transaction_count, hl.operation_count, hl.closed_at,
+				hl.total_coins, hl.fee_pool, hl.base_fee, hl.base_reserve,
+				hl.max_tx_set_size, hl.protocol_version
+			FROM history_ledgers hl
+			WHERE hl.sequence BETWEEN ? AND ?
+			ORDER BY hl.id`,
+		rows: func() interface{} { return &[]LedgerRow{} },
+	},
+	Transactions: {
+		sql: `
+			SELECT
+				ht.id, ht.transaction_hash, ht.ledger_sequence,
+				hl.closed_at AS ledger_close_time, ht.application_order,
+				ht.account, ht.account_sequence, ht.fee_paid, ht.operation_count,
+				ht.memo_type, COALESCE(ht.memo, '') AS memo,
+				ht.tx_envelope, ht.tx_result, ht.tx_meta
+			FROM history_transactions ht
+			JOIN history_ledgers hl ON hl.sequence = ht.ledger_sequence
+			WHERE ht.ledger_sequence BETWEEN ? AND ?
+			ORDER BY ht.id`,
+		rows: func() interface{} { return &[]TransactionRow{} },
+	},
+	Operations: {
+		sql: `
+			SELECT
+				hop.id, hop.transaction_id, ht.transaction_hash,
+				ht.ledger_sequence, hl.closed_at AS ledger_close_time,
+				hop.application_order, hop.type, hop.source_account,
+				COALESCE(hop.details::text, '') AS details
+			FROM history_operations hop
+			JOIN history_transactions ht ON ht.id = hop.transaction_id
+			JOIN history_ledgers hl ON hl.sequence = ht.ledger_sequence
+			WHERE ht.ledger_sequence BETWEEN ? AND ?
+			ORDER BY hop.id`,
+		rows: func() interface{} { return &[]OperationRow{} },
+	},
+}
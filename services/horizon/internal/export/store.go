@@ -0,0 +1,108 @@
+package export
+
+import (
+	"bytes"
+	"io"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/stellar/go/support/errors"
+)
+
+// Store is where the exported files are written.
+type Store interface {
+	// Put writes the file at `name`, a slash-separated path relative to the
+	// root of the store.
+	Put(name string, body io.Reader) error
+}
+
+// FileStore writes files to a directory of the local filesystem.
+type FileStore struct {
+	Dir string
+}
+
+// Put writes the file at `name` within the directory of the store.
+func (s *FileStore) Put(name string, body io.Reader) error {
+	pth := filepath.Join(s.Dir, filepath.FromSlash(name))
+	err := os.MkdirAll(filepath.Dir(pth), 0755)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Create(pth)
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(f, body)
+	if err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// S3Store writes files to an S3 bucket, with the keys of its prefix.
+type S3Store struct {
+	Bucket string
+	Prefix string
+
+	svc *s3.S3
+}
+
+// NewS3Store returns a store writing to the S3 `bucket`.  The credentials and
+// the region are those of the environment, as with the AWS command line.
+func NewS3Store(bucket string, prefix string) (*S3Store, error) {
+	sess, err := session.NewSession()
+	if err != nil {
+		return nil, err
+	}
+
+	return &S3Store{Bucket: bucket, Prefix: prefix, svc: s3.New(sess)}, nil
+}
+
+// Put writes the file at `name`, prefixed by the prefix of the store.
+func (s *S3Store) Put(name string, body io.Reader) error {
+	var buf bytes.Buffer
+	_, err := buf.ReadFrom(body)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.svc.PutObject(&s3.PutObjectInput{
+		Bucket: aws.String(s.Bucket),
+		Key:    aws.String(path.Join(s.Prefix, name)),
+		Body:   bytes.NewReader(buf.Bytes()),
+	})
+	return err
+}
+
+// NewStore returns the store of `dest`, either a `s3://bucket/prefix` url or
+// the path of a directory.
+func NewStore(dest string) (Store, error) {
+	u, err := url.Parse(dest)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid destination")
+	}
+
+	switch u.Scheme {
+	case "s3":
+		// keys of s3 objects don't start with a /
+		prefix := u.Path
+		if len(prefix) > 0 && prefix[0] == '/' {
+			prefix = prefix[1:]
+		}
+		return NewS3Store(u.Host, prefix)
+	case "file":
+		return &FileStore{Dir: path.Join(u.Host, u.Path)}, nil
+	case "":
+		return &FileStore{Dir: dest}, nil
+	default:
+		return nil, errors.Errorf("unknown destination scheme: %s", u.Scheme)
+	}
+}
@@ -0,0 +1,100 @@
+package horizon
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"mime"
+	"net/http"
+
+	gctx "github.com/goji/context"
+	"github.com/stellar/go/services/horizon/internal/db2/core"
+	"github.com/stellar/go/services/horizon/internal/db2/history"
+	"github.com/stellar/go/services/horizon/internal/graphql"
+	"github.com/stellar/go/support/errors"
+	"github.com/stellar/go/support/render/problem"
+	"github.com/zenazn/goji/web"
+)
+
+// This file contains the /graphql endpoint, which answers GraphQL queries for
+// accounts, ledgers, transactions, operations and effects.  Queries are sent
+// in the `query`, `operationName` and `variables` parameters of GET requests,
+// or in the body of POST requests, either as a JSON object with those fields
+// or as the raw query with the application/graphql content type.
+
+// ServeGraphQL serves the /graphql endpoint.
+func (app *App) ServeGraphQL(c web.C, w http.ResponseWriter, r *http.Request) {
+	ctx := gctx.FromC(c)
+
+	req, err := readGraphQLRequest(r)
+	if err != nil {
+		p := problem.BadRequest
+		p.Detail = "The GraphQL query could not be read: " + err.Error()
+		problem.Render(ctx, w, p)
+		return
+	}
+
+	if req.Query == "" {
+		problem.Render(ctx, w, problem.MakeInvalidFieldProblem(
+			"query",
+			errors.New("a GraphQL query is required"),
+		))
+		return
+	}
+
+	res := app.graphQLSchema.Exec(ctx, req.Query, req.OperationName, req.Variables)
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	json.NewEncoder(w).Encode(res)
+}
+
+// readGraphQLRequest reads the query sent to the /graphql endpoint.
+func readGraphQLRequest(r *http.Request) (req graphql.Request, err error) {
+	if r.Method != http.MethodPost {
+		query := r.URL.Query()
+		req.Query = query.Get("query")
+		req.OperationName = query.Get("operationName")
+		if vars := query.Get("variables"); vars != "" {
+			err = json.Unmarshal([]byte(vars), &req.Variables)
+		}
+		return
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if mediaType == "application/graphql" {
+		var body []byte
+		body, err = ioutil.ReadAll(r.Body)
+		req.Query = string(body)
+		return
+	}
+
+	err = json.NewDecoder(r.Body).Decode(&req)
+	return
+}
+
+// initGraphQL parses the schema served by the /graphql endpoint, whose
+// queries are resolved with the horizon and stellar-core databases.
+func initGraphQL(app *App) {
+	if !app.config.EnableGraphQL {
+		return
+	}
+
+	schema, err := graphql.NewSchema(&graphql.Resolver{
+		HistoryQ: func(ctx context.Context) *history.Q {
+			return &history.Q{Session: app.HorizonReadSession(ctx)}
+		},
+		CoreQ: func(ctx context.Context) *core.Q {
+			return &core.Q{Session: app.CoreSession(ctx)}
+		},
+		MaxPageSize: app.MaxPageSize(),
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	app.graphQLSchema = schema
+}
+
+func init() {
+	appInit.Add("graphql", initGraphQL, "app-context", "horizon-db", "core-db")
+}
@@ -0,0 +1,122 @@
+package graphql
+
+import (
+	"context"
+
+	gql "github.com/graph-gophers/graphql-go"
+	"github.com/stellar/go/services/horizon/internal/db2/core"
+	"github.com/stellar/go/services/horizon/internal/db2/history"
+	"github.com/stellar/go/services/horizon/internal/resource"
+)
+
+// Account resolves the account query, returning nil if the account does not
+// exist in stellar-core.
+func (r *Resolver) Account(
+	ctx context.Context,
+	args struct{ ID gql.ID },
+) (*accountResolver, error) {
+	address := string(args.ID)
+	cq := r.CoreQ(ctx)
+
+	var record core.Account
+	err := cq.AccountByAddress(&record, address)
+	if cq.NoRows(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var data []core.AccountData
+	err = cq.AllDataByAddress(&data, address)
+	if err != nil {
+		return nil, err
+	}
+
+	var signers []core.Signer
+	err = cq.SignersByAddress(&signers, address)
+	if err != nil {
+		return nil, err
+	}
+
+	var trustlines []core.Trustline
+	err = cq.TrustlinesByAddress(&trustlines, address)
+	if err != nil {
+		return nil, err
+	}
+
+	// accounts created outside of the known history have no history record
+	hq := r.HistoryQ(ctx)
+	var historyRecord history.Account
+	err = hq.AccountByAddress(&historyRecord, address)
+	if hq.NoRows(err) {
+		err = nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var res resource.Account
+	err = res.Populate(ctx, record, data, signers, trustlines, historyRecord)
+	if err != nil {
+		return nil, err
+	}
+
+	return &accountResolver{root: r, res: res}, nil
+}
+
+// accountResolver resolves the Account type.
+type accountResolver struct {
+	root *Resolver
+	res  resource.Account
+}
+
+func (a *accountResolver) ID() gql.ID           { return gql.ID(a.res.AccountID) }
+func (a *accountResolver) Sequence() string     { return a.res.Sequence }
+func (a *accountResolver) SubentryCount() int32 { return a.res.SubentryCount }
+
+func (a *accountResolver) Balances() []*balanceResolver {
+	balances := make([]*balanceResolver, len(a.res.Balances))
+	for i := range a.res.Balances {
+		balances[i] = &balanceResolver{res: a.res.Balances[i]}
+	}
+	return balances
+}
+
+func (a *accountResolver) Transactions(
+	ctx context.Context,
+	args connectionArgs,
+) (*transactionConnection, error) {
+	return a.root.transactions(ctx, args, func(q *history.TransactionsQ) {
+		q.ForAccount(a.res.AccountID)
+	})
+}
+
+func (a *accountResolver) Operations(
+	ctx context.Context,
+	args connectionArgs,
+) (*operationConnection, error) {
+	return a.root.operations(ctx, args, func(q *history.OperationsQ) {
+		q.ForAccount(a.res.AccountID)
+	})
+}
+
+func (a *accountResolver) Effects(
+	ctx context.Context,
+	args connectionArgs,
+) (*effectConnection, error) {
+	return a.root.effects(ctx, args, func(q *history.EffectsQ) {
+		q.ForAccount(a.res.AccountID)
+	})
+}
+
+// balanceResolver resolves the Balance type.
+type balanceResolver struct {
+	res resource.Balance
+}
+
+func (b *balanceResolver) AssetType() string    { return b.res.Type }
+func (b *balanceResolver) AssetCode() *string   { return optional(b.res.Code) }
+func (b *balanceResolver) AssetIssuer() *string { return optional(b.res.Issuer) }
+func (b *balanceResolver) Balance() string      { return b.res.Balance }
+func (b *balanceResolver) Limit() *string       { return optional(b.res.Limit) }
@@ -0,0 +1,103 @@
+package graphql
+
+import (
+	"github.com/stellar/go/services/horizon/internal/db2"
+	"github.com/stellar/go/support/errors"
+)
+
+// connectionArgs are the arguments of the fields returning connections, which
+// page forward from the `after` cursor or backward from the `before` cursor.
+type connectionArgs struct {
+	First  *int32
+	After  *string
+	Last   *int32
+	Before *string
+}
+
+// pageQuery returns the page query loading the records of the connection, of
+// at most `maxLimit` records.  It loads one more record than the page holds,
+// to find out whether another page follows.
+func (args connectionArgs) pageQuery(maxLimit uint64) (db2.PageQuery, error) {
+	forward := args.First != nil || args.After != nil
+	backward := args.Last != nil || args.Before != nil
+	if forward && backward {
+		return db2.PageQuery{}, errors.New("first and after cannot be used with last and before")
+	}
+
+	pq := db2.PageQuery{Order: db2.OrderAscending, Limit: db2.DefaultPageSize}
+	limit, cursor := args.First, args.After
+	if backward {
+		pq.Order = db2.OrderDescending
+		limit, cursor = args.Last, args.Before
+	}
+
+	if limit != nil {
+		if *limit <= 0 || uint64(*limit) > maxLimit {
+			return db2.PageQuery{}, errors.Errorf(
+				"the number of records must be between 1 and %d",
+				maxLimit,
+			)
+		}
+		pq.Limit = uint64(*limit)
+	}
+
+	if cursor != nil {
+		pq.Cursor = *cursor
+	}
+
+	pq.Limit++
+	return pq, nil
+}
+
+// page is a page of the records loaded for a connection.
+type page struct {
+	// indexes are the indexes among the records loaded of the records in the
+	// page, in ascending order.
+	indexes []int
+	info    *pageInfo
+}
+
+// newPage returns the page of the `n` records loaded with pq, whose cursors
+// are returned by `cursor`.
+func newPage(pq db2.PageQuery, n int, cursor func(int) string) page {
+	size := int(pq.Limit) - 1
+	more := n > size
+	if more {
+		n = size
+	}
+
+	p := page{indexes: make([]int, n), info: &pageInfo{}}
+	for i := range p.indexes {
+		if pq.Order == db2.OrderDescending {
+			p.indexes[i] = n - 1 - i
+		} else {
+			p.indexes[i] = i
+		}
+	}
+
+	if pq.Order == db2.OrderDescending {
+		p.info.hasPreviousPage = more
+	} else {
+		p.info.hasNextPage = more
+	}
+
+	if n > 0 {
+		start, end := cursor(p.indexes[0]), cursor(p.indexes[n-1])
+		p.info.startCursor, p.info.endCursor = &start, &end
+	}
+
+	return p
+}
+
+// pageInfo resolves the PageInfo type.
+type pageInfo struct {
+	hasNextPage     bool
+	hasPreviousPage bool
+	startCursor     *string
+	endCursor       *string
+}
+
+func (p *pageInfo) HasNextPage() bool     { return p.hasNextPage }
+func (p *pageInfo) HasPreviousPage() bool { return p.hasPreviousPage }
+func (p *pageInfo) StartCursor() *string  { return p.startCursor }
+func (p *pageInfo) EndCursor() *string    { return p.endCursor }
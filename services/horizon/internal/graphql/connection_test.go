@@ -0,0 +1,85 @@
+package graphql
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stellar/go/services/horizon/internal/db2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConnectionArgs(t *testing.T) {
+	assert := assert.New(t)
+	require := require.New(t)
+
+	one, ten := int32(1), int32(10)
+	cursor := "12"
+
+	// pages forward by default
+	pq, err := connectionArgs{}.pageQuery(db2.MaxPageSize)
+	require.NoError(err)
+	assert.Equal(db2.OrderAscending, pq.Order)
+	assert.Equal(uint64(db2.DefaultPageSize+1), pq.Limit)
+	assert.Equal("", pq.Cursor)
+
+	pq, err = connectionArgs{First: &ten, After: &cursor}.pageQuery(db2.MaxPageSize)
+	require.NoError(err)
+	assert.Equal(db2.OrderAscending, pq.Order)
+	assert.Equal(uint64(11), pq.Limit)
+	assert.Equal("12", pq.Cursor)
+
+	pq, err = connectionArgs{Last: &one, Before: &cursor}.pageQuery(db2.MaxPageSize)
+	require.NoError(err)
+	assert.Equal(db2.OrderDescending, pq.Order)
+	assert.Equal(uint64(2), pq.Limit)
+	assert.Equal("12", pq.Cursor)
+
+	// invalid arguments
+	_, err = connectionArgs{First: &one, Last: &one}.pageQuery(db2.MaxPageSize)
+	assert.Error(err)
+	_, err = connectionArgs{After: &cursor, Before: &cursor}.pageQuery(db2.MaxPageSize)
+	assert.Error(err)
+
+	zero, tooMany := int32(0), int32(db2.MaxPageSize+1)
+	_, err = connectionArgs{First: &zero}.pageQuery(db2.MaxPageSize)
+	assert.Error(err)
+	_, err = connectionArgs{Last: &tooMany}.pageQuery(db2.MaxPageSize)
+	assert.Error(err)
+
+	// the max limit is configurable
+	pq, err = connectionArgs{Last: &tooMany}.pageQuery(db2.MaxPageSize + 1)
+	require.NoError(err)
+	assert.Equal(uint64(db2.MaxPageSize+2), pq.Limit)
+}
+
+func TestNewPage(t *testing.T) {
+	assert := assert.New(t)
+	cursor := func(i int) string { return strconv.Itoa(i) }
+
+	// ascending pages with another page following
+	p := newPage(db2.PageQuery{Order: db2.OrderAscending, Limit: 3}, 3, cursor)
+	assert.Equal([]int{0, 1}, p.indexes)
+	assert.True(p.info.HasNextPage())
+	assert.False(p.info.HasPreviousPage())
+	assert.Equal("0", *p.info.StartCursor())
+	assert.Equal("1", *p.info.EndCursor())
+
+	// descending pages are reversed
+	p = newPage(db2.PageQuery{Order: db2.OrderDescending, Limit: 3}, 2, cursor)
+	assert.Equal([]int{1, 0}, p.indexes)
+	assert.False(p.info.HasNextPage())
+	assert.False(p.info.HasPreviousPage())
+	assert.Equal("1", *p.info.StartCursor())
+	assert.Equal("0", *p.info.EndCursor())
+
+	p = newPage(db2.PageQuery{Order: db2.OrderDescending, Limit: 3}, 3, cursor)
+	assert.Equal([]int{1, 0}, p.indexes)
+	assert.True(p.info.HasPreviousPage())
+
+	// empty pages have no cursors
+	p = newPage(db2.PageQuery{Order: db2.OrderAscending, Limit: 3}, 0, cursor)
+	assert.Empty(p.indexes)
+	assert.Nil(p.info.StartCursor())
+	assert.Nil(p.info.EndCursor())
+}
@@ -0,0 +1,104 @@
+package graphql
+
+import (
+	"context"
+
+	gql "github.com/graph-gophers/graphql-go"
+	"github.com/stellar/go/services/horizon/internal/db2/history"
+	"github.com/stellar/go/services/horizon/internal/resource/effects"
+)
+
+// Effects resolves the effects query.
+func (r *Resolver) Effects(
+	ctx context.Context,
+	args connectionArgs,
+) (*effectConnection, error) {
+	return r.effects(ctx, args, nil)
+}
+
+// effects returns the connection of the effects selected by the query
+// `filter` configures.
+func (r *Resolver) effects(
+	ctx context.Context,
+	args connectionArgs,
+	filter func(*history.EffectsQ),
+) (*effectConnection, error) {
+	pq, err := args.pageQuery(r.maxPageSize())
+	if err != nil {
+		return nil, err
+	}
+
+	hq := r.HistoryQ(ctx)
+	q := hq.Effects()
+	if filter != nil {
+		filter(q)
+	}
+
+	var records []history.Effect
+	err = q.Page(pq).Select(&records)
+	// accounts unknown to the history database have no effects
+	if hq.NoRows(err) {
+		err = nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	p := newPage(pq, len(records), func(i int) string {
+		return records[i].PagingToken()
+	})
+
+	conn := &effectConnection{edges: []*effectEdge{}, info: p.info}
+	for _, i := range p.indexes {
+		conn.edges = append(conn.edges, &effectEdge{
+			cursor: records[i].PagingToken(),
+			node:   &effectResolver{root: r, record: records[i]},
+		})
+	}
+	return conn, nil
+}
+
+// effectResolver resolves the Effect type.
+type effectResolver struct {
+	root   *Resolver
+	record history.Effect
+}
+
+func (e *effectResolver) ID() gql.ID      { return gql.ID(e.record.ID()) }
+func (e *effectResolver) Type() string    { return effects.TypeNames[e.record.Type] }
+func (e *effectResolver) TypeI() int32    { return int32(e.record.Type) }
+func (e *effectResolver) Account() string { return e.record.Account }
+
+func (e *effectResolver) Details() *string {
+	if !e.record.DetailsString.Valid {
+		return nil
+	}
+	return &e.record.DetailsString.String
+}
+
+func (e *effectResolver) Operation(ctx context.Context) (*operationResolver, error) {
+	var record history.Operation
+	err := e.root.HistoryQ(ctx).OperationByID(&record, e.record.HistoryOperationID)
+	if err != nil {
+		return nil, err
+	}
+	return &operationResolver{root: e.root, record: record}, nil
+}
+
+// effectConnection resolves the EffectConnection type.
+type effectConnection struct {
+	edges []*effectEdge
+	info  *pageInfo
+}
+
+func (c *effectConnection) Edges() []*effectEdge { return c.edges }
+func (c *effectConnection) PageInfo() *pageInfo  { return c.info }
+
+// effectEdge resolves the EffectEdge type.
+type effectEdge struct {
+	cursor string
+	node   *effectResolver
+}
+
+func (e *effectEdge) Cursor() string        { return e.cursor }
+func (e *effectEdge) Node() *effectResolver { return e.node }
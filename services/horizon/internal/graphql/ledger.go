@@ -0,0 +1,140 @@
+package graphql
+
+import (
+	"context"
+	"time"
+
+	gql "github.com/graph-gophers/graphql-go"
+	"github.com/stellar/go/services/horizon/internal/db2/history"
+	"github.com/stellar/go/services/horizon/internal/resource"
+)
+
+// Ledger resolves the ledger query, returning nil if no ledger has the
+// sequence.
+func (r *Resolver) Ledger(
+	ctx context.Context,
+	args struct{ Sequence int32 },
+) (*ledgerResolver, error) {
+	hq := r.HistoryQ(ctx)
+
+	var record history.Ledger
+	err := hq.LedgerBySequence(&record, args.Sequence)
+	if hq.NoRows(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return r.newLedger(ctx, record), nil
+}
+
+// Ledgers resolves the ledgers query.
+func (r *Resolver) Ledgers(
+	ctx context.Context,
+	args connectionArgs,
+) (*ledgerConnection, error) {
+	pq, err := args.pageQuery(r.maxPageSize())
+	if err != nil {
+		return nil, err
+	}
+
+	var records []history.Ledger
+	err = r.HistoryQ(ctx).Ledgers().Page(pq).Select(&records)
+	if err != nil {
+		return nil, err
+	}
+
+	p := newPage(pq, len(records), func(i int) string {
+		return records[i].PagingToken()
+	})
+
+	conn := &ledgerConnection{edges: []*ledgerEdge{}, info: p.info}
+	for _, i := range p.indexes {
+		conn.edges = append(conn.edges, &ledgerEdge{
+			cursor: records[i].PagingToken(),
+			node:   r.newLedger(ctx, records[i]),
+		})
+	}
+	return conn, nil
+}
+
+func (r *Resolver) newLedger(ctx context.Context, record history.Ledger) *ledgerResolver {
+	var res resource.Ledger
+	res.Populate(ctx, record)
+	return &ledgerResolver{root: r, res: res}
+}
+
+// ledgerResolver resolves the Ledger type.
+type ledgerResolver struct {
+	root *Resolver
+	res  resource.Ledger
+}
+
+func (l *ledgerResolver) ID() gql.ID                  { return gql.ID(l.res.ID) }
+func (l *ledgerResolver) Sequence() int32             { return l.res.Sequence }
+func (l *ledgerResolver) Hash() string                { return l.res.Hash }
+func (l *ledgerResolver) PrevHash() *string           { return optional(l.res.PrevHash) }
+func (l *ledgerResolver) TransactionCount() int32     { return l.res.TransactionCount }
+func (l *ledgerResolver) OperationCount() int32       { return l.res.OperationCount }
+func (l *ledgerResolver) ClosedAt() string            { return l.res.ClosedAt.Format(time.RFC3339) }
+func (l *ledgerResolver) TotalCoins() string          { return l.res.TotalCoins }
+func (l *ledgerResolver) FeePool() string             { return l.res.FeePool }
+func (l *ledgerResolver) BaseFeeInStroops() int32     { return l.res.BaseFee }
+func (l *ledgerResolver) BaseReserveInStroops() int32 { return l.res.BaseReserve }
+func (l *ledgerResolver) MaxTxSetSize() int32         { return l.res.MaxTxSetSize }
+func (l *ledgerResolver) ProtocolVersion() int32      { return l.res.ProtocolVersion }
+func (l *ledgerResolver) HeaderXdr() string           { return l.res.HeaderXDR }
+
+func (l *ledgerResolver) Transactions(
+	ctx context.Context,
+	args connectionArgs,
+) (*transactionConnection, error) {
+	return l.root.transactions(ctx, args, func(q *history.TransactionsQ) {
+		q.ForLedger(l.res.Sequence)
+	})
+}
+
+func (l *ledgerResolver) Operations(
+	ctx context.Context,
+	args connectionArgs,
+) (*operationConnection, error) {
+	return l.root.operations(ctx, args, func(q *history.OperationsQ) {
+		q.ForLedger(l.res.Sequence)
+	})
+}
+
+func (l *ledgerResolver) Effects(
+	ctx context.Context,
+	args connectionArgs,
+) (*effectConnection, error) {
+	return l.root.effects(ctx, args, func(q *history.EffectsQ) {
+		q.ForLedger(l.res.Sequence)
+	})
+}
+
+// ledgerConnection resolves the LedgerConnection type.
+type ledgerConnection struct {
+	edges []*ledgerEdge
+	info  *pageInfo
+}
+
+func (c *ledgerConnection) Edges() []*ledgerEdge { return c.edges }
+func (c *ledgerConnection) PageInfo() *pageInfo  { return c.info }
+
+// ledgerEdge resolves the LedgerEdge type.
+type ledgerEdge struct {
+	cursor string
+	node   *ledgerResolver
+}
+
+func (e *ledgerEdge) Cursor() string        { return e.cursor }
+func (e *ledgerEdge) Node() *ledgerResolver { return e.node }
+
+// optional returns nil for empty strings, which are null in the schema.
+func optional(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
@@ -0,0 +1,186 @@
+// Package graphql implements the GraphQL schema served by horizon's /graphql
+// endpoint, exposing accounts, ledgers, transactions, operations and effects
+// with relay-style pagination on top of the history database.
+package graphql
+
+import (
+	"context"
+
+	gql "github.com/graph-gophers/graphql-go"
+	"github.com/stellar/go/services/horizon/internal/db2"
+	"github.com/stellar/go/services/horizon/internal/db2/core"
+	"github.com/stellar/go/services/horizon/internal/db2/history"
+)
+
+// Schema is the GraphQL schema served by horizon.
+const Schema = `
+schema {
+	query: Query
+}
+
+type Query {
+	account(id: ID!): Account
+	ledger(sequence: Int!): Ledger
+	transaction(hash: ID!): Transaction
+	operation(id: ID!): Operation
+	ledgers(first: Int, after: String, last: Int, before: String): LedgerConnection!
+	transactions(first: Int, after: String, last: Int, before: String): TransactionConnection!
+	operations(first: Int, after: String, last: Int, before: String): OperationConnection!
+	effects(first: Int, after: String, last: Int, before: String): EffectConnection!
+}
+
+type PageInfo {
+	hasNextPage: Boolean!
+	hasPreviousPage: Boolean!
+	startCursor: String
+	endCursor: String
+}
+
+type Account {
+	id: ID!
+	sequence: String!
+	subentryCount: Int!
+	balances: [Balance!]!
+	transactions(first: Int, after: String, last: Int, before: String): TransactionConnection!
+	operations(first: Int, after: String, last: Int, before: String): OperationConnection!
+	effects(first: Int, after: String, last: Int, before: String): EffectConnection!
+}
+
+type Balance {
+	assetType: String!
+	assetCode: String
+	assetIssuer: String
+	balance: String!
+	limit: String
+}
+
+type Ledger {
+	id: ID!
+	sequence: Int!
+	hash: String!
+	prevHash: String
+	transactionCount: Int!
+	operationCount: Int!
+	closedAt: String!
+	totalCoins: String!
+	feePool: String!
+	baseFeeInStroops: Int!
+	baseReserveInStroops: Int!
+	maxTxSetSize: Int!
+	protocolVersion: Int!
+	headerXdr: String!
+	transactions(first: Int, after: String, last: Int, before: String): TransactionConnection!
+	operations(first: Int, after: String, last: Int, before: String): OperationConnection!
+	effects(first: Int, after: String, last: Int, before: String): EffectConnection!
+}
+
+type LedgerConnection {
+	edges: [LedgerEdge!]!
+	pageInfo: PageInfo!
+}
+
+type LedgerEdge {
+	cursor: String!
+	node: Ledger!
+}
+
+type Transaction {
+	id: ID!
+	hash: String!
+	ledger: Ledger!
+	createdAt: String!
+	sourceAccount: String!
+	sourceAccountSequence: String!
+	feePaid: Int!
+	operationCount: Int!
+	envelopeXdr: String!
+	resultXdr: String!
+	resultMetaXdr: String!
+	feeMetaXdr: String!
+	memoType: String!
+	memo: String
+	signatures: [String!]!
+	operations(first: Int, after: String, last: Int, before: String): OperationConnection!
+	effects(first: Int, after: String, last: Int, before: String): EffectConnection!
+}
+
+type TransactionConnection {
+	edges: [TransactionEdge!]!
+	pageInfo: PageInfo!
+}
+
+type TransactionEdge {
+	cursor: String!
+	node: Transaction!
+}
+
+# The details of operations and effects are the JSON encoded attributes
+# specific to their type.
+type Operation {
+	id: ID!
+	type: String!
+	typeI: Int!
+	sourceAccount: String!
+	transaction: Transaction!
+	details: String
+	effects(first: Int, after: String, last: Int, before: String): EffectConnection!
+}
+
+type OperationConnection {
+	edges: [OperationEdge!]!
+	pageInfo: PageInfo!
+}
+
+type OperationEdge {
+	cursor: String!
+	node: Operation!
+}
+
+type Effect {
+	id: ID!
+	type: String!
+	typeI: Int!
+	account: String!
+	operation: Operation!
+	details: String
+}
+
+type EffectConnection {
+	edges: [EffectEdge!]!
+	pageInfo: PageInfo!
+}
+
+type EffectEdge {
+	cursor: String!
+	node: Effect!
+}
+`
+
+// Resolver is the root resolver of Schema.  The data of each query is loaded
+// through the sessions returned by HistoryQ and CoreQ for the context of the
+// query.  Connections hold at most MaxPageSize records, or db2.MaxPageSize
+// when zero.
+type Resolver struct {
+	HistoryQ    func(context.Context) *history.Q
+	CoreQ       func(context.Context) *core.Q
+	MaxPageSize uint64
+}
+
+func (r *Resolver) maxPageSize() uint64 {
+	if r.MaxPageSize == 0 {
+		return db2.MaxPageSize
+	}
+	return r.MaxPageSize
+}
+
+// Request is a GraphQL query sent to the /graphql endpoint.
+type Request struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+// NewSchema parses Schema, resolving its queries with r.
+func NewSchema(r *Resolver) (*gql.Schema, error) {
+	return gql.ParseSchema(Schema, r)
+}
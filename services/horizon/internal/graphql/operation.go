@@ -0,0 +1,140 @@
+package graphql
+
+import (
+	"context"
+	"strconv"
+
+	gql "github.com/graph-gophers/graphql-go"
+	"github.com/stellar/go/services/horizon/internal/db2/history"
+	"github.com/stellar/go/services/horizon/internal/resource/operations"
+	"github.com/stellar/go/support/errors"
+)
+
+// Operation resolves the operation query, returning nil if no operation has
+// the id.
+func (r *Resolver) Operation(
+	ctx context.Context,
+	args struct{ ID gql.ID },
+) (*operationResolver, error) {
+	id, err := strconv.ParseInt(string(args.ID), 10, 64)
+	if err != nil {
+		return nil, errors.New("invalid operation id")
+	}
+
+	hq := r.HistoryQ(ctx)
+
+	var record history.Operation
+	err = hq.OperationByID(&record, id)
+	if hq.NoRows(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &operationResolver{root: r, record: record}, nil
+}
+
+// Operations resolves the operations query.
+func (r *Resolver) Operations(
+	ctx context.Context,
+	args connectionArgs,
+) (*operationConnection, error) {
+	return r.operations(ctx, args, nil)
+}
+
+// operations returns the connection of the operations selected by the query
+// `filter` configures.
+func (r *Resolver) operations(
+	ctx context.Context,
+	args connectionArgs,
+	filter func(*history.OperationsQ),
+) (*operationConnection, error) {
+	pq, err := args.pageQuery(r.maxPageSize())
+	if err != nil {
+		return nil, err
+	}
+
+	hq := r.HistoryQ(ctx)
+	q := hq.Operations()
+	if filter != nil {
+		filter(q)
+	}
+
+	var records []history.Operation
+	err = q.Page(pq).Select(&records)
+	// accounts unknown to the history database have no operations
+	if hq.NoRows(err) {
+		err = nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	p := newPage(pq, len(records), func(i int) string {
+		return records[i].PagingToken()
+	})
+
+	conn := &operationConnection{edges: []*operationEdge{}, info: p.info}
+	for _, i := range p.indexes {
+		conn.edges = append(conn.edges, &operationEdge{
+			cursor: records[i].PagingToken(),
+			node:   &operationResolver{root: r, record: records[i]},
+		})
+	}
+	return conn, nil
+}
+
+// operationResolver resolves the Operation type.
+type operationResolver struct {
+	root   *Resolver
+	record history.Operation
+}
+
+func (o *operationResolver) ID() gql.ID            { return gql.ID(strconv.FormatInt(o.record.ID, 10)) }
+func (o *operationResolver) Type() string          { return operations.TypeNames[o.record.Type] }
+func (o *operationResolver) TypeI() int32          { return int32(o.record.Type) }
+func (o *operationResolver) SourceAccount() string { return o.record.SourceAccount }
+
+func (o *operationResolver) Details() *string {
+	if !o.record.DetailsString.Valid {
+		return nil
+	}
+	return &o.record.DetailsString.String
+}
+
+func (o *operationResolver) Transaction(ctx context.Context) (*transactionResolver, error) {
+	var record history.Transaction
+	err := o.root.HistoryQ(ctx).TransactionByHash(&record, o.record.TransactionHash)
+	if err != nil {
+		return nil, err
+	}
+	return o.root.newTransaction(ctx, record)
+}
+
+func (o *operationResolver) Effects(
+	ctx context.Context,
+	args connectionArgs,
+) (*effectConnection, error) {
+	return o.root.effects(ctx, args, func(q *history.EffectsQ) {
+		q.ForOperation(o.record.ID)
+	})
+}
+
+// operationConnection resolves the OperationConnection type.
+type operationConnection struct {
+	edges []*operationEdge
+	info  *pageInfo
+}
+
+func (c *operationConnection) Edges() []*operationEdge { return c.edges }
+func (c *operationConnection) PageInfo() *pageInfo     { return c.info }
+
+// operationEdge resolves the OperationEdge type.
+type operationEdge struct {
+	cursor string
+	node   *operationResolver
+}
+
+func (e *operationEdge) Cursor() string           { return e.cursor }
+func (e *operationEdge) Node() *operationResolver { return e.node }
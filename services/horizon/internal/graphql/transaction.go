@@ -0,0 +1,163 @@
+package graphql
+
+import (
+	"context"
+	"time"
+
+	gql "github.com/graph-gophers/graphql-go"
+	"github.com/stellar/go/services/horizon/internal/db2/history"
+	"github.com/stellar/go/services/horizon/internal/resource"
+)
+
+// Transaction resolves the transaction query, returning nil if no transaction
+// has the hash.
+func (r *Resolver) Transaction(
+	ctx context.Context,
+	args struct{ Hash gql.ID },
+) (*transactionResolver, error) {
+	hq := r.HistoryQ(ctx)
+
+	var record history.Transaction
+	err := hq.TransactionByHash(&record, string(args.Hash))
+	if hq.NoRows(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return r.newTransaction(ctx, record)
+}
+
+// Transactions resolves the transactions query.
+func (r *Resolver) Transactions(
+	ctx context.Context,
+	args connectionArgs,
+) (*transactionConnection, error) {
+	return r.transactions(ctx, args, nil)
+}
+
+// transactions returns the connection of the transactions selected by the
+// query `filter` configures.
+func (r *Resolver) transactions(
+	ctx context.Context,
+	args connectionArgs,
+	filter func(*history.TransactionsQ),
+) (*transactionConnection, error) {
+	pq, err := args.pageQuery(r.maxPageSize())
+	if err != nil {
+		return nil, err
+	}
+
+	hq := r.HistoryQ(ctx)
+	q := hq.Transactions()
+	if filter != nil {
+		filter(q)
+	}
+
+	var records []history.Transaction
+	err = q.Page(pq).Select(&records)
+	// accounts unknown to the history database have no transactions
+	if hq.NoRows(err) {
+		err = nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	p := newPage(pq, len(records), func(i int) string {
+		return records[i].PagingToken()
+	})
+
+	conn := &transactionConnection{edges: []*transactionEdge{}, info: p.info}
+	for _, i := range p.indexes {
+		node, err := r.newTransaction(ctx, records[i])
+		if err != nil {
+			return nil, err
+		}
+
+		conn.edges = append(conn.edges, &transactionEdge{
+			cursor: records[i].PagingToken(),
+			node:   node,
+		})
+	}
+	return conn, nil
+}
+
+func (r *Resolver) newTransaction(
+	ctx context.Context,
+	record history.Transaction,
+) (*transactionResolver, error) {
+	var res resource.Transaction
+	err := res.Populate(ctx, record)
+	if err != nil {
+		return nil, err
+	}
+	return &transactionResolver{root: r, res: res}, nil
+}
+
+// transactionResolver resolves the Transaction type.
+type transactionResolver struct {
+	root *Resolver
+	res  resource.Transaction
+}
+
+func (t *transactionResolver) ID() gql.ID                    { return gql.ID(t.res.ID) }
+func (t *transactionResolver) Hash() string                  { return t.res.Hash }
+func (t *transactionResolver) CreatedAt() string             { return t.res.LedgerCloseTime.Format(time.RFC3339) }
+func (t *transactionResolver) SourceAccount() string         { return t.res.Account }
+func (t *transactionResolver) SourceAccountSequence() string { return t.res.AccountSequence }
+func (t *transactionResolver) FeePaid() int32                { return t.res.FeePaid }
+func (t *transactionResolver) OperationCount() int32         { return t.res.OperationCount }
+func (t *transactionResolver) EnvelopeXdr() string           { return t.res.EnvelopeXdr }
+func (t *transactionResolver) ResultXdr() string             { return t.res.ResultXdr }
+func (t *transactionResolver) ResultMetaXdr() string         { return t.res.ResultMetaXdr }
+func (t *transactionResolver) FeeMetaXdr() string            { return t.res.FeeMetaXdr }
+func (t *transactionResolver) MemoType() string              { return t.res.MemoType }
+func (t *transactionResolver) Memo() *string                 { return optional(t.res.Memo) }
+func (t *transactionResolver) Signatures() []string          { return t.res.Signatures }
+
+func (t *transactionResolver) Ledger(ctx context.Context) (*ledgerResolver, error) {
+	var record history.Ledger
+	err := t.root.HistoryQ(ctx).LedgerBySequence(&record, t.res.Ledger)
+	if err != nil {
+		return nil, err
+	}
+	return t.root.newLedger(ctx, record), nil
+}
+
+func (t *transactionResolver) Operations(
+	ctx context.Context,
+	args connectionArgs,
+) (*operationConnection, error) {
+	return t.root.operations(ctx, args, func(q *history.OperationsQ) {
+		q.ForTransaction(t.res.Hash)
+	})
+}
+
+func (t *transactionResolver) Effects(
+	ctx context.Context,
+	args connectionArgs,
+) (*effectConnection, error) {
+	return t.root.effects(ctx, args, func(q *history.EffectsQ) {
+		q.ForTransaction(t.res.Hash)
+	})
+}
+
+// transactionConnection resolves the TransactionConnection type.
+type transactionConnection struct {
+	edges []*transactionEdge
+	info  *pageInfo
+}
+
+func (c *transactionConnection) Edges() []*transactionEdge { return c.edges }
+func (c *transactionConnection) PageInfo() *pageInfo       { return c.info }
+
+// transactionEdge resolves the TransactionEdge type.
+type transactionEdge struct {
+	cursor string
+	node   *transactionResolver
+}
+
+func (e *transactionEdge) Cursor() string             { return e.cursor }
+func (e *transactionEdge) Node() *transactionResolver { return e.node }
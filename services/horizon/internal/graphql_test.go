@@ -0,0 +1,131 @@
+package horizon
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stellar/go/services/horizon/internal/test"
+)
+
+func TestGraphQL(t *testing.T) {
+	tt := test.Start(t).Scenario("base")
+	defer tt.Finish()
+
+	config := NewTestConfig()
+	config.EnableGraphQL = true
+	app, err := NewApp(config)
+	tt.Require.NoError(err)
+	defer app.Close()
+	rh := NewRequestHelper(app)
+
+	type edges []struct {
+		Cursor string          `json:"cursor"`
+		Node   json.RawMessage `json:"node"`
+	}
+	type pageInfo struct {
+		HasNextPage     bool    `json:"hasNextPage"`
+		HasPreviousPage bool    `json:"hasPreviousPage"`
+		StartCursor     *string `json:"startCursor"`
+		EndCursor       *string `json:"endCursor"`
+	}
+	var res struct {
+		Data   json.RawMessage `json:"data"`
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	get := func(query string) {
+		w := rh.Get("/graphql?" + url.Values{"query": {query}}.Encode())
+		tt.Require.Equal(200, w.Code)
+		res.Data, res.Errors = nil, nil
+		tt.Require.NoError(json.Unmarshal(w.Body.Bytes(), &res))
+	}
+
+	// ledgers are paged forward
+	get(`{ ledgers(first: 2) { edges { cursor node { sequence } } pageInfo { hasNextPage hasPreviousPage startCursor endCursor } } }`)
+	tt.Require.Empty(res.Errors)
+	var ledgers struct {
+		Ledgers struct {
+			Edges    edges    `json:"edges"`
+			PageInfo pageInfo `json:"pageInfo"`
+		} `json:"ledgers"`
+	}
+	tt.Require.NoError(json.Unmarshal(res.Data, &ledgers))
+	if tt.Assert.Len(ledgers.Ledgers.Edges, 2) {
+		tt.Assert.JSONEq(`{"sequence": 1}`, string(ledgers.Ledgers.Edges[0].Node))
+		tt.Assert.JSONEq(`{"sequence": 2}`, string(ledgers.Ledgers.Edges[1].Node))
+	}
+	tt.Assert.True(ledgers.Ledgers.PageInfo.HasNextPage)
+	tt.Assert.False(ledgers.Ledgers.PageInfo.HasPreviousPage)
+	tt.Assert.Equal(ledgers.Ledgers.Edges[1].Cursor, *ledgers.Ledgers.PageInfo.EndCursor)
+
+	// and backward, in ascending order
+	get(`{ ledgers(last: 2) { edges { node { sequence } } pageInfo { hasNextPage hasPreviousPage } } }`)
+	tt.Require.Empty(res.Errors)
+	tt.Require.NoError(json.Unmarshal(res.Data, &ledgers))
+	if tt.Assert.Len(ledgers.Ledgers.Edges, 2) {
+		tt.Assert.JSONEq(`{"sequence": 2}`, string(ledgers.Ledgers.Edges[0].Node))
+		tt.Assert.JSONEq(`{"sequence": 3}`, string(ledgers.Ledgers.Edges[1].Node))
+	}
+	tt.Assert.False(ledgers.Ledgers.PageInfo.HasNextPage)
+	tt.Assert.True(ledgers.Ledgers.PageInfo.HasPreviousPage)
+
+	// accounts with their transactions and operations
+	get(`{ account(id: "GCXKG6RN4ONIEPCMNFB732A436Z5PNDSRLGWK7GBLCMQLIFO4S7EYWVU") {
+		id
+		transactions { edges { node { ledger { sequence } } } }
+		operations { edges { node { type } } }
+	} }`)
+	tt.Require.Empty(res.Errors)
+	var account struct {
+		Account struct {
+			ID           string `json:"id"`
+			Transactions struct {
+				Edges edges `json:"edges"`
+			} `json:"transactions"`
+			Operations struct {
+				Edges edges `json:"edges"`
+			} `json:"operations"`
+		} `json:"account"`
+	}
+	tt.Require.NoError(json.Unmarshal(res.Data, &account))
+	tt.Assert.Equal("GCXKG6RN4ONIEPCMNFB732A436Z5PNDSRLGWK7GBLCMQLIFO4S7EYWVU", account.Account.ID)
+	if tt.Assert.Len(account.Account.Transactions.Edges, 2) {
+		tt.Assert.JSONEq(`{"ledger": {"sequence": 2}}`, string(account.Account.Transactions.Edges[0].Node))
+		tt.Assert.JSONEq(`{"ledger": {"sequence": 3}}`, string(account.Account.Transactions.Edges[1].Node))
+	}
+	if tt.Assert.Len(account.Account.Operations.Edges, 2) {
+		tt.Assert.JSONEq(`{"type": "create_account"}`, string(account.Account.Operations.Edges[0].Node))
+		tt.Assert.JSONEq(`{"type": "payment"}`, string(account.Account.Operations.Edges[1].Node))
+	}
+
+	// unknown records are null
+	get(`{ account(id: "GDYNPS7A4QNHCSNZVUEKIXT3VXEJWWUXLXUQTNLEDYHGXHQSLDCVPBHA") { id } ledger(sequence: 100) { id } }`)
+	tt.Require.Empty(res.Errors)
+	tt.Assert.JSONEq(`{"account": null, "ledger": null}`, string(res.Data))
+
+	// invalid page arguments are reported as errors
+	get(`{ ledgers(first: 1, last: 1) { edges { cursor } } }`)
+	tt.Assert.NotEmpty(res.Errors)
+
+	// queries can be posted as JSON
+	w := rh.Post("/graphql", nil, func(r *http.Request) {
+		r.Header.Set("Content-Type", "application/json")
+		r.Body = ioutil.NopCloser(strings.NewReader(
+			`{"query": "query L($seq: Int!) { ledger(sequence: $seq) { transactionCount } }", "variables": {"seq": 2}}`,
+		))
+	})
+	tt.Require.Equal(200, w.Code)
+	res.Data, res.Errors = nil, nil
+	tt.Require.NoError(json.Unmarshal(w.Body.Bytes(), &res))
+	tt.Require.Empty(res.Errors)
+	tt.Assert.JSONEq(`{"ledger": {"transactionCount": 3}}`, string(res.Data))
+
+	// requests without a query are rejected
+	w = rh.Get("/graphql")
+	tt.Assert.Equal(400, w.Code)
+}
@@ -7,7 +7,8 @@ import (
 )
 
 // BaseURL returns the "base" url for this request, defined as a url containing
-// the Host and Scheme portions of the request uri.
+// the Host and Scheme portions of the request uri, and the path prefix the
+// request is served under, if any.
 func BaseURL(ctx context.Context) *url.URL {
 	r := RequestFromContext(ctx)
 
@@ -28,5 +29,21 @@ func BaseURL(ctx context.Context) *url.URL {
 	return &url.URL{
 		Scheme: scheme,
 		Host:   r.Host,
+		Path:   BasePath(ctx),
 	}
 }
+
+// BasePathContext returns a context whose requests are served under the path
+// prefix `path`, which is stripped from their url before they are routed.
+func BasePathContext(parent context.Context, path string) context.Context {
+	return context.WithValue(parent, &basePathContextKey, path)
+}
+
+// BasePath returns the path prefix the requests of ctx are served under, or
+// the empty string if they are served at the root.
+func BasePath(ctx context.Context) string {
+	path, _ := ctx.Value(&basePathContextKey).(string)
+	return path
+}
+
+var basePathContextKey = 0
@@ -0,0 +1,83 @@
+package ingest
+
+import (
+	sq "github.com/Masterminds/squirrel"
+	"github.com/stellar/go/services/horizon/internal/db2/core"
+	"github.com/stellar/go/xdr"
+)
+
+// IngestChanges adds the accounts whose account or trustline entries were
+// changed by `changes`, since their signers or trustlines may have changed.
+func (accountsModified AccountsModified) IngestChanges(changes xdr.LedgerEntryChanges) {
+	for _, change := range changes {
+		key := change.LedgerKey()
+		switch key.Type {
+		case xdr.LedgerEntryTypeAccount:
+			accountsModified.add(key.Account.AccountId)
+		case xdr.LedgerEntryTypeTrustline:
+			accountsModified.add(key.TrustLine.AccountId)
+		}
+	}
+}
+
+func (accountsModified AccountsModified) add(aid xdr.AccountId) {
+	accountsModified[aid.Address()] = aid
+}
+
+// UpdateAccountIndexes replaces the rows of the accounts_signers and
+// accounts_trustlines tables of the modified accounts with their signers and
+// trustlines in stellar-core.  Merged accounts are removed from the indexes.
+func (accountsModified AccountsModified) UpdateAccountIndexes(is *Session) {
+	if is.Err != nil || len(accountsModified) == 0 {
+		return
+	}
+
+	addresses := make([]string, 0, len(accountsModified))
+	for address := range accountsModified {
+		addresses = append(addresses, address)
+	}
+
+	coreQ := &core.Q{Session: is.Cursor.CoreDB}
+
+	var signers []core.Signer
+	is.Err = coreQ.SignersByAddresses(&signers, addresses)
+	if is.Err != nil {
+		return
+	}
+
+	var trustlines []core.Trustline
+	is.Err = coreQ.TrustlinesByAddresses(&trustlines, addresses)
+	if is.Err != nil {
+		return
+	}
+
+	// perform a delete first since upsert is not supported if postgres < 9.5
+	for _, table := range []TableName{AccountSignersTableName, AccountTrustlinesTableName} {
+		_, is.Err = is.Ingestion.DB.Exec(sq.Delete(string(table)).Where(sq.Eq{"account": addresses}))
+		if is.Err != nil {
+			return
+		}
+	}
+
+	for _, signer := range signers {
+		is.Ingestion.builders[AccountSignersTableName].Values(
+			signer.Accountid,
+			signer.Publickey,
+			signer.Weight,
+		)
+	}
+	is.Err = is.Ingestion.builders[AccountSignersTableName].Exec(is.Ingestion.DB)
+	if is.Err != nil {
+		return
+	}
+
+	for _, tl := range trustlines {
+		is.Ingestion.builders[AccountTrustlinesTableName].Values(
+			tl.Accountid,
+			tl.Assettype,
+			tl.Assetcode,
+			tl.Issuer,
+		)
+	}
+	is.Err = is.Ingestion.builders[AccountTrustlinesTableName].Exec(is.Ingestion.DB)
+}
@@ -0,0 +1,36 @@
+package ingest
+
+import (
+	"testing"
+
+	"github.com/stellar/go/services/horizon/internal/test"
+)
+
+func TestUpdateAccountIndexes(t *testing.T) {
+	tt := test.Start(t).ScenarioWithoutHorizon("kahuna")
+	defer tt.Finish()
+
+	s := ingest(tt)
+	tt.Require.NoError(s.Err)
+
+	// every account of the scenario is modified by its operations, so that
+	// the indexes hold all the signers and trustlines of stellar-core
+	var core, indexed int
+	tt.Require.NoError(tt.CoreSession().GetRaw(&core, `SELECT COUNT(*) FROM signers`))
+	tt.Require.NoError(tt.HorizonSession().GetRaw(&indexed, `SELECT COUNT(*) FROM accounts_signers`))
+	tt.Assert.NotZero(indexed)
+	tt.Assert.Equal(core, indexed)
+
+	tt.Require.NoError(tt.CoreSession().GetRaw(&core, `SELECT COUNT(*) FROM trustlines`))
+	tt.Require.NoError(tt.HorizonSession().GetRaw(&indexed, `SELECT COUNT(*) FROM accounts_trustlines`))
+	tt.Assert.NotZero(indexed)
+	tt.Assert.Equal(core, indexed)
+
+	// reingesting replaces the rows of the modified accounts
+	s.Err = nil
+	s.ClearExisting = true
+	s.Run()
+	tt.Require.NoError(s.Err)
+	tt.Require.NoError(tt.HorizonSession().GetRaw(&indexed, `SELECT COUNT(*) FROM accounts_trustlines`))
+	tt.Assert.Equal(core, indexed)
+}
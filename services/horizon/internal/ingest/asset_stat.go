@@ -30,7 +30,7 @@ func (assetsModified AssetsModified) add(asset xdr.Asset) {
 }
 
 // IngestOperation updates the assetsModified using the passed in operation
-func (assetsModified AssetsModified) IngestOperation(err error, op *xdr.Operation, source *xdr.AccountId, coreQ *core.Q) error {
+func (assetsModified AssetsModified) IngestOperation(err error, op *xdr.Operation, source *xdr.AccountId, historyQ *history.Q) error {
 	if err != nil {
 		return err
 	}
@@ -38,9 +38,12 @@ func (assetsModified AssetsModified) IngestOperation(err error, op *xdr.Operatio
 	body := op.Body
 	sourceAccount := defaultSourceAccount(op.SourceAccount, source)
 	switch body.Type {
-	// TODO NNS 2 need to fix GetCreateAssetID call when adding assets from account
-	// case xdr.OperationTypeSetOptions:
-	// 	assetsModified.addAssetsFromAccount(coreQ, sourceAccount)
+	case xdr.OperationTypeSetOptions:
+		// the flags and home domain of an issuer are part of the stats of the assets it issued
+		setOptions := body.SetOptionsOp
+		if setOptions.SetFlags != nil || setOptions.ClearFlags != nil || setOptions.HomeDomain != nil {
+			return assetsModified.addAssetsFromIssuer(historyQ, *sourceAccount)
+		}
 	case xdr.OperationTypePayment:
 		// payments is the only operation where we currently perform the optimization of checking against the issuer
 		return assetsModified.handlePaymentOp(body.PaymentOp, sourceAccount)
@@ -108,20 +111,19 @@ func (assetsModified AssetsModified) UpdateAssetStats(is *Session) {
 	}
 }
 
-// func (assetsModified AssetsModified) addAssetsFromAccount(coreQ *core.Q, account *xdr.AccountId) {
-// 	if account == nil {
-// 		return
-// 	}
-
-// 	var assets []xdr.Asset
-// 	coreQ.AssetsForAddress(&assets, account.Address())
+// addAssetsFromIssuer adds the assets issued by issuer that are already known
+// to horizon.  Assets without any history cannot have stats to update yet.
+func (assetsModified AssetsModified) addAssetsFromIssuer(historyQ *history.Q, issuer xdr.AccountId) error {
+	assets, err := historyQ.GetAssetsForIssuer(issuer.Address())
+	if err != nil {
+		return err
+	}
 
-// 	for _, asset := range assets {
-// 		if asset.Type != xdr.AssetTypeAssetTypeNative {
-// 			assetsModified.add(asset)
-// 		}
-// 	}
-// }
+	for _, asset := range assets {
+		assetsModified.add(asset)
+	}
+	return nil
+}
 
 func (assetsModified AssetsModified) deleteRows(session *db.Session) error {
 	if len(assetsModified) == 0 {
@@ -7,6 +7,7 @@ import (
 
 	"github.com/stellar/go/keypair"
 	"github.com/stellar/go/services/horizon/internal/db2/core"
+	"github.com/stellar/go/services/horizon/internal/db2/history"
 	"github.com/stellar/go/services/horizon/internal/test"
 	"github.com/stellar/go/support/db"
 	"github.com/stellar/go/xdr"
@@ -184,9 +185,9 @@ func TestAssetModified(t *testing.T) {
 	anotherAccount, anotherUSD := makeAccount("SAISD7SISIIW5YNQ7GY5727L6MOFS667K3LVIPYPPUBIPCRQUORFLQMN", "USD")
 
 	testCases := []struct {
-		opBody     xdr.OperationBody
-		needsCoreQ bool
-		wantAssets []string
+		opBody        xdr.OperationBody
+		needsHistoryQ bool
+		wantAssets    []string
 	}{
 		{
 			opBody: makeOperationBody(xdr.OperationTypeCreateAccount, xdr.CreateAccountOp{
@@ -300,14 +301,12 @@ func TestAssetModified(t *testing.T) {
 				"credit_alphanum4/USD/GAB7GMQPJ5YY2E4UJMLNAZPDEUKPK4AAIPRXIZHKZGUIRC6FP2LAQSDN", // anotherUSD
 				"credit_alphanum4/USD/GCFZWN3AOVFQM2BZTZX7P47WSI4QMGJC62LILPKODTNDLVKZZNA5BQJ3", // issuerUSD
 			},
-			// }, {
-			// TODO NNS 2
-			// 	opBody: makeOperationBody(xdr.OperationTypeSetOptions, xdr.SetOptionsOp{
-			// 		InflationDest: &destAccount,
-			// 	}),
-			// 	needsCoreQ: true,
-			// 	// the source account trusts issuerUSD in asset_stat_operations.rb
-			// 	wantAssets: []string{"credit_alphanum4/USD/GCFZWN3AOVFQM2BZTZX7P47WSI4QMGJC62LILPKODTNDLVKZZNA5BQJ3"}, // issuerUSD
+		}, {
+			// set options only modifies the assets issued by the source account when changing its flags or home domain
+			opBody: makeOperationBody(xdr.OperationTypeSetOptions, xdr.SetOptionsOp{
+				InflationDest: &destAccount,
+			}),
+			wantAssets: []string{},
 		}, {
 			opBody: makeOperationBody(xdr.OperationTypeChangeTrust, xdr.ChangeTrustOp{
 				Line:  issuerUSD,
@@ -331,8 +330,8 @@ func TestAssetModified(t *testing.T) {
 			}),
 			wantAssets: []string{"credit_alphanum4/CAT/GCYLTPOU7IVYHHA3XKQF4YB4W4ZWHFERMOQ7K47IWANKNBFBNJJNEOG5"}, // issued by anotherAccount
 		}, {
-			opBody:     makeOperationBody(xdr.OperationTypeAccountMerge, destAccount),
-			needsCoreQ: true,
+			opBody:        makeOperationBody(xdr.OperationTypeAccountMerge, destAccount),
+			needsHistoryQ: true,
 			// account merge can only happen on accounts that don't trust any assets
 			wantAssets: []string{},
 		}, {
@@ -349,12 +348,11 @@ func TestAssetModified(t *testing.T) {
 
 	for _, kase := range testCases {
 		t.Run(kase.opBody.Type.String(), func(t *testing.T) {
-			var coreQ *core.Q
-			if kase.needsCoreQ {
-				tt := test.Start(t).ScenarioWithoutHorizon("asset_stat_operations")
+			var historyQ *history.Q
+			if kase.needsHistoryQ {
+				tt := test.Start(t).Scenario("asset_stat_operations")
 				defer tt.Finish()
-				session := &db.Session{DB: tt.CoreDB}
-				coreQ = &core.Q{Session: session}
+				historyQ = &history.Q{Session: tt.HorizonSession()}
 			}
 
 			assetsModified := AssetsModified(make(map[string]xdr.Asset))
@@ -365,12 +363,64 @@ func TestAssetModified(t *testing.T) {
 					Body:          kase.opBody,
 				},
 				&sourceAccount,
-				coreQ)
+				historyQ)
 			assert.Equal(t, kase.wantAssets, extractKeys(assetsModified))
 		})
 	}
 }
 
+func TestAssetModifiedSetOptions(t *testing.T) {
+	tt := test.Start(t).Scenario("asset_stat_operations")
+	defer tt.Finish()
+	historyQ := &history.Q{Session: tt.HorizonSession()}
+
+	// GCFZWN3AOVFQM2BZTZX7P47WSI4QMGJC62LILPKODTNDLVKZZNA5BQJ3
+	issuerAccount, _ := makeAccount("SCCUFFUANIXJPAWBHDXZXY5D4GB32QPM6MOUWDD6PTYBLPE6JVYZFE76", "USD")
+	authRequired := xdr.Uint32(xdr.AccountFlagsAuthRequiredFlag)
+	homeDomain := xdr.String32("example.com")
+
+	testCases := []struct {
+		name       string
+		setOptions xdr.SetOptionsOp
+		wantAssets []string
+	}{
+		{
+			name:       "set flags",
+			setOptions: xdr.SetOptionsOp{SetFlags: &authRequired},
+			wantAssets: []string{"credit_alphanum4/USD/GCFZWN3AOVFQM2BZTZX7P47WSI4QMGJC62LILPKODTNDLVKZZNA5BQJ3"}, // issuerUSD
+		}, {
+			name:       "clear flags",
+			setOptions: xdr.SetOptionsOp{ClearFlags: &authRequired},
+			wantAssets: []string{"credit_alphanum4/USD/GCFZWN3AOVFQM2BZTZX7P47WSI4QMGJC62LILPKODTNDLVKZZNA5BQJ3"}, // issuerUSD
+		}, {
+			name:       "home domain",
+			setOptions: xdr.SetOptionsOp{HomeDomain: &homeDomain},
+			wantAssets: []string{"credit_alphanum4/USD/GCFZWN3AOVFQM2BZTZX7P47WSI4QMGJC62LILPKODTNDLVKZZNA5BQJ3"}, // issuerUSD
+		}, {
+			name:       "inflation destination",
+			setOptions: xdr.SetOptionsOp{InflationDest: &issuerAccount},
+			wantAssets: []string{},
+		},
+	}
+
+	for _, kase := range testCases {
+		t.Run(kase.name, func(t *testing.T) {
+			assetsModified := AssetsModified(make(map[string]xdr.Asset))
+			err := assetsModified.IngestOperation(
+				nil,
+				&xdr.Operation{
+					SourceAccount: &issuerAccount,
+					Body:          makeOperationBody(xdr.OperationTypeSetOptions, kase.setOptions),
+				},
+				&issuerAccount,
+				historyQ)
+			if assert.NoError(t, err) {
+				assert.Equal(t, kase.wantAssets, extractKeys(assetsModified))
+			}
+		})
+	}
+}
+
 func TestSourceAccountForAllowTrust(t *testing.T) {
 	// GCYLTPOU7IVYHHA3XKQF4YB4W4ZWHFERMOQ7K47IWANKNBFBNJJNEOG5
 	sourceAccount, _ := makeAccount("SANFNPZPA4LWBD3RPDSCJU63KCBU3OBFOM5FFBJCGIOCVIABMRTKBAU2", "USD")
@@ -0,0 +1,151 @@
+package ingest
+
+import (
+	"time"
+
+	"github.com/stellar/go/services/horizon/internal/db2/core"
+	"github.com/stellar/go/services/horizon/internal/db2/history"
+	"github.com/stellar/go/services/horizon/internal/log"
+	"github.com/stellar/go/support/errors"
+)
+
+// GapCheckInterval is the interval at which the ingestion system checks the
+// history database for gaps, i.e. ledgers missing between its elder and latest
+// ledgers, to backfill them.
+const GapCheckInterval = 10 * time.Minute
+
+// MaxGapBackfill is the maximum number of missing ledgers re-ingested during
+// a single tick of the ingestion system, so that backfilling large gaps does
+// not hold the ingestion of new ledgers for long.  Gaps larger than that are
+// backfilled over the following ticks.
+const MaxGapBackfill = 100
+
+// ReingestGaps re-ingests all the ledgers missing between the elder and the
+// latest ledgers of the history database, that stellar-core still has.  It
+// returns the number of ledgers ingested.
+func (i *System) ReingestGaps() (int, error) {
+	ingested, _, err := i.reingestGaps(0)
+	return ingested, err
+}
+
+// GapProgress returns the progress of the backfill of the ledgers missing from
+// the history database.
+func (i *System) GapProgress() GapProgress {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+	return i.gaps
+}
+
+// updateGapProgress applies `update` to the gap progress of the system.
+func (i *System) updateGapProgress(update func(*GapProgress)) {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+	update(&i.gaps)
+}
+
+// backfillGaps re-ingests the ledgers missing from the history database when
+// a gap check is due, i.e. on the first tick of the ingestion system, every
+// GapCheckInterval, and on every tick while gaps remain to be backfilled.
+func (i *System) backfillGaps() {
+	if time.Now().Before(i.nextGapCheck) || i.isStopping() {
+		return
+	}
+
+	_, remaining, err := i.reingestGaps(MaxGapBackfill)
+	if err != nil {
+		log.Errorf("ingest: gap backfill failed: %s", err)
+	}
+
+	if err != nil || remaining == 0 {
+		i.nextGapCheck = time.Now().Add(GapCheckInterval)
+	}
+}
+
+// reingestGaps re-ingests up to max of the ledgers missing from the history
+// database, or all of them when max is 0.  It returns the number of ledgers
+// ingested, and the number of missing ledgers that remain to be ingested.
+// i.Metrics.MissingLedgersGauge and GapProgress report the number of missing
+// ledgers as ingestion progresses, including those that can't be ingested because
+// stellar-core doesn't have them anymore.
+func (i *System) reingestGaps(max int) (ingested int, remaining int32, err error) {
+	var gaps []history.LedgerRange
+	hq := &history.Q{Session: i.HorizonDB}
+	err = hq.LedgerGaps(&gaps)
+	if err != nil {
+		err = errors.Wrap(err, "load ledger gaps failed")
+		return
+	}
+
+	var missing int32
+	for _, gap := range gaps {
+		missing += gap.Count()
+	}
+	i.Metrics.MissingLedgersGauge.Update(int64(missing))
+	i.updateGapProgress(func(p *GapProgress) {
+		p.Missing = missing
+		p.LastScan = time.Now()
+	})
+
+	if len(gaps) == 0 {
+		return
+	}
+
+	var coreElder int32
+	cq := &core.Q{Session: i.CoreDB}
+	err = cq.ElderLedger(&coreElder)
+	if err != nil {
+		err = errors.Wrap(err, "load core elder ledger failed")
+		return
+	}
+
+	log.
+		WithField("gaps", len(gaps)).
+		WithField("missing", missing).
+		Info("ingest: ledger gaps found")
+
+	for _, gap := range gaps {
+		if gap.End < coreElder {
+			log.
+				WithField("start", gap.Start).
+				WithField("end", gap.End).
+				Warn("ingest: ledger gap is older than stellar-core history")
+			continue
+		}
+		if gap.Start < coreElder {
+			gap.Start = coreElder
+		}
+
+		if max > 0 && ingested >= max {
+			remaining += gap.Count()
+			continue
+		}
+		if max > 0 && int(gap.Count()) > max-ingested {
+			remaining += gap.End - (gap.Start + int32(max-ingested)) + 1
+			gap.End = gap.Start + int32(max-ingested) - 1
+		}
+
+		start, end := gap.Start, gap.End
+		i.updateGapProgress(func(p *GapProgress) {
+			p.BackfillStart, p.BackfillEnd = start, end
+		})
+
+		var n int
+		n, err = i.ReingestRange(gap.Start, gap.End)
+		ingested += n
+		missing -= int32(n)
+		i.Metrics.MissingLedgersGauge.Update(int64(missing))
+		i.updateGapProgress(func(p *GapProgress) {
+			p.Missing = missing
+			p.BackfillStart, p.BackfillEnd = 0, 0
+		})
+		if err != nil {
+			return
+		}
+	}
+
+	log.
+		WithField("ingested", ingested).
+		WithField("remaining", remaining).
+		Info("ingest: ledger gaps backfilled")
+	return
+}
@@ -59,7 +59,7 @@ func (ingest *Ingestion) Clear(start int64, end int64) error {
 		return err
 	}
 
-	return nil
+	return ingest.clearProcessors(start, end)
 }
 
 // Close finishes the current transaction and finishes this ingestion.
@@ -75,6 +75,16 @@ func (ingest *Ingestion) Effect(address Address, opid int64, order int, typ hist
 	}
 
 	ingest.builders[EffectsTableName].Values(address, opid, order, typ, djson)
+
+	if l := ingest.processed(); l != nil {
+		l.Effects = append(l.Effects, ProcessedEffect{
+			Account:     address,
+			OperationID: opid,
+			Order:       order,
+			Type:        typ,
+			Details:     details,
+		})
+	}
 	return nil
 }
 
@@ -103,6 +113,11 @@ func (ingest *Ingestion) Flush() error {
 		}
 	}
 
+	err = ingest.process()
+	if err != nil {
+		return err
+	}
+
 	err = ingest.commit()
 	if err != nil {
 		return err
@@ -199,6 +214,10 @@ func (ingest *Ingestion) Ledger(
 		header.Data.LedgerVersion,
 		header.DataXDR(),
 	)
+
+	if len(ingest.Processors) > 0 {
+		ingest.pending = append(ingest.pending, &ProcessedLedger{ID: id, Header: header})
+	}
 }
 
 // Operation ingests the provided operation data into a new row in the
@@ -218,6 +237,17 @@ func (ingest *Ingestion) Operation(
 	}
 
 	ingest.builders[OperationsTableName].Values(id, txid, order, source.Address(), typ, djson)
+
+	if l := ingest.processed(); l != nil {
+		l.Operations = append(l.Operations, ProcessedOperation{
+			ID:            id,
+			TransactionID: txid,
+			Order:         order,
+			Source:        source,
+			Type:          typ,
+			Details:       details,
+		})
+	}
 	return nil
 }
 
@@ -244,6 +274,7 @@ func (ingest *Ingestion) Start() (err error) {
 	}
 
 	ingest.createInsertBuilders()
+	ingest.pending = nil
 
 	return
 }
@@ -336,6 +367,14 @@ func (ingest *Ingestion) Transaction(
 		time.Now().UTC(),
 		time.Now().UTC(),
 	)
+
+	if l := ingest.processed(); l != nil {
+		l.Transactions = append(l.Transactions, ProcessedTransaction{
+			ID:          id,
+			Transaction: tx,
+			Fee:         fee,
+		})
+	}
 }
 
 // TransactionParticipants ingests the provided account ids as participants of
@@ -453,6 +492,25 @@ func (ingest *Ingestion) createInsertBuilders() {
 		},
 	}
 
+	ingest.builders[AccountSignersTableName] = &BatchInsertBuilder{
+		TableName: AccountSignersTableName,
+		Columns: []string{
+			"account",
+			"signer",
+			"weight",
+		},
+	}
+
+	ingest.builders[AccountTrustlinesTableName] = &BatchInsertBuilder{
+		TableName: AccountTrustlinesTableName,
+		Columns: []string{
+			"account",
+			"asset_type",
+			"asset_code",
+			"asset_issuer",
+		},
+	}
+
 	ingest.builders[AssetStatsTableName] = &BatchInsertBuilder{
 		TableName: AssetStatsTableName,
 		Columns: []string{
@@ -5,11 +5,15 @@ package ingest
 
 import (
 	"sync"
+	"time"
 
 	sq "github.com/Masterminds/squirrel"
 	metrics "github.com/rcrowley/go-metrics"
 	"github.com/stellar/go/services/horizon/internal/db2/core"
+	"github.com/stellar/go/services/horizon/internal/ledger"
+	"github.com/stellar/go/services/horizon/internal/orderbook"
 	"github.com/stellar/go/support/db"
+	"github.com/stellar/go/support/errors"
 	"github.com/stellar/go/xdr"
 )
 
@@ -32,6 +36,8 @@ type Address string
 type TableName string
 
 const (
+	AccountSignersTableName          TableName = "accounts_signers"
+	AccountTrustlinesTableName       TableName = "accounts_trustlines"
 	AssetStatsTableName              TableName = "asset_stats"
 	EffectsTableName                 TableName = "history_effects"
 	LedgersTableName                 TableName = "history_ledgers"
@@ -54,8 +60,9 @@ type Cursor struct {
 	// CoreDB is the stellar-core db that data is ingested from.
 	CoreDB *db.Session
 
-	Metrics        *IngesterMetrics
-	AssetsModified AssetsModified
+	Metrics          *IngesterMetrics
+	AssetsModified   AssetsModified
+	AccountsModified AccountsModified
 
 	// Err is the error that caused this iteration to fail, if any.
 	Err error
@@ -114,15 +121,65 @@ type System struct {
 	// ledger.  0 represents "all ledgers".
 	HistoryRetentionCount uint
 
-	lock    sync.Mutex
-	current *Session
+	// ReingestWorkers is the number of workers that reingest ranges of ledgers
+	// concurrently.  Reingestion is sequential, in a single transaction, when
+	// it is 0 or 1.
+	ReingestWorkers int
+
+	// ReingestRangeSize is the number of ledgers a worker reingests in a single
+	// transaction.  DefaultReingestRangeSize is used when zero.
+	ReingestRangeSize int32
+
+	// OrderBookGraph, when set, is reloaded from the offers of stellar-core
+	// whenever stellar-core closes a new ledger, for path finding.
+	OrderBookGraph *orderbook.Graph
+
+	// Ledger tracks the ledger state of the network being imported.  The
+	// default tracker of the process is used when nil.
+	Ledger *ledger.Tracker
+
+	// Processors maintain tables derived from the ingested history, within
+	// the transactions of the ingestion.  It holds the processors registered
+	// with RegisterProcessor when the system is created.
+	Processors []Processor
+
+	lock         sync.Mutex
+	current      *Session
+	nextGapCheck time.Time
+	gaps         GapProgress
+	stopping     bool
+	sessions     sync.WaitGroup
 }
 
+// GapProgress reports the progress of the backfill of the ledgers missing from
+// the history database.
+type GapProgress struct {
+	// Missing is the number of ledgers missing between the elder and the latest
+	// ledgers of the history database, as of the last scan and the ledgers
+	// backfilled since.
+	Missing int32
+	// BackfillStart and BackfillEnd are the range of ledgers being backfilled,
+	// both 0 when no backfill is in progress.
+	BackfillStart int32
+	BackfillEnd   int32
+	// LastScan is when the history database was last scanned for gaps, zero
+	// if it never was.
+	LastScan time.Time
+}
+
+// ErrSessionInProgress is returned when a session cannot start because
+// another session is in progress or the system is stopping.
+var ErrSessionInProgress = errors.New("ingest: session in progress")
+
 // IngesterMetrics tracks all the metrics for the ingestion subsystem
 type IngesterMetrics struct {
 	ClearLedgerTimer  metrics.Timer
 	IngestLedgerTimer metrics.Timer
 	LoadLedgerTimer   metrics.Timer
+
+	// MissingLedgersGauge is the number of ledgers missing between the elder
+	// and the latest ledgers of the history database, as of the last gap check.
+	MissingLedgersGauge metrics.Gauge
 }
 
 // BatchInsertBuilder works like sq.InsertBuilder but has a better support for batching
@@ -136,6 +193,10 @@ type BatchInsertBuilder struct {
 	insertBuilder sq.InsertBuilder
 }
 
+// AccountsModified tracks the accounts whose signers or trustlines may have
+// changed during a cycle of ingestion
+type AccountsModified map[string]xdr.AccountId
+
 // AssetsModified tracks all the assets modified during a cycle of ingestion
 type AssetsModified map[string]xdr.Asset
 
@@ -143,8 +204,13 @@ type AssetsModified map[string]xdr.Asset
 type Ingestion struct {
 	// DB is the sql connection to be used for writing any rows into the horizon
 	// database.
-	DB       *db.Session
+	DB *db.Session
+
+	// Processors maintain the tables derived from the ingested history.
+	Processors []Processor
+
 	builders map[TableName]*BatchInsertBuilder
+	pending  []*ProcessedLedger
 }
 
 // Session represents a single attempt at ingesting data into the history
@@ -171,6 +237,10 @@ type Session struct {
 	// Metrics is a reference to where the session should record its metric information
 	Metrics *IngesterMetrics
 
+	// Stopping, when set, is checked after each ledger is ingested.  Once it
+	// returns true the session ends, committing the ledgers ingested so far.
+	Stopping func() bool
+
 	//
 	// Results fields
 	//
@@ -191,22 +261,25 @@ func New(network string, coreURL string, core, horizon *db.Session) *System {
 		StellarCoreURL: coreURL,
 		HorizonDB:      horizon,
 		CoreDB:         core,
+		Processors:     append([]Processor(nil), processors...),
 	}
 
 	i.Metrics.ClearLedgerTimer = metrics.NewTimer()
 	i.Metrics.IngestLedgerTimer = metrics.NewTimer()
 	i.Metrics.LoadLedgerTimer = metrics.NewTimer()
+	i.Metrics.MissingLedgersGauge = metrics.NewGauge()
 	return i
 }
 
 // NewCursor initializes a new ingestion cursor
 func NewCursor(first, last int32, i *System) *Cursor {
 	return &Cursor{
-		FirstLedger:    first,
-		LastLedger:     last,
-		CoreDB:         i.CoreDB,
-		Metrics:        &i.Metrics,
-		AssetsModified: AssetsModified(make(map[string]xdr.Asset)),
+		FirstLedger:      first,
+		LastLedger:       last,
+		CoreDB:           i.CoreDB,
+		Metrics:          &i.Metrics,
+		AssetsModified:   AssetsModified(make(map[string]xdr.Asset)),
+		AccountsModified: AccountsModified(make(map[string]xdr.AccountId)),
 	}
 }
 
@@ -216,11 +289,13 @@ func NewSession(i *System) *Session {
 
 	return &Session{
 		Ingestion: &Ingestion{
-			DB: hdb,
+			DB:         hdb,
+			Processors: i.Processors,
 		},
 		Network:          i.Network,
 		StellarCoreURL:   i.StellarCoreURL,
 		SkipCursorUpdate: i.SkipCursorUpdate,
 		Metrics:          &i.Metrics,
+		Stopping:         i.isStopping,
 	}
 }
@@ -2,6 +2,7 @@ package ingest
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stellar/go/network"
 	"github.com/stellar/go/services/horizon/internal/ledger"
@@ -45,6 +46,30 @@ func TestTick(t *testing.T) {
 	tt.Require.NoError(s.Err)
 }
 
+func TestShutdown(t *testing.T) {
+	tt := test.Start(t).ScenarioWithoutHorizon("kahuna")
+	defer tt.Finish()
+	sys := sys(tt)
+
+	// stopping sessions commit the ledgers ingested so far
+	s := NewSession(sys)
+	s.Cursor = NewCursor(1, ledger.CurrentState().CoreLatest, sys)
+	s.Stopping = func() bool { return s.Cursor.LedgerSequence() == 3 }
+	s.Run()
+	tt.Require.NoError(s.Err)
+	tt.Assert.Equal(3, s.Ingested)
+	tt.Assert.Equal(int32(3), s.Cursor.LastLedger)
+
+	var found int
+	err := tt.HorizonSession().GetRaw(&found, "SELECT COUNT(*) FROM history_ledgers")
+	tt.Require.NoError(err)
+	tt.Assert.Equal(3, found)
+
+	// no session starts once shut down
+	tt.Assert.True(sys.Shutdown(time.Second))
+	tt.Assert.Nil(sys.Tick())
+}
+
 func ingest(tt *test.T) *Session {
 	sys := sys(tt)
 	s := NewSession(sys)
@@ -0,0 +1,29 @@
+package ingest
+
+import (
+	"github.com/stellar/go/services/horizon/internal/db2/core"
+	"github.com/stellar/go/services/horizon/internal/log"
+)
+
+// updateOrderBookGraph reloads the order book graph of the system, if any,
+// when stellar-core closed a ledger since it was last loaded.  It is updated
+// on every tick, including those that ingest no ledger, so that the graph is
+// loaded as soon as the system starts.
+func (i *System) updateOrderBookGraph() {
+	if i.OrderBookGraph == nil {
+		return
+	}
+
+	latest := i.Ledger.CurrentState().CoreLatest
+	if latest <= i.OrderBookGraph.Ledger() {
+		return
+	}
+
+	err := i.OrderBookGraph.Load(&core.Q{Session: i.CoreDB}, latest)
+	if err != nil {
+		log.Errorf("ingest: order book graph update failed: %s", err)
+		return
+	}
+
+	log.WithField("ledger", latest).Debug("ingest: order book graph updated")
+}
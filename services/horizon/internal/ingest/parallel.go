@@ -0,0 +1,166 @@
+package ingest
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/stellar/go/services/horizon/internal/db2/history"
+	"github.com/stellar/go/services/horizon/internal/log"
+	"github.com/stellar/go/support/errors"
+)
+
+// DefaultReingestRangeSize is the number of ledgers ingested in a single
+// transaction by the workers of a parallel reingestion, when the
+// ReingestRangeSize of the system is not set.
+const DefaultReingestRangeSize = 1000
+
+// reingestAttempts is the number of times a worker attempts to ingest a range
+// of ledgers.  Concurrent workers may conflict when they insert the same new
+// account or asset, in which case the range of the worker that lost is
+// ingested again once the other one committed.
+const reingestAttempts = 3
+
+// reingestParallel reingests the ledgers from `start` to `end`, inclusive, by
+// splitting them in ranges ingested concurrently by i.ReingestWorkers
+// workers, each range in its own transaction.  Once all the ranges are
+// ingested, it verifies that the ledgers form an unbroken chain.
+func (i *System) reingestParallel(start, end int32) (int, error) {
+	if start > end {
+		start, end = end, start
+	}
+
+	size := i.ReingestRangeSize
+	if size <= 0 {
+		size = DefaultReingestRangeSize
+	}
+
+	log.
+		WithField("start", start).
+		WithField("end", end).
+		WithField("workers", i.ReingestWorkers).
+		WithField("range_size", size).
+		Info("reingest: parallel")
+
+	type result struct {
+		ingested int
+		err      error
+	}
+
+	var (
+		ranges  = make(chan history.LedgerRange)
+		results = make(chan result)
+		failed  = make(chan struct{})
+		wg      sync.WaitGroup
+	)
+
+	// queue the ranges until they are all queued or a range fails
+	go func() {
+		defer close(ranges)
+		for s := start; s <= end; s += size {
+			e := s + size - 1
+			if e > end || e < s {
+				e = end
+			}
+
+			select {
+			case ranges <- history.LedgerRange{Start: s, End: e}:
+			case <-failed:
+				return
+			}
+
+			if e == end {
+				return
+			}
+		}
+	}()
+
+	for w := 0; w < i.ReingestWorkers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for r := range ranges {
+				n, err := i.reingestRangeWithRetry(r)
+				results <- result{ingested: n, err: err}
+			}
+		}()
+	}
+
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+
+	var (
+		ingested int
+		err      error
+	)
+	for res := range results {
+		ingested += res.ingested
+		if res.err != nil && err == nil {
+			err = res.err
+			close(failed)
+		}
+	}
+
+	if err != nil {
+		return ingested, err
+	}
+
+	err = i.verifyLedgerChain(start, end)
+	return ingested, err
+}
+
+// reingestRangeWithRetry reingests r in a single transaction, attempting it
+// again when it fails.
+func (i *System) reingestRangeWithRetry(r history.LedgerRange) (ingested int, err error) {
+	for attempt := 1; attempt <= reingestAttempts; attempt++ {
+		is := NewSession(i)
+		is.Cursor = NewCursor(r.Start, r.End, i)
+		is.ClearExisting = true
+		// ranges complete out of order, so the cursor of stellar-core is not
+		// moved
+		is.SkipCursorUpdate = true
+
+		is.Run()
+		if is.Err == nil {
+			log.WithField("start", r.Start).
+				WithField("end", r.End).
+				WithField("ingested", is.Ingested).
+				Info("ingest: range complete")
+			return is.Ingested, nil
+		}
+
+		err = is.Err
+		log.WithField("start", r.Start).
+			WithField("end", r.End).
+			WithField("attempt", attempt).
+			WithField("err", err).
+			Warn("ingest: range failed")
+	}
+
+	return 0, errors.Wrap(err, fmt.Sprintf("reingest of ledgers %d to %d failed", r.Start, r.End))
+}
+
+// verifyLedgerChain verifies that the ledgers from `start` to `end`,
+// inclusive, are all in the history database and form an unbroken chain.
+func (i *System) verifyLedgerChain(start, end int32) error {
+	var broken []int32
+	q := &history.Q{Session: i.HorizonDB}
+	err := q.BrokenLedgerChain(&broken, start, end)
+	if err != nil {
+		return errors.Wrap(err, "load broken ledger chain failed")
+	}
+
+	if len(broken) > 0 {
+		return fmt.Errorf(
+			"reingest verification failed: %d ledgers missing or unchained, first at %d",
+			len(broken), broken[0],
+		)
+	}
+
+	log.
+		WithField("start", start).
+		WithField("end", end).
+		Info("reingest: verified")
+	return nil
+}
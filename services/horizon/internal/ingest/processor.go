@@ -0,0 +1,109 @@
+package ingest
+
+import (
+	"github.com/stellar/go/services/horizon/internal/db2/core"
+	"github.com/stellar/go/services/horizon/internal/db2/history"
+	"github.com/stellar/go/support/db"
+	"github.com/stellar/go/support/errors"
+	"github.com/stellar/go/xdr"
+)
+
+// Processor is an extension of ingestion that maintains tables derived from
+// the ingested history, such as the payment volume of each asset.  Processors
+// write to the horizon database within the transaction of the ingestion, so
+// that their tables are always consistent with the history tables.
+type Processor interface {
+	// Name identifies the processor in the errors it causes.
+	Name() string
+
+	// ProcessLedger is called with each ingested ledger once its history rows
+	// have been written.  An error fails the ingestion of the ledger.
+	ProcessLedger(db *db.Session, ledger *ProcessedLedger) error
+
+	// ClearLedgers removes the rows derived from the ledgers whose ids are
+	// within [start, end), before those ledgers are reingested.
+	ClearLedgers(db *db.Session, start int64, end int64) error
+}
+
+// ProcessedLedger is the history of a ledger, as given to processors.
+type ProcessedLedger struct {
+	ID           int64
+	Header       *core.LedgerHeader
+	Transactions []ProcessedTransaction
+	Operations   []ProcessedOperation
+	Effects      []ProcessedEffect
+}
+
+// ProcessedTransaction is a successful transaction of a processed ledger.
+type ProcessedTransaction struct {
+	ID          int64
+	Transaction *core.Transaction
+	Fee         *core.TransactionFee
+}
+
+// ProcessedOperation is an operation of a processed ledger, with the same
+// details as its row of the `history_operations` table.
+type ProcessedOperation struct {
+	ID            int64
+	TransactionID int64
+	Order         int32
+	Source        xdr.AccountId
+	Type          xdr.OperationType
+	Details       map[string]interface{}
+}
+
+// ProcessedEffect is an effect of a processed ledger, with the same details
+// as its row of the `history_effects` table.
+type ProcessedEffect struct {
+	Account     Address
+	OperationID int64
+	Order       int
+	Type        history.EffectType
+	Details     interface{}
+}
+
+// RegisterProcessor registers `p` to be run by the ingestion systems created
+// after the call.  It is meant to be called from the init function of the
+// package implementing the processor.
+func RegisterProcessor(p Processor) {
+	processors = append(processors, p)
+}
+
+// clearProcessors runs ClearLedgers for each processor of the ingestion.
+func (ingest *Ingestion) clearProcessors(start int64, end int64) error {
+	for _, p := range ingest.Processors {
+		err := p.ClearLedgers(ingest.DB, start, end)
+		if err != nil {
+			return errors.Wrapf(err, "failed to clear processor %s", p.Name())
+		}
+	}
+	return nil
+}
+
+// process runs the processors of the ingestion with the ledgers ingested
+// since the last call.
+func (ingest *Ingestion) process() error {
+	pending := ingest.pending
+	ingest.pending = nil
+
+	for _, ledger := range pending {
+		for _, p := range ingest.Processors {
+			err := p.ProcessLedger(ingest.DB, ledger)
+			if err != nil {
+				return errors.Wrapf(err, "processor %s failed on ledger %d", p.Name(), ledger.Header.Sequence)
+			}
+		}
+	}
+	return nil
+}
+
+// processed returns the ledger being ingested, as given to processors, or
+// nil when the ingestion has no processors.
+func (ingest *Ingestion) processed() *ProcessedLedger {
+	if len(ingest.Processors) == 0 || len(ingest.pending) == 0 {
+		return nil
+	}
+	return ingest.pending[len(ingest.pending)-1]
+}
+
+var processors []Processor
@@ -0,0 +1,78 @@
+package ingest
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stellar/go/services/horizon/internal/db2/history"
+	"github.com/stellar/go/services/horizon/internal/ledger"
+	"github.com/stellar/go/services/horizon/internal/test"
+	"github.com/stellar/go/support/db"
+)
+
+// recordingProcessor records the ledgers it processes.
+type recordingProcessor struct {
+	ledgers []*ProcessedLedger
+	cleared [][2]int64
+	err     error
+}
+
+func (p *recordingProcessor) Name() string { return "recording" }
+
+func (p *recordingProcessor) ProcessLedger(db *db.Session, l *ProcessedLedger) error {
+	p.ledgers = append(p.ledgers, l)
+	return p.err
+}
+
+func (p *recordingProcessor) ClearLedgers(db *db.Session, start int64, end int64) error {
+	p.cleared = append(p.cleared, [2]int64{start, end})
+	return nil
+}
+
+func TestProcessors(t *testing.T) {
+	tt := test.Start(t).ScenarioWithoutHorizon("kahuna")
+	defer tt.Finish()
+
+	p := &recordingProcessor{}
+	sys := sys(tt)
+	sys.Processors = []Processor{p}
+
+	s := NewSession(sys)
+	s.Cursor = NewCursor(1, ledger.CurrentState().CoreLatest, sys)
+	s.Run()
+	tt.Require.NoError(s.Err)
+	tt.Require.Len(p.ledgers, s.Ingested)
+
+	// the processors see the same history as the history tables
+	q := &history.Q{Session: tt.HorizonSession()}
+	var txs, ops, effects int
+	for _, l := range p.ledgers {
+		txs += len(l.Transactions)
+		ops += len(l.Operations)
+		effects += len(l.Effects)
+	}
+
+	var count int
+	tt.Require.NoError(q.GetRaw(&count, `SELECT COUNT(*) FROM history_transactions`))
+	tt.Assert.Equal(count, txs)
+	tt.Require.NoError(q.GetRaw(&count, `SELECT COUNT(*) FROM history_operations`))
+	tt.Assert.Equal(count, ops)
+	tt.Require.NoError(q.GetRaw(&count, `SELECT COUNT(*) FROM history_effects`))
+	tt.Assert.Equal(count, effects)
+
+	// reingestion clears the derived rows first
+	s = NewSession(sys)
+	s.Cursor = NewCursor(1, ledger.CurrentState().CoreLatest, sys)
+	s.ClearExisting = true
+	s.Run()
+	tt.Require.NoError(s.Err)
+	tt.Assert.NotEmpty(p.cleared)
+
+	// a failing processor fails the ingestion
+	p.err = errors.New("busted")
+	s = NewSession(sys)
+	s.Cursor = NewCursor(1, ledger.CurrentState().CoreLatest, sys)
+	s.ClearExisting = true
+	s.Run()
+	tt.Assert.Error(s.Err)
+}
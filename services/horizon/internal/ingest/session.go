@@ -12,7 +12,6 @@ import (
 	"github.com/stellar/go/amount"
 	"github.com/stellar/go/keypair"
 	"github.com/stellar/go/meta"
-	"github.com/stellar/go/services/horizon/internal/db2/core"
 	"github.com/stellar/go/services/horizon/internal/db2/history"
 	"github.com/stellar/go/services/horizon/internal/ingest/participants"
 	"github.com/stellar/go/support/errors"
@@ -44,8 +43,16 @@ func (is *Session) Run() {
 		if is.Err != nil {
 			break
 		}
+
+		// end the range at the ledger just ingested, so that it is the one
+		// reported to stellar-core
+		if is.Stopping != nil && is.Stopping() {
+			is.Cursor.LastLedger = is.Cursor.LedgerSequence()
+			break
+		}
 	}
 	is.Cursor.AssetsModified.UpdateAssetStats(is)
+	is.Cursor.AccountsModified.UpdateAccountIndexes(is)
 
 	if is.Err != nil {
 		is.Ingestion.Rollback()
@@ -372,8 +379,9 @@ func (is *Session) ingestOperation() {
 		is.Err,
 		is.Cursor.Operation(),
 		&is.Cursor.Transaction().Envelope.Tx.SourceAccount,
-		&core.Q{Session: is.Ingestion.DB},
+		&history.Q{Session: is.Ingestion.DB},
 	)
+	is.Cursor.AccountsModified.IngestChanges(is.Cursor.OperationChanges())
 }
 
 func (is *Session) ingestOperationParticipants() {
@@ -1,10 +1,11 @@
 package ingest
 
 import (
+	"time"
+
 	"github.com/stellar/go/services/horizon/internal/db2/core"
 	"github.com/stellar/go/services/horizon/internal/db2/history"
 	herr "github.com/stellar/go/services/horizon/internal/errors"
-	"github.com/stellar/go/services/horizon/internal/ledger"
 	"github.com/stellar/go/services/horizon/internal/log"
 	"github.com/stellar/go/services/horizon/internal/toid"
 	"github.com/stellar/go/support/errors"
@@ -13,7 +14,7 @@ import (
 // Backfill ingests history in reverse chronological order, from the current
 // horizon elder query for `n` ledgers
 func (i *System) Backfill(n uint) error {
-	start := ledger.CurrentState().HistoryElder
+	start := i.Ledger.CurrentState().HistoryElder
 	end := start - int32(n)
 	is := NewSession(i)
 	is.Cursor = NewCursor(start, end, i)
@@ -34,7 +35,7 @@ func (i *System) Backfill(n uint) error {
 func (i *System) ClearAll() error {
 
 	hdb := i.HorizonDB.Clone()
-	ingestion := &Ingestion{DB: hdb}
+	ingestion := &Ingestion{DB: hdb, Processors: i.Processors}
 
 	err := ingestion.Start()
 	if err != nil {
@@ -163,7 +164,12 @@ func (i *System) ReingestOutdated() (n int, err error) {
 }
 
 // ReingestRange reingests a range of ledgers, from `start` to `end`, inclusive.
+// The ledgers are reingested concurrently when i.ReingestWorkers is above 1.
 func (i *System) ReingestRange(start, end int32) (int, error) {
+	if i.ReingestWorkers > 1 {
+		return i.reingestParallel(start, end)
+	}
+
 	is := NewSession(i)
 	is.Cursor = NewCursor(start, end, i)
 	is.ClearExisting = true
@@ -177,6 +183,32 @@ func (i *System) ReingestRange(start, end int32) (int, error) {
 	return is.Ingested, is.Err
 }
 
+// ReingestRangeSession reingests a range of ledgers like ReingestRange, but as
+// a session of the system: it never runs concurrently with the session started
+// by Tick, and Shutdown waits for it to end.  It returns ErrSessionInProgress
+// without reingesting anything when another session is in progress or the
+// system is stopping.
+func (i *System) ReingestRangeSession(start, end int32) (int, error) {
+	i.lock.Lock()
+	if i.stopping || i.current != nil {
+		i.lock.Unlock()
+		return 0, ErrSessionInProgress
+	}
+
+	i.current = NewSession(i)
+	i.sessions.Add(1)
+	i.lock.Unlock()
+
+	defer func() {
+		i.lock.Lock()
+		i.current = nil
+		i.lock.Unlock()
+		i.sessions.Done()
+	}()
+
+	return i.ReingestRange(start, end)
+}
+
 // ReingestSingle re-ingests a single ledger
 func (i *System) ReingestSingle(sequence int32) error {
 	_, err := i.ReingestRange(sequence, sequence)
@@ -187,6 +219,11 @@ func (i *System) ReingestSingle(sequence int32) error {
 // that there currently is not an import session in progress.
 func (i *System) Tick() *Session {
 	i.lock.Lock()
+	if i.stopping {
+		i.lock.Unlock()
+		return nil
+	}
+
 	if i.current != nil {
 		log.Info("ingest: already in progress")
 		i.lock.Unlock()
@@ -195,12 +232,44 @@ func (i *System) Tick() *Session {
 
 	is := NewSession(i)
 	i.current = is
+	i.sessions.Add(1)
 	i.lock.Unlock()
 
+	defer i.sessions.Done()
 	i.runOnce()
 	return is
 }
 
+// Shutdown stops the ingestion of new ledgers: the session in progress
+// commits the ledgers it ingested so far, and no session starts afterwards.
+// It waits up to timeout for the session in progress to end, and reports
+// whether it did.
+func (i *System) Shutdown(timeout time.Duration) bool {
+	i.lock.Lock()
+	i.stopping = true
+	i.lock.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		i.sessions.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return true
+	case <-time.After(timeout):
+		return false
+	}
+}
+
+// isStopping reports whether Shutdown was called.
+func (i *System) isStopping() bool {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+	return i.stopping
+}
+
 // run causes the importer to check stellar-core to see if we can import new
 // data.
 func (i *System) runOnce() {
@@ -212,7 +281,7 @@ func (i *System) runOnce() {
 		}
 	}()
 
-	ls := ledger.CurrentState()
+	ls := i.Ledger.CurrentState()
 
 	// 1. stash a copy of the current ingestion session (assigned from the tick)
 	// 2. decide what to import
@@ -229,6 +298,11 @@ func (i *System) runOnce() {
 		i.lock.Unlock()
 	}()
 
+	// backfill gaps once new ledgers are ingested, but before the session ends
+	// so that it never runs concurrently with another session
+	defer i.backfillGaps()
+	defer i.updateOrderBookGraph()
+
 	if is == nil {
 		log.Warn("ingest: runOnce ran with a nil current session")
 		return
@@ -2,6 +2,7 @@ package ingest
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stellar/go/network"
 	"github.com/stellar/go/services/horizon/internal/test"
@@ -31,6 +32,70 @@ func TestBackfill(t *testing.T) {
 	}
 }
 
+func TestReingestGaps(t *testing.T) {
+	tt := test.Start(t).Scenario("kahuna")
+	defer tt.Finish()
+	is := sys(tt)
+
+	_, err := tt.HorizonSession().ExecRaw(
+		`DELETE FROM history_ledgers WHERE sequence BETWEEN 5 AND 9`,
+	)
+	tt.Require.NoError(err)
+
+	// limited backfill
+	ingested, remaining, err := is.reingestGaps(2)
+	tt.Require.NoError(err)
+	tt.Assert.Equal(2, ingested)
+	tt.Assert.EqualValues(3, remaining)
+	tt.Assert.EqualValues(3, is.Metrics.MissingLedgersGauge.Value())
+
+	progress := is.GapProgress()
+	tt.Assert.EqualValues(3, progress.Missing)
+	tt.Assert.EqualValues(0, progress.BackfillStart)
+	tt.Assert.EqualValues(0, progress.BackfillEnd)
+	tt.Assert.False(progress.LastScan.IsZero())
+
+	// full backfill
+	n, err := is.ReingestGaps()
+	tt.Require.NoError(err)
+	tt.Assert.Equal(3, n)
+	tt.Assert.EqualValues(0, is.Metrics.MissingLedgersGauge.Value())
+
+	var found int
+	err = tt.HorizonSession().GetRaw(&found, "SELECT COUNT(*) FROM history_ledgers WHERE sequence BETWEEN 5 AND 9")
+	tt.Require.NoError(err)
+	tt.Assert.Equal(5, found)
+}
+
+func TestReingestParallel(t *testing.T) {
+	tt := test.Start(t).Scenario("kahuna")
+	defer tt.Finish()
+	is := sys(tt)
+	is.ReingestWorkers = 4
+	is.ReingestRangeSize = 10
+
+	var expected int
+	err := tt.HorizonSession().GetRaw(&expected, "SELECT COUNT(*) FROM history_ledgers")
+	tt.Require.NoError(err)
+
+	n, err := is.ReingestAll()
+	tt.Require.NoError(err)
+	tt.Assert.Equal(expected, n)
+
+	var found int
+	err = tt.HorizonSession().GetRaw(&found, "SELECT COUNT(*) FROM history_ledgers")
+	tt.Require.NoError(err)
+	tt.Assert.Equal(expected, found)
+
+	// verification catches ledgers that are still missing
+	_, err = tt.HorizonSession().ExecRaw(`DELETE FROM history_ledgers WHERE sequence = 5`)
+	tt.Require.NoError(err)
+	err = is.verifyLedgerChain(2, 10)
+	if tt.Assert.Error(err) {
+		tt.Assert.Contains(err.Error(), "first at 5")
+	}
+}
+
 func TestClearAll(t *testing.T) {
 	tt := test.Start(t).Scenario("kahuna")
 	defer tt.Finish()
@@ -104,3 +169,25 @@ func TestValidation(t *testing.T) {
 		tt.Assert.Contains(err.Error(), "cur and prev ledger hashes don't match")
 	}
 }
+
+func TestReingestRangeSession(t *testing.T) {
+	tt := test.Start(t).Scenario("kahuna")
+	defer tt.Finish()
+	is := sys(tt)
+
+	n, err := is.ReingestRangeSession(2, 3)
+	tt.Require.NoError(err)
+	tt.Assert.Equal(2, n)
+	tt.Assert.Nil(is.current)
+
+	// refused while another session is in progress
+	is.current = NewSession(is)
+	_, err = is.ReingestRangeSession(2, 3)
+	tt.Assert.Equal(ErrSessionInProgress, err)
+	is.current = nil
+
+	// refused once the system is stopping
+	tt.Require.True(is.Shutdown(time.Second))
+	_, err = is.ReingestRangeSession(2, 3)
+	tt.Assert.Equal(ErrSessionInProgress, err)
+}
@@ -1,11 +1,23 @@
 package horizon
 
 import (
+	"github.com/stellar/go/services/horizon/internal/httpx"
+	"github.com/stellar/go/services/horizon/internal/ledger"
 	"golang.org/x/net/context"
 )
 
 func initAppContext(app *App) {
-	app.ctx, app.cancel = context.WithCancel(context.Background())
+	ctx := context.Background()
+
+	// apps serving a network under a prefix share the process with the apps
+	// of other networks
+	if app.config.URLPrefix != "" {
+		app.ledgerState = &ledger.Tracker{}
+		ctx = ledger.Context(ctx, app.ledgerState)
+		ctx = httpx.BasePathContext(ctx, app.config.URLPrefix)
+	}
+
+	app.ctx, app.cancel = context.WithCancel(ctx)
 }
 
 func init() {
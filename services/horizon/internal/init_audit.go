@@ -0,0 +1,30 @@
+package horizon
+
+import (
+	"github.com/stellar/go/services/horizon/internal/audit"
+	"github.com/stellar/go/services/horizon/internal/log"
+)
+
+func initAudit(app *App) {
+	var sink audit.Sink
+	switch app.config.AuditSubmissions {
+	case "":
+		return
+	case "db":
+		sink = &audit.DBSink{Session: app.HorizonSession(nil)}
+	case "log":
+		sink = &audit.LogSink{}
+	default:
+		log.Panicf("unknown audit-submissions: %s", app.config.AuditSubmissions)
+	}
+
+	app.audit = &audit.System{
+		Sink:              sink,
+		NetworkPassphrase: app.networkPassphrase,
+		Retention:         app.config.AuditRetention,
+	}
+}
+
+func init() {
+	appInit.Add("audit", initAudit, "app-context", "log", "horizon-db", "stellarCoreInfo")
+}
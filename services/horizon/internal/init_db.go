@@ -1,6 +1,8 @@
 package horizon
 
 import (
+	"github.com/stellar/go/services/horizon/internal/cache"
+	"github.com/stellar/go/services/horizon/internal/db2"
 	"github.com/stellar/go/services/horizon/internal/db2/core"
 	"github.com/stellar/go/services/horizon/internal/db2/history"
 	"github.com/stellar/go/services/horizon/internal/log"
@@ -17,6 +19,28 @@ func initHorizonDb(app *App) {
 	session.DB.SetMaxOpenConns(12)
 
 	app.historyQ = &history.Q{session}
+
+	if app.config.HistoryCacheSize > 0 {
+		app.historyCache = cache.New(int(app.config.HistoryCacheSize))
+		app.historyCache.Ledger = app.ledgerState
+	}
+
+	if app.config.ReplicaDatabaseURL == "" {
+		return
+	}
+
+	replica, err := db.Open("postgres", app.config.ReplicaDatabaseURL)
+	if err != nil {
+		log.Panic(err)
+	}
+	replica.DB.SetMaxIdleConns(4)
+	replica.DB.SetMaxOpenConns(12)
+
+	app.historyReplica = &db2.Replica{
+		Primary: session.DB,
+		Replica: replica.DB,
+		MaxLag:  app.config.ReplicaMaxLag,
+	}
 }
 
 func initCoreDb(app *App) {
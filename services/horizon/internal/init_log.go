@@ -3,12 +3,14 @@ package horizon
 import (
 	"github.com/getsentry/raven-go"
 	"github.com/stellar/go/services/horizon/internal/log"
+	slog "github.com/stellar/go/support/log"
 )
 
 // initLog initialized the logging subsystem, attaching app.log and
 // app.logMetrics.  It also configured the logger's level using Config.LogLevel.
 func initLog(app *App) {
 	log.DefaultLogger.Logger.Level = app.config.LogLevel
+	slog.SetLevel(app.config.LogLevel)
 }
 
 // initSentry initialized the default sentry client with the configured DSN
@@ -19,12 +19,25 @@ func initDbMetrics(app *App) {
 	app.horizonConnGauge = metrics.NewGauge()
 	app.coreConnGauge = metrics.NewGauge()
 	app.goroutineGauge = metrics.NewGauge()
+	app.ingestLagGauge = metrics.NewGauge()
+	app.streamGauge = metrics.NewGauge()
 	app.metrics.Register("history.latest_ledger", app.historyLatestLedgerGauge)
 	app.metrics.Register("history.elder_ledger", app.historyElderLedgerGauge)
 	app.metrics.Register("stellar_core.latest_ledger", app.coreLatestLedgerGauge)
 	app.metrics.Register("history.open_connections", app.horizonConnGauge)
 	app.metrics.Register("stellar_core.open_connections", app.coreConnGauge)
 	app.metrics.Register("goroutines", app.goroutineGauge)
+	app.metrics.Register("history.ingest_lag", app.ingestLagGauge)
+	app.metrics.Register("streams.open", app.streamGauge)
+}
+
+func initCacheMetrics(app *App) {
+	if app.historyCache == nil {
+		return
+	}
+	app.metrics.Register("history.cache.hits", app.historyCache.Metrics.HitMeter)
+	app.metrics.Register("history.cache.misses", app.historyCache.Metrics.MissMeter)
+	app.metrics.Register("history.cache.hit_rate", app.historyCache.Metrics.HitRateGauge)
 }
 
 func initIngesterMetrics(app *App) {
@@ -35,6 +48,16 @@ func initIngesterMetrics(app *App) {
 		app.ingester.Metrics.IngestLedgerTimer)
 	app.metrics.Register("ingester.clear_ledger",
 		app.ingester.Metrics.ClearLedgerTimer)
+	app.metrics.Register("ingester.missing_ledgers",
+		app.ingester.Metrics.MissingLedgersGauge)
+}
+
+func initReaperMetrics(app *App) {
+	for resource, gauge := range app.reaper.Metrics.DeletedRowsGauges {
+		key := fmt.Sprintf("reaper.deleted.%s", resource)
+		app.metrics.Register(key, gauge)
+	}
+	app.metrics.Register("reaper.run", app.reaper.Metrics.RunTimer)
 }
 
 func initLogMetrics(app *App) {
@@ -47,6 +70,9 @@ func initLogMetrics(app *App) {
 func initTxSubMetrics(app *App) {
 	app.submitter.Init()
 	app.metrics.Register("txsub.buffered", app.submitter.Metrics.BufferedSubmissionsGauge)
+	app.metrics.Register("txsub.held", app.submitter.Metrics.HeldSubmissionsGauge)
+	app.metrics.Register("txsub.queued_accounts", app.submitter.Metrics.QueuedAccountsGauge)
+	app.metrics.Register("txsub.max_queue_depth", app.submitter.Metrics.MaxQueueDepthGauge)
 	app.metrics.Register("txsub.open", app.submitter.Metrics.OpenSubmissionsGauge)
 	app.metrics.Register("txsub.succeeded", app.submitter.Metrics.SuccessfulSubmissionsMeter)
 	app.metrics.Register("txsub.failed", app.submitter.Metrics.FailedSubmissionsMeter)
@@ -65,7 +91,9 @@ func init() {
 	appInit.Add("metrics", initMetrics)
 	appInit.Add("log.metrics", initLogMetrics, "metrics")
 	appInit.Add("db-metrics", initDbMetrics, "metrics", "horizon-db", "core-db")
+	appInit.Add("cache-metrics", initCacheMetrics, "metrics", "horizon-db")
 	appInit.Add("web.metrics", initWebMetrics, "web.init", "metrics")
 	appInit.Add("txsub.metrics", initTxSubMetrics, "txsub", "metrics")
 	appInit.Add("ingester.metrics", initIngesterMetrics, "ingester", "metrics")
+	appInit.Add("reaper.metrics", initReaperMetrics, "reaper", "metrics")
 }
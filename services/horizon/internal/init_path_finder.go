@@ -1,13 +1,38 @@
 package horizon
 
 import (
+	"github.com/stellar/go/services/horizon/internal/log"
+	"github.com/stellar/go/services/horizon/internal/orderbook"
 	"github.com/stellar/go/services/horizon/internal/simplepath"
 )
 
 func initPathFinding(app *App) {
-	app.paths = &simplepath.Finder{app.CoreQ()}
+	maxPathLength := int(app.config.MaxPathLength)
+	maxResults := int(app.config.MaxPathResults)
+
+	if app.config.EnableOrderBookGraph {
+		// the graph is maintained by the ingestion system
+		if app.ingester != nil {
+			graph := orderbook.NewGraph()
+			app.ingester.OrderBookGraph = graph
+			app.paths = &orderbook.Finder{
+				Graph:         graph,
+				MaxPathLength: maxPathLength,
+				MaxResults:    maxResults,
+			}
+			return
+		}
+
+		log.Warn("order book graph requires ingestion, finding paths from stellar-core's database")
+	}
+
+	app.paths = &simplepath.Finder{
+		Q:             app.CoreQ(),
+		MaxPathLength: maxPathLength,
+		MaxResults:    maxResults,
+	}
 }
 
 func init() {
-	appInit.Add("path-finder", initPathFinding, "app-context", "log", "core-db")
+	appInit.Add("path-finder", initPathFinding, "app-context", "log", "core-db", "ingester")
 }
@@ -6,6 +6,9 @@ import (
 
 func initReaper(app *App) {
 	app.reaper = reap.New(app.config.HistoryRetentionCount, app.HorizonSession(nil))
+	app.reaper.Policies = app.config.HistoryRetentionPolicies
+	app.reaper.Interval = app.config.HistoryReapInterval
+	app.reaper.Ledger = app.ledgerState
 }
 
 func init() {
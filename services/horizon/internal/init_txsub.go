@@ -13,15 +13,25 @@ import (
 func initSubmissionSystem(app *App) {
 	cq := &core.Q{Session: app.CoreSession(nil)}
 
+	queue := sequence.NewManager()
+	if app.config.TxSubQueueSize > 0 {
+		queue.MaxSize = int(app.config.TxSubQueueSize)
+	}
+	if app.config.TxSubQueueTimeout > 0 {
+		queue.Timeout = app.config.TxSubQueueTimeout
+	}
+
 	app.submitter = &txsub.System{
 		Pending:         txsub.NewDefaultSubmissionList(),
 		Submitter:       txsub.NewDefaultSubmitter(http.DefaultClient, app.config.StellarCoreURL),
-		SubmissionQueue: sequence.NewManager(),
+		SubmissionQueue: queue,
 		Results: &results.DB{
 			Core:    cq,
 			History: &history.Q{Session: app.HorizonSession(nil)},
+			Ledger:  app.ledgerState,
 		},
 		Sequences:         cq.SequenceProvider(),
+		Validation:        cq.ValidationProvider(),
 		NetworkPassphrase: app.networkPassphrase,
 	}
 }
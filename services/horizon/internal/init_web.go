@@ -4,13 +4,16 @@ import (
 	"database/sql"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/PuerkitoBio/throttled"
 	"github.com/PuerkitoBio/throttled/store"
 	metrics "github.com/rcrowley/go-metrics"
 	"github.com/rs/cors"
 	"github.com/sebest/xff"
+	"github.com/stellar/go/services/horizon/internal/context/requestid"
 	"github.com/stellar/go/services/horizon/internal/db2"
+	"github.com/stellar/go/services/horizon/internal/log"
 	hProblem "github.com/stellar/go/services/horizon/internal/render/problem"
 	"github.com/stellar/go/services/horizon/internal/txsub/sequence"
 	"github.com/stellar/go/support/render/problem"
@@ -21,12 +24,18 @@ import (
 // Web contains the http server related fields for horizon: the router,
 // rate limiter, etc.
 type Web struct {
-	router      *web.Mux
-	rateLimiter *throttled.Throttler
+	router         *web.Mux
+	rateLimiter    *throttled.Throttler
+	rateLimitStore store.Store
+	apiKeys        APIKeyStore
 
 	requestTimer metrics.Timer
 	failureMeter metrics.Meter
 	successMeter metrics.Meter
+
+	// routeTimers times the requests of each route, keyed by method and
+	// pattern, e.g. "GET /ledgers/:id".
+	routeTimers metrics.Registry
 }
 
 // initWeb installed a new Web instance onto the provided app object.
@@ -36,6 +45,7 @@ func initWeb(app *App) {
 		requestTimer: metrics.NewTimer(),
 		failureMeter: metrics.NewMeter(),
 		successMeter: metrics.NewMeter(),
+		routeTimers:  metrics.NewRegistry(),
 	}
 
 	// register problems
@@ -44,6 +54,9 @@ func initWeb(app *App) {
 	problem.RegisterError(db2.ErrInvalidCursor, problem.BadRequest)
 	problem.RegisterError(db2.ErrInvalidLimit, problem.BadRequest)
 	problem.RegisterError(db2.ErrInvalidOrder, problem.BadRequest)
+
+	// problems report the id of the request they respond to
+	problem.SetInstanceFunc(requestid.FromContext)
 }
 
 // initWebMiddleware installs the middleware stack used for horizon onto the
@@ -54,23 +67,44 @@ func initWebMiddleware(app *App) {
 	r.Use(stripTrailingSlashMiddleware())
 	r.Use(middleware.EnvInit)
 	r.Use(app.Middleware)
-	r.Use(middleware.RequestID)
+	r.Use(RequestIDMiddleware)
 	r.Use(contextMiddleware(app.ctx))
+	r.Use(apiVersionMiddleware)
 	r.Use(xff.Handler)
 	r.Use(LoggerMiddleware)
+	// route before timing, so that requests are timed by route
+	r.Use(r.Router)
 	r.Use(requestMetricsMiddleware)
+	r.Use(timeoutMiddleware(app.config))
 	r.Use(RecoverMiddleware)
 	r.Use(middleware.AutomaticOptions)
 
-	c := cors.New(cors.Options{
-		AllowedOrigins: []string{"*"},
-		AllowedHeaders: []string{"*"},
-	})
+	c := cors.New(corsOptions(app.config))
 	r.Use(c.Handler)
 
 	r.Use(app.web.RateLimitMiddleware)
 }
 
+// corsOptions returns the options of the CORS middleware configured by
+// `config`, allowing any origin and header unless configured otherwise.
+func corsOptions(config Config) cors.Options {
+	opts := cors.Options{
+		AllowedOrigins: config.CORSAllowedOrigins,
+		AllowedMethods: config.CORSAllowedMethods,
+		AllowedHeaders: config.CORSAllowedHeaders,
+		MaxAge:         int(config.CORSMaxAge / time.Second),
+	}
+
+	if len(opts.AllowedOrigins) == 0 {
+		opts.AllowedOrigins = []string{"*"}
+	}
+	if len(opts.AllowedHeaders) == 0 {
+		opts.AllowedHeaders = []string{"*"}
+	}
+
+	return opts
+}
+
 // initWebActions installs the routing configuration of horizon onto the
 // provided app.  All route registration should be implemented here.
 func initWebActions(app *App) {
@@ -87,18 +121,21 @@ func initWebActions(app *App) {
 	r.Get("/ledgers/:ledger_id/effects", &EffectIndexAction{})
 
 	// account actions
+	r.Get("/accounts", &AccountIndexAction{})
 	r.Get("/accounts/:id", &AccountShowAction{})
 	r.Get("/accounts/:account_id/transactions", &TransactionIndexAction{})
 	r.Get("/accounts/:account_id/operations", &OperationIndexAction{})
 	r.Get("/accounts/:account_id/payments", &PaymentsIndexAction{})
 	r.Get("/accounts/:account_id/effects", &EffectIndexAction{})
 	r.Get("/accounts/:account_id/offers", &OffersByAccountAction{})
-	r.Get("/accounts/:account_id/trades", &TradeEffectIndexAction{})
+	r.Get("/accounts/:account_id/trades", &TradeIndexAction{})
+	r.Get("/accounts/:account_id/data", &DataIndexAction{})
 	r.Get("/accounts/:account_id/data/:key", &DataShowAction{})
 
 	// transaction history actions
 	r.Get("/transactions", &TransactionIndexAction{})
 	r.Get("/transactions/:id", &TransactionShowAction{})
+	r.Get("/transactions/:id/status", &TransactionStatusAction{})
 	r.Get("/transactions/:tx_id/operations", &OperationIndexAction{})
 	r.Get("/transactions/:tx_id/payments", &PaymentsIndexAction{})
 	r.Get("/transactions/:tx_id/effects", &EffectIndexAction{})
@@ -125,6 +162,20 @@ func initWebActions(app *App) {
 	// Asset related endpoints
 	r.Get("/assets", &AssetsAction{})
 
+	// Network related endpoints
+	r.Get("/fee_stats", &FeeStatsAction{})
+
+	// WebSocket alternative to streaming with Server Sent Events
+	if app.config.EnableWebSocket {
+		r.Get("/ws", app.ServeWebSocket)
+	}
+
+	// GraphQL queries
+	if app.config.EnableGraphQL {
+		r.Get("/graphql", app.ServeGraphQL)
+		r.Post("/graphql", app.ServeGraphQL)
+	}
+
 	// friendbot
 	redirectFriendbot := func(w http.ResponseWriter, r *http.Request) {
 		redirectURL := app.config.FriendbotURL + "?" + r.URL.RawQuery
@@ -151,6 +202,15 @@ func initWebRateLimiter(app *App) {
 
 	rateLimiter.DeniedHandler = &RateLimitExceededAction{App: app, Action: Action{}}
 	app.web.rateLimiter = rateLimiter
+	app.web.rateLimitStore = rateLimitStore
+
+	app.web.apiKeys = app.config.APIKeyStore
+	if app.web.apiKeys == nil && app.config.EnableAPIKeys {
+		if app.redis == nil {
+			log.Panic("api keys require redis, set --redis-url")
+		}
+		app.web.apiKeys = &RedisAPIKeyStore{Pool: app.redis}
+	}
 }
 
 func remoteAddrIP(r *http.Request) string {
@@ -171,6 +231,7 @@ func init() {
 		initWebRateLimiter,
 
 		"web.init",
+		"redis",
 	)
 	appInit.Add(
 		"web.middleware",
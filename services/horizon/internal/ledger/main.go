@@ -7,6 +7,8 @@ package ledger
 
 import (
 	"sync"
+
+	"golang.org/x/net/context"
 )
 
 // State represents a snapshot of both horizon's and stellar-core's view of the
@@ -17,20 +19,63 @@ type State struct {
 	HistoryElder  int32 `db:"history_elder"`
 }
 
+// Tracker holds the cached snapshot of the ledger state of a network.  A
+// process serving many networks tracks the state of each with its own
+// Tracker.  A nil Tracker is the default tracker of the process, whose state
+// is the one returned by CurrentState.
+type Tracker struct {
+	lock    sync.RWMutex
+	current State
+}
+
 // CurrentState returns the cached snapshot of ledger state
 func CurrentState() State {
-	lock.RLock()
-	ret := current
-	lock.RUnlock()
-	return ret
+	return defaultTracker.CurrentState()
 }
 
 // SetState updates the cached snapshot of the ledger state
 func SetState(next State) {
-	lock.Lock()
-	current = next
-	lock.Unlock()
+	defaultTracker.SetState(next)
+}
+
+// CurrentState returns the cached snapshot of the ledger state tracked by t.
+func (t *Tracker) CurrentState() State {
+	if t == nil {
+		t = defaultTracker
+	}
+
+	t.lock.RLock()
+	ret := t.current
+	t.lock.RUnlock()
+	return ret
+}
+
+// SetState updates the cached snapshot of the ledger state tracked by t.
+func (t *Tracker) SetState(next State) {
+	if t == nil {
+		t = defaultTracker
+	}
+
+	t.lock.Lock()
+	t.current = next
+	t.lock.Unlock()
+}
+
+// Context returns a context carrying the tracker t.
+func Context(parent context.Context, t *Tracker) context.Context {
+	return context.WithValue(parent, &trackerContextKey, t)
+}
+
+// FromContext returns the tracker carried by ctx, or nil, the default tracker,
+// if it carries none.
+func FromContext(ctx context.Context) *Tracker {
+	if ctx == nil {
+		return nil
+	}
+
+	t, _ := ctx.Value(&trackerContextKey).(*Tracker)
+	return t
 }
 
-var current State
-var lock sync.RWMutex
+var defaultTracker = &Tracker{}
+var trackerContextKey = 0
@@ -0,0 +1,33 @@
+package horizon
+
+import (
+	"net/http"
+	"strconv"
+
+	gctx "github.com/goji/context"
+	"github.com/stellar/go/services/horizon/internal/context/apiversion"
+	"github.com/stellar/go/support/render/problem"
+	"github.com/zenazn/goji/web"
+)
+
+// apiVersionMiddleware sets the version of the API the request is served
+// with, taken from its X-API-Version header and defaulting to the latest
+// version, and returns it in the X-API-Version header of the response.
+func apiVersionMiddleware(c *web.C, next http.Handler) http.Handler {
+	fn := func(w http.ResponseWriter, r *http.Request) {
+		ctx := gctx.FromC(*c)
+
+		version, err := apiversion.Parse(r.Header.Get(apiversion.Header))
+		if err != nil {
+			p := problem.BadRequest
+			p.Detail = err.Error()
+			problem.Render(ctx, w, p)
+			return
+		}
+
+		w.Header().Set(apiversion.Header, strconv.Itoa(version))
+		gctx.Set(c, apiversion.Context(ctx, version))
+		next.ServeHTTP(w, r)
+	}
+	return http.HandlerFunc(fn)
+}
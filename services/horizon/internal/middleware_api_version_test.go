@@ -0,0 +1,34 @@
+package horizon
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stellar/go/services/horizon/internal/context/apiversion"
+)
+
+func TestAPIVersionMiddleware(t *testing.T) {
+	ht := StartHTTPTest(t, "base")
+	defer ht.Finish()
+
+	// the latest version is served by default
+	w := ht.Get("/operations")
+	if ht.Assert.Equal(200, w.Code) {
+		ht.Assert.Equal("1", w.Header().Get("X-API-Version"))
+	}
+
+	w = ht.Get("/operations", func(r *http.Request) {
+		r.Header.Set("X-API-Version", "1")
+	})
+	if ht.Assert.Equal(200, w.Code) {
+		ht.Assert.Equal("1", w.Header().Get("X-API-Version"))
+	}
+
+	// unsupported versions are rejected
+	for _, version := range []string{"0", "latest", "99"} {
+		w = ht.Get("/operations", func(r *http.Request) {
+			r.Header.Set(apiversion.Header, version)
+		})
+		ht.Assert.Equal(400, w.Code, version)
+	}
+}
@@ -10,6 +10,7 @@ import (
 	gctx "github.com/goji/context"
 	"github.com/stellar/go/services/horizon/internal/log"
 	"github.com/stellar/go/services/horizon/internal/render"
+	slog "github.com/stellar/go/support/log"
 	"github.com/zenazn/goji/web"
 	"github.com/zenazn/goji/web/middleware"
 	"github.com/zenazn/goji/web/mutil"
@@ -22,9 +23,13 @@ func LoggerMiddleware(c *web.C, h http.Handler) http.Handler {
 		ctx := gctx.FromC(*c)
 		mw := mutil.WrapWriter(w)
 
-		logger := log.WithField("req", middleware.GetReqID(*c))
+		reqid := middleware.GetReqID(*c)
+		logger := log.WithField("req", reqid)
 
 		ctx = log.Set(ctx, logger)
+		// the logger of the support packages, e.g. of the sql queries run by
+		// db sessions bound to ctx
+		ctx = slog.Set(ctx, slog.WithField("req", reqid))
 		gctx.Set(c, ctx)
 
 		logStartOfRequest(ctx, r)
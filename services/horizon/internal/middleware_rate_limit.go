@@ -2,10 +2,100 @@ package horizon
 
 import (
 	"net/http"
+	"sync"
 
+	"github.com/PuerkitoBio/throttled"
+	gctx "github.com/goji/context"
+	hProblem "github.com/stellar/go/services/horizon/internal/render/problem"
+	"github.com/stellar/go/support/render/problem"
 	"github.com/zenazn/goji/web"
 )
 
+// APIKeyHeader is the header in which clients send their API key.  The key
+// can also be sent in the api_key query parameter, e.g. by browsers streaming
+// with EventSource, which cannot set headers.
+const APIKeyHeader = "X-API-Key"
+
+// RateLimitMiddleware limits the requests made with an API key by the limits
+// of the key, and the other requests by the IP address they come from.
 func (web *Web) RateLimitMiddleware(c *web.C, next http.Handler) http.Handler {
-	return web.rateLimiter.Throttle(next)
+	byIP := web.rateLimiter.Throttle(next)
+	if web.apiKeys == nil {
+		return byIP
+	}
+
+	limiters := &apiKeyLimiters{
+		web:      web,
+		next:     next,
+		limiters: map[string]apiKeyLimiter{},
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		key := r.Header.Get(APIKeyHeader)
+		if key == "" {
+			key = r.URL.Query().Get("api_key")
+		}
+		if key == "" {
+			byIP.ServeHTTP(w, r)
+			return
+		}
+
+		ctx := gctx.FromC(*c)
+		apiKey, err := web.apiKeys.Get(key)
+		if err != nil {
+			problem.Render(ctx, w, err)
+			return
+		}
+		if apiKey == nil {
+			problem.Render(ctx, w, hProblem.InvalidAPIKey)
+			return
+		}
+
+		limiters.Get(key, *apiKey).ServeHTTP(w, r)
+	})
+}
+
+// apiKeyLimiter limits the requests made with an API key.
+type apiKeyLimiter struct {
+	apiKey  APIKey
+	handler http.Handler
+}
+
+// apiKeyLimiters holds the limiters of the API keys seen by a rate limiting
+// middleware.
+type apiKeyLimiters struct {
+	web  *Web
+	next http.Handler
+
+	lock     sync.Mutex
+	limiters map[string]apiKeyLimiter
+}
+
+// Get returns the handler limiting the requests made with key, creating it
+// when the key is first seen or its limits changed.
+func (l *apiKeyLimiters) Get(key string, apiKey APIKey) http.Handler {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	limiter, ok := l.limiters[key]
+	if ok && limiter.apiKey == apiKey {
+		return limiter.handler
+	}
+
+	vary := &throttled.VaryBy{Custom: func(*http.Request) string { return "key:" + key }}
+	hourly := throttled.RateLimit(throttled.PerHour(apiKey.RateLimit), vary, l.web.rateLimitStore)
+	hourly.DeniedHandler = l.web.rateLimiter.DeniedHandler
+	handler := hourly.Throttle(l.next)
+
+	// the burst limit is checked first, so that the headers of the hourly
+	// limit are the ones sent to clients that aren't denied
+	if apiKey.Burst > 0 {
+		vary := &throttled.VaryBy{Custom: func(*http.Request) string { return "burst:" + key }}
+		burst := throttled.RateLimit(throttled.PerSec(apiKey.Burst), vary, l.web.rateLimitStore)
+		burst.DeniedHandler = l.web.rateLimiter.DeniedHandler
+		handler = burst.Throttle(handler)
+	}
+
+	l.limiters[key] = apiKeyLimiter{apiKey: apiKey, handler: handler}
+	return handler
 }
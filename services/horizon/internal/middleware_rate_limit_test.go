@@ -1,6 +1,7 @@
 package horizon
 
 import (
+	"net/http"
 	"strconv"
 	"testing"
 
@@ -86,6 +87,70 @@ func TestRateLimitMiddleware(t *testing.T) {
 		})
 	})
 
+	Convey("Rate Limiting by API key", t, func() {
+		keys := NewMemoryAPIKeyStore()
+		keys.Set("limited", APIKey{RateLimit: 5})
+		keys.Set("bursty", APIKey{RateLimit: 100, Burst: 2})
+
+		c := NewTestConfig()
+		c.RateLimit = throttled.PerHour(10)
+		c.APIKeyStore = keys
+		app, _ := NewApp(c)
+		defer app.Close()
+		rh := NewRequestHelper(app)
+
+		withKey := func(key string) func(*http.Request) {
+			return func(r *http.Request) {
+				r.Header.Set(APIKeyHeader, key)
+			}
+		}
+
+		Convey("sets X-RateLimit headers from the limits of the key", func() {
+			w := rh.Get("/", withKey("limited"))
+			So(w.Code, ShouldEqual, 200)
+			So(w.Header().Get("X-RateLimit-Limit"), ShouldEqual, "5")
+			So(w.Header().Get("X-RateLimit-Remaining"), ShouldEqual, "4")
+		})
+
+		Convey("Restricts based on the key, independently of the IP", func() {
+			for i := 0; i < 5; i++ {
+				w := rh.Get("/", withKey("limited"))
+				So(w.Code, ShouldEqual, 200)
+			}
+
+			w := rh.Get("/", withKey("limited"))
+			So(w.Code, ShouldEqual, 429)
+
+			w = rh.Get("/", withKey("limited"), test.RequestHelperRemoteAddr("127.0.0.2"))
+			So(w.Code, ShouldEqual, 429)
+
+			// the key can be sent as a query parameter
+			w = rh.Get("/?api_key=limited")
+			So(w.Code, ShouldEqual, 429)
+
+			// requests without a key are limited by IP
+			w = rh.Get("/")
+			So(w.Code, ShouldEqual, 200)
+			So(w.Header().Get("X-RateLimit-Limit"), ShouldEqual, "10")
+		})
+
+		Convey("Restricts bursts", func() {
+			for i := 0; i < 2; i++ {
+				w := rh.Get("/", withKey("bursty"))
+				So(w.Code, ShouldEqual, 200)
+				So(w.Header().Get("X-RateLimit-Limit"), ShouldEqual, "100")
+			}
+
+			w := rh.Get("/", withKey("bursty"))
+			So(w.Code, ShouldEqual, 429)
+		})
+
+		Convey("Rejects unknown keys", func() {
+			w := rh.Get("/", withKey("unknown"))
+			So(w.Code, ShouldEqual, 401)
+		})
+	})
+
 	Convey("Rate Limiting works with redis", t, func() {
 		c := NewTestConfig()
 		c.RateLimit = throttled.PerHour(10)
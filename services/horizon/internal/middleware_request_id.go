@@ -0,0 +1,57 @@
+package horizon
+
+import (
+	"net/http"
+
+	"github.com/zenazn/goji/web"
+	"github.com/zenazn/goji/web/middleware"
+)
+
+// RequestIDHeader is the header in which horizon returns the id of a request.
+// Clients, or the proxies in front of horizon, can set it on a request to have
+// their own id used, so that their reports can be correlated with the logs of
+// horizon.
+const RequestIDHeader = "X-Request-ID"
+
+// maxRequestIDLength is the length of the longest request id accepted from a
+// client.
+const maxRequestIDLength = 128
+
+// RequestIDMiddleware sets the id of the request, taking it from the
+// X-Request-ID header when valid and generating one otherwise, and returns it
+// in the X-Request-ID header of the response.
+func RequestIDMiddleware(c *web.C, next http.Handler) http.Handler {
+	respond := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set(RequestIDHeader, middleware.GetReqID(*c))
+		next.ServeHTTP(w, r)
+	})
+	generate := middleware.RequestID(c, respond)
+
+	fn := func(w http.ResponseWriter, r *http.Request) {
+		reqid := r.Header.Get(RequestIDHeader)
+		if !isValidRequestID(reqid) {
+			generate.ServeHTTP(w, r)
+			return
+		}
+
+		c.Env[middleware.RequestIDKey] = reqid
+		respond.ServeHTTP(w, r)
+	}
+	return http.HandlerFunc(fn)
+}
+
+// isValidRequestID reports whether reqid can be used as the id of a request.
+// Only short ids made of printable ascii characters are accepted, so that they
+// cannot forge log lines.
+func isValidRequestID(reqid string) bool {
+	if reqid == "" || len(reqid) > maxRequestIDLength {
+		return false
+	}
+
+	for i := 0; i < len(reqid); i++ {
+		if reqid[i] <= ' ' || reqid[i] > '~' {
+			return false
+		}
+	}
+	return true
+}
@@ -1,23 +1,30 @@
 package horizon
 
 import (
+	"fmt"
 	"net/http"
+	"time"
 
+	"github.com/rcrowley/go-metrics"
 	"github.com/zenazn/goji/web"
 	"github.com/zenazn/goji/web/mutil"
 )
 
 // Middleware that records metrics.
 //
-// It records success and failures using a meter, and times every request
+// It records success and failures using a meter, and times every request, in
+// total and by route.
 func requestMetricsMiddleware(c *web.C, h http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		app := c.Env["app"].(*App)
 		mw := mutil.WrapWriter(w)
 
-		app.web.requestTimer.Time(func() {
-			h.ServeHTTP(mw.(http.ResponseWriter), r)
-		})
+		then := time.Now()
+		h.ServeHTTP(mw.(http.ResponseWriter), r)
+		duration := time.Since(then)
+
+		app.web.requestTimer.Update(duration)
+		metrics.GetOrRegisterTimer(r.Method+" "+routePattern(*c), app.web.routeTimers).Update(duration)
 
 		if 200 <= mw.Status() && mw.Status() < 400 {
 			// a success is in [200, 400)
@@ -29,3 +36,13 @@ func requestMetricsMiddleware(c *web.C, h http.Handler) http.Handler {
 
 	})
 }
+
+// routePattern returns the pattern of the route matched by the request, or
+// "unmatched" for requests that don't match any route.
+func routePattern(c web.C) string {
+	match := web.GetMatch(c)
+	if match.Pattern == nil {
+		return "unmatched"
+	}
+	return fmt.Sprint(match.RawPattern())
+}
@@ -0,0 +1,94 @@
+package horizon
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	gctx "github.com/goji/context"
+	"github.com/stellar/go/services/horizon/internal/render"
+	"github.com/zenazn/goji/web"
+	"golang.org/x/net/context"
+)
+
+// timeoutMiddleware bounds how long requests may take: the context of each
+// request times out after the timeout of its route, cancelling the database
+// queries it runs.  Streams, over server sent events or web sockets, are
+// never timed out.
+func timeoutMiddleware(config Config) func(c *web.C, next http.Handler) http.Handler {
+	return func(c *web.C, next http.Handler) http.Handler {
+		fn := func(w http.ResponseWriter, r *http.Request) {
+			timeout := requestTimeout(config, *c, r)
+			if timeout == 0 {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			ctx, cancel := context.WithTimeout(gctx.FromC(*c), timeout)
+			defer cancel()
+
+			gctx.Set(c, ctx)
+			next.ServeHTTP(w, r)
+		}
+		return http.HandlerFunc(fn)
+	}
+}
+
+// requestTimeout returns the timeout of `r`, as configured by `config` for
+// the route it matched, or zero when it may run for as long as it needs.
+func requestTimeout(config Config, c web.C, r *http.Request) time.Duration {
+	if isStream(r) {
+		return 0
+	}
+
+	timeout, ok := config.RouteTimeouts[routePattern(c)]
+	if ok {
+		return timeout
+	}
+
+	return config.RequestTimeout
+}
+
+// isStream returns true when `r` opens a stream, rather than requesting a
+// single response.
+func isStream(r *http.Request) bool {
+	if strings.ToLower(r.Header.Get("Upgrade")) == "websocket" {
+		return true
+	}
+
+	return r.Header.Get("Accept") != "" &&
+		render.Negotiate(context.Background(), r) == render.MimeEventStream
+}
+
+// ParseRouteTimeouts parses a comma separated list of route timeouts, each
+// the pattern of a route and a duration separated by "=", such as
+// "/paths=1m,/trade_aggregations=20s".  A timeout of zero exempts its route
+// from timing out.
+func ParseRouteTimeouts(s string) (map[string]time.Duration, error) {
+	timeouts := map[string]time.Duration{}
+
+	for _, item := range strings.Split(s, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+
+		parts := strings.SplitN(item, "=", 2)
+		if len(parts) != 2 || !strings.HasPrefix(parts[0], "/") {
+			return nil, fmt.Errorf("invalid route timeout %q: expected /route=duration", item)
+		}
+
+		timeout, err := time.ParseDuration(parts[1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid route timeout %q: %s", item, err)
+		}
+		if timeout < 0 {
+			return nil, fmt.Errorf("invalid route timeout %q: negative duration", item)
+		}
+
+		timeouts[parts[0]] = timeout
+	}
+
+	return timeouts, nil
+}
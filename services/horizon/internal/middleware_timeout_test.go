@@ -0,0 +1,75 @@
+package horizon
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stellar/go/services/horizon/internal/test"
+	"github.com/zenazn/goji/web"
+)
+
+func TestRequestTimeout(t *testing.T) {
+	tt := test.Start(t)
+	defer tt.Finish()
+
+	config := NewTestConfig()
+	config.RequestTimeout = 5 * time.Second
+	config.RouteTimeouts = map[string]time.Duration{
+		"/paths":     time.Minute,
+		"/fee_stats": 0,
+	}
+
+	mux := web.New()
+	mux.Use(mux.Router)
+	timeout := func(path string, header http.Header) time.Duration {
+		var got time.Duration
+		mux.Get(path, func(c web.C, w http.ResponseWriter, r *http.Request) {
+			got = requestTimeout(config, c, r)
+		})
+		r, _ := http.NewRequest("GET", path, nil)
+		for k, v := range header {
+			r.Header[k] = v
+		}
+		mux.ServeHTTP(httptest.NewRecorder(), r)
+		return got
+	}
+
+	tt.Assert.Equal(5*time.Second, timeout("/ledgers", nil))
+	tt.Assert.Equal(time.Minute, timeout("/paths", nil))
+	tt.Assert.Equal(time.Duration(0), timeout("/fee_stats", nil))
+
+	// streams are never timed out
+	tt.Assert.Equal(time.Duration(0), timeout("/transactions", http.Header{
+		"Accept": []string{"text/event-stream"},
+	}))
+	tt.Assert.Equal(time.Duration(0), timeout("/ws", http.Header{
+		"Upgrade": []string{"websocket"},
+	}))
+	tt.Assert.Equal(5*time.Second, timeout("/effects", http.Header{
+		"Accept": []string{"application/hal+json"},
+	}))
+}
+
+func TestParseRouteTimeouts(t *testing.T) {
+	tt := test.Start(t)
+	defer tt.Finish()
+
+	timeouts, err := ParseRouteTimeouts("")
+	tt.Require.NoError(err)
+	tt.Assert.Empty(timeouts)
+
+	timeouts, err = ParseRouteTimeouts("/paths=1m, /accounts/:id/trades=20s,/fee_stats=0s")
+	tt.Require.NoError(err)
+	tt.Assert.Equal(map[string]time.Duration{
+		"/paths":               time.Minute,
+		"/accounts/:id/trades": 20 * time.Second,
+		"/fee_stats":           0,
+	}, timeouts)
+
+	for _, s := range []string{"/paths", "paths=1m", "/paths=soon", "/paths=-1s"} {
+		_, err = ParseRouteTimeouts(s)
+		tt.Assert.Error(err, s)
+	}
+}
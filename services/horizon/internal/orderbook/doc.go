@@ -0,0 +1,4 @@
+// Package orderbook provides an in-memory graph of the order books of
+// stellar-core, maintained by the ingestion system, and an implementation of
+// paths.Finder that searches it for payment paths.
+package orderbook
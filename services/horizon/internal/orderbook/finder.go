@@ -0,0 +1,166 @@
+package orderbook
+
+import (
+	"errors"
+
+	"github.com/stellar/go/services/horizon/internal/log"
+	"github.com/stellar/go/services/horizon/internal/paths"
+	"github.com/stellar/go/xdr"
+)
+
+// Finder implements the paths.Finder interface and searches for payment paths
+// using a breadth first search of an order book graph, without querying any
+// database.
+type Finder struct {
+	Graph *Graph
+
+	// MaxPathLength is the maximum number of assets between the source and
+	// the destination assets of the paths found.  paths.DefaultMaxPathLength
+	// is used when zero.
+	MaxPathLength int
+
+	// MaxResults is the maximum number of paths found by a search.
+	// paths.DefaultMaxResults is used when zero.
+	MaxResults int
+}
+
+// ensure the struct is paths.Finder compliant
+var _ paths.Finder = &Finder{}
+
+// Find performs a path find with the provided query.
+func (f *Finder) Find(q paths.Query) (result []paths.Path, err error) {
+	if len(q.SourceAssets) == 0 {
+		err = errors.New("No source assets")
+		return
+	}
+
+	s := f.Graph.current()
+	log.WithField("source_assets", q.SourceAssets).
+		WithField("destination_asset", q.DestinationAsset).
+		WithField("destination_amount", q.DestinationAmount).
+		WithField("ledger", s.ledger).
+		Info("Starting pathfind")
+
+	targets := map[string]bool{}
+	for _, a := range q.SourceAssets {
+		targets[a.String()] = true
+	}
+
+	var (
+		queue   = []*pathNode{{Asset: q.DestinationAsset, snapshot: s}}
+		visited = map[string]bool{}
+	)
+
+	for len(queue) > 0 && len(result) < f.maxResults() {
+		cur := queue[0]
+		queue = queue[1:]
+
+		id := cur.Asset.String()
+		if targets[id] {
+			result = append(result, cur)
+		}
+
+		if visited[id] {
+			continue
+		}
+		visited[id] = true
+
+		// the path of the nodes extending cur is made of all the assets of cur
+		// but the destination
+		if cur.Depth()-1 > f.maxPathLength() {
+			continue
+		}
+
+		for _, a := range s.connectedAssets(cur.Asset) {
+			next := &pathNode{Asset: a, Tail: cur, snapshot: s}
+
+			_, err = next.Cost(q.DestinationAmount)
+			if err == ErrNotEnough {
+				err = nil
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+
+			queue = append(queue, next)
+		}
+	}
+
+	log.WithField("found", len(result)).
+		Info("Finished pathfind")
+	return
+}
+
+func (f *Finder) maxPathLength() int {
+	if f.MaxPathLength <= 0 {
+		return paths.DefaultMaxPathLength
+	}
+	return f.MaxPathLength
+}
+
+func (f *Finder) maxResults() int {
+	if f.MaxResults <= 0 {
+		return paths.DefaultMaxResults
+	}
+	return f.MaxResults
+}
+
+// pathNode implements the paths.Path interface and represents a path
+// as a linked list pointing from source to destination.
+type pathNode struct {
+	Asset xdr.Asset
+	Tail  *pathNode
+
+	snapshot *snapshot
+}
+
+// check interface compatibility
+var _ paths.Path = &pathNode{}
+
+// Destination implements paths.Path.Destination interface method
+func (p *pathNode) Destination() xdr.Asset {
+	cur := p
+	for cur.Tail != nil {
+		cur = cur.Tail
+	}
+	return cur.Asset
+}
+
+// Source implements paths.Path.Source interface method
+func (p *pathNode) Source() xdr.Asset {
+	// the destination for path is the head of the linked list
+	return p.Asset
+}
+
+// Path implements paths.Path.Path interface method
+func (p *pathNode) Path() []xdr.Asset {
+	var path []xdr.Asset
+	for cur := p.Tail; cur != nil && cur.Tail != nil; cur = cur.Tail {
+		path = append(path, cur.Asset)
+	}
+	return path
+}
+
+// Cost implements the paths.Path.Cost interface method
+func (p *pathNode) Cost(amount xdr.Int64) (result xdr.Int64, err error) {
+	result = amount
+
+	for cur := p; cur.Tail != nil; cur = cur.Tail {
+		result, err = p.snapshot.cost(cur.Tail.Asset, cur.Asset, result)
+		if err != nil {
+			return
+		}
+	}
+
+	return
+}
+
+// Depth returns the length of the list
+func (p *pathNode) Depth() int {
+	depth := 0
+	for cur := p; cur != nil; cur = cur.Tail {
+		depth++
+	}
+	return depth
+}
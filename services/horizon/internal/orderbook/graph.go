@@ -0,0 +1,157 @@
+package orderbook
+
+import (
+	"errors"
+	"math/big"
+	"sync"
+
+	"github.com/stellar/go/services/horizon/internal/db2/core"
+	"github.com/stellar/go/xdr"
+)
+
+// ErrNotEnough represents an error that occurs when pricing a trade on an
+// orderbook.  This error occurs when the orderbook cannot fulfill the
+// requested amount.
+var ErrNotEnough = errors.New("not enough depth")
+
+// Graph is an in-memory graph of the order books of stellar-core, whose nodes
+// are assets and whose edges are the order books trading them.  It is loaded
+// from the offers table of stellar-core, and reloaded as new ledgers close.
+// Graph is safe for concurrent use.
+type Graph struct {
+	lock     sync.RWMutex
+	snapshot *snapshot
+}
+
+// snapshot is the state of the order books as of a ledger.  Snapshots are
+// never modified once loaded, so that searches can run against a consistent
+// state while the graph is reloaded.
+type snapshot struct {
+	ledger int32
+
+	// books holds the offers of each order book, ordered by price, keyed by
+	// the selling and the buying assets of the offers.
+	books map[pair][]offer
+
+	// edges holds, for each asset, the assets that offers selling it buy.
+	edges map[string][]xdr.Asset
+}
+
+// pair identifies an order book by the string representations of its selling
+// and buying assets, since xdr.Asset is not suitable for use as a map key.
+type pair struct {
+	selling string
+	buying  string
+}
+
+// offer is the part of an offer of stellar-core needed to price trades.
+type offer struct {
+	amount int64
+	pricen int64
+	priced int64
+}
+
+// NewGraph returns an empty graph, which finds no paths until it is loaded.
+func NewGraph() *Graph {
+	return &Graph{snapshot: &snapshot{
+		books: map[pair][]offer{},
+		edges: map[string][]xdr.Asset{},
+	}}
+}
+
+// Ledger returns the sequence of the ledger the graph was last loaded at, or 0
+// if it was never loaded.
+func (g *Graph) Ledger() int32 {
+	return g.current().ledger
+}
+
+// Load replaces the order books of the graph by the offers of stellar-core,
+// as of `ledger`.
+func (g *Graph) Load(q *core.Q, ledger int32) error {
+	var offers []core.Offer
+	err := q.AllOffers(&offers)
+	if err != nil {
+		return err
+	}
+
+	s := &snapshot{
+		ledger: ledger,
+		books:  map[pair][]offer{},
+		edges:  map[string][]xdr.Asset{},
+	}
+
+	for _, o := range offers {
+		var selling, buying xdr.Asset
+		selling, err = core.AssetFromDB(o.SellingAssetType, o.SellingAssetCode.String, o.SellingIssuer.String)
+		if err != nil {
+			return err
+		}
+		buying, err = core.AssetFromDB(o.BuyingAssetType, o.BuyingAssetCode.String, o.BuyingIssuer.String)
+		if err != nil {
+			return err
+		}
+
+		p := pair{selling: selling.String(), buying: buying.String()}
+		if _, ok := s.books[p]; !ok {
+			s.edges[p.selling] = append(s.edges[p.selling], buying)
+		}
+
+		// offers are loaded by price, so that each book stays ordered
+		s.books[p] = append(s.books[p], offer{
+			amount: int64(o.Amount),
+			pricen: int64(o.Pricen),
+			priced: int64(o.Priced),
+		})
+	}
+
+	g.lock.Lock()
+	g.snapshot = s
+	g.lock.Unlock()
+	return nil
+}
+
+func (g *Graph) current() *snapshot {
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+	return g.snapshot
+}
+
+// connectedAssets returns the assets bought by the offers selling `selling`.
+func (s *snapshot) connectedAssets(selling xdr.Asset) []xdr.Asset {
+	return s.edges[selling.String()]
+}
+
+// cost returns the amount of `buying` needed to buy `amount` of `selling`
+// from the offers of the order book trading them, taking the best priced
+// offers first.
+func (s *snapshot) cost(selling, buying xdr.Asset, amount xdr.Int64) (xdr.Int64, error) {
+	var (
+		needed = int64(amount)
+		cost   int64
+	)
+
+	for _, o := range s.books[pair{selling: selling.String(), buying: buying.String()}] {
+		if o.amount >= needed {
+			cost += mul(needed, o.pricen, o.priced)
+			return xdr.Int64(cost), nil
+		}
+
+		cost += mul(o.amount, o.pricen, o.priced)
+		needed -= o.amount
+	}
+
+	return 0, ErrNotEnough
+}
+
+// mul multiplies the input amount by the input price
+func mul(amount int64, pricen int64, priced int64) int64 {
+	var r, n, d big.Int
+
+	r.SetInt64(amount)
+	n.SetInt64(pricen)
+	d.SetInt64(priced)
+
+	r.Mul(&r, &n)
+	r.Quo(&r, &d)
+	return r.Int64()
+}
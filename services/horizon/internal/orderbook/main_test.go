@@ -0,0 +1,143 @@
+package orderbook
+
+import (
+	"testing"
+
+	"github.com/stellar/go/services/horizon/internal/db2/core"
+	"github.com/stellar/go/services/horizon/internal/paths"
+	"github.com/stellar/go/services/horizon/internal/simplepath"
+	"github.com/stellar/go/services/horizon/internal/test"
+	"github.com/stellar/go/xdr"
+)
+
+func TestGraph(t *testing.T) {
+	tt := test.Start(t).Scenario("paths")
+	defer tt.Finish()
+
+	g := NewGraph()
+	tt.Assert.Equal(int32(0), g.Ledger())
+
+	// an empty graph finds no paths
+	finder := &Finder{Graph: g}
+	p, err := finder.Find(eurQuery())
+	if tt.Assert.NoError(err) {
+		tt.Assert.Len(p, 0)
+	}
+
+	err = g.Load(&core.Q{Session: tt.CoreSession()}, 3)
+	tt.Require.NoError(err)
+	tt.Assert.Equal(int32(3), g.Ledger())
+	tt.Assert.NotEmpty(g.current().connectedAssets(eur))
+}
+
+func TestFinder(t *testing.T) {
+	tt := test.Start(t).Scenario("paths")
+	defer tt.Finish()
+
+	q := &core.Q{Session: tt.CoreSession()}
+	g := NewGraph()
+	tt.Require.NoError(g.Load(q, 3))
+
+	finder := &Finder{Graph: g}
+	query := eurQuery()
+
+	p, err := finder.Find(query)
+	if tt.Assert.NoError(err) {
+		tt.Assert.Len(p, 3)
+	}
+
+	// the paths and costs are the ones found from stellar-core's database
+	expected, err := (&simplepath.Finder{Q: q}).Find(query)
+	tt.Require.NoError(err)
+	tt.Assert.Equal(costs(tt, expected, query.DestinationAmount), costs(tt, p, query.DestinationAmount))
+
+	query.DestinationAmount = xdr.Int64(200000001)
+	p, err = finder.Find(query)
+	if tt.Assert.NoError(err) {
+		tt.Assert.Len(p, 2)
+	}
+
+	query.DestinationAmount = xdr.Int64(500000001)
+	p, err = finder.Find(query)
+	if tt.Assert.NoError(err) {
+		tt.Assert.Len(p, 0)
+	}
+
+	// results are limited
+	finder.MaxResults = 1
+	p, err = finder.Find(eurQuery())
+	if tt.Assert.NoError(err) {
+		tt.Assert.Len(p, 1)
+	}
+
+	// path lengths are limited
+	finder.MaxResults = 0
+	finder.MaxPathLength = 1
+	p, err = finder.Find(eurQuery())
+	if tt.Assert.NoError(err) {
+		for _, path := range p {
+			tt.Assert.True(len(path.Path()) <= 1)
+		}
+	}
+
+	//  regression: paths that involve native currencies can be found
+	query = paths.Query{
+		DestinationAddress: "GDSBCQO34HWPGUGQSP3QBFEXVTSR2PW46UIGTHVWGWJGQKH3AFNHXHXN",
+		DestinationAsset:   native,
+		DestinationAmount:  xdr.Int64(1),
+		SourceAssets:       []xdr.Asset{usd, native},
+	}
+	p, err = finder.Find(query)
+	if tt.Assert.NoError(err) {
+		tt.Assert.Len(p, 2)
+	}
+}
+
+var (
+	native = makeAsset(xdr.AssetTypeAssetTypeNative, "", "")
+	usd    = makeAsset(
+		xdr.AssetTypeAssetTypeCreditAlphanum4,
+		"USD",
+		"GDSBCQO34HWPGUGQSP3QBFEXVTSR2PW46UIGTHVWGWJGQKH3AFNHXHXN")
+	eur = makeAsset(
+		xdr.AssetTypeAssetTypeCreditAlphanum4,
+		"EUR",
+		"GDSBCQO34HWPGUGQSP3QBFEXVTSR2PW46UIGTHVWGWJGQKH3AFNHXHXN")
+)
+
+// costs returns the costs of ps, keyed by the assets of their paths.
+func costs(tt *test.T, ps []paths.Path, amount xdr.Int64) map[string]xdr.Int64 {
+	result := map[string]xdr.Int64{}
+	for _, p := range ps {
+		cost, err := p.Cost(amount)
+		tt.Require.NoError(err)
+
+		key := p.Source().String()
+		for _, a := range p.Path() {
+			key += " -> " + a.String()
+		}
+		result[key] = cost
+	}
+	return result
+}
+
+func eurQuery() paths.Query {
+	return paths.Query{
+		DestinationAddress: "GAEDTJ4PPEFVW5XV2S7LUXBEHNQMX5Q2GM562RJGOQG7GVCE5H3HIB4V",
+		DestinationAsset:   eur,
+		DestinationAmount:  xdr.Int64(200000000),
+		SourceAssets:       []xdr.Asset{usd},
+	}
+}
+
+func makeAsset(typ xdr.AssetType, code string, issuer string) xdr.Asset {
+	if typ == xdr.AssetTypeAssetTypeNative {
+		return xdr.Asset{Type: typ}
+	}
+
+	result, err := core.AssetFromDB(typ, code, issuer)
+	if err != nil {
+		panic(err)
+	}
+	return result
+}
@@ -4,6 +4,15 @@ import (
 	"github.com/stellar/go/xdr"
 )
 
+// DefaultMaxPathLength is the maximum number of assets between the source and
+// the destination assets of the paths found by a finder, when not configured.
+// It is the maximum length of the path of a PathPaymentOp.
+const DefaultMaxPathLength = 5
+
+// DefaultMaxResults is the maximum number of paths found by a search, when not
+// configured.
+const DefaultMaxResults = 20
+
 // Query is a query for paths
 type Query struct {
 	DestinationAddress string
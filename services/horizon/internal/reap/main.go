@@ -1,23 +1,84 @@
 // Package reap contains the history reaping subsystem for horizon.  This system
 // is designed to remove data from the history database such that it does not
 // grow indefinitely.  The system can be configured with a number of ledgers to
-// maintain at a minimum.
+// maintain at a minimum, and with retention policies that reap some resources,
+// such as effects, earlier than the ledgers they belong to.
 package reap
 
 import (
 	"time"
 
+	"github.com/rcrowley/go-metrics"
+	"github.com/stellar/go/services/horizon/internal/ledger"
 	"github.com/stellar/go/support/db"
 )
 
+// DefaultInterval is the interval between the passes of a reaper whose
+// Interval is not set.
+const DefaultInterval = 1 * time.Hour
+
+// The resources of the history database that can be given their own retention
+// policy.
+const (
+	Effects      = "effects"
+	Operations   = "operations"
+	Transactions = "transactions"
+)
+
+// Ledgers is the resource made of the ledgers of the history database and of
+// all the data associated with them.  It is reaped according to the
+// RetentionCount of the system.
+const Ledgers = "ledgers"
+
 // System represents the history reaping subsystem of horizon.
 type System struct {
 	HorizonDB      *db.Session
 	RetentionCount uint
 
+	// Policies are the retention policies of the resources of the history
+	// database, which are reaped earlier than the ledgers they belong to.  A
+	// resource without a policy is retained as long as its ledgers.
+	Policies []Policy
+
+	// Interval is the interval between two passes of the reaper.
+	// DefaultInterval is used when zero.
+	Interval time.Duration
+
+	// Ledger tracks the ledger state of the network of HorizonDB.  The default
+	// tracker of the process is used when nil.
+	Ledger *ledger.Tracker
+
+	Metrics Metrics
+
 	nextRun time.Time
 }
 
+// Policy is the retention policy of a resource of the history database.  The
+// rows of the resource belonging to ledgers that are neither among the Count
+// latest ledgers nor closed within Age are reaped.  A policy with neither Count
+// nor Age set retains the resource as long as its ledgers.
+type Policy struct {
+	// Resource is the resource the policy applies to: Effects, Operations or
+	// Transactions.
+	Resource string
+
+	// Count is the minimum number of ledgers worth of the resource to retain.
+	Count uint
+
+	// Age is the minimum age of the resource to retain.
+	Age time.Duration
+}
+
+// Metrics tracks the metrics of the reaping subsystem.
+type Metrics struct {
+	// DeletedRowsGauges hold, for each resource, the number of rows deleted
+	// during the last pass of the reaper.
+	DeletedRowsGauges map[string]metrics.Gauge
+
+	// RunTimer times the passes of the reaper.
+	RunTimer metrics.Timer
+}
+
 // New initializes the reaper, causing it to begin polling the stellar-core
 // database for now ledgers and ingesting data into the horizon database.
 func New(retention uint, horizon *db.Session) *System {
@@ -26,6 +87,12 @@ func New(retention uint, horizon *db.Session) *System {
 		RetentionCount: retention,
 	}
 
-	r.nextRun = time.Now().Add(1 * time.Hour)
+	r.Metrics.DeletedRowsGauges = map[string]metrics.Gauge{}
+	for _, resource := range []string{Effects, Operations, Transactions, Ledgers} {
+		r.Metrics.DeletedRowsGauges[resource] = metrics.NewGauge()
+	}
+	r.Metrics.RunTimer = metrics.NewTimer()
+
+	r.nextRun = time.Now().Add(r.interval())
 	return r
 }
@@ -0,0 +1,71 @@
+package reap
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ParsePolicies parses a comma separated list of retention policies, each
+// written as `resource=retention`, e.g. `effects=30d,operations=1000000`.  The
+// retention is either a number of ledgers or an age, written as a number of
+// days followed by `d` or as a go duration such as `72h`.  A retention of `0`
+// retains the resource as long as its ledgers.
+func ParsePolicies(spec string) ([]Policy, error) {
+	var policies []Policy
+	seen := map[string]bool{}
+
+	for _, field := range strings.Split(spec, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+
+		parts := strings.SplitN(field, "=", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("invalid retention policy %q: expected resource=retention", field)
+		}
+
+		p := Policy{Resource: strings.TrimSpace(parts[0])}
+		if _, ok := resourceTables[p.Resource]; !ok {
+			return nil, fmt.Errorf("invalid retention policy %q: unknown resource %q", field, p.Resource)
+		}
+		if seen[p.Resource] {
+			return nil, fmt.Errorf("invalid retention policy %q: duplicate resource %q", field, p.Resource)
+		}
+		seen[p.Resource] = true
+
+		err := p.parseRetention(strings.TrimSpace(parts[1]))
+		if err != nil {
+			return nil, fmt.Errorf("invalid retention policy %q: %s", field, err)
+		}
+
+		policies = append(policies, p)
+	}
+
+	return policies, nil
+}
+
+func (p *Policy) parseRetention(retention string) error {
+	if count, err := strconv.ParseUint(retention, 10, 32); err == nil {
+		p.Count = uint(count)
+		return nil
+	}
+
+	if strings.HasSuffix(retention, "d") {
+		days, err := strconv.ParseUint(strings.TrimSuffix(retention, "d"), 10, 16)
+		if err != nil {
+			return fmt.Errorf("invalid number of days %q", retention)
+		}
+		p.Age = time.Duration(days) * 24 * time.Hour
+		return nil
+	}
+
+	age, err := time.ParseDuration(retention)
+	if err != nil || age < 0 {
+		return fmt.Errorf("invalid retention %q", retention)
+	}
+	p.Age = age
+	return nil
+}
@@ -0,0 +1,41 @@
+package reap
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParsePolicies(t *testing.T) {
+	policies, err := ParsePolicies("effects=30d, operations=1000,transactions=72h")
+	if assert.NoError(t, err) {
+		assert.Equal(t, []Policy{
+			{Resource: Effects, Age: 30 * 24 * time.Hour},
+			{Resource: Operations, Count: 1000},
+			{Resource: Transactions, Age: 72 * time.Hour},
+		}, policies)
+	}
+
+	policies, err = ParsePolicies("")
+	if assert.NoError(t, err) {
+		assert.Len(t, policies, 0)
+	}
+
+	policies, err = ParsePolicies("transactions=0")
+	if assert.NoError(t, err) {
+		assert.Equal(t, []Policy{{Resource: Transactions}}, policies)
+	}
+
+	for _, spec := range []string{
+		"effects",
+		"ledgers=10",
+		"effects=10,effects=20",
+		"effects=ten",
+		"effects=-1h",
+		"effects=xd",
+	} {
+		_, err = ParsePolicies(spec)
+		assert.Error(t, err, spec)
+	}
+}
@@ -3,36 +3,82 @@ package reap
 import (
 	"time"
 
-	"github.com/stellar/go/services/horizon/internal/errors"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/stellar/go/services/horizon/internal/db2/history"
+	herr "github.com/stellar/go/services/horizon/internal/errors"
 	"github.com/stellar/go/services/horizon/internal/ledger"
 	"github.com/stellar/go/services/horizon/internal/log"
 	"github.com/stellar/go/services/horizon/internal/toid"
+	"github.com/stellar/go/support/errors"
 )
 
-// DeleteUnretainedHistory removes all data associated with unretained ledgers.
+// table is a table of the history database, along with the column holding the
+// id from which the ledger of its rows is derived.
+type table struct {
+	name   string
+	column string
+}
+
+// resourceTables are the tables of the resources that can be given their own
+// retention policy.
+var resourceTables = map[string][]table{
+	Effects: {
+		{"history_effects", "history_operation_id"},
+	},
+	Operations: {
+		{"history_operation_participants", "history_operation_id"},
+		{"history_operations", "id"},
+	},
+	Transactions: {
+		{"history_transaction_participants", "history_transaction_id"},
+		{"history_transactions", "id"},
+	},
+}
+
+// DeleteUnretainedHistory removes all data associated with unretained ledgers,
+// then the resources that are not retained by their policies.
 func (r *System) DeleteUnretainedHistory() error {
-	// RetentionCount of 0 indicates "keep all history"
-	if r.RetentionCount == 0 {
-		return nil
-	}
+	deleted := map[string]int64{}
+	defer func() {
+		for resource, gauge := range r.Metrics.DeletedRowsGauges {
+			gauge.Update(deleted[resource])
+		}
+	}()
 
-	var (
-		latest      = ledger.CurrentState()
-		targetElder = (latest.HistoryLatest - int32(r.RetentionCount)) + 1
-	)
+	latest := r.Ledger.CurrentState()
 
-	if targetElder < latest.HistoryElder {
-		return nil
+	// RetentionCount of 0 indicates "keep all history"
+	if r.RetentionCount > 0 {
+		targetElder := (latest.HistoryLatest - int32(r.RetentionCount)) + 1
+
+		if targetElder > latest.HistoryElder {
+			err := r.clearBefore(targetElder, deleted)
+			if err != nil {
+				return err
+			}
+
+			log.
+				WithField("new_elder", targetElder).
+				Info("reaper succeeded")
+		}
 	}
 
-	err := r.clearBefore(targetElder)
+	elders, err := r.resourceElders(latest)
 	if err != nil {
 		return err
 	}
 
-	log.
-		WithField("new_elder", targetElder).
-		Info("reaper succeeded")
+	for _, resource := range []string{Effects, Operations, Transactions} {
+		elder, ok := elders[resource]
+		if !ok || elder <= latest.HistoryElder {
+			continue
+		}
+
+		err = r.clearResourceBefore(resource, elder, deleted)
+		if err != nil {
+			return err
+		}
+	}
 
 	return nil
 }
@@ -40,58 +86,146 @@ func (r *System) DeleteUnretainedHistory() error {
 // Tick triggers the reaper system to update itself, deleted unretained history
 // if it is the appropriate time.
 func (r *System) Tick() {
-	if time.Now().After(r.nextRun) {
+	if time.Now().Before(r.nextRun) {
 		return
 	}
 
 	r.runOnce()
-	r.nextRun = time.Now().Add(1 * time.Hour)
+	r.nextRun = time.Now().Add(r.interval())
+}
+
+func (r *System) interval() time.Duration {
+	if r.Interval <= 0 {
+		return DefaultInterval
+	}
+	return r.Interval
 }
 
 func (r *System) runOnce() {
 	defer func() {
 		if rec := recover(); rec != nil {
-			err := errors.FromPanic(rec)
+			err := herr.FromPanic(rec)
 			log.Errorf("reaper panicked: %s", err)
-			errors.ReportToSentry(err, nil)
+			herr.ReportToSentry(err, nil)
 		}
 	}()
 
+	if r.Metrics.RunTimer != nil {
+		defer r.Metrics.RunTimer.UpdateSince(time.Now())
+	}
+
 	err := r.DeleteUnretainedHistory()
 	if err != nil {
 		log.Errorf("reaper failed: %s", err)
 	}
 }
 
-func (r *System) clearBefore(seq int32) error {
-	log.WithField("new_elder", seq).Info("reaper: clearing")
-
-	clear := r.HorizonDB.DeleteRange
-	end := toid.New(seq, 0, 0).ToInt64()
-
-	err := clear(0, end, "history_effects", "history_operation_id")
-	if err != nil {
-		return err
+// resourceElders returns, for each resource whose policy reaps it, the
+// sequence of the oldest ledger whose rows of the resource are retained.
+// Operations load the hash of their transaction, so transactions are never
+// reaped earlier than operations.
+func (r *System) resourceElders(latest ledger.State) (map[string]int32, error) {
+	elders := map[string]int32{}
+	for _, p := range r.Policies {
+		elder, err := r.policyElder(p, latest)
+		if err != nil {
+			return nil, errors.Wrap(err, "compute "+p.Resource+" retention failed")
+		}
+		if elder > 0 {
+			elders[p.Resource] = elder
+		}
 	}
-	err = clear(0, end, "history_operation_participants", "history_operation_id")
-	if err != nil {
-		return err
+
+	if elder, ok := elders[Transactions]; ok {
+		opsElder, ok := elders[Operations]
+		switch {
+		case !ok:
+			delete(elders, Transactions)
+		case opsElder < elder:
+			elders[Transactions] = opsElder
+		}
 	}
-	err = clear(0, end, "history_operations", "id")
-	if err != nil {
-		return err
+
+	return elders, nil
+}
+
+// policyElder returns the sequence of the oldest ledger retained by p, or 0 if
+// p doesn't reap anything.  The latest ledger is always retained.
+func (r *System) policyElder(p Policy, latest ledger.State) (int32, error) {
+	var elder int32
+	if p.Count > 0 {
+		elder = (latest.HistoryLatest - int32(p.Count)) + 1
 	}
-	err = clear(0, end, "history_transaction_participants", "history_transaction_id")
-	if err != nil {
-		return err
+
+	if p.Age > 0 {
+		var closed int32
+		q := &history.Q{Session: r.HorizonDB}
+		err := q.ElderLedgerClosedSince(&closed, time.Now().Add(-p.Age))
+		if err != nil {
+			return 0, errors.Wrap(err, "load elder ledger failed")
+		}
+
+		if closed == 0 {
+			closed = latest.HistoryLatest
+		}
+		if elder == 0 || closed < elder {
+			elder = closed
+		}
 	}
-	err = clear(0, end, "history_transactions", "id")
-	if err != nil {
-		return err
+
+	return elder, nil
+}
+
+func (r *System) clearBefore(seq int32, deleted map[string]int64) error {
+	log.WithField("new_elder", seq).Info("reaper: clearing")
+
+	for _, resource := range []string{Effects, Operations, Transactions} {
+		err := r.clearTablesBefore(resource, resourceTables[resource], seq, deleted)
+		if err != nil {
+			return err
+		}
 	}
-	err = clear(0, end, "history_ledgers", "id")
-	if err != nil {
-		return err
+
+	ledgers := []table{{"history_ledgers", "id"}}
+	return r.clearTablesBefore(Ledgers, ledgers, seq, deleted)
+}
+
+func (r *System) clearResourceBefore(resource string, seq int32, deleted map[string]int64) error {
+	log.
+		WithField("resource", resource).
+		WithField("new_elder", seq).
+		Info("reaper: clearing resource")
+
+	return r.clearTablesBefore(resource, resourceTables[resource], seq, deleted)
+}
+
+// clearTablesBefore deletes the rows of tables belonging to the ledgers before
+// seq, adding the number of rows deleted to deleted[resource].
+func (r *System) clearTablesBefore(
+	resource string,
+	tables []table,
+	seq int32,
+	deleted map[string]int64,
+) error {
+	end := toid.New(seq, 0, 0).ToInt64()
+
+	for _, t := range tables {
+		del := sq.Delete(t.name).Where(
+			t.column+" >= ? AND "+t.column+" < ?",
+			0,
+			end,
+		)
+
+		result, err := r.HorizonDB.Exec(del)
+		if err != nil {
+			return errors.Wrap(err, "delete from "+t.name+" failed")
+		}
+
+		n, err := result.RowsAffected()
+		if err != nil {
+			return errors.Wrap(err, "count rows deleted from "+t.name+" failed")
+		}
+		deleted[resource] += n
 	}
 
 	return nil
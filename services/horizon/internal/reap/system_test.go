@@ -46,3 +46,50 @@ func TestDeleteUnretainedHistory(t *testing.T) {
 		tt.Assert.Equal(1, cur)
 	}
 }
+
+func TestDeleteUnretainedHistory_Policies(t *testing.T) {
+	tt := test.Start(t).Scenario("kahuna")
+	defer tt.Finish()
+
+	db := tt.HorizonSession()
+
+	sys := New(0, db)
+	sys.Policies = []Policy{
+		{Resource: Effects, Count: 1},
+		{Resource: Transactions, Count: 1},
+	}
+
+	var (
+		ledgers      int
+		effects      int
+		transactions int
+	)
+	err := db.GetRaw(&ledgers, `SELECT COUNT(*) FROM history_ledgers`)
+	tt.Require.NoError(err)
+	err = db.GetRaw(&transactions, `SELECT COUNT(*) FROM history_transactions`)
+	tt.Require.NoError(err)
+
+	tt.UpdateLedgerState()
+	err = sys.DeleteUnretainedHistory()
+	if tt.Assert.NoError(err) {
+		var cur int
+		err = db.GetRaw(&cur, `SELECT COUNT(*) FROM history_ledgers`)
+		tt.Require.NoError(err)
+		tt.Assert.Equal(ledgers, cur, "Ledgers deleted by a resource policy")
+
+		// transactions are retained as long as operations
+		err = db.GetRaw(&cur, `SELECT COUNT(*) FROM history_transactions`)
+		tt.Require.NoError(err)
+		tt.Assert.Equal(transactions, cur, "Transactions deleted before operations")
+
+		err = db.GetRaw(&effects, `
+			SELECT COUNT(*) FROM history_effects
+			WHERE history_operation_id < (
+				SELECT id FROM history_ledgers ORDER BY sequence DESC LIMIT 1
+			)`)
+		tt.Require.NoError(err)
+		tt.Assert.Equal(0, effects)
+		tt.Assert.NotEqual(int64(0), sys.Metrics.DeletedRowsGauges[Effects].Value())
+		tt.Assert.Equal(int64(0), sys.Metrics.DeletedRowsGauges[Transactions].Value())
+	}
+}
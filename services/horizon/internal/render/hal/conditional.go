@@ -0,0 +1,118 @@
+package hal
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// ETag returns the strong entity tag of the document js.
+func ETag(js []byte) string {
+	sum := sha256.Sum256(js)
+	return `"` + hex.EncodeToString(sum[:16]) + `"`
+}
+
+// ConditionalWriter is a http.ResponseWriter buffering the document written to
+// it, which Finish writes to the underlying writer.  The responses of
+// immutable resources are tagged with a strong ETag and their Last-Modified
+// time, and are replaced by a 304 Not Modified response when the request is
+// conditioned on them with the If-None-Match or If-Modified-Since headers.
+type ConditionalWriter struct {
+	http.ResponseWriter
+	r            *http.Request
+	buf          bytes.Buffer
+	status       int
+	immutable    bool
+	lastModified time.Time
+}
+
+// NewConditionalWriter returns a ConditionalWriter writing to w the response
+// to r.
+func NewConditionalWriter(w http.ResponseWriter, r *http.Request) *ConditionalWriter {
+	return &ConditionalWriter{ResponseWriter: w, r: r}
+}
+
+// Immutable marks the document written to w, a ConditionalWriter or a
+// FieldsWriter wrapping one, as an immutable resource last modified at
+// lastModified.  It does nothing for other writers.
+func Immutable(w http.ResponseWriter, lastModified time.Time) {
+	switch w := w.(type) {
+	case *ConditionalWriter:
+		w.immutable = true
+		w.lastModified = lastModified
+	case *FieldsWriter:
+		Immutable(w.ResponseWriter, lastModified)
+	}
+}
+
+// WriteHeader records the status of the response until Finish is called.
+func (w *ConditionalWriter) WriteHeader(status int) {
+	w.status = status
+}
+
+// Write buffers b until Finish is called.
+func (w *ConditionalWriter) Write(b []byte) (int, error) {
+	return w.buf.Write(b)
+}
+
+// Finish writes the buffered response to the underlying writer, or a 304 Not
+// Modified response if the request is conditioned on the immutable resource
+// it represents.
+func (w *ConditionalWriter) Finish() error {
+	if !w.immutable || (w.status != 0 && w.status != http.StatusOK) {
+		if w.status != 0 {
+			w.ResponseWriter.WriteHeader(w.status)
+		}
+		_, err := w.ResponseWriter.Write(w.buf.Bytes())
+		return err
+	}
+
+	etag := ETag(w.buf.Bytes())
+	header := w.ResponseWriter.Header()
+	header.Set("ETag", etag)
+	if !w.lastModified.IsZero() {
+		header.Set("Last-Modified", w.lastModified.UTC().Format(http.TimeFormat))
+	}
+
+	if w.notModified(etag) {
+		header.Del("Content-Type")
+		header.Del("Content-Length")
+		w.ResponseWriter.WriteHeader(http.StatusNotModified)
+		return nil
+	}
+
+	_, err := w.ResponseWriter.Write(w.buf.Bytes())
+	return err
+}
+
+// notModified reports whether the request is conditioned on the version of the
+// resource tagged with etag.  If-Modified-Since is only considered without
+// If-None-Match, as required by RFC 7232.
+func (w *ConditionalWriter) notModified(etag string) bool {
+	if w.r.Method != http.MethodGet && w.r.Method != http.MethodHead {
+		return false
+	}
+
+	if inm := w.r.Header.Get("If-None-Match"); inm != "" {
+		for _, tag := range strings.Split(inm, ",") {
+			tag = strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+			if tag == "*" || tag == etag {
+				return true
+			}
+		}
+		return false
+	}
+
+	if w.lastModified.IsZero() {
+		return false
+	}
+
+	since, err := http.ParseTime(w.r.Header.Get("If-Modified-Since"))
+	if err != nil {
+		return false
+	}
+	return !w.lastModified.Truncate(time.Second).After(since)
+}
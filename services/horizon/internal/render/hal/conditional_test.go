@@ -0,0 +1,80 @@
+package hal
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestConditionalWriter(t *testing.T) {
+	lastModified := time.Date(2018, 2, 14, 0, 0, 0, 0, time.UTC)
+	doc := `{"hash": "abc"}`
+	etag := ETag([]byte(doc))
+
+	respond := func(r *http.Request, immutable bool) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		w := NewConditionalWriter(rec, r)
+		if immutable {
+			Immutable(NewFieldsWriter(w, nil), lastModified)
+		}
+		w.Write([]byte(doc))
+		So(w.Finish(), ShouldBeNil)
+		return rec
+	}
+
+	Convey("mutable resources are written as is", t, func() {
+		r := httptest.NewRequest("GET", "/transactions", nil)
+		r.Header.Set("If-None-Match", etag)
+		rec := respond(r, false)
+		So(rec.Code, ShouldEqual, http.StatusOK)
+		So(rec.Body.String(), ShouldEqual, doc)
+		So(rec.HeaderMap.Get("ETag"), ShouldBeEmpty)
+	})
+
+	Convey("immutable resources are tagged", t, func() {
+		rec := respond(httptest.NewRequest("GET", "/transactions/abc", nil), true)
+		So(rec.Code, ShouldEqual, http.StatusOK)
+		So(rec.Body.String(), ShouldEqual, doc)
+		So(rec.HeaderMap.Get("ETag"), ShouldEqual, etag)
+		So(rec.HeaderMap.Get("Last-Modified"), ShouldEqual, "Wed, 14 Feb 2018 00:00:00 GMT")
+	})
+
+	Convey("If-None-Match", t, func() {
+		r := httptest.NewRequest("GET", "/transactions/abc", nil)
+		r.Header.Set("If-None-Match", `"other", W/`+etag)
+		rec := respond(r, true)
+		So(rec.Code, ShouldEqual, http.StatusNotModified)
+		So(rec.Body.String(), ShouldBeEmpty)
+
+		// If-Modified-Since is ignored when If-None-Match doesn't match
+		r.Header.Set("If-None-Match", `"other"`)
+		r.Header.Set("If-Modified-Since", lastModified.Format(http.TimeFormat))
+		rec = respond(r, true)
+		So(rec.Code, ShouldEqual, http.StatusOK)
+	})
+
+	Convey("If-Modified-Since", t, func() {
+		r := httptest.NewRequest("GET", "/transactions/abc", nil)
+		r.Header.Set("If-Modified-Since", lastModified.Format(http.TimeFormat))
+		So(respond(r, true).Code, ShouldEqual, http.StatusNotModified)
+
+		r.Header.Set("If-Modified-Since", lastModified.Add(-time.Second).Format(http.TimeFormat))
+		So(respond(r, true).Code, ShouldEqual, http.StatusOK)
+	})
+
+	Convey("statuses other than 200 are written as is", t, func() {
+		r := httptest.NewRequest("POST", "/transactions", nil)
+		rec := httptest.NewRecorder()
+		w := NewConditionalWriter(rec, r)
+		Immutable(w, lastModified)
+		w.WriteHeader(http.StatusAccepted)
+		w.Write([]byte(doc))
+		So(w.Finish(), ShouldBeNil)
+		So(rec.Code, ShouldEqual, http.StatusAccepted)
+		So(rec.Body.String(), ShouldEqual, doc)
+		So(rec.HeaderMap.Get("ETag"), ShouldBeEmpty)
+	})
+}
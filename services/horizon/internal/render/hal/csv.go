@@ -0,0 +1,140 @@
+package hal
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+)
+
+// ToCSV returns the json document js as csv: one row per record for pages,
+// or a single row for other resources.  Nested attributes are flattened into
+// columns named by their path, e.g. `price_r.n`, and arrays are rendered as
+// json.  Links are left out, being of no use outside of a hal document.
+func ToCSV(js []byte) ([]byte, error) {
+	var doc map[string]interface{}
+	dec := json.NewDecoder(bytes.NewReader(js))
+	dec.UseNumber()
+	err := dec.Decode(&doc)
+	if err != nil {
+		return nil, err
+	}
+
+	records := []interface{}{doc}
+	if embedded, ok := doc["_embedded"].(map[string]interface{}); ok {
+		if r, ok := embedded["records"].([]interface{}); ok {
+			records = r
+		}
+	}
+
+	var rows []map[string]string
+	columns := map[string]bool{}
+	for _, record := range records {
+		obj, ok := record.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("record is not a json object: %v", record)
+		}
+
+		row := map[string]string{}
+		err = flatten(row, "", obj)
+		if err != nil {
+			return nil, err
+		}
+		for col := range row {
+			columns[col] = true
+		}
+		rows = append(rows, row)
+	}
+
+	header := make([]string, 0, len(columns))
+	for col := range columns {
+		header = append(header, col)
+	}
+	sort.Strings(header)
+
+	if len(header) == 0 {
+		// an empty page
+		return nil, nil
+	}
+
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	w.Write(header)
+	for _, row := range rows {
+		line := make([]string, len(header))
+		for i, col := range header {
+			line[i] = row[col]
+		}
+		w.Write(line)
+	}
+	w.Flush()
+
+	return buf.Bytes(), w.Error()
+}
+
+// flatten adds the attributes of obj to row, their columns prefixed with
+// `prefix`.
+func flatten(row map[string]string, prefix string, obj map[string]interface{}) error {
+	for k, v := range obj {
+		if k == "_links" || (prefix == "" && k == "_embedded") {
+			continue
+		}
+
+		col := prefix + k
+		switch v := v.(type) {
+		case nil:
+			row[col] = ""
+		case string:
+			row[col] = v
+		case json.Number:
+			row[col] = v.String()
+		case bool:
+			row[col] = fmt.Sprint(v)
+		case map[string]interface{}:
+			err := flatten(row, col+".", v)
+			if err != nil {
+				return err
+			}
+		default:
+			js, err := json.Marshal(v)
+			if err != nil {
+				return err
+			}
+			row[col] = string(js)
+		}
+	}
+	return nil
+}
+
+// CSVWriter is a http.ResponseWriter buffering the json document written to
+// it, which Finish writes to the underlying writer as csv.
+type CSVWriter struct {
+	http.ResponseWriter
+	buf bytes.Buffer
+}
+
+// NewCSVWriter returns a CSVWriter writing to w the documents written to it
+// as csv.
+func NewCSVWriter(w http.ResponseWriter) *CSVWriter {
+	return &CSVWriter{ResponseWriter: w}
+}
+
+// Write buffers b until Finish is called.
+func (w *CSVWriter) Write(b []byte) (int, error) {
+	return w.buf.Write(b)
+}
+
+// Finish writes the buffered document to the underlying writer as csv.
+func (w *CSVWriter) Finish() error {
+	out, err := ToCSV(w.buf.Bytes())
+	if err != nil {
+		return err
+	}
+
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Del("Content-Disposition")
+	_, err = w.ResponseWriter.Write(out)
+	return err
+}
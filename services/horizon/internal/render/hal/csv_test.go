@@ -0,0 +1,45 @@
+package hal
+
+import (
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestCSV(t *testing.T) {
+	Convey("ToCSV renders the records of pages", t, func() {
+		js := `{
+			"_links": {"self": {"href": "/offers"}},
+			"_embedded": {"records": [
+				{"_links": {"self": {"href": "/offers/1"}}, "id": 1, "amount": "10.0000000", "price_r": {"n": 1, "d": 2}, "passive": false},
+				{"_links": {"self": {"href": "/offers/2"}}, "id": 2, "amount": "5.0000000", "price_r": {"n": 3, "d": 4}, "memo": null, "signatures": ["a", "b"]}
+			]}
+		}`
+
+		out, err := ToCSV([]byte(js))
+		So(err, ShouldBeNil)
+		So(string(out), ShouldEqual, ""+
+			"amount,id,memo,passive,price_r.d,price_r.n,signatures\n"+
+			"10.0000000,1,,false,2,1,\n"+
+			"5.0000000,2,,,4,3,\"[\"\"a\"\",\"\"b\"\"]\"\n")
+	})
+
+	Convey("ToCSV renders single resources as one row", t, func() {
+		js := `{"_links": {"self": {"href": "/ledgers/2"}}, "sequence": 2, "hash": "abc"}`
+
+		out, err := ToCSV([]byte(js))
+		So(err, ShouldBeNil)
+		So(string(out), ShouldEqual, "hash,sequence\nabc,2\n")
+	})
+
+	Convey("ToCSV renders empty pages as nothing", t, func() {
+		out, err := ToCSV([]byte(`{"_embedded": {"records": []}}`))
+		So(err, ShouldBeNil)
+		So(out, ShouldBeEmpty)
+	})
+
+	Convey("ToCSV fails on documents that are not json objects", t, func() {
+		_, err := ToCSV([]byte(`["a"]`))
+		So(err, ShouldNotBeNil)
+	})
+}
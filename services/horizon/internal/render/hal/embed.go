@@ -0,0 +1,48 @@
+package hal
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+)
+
+// Embedded is a single resource rendered with related records embedded in
+// its `_embedded` attribute, under Name, such as a transaction with its
+// operations.
+type Embedded struct {
+	Resource interface{}
+	Name     string
+	Records  []Pageable
+}
+
+// MarshalJSON renders the resource, followed by its embedded records.
+func (e Embedded) MarshalJSON() ([]byte, error) {
+	js, err := json.Marshal(e.Resource)
+	if err != nil {
+		return nil, err
+	}
+
+	js = bytes.TrimSpace(js)
+	if len(js) < 2 || js[0] != '{' || js[len(js)-1] != '}' {
+		return nil, errors.New("embedded resource is not a json object")
+	}
+
+	records := e.Records
+	if records == nil {
+		records = []Pageable{}
+	}
+	embedded, err := json.Marshal(map[string][]Pageable{e.Name: records})
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	buf.Write(js[:len(js)-1])
+	if len(js) > 2 {
+		buf.WriteByte(',')
+	}
+	buf.WriteString(`"_embedded":`)
+	buf.Write(embedded)
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,41 @@
+package hal
+
+import (
+	"encoding/json"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+type embedTestRecord struct {
+	ID string `json:"id"`
+}
+
+func (r embedTestRecord) PagingToken() string {
+	return r.ID
+}
+
+func TestEmbedded(t *testing.T) {
+	Convey("Embedded renders records after the resource", t, func() {
+		e := Embedded{
+			Resource: map[string]string{"hash": "abc"},
+			Name:     "operations",
+			Records:  []Pageable{embedTestRecord{"1"}, embedTestRecord{"2"}},
+		}
+
+		js, err := json.Marshal(e)
+		So(err, ShouldBeNil)
+		So(string(js), ShouldEqual, `{"hash":"abc","_embedded":{"operations":[{"id":"1"},{"id":"2"}]}}`)
+	})
+
+	Convey("Embedded renders no records as an empty array", t, func() {
+		js, err := json.Marshal(Embedded{Resource: struct{}{}, Name: "effects"})
+		So(err, ShouldBeNil)
+		So(string(js), ShouldEqual, `{"_embedded":{"effects":[]}}`)
+	})
+
+	Convey("Embedded fails on resources that are not objects", t, func() {
+		_, err := json.Marshal(Embedded{Resource: []string{"abc"}, Name: "effects"})
+		So(err, ShouldNotBeNil)
+	})
+}
@@ -0,0 +1,122 @@
+package hal
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+// FieldsParam is the name of the query string param listing the fields of the
+// resources a client requests, e.g. `?fields=hash,ledger,_links`.
+const FieldsParam = "fields"
+
+// RequestedFields returns the fields of the resources requested by r, or nil
+// if r requests all of them.
+func RequestedFields(r *http.Request) []string {
+	var fields []string
+	for _, f := range strings.Split(r.URL.Query().Get(FieldsParam), ",") {
+		f = strings.TrimSpace(f)
+		if f != "" {
+			fields = append(fields, f)
+		}
+	}
+	return fields
+}
+
+// FilterFields returns the json document js, keeping only the top level
+// `fields` of the resource it represents.  The fields of the records of a
+// page are filtered instead of the fields of the page itself, so that the
+// links of the page are kept.
+func FilterFields(js []byte, fields []string) ([]byte, error) {
+	var doc map[string]json.RawMessage
+	err := json.Unmarshal(js, &doc)
+	if err != nil {
+		return nil, err
+	}
+
+	var embedded map[string]json.RawMessage
+	if raw, ok := doc["_embedded"]; ok {
+		err = json.Unmarshal(raw, &embedded)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	raw, ok := embedded["records"]
+	if !ok {
+		return json.Marshal(filter(doc, fields))
+	}
+
+	var records []map[string]json.RawMessage
+	err = json.Unmarshal(raw, &records)
+	if err != nil {
+		return nil, err
+	}
+
+	for i, record := range records {
+		records[i] = filter(record, fields)
+	}
+
+	embedded["records"], err = json.Marshal(records)
+	if err != nil {
+		return nil, err
+	}
+
+	doc["_embedded"], err = json.Marshal(embedded)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(doc)
+}
+
+func filter(doc map[string]json.RawMessage, fields []string) map[string]json.RawMessage {
+	result := make(map[string]json.RawMessage, len(fields))
+	for _, f := range fields {
+		if v, ok := doc[f]; ok {
+			result[f] = v
+		}
+	}
+	return result
+}
+
+// FieldsWriter is a http.ResponseWriter buffering the json document written
+// to it, which Finish writes to the underlying writer keeping only the
+// requested fields.
+type FieldsWriter struct {
+	http.ResponseWriter
+	fields []string
+	buf    bytes.Buffer
+}
+
+// NewFieldsWriter returns a FieldsWriter writing to w the fields of the
+// documents written to it.
+func NewFieldsWriter(w http.ResponseWriter, fields []string) *FieldsWriter {
+	return &FieldsWriter{ResponseWriter: w, fields: fields}
+}
+
+// Write buffers b until Finish is called.
+func (w *FieldsWriter) Write(b []byte) (int, error) {
+	return w.buf.Write(b)
+}
+
+// Finish writes the buffered document to the underlying writer, keeping only
+// the requested fields.  Documents that are not json objects are written as
+// is.
+func (w *FieldsWriter) Finish() error {
+	filtered, err := FilterFields(w.buf.Bytes(), w.fields)
+	if err != nil {
+		_, err = w.ResponseWriter.Write(w.buf.Bytes())
+		return err
+	}
+
+	var out bytes.Buffer
+	err = json.Indent(&out, filtered, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	_, err = w.ResponseWriter.Write(out.Bytes())
+	return err
+}
@@ -0,0 +1,62 @@
+package hal
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestFields(t *testing.T) {
+	Convey("RequestedFields", t, func() {
+		r := httptest.NewRequest("GET", "/transactions", nil)
+		So(RequestedFields(r), ShouldBeNil)
+
+		r = httptest.NewRequest("GET", "/transactions?fields=hash,+ledger,,", nil)
+		So(RequestedFields(r), ShouldResemble, []string{"hash", "ledger"})
+	})
+
+	Convey("FilterFields filters resources", t, func() {
+		js := `{"_links": {"self": {"href": "/transactions/abc"}}, "hash": "abc", "ledger": 2, "envelope_xdr": "AAAA"}`
+
+		filtered, err := FilterFields([]byte(js), []string{"hash", "ledger", "unknown"})
+		So(err, ShouldBeNil)
+		So(string(filtered), ShouldEqual, `{"hash":"abc","ledger":2}`)
+	})
+
+	Convey("FilterFields filters the records of pages", t, func() {
+		js := `{
+			"_links": {"self": {"href": "/transactions"}},
+			"_embedded": {"records": [
+				{"hash": "abc", "envelope_xdr": "AAAA"},
+				{"hash": "def", "envelope_xdr": "BBBB"}
+			]}
+		}`
+
+		filtered, err := FilterFields([]byte(js), []string{"hash"})
+		So(err, ShouldBeNil)
+		So(string(filtered), ShouldEqual,
+			`{"_embedded":{"records":[{"hash":"abc"},{"hash":"def"}]},"_links":{"self":{"href":"/transactions"}}}`)
+	})
+
+	Convey("FieldsWriter writes the requested fields", t, func() {
+		w := httptest.NewRecorder()
+		fw := NewFieldsWriter(w, []string{"hash"})
+		fw.Header().Set("Content-Type", "application/hal+json")
+		fw.Write([]byte(`{"hash": "abc", "ledger": 2}`))
+		So(w.Body.Len(), ShouldEqual, 0)
+
+		So(fw.Finish(), ShouldBeNil)
+		So(w.Body.String(), ShouldEqual, "{\n  \"hash\": \"abc\"\n}")
+		So(w.Header().Get("Content-Type"), ShouldEqual, "application/hal+json")
+	})
+
+	Convey("FieldsWriter writes other documents as is", t, func() {
+		w := httptest.NewRecorder()
+		fw := NewFieldsWriter(w, []string{"hash"})
+		fw.Write([]byte(`["abc"]`))
+
+		So(fw.Finish(), ShouldBeNil)
+		So(w.Body.String(), ShouldEqual, `["abc"]`)
+	})
+}
@@ -59,6 +59,9 @@ func (lb *LinkBuilder) expandLink(href string) string {
 		if u.Scheme == "" {
 			u.Scheme = lb.Base.Scheme
 		}
+
+		// links are relative to the path prefix of the base url, if any
+		u.Path = strings.TrimSuffix(lb.Base.Path, "/") + u.Path
 	}
 
 	//HACK: replace the encoded path with the un-encoded path, which preserves
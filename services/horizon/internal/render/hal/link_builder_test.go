@@ -29,6 +29,11 @@ func TestLinkBuilder(t *testing.T) {
 
 		// Regression: ensure that parameters are not escaped
 		check("/accounts/{id}", "https://stellar.org", "https://stellar.org/accounts/{id}")
+
+		// links are relative to the path prefix of the base url
+		check("/root", "https://stellar.org/testnet", "https://stellar.org/testnet/root")
+		check("/accounts/{id}", "https://stellar.org/testnet/", "https://stellar.org/testnet/accounts/{id}")
+		check("https://else.org/root", "https://stellar.org/testnet", "https://else.org/root")
 	})
 
 }
@@ -1,6 +1,7 @@
 package render
 
 import (
+	"bytes"
 	"net/http"
 	"testing"
 
@@ -36,10 +37,36 @@ func TestRenderPackage(t *testing.T) {
 			So(Negotiate(ctx, r), ShouldEqual, MimeHal)
 		})
 
+		Convey("Negotiates plain text for prometheus", func() {
+			r.Header.Set("Accept", "text/plain;version=0.0.4;q=1,*/*;q=0.1")
+			So(Negotiate(ctx, r), ShouldEqual, MimeText)
+		})
+
+		Convey("Negotiates csv and xdr", func() {
+			r.Header.Set("Accept", "text/csv")
+			So(Negotiate(ctx, r), ShouldEqual, MimeCSV)
+
+			r.Header.Set("Accept", "application/xdr")
+			So(Negotiate(ctx, r), ShouldEqual, MimeXDR)
+		})
+
 		Convey("Returns empty string for invalid type", func() {
-			r.Header.Set("Accept", "text/plain")
+			r.Header.Set("Accept", "image/png")
 			So(Negotiate(ctx, r), ShouldEqual, "")
 		})
 
 	})
+
+	Convey("render.WriteXDRStream", t, func() {
+		var buf bytes.Buffer
+		err := WriteXDRStream(&buf, "AAAAAQ==", "AAAAAgAAAAM=")
+		So(err, ShouldBeNil)
+		So(buf.Bytes(), ShouldResemble, []byte{
+			0x80, 0, 0, 4, 0, 0, 0, 1,
+			0x80, 0, 0, 8, 0, 0, 0, 2, 0, 0, 0, 3,
+		})
+
+		err = WriteXDRStream(&buf, "not base64!")
+		So(err, ShouldNotBeNil)
+	})
 }
@@ -12,4 +12,10 @@ const (
 	MimeProblem = "application/problem+json"
 	//MimeRaw is the mime type for "application/octet-stream"
 	MimeRaw = "application/octet-stream"
+	//MimeText is the mime type for "text/plain"
+	MimeText = "text/plain"
+	//MimeCSV is the mime type for "text/csv"
+	MimeCSV = "text/csv"
+	//MimeXDR is the mime type for "application/xdr"
+	MimeXDR = "application/xdr"
 )
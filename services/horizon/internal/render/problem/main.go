@@ -23,12 +23,23 @@ var (
 		Type:   "rate_limit_exceeded",
 		Title:  "Rate limit exceeded",
 		Status: 429,
-		Detail: "The rate limit for the requesting IP address is over its alloted " +
+		Detail: "The rate limit for the requesting IP address or API key is over its alloted " +
 			"limit.  The allowed limit and requests left per time period are " +
 			"communicated to clients via the http response headers 'X-RateLimit-*' " +
 			"headers.",
 	}
 
+	// InvalidAPIKey is a well-known problem type.  Use it as a shortcut
+	// in your actions.
+	InvalidAPIKey = problem.P{
+		Type:   "invalid_api_key",
+		Title:  "Invalid API Key",
+		Status: http.StatusUnauthorized,
+		Detail: "The API key sent in the 'X-API-Key' header or the 'api_key' " +
+			"query parameter was not issued by this horizon server, or has been " +
+			"revoked.  Omit it to make requests limited by IP address instead.",
+	}
+
 	// NotImplemented is a well-known problem type.  Use it as a shortcut
 	// in your actions.
 	NotImplemented = problem.P{
@@ -59,6 +70,16 @@ var (
 			"several minutes before trying your request again.",
 	}
 
+	// Draining is a well-known problem type, rendered to the streams opened
+	// while the server drains its streams before shutting down.
+	Draining = problem.P{
+		Type:   "draining",
+		Title:  "Server Draining",
+		Status: http.StatusServiceUnavailable,
+		Detail: "This horizon server is shutting down and no longer accepts " +
+			"streams.  Please reconnect to another server.",
+	}
+
 	// Timeout is a well-known problem type.  Use it as a shortcut
 	// in your actions.
 	Timeout = problem.P{
@@ -91,6 +112,29 @@ var (
 			"this horizon instance.",
 	}
 
+	// Timeout is a well-known problem type, rendered to the requests that
+	// exceed the timeout of their route.
+	Timeout = problem.P{
+		Type:   "timeout",
+		Title:  "Timeout",
+		Status: http.StatusServiceUnavailable,
+		Detail: "Your request timed out before completing.  Please try your " +
+			"request again, narrowing it with a smaller limit or more precise " +
+			"filters if it keeps timing out.",
+	}
+
+	// UnsupportedPagination is a well-known problem type, rendered to the
+	// requests paging by offset instead of by cursor.
+	UnsupportedPagination = problem.P{
+		Type:   "unsupported_pagination",
+		Title:  "Unsupported Pagination",
+		Status: http.StatusBadRequest,
+		Detail: "This horizon server pages collections by cursor only.  Use the " +
+			"'cursor' parameter, set to the paging token of the last record " +
+			"received or from the 'next' link of the previous page, instead of " +
+			"'offset', 'page' or 'skip'.",
+	}
+
 	// StaleHistory is a well-known problem type.  Use it as a shortcut
 	// in your actions.
 	StaleHistory = problem.P{
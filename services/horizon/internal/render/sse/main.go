@@ -64,7 +64,6 @@ func WritePreamble(ctx context.Context, w http.ResponseWriter) bool {
 	w.Header().Set("Content-Type", "text/event-stream; charset=utf-8")
 	w.Header().Set("Cache-Control", "no-cache")
 	w.Header().Set("Connection", "keep-alive")
-	w.Header().Set("Access-Control-Allow-Origin", "*")
 	w.WriteHeader(200)
 
 	WriteEvent(ctx, w, helloEvent)
@@ -111,6 +110,15 @@ var goodbyeEvent = Event{
 	Retry: 10,
 }
 
+// When the streams are drained before the server shuts down, we send this
+// event to tell the client that it should reconnect, possibly to another
+// server, after 1 second.
+var restartingEvent = Event{
+	Data:  "server restarting",
+	Event: "restarting",
+	Retry: 1000,
+}
+
 // Upon initial stream creation, we send this event to inform the client
 // that they may retry an errored connection after 1 second.
 var helloEvent = Event{
@@ -1,11 +1,54 @@
 package sse
 
 import (
+	"encoding/json"
 	"net/http"
+	"sync/atomic"
+	"time"
 
+	"github.com/stellar/go/services/horizon/internal/render/hal"
+	"github.com/stellar/go/support/log"
 	"golang.org/x/net/context"
 )
 
+// DefaultBufferSize is the number of events a stream buffers for a client
+// that has not received them yet.
+const DefaultBufferSize = 100
+
+// DefaultEvictionTimeout is how long a stream waits for a client whose buffer
+// is full to catch up, before closing the stream.  It prevents slow clients
+// from holding the database queries of a stream.
+const DefaultEvictionTimeout = 10 * time.Second
+
+// openStreams is the number of streams that are not closed yet.
+var openStreams int64
+
+// OpenStreamCount returns the number of streams that are open, i.e. the
+// number of clients currently streaming.
+func OpenStreamCount() int64 {
+	return atomic.LoadInt64(&openStreams)
+}
+
+// draining is 1 once Drain is called, until Resume is called.
+var draining int32
+
+// Drain causes the open streams to end at their next tick, and new streams to
+// be rejected, so that their clients reconnect to other servers before this
+// one shuts down.
+func Drain() {
+	atomic.StoreInt32(&draining, 1)
+}
+
+// Resume accepts streams again after Drain.
+func Resume() {
+	atomic.StoreInt32(&draining, 0)
+}
+
+// Draining reports whether the streams are draining.
+func Draining() bool {
+	return atomic.LoadInt32(&draining) == 1
+}
+
 // Stream represents an output stream that data can be written to
 type Stream interface {
 	Send(Event)
@@ -14,14 +57,44 @@ type Stream interface {
 	SetLimit(limit int)
 	IsDone() bool
 	Err(error)
+	Restarting()
+	Close()
 }
 
 // NewStream creates a new stream against the provided response writer
 func NewStream(ctx context.Context, w http.ResponseWriter, r *http.Request) Stream {
-	result := &stream{ctx, w, r, false, 0, 0}
-	return result
+	return newStream(ctx, w, r, DefaultBufferSize, DefaultEvictionTimeout)
 }
 
+func newStream(
+	ctx context.Context,
+	w http.ResponseWriter,
+	r *http.Request,
+	bufferSize int,
+	evictionTimeout time.Duration,
+) *stream {
+	atomic.AddInt64(&openStreams, 1)
+
+	var fields []string
+	if r != nil {
+		fields = hal.RequestedFields(r)
+	}
+
+	return &stream{
+		ctx:             ctx,
+		w:               w,
+		r:               r,
+		fields:          fields,
+		evictionTimeout: evictionTimeout,
+		events:          make(chan Event, bufferSize),
+		evicted:         make(chan struct{}),
+		written:         make(chan struct{}),
+	}
+}
+
+// stream writes its events to the client from a separate goroutine, so that
+// a slow client does not block the action producing them until its buffer is
+// full.
 type stream struct {
 	ctx   context.Context
 	w     http.ResponseWriter
@@ -29,19 +102,56 @@ type stream struct {
 	done  bool
 	sent  int
 	limit int
+
+	// fields are the fields of the resources sent requested by the client,
+	// or nil if it requested all of them.
+	fields []string
+
+	evictionTimeout time.Duration
+	started         bool
+	closed          bool
+	events          chan Event
+	evicted         chan struct{}
+	written         chan struct{}
 }
 
 func (s *stream) Send(e Event) {
+	if s.done {
+		return
+	}
+
 	if s.sent == 0 {
 		ok := WritePreamble(s.ctx, s.w)
 		if !ok {
 			s.done = true
 			return
 		}
+		s.start()
+	}
+
+	if len(s.fields) > 0 {
+		e.Data = s.filter(e.Data)
+	}
+
+	if s.enqueue(e) {
+		s.sent++
+	}
+}
+
+// filter returns the json of data, keeping only the fields requested by the
+// client.
+func (s *stream) filter(data interface{}) interface{} {
+	js, err := json.Marshal(data)
+	if err != nil {
+		return data
 	}
 
-	WriteEvent(s.ctx, s.w, e)
-	s.sent++
+	filtered, err := hal.FilterFields(js, s.fields)
+	if err != nil {
+		return data
+	}
+
+	return json.RawMessage(filtered)
 }
 
 func (s *stream) SentCount() int {
@@ -53,7 +163,7 @@ func (s *stream) SetLimit(limit int) {
 }
 
 func (s *stream) Done() {
-	WriteEvent(s.ctx, s.w, goodbyeEvent)
+	s.write(goodbyeEvent)
 	s.done = true
 }
 
@@ -65,7 +175,98 @@ func (s *stream) IsDone() bool {
 	return s.done || s.sent >= s.limit
 }
 
+// Restarting ends the stream with an event telling the client that the
+// server is restarting, sending the preamble first if no event was sent yet.
+func (s *stream) Restarting() {
+	if s.done {
+		return
+	}
+
+	if !s.started && !WritePreamble(s.ctx, s.w) {
+		s.done = true
+		return
+	}
+
+	s.write(restartingEvent)
+	s.done = true
+}
+
 func (s *stream) Err(err error) {
-	WriteEvent(s.ctx, s.w, Event{Error: err})
+	s.write(Event{Error: err})
 	s.done = true
 }
+
+// Close waits for the buffered events to be written to the client.  The
+// response writer must not be used once the handler of the request returns,
+// so Close must be called before.
+func (s *stream) Close() {
+	if s.closed {
+		return
+	}
+	s.closed = true
+	atomic.AddInt64(&openStreams, -1)
+
+	if !s.started {
+		return
+	}
+
+	close(s.events)
+	<-s.written
+}
+
+// start starts writing the buffered events to the client.
+func (s *stream) start() {
+	s.started = true
+	go func() {
+		defer close(s.written)
+		for e := range s.events {
+			select {
+			case <-s.evicted:
+				return
+			default:
+			}
+			WriteEvent(s.ctx, s.w, e)
+		}
+	}()
+}
+
+// write sends e to the client, through the buffer once the stream has
+// started.
+func (s *stream) write(e Event) {
+	if s.done {
+		return
+	}
+
+	if !s.started {
+		WriteEvent(s.ctx, s.w, e)
+		return
+	}
+	s.enqueue(e)
+}
+
+// enqueue buffers e, evicting the client if it doesn't catch up within the
+// eviction timeout when the buffer is full.  It returns false if e is
+// dropped.
+func (s *stream) enqueue(e Event) bool {
+	select {
+	case s.events <- e:
+		return true
+	default:
+	}
+
+	timer := time.NewTimer(s.evictionTimeout)
+	defer timer.Stop()
+
+	select {
+	case s.events <- e:
+		return true
+	case <-s.ctx.Done():
+		s.done = true
+		return false
+	case <-timer.C:
+		log.Ctx(s.ctx).Warn("evicting slow stream client")
+		close(s.evicted)
+		s.done = true
+		return false
+	}
+}
@@ -0,0 +1,118 @@
+package sse
+
+import (
+	"errors"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"github.com/stellar/go/support/test"
+)
+
+// slowRecorder is a response recorder whose flushes, except the one of the
+// preamble, block until unblock is closed.
+type slowRecorder struct {
+	*httptest.ResponseRecorder
+	flushes int
+	unblock chan struct{}
+}
+
+func (w *slowRecorder) Flush() {
+	w.flushes++
+	if w.flushes > 1 {
+		<-w.unblock
+	}
+	w.ResponseRecorder.Flush()
+}
+
+func TestStream(t *testing.T) {
+	ctx, _ := test.ContextWithLogBuffer()
+
+	Convey("stream writes the events in order", t, func() {
+		w := httptest.NewRecorder()
+		s := NewStream(ctx, w, nil)
+		s.Send(Event{ID: "1", Data: "a"})
+		s.Send(Event{ID: "2", Data: "b"})
+		s.Err(errors.New("busted"))
+		s.Close()
+
+		body := w.Body.String()
+		So(s.SentCount(), ShouldEqual, 2)
+		So(s.IsDone(), ShouldBeTrue)
+		So(body, ShouldStartWith, "retry: 1000\nevent: open\n")
+		So(strings.Index(body, "id: 1\n"), ShouldBeLessThan, strings.Index(body, "id: 2\n"))
+		So(strings.Index(body, "id: 2\n"), ShouldBeLessThan, strings.Index(body, "event: err\n"))
+	})
+
+	Convey("stream evicts slow clients", t, func() {
+		w := &slowRecorder{
+			ResponseRecorder: httptest.NewRecorder(),
+			unblock:          make(chan struct{}),
+		}
+		s := newStream(ctx, w, nil, 1, 10*time.Millisecond)
+		for i := 0; i < 3; i++ {
+			s.Send(Event{Data: i})
+		}
+
+		So(s.IsDone(), ShouldBeTrue)
+		So(s.SentCount(), ShouldBeLessThan, 3)
+
+		close(w.unblock)
+		s.Close()
+	})
+
+	Convey("stream sends the fields requested by the client", t, func() {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest("GET", "/ledgers?fields=id,hash", nil)
+		s := NewStream(ctx, w, r)
+		s.Send(Event{ID: "1", Data: map[string]interface{}{
+			"id":       "1",
+			"hash":     "abc",
+			"sequence": 1,
+		}})
+		s.Close()
+
+		So(w.Body.String(), ShouldContainSubstring, `data: {"hash":"abc","id":"1"}`+"\n")
+	})
+
+	Convey("restarting streams end with a restarting event", t, func() {
+		w := httptest.NewRecorder()
+		s := NewStream(ctx, w, nil)
+		s.Send(Event{ID: "1", Data: "a"})
+		s.Restarting()
+		s.Close()
+
+		body := w.Body.String()
+		So(s.IsDone(), ShouldBeTrue)
+		So(body, ShouldEndWith, "retry: 1000\nevent: restarting\ndata: \"server restarting\"\n\n")
+
+		// before any event
+		w = httptest.NewRecorder()
+		s = NewStream(ctx, w, nil)
+		s.Restarting()
+		s.Close()
+
+		body = w.Body.String()
+		So(body, ShouldStartWith, "retry: 1000\nevent: open\n")
+		So(body, ShouldContainSubstring, "event: restarting\n")
+	})
+
+	Convey("closing an unused stream writes nothing", t, func() {
+		w := httptest.NewRecorder()
+		s := NewStream(ctx, w, nil)
+		s.Close()
+		So(w.Body.Len(), ShouldEqual, 0)
+	})
+
+	Convey("open streams are counted until closed", t, func() {
+		before := OpenStreamCount()
+		s := NewStream(ctx, httptest.NewRecorder(), nil)
+		So(OpenStreamCount(), ShouldEqual, before+1)
+
+		s.Close()
+		s.Close()
+		So(OpenStreamCount(), ShouldEqual, before)
+	})
+}
@@ -0,0 +1,40 @@
+package render
+
+import (
+	"encoding/base64"
+	"encoding/binary"
+	"io"
+
+	"github.com/stellar/go/support/errors"
+)
+
+// lastFragment is the bit set in the record mark of the last fragment of a
+// record, see RFC 5531 section 11.
+const lastFragment = 0x80000000
+
+// WriteXDRStream writes `objects`, xdr objects encoded in base64, to w as the
+// records of an xdr stream: each object is preceded by its length, as a big
+// endian uint32 marking it as the last fragment of its record.  This is the
+// format of the xdr files stellar-core writes to history archives.
+func WriteXDRStream(w io.Writer, objects ...string) error {
+	for _, obj := range objects {
+		raw, err := base64.StdEncoding.DecodeString(obj)
+		if err != nil {
+			return errors.Wrap(err, "decode xdr failed")
+		}
+
+		var mark [4]byte
+		binary.BigEndian.PutUint32(mark[:], uint32(len(raw))|lastFragment)
+
+		_, err = w.Write(mark[:])
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(raw)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
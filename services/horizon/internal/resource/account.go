@@ -21,6 +21,7 @@ func (this *Account) Populate(
 	ha history.Account,
 ) (err error) {
 	this.ID = ca.Accountid
+	this.PT = ca.Accountid
 	this.AccountID = ca.Accountid
 	this.Sequence = ca.Seqnum
 	this.SubentryCount = ca.Numsubentries
@@ -73,6 +74,11 @@ func (this *Account) Populate(
 	return
 }
 
+// PagingToken implementation for hal.Pageable
+func (this Account) PagingToken() string {
+	return this.PT
+}
+
 // MustGetData returns decoded value for a given key. If the key does
 // not exist, empty slice will be returned. If there is an error
 // decoding a value, it will panic.
@@ -0,0 +1,26 @@
+package resource
+
+import (
+	"net/url"
+
+	"github.com/stellar/go/services/horizon/internal/db2/core"
+	"github.com/stellar/go/services/horizon/internal/httpx"
+	"github.com/stellar/go/services/horizon/internal/render/hal"
+	"golang.org/x/net/context"
+)
+
+// Populate fills out the resource's fields
+func (this *AccountData) Populate(ctx context.Context, row core.AccountData) {
+	this.PT = row.PagingToken()
+	this.Key = row.Key
+	this.Value = row.Value
+
+	lb := hal.LinkBuilder{httpx.BaseURL(ctx)}
+	this.Links.Self = lb.Linkf("/accounts/%s/data/%s", row.Accountid, url.QueryEscape(row.Key))
+	this.Links.Account = lb.Linkf("/accounts/%s", row.Accountid)
+}
+
+// PagingToken implementation for hal.Pageable
+func (this AccountData) PagingToken() string {
+	return this.PT
+}
@@ -1,6 +1,7 @@
 package effects
 
 import (
+	"github.com/stellar/go/services/horizon/internal/context/apiversion"
 	"github.com/stellar/go/services/horizon/internal/db2/history"
 	"github.com/stellar/go/services/horizon/internal/render/hal"
 	"github.com/stellar/go/services/horizon/internal/resource/base"
@@ -33,6 +34,11 @@ var TypeNames = map[history.EffectType]string{
 	history.EffectDataUpdated:                        "data_updated",
 }
 
+// typeVersions maps the effect types introduced after the first version of
+// the API to the version introducing them.  Clients of earlier versions are
+// served these effects as unknown ones.  See package apiversion.
+var typeVersions = map[history.EffectType]int{}
+
 // New creates a new effect resource from the provided database representation
 // of the effect.
 func New(
@@ -43,6 +49,11 @@ func New(
 	basev := Base{}
 	basev.Populate(ctx, row)
 
+	if v, ok := typeVersions[row.Type]; ok && v > apiversion.FromContext(ctx) {
+		basev.Type = "unknown"
+		return newUnknown(basev, row)
+	}
+
 	switch row.Type {
 	case history.EffectAccountCreated:
 		e := AccountCreated{Base: basev}
@@ -136,6 +147,21 @@ type Base struct {
 	TypeI   int32  `json:"type_i"`
 }
 
+// Unknown is the resource of an effect whose type is unknown to the client,
+// because it was introduced after the version of the API requested.  Its
+// details are rendered as they were recorded.
+type Unknown struct {
+	Base
+	Details map[string]interface{} `json:"details,omitempty"`
+}
+
+// newUnknown creates the generic resource of row.
+func newUnknown(basev Base, row history.Effect) (hal.Pageable, error) {
+	e := Unknown{Base: basev}
+	err := row.UnmarshalDetails(&e.Details)
+	return e, err
+}
+
 type AccountCreated struct {
 	Base
 	StartingBalance string `json:"starting_balance"`
@@ -0,0 +1,34 @@
+package resource
+
+import (
+	"context"
+	"math"
+
+	"github.com/stellar/go/services/horizon/internal/db2/history"
+)
+
+// Populate fills out the fee statistics from the aggregated fees of recent
+// ledgers and the latest of them.
+func (res *FeeStats) Populate(
+	ctx context.Context,
+	row history.FeeStats,
+	latest history.Ledger,
+) {
+	res.LastLedger = latest.Sequence
+	res.LastLedgerBaseFee = latest.BaseFee
+	res.LedgerCapacityUsage = math.Floor(row.CapacityUsage*100+0.5) / 100
+
+	res.MinAcceptedFee = row.Min
+	res.ModeAcceptedFee = row.Mode
+	res.P10AcceptedFee = row.P10
+	res.P20AcceptedFee = row.P20
+	res.P30AcceptedFee = row.P30
+	res.P40AcceptedFee = row.P40
+	res.P50AcceptedFee = row.P50
+	res.P60AcceptedFee = row.P60
+	res.P70AcceptedFee = row.P70
+	res.P80AcceptedFee = row.P80
+	res.P90AcceptedFee = row.P90
+	res.P95AcceptedFee = row.P95
+	res.P99AcceptedFee = row.P99
+}
@@ -50,6 +50,19 @@ type Account struct {
 	Data                 map[string]string `json:"data"`
 }
 
+// AccountData represents a single data entry of an account.  Entries removed
+// from the account are streamed with an empty value.
+type AccountData struct {
+	Links struct {
+		Self    hal.Link `json:"self"`
+		Account hal.Link `json:"account"`
+	} `json:"_links"`
+
+	PT    string `json:"paging_token"`
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
 // AccountFlags represents the state of an account's flags
 type AccountFlags struct {
 	AuthRequired  bool `json:"auth_required"`
@@ -87,6 +100,28 @@ type Balance struct {
 	base.Asset
 }
 
+// FeeStats represents the statistics of the fees per operation offered by
+// the transactions of recent ledgers, in stroops.
+type FeeStats struct {
+	LastLedger          int32   `json:"last_ledger,string"`
+	LastLedgerBaseFee   int32   `json:"last_ledger_base_fee,string"`
+	LedgerCapacityUsage float64 `json:"ledger_capacity_usage,string"`
+
+	MinAcceptedFee  int32 `json:"min_accepted_fee,string"`
+	ModeAcceptedFee int32 `json:"mode_accepted_fee,string"`
+	P10AcceptedFee  int32 `json:"p10_accepted_fee,string"`
+	P20AcceptedFee  int32 `json:"p20_accepted_fee,string"`
+	P30AcceptedFee  int32 `json:"p30_accepted_fee,string"`
+	P40AcceptedFee  int32 `json:"p40_accepted_fee,string"`
+	P50AcceptedFee  int32 `json:"p50_accepted_fee,string"`
+	P60AcceptedFee  int32 `json:"p60_accepted_fee,string"`
+	P70AcceptedFee  int32 `json:"p70_accepted_fee,string"`
+	P80AcceptedFee  int32 `json:"p80_accepted_fee,string"`
+	P90AcceptedFee  int32 `json:"p90_accepted_fee,string"`
+	P95AcceptedFee  int32 `json:"p95_accepted_fee,string"`
+	P99AcceptedFee  int32 `json:"p99_accepted_fee,string"`
+}
+
 // HistoryAccount is a simple resource, used for the account collection actions.
 // It provides only the "TotalOrderID" of the account and its account id.
 type HistoryAccount struct {
@@ -227,34 +262,6 @@ type Trade struct {
 	Price              xdr.Price `json:"price"`
 }
 
-// TradeEffect represents a trade effect resource.  NOTE (scott, 2017-12-08):
-// this resource is being added back in temporarily to deal with a deploy snafu.
-// I didn't properly message the community that we were changing the response
-// format, and so we're adding this back in to allow transition.
-type TradeEffect struct {
-	Links struct {
-		Self      hal.Link `json:"self"`
-		Seller    hal.Link `json:"seller"`
-		Buyer     hal.Link `json:"buyer"`
-		Operation hal.Link `json:"operation"`
-	} `json:"_links"`
-
-	ID                string    `json:"id"`
-	PT                string    `json:"paging_token"`
-	OfferID           string    `json:"offer_id"`
-	Seller            string    `json:"seller"`
-	SoldAmount        string    `json:"sold_amount"`
-	SoldAssetType     string    `json:"sold_asset_type"`
-	SoldAssetCode     string    `json:"sold_asset_code,omitempty"`
-	SoldAssetIssuer   string    `json:"sold_asset_issuer,omitempty"`
-	Buyer             string    `json:"buyer"`
-	BoughtAmount      string    `json:"bought_amount"`
-	BoughtAssetType   string    `json:"bought_asset_type"`
-	BoughtAssetCode   string    `json:"bought_asset_code,omitempty"`
-	BoughtAssetIssuer string    `json:"bought_asset_issuer,omitempty"`
-	LedgerCloseTime   time.Time `json:"created_at"`
-}
-
 // Transaction represents trade data aggregation over a period of time
 type TradeAggregation struct {
 	Timestamp     int64     `json:"timestamp"`
@@ -323,6 +330,22 @@ type TransactionSuccess struct {
 	Meta   string `json:"result_meta_xdr"`
 }
 
+// TransactionStatus represents the status of a submitted transaction: pending
+// while its result is unknown, then applied or failed.
+type TransactionStatus struct {
+	Links struct {
+		Self        hal.Link `json:"self"`
+		Transaction hal.Link `json:"transaction"`
+	} `json:"_links"`
+	Hash        string                  `json:"hash"`
+	Status      string                  `json:"status"`
+	Ledger      int32                   `json:"ledger,omitempty"`
+	Env         string                  `json:"envelope_xdr,omitempty"`
+	Result      string                  `json:"result_xdr,omitempty"`
+	ResultCodes *TransactionResultCodes `json:"result_codes,omitempty"`
+	Error       string                  `json:"error,omitempty"`
+}
+
 // NewEffect returns a resource of the appropriate sub-type for the provided
 // effect record.
 func NewEffect(
@@ -339,7 +362,18 @@ func NewOperation(
 	row history.Operation,
 	ledger history.Ledger,
 ) (result hal.Pageable, err error) {
-	return operations.New(ctx, row, ledger)
+	return operations.New(ctx, row, ledger, nil)
+}
+
+// NewOperationWithTransaction is like NewOperation, but embeds tx, the
+// transaction the operation is part of, in the resource.
+func NewOperationWithTransaction(
+	ctx context.Context,
+	row history.Operation,
+	ledger history.Ledger,
+	tx Transaction,
+) (result hal.Pageable, err error) {
+	return operations.New(ctx, row, ledger, tx)
 }
 
 // KeyTypeFromAddress converts the version byte of the provided strkey encoded
@@ -3,6 +3,7 @@ package operations
 import (
 	"time"
 
+	"github.com/stellar/go/services/horizon/internal/context/apiversion"
 	"github.com/stellar/go/services/horizon/internal/db2/history"
 	"github.com/stellar/go/services/horizon/internal/resource/base"
 	"github.com/stellar/go/services/horizon/internal/render/hal"
@@ -26,16 +27,29 @@ var TypeNames = map[xdr.OperationType]string{
 	xdr.OperationTypeManageData:         "manage_data",
 }
 
+// typeVersions maps the operation types introduced after the first version
+// of the API to the version introducing them.  Clients of earlier versions are
+// served these operations as unknown ones.  See package apiversion.
+var typeVersions = map[xdr.OperationType]int{}
+
 // New creates a new operation resource, finding the appropriate type to use
-// based upon the row's type.
+// based upon the row's type.  When transaction is not nil, it is embedded in
+// the resource as the transaction the operation is part of.
 func New(
 	ctx context.Context,
 	row history.Operation,
 	ledger history.Ledger,
+	transaction hal.Pageable,
 ) (result hal.Pageable, err error) {
 
 	base := Base{}
 	base.Populate(ctx, row, ledger)
+	base.Transaction = transaction
+
+	if v, ok := typeVersions[row.Type]; ok && v > apiversion.FromContext(ctx) {
+		base.Type = "unknown"
+		return newUnknown(base, row)
+	}
 
 	switch row.Type {
 	case xdr.OperationTypeCreateAccount:
@@ -85,7 +99,7 @@ func New(
 		err = row.UnmarshalDetails(&e)
 		result = e
 	default:
-		result = base
+		return newUnknown(base, row)
 	}
 
 	return
@@ -108,6 +122,26 @@ type Base struct {
 	TypeI           int32     `json:"type_i"`
 	LedgerCloseTime time.Time `json:"created_at"`
 	TransactionHash string    `json:"transaction_hash"`
+
+	// Transaction is the transaction the operation is part of, only embedded
+	// when requested with `join=transactions`.
+	Transaction hal.Pageable `json:"transaction,omitempty"`
+}
+
+// Unknown is the json resource representing a single operation whose type is
+// unknown to the client, either because it was introduced after the version
+// of the API requested, or because horizon has no resource for it.  Its
+// details are rendered as they were recorded.
+type Unknown struct {
+	Base
+	Details map[string]interface{} `json:"details,omitempty"`
+}
+
+// newUnknown creates the generic resource of row.
+func newUnknown(base Base, row history.Operation) (hal.Pageable, error) {
+	e := Unknown{Base: base}
+	err := row.UnmarshalDetails(&e.Details)
+	return e, err
 }
 
 // CreateAccount is the json resource representing a single operation whose type
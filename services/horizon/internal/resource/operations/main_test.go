@@ -0,0 +1,61 @@
+package operations
+
+import (
+	"testing"
+
+	"github.com/guregu/null"
+	. "github.com/smartystreets/goconvey/convey"
+	"github.com/stellar/go/services/horizon/internal/context/apiversion"
+	"github.com/stellar/go/services/horizon/internal/db2/history"
+	"github.com/stellar/go/xdr"
+	"golang.org/x/net/context"
+)
+
+func TestNew(t *testing.T) {
+	row := history.Operation{
+		TotalOrderID:  history.TotalOrderID{ID: 8589938689},
+		Type:          xdr.OperationTypeManageData,
+		DetailsString: null.StringFrom(`{"name": "hello", "value": "d29ybGQ="}`),
+	}
+
+	Convey("New renders known types", t, func() {
+		res, err := New(context.Background(), row, history.Ledger{}, nil)
+		So(err, ShouldBeNil)
+		op, ok := res.(ManageData)
+		So(ok, ShouldBeTrue)
+		So(op.Type, ShouldEqual, "manage_data")
+		So(op.Name, ShouldEqual, "hello")
+	})
+
+	Convey("New renders types unknown to the api version generically", t, func() {
+		typeVersions[xdr.OperationTypeManageData] = apiversion.Latest + 1
+		defer delete(typeVersions, xdr.OperationTypeManageData)
+
+		res, err := New(context.Background(), row, history.Ledger{}, nil)
+		So(err, ShouldBeNil)
+		op, ok := res.(Unknown)
+		So(ok, ShouldBeTrue)
+		So(op.Type, ShouldEqual, "unknown")
+		So(op.TypeI, ShouldEqual, int32(xdr.OperationTypeManageData))
+		So(op.Details["name"], ShouldEqual, "hello")
+
+		// unless the client requests the version introducing them
+		ctx := apiversion.Context(context.Background(), apiversion.Latest+1)
+		res, err = New(ctx, row, history.Ledger{}, nil)
+		So(err, ShouldBeNil)
+		_, ok = res.(ManageData)
+		So(ok, ShouldBeTrue)
+	})
+
+	Convey("New renders types without resources generically", t, func() {
+		unknown := row
+		unknown.Type = xdr.OperationType(100)
+
+		res, err := New(context.Background(), unknown, history.Ledger{}, nil)
+		So(err, ShouldBeNil)
+		op, ok := res.(Unknown)
+		So(ok, ShouldBeTrue)
+		So(op.Type, ShouldEqual, "unknown")
+		So(op.Details["value"], ShouldEqual, "d29ybGQ=")
+	})
+}
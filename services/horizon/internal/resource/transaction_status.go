@@ -0,0 +1,63 @@
+package resource
+
+import (
+	"github.com/stellar/go/services/horizon/internal/httpx"
+	"github.com/stellar/go/services/horizon/internal/render/hal"
+	"github.com/stellar/go/services/horizon/internal/txsub"
+	"golang.org/x/net/context"
+)
+
+// The statuses of a submitted transaction
+const (
+	TransactionPending = "pending"
+	TransactionApplied = "applied"
+	TransactionFailed  = "failed"
+)
+
+// Populate fills out the details of the transaction whose hash is `hash`,
+// given the result of its submission.  The transaction is pending until the
+// submission is done.
+func (res *TransactionStatus) Populate(
+	ctx context.Context,
+	hash string,
+	result txsub.Result,
+	done bool,
+) (err error) {
+	res.Hash = hash
+	lb := hal.LinkBuilder{httpx.BaseURL(ctx)}
+	res.Links.Self = lb.Link("/transactions", hash, "status")
+	res.Links.Transaction = lb.Link("/transactions", hash)
+
+	switch {
+	case !done:
+		res.Status = TransactionPending
+		return
+	case result.Err == nil:
+		res.Status = TransactionApplied
+		res.Ledger = result.LedgerSequence
+		res.Env = result.EnvelopeXDR
+		res.Result = result.ResultXDR
+		return
+	}
+
+	res.Status = TransactionFailed
+	res.Env = result.EnvelopeXDR
+
+	fte, ok := result.Err.(*txsub.FailedTransactionError)
+	if !ok {
+		if rte, ok := result.Err.(*txsub.RejectedTransactionError); ok {
+			fte = rte.FailedTransactionError
+		}
+	}
+
+	if fte == nil {
+		res.Error = result.Err.Error()
+		return
+	}
+
+	res.Ledger = result.LedgerSequence
+	res.Result = fte.ResultXDR
+	res.ResultCodes = &TransactionResultCodes{}
+	err = res.ResultCodes.Populate(ctx, fte)
+	return
+}
@@ -14,6 +14,15 @@ import (
 // rather is meant to be a simple implementation that gives usable paths.
 type Finder struct {
 	Q *core.Q
+
+	// MaxPathLength is the maximum number of assets between the source and
+	// the destination assets of the paths found.  paths.DefaultMaxPathLength
+	// is used when zero.
+	MaxPathLength int
+
+	// MaxResults is the maximum number of paths found by a search.
+	// paths.DefaultMaxResults is used when zero.
+	MaxResults int
 }
 
 // ensure the struct is paths.Finder compliant
@@ -46,3 +55,17 @@ func (f *Finder) Find(q paths.Query) (result []paths.Path, err error) {
 		Info("Finished pathfind")
 	return
 }
+
+func (f *Finder) maxPathLength() int {
+	if f.MaxPathLength <= 0 {
+		return paths.DefaultMaxPathLength
+	}
+	return f.MaxPathLength
+}
+
+func (f *Finder) maxResults() int {
+	if f.MaxResults <= 0 {
+		return paths.DefaultMaxResults
+	}
+	return f.MaxResults
+}
@@ -30,8 +30,6 @@ type search struct {
 	Results []paths.Path
 }
 
-const maxResults = 20
-
 // Init initialized the search, setting fields on the struct used to
 // hold state needed during the actual search.
 func (s *search) Init() {
@@ -81,7 +79,7 @@ func (s *search) hasMore() bool {
 		return false
 	}
 
-	if len(s.Results) >= maxResults {
+	if len(s.Results) >= s.Finder.maxResults() {
 		return false
 	}
 
@@ -120,10 +118,10 @@ func (s *search) runOnce() {
 		return
 	}
 
-	// A PathPaymentOp's path cannot be over 5 elements in length, and so
-	// we abort our search if the current linked list is over 7 (since the list
-	// includes both source and destination in addition to the path)
-	if cur.Depth() > 7 {
+	// The path of the nodes extending cur is made of all the assets of cur but
+	// the destination, and a PathPaymentOp's path cannot be over 5 elements in
+	// length, so we stop extending the search once it reaches the max length.
+	if cur.Depth()-1 > s.Finder.maxPathLength() {
 		return
 	}
 
@@ -1,6 +1,10 @@
 package test
 
 import (
+	"database/sql"
+	"log"
+
+	"github.com/stellar/go/services/horizon/internal/db2/schema"
 	"github.com/stellar/go/services/horizon/internal/test/scenarios"
 )
 
@@ -14,4 +18,20 @@ func loadScenario(scenarioName string, includeHorizon bool) {
 
 	scenarios.Load(StellarCoreDatabaseURL(), stellarCorePath)
 	scenarios.Load(DatabaseURL(), horizonPath)
+	migrateHorizon()
+}
+
+// migrateHorizon brings the schema of the horizon database up to date, since
+// scenarios are dumped with the schema of the time they were built.
+func migrateHorizon() {
+	db, err := sql.Open("postgres", DatabaseURL())
+	if err != nil {
+		log.Panic(err)
+	}
+	defer db.Close()
+
+	_, err = schema.Migrate(db, schema.MigrateUp, 0)
+	if err != nil {
+		log.Panic(err)
+	}
 }
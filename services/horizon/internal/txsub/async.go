@@ -0,0 +1,125 @@
+package txsub
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// DefaultAsyncRetention is how long the result of an asynchronous submission
+// is kept once known, when the AsyncRetention of the system is not set.
+const DefaultAsyncRetention = 10 * time.Minute
+
+// asyncList tracks the submissions made by SubmitAsync, keyed by transaction
+// hash, until their results expire.
+type asyncList struct {
+	sync.Mutex
+	submissions map[string]*asyncSubmission
+}
+
+type asyncSubmission struct {
+	Result     Result
+	Done       bool
+	FinishedAt time.Time
+}
+
+// SubmitAsync submits the provided base64 encoded transaction envelope like
+// Submit, but returns the hash of the transaction without waiting for its
+// result, which AsyncResult reports once known.  Since the submission outlives
+// the call, `ctx` should not be bound to the request of the submitter.
+// Submitting a transaction whose asynchronous submission is pending is a no-op.
+// `done`, when not nil, is called with the result once known, unless the call
+// was a no-op.
+func (sys *System) SubmitAsync(ctx context.Context, env string, done func(Result)) (string, error) {
+	sys.Init()
+
+	info, err := extractEnvelopeInfo(ctx, env, sys.NetworkPassphrase)
+	if err != nil {
+		return "", err
+	}
+
+	if !sys.async.add(info.Hash) {
+		return info.Hash, nil
+	}
+
+	go func() {
+		r := <-sys.Submit(ctx, env)
+		sys.async.finish(info.Hash, r)
+		if done != nil {
+			done(r)
+		}
+	}()
+
+	return info.Hash, nil
+}
+
+// AsyncResult returns the result of the asynchronous submission of the
+// transaction whose hash is `hash`.  `ok` is false if no such submission is
+// tracked, and `done` is false while the submission is pending.
+func (sys *System) AsyncResult(hash string) (r Result, done bool, ok bool) {
+	sys.Init()
+	return sys.async.get(hash)
+}
+
+func (sys *System) asyncRetention() time.Duration {
+	if sys.AsyncRetention <= 0 {
+		return DefaultAsyncRetention
+	}
+	return sys.AsyncRetention
+}
+
+// add tracks a new pending submission of `hash`, returning false if one is
+// already pending.
+func (l *asyncList) add(hash string) bool {
+	l.Lock()
+	defer l.Unlock()
+
+	if l.submissions == nil {
+		l.submissions = map[string]*asyncSubmission{}
+	}
+
+	if s, ok := l.submissions[hash]; ok && !s.Done {
+		return false
+	}
+
+	l.submissions[hash] = &asyncSubmission{}
+	return true
+}
+
+func (l *asyncList) finish(hash string, r Result) {
+	l.Lock()
+	defer l.Unlock()
+
+	s, ok := l.submissions[hash]
+	if !ok {
+		return
+	}
+
+	s.Result = r
+	s.Done = true
+	s.FinishedAt = time.Now()
+}
+
+func (l *asyncList) get(hash string) (Result, bool, bool) {
+	l.Lock()
+	defer l.Unlock()
+
+	s, ok := l.submissions[hash]
+	if !ok {
+		return Result{}, false, false
+	}
+
+	return s.Result, s.Done, true
+}
+
+// clean forgets the submissions finished for longer than maxAge.
+func (l *asyncList) clean(maxAge time.Duration) {
+	l.Lock()
+	defer l.Unlock()
+
+	for hash, s := range l.submissions {
+		if s.Done && time.Since(s.FinishedAt) > maxAge {
+			delete(l.submissions, hash)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package txsub
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"github.com/stellar/go/build"
+	"github.com/stellar/go/services/horizon/internal/test"
+	"github.com/stellar/go/services/horizon/internal/txsub/sequence"
+)
+
+func TestSubmitAsync(t *testing.T) {
+	Convey("txsub.System.SubmitAsync", t, func() {
+		ctx := test.Context()
+		results := &MockResultProvider{}
+
+		system := &System{
+			Pending:           NewDefaultSubmissionList(),
+			Submitter:         &MockSubmitter{},
+			Results:           results,
+			Sequences:         &MockSequenceProvider{},
+			SubmissionQueue:   sequence.NewManager(),
+			NetworkPassphrase: build.TestNetwork.Passphrase,
+		}
+
+		successTx := Result{
+			Hash:           "2374e99349b9ef7dba9a5db3339b78fda8f34777b1af33ba468ad5c0df946d4d",
+			LedgerSequence: 2,
+			EnvelopeXDR:    "AAAAAGL8HQvQkbK2HA3WVjRrKmjX00fG8sLI7m0ERwJW/AX3AAAAZAAAAAAAAAABAAAAAAAAAAAAAAABAAAAAAAAAAAAAAAArqN6LeOagjxMaUP96Bzfs9e0corNZXzBWJkFoK7kvkwAAAAAO5rKAAAAAAAAAAABVvwF9wAAAECDzqvkQBQoNAJifPRXDoLhvtycT3lFPCQ51gkdsFHaBNWw05S/VhW0Xgkr0CBPE4NaFV2Kmcs3ZwLmib4TRrML",
+		}
+
+		// waitFor polls the asynchronous submission of hash until it is done
+		waitFor := func(hash string) Result {
+			for i := 0; i < 100; i++ {
+				r, done, ok := system.AsyncResult(hash)
+				So(ok, ShouldBeTrue)
+				if done {
+					return r
+				}
+				time.Sleep(10 * time.Millisecond)
+			}
+			panic("asynchronous submission never finished")
+		}
+
+		Convey("returns the hash of the transaction, then its result", func() {
+			results.Results = []Result{successTx}
+			hash, err := system.SubmitAsync(ctx, successTx.EnvelopeXDR, nil)
+
+			So(err, ShouldBeNil)
+			So(hash, ShouldEqual, successTx.Hash)
+
+			r := waitFor(hash)
+			So(r.Err, ShouldBeNil)
+			So(r.LedgerSequence, ShouldEqual, 2)
+		})
+
+		Convey("calls done with the result", func() {
+			results.Results = []Result{successTx}
+			done := make(chan Result, 1)
+			_, err := system.SubmitAsync(ctx, successTx.EnvelopeXDR, func(r Result) {
+				done <- r
+			})
+			So(err, ShouldBeNil)
+
+			select {
+			case r := <-done:
+				So(r.Err, ShouldBeNil)
+				So(r.Hash, ShouldEqual, successTx.Hash)
+			case <-time.After(time.Second):
+				panic("done never called")
+			}
+		})
+
+		Convey("returns an error for malformed transactions", func() {
+			_, err := system.SubmitAsync(ctx, "AAAA", nil)
+			So(err, ShouldHaveSameTypeAs, &MalformedTransactionError{})
+		})
+
+		Convey("reports unknown submissions", func() {
+			_, _, ok := system.AsyncResult(successTx.Hash)
+			So(ok, ShouldBeFalse)
+		})
+
+		Convey("forgets results once expired", func() {
+			results.Results = []Result{successTx}
+			hash, err := system.SubmitAsync(ctx, successTx.EnvelopeXDR, nil)
+			So(err, ShouldBeNil)
+			waitFor(hash)
+
+			system.AsyncRetention = time.Nanosecond
+			time.Sleep(time.Millisecond)
+			system.Tick(ctx)
+
+			_, _, ok := system.AsyncResult(hash)
+			So(ok, ShouldBeFalse)
+		})
+	})
+}
@@ -71,6 +71,45 @@ func (fte *FailedTransactionError) OperationResultCodes() (result []string, err
 	return
 }
 
+// RejectedTransactionError represent an error that occurred because horizon
+// found, before submitting it, that stellar-core would reject the transaction.
+// It embeds the failure stellar-core would have responded with, so that it can
+// be handled like any other failed transaction.
+type RejectedTransactionError struct {
+	*FailedTransactionError
+
+	// Reason describes why the transaction was rejected and how to fix it
+	Reason string
+
+	// Extras holds the details of the rejection, such as the minimum fee of
+	// the transaction
+	Extras map[string]interface{}
+}
+
+func newRejectedTransactionError(
+	code xdr.TransactionResultCode,
+	reason string,
+	extras map[string]interface{},
+) (*RejectedTransactionError, error) {
+	var result xdr.TransactionResult
+	result.Result.Code = code
+
+	resultXDR, err := xdr.MarshalBase64(result)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RejectedTransactionError{
+		FailedTransactionError: &FailedTransactionError{resultXDR},
+		Reason:                 reason,
+		Extras:                 extras,
+	}, nil
+}
+
+func (err *RejectedTransactionError) Error() string {
+	return fmt.Sprintf("tx rejected: %s", err.Reason)
+}
+
 // MalformedTransactionError represent an error that occurred because
 // a TransactionEnvelope could not be decoded from the provided data.
 type MalformedTransactionError struct {
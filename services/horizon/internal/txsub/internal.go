@@ -9,6 +9,7 @@ import (
 )
 
 type envelopeInfo struct {
+	Envelope      xdr.TransactionEnvelope
 	Hash          string
 	Sequence      uint64
 	SourceAddress string
@@ -27,6 +28,7 @@ func extractEnvelopeInfo(ctx context.Context, env string, passphrase string) (re
 	txb := build.TransactionBuilder{TX: &tx.Tx}
 	txb.Mutate(build.Network{passphrase})
 
+	result.Envelope = tx
 	result.Hash, err = txb.HashHex()
 	if err != nil {
 		return
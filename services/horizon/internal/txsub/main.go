@@ -25,6 +25,18 @@ type SequenceProvider interface {
 	Get(addresses []string) (map[string]uint64, error)
 }
 
+// ValidationProvider represents an abstract store that loads the state of the
+// network needed to validate transactions before their submission to
+// stellar-core.
+type ValidationProvider interface {
+	// BaseFee returns the base fee, in stroops, of the latest ledger
+	BaseFee() (int32, error)
+
+	// Signers returns the thresholds and the signers of the account at
+	// `address`, keyed by signer key, including its master key
+	Signers(address string) (xdr.Thresholds, map[string]int32, error)
+}
+
 // Listener represents some client who is interested in retrieving the result
 // of a specific transaction.
 type Listener chan<- Result
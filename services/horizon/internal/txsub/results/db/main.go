@@ -19,6 +19,10 @@ import (
 type DB struct {
 	Core    *core.Q
 	History *history.Q
+
+	// Ledger tracks the ledger state of the network of the databases.  The
+	// default tracker of the process is used when nil.
+	Ledger *ledger.Tracker
 }
 
 var _ txsub.ResultProvider = &DB{}
@@ -38,7 +42,7 @@ func (rp *DB) ResultByHash(ctx context.Context, hash string) txsub.Result {
 
 	// query core database
 	var cr core.Transaction
-	err = rp.Core.TransactionByHashAfterLedger(&cr, hash, ledger.CurrentState().HistoryLatest)
+	err = rp.Core.TransactionByHashAfterLedger(&cr, hash, rp.Ledger.CurrentState().HistoryLatest)
 	if err == nil {
 		return txResultFromCore(cr)
 	}
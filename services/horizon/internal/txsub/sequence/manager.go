@@ -4,23 +4,55 @@ import (
 	"fmt"
 	"strings"
 	"sync"
+	"time"
 )
 
+// DefaultMaxSize is the maximum count of submissions buffered by a manager,
+// when not configured.
+const DefaultMaxSize = 1024
+
 // Manager provides a system for tracking the transaction submission queue for
 // a set of addresses.  Requests to submit at a certain sequence number are
 // registered using the Push() method, and as the system is updated with
 // account sequence information (through the Update() method) requests are
 // notified that they can safely submit to stellar-core.
+//
+// Once MaxSize submissions are buffered, the submissions held the farthest
+// from their account's next sequence number make room for the submissions
+// closer to theirs.
 type Manager struct {
 	mutex   sync.Mutex
 	MaxSize int
-	queues  map[string]*Queue
+
+	// Timeout is the time the queue of an account holds submissions for
+	// without any progress of the account.
+	Timeout time.Duration
+
+	queues map[string]*Queue
+}
+
+// Stats are statistics about the submissions buffered by a manager.
+type Stats struct {
+	// Accounts is the count of accounts with buffered submissions.
+	Accounts int
+
+	// Buffered is the count of buffered submissions.
+	Buffered int
+
+	// Held is the count of buffered submissions waiting for their predecessors
+	// to be applied.
+	Held int
+
+	// MaxDepth is the count of buffered submissions of the account with the
+	// most of them.
+	MaxDepth int
 }
 
 // NewManager returns a new manager
 func NewManager() *Manager {
 	return &Manager{
-		MaxSize: 1024, //TODO: make MaxSize configurable
+		MaxSize: DefaultMaxSize,
+		Timeout: DefaultTimeout,
 		queues:  map[string]*Queue{},
 	}
 }
@@ -64,13 +96,26 @@ func (m *Manager) Push(address string, sequence uint64) <-chan error {
 	m.mutex.Lock()
 	defer m.mutex.Unlock()
 
+	aq, ok := m.queues[address]
+
 	if m.size() >= m.MaxSize {
-		return m.getError(ErrNoMoreRoom)
+		// the gap of a submission for an account without queue is unknown, but
+		// most likely small
+		var gap uint64
+		if ok && sequence > aq.nextSequence {
+			gap = sequence - aq.nextSequence
+		}
+
+		if !m.evictFarther(gap) {
+			return m.getError(ErrNoMoreRoom)
+		}
 	}
 
-	aq, ok := m.queues[address]
 	if !ok {
 		aq = NewQueue()
+		if m.Timeout > 0 {
+			aq.timeout = m.Timeout
+		}
 		m.queues[address] = aq
 	}
 
@@ -96,6 +141,48 @@ func (m *Manager) Update(updates map[string]uint64) {
 	}
 }
 
+// Stats returns statistics about the submissions buffered within this
+// manager.
+func (m *Manager) Stats() Stats {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	stats := Stats{Accounts: len(m.queues)}
+	for _, q := range m.queues {
+		size := q.Size()
+		stats.Buffered += size
+		stats.Held += q.Held()
+		if size > stats.MaxDepth {
+			stats.MaxDepth = size
+		}
+	}
+	return stats
+}
+
+// evictFarther evicts the submission held the farthest from its account's
+// next sequence number, if it is farther than gap.  It returns false if no
+// submission was evicted.  This internal version assumes you have locked the
+// manager previously.
+func (m *Manager) evictFarther(gap uint64) bool {
+	var (
+		farthest *Queue
+		index    int
+	)
+	for _, q := range m.queues {
+		i, g := q.farthest()
+		if i >= 0 && g > gap {
+			farthest, index, gap = q, i, g
+		}
+	}
+
+	if farthest == nil {
+		return false
+	}
+
+	farthest.evict(index, ErrNoMoreRoom)
+	return true
+}
+
 // size returns the count of submissions buffered within this manager.  This
 // internal version assumes you have locked the manager previously.
 func (m *Manager) size() int {
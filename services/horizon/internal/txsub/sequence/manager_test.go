@@ -42,6 +42,38 @@ func TestManager(t *testing.T) {
 			So(len(results[1]), ShouldEqual, 0)
 		})
 
+		Convey("Push evicts the submissions held the farthest when full", func() {
+			mgr.MaxSize = 2
+			far := mgr.Push("1", 10)
+			near := mgr.Push("2", 3)
+			mgr.Update(map[string]uint64{"1": 1, "2": 1})
+
+			next := mgr.Push("1", 2)
+			So(<-far, ShouldEqual, ErrNoMoreRoom)
+			So(len(near), ShouldEqual, 0)
+
+			// submissions farther than any held one are rejected
+			So(<-mgr.Push("2", 20), ShouldEqual, ErrNoMoreRoom)
+
+			mgr.Update(map[string]uint64{"1": 1})
+			So(<-next, ShouldEqual, nil)
+		})
+
+		Convey("Stats", func() {
+			mgr.Push("1", 2)
+			mgr.Push("1", 3)
+			mgr.Push("1", 5)
+			mgr.Push("2", 4)
+			mgr.Update(map[string]uint64{"1": 1, "2": 1})
+
+			So(mgr.Stats(), ShouldResemble, Stats{
+				Accounts: 2,
+				Buffered: 3,
+				Held:     3,
+				MaxDepth: 2,
+			})
+		})
+
 		Convey("Push returns ErrNoMoreRoom when fill", func() {
 			for i := 0; i < mgr.MaxSize; i++ {
 				mgr.Push("1", 2)
@@ -5,6 +5,10 @@ import (
 	"time"
 )
 
+// DefaultTimeout is the time a queue holds submissions for without any
+// progress of its account, when not configured.
+const DefaultTimeout = 10 * time.Second
+
 // Queue manages the submission queue for a single source account. The
 // transaction system uses Push to enqueue submissions for given sequence
 // numbers.
@@ -12,6 +16,9 @@ import (
 // Queue maintains a priority queue of pending submissions, and when updated
 // (via the Update() method) with the current sequence number of the account
 // being managed, queued submissions that can be acted upon will be unblocked.
+// Submissions whose sequence number is in the future are held until their
+// predecessors are applied, as long as the account makes progress within the
+// timeout of the queue.
 //
 type Queue struct {
 	lastActiveAt time.Time
@@ -24,7 +31,7 @@ type Queue struct {
 func NewQueue() *Queue {
 	result := &Queue{
 		lastActiveAt: time.Now(),
-		timeout:      10 * time.Second,
+		timeout:      DefaultTimeout,
 		queue:        nil,
 	}
 
@@ -38,6 +45,19 @@ func (q *Queue) Size() int {
 	return len(q.queue)
 }
 
+// Held returns the count of buffered submissions whose sequence number is
+// beyond the next sequence number of the account, i.e. that wait for their
+// predecessors to be applied.
+func (q *Queue) Held() int {
+	var held int
+	for _, i := range q.queue {
+		if i.Sequence > q.nextSequence {
+			held++
+		}
+	}
+	return held
+}
+
 // Push enqueues the intent to submit a transaction at the provided sequence
 // number and returns a channel that will emit when it is safe for the client
 // to do so.
@@ -63,12 +83,14 @@ func (q *Queue) Push(sequence uint64) <-chan error {
 // This function is monotonic... calling it with a sequence number lower than
 // the latest seen sequence number is a noop.
 func (q *Queue) Update(sequence uint64) {
+	// the account making progress is a change of the queue, so that submissions
+	// held for their predecessors aren't dropped while these are being applied
+	wasChanged := false
 	if q.nextSequence <= sequence {
+		wasChanged = q.nextSequence != 0
 		q.nextSequence = sequence + 1
 	}
 
-	wasChanged := false
-
 	for {
 		if q.Size() == 0 {
 			break
@@ -112,6 +134,28 @@ func (q *Queue) Update(sequence uint64) {
 	}
 }
 
+// farthest returns the index in the priority queue of the submission that is
+// the farthest from being submittable, along with the count of submissions of
+// the account that must be applied before it can be, or -1 if no submission
+// is held.
+func (q *Queue) farthest() (index int, gap uint64) {
+	index = -1
+	for i, item := range q.queue {
+		if item.Sequence > q.nextSequence && item.Sequence-q.nextSequence > gap {
+			index, gap = i, item.Sequence-q.nextSequence
+		}
+	}
+	return
+}
+
+// evict removes the submission at index in the priority queue, notifying it
+// with err.
+func (q *Queue) evict(index int, err error) {
+	i := heap.Remove(&q.queue, index).(item)
+	i.Chan <- err
+	close(i.Chan)
+}
+
 // helper function for interacting with the priority queue
 func (q *Queue) head() (chan error, uint64) {
 	if len(q.queue) == 0 {
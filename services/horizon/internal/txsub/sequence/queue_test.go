@@ -54,6 +54,35 @@ func TestQueue(t *testing.T) {
 
 		})
 
+		Convey("Held counts the submissions waiting for their predecessors", func() {
+			queue.Push(2)
+			queue.Push(4)
+			queue.Update(1)
+
+			So(queue.Size(), ShouldEqual, 1)
+			So(queue.Held(), ShouldEqual, 1)
+
+			i, gap := queue.farthest()
+			So(i, ShouldEqual, 0)
+			So(gap, ShouldEqual, 2)
+		})
+
+		Convey("Update holds submissions while the account makes progress", func() {
+			queue.timeout = 50 * time.Millisecond
+			result := queue.Push(4)
+			queue.Update(1)
+
+			<-time.After(30 * time.Millisecond)
+			queue.Update(2)
+			<-time.After(30 * time.Millisecond)
+			queue.Update(2)
+
+			So(queue.Size(), ShouldEqual, 1)
+
+			queue.Update(3)
+			So(<-result, ShouldEqual, nil)
+		})
+
 		Convey("Update clears the queue if the head has not been released within the time limit", func() {
 			queue.timeout = 1 * time.Millisecond
 			result := queue.Push(2)
@@ -19,11 +19,18 @@ type System struct {
 	Pending           OpenSubmissionList
 	Results           ResultProvider
 	Sequences         SequenceProvider
+	Validation        ValidationProvider
 	Submitter         Submitter
 	SubmissionQueue   *sequence.Manager
 	NetworkPassphrase string
 	SubmissionTimeout time.Duration
 
+	// AsyncRetention is how long the result of an asynchronous submission is
+	// kept once known.  DefaultAsyncRetention is used when zero.
+	AsyncRetention time.Duration
+
+	async asyncList
+
 	Metrics struct {
 		// SubmissionTimer exposes timing metrics about the rate and latency of
 		// submissions to stellar-core
@@ -33,6 +40,18 @@ type System struct {
 		// behind this system's SubmissionQueue
 		BufferedSubmissionsGauge metrics.Gauge
 
+		// HeldSubmissionsGauge tracks the count of buffered submissions whose
+		// sequence number is in the future, held until their predecessors apply
+		HeldSubmissionsGauge metrics.Gauge
+
+		// QueuedAccountsGauge tracks the count of source accounts with buffered
+		// submissions
+		QueuedAccountsGauge metrics.Gauge
+
+		// MaxQueueDepthGauge tracks the count of buffered submissions of the
+		// source account with the most of them
+		MaxQueueDepthGauge metrics.Gauge
+
 		// OpenSubmissionsGauge tracks the count of "open" submissions (i.e.
 		// submissions whose transactions haven't been confirmed successful or failed
 		OpenSubmissionsGauge metrics.Gauge
@@ -82,6 +101,15 @@ func (sys *System) Submit(ctx context.Context, env string) (result <-chan Result
 		return
 	}
 
+	// reject the transactions stellar-core would fail, with a friendlier error
+	if sys.Validation != nil {
+		err = sys.validate(info)
+		if err != nil {
+			sys.finish(response, Result{Err: err, EnvelopeXDR: env})
+			return
+		}
+	}
+
 	// queue the submission and get the channel that will emit when
 	// submission is valid
 	seq := sys.SubmissionQueue.Push(info.SourceAddress, info.Sequence)
@@ -206,8 +234,14 @@ func (sys *System) Tick(ctx context.Context) {
 		logger.WithStack(err).Error(err)
 	}
 
+	sys.async.clean(sys.asyncRetention())
+
+	stats := sys.SubmissionQueue.Stats()
 	sys.Metrics.OpenSubmissionsGauge.Update(int64(stillOpen))
-	sys.Metrics.BufferedSubmissionsGauge.Update(int64(sys.SubmissionQueue.Size()))
+	sys.Metrics.BufferedSubmissionsGauge.Update(int64(stats.Buffered))
+	sys.Metrics.HeldSubmissionsGauge.Update(int64(stats.Held))
+	sys.Metrics.QueuedAccountsGauge.Update(int64(stats.Accounts))
+	sys.Metrics.MaxQueueDepthGauge.Update(int64(stats.MaxDepth))
 }
 
 // Init initializes `sys`
@@ -218,6 +252,9 @@ func (sys *System) Init() {
 		sys.Metrics.SubmissionTimer = metrics.NewTimer()
 		sys.Metrics.OpenSubmissionsGauge = metrics.NewGauge()
 		sys.Metrics.BufferedSubmissionsGauge = metrics.NewGauge()
+		sys.Metrics.HeldSubmissionsGauge = metrics.NewGauge()
+		sys.Metrics.QueuedAccountsGauge = metrics.NewGauge()
+		sys.Metrics.MaxQueueDepthGauge = metrics.NewGauge()
 
 		if sys.SubmissionTimeout == 0 {
 			sys.SubmissionTimeout = 1 * time.Minute
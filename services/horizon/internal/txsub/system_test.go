@@ -114,6 +114,27 @@ func TestTxsub(t *testing.T) {
 				So(len(system.Pending.Pending(ctx)), ShouldEqual, 0)
 			})
 
+			Convey("reports the submissions held for their predecessors", func() {
+				held := system.SubmissionQueue.Push("GBRPYHIL2CI3FNQ4BXLFMNDLFJUNPU2HY3ZMFSHONUCEOASW7QC7OX2H", 3)
+				system.Tick(ctx)
+
+				So(len(held), ShouldEqual, 0)
+				So(system.Metrics.BufferedSubmissionsGauge.Value(), ShouldEqual, 1)
+				So(system.Metrics.HeldSubmissionsGauge.Value(), ShouldEqual, 1)
+				So(system.Metrics.QueuedAccountsGauge.Value(), ShouldEqual, 1)
+				So(system.Metrics.MaxQueueDepthGauge.Value(), ShouldEqual, 1)
+
+				// the predecessor of the held submission applies
+				sequences.Results = map[string]uint64{
+					"GBRPYHIL2CI3FNQ4BXLFMNDLFJUNPU2HY3ZMFSHONUCEOASW7QC7OX2H": 2,
+				}
+				system.Tick(ctx)
+
+				So(<-held, ShouldBeNil)
+				So(system.Metrics.HeldSubmissionsGauge.Value(), ShouldEqual, 0)
+				So(system.Metrics.QueuedAccountsGauge.Value(), ShouldEqual, 0)
+			})
+
 			Convey("removes old submissions that have timed out", func() {
 				l := make(chan Result, 1)
 				system.SubmissionTimeout = 100 * time.Millisecond
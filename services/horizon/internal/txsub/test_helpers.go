@@ -8,6 +8,8 @@ package txsub
 
 import (
 	"context"
+
+	"github.com/stellar/go/xdr"
 )
 
 // MockSubmitter is a test helper that simplements the Submitter interface
@@ -51,3 +53,22 @@ type MockSequenceProvider struct {
 func (results *MockSequenceProvider) Get(addresses []string) (map[string]uint64, error) {
 	return results.Results, results.Err
 }
+
+// MockValidationProvider is a test helper that simplements the
+// ValidationProvider interface
+type MockValidationProvider struct {
+	Fee        int32
+	Thresholds xdr.Thresholds
+	Results    map[string]int32
+	Err        error
+}
+
+// BaseFee implements `txsub.ValidationProvider`
+func (results *MockValidationProvider) BaseFee() (int32, error) {
+	return results.Fee, results.Err
+}
+
+// Signers implements `txsub.ValidationProvider`
+func (results *MockValidationProvider) Signers(address string) (xdr.Thresholds, map[string]int32, error) {
+	return results.Thresholds, results.Results, results.Err
+}
@@ -0,0 +1,236 @@
+package txsub
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/stellar/go/keypair"
+	"github.com/stellar/go/strkey"
+	"github.com/stellar/go/support/errors"
+	"github.com/stellar/go/xdr"
+)
+
+// validate checks the time bounds, the fee and the signatures of the
+// transaction described by info, returning a *RejectedTransactionError if
+// stellar-core would fail it.  Only the signatures of the source account of the
+// transaction are checked, since the source accounts of its operations may be
+// signed for by any of its signatures.
+func (sys *System) validate(info envelopeInfo) error {
+	tx := info.Envelope.Tx
+
+	err := validateTimeBounds(tx, time.Now())
+	if err != nil {
+		return err
+	}
+
+	baseFee, err := sys.Validation.BaseFee()
+	if err != nil {
+		return errors.Wrap(err, "load base fee failed")
+	}
+
+	minFee := int64(baseFee) * int64(len(tx.Operations))
+	if int64(tx.Fee) < minFee {
+		return rejected(
+			xdr.TransactionResultCodeTxInsufficientFee,
+			fmt.Sprintf(
+				"The fee of the transaction is too low: it must be at least %d stroops, "+
+					"the base fee multiplied by the number of operations of the transaction.",
+				minFee,
+			),
+			map[string]interface{}{
+				"fee":      tx.Fee,
+				"min_fee":  minFee,
+				"base_fee": baseFee,
+			},
+		)
+	}
+
+	thresholds, signers, err := sys.Validation.Signers(info.SourceAddress)
+	if err != nil {
+		return errors.Wrap(err, "load signers failed")
+	}
+
+	hash, err := hex.DecodeString(info.Hash)
+	if err != nil {
+		return errors.Wrap(err, "decode hash failed")
+	}
+
+	weight := signatureWeight(info.Envelope.Signatures, hash, signers)
+	threshold := neededThreshold(tx, thresholds)
+	if weight < threshold {
+		return rejected(
+			xdr.TransactionResultCodeTxBadAuth,
+			fmt.Sprintf(
+				"The transaction is not signed by enough signers of its source account: "+
+					"the weight of its signatures is %d, while %d is needed.",
+				weight,
+				threshold,
+			),
+			map[string]interface{}{
+				"source_account":   info.SourceAddress,
+				"signature_weight": weight,
+				"threshold":        threshold,
+			},
+		)
+	}
+
+	return nil
+}
+
+// validateTimeBounds checks that the time bounds of tx include now.
+func validateTimeBounds(tx xdr.Transaction, now time.Time) error {
+	if tx.TimeBounds == nil {
+		return nil
+	}
+
+	unix := now.Unix()
+	extras := map[string]interface{}{
+		"min_time":     tx.TimeBounds.MinTime,
+		"max_time":     tx.TimeBounds.MaxTime,
+		"current_time": unix,
+	}
+
+	if int64(tx.TimeBounds.MinTime) > unix {
+		return rejected(
+			xdr.TransactionResultCodeTxTooEarly,
+			"The transaction is not valid yet: submit it again once the current "+
+				"time is past its minimum time.",
+			extras,
+		)
+	}
+
+	if tx.TimeBounds.MaxTime != 0 && int64(tx.TimeBounds.MaxTime) < unix {
+		return rejected(
+			xdr.TransactionResultCodeTxTooLate,
+			"The transaction is no longer valid: build and sign it again with a "+
+				"later maximum time.",
+			extras,
+		)
+	}
+
+	return nil
+}
+
+// signatureWeight returns the sum of the weights of the signers that signed the
+// transaction whose hash is `hash`.  Pre-authorized transaction signers sign
+// the transaction with that hash, and hash(x) signers sign any transaction
+// with a signature hashing to x.
+func signatureWeight(
+	sigs []xdr.DecoratedSignature,
+	hash []byte,
+	signers map[string]int32,
+) int32 {
+	var weight int32
+
+	for key, w := range signers {
+		version, err := strkey.Version(key)
+		if err != nil {
+			continue
+		}
+
+		var signed bool
+		switch version {
+		case strkey.VersionByteAccountID:
+			signed = signedByKey(sigs, hash, key)
+		case strkey.VersionByteHashTx:
+			signed = bytes.Equal(strkey.MustDecode(strkey.VersionByteHashTx, key), hash)
+		case strkey.VersionByteHashX:
+			signed = signedByHashX(sigs, strkey.MustDecode(strkey.VersionByteHashX, key))
+		}
+
+		if signed {
+			weight += w
+		}
+	}
+
+	return weight
+}
+
+func signedByKey(sigs []xdr.DecoratedSignature, hash []byte, address string) bool {
+	kp, err := keypair.Parse(address)
+	if err != nil {
+		return false
+	}
+
+	hint := kp.Hint()
+	for _, sig := range sigs {
+		if sig.Hint != xdr.SignatureHint(hint) {
+			continue
+		}
+		if kp.Verify(hash, sig.Signature) == nil {
+			return true
+		}
+	}
+
+	return false
+}
+
+func signedByHashX(sigs []xdr.DecoratedSignature, x []byte) bool {
+	for _, sig := range sigs {
+		if !bytes.Equal(sig.Hint[:], x[len(x)-4:]) {
+			continue
+		}
+		sum := sha256.Sum256(sig.Signature)
+		if bytes.Equal(sum[:], x) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// neededThreshold returns the signature weight the source account of tx must
+// reach: the highest of the thresholds of the operations it is the source
+// account of, and at least the low threshold of the account.  A weight of at
+// least 1 is always needed.
+func neededThreshold(tx xdr.Transaction, thresholds xdr.Thresholds) int32 {
+	needed := int32(thresholds[1])
+
+	for _, op := range tx.Operations {
+		if op.SourceAccount != nil && !op.SourceAccount.Equals(tx.SourceAccount) {
+			continue
+		}
+
+		var t int32
+		switch op.Body.Type {
+		case xdr.OperationTypeAllowTrust, xdr.OperationTypeInflation:
+			t = int32(thresholds[1])
+		case xdr.OperationTypeAccountMerge:
+			t = int32(thresholds[3])
+		case xdr.OperationTypeSetOptions:
+			t = int32(thresholds[2])
+			o := op.Body.MustSetOptionsOp()
+			if o.MasterWeight != nil || o.LowThreshold != nil ||
+				o.MedThreshold != nil || o.HighThreshold != nil || o.Signer != nil {
+				t = int32(thresholds[3])
+			}
+		default:
+			t = int32(thresholds[2])
+		}
+
+		if t > needed {
+			needed = t
+		}
+	}
+
+	if needed < 1 {
+		needed = 1
+	}
+	return needed
+}
+
+// rejected returns the *RejectedTransactionError for `code`.
+func rejected(
+	code xdr.TransactionResultCode,
+	reason string,
+	extras map[string]interface{},
+) error {
+	rte, err := newRejectedTransactionError(code, reason, extras)
+	if err != nil {
+		return errors.Wrap(err, "marshal result failed")
+	}
+	return rte
+}
@@ -0,0 +1,188 @@
+package txsub
+
+import (
+	"crypto/sha256"
+	"errors"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+	"github.com/stellar/go/build"
+	"github.com/stellar/go/keypair"
+	"github.com/stellar/go/services/horizon/internal/test"
+	"github.com/stellar/go/services/horizon/internal/txsub/sequence"
+	"github.com/stellar/go/strkey"
+	"github.com/stellar/go/xdr"
+)
+
+func TestValidation(t *testing.T) {
+	Convey("txsub.System validation", t, func() {
+		ctx := test.Context()
+		submitter := &MockSubmitter{}
+		sequences := &MockSequenceProvider{}
+		validation := &MockValidationProvider{Fee: 100}
+
+		// fail the submissions reaching stellar-core, so that their result is
+		// returned without waiting for a ledger to close
+		errSubmitted := errors.New("submitted")
+		submitter.R.Err = errSubmitted
+
+		system := &System{
+			Pending:           NewDefaultSubmissionList(),
+			Submitter:         submitter,
+			Results:           &MockResultProvider{},
+			Sequences:         sequences,
+			Validation:        validation,
+			SubmissionQueue:   sequence.NewManager(),
+			NetworkPassphrase: build.TestNetwork.Passphrase,
+		}
+
+		source, err := keypair.Random()
+		So(err, ShouldBeNil)
+		other, err := keypair.Random()
+		So(err, ShouldBeNil)
+
+		sequences.Results = map[string]uint64{source.Address(): 0}
+		validation.Thresholds = xdr.Thresholds{1, 0, 0, 0}
+		validation.Results = map[string]int32{source.Address(): 1}
+
+		envelope := func(fee uint64, signers ...string) string {
+			tx, err := build.Transaction(
+				build.SourceAccount{AddressOrSeed: source.Address()},
+				build.Sequence{Sequence: 1},
+				build.TestNetwork,
+				build.BaseFee{Amount: fee},
+				build.Payment(
+					build.Destination{AddressOrSeed: other.Address()},
+					build.NativeAmount{Amount: "10"},
+				),
+			)
+			So(err, ShouldBeNil)
+
+			txe, err := tx.Sign(signers...)
+			So(err, ShouldBeNil)
+			env, err := txe.Base64()
+			So(err, ShouldBeNil)
+			return env
+		}
+
+		Convey("submits valid transactions", func() {
+			r := <-system.Submit(ctx, envelope(100, source.Seed()))
+
+			So(r.Err, ShouldEqual, errSubmitted)
+			So(submitter.WasSubmittedTo, ShouldBeTrue)
+		})
+
+		Convey("rejects transactions with too low a fee", func() {
+			r := <-system.Submit(ctx, envelope(50, source.Seed()))
+
+			rte, ok := r.Err.(*RejectedTransactionError)
+			So(ok, ShouldBeTrue)
+			So(submitter.WasSubmittedTo, ShouldBeFalse)
+			So(rte.Extras["min_fee"], ShouldEqual, int64(100))
+
+			code, err := rte.TransactionResultCode()
+			So(err, ShouldBeNil)
+			So(code, ShouldEqual, "tx_insufficient_fee")
+		})
+
+		Convey("rejects transactions without enough signatures", func() {
+			r := <-system.Submit(ctx, envelope(100, other.Seed()))
+
+			rte, ok := r.Err.(*RejectedTransactionError)
+			So(ok, ShouldBeTrue)
+			So(submitter.WasSubmittedTo, ShouldBeFalse)
+			So(rte.Extras["signature_weight"], ShouldEqual, int32(0))
+
+			code, err := rte.TransactionResultCode()
+			So(err, ShouldBeNil)
+			So(code, ShouldEqual, "tx_bad_auth")
+		})
+
+		Convey("counts the weights of the other signers", func() {
+			validation.Thresholds = xdr.Thresholds{1, 2, 2, 2}
+			validation.Results[other.Address()] = 1
+
+			r := <-system.Submit(ctx, envelope(100, source.Seed()))
+			So(r.Err, ShouldHaveSameTypeAs, &RejectedTransactionError{})
+
+			r = <-system.Submit(ctx, envelope(100, source.Seed(), other.Seed()))
+			So(r.Err, ShouldEqual, errSubmitted)
+		})
+
+		Convey("returns provider errors", func() {
+			validation.Err = ErrCanceled
+			r := <-system.Submit(ctx, envelope(100, source.Seed()))
+
+			So(r.Err, ShouldNotBeNil)
+			So(r.Err, ShouldNotEqual, errSubmitted)
+			So(submitter.WasSubmittedTo, ShouldBeFalse)
+		})
+	})
+
+	Convey("validateTimeBounds", t, func() {
+		now := time.Unix(1000, 0)
+		tx := xdr.Transaction{}
+
+		So(validateTimeBounds(tx, now), ShouldBeNil)
+
+		tx.TimeBounds = &xdr.TimeBounds{MinTime: 900, MaxTime: 0}
+		So(validateTimeBounds(tx, now), ShouldBeNil)
+
+		tx.TimeBounds = &xdr.TimeBounds{MinTime: 1100, MaxTime: 1200}
+		err := validateTimeBounds(tx, now)
+		So(err, ShouldNotBeNil)
+		code, _ := err.(*RejectedTransactionError).TransactionResultCode()
+		So(code, ShouldEqual, "tx_too_early")
+
+		tx.TimeBounds = &xdr.TimeBounds{MinTime: 0, MaxTime: 900}
+		err = validateTimeBounds(tx, now)
+		So(err, ShouldNotBeNil)
+		code, _ = err.(*RejectedTransactionError).TransactionResultCode()
+		So(code, ShouldEqual, "tx_too_late")
+	})
+
+	Convey("neededThreshold", t, func() {
+		thresholds := xdr.Thresholds{1, 2, 3, 4}
+		tx := xdr.Transaction{}
+
+		So(neededThreshold(tx, xdr.Thresholds{}), ShouldEqual, 1)
+		So(neededThreshold(tx, thresholds), ShouldEqual, 2)
+
+		tx.Operations = []xdr.Operation{
+			{Body: xdr.OperationBody{Type: xdr.OperationTypeInflation}},
+		}
+		So(neededThreshold(tx, thresholds), ShouldEqual, 2)
+
+		tx.Operations = append(tx.Operations, xdr.Operation{
+			Body: xdr.OperationBody{Type: xdr.OperationTypePayment, PaymentOp: &xdr.PaymentOp{}},
+		})
+		So(neededThreshold(tx, thresholds), ShouldEqual, 3)
+
+		var weight xdr.Uint32 = 1
+		tx.Operations = append(tx.Operations, xdr.Operation{
+			Body: xdr.OperationBody{
+				Type:         xdr.OperationTypeSetOptions,
+				SetOptionsOp: &xdr.SetOptionsOp{MasterWeight: &weight},
+			},
+		})
+		So(neededThreshold(tx, thresholds), ShouldEqual, 4)
+	})
+
+	Convey("signatureWeight", t, func() {
+		hash := sha256.Sum256([]byte("transaction"))
+		preimage := []byte("secret")
+		x := sha256.Sum256(preimage)
+
+		var hint xdr.SignatureHint
+		copy(hint[:], x[28:])
+		sigs := []xdr.DecoratedSignature{{Hint: hint, Signature: preimage}}
+
+		signers := map[string]int32{
+			strkey.MustEncode(strkey.VersionByteHashTx, hash[:]): 1,
+			strkey.MustEncode(strkey.VersionByteHashX, x[:]):     2,
+		}
+		So(signatureWeight(sigs, hash[:], signers), ShouldEqual, 3)
+		So(signatureWeight(nil, hash[:], signers), ShouldEqual, 1)
+	})
+}
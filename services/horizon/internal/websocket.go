@@ -0,0 +1,339 @@
+package horizon
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+
+	gctx "github.com/goji/context"
+	"github.com/stellar/go/services/horizon/internal/db2"
+	"github.com/stellar/go/services/horizon/internal/db2/history"
+	"github.com/stellar/go/services/horizon/internal/log"
+	"github.com/stellar/go/services/horizon/internal/render/hal"
+	"github.com/stellar/go/services/horizon/internal/render/sse"
+	"github.com/stellar/go/services/horizon/internal/resource"
+	"github.com/stellar/go/services/horizon/internal/toid"
+	"github.com/stellar/go/support/errors"
+	"github.com/zenazn/goji/web"
+	"golang.org/x/net/context"
+	"golang.org/x/net/websocket"
+)
+
+// This file contains the /ws endpoint, a WebSocket alternative to streaming
+// with Server Sent Events.  A client subscribes to any number of streams of
+// ledgers, transactions, operations or effects over a single connection:
+//
+//   -> {"type": "subscribe", "id": "txs", "resource": "transactions", "account_id": "G...", "cursor": "now"}
+//   <- {"type": "subscribed", "id": "txs"}
+//   <- {"type": "event", "id": "txs", "data": {...}}
+//   -> {"type": "unsubscribe", "id": "txs"}
+//   <- {"type": "unsubscribed", "id": "txs"}
+//
+// Errors that end a subscription, or concern a request of the client, are
+// sent as {"type": "error", "id": "txs", "error": "..."}.
+
+// wsRequest is a message sent by a client of the /ws endpoint.
+type wsRequest struct {
+	Type      string `json:"type"`
+	ID        string `json:"id"`
+	Resource  string `json:"resource"`
+	AccountID string `json:"account_id"`
+	Cursor    string `json:"cursor"`
+}
+
+// wsMessage is a message sent to a client of the /ws endpoint.
+type wsMessage struct {
+	Type  string      `json:"type"`
+	ID    string      `json:"id,omitempty"`
+	Data  interface{} `json:"data,omitempty"`
+	Error string      `json:"error,omitempty"`
+}
+
+// wsConn is a connection to the /ws endpoint, multiplexing the subscriptions
+// of a client.
+type wsConn struct {
+	app *App
+	ws  *websocket.Conn
+
+	sendLock sync.Mutex
+
+	lock          sync.Mutex
+	subscriptions map[string]context.CancelFunc
+	wg            sync.WaitGroup
+}
+
+// ServeWebSocket serves the /ws endpoint.
+func (app *App) ServeWebSocket(c web.C, w http.ResponseWriter, r *http.Request) {
+	ctx := gctx.FromC(c)
+
+	server := websocket.Server{
+		// horizon allows requests from any origin, see initWebMiddleware
+		Handshake: func(*websocket.Config, *http.Request) error { return nil },
+		Handler: func(ws *websocket.Conn) {
+			conn := &wsConn{
+				app:           app,
+				ws:            ws,
+				subscriptions: map[string]context.CancelFunc{},
+			}
+			conn.run(ctx)
+		},
+	}
+	server.ServeHTTP(w, r)
+}
+
+// run handles the requests of the client until it disconnects.
+func (conn *wsConn) run(ctx context.Context) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer func() {
+		cancel()
+		conn.wg.Wait()
+	}()
+
+	for {
+		var req wsRequest
+		err := websocket.JSON.Receive(conn.ws, &req)
+		switch err.(type) {
+		case nil:
+			conn.handle(ctx, req)
+		case *json.SyntaxError, *json.UnmarshalTypeError:
+			conn.send(wsMessage{Type: "error", Error: "invalid message: " + err.Error()})
+		default:
+			return
+		}
+	}
+}
+
+func (conn *wsConn) handle(ctx context.Context, req wsRequest) {
+	switch req.Type {
+	case "subscribe":
+		start, err := conn.subscribe(ctx, req)
+		if err != nil {
+			conn.send(wsMessage{Type: "error", ID: req.ID, Error: err.Error()})
+			return
+		}
+		conn.send(wsMessage{Type: "subscribed", ID: req.ID})
+		start()
+	case "unsubscribe":
+		if !conn.unsubscribe(req.ID) {
+			conn.send(wsMessage{Type: "error", ID: req.ID, Error: "unknown subscription"})
+			return
+		}
+		conn.send(wsMessage{Type: "unsubscribed", ID: req.ID})
+	default:
+		conn.send(wsMessage{Type: "error", ID: req.ID, Error: fmt.Sprintf("unknown message type %q", req.Type)})
+	}
+}
+
+// subscribe validates req and registers the subscription.  It returns the
+// function starting to stream the records subscribed to, called once the
+// subscription is acknowledged so that the acknowledgement comes first.
+func (conn *wsConn) subscribe(ctx context.Context, req wsRequest) (func(), error) {
+	if req.ID == "" {
+		return nil, errors.New("id is required")
+	}
+
+	switch req.Resource {
+	case "ledgers":
+		if req.AccountID != "" {
+			return nil, errors.New("ledgers cannot be filtered by account")
+		}
+	case "transactions", "operations", "effects":
+	default:
+		return nil, fmt.Errorf("unknown resource %q", req.Resource)
+	}
+
+	cursor := req.Cursor
+	if cursor == "now" {
+		cursor = toid.AfterLedger(conn.app.ledgerState.CurrentState().HistoryLatest).String()
+	}
+
+	// validate the cursor and account before acknowledging the subscription
+	_, err := conn.load(ctx, req, cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	conn.lock.Lock()
+	defer conn.lock.Unlock()
+
+	if _, ok := conn.subscriptions[req.ID]; ok {
+		return nil, errors.New("id is already subscribed")
+	}
+
+	subCtx, cancel := context.WithCancel(ctx)
+	conn.subscriptions[req.ID] = cancel
+
+	conn.wg.Add(1)
+	start := func() {
+		go func() {
+			defer conn.wg.Done()
+			conn.stream(subCtx, req, cursor)
+		}()
+	}
+	return start, nil
+}
+
+// unsubscribe stops the subscription identified by id, returning false if
+// there is none.
+func (conn *wsConn) unsubscribe(id string) bool {
+	conn.lock.Lock()
+	defer conn.lock.Unlock()
+
+	cancel, ok := conn.subscriptions[id]
+	if !ok {
+		return false
+	}
+	cancel()
+	delete(conn.subscriptions, id)
+	return true
+}
+
+// stream sends the records subscribed to by req, starting after cursor, as
+// they are ingested.
+func (conn *wsConn) stream(ctx context.Context, req wsRequest, cursor string) {
+	for {
+		// get the next tick before loading, so that records ingested meanwhile
+		// aren't missed
+		pumped := sse.Pumped()
+
+		records, err := conn.load(ctx, req, cursor)
+		if ctx.Err() != nil {
+			return
+		}
+		if err != nil {
+			log.Ctx(ctx).WithField("err", err.Error()).Warn("websocket subscription failed")
+			conn.unsubscribe(req.ID)
+			conn.send(wsMessage{Type: "error", ID: req.ID, Error: err.Error()})
+			return
+		}
+
+		for _, record := range records {
+			err = conn.send(wsMessage{Type: "event", ID: req.ID, Data: record})
+			if err != nil {
+				return
+			}
+			cursor = record.PagingToken()
+		}
+
+		// more records are waiting
+		if uint64(len(records)) >= conn.app.MaxPageSize() {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-pumped:
+		}
+	}
+}
+
+// load loads the page of records subscribed to by req after cursor.
+func (conn *wsConn) load(ctx context.Context, req wsRequest, cursor string) ([]hal.Pageable, error) {
+	// subscriptions load pages of the max page size clients may request
+	pageSize := conn.app.MaxPageSize()
+	page, err := db2.NewPageQueryWithMax(cursor, db2.OrderAscending, pageSize, pageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	q := &history.Q{Session: conn.app.HorizonReadSession(ctx)}
+	var records []hal.Pageable
+
+	switch req.Resource {
+	case "ledgers":
+		var rows []history.Ledger
+		err = q.Ledgers().Page(page).Select(&rows)
+		if err != nil {
+			return nil, err
+		}
+		for _, row := range rows {
+			var res resource.Ledger
+			res.Populate(ctx, row)
+			records = append(records, res)
+		}
+
+	case "transactions":
+		txs := q.Transactions()
+		if req.AccountID != "" {
+			txs.ForAccount(req.AccountID)
+		}
+
+		var rows []history.Transaction
+		err = txs.Page(page).Select(&rows)
+		if err != nil {
+			return nil, err
+		}
+		for _, row := range rows {
+			var res resource.Transaction
+			err = res.Populate(ctx, row)
+			if err != nil {
+				return nil, err
+			}
+			records = append(records, res)
+		}
+
+	case "operations":
+		ops := q.Operations()
+		if req.AccountID != "" {
+			ops.ForAccount(req.AccountID)
+		}
+
+		var rows []history.Operation
+		err = ops.Page(page).Select(&rows)
+		if err != nil {
+			return nil, err
+		}
+
+		var ledgers history.LedgerCache
+		for _, row := range rows {
+			ledgers.Queue(row.LedgerSequence())
+		}
+		err = ledgers.Load(q)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, row := range rows {
+			l, found := ledgers.Records[row.LedgerSequence()]
+			if !found {
+				return nil, fmt.Errorf("could not find ledger data for sequence %d", row.LedgerSequence())
+			}
+
+			res, err := resource.NewOperation(ctx, row, l)
+			if err != nil {
+				return nil, err
+			}
+			records = append(records, res)
+		}
+
+	case "effects":
+		effects := q.Effects()
+		if req.AccountID != "" {
+			effects.ForAccount(req.AccountID)
+		}
+
+		var rows []history.Effect
+		err = effects.Page(page).Select(&rows)
+		if err != nil {
+			return nil, err
+		}
+		for _, row := range rows {
+			res, err := resource.NewEffect(ctx, row)
+			if err != nil {
+				return nil, err
+			}
+			records = append(records, res)
+		}
+	}
+
+	return records, nil
+}
+
+// send sends msg to the client.  It is safe for concurrent use.
+func (conn *wsConn) send(msg wsMessage) error {
+	conn.sendLock.Lock()
+	defer conn.sendLock.Unlock()
+	return websocket.JSON.Send(conn.ws, msg)
+}
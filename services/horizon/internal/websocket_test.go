@@ -0,0 +1,128 @@
+package horizon
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stellar/go/services/horizon/internal/test"
+	"golang.org/x/net/websocket"
+)
+
+func TestWebSocket(t *testing.T) {
+	tt := test.Start(t).Scenario("base")
+	defer tt.Finish()
+
+	config := NewTestConfig()
+	config.EnableWebSocket = true
+	app, err := NewApp(config)
+	tt.Require.NoError(err)
+	defer app.Close()
+	app.UpdateLedgerState()
+
+	server := httptest.NewServer(app.web.router)
+	defer server.Close()
+
+	url := "ws" + strings.TrimPrefix(server.URL, "http") + "/ws"
+	ws, err := websocket.Dial(url, "", server.URL)
+	tt.Require.NoError(err)
+	defer ws.Close()
+
+	receive := func() (msg struct {
+		Type  string          `json:"type"`
+		ID    string          `json:"id"`
+		Data  json.RawMessage `json:"data"`
+		Error string          `json:"error"`
+	}) {
+		tt.Require.NoError(websocket.JSON.Receive(ws, &msg))
+		return
+	}
+
+	// unknown resources are rejected
+	err = websocket.JSON.Send(ws, wsRequest{Type: "subscribe", ID: "bad", Resource: "offers"})
+	tt.Require.NoError(err)
+	msg := receive()
+	tt.Assert.Equal("error", msg.Type)
+	tt.Assert.Equal("bad", msg.ID)
+
+	// ledgers are streamed from the cursor
+	err = websocket.JSON.Send(ws, wsRequest{Type: "subscribe", ID: "ledgers", Resource: "ledgers"})
+	tt.Require.NoError(err)
+	msg = receive()
+	tt.Assert.Equal("subscribed", msg.Type)
+
+	for i := 1; i <= 3; i++ {
+		msg = receive()
+		tt.Assert.Equal("event", msg.Type)
+		tt.Assert.Equal("ledgers", msg.ID)
+
+		var ledger struct {
+			Sequence int32 `json:"sequence"`
+		}
+		tt.Require.NoError(json.Unmarshal(msg.Data, &ledger))
+		tt.Assert.EqualValues(i, ledger.Sequence)
+	}
+
+	err = websocket.JSON.Send(ws, wsRequest{Type: "unsubscribe", ID: "ledgers"})
+	tt.Require.NoError(err)
+	msg = receive()
+	tt.Assert.Equal("unsubscribed", msg.Type)
+
+	// the subscription is gone
+	err = websocket.JSON.Send(ws, wsRequest{Type: "unsubscribe", ID: "ledgers"})
+	tt.Require.NoError(err)
+	msg = receive()
+	tt.Assert.Equal("error", msg.Type)
+}
+
+func TestWebSocket_MaxPageSize(t *testing.T) {
+	tt := test.Start(t).Scenario("base")
+	defer tt.Finish()
+
+	config := NewTestConfig()
+	config.EnableWebSocket = true
+	config.MaxPageSize = 2
+	app, err := NewApp(config)
+	tt.Require.NoError(err)
+	defer app.Close()
+	app.UpdateLedgerState()
+
+	server := httptest.NewServer(app.web.router)
+	defer server.Close()
+
+	url := "ws" + strings.TrimPrefix(server.URL, "http") + "/ws"
+	ws, err := websocket.Dial(url, "", server.URL)
+	tt.Require.NoError(err)
+	defer ws.Close()
+
+	var msg struct {
+		Type string          `json:"type"`
+		Data json.RawMessage `json:"data"`
+	}
+
+	err = websocket.JSON.Send(ws, wsRequest{Type: "subscribe", ID: "ledgers", Resource: "ledgers"})
+	tt.Require.NoError(err)
+	tt.Require.NoError(websocket.JSON.Receive(ws, &msg))
+	tt.Assert.Equal("subscribed", msg.Type)
+
+	// records are streamed in pages of the max page size
+	for i := 1; i <= 3; i++ {
+		tt.Require.NoError(websocket.JSON.Receive(ws, &msg))
+		tt.Assert.Equal("event", msg.Type)
+
+		var ledger struct {
+			Sequence int32 `json:"sequence"`
+		}
+		tt.Require.NoError(json.Unmarshal(msg.Data, &ledger))
+		tt.Assert.EqualValues(i, ledger.Sequence)
+	}
+}
+
+func TestWebSocket_Disabled(t *testing.T) {
+	ht := StartHTTPTest(t, "base")
+	defer ht.Finish()
+
+	w := ht.Get("/ws")
+	ht.Assert.Equal(404, w.Code)
+}
@@ -3,13 +3,18 @@ package main
 import (
 	"log"
 	"runtime"
+	"strings"
 
 	"github.com/PuerkitoBio/throttled"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 	"github.com/stellar/go/services/horizon/internal"
+	"github.com/stellar/go/services/horizon/internal/db2"
 	hlog "github.com/stellar/go/services/horizon/internal/log"
+	"github.com/stellar/go/services/horizon/internal/paths"
+	"github.com/stellar/go/services/horizon/internal/reap"
+	"github.com/stellar/go/services/horizon/internal/txsub/sequence"
 )
 
 var app *horizon.App
@@ -43,8 +48,35 @@ func init() {
 	viper.BindEnv("ingest", "INGEST")
 	viper.BindEnv("network-passphrase", "NETWORK_PASSPHRASE")
 	viper.BindEnv("history-retention-count", "HISTORY_RETENTION_COUNT")
+	viper.BindEnv("history-retention-policies", "HISTORY_RETENTION_POLICIES")
+	viper.BindEnv("history-reap-interval", "HISTORY_REAP_INTERVAL")
+	viper.BindEnv("enable-order-book-graph", "ENABLE_ORDER_BOOK_GRAPH")
+	viper.BindEnv("max-path-length", "MAX_PATH_LENGTH")
+	viper.BindEnv("max-path-results", "MAX_PATH_RESULTS")
+	viper.BindEnv("txsub-queue-size", "TXSUB_QUEUE_SIZE")
+	viper.BindEnv("txsub-queue-timeout", "TXSUB_QUEUE_TIMEOUT")
+	viper.BindEnv("cors-allowed-origins", "CORS_ALLOWED_ORIGINS")
+	viper.BindEnv("cors-allowed-methods", "CORS_ALLOWED_METHODS")
+	viper.BindEnv("cors-allowed-headers", "CORS_ALLOWED_HEADERS")
+	viper.BindEnv("cors-max-age", "CORS_MAX_AGE")
+	viper.BindEnv("enable-graphql", "ENABLE_GRAPHQL")
+	viper.BindEnv("replica-db-url", "REPLICA_DATABASE_URL")
+	viper.BindEnv("replica-max-lag", "REPLICA_MAX_LAG")
+	viper.BindEnv("history-cache-size", "HISTORY_CACHE_SIZE")
+	viper.BindEnv("admin-port", "ADMIN_PORT")
+	viper.BindEnv("admin-token", "ADMIN_TOKEN")
+	viper.BindEnv("shutdown-timeout", "SHUTDOWN_TIMEOUT")
+	viper.BindEnv("audit-submissions", "AUDIT_SUBMISSIONS")
+	viper.BindEnv("audit-retention", "AUDIT_RETENTION")
+	viper.BindEnv("url-prefix", "URL_PREFIX")
+	viper.BindEnv("network-config", "NETWORK_CONFIG")
+	viper.BindEnv("max-page-size", "MAX_PAGE_SIZE")
+	viper.BindEnv("request-timeout", "REQUEST_TIMEOUT")
+	viper.BindEnv("route-timeouts", "ROUTE_TIMEOUTS")
 	viper.BindEnv("history-stale-threshold", "HISTORY_STALE_THRESHOLD")
 	viper.BindEnv("skip-cursor-update", "SKIP_CURSOR_UPDATE")
+	viper.BindEnv("enable-websocket", "ENABLE_WEBSOCKET")
+	viper.BindEnv("enable-api-keys", "ENABLE_API_KEYS")
 
 	rootCmd = &cobra.Command{
 		Use:   "horizon",
@@ -52,7 +84,7 @@ func init() {
 		Long:  "client-facing api server for the stellar network",
 		Run: func(cmd *cobra.Command, args []string) {
 			initApp(cmd, args)
-			app.Serve()
+			serveApps()
 		},
 	}
 
@@ -152,12 +184,174 @@ func init() {
 		"the minimum number of ledgers to maintain within horizon's history tables.  0 signifies an unlimited number of ledgers will be retained",
 	)
 
+	rootCmd.Flags().String(
+		"history-retention-policies",
+		"",
+		"comma separated retention policies of resources reaped earlier than their ledgers, e.g. effects=30d,operations=1000000.  resources are effects, operations and transactions",
+	)
+
+	rootCmd.Flags().Duration(
+		"history-reap-interval",
+		reap.DefaultInterval,
+		"the interval between two passes of the history reaper",
+	)
+
 	rootCmd.Flags().Uint(
 		"history-stale-threshold",
 		0,
 		"the maximum number of ledgers the history db is allowed to be out of date from the connected stellar-core db before horizon considers history stale",
 	)
 
+	rootCmd.Flags().Bool(
+		"enable-websocket",
+		false,
+		"serves the /ws endpoint, streaming ledgers, transactions, operations and effects over websockets",
+	)
+
+	rootCmd.Flags().Bool(
+		"enable-api-keys",
+		false,
+		"limits the requests made with an api key, issued in redis, by the limits of the key instead of by ip address",
+	)
+
+	rootCmd.Flags().Bool(
+		"enable-order-book-graph",
+		false,
+		"finds payment paths in an in-memory graph of the order books, maintained by ingestion, instead of querying stellar-core's db for each request",
+	)
+
+	rootCmd.Flags().Uint(
+		"max-path-length",
+		paths.DefaultMaxPathLength,
+		"the maximum number of assets between the source and destination assets of the payment paths found, up to 5",
+	)
+
+	rootCmd.Flags().Uint(
+		"max-path-results",
+		paths.DefaultMaxResults,
+		"the maximum number of payment paths returned by a path finding request",
+	)
+
+	rootCmd.Flags().Uint(
+		"txsub-queue-size",
+		sequence.DefaultMaxSize,
+		"the maximum number of transaction submissions buffered until their sequence number can be submitted",
+	)
+
+	rootCmd.Flags().Duration(
+		"txsub-queue-timeout",
+		sequence.DefaultTimeout,
+		"the time transaction submissions with a future sequence number are held for without any transaction of their source account applying",
+	)
+
+	rootCmd.Flags().String(
+		"cors-allowed-origins",
+		"*",
+		"comma separated origins allowed to make cross-origin requests, which may contain a * wildcard, e.g. https://*.example.com",
+	)
+
+	rootCmd.Flags().String(
+		"cors-allowed-methods",
+		"GET,POST",
+		"comma separated methods allowed in cross-origin requests",
+	)
+
+	rootCmd.Flags().String(
+		"cors-allowed-headers",
+		"*",
+		"comma separated headers allowed in cross-origin requests",
+	)
+
+	rootCmd.Flags().Duration(
+		"cors-max-age",
+		0,
+		"how long browsers may cache the responses to cors preflight requests",
+	)
+
+	rootCmd.Flags().Bool(
+		"enable-graphql",
+		false,
+		"serves the /graphql endpoint, answering graphql queries for accounts, ledgers, transactions, operations and effects",
+	)
+
+	rootCmd.Flags().String(
+		"replica-db-url",
+		"",
+		"horizon postgres read replica, to which the queries of requests are routed while it keeps up with ingestion",
+	)
+
+	rootCmd.Flags().Int(
+		"replica-max-lag",
+		2,
+		"the maximum number of ledgers the read replica may lag behind ingestion before queries are routed back to the horizon db",
+	)
+
+	rootCmd.Flags().Int(
+		"history-cache-size",
+		10000,
+		"the number of ledgers, transactions and operations cached in memory once loaded by their sequence, hash or id, 0 to disable the cache",
+	)
+
+	rootCmd.Flags().Int(
+		"admin-port",
+		0,
+		"tcp port to listen on for admin api requests, 0 to disable the admin api",
+	)
+
+	rootCmd.Flags().String(
+		"admin-token",
+		"",
+		"bearer token required by the admin api",
+	)
+
+	rootCmd.Flags().Duration(
+		"shutdown-timeout",
+		horizon.DefaultShutdownTimeout,
+		"how long to wait, once signaled to stop, for requests in progress, buffered transaction submissions and ingestion to end",
+	)
+
+	rootCmd.Flags().String(
+		"audit-submissions",
+		"",
+		"where to record the submitted transactions for auditing: db, to the audit_submissions table of the horizon database, or log; they are not recorded when blank",
+	)
+
+	rootCmd.Flags().Duration(
+		"audit-retention",
+		0,
+		"how long recorded submissions are kept in the horizon database, 0 to keep them forever",
+	)
+
+	rootCmd.Flags().String(
+		"url-prefix",
+		"",
+		"path prefix the network is served under, e.g. /pubnet, when serving many networks",
+	)
+
+	rootCmd.Flags().String(
+		"network-config",
+		"",
+		"path to a toml file configuring the other networks served by this process, each under its own url prefix",
+	)
+
+	rootCmd.Flags().Int(
+		"max-page-size",
+		db2.MaxPageSize,
+		"the maximum number of records clients may request in a single page",
+	)
+
+	rootCmd.Flags().Duration(
+		"request-timeout",
+		0,
+		"how long requests may take before their database queries are cancelled, streams excepted; requests are not timed out when zero",
+	)
+
+	rootCmd.Flags().String(
+		"route-timeouts",
+		"",
+		"comma separated list of /route=duration overriding request-timeout for some routes, e.g. /paths=1m",
+	)
+
 	rootCmd.AddCommand(dbCmd)
 
 	viper.BindPFlags(rootCmd.Flags())
@@ -172,6 +366,8 @@ func initApp(cmd *cobra.Command, args []string) {
 	if err != nil {
 		log.Fatal(err.Error())
 	}
+
+	initNetworkApps()
 }
 
 func initConfig() {
@@ -187,6 +383,10 @@ func initConfig() {
 		log.Fatal("Invalid config: stellar-core-url is blank.  Please specify --stellar-core-url on the command line or set the STELLAR_CORE_URL environment variable.")
 	}
 
+	if viper.GetInt("admin-port") != 0 && viper.GetString("admin-token") == "" {
+		log.Fatal("Invalid config: admin-token is blank.  Please specify --admin-token on the command line or set the ADMIN_TOKEN environment variable to serve the admin api.")
+	}
+
 	ll, err := logrus.ParseLevel(viper.GetString("log-level"))
 
 	if err != nil {
@@ -204,6 +404,35 @@ func initConfig() {
 		log.Fatal("Invalid TLS config: cert not configured")
 	}
 
+	switch viper.GetString("audit-submissions") {
+	case "", "db", "log":
+	default:
+		log.Fatal("Invalid config: audit-submissions must be db or log")
+	}
+
+	if viper.GetInt("max-page-size") < db2.DefaultPageSize {
+		log.Fatalf("Invalid config: max-page-size is less than %d, the default page size", db2.DefaultPageSize)
+	}
+
+	prefix := viper.GetString("url-prefix")
+	if prefix != "" && (!strings.HasPrefix(prefix, "/") || strings.HasSuffix(prefix, "/")) {
+		log.Fatal("Invalid config: url-prefix must start with a / and not end with one")
+	}
+
+	policies, err := reap.ParsePolicies(viper.GetString("history-retention-policies"))
+	if err != nil {
+		log.Fatalf("Could not parse history-retention-policies: %v", err)
+	}
+
+	if viper.GetInt("max-path-length") > paths.DefaultMaxPathLength {
+		log.Fatalf("Invalid config: max-path-length is greater than %d, the maximum length of a payment path", paths.DefaultMaxPathLength)
+	}
+
+	routeTimeouts, err := horizon.ParseRouteTimeouts(viper.GetString("route-timeouts"))
+	if err != nil {
+		log.Fatalf("Could not parse route-timeouts: %v", err)
+	}
+
 	config = horizon.Config{
 		DatabaseURL:            viper.GetString("db-url"),
 		StellarCoreDatabaseURL: viper.GetString("stellar-core-db-url"),
@@ -222,5 +451,44 @@ func initConfig() {
 		HistoryRetentionCount:  uint(viper.GetInt("history-retention-count")),
 		StaleThreshold:         uint(viper.GetInt("history-stale-threshold")),
 		SkipCursorUpdate:       viper.GetBool("skip-cursor-update"),
+		EnableWebSocket:        viper.GetBool("enable-websocket"),
+		EnableAPIKeys:          viper.GetBool("enable-api-keys"),
+
+		HistoryRetentionPolicies: policies,
+		HistoryReapInterval:      viper.GetDuration("history-reap-interval"),
+		EnableOrderBookGraph:     viper.GetBool("enable-order-book-graph"),
+		MaxPathLength:            uint(viper.GetInt("max-path-length")),
+		MaxPathResults:           uint(viper.GetInt("max-path-results")),
+		TxSubQueueSize:           uint(viper.GetInt("txsub-queue-size")),
+		TxSubQueueTimeout:        viper.GetDuration("txsub-queue-timeout"),
+		CORSAllowedOrigins:       splitList(viper.GetString("cors-allowed-origins")),
+		CORSAllowedMethods:       splitList(viper.GetString("cors-allowed-methods")),
+		CORSAllowedHeaders:       splitList(viper.GetString("cors-allowed-headers")),
+		CORSMaxAge:               viper.GetDuration("cors-max-age"),
+		EnableGraphQL:            viper.GetBool("enable-graphql"),
+		ReplicaDatabaseURL:       viper.GetString("replica-db-url"),
+		ReplicaMaxLag:            uint(viper.GetInt("replica-max-lag")),
+		HistoryCacheSize:         uint(viper.GetInt("history-cache-size")),
+		AdminPort:                uint(viper.GetInt("admin-port")),
+		AdminToken:               viper.GetString("admin-token"),
+		ShutdownTimeout:          viper.GetDuration("shutdown-timeout"),
+		AuditSubmissions:         viper.GetString("audit-submissions"),
+		AuditRetention:           viper.GetDuration("audit-retention"),
+		URLPrefix:                prefix,
+		MaxPageSize:              uint(viper.GetInt("max-page-size")),
+		RequestTimeout:           viper.GetDuration("request-timeout"),
+		RouteTimeouts:            routeTimeouts,
+	}
+}
+
+// splitList splits a comma separated list, ignoring blank items.
+func splitList(list string) []string {
+	var result []string
+	for _, item := range strings.Split(list, ",") {
+		item = strings.TrimSpace(item)
+		if item != "" {
+			result = append(result, item)
+		}
 	}
+	return result
 }
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"log"
+	"strings"
+
+	"github.com/spf13/viper"
+	"github.com/stellar/go/services/horizon/internal"
+	supportconfig "github.com/stellar/go/support/config"
+)
+
+// networkApps are the apps of the networks served by the process in addition
+// to the one configured by the flags.
+var networkApps []*horizon.App
+
+// networkConfig is the configuration of a network read from the file of
+// --network-config.  The settings it lacks are those of the flags.
+type networkConfig struct {
+	URLPrefix              string `toml:"url_prefix" valid:"required"`
+	DatabaseURL            string `toml:"db_url" valid:"required"`
+	StellarCoreDatabaseURL string `toml:"stellar_core_db_url" valid:"required"`
+	StellarCoreURL         string `toml:"stellar_core_url" valid:"required"`
+	Ingest                 bool   `toml:"ingest" valid:"optional"`
+}
+
+// networkConfigFile is the file of --network-config.
+type networkConfigFile struct {
+	Networks []networkConfig `toml:"network" valid:"required"`
+}
+
+// initNetworkApps initializes the apps of the networks of the file of
+// --network-config, if set.
+func initNetworkApps() {
+	path := viper.GetString("network-config")
+	if path == "" {
+		return
+	}
+
+	var file networkConfigFile
+	err := supportconfig.Read(path, &file)
+	if err != nil {
+		switch cause := err.(type) {
+		case *supportconfig.InvalidConfigError:
+			log.Fatalf("Invalid network-config: %v", cause.InvalidFields)
+		default:
+			log.Fatalf("Could not read network-config: %v", err)
+		}
+	}
+
+	prefixes := map[string]bool{config.URLPrefix: true}
+	for _, network := range file.Networks {
+		if !strings.HasPrefix(network.URLPrefix, "/") || strings.HasSuffix(network.URLPrefix, "/") {
+			log.Fatalf("Invalid network-config: url_prefix %q must start with a / and not end with one", network.URLPrefix)
+		}
+		if prefixes[network.URLPrefix] {
+			log.Fatalf("Invalid network-config: url_prefix %q is served by another network", network.URLPrefix)
+		}
+		prefixes[network.URLPrefix] = true
+
+		// the networks share the port and the admin api of the process
+		c := config
+		c.URLPrefix = network.URLPrefix
+		c.DatabaseURL = network.DatabaseURL
+		c.StellarCoreDatabaseURL = network.StellarCoreDatabaseURL
+		c.StellarCoreURL = network.StellarCoreURL
+		c.Ingest = network.Ingest
+		c.ReplicaDatabaseURL = ""
+
+		app, err := horizon.NewApp(c)
+		if err != nil {
+			log.Fatal(err.Error())
+		}
+		networkApps = append(networkApps, app)
+	}
+}
+
+// serveApps serves the app configured by the flags along with the apps of
+// the other networks.
+func serveApps() {
+	horizon.ServeNetworks(append([]*horizon.App{app}, networkApps...)...)
+}
@@ -0,0 +1,53 @@
+// +build go1.8
+
+package db
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// contextConn is a connection able to run queries within a context, which
+// both *sqlx.DB and *sqlx.Tx are since go 1.8.
+type contextConn interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	GetContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error
+	QueryxContext(ctx context.Context, query string, args ...interface{}) (*sqlx.Rows, error)
+	SelectContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error
+}
+
+// boundConn is a Conn whose queries are run within ctx, and so are cancelled
+// once ctx is done.
+type boundConn struct {
+	Conn
+	ctx  context.Context
+	conn contextConn
+}
+
+// bindConn returns `conn`, bound to `ctx` when it is not nil.
+func bindConn(ctx context.Context, conn Conn) Conn {
+	cc, ok := conn.(contextConn)
+	if ctx == nil || !ok {
+		return conn
+	}
+
+	return &boundConn{Conn: conn, ctx: ctx, conn: cc}
+}
+
+func (c *boundConn) Exec(query string, args ...interface{}) (sql.Result, error) {
+	return c.conn.ExecContext(c.ctx, query, args...)
+}
+
+func (c *boundConn) Get(dest interface{}, query string, args ...interface{}) error {
+	return c.conn.GetContext(c.ctx, dest, query, args...)
+}
+
+func (c *boundConn) Queryx(query string, args ...interface{}) (*sqlx.Rows, error) {
+	return c.conn.QueryxContext(c.ctx, query, args...)
+}
+
+func (c *boundConn) Select(dest interface{}, query string, args ...interface{}) error {
+	return c.conn.SelectContext(c.ctx, dest, query, args...)
+}
@@ -0,0 +1,45 @@
+// +build go1.8
+
+package db
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stellar/go/support/db/dbtest"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/context"
+)
+
+func TestSession_Ctx(t *testing.T) {
+	db := dbtest.Postgres(t).Load(testSchema)
+	defer db.Close()
+
+	assert := assert.New(t)
+	sess := &Session{DB: db.Open()}
+	defer sess.DB.Close()
+
+	// queries run within the context of the session...
+	ctx, cancel := context.WithCancel(context.Background())
+	sess.Ctx = ctx
+
+	var count int
+	err := sess.GetRaw(&count, "SELECT COUNT(*) FROM people")
+	assert.NoError(err)
+	assert.Equal(3, count)
+
+	// ...and fail once it is done
+	cancel()
+	err = sess.GetRaw(&count, "SELECT COUNT(*) FROM people")
+	assert.Error(err)
+
+	// including the queries in progress
+	ctx, cancel = context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	sess.Ctx = ctx
+
+	start := time.Now()
+	_, err = sess.ExecRaw("SELECT pg_sleep(5)")
+	assert.Error(err)
+	assert.True(time.Since(start) < 5*time.Second)
+}
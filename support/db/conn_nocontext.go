@@ -0,0 +1,14 @@
+// +build !go1.8
+
+package db
+
+import (
+	"golang.org/x/net/context"
+)
+
+// bindConn returns `conn` as is: database/sql cannot cancel queries before go
+// 1.8, so the queries of a session run to completion even once its context
+// is done.
+func bindConn(ctx context.Context, conn Conn) Conn {
+	return conn
+}
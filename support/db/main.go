@@ -87,7 +87,9 @@ type Session struct {
 	// DB is the database connection that queries should be executed against.
 	DB *sqlx.DB
 
-	// Ctx is the optional context in which the repo is operating under.
+	// Ctx is the optional context in which the repo is operating under.  When
+	// built with go 1.8 or later, the queries of the session are cancelled
+	// once it is done.
 	Ctx context.Context
 
 	tx *sqlx.Tx
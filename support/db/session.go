@@ -296,10 +296,10 @@ func (s *Session) clearSliceIfPossible(dest interface{}) {
 
 func (s *Session) conn() Conn {
 	if s.tx != nil {
-		return s.tx
+		return bindConn(s.Ctx, s.tx)
 	}
 
-	return s.DB
+	return bindConn(s.Ctx, s.DB)
 }
 
 func (s *Session) log(typ string, start time.Time, query string, args []interface{}) {
@@ -40,6 +40,20 @@ func RegisterError(err error, p P) {
 	errToProblemMap[err] = p
 }
 
+var instanceFunc func(context.Context) string
+
+// SetInstanceFunc registers fn to derive the instance of the problems rendered
+// from the context of the request, allowing the app to report e.g. the id of
+// the request in its problems so that clients can quote it.
+//
+// For example, an app storing the request id in the context would call:
+//
+// problem.SetInstanceFunc(requestid.FromContext) in its application
+// initialization sequence
+func SetInstanceFunc(fn func(context.Context) string) {
+	instanceFunc = fn
+}
+
 // Inflate sets some basic parameters on the problem, mostly the type for now
 func Inflate(p *P) {
 	//TODO: add requesting url to extra info
@@ -80,6 +94,9 @@ func Render(ctx context.Context, w http.ResponseWriter, p interface{}) {
 
 func render(ctx context.Context, w http.ResponseWriter, p P) {
 	Inflate(&p)
+	if instanceFunc != nil && ctx != nil {
+		p.Instance = instanceFunc(ctx)
+	}
 
 	w.Header().Set("Content-Type", "application/problem+json; charset=utf-8")
 	js, err := json.MarshalIndent(p, "", "  ")
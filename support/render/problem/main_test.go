@@ -116,6 +116,25 @@ func TestServerErrorConversion(t *testing.T) {
 	}
 }
 
+// TestInstance tests that the instance of problems is derived from the context
+// once an instance func is registered
+func TestInstance(t *testing.T) {
+	var key = 0
+	ctx := context.WithValue(context.Background(), &key, "req-1")
+
+	w := testRender(ctx, NotFound)
+	assert.False(t, strings.Contains(w.Body.String(), "req-1"), w.Body.String())
+
+	SetInstanceFunc(func(ctx context.Context) string {
+		id, _ := ctx.Value(&key).(string)
+		return id
+	})
+	defer SetInstanceFunc(nil)
+
+	w = testRender(ctx, NotFound)
+	assert.True(t, strings.Contains(w.Body.String(), `"instance": "req-1"`), w.Body.String())
+}
+
 func testRender(ctx context.Context, p interface{}) *httptest.ResponseRecorder {
 	w := httptest.NewRecorder()
 	Render(ctx, w, p)
@@ -0,0 +1,76 @@
+package commands
+
+import (
+	"encoding/hex"
+	"regexp"
+	"strings"
+
+	"github.com/bartekn/go-bip39"
+	"github.com/stellar/go/exp/crypto/derivation"
+	"github.com/stellar/go/keypair"
+	"github.com/stellar/go/support/errors"
+)
+
+var wordsRegexp = regexp.MustCompile(`^[a-z]+$`)
+
+var allowedNumbers = map[uint32]bool{12: true, 15: true, 18: true, 21: true, 24: true}
+
+// readMasterKey prompts for a mnemonic code and an optional password and
+// returns the m/44'/148' key derived from the resulting BIP39 seed.
+func readMasterKey() (*derivation.Key, error) {
+	printf("How many words? ")
+	wordsCount := readUint()
+	if _, exist := allowedNumbers[wordsCount]; !exist {
+		return nil, errors.New("Invalid value, allowed values: 12, 15, 18, 21, 24")
+	}
+
+	words := make([]string, wordsCount)
+	for i := uint32(0); i < wordsCount; i++ {
+		printf("Enter word #%-4d", i+1)
+		words[i] = readString()
+		if !wordsRegexp.MatchString(words[i]) {
+			println("Invalid word, try again.")
+			i--
+		}
+	}
+
+	printf("Enter password (leave empty if none): ")
+	password := readString()
+
+	mnemonic := strings.Join(words, " ")
+	println("Mnemonic:", mnemonic)
+
+	seed, err := bip39.NewSeedWithErrorChecking(mnemonic, password)
+	if err != nil {
+		return nil, errors.New("Invalid words or checksum")
+	}
+
+	println("BIP39 Seed:", hex.EncodeToString(seed))
+
+	masterKey, err := derivation.DeriveForPath(derivation.StellarAccountPrefix, seed)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error deriving master key")
+	}
+
+	println("m/44'/148' key:", hex.EncodeToString(masterKey.Key))
+
+	println("")
+
+	return masterKey, nil
+}
+
+// deriveKeypair derives the key pair for account index i (m/44'/148'/i')
+// from the m/44'/148' master key.
+func deriveKeypair(masterKey *derivation.Key, i uint32) (*keypair.Full, error) {
+	key, err := masterKey.Derive(derivation.FirstHardenedIndex + i)
+	if err != nil {
+		return nil, errors.Wrap(err, "Error deriving child key")
+	}
+
+	kp, err := keypair.FromRawSeed(key.RawSeed())
+	if err != nil {
+		return nil, errors.Wrap(err, "Error creating key pair")
+	}
+
+	return kp, nil
+}
@@ -0,0 +1,82 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/stellar/go/build"
+	"github.com/stellar/go/exp/crypto/derivation"
+	"github.com/stellar/go/support/errors"
+	"github.com/stellar/go/xdr"
+)
+
+var signIndex uint32
+var testnet bool
+
+var SignCmd = &cobra.Command{
+	Use:   "sign",
+	Short: "Sign a transaction envelope with an account derived from a mnemonic code",
+	Long:  "",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		masterKey, err := readMasterKey()
+		if err != nil {
+			return err
+		}
+
+		kp, err := deriveKeypair(masterKey, signIndex)
+		if err != nil {
+			return err
+		}
+
+		println(fmt.Sprintf(derivation.StellarAccountPathFormat, signIndex), kp.Address())
+		println("")
+
+		printf("Enter envelope (base64): ")
+		env := readString()
+
+		var txe xdr.TransactionEnvelope
+		err = xdr.SafeUnmarshalBase64(env, &txe)
+		if err != nil {
+			return errors.New("Invalid transaction envelope")
+		}
+
+		println("")
+		println("Transaction Summary:")
+		println("  source:", txe.Tx.SourceAccount.Address())
+		println("  ops:", len(txe.Tx.Operations))
+		println("  sigs:", len(txe.Signatures))
+		println("")
+
+		network := build.PublicNetwork
+		if testnet {
+			network = build.TestNetwork
+		}
+
+		b := &build.TransactionEnvelopeBuilder{E: &txe}
+		b.Init()
+		err = b.MutateTX(network)
+		if err != nil {
+			return errors.Wrap(err, "Error setting network")
+		}
+
+		err = b.Mutate(build.Sign{Seed: kp.Seed()})
+		if err != nil {
+			return errors.Wrap(err, "Error signing transaction")
+		}
+
+		newEnv, err := b.Base64()
+		if err != nil {
+			return errors.Wrap(err, "Error encoding transaction envelope")
+		}
+
+		println("Signed envelope:")
+		println(newEnv)
+
+		return nil
+	},
+}
+
+func init() {
+	SignCmd.Flags().Uint32VarP(&signIndex, "index", "i", 0, "ID of the wallet to sign with")
+	SignCmd.Flags().BoolVarP(&testnet, "testnet", "t", false, "sign for the test network instead of the public network")
+}
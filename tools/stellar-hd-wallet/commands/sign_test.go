@@ -0,0 +1,73 @@
+package commands
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stellar/go/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSign(t *testing.T) {
+	// Payment transaction already signed once, see build.ExampleTransactionBuilder
+	envelope := "AAAAADZY/nWY0gx6beMpf4S8Ur0qHsjA8fbFtBzBx1cbQzHwAAAAZAAAAAAAAAABAAAAAAAAAAAAAAABAAAAAAAAAAEAAAAALSRpLtCLv2eboZlEiHDSGR6Hb+zZL92fbSdNpObeE0EAAAAAAAAAAB3NZQAAAAAAAAAAARtDMfAAAABA2oIeQxoJl53RMRWFeLB865zcky39f2gf2PmUubCuJYccEePRSrTC8QQrMOgGwD8a6oe8dgltvezdDsmmXBPyBw=="
+
+	tests := []struct {
+		Words    string
+		Index    uint32
+		Envelope string
+		Error    string
+		Want     string
+	}{
+		{
+			Words:    "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+			Index:    0,
+			Envelope: envelope,
+			Want:     "m/44'/148'/0' GB3JDWCQJCWMJ3IILWIGDTQJJC5567PGVEVXSCVPEQOTDN64VJBDQBYX",
+		},
+		{
+			Words:    "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+			Index:    3,
+			Envelope: envelope,
+			Want:     "m/44'/148'/3' GCCCOWAKYVFY5M6SYHOW33TSNC7Z5IBRUEU2XQVVT34CIZU7CXZ4OQ4O",
+		},
+		// Invalid:
+		{
+			Words:    "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+			Envelope: "AAAA",
+			Error:    "Invalid transaction envelope",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("words %s index %d", test.Words, test.Index), func(t *testing.T) {
+			words := strings.Split(test.Words, " ")
+			input := fmt.Sprintf("%d\n%s\n\n%s\n", len(words), strings.Join(words, "\n"), test.Envelope)
+
+			reader = bufio.NewReader(bytes.NewBufferString(input))
+			out = &bytes.Buffer{}
+			signIndex = test.Index
+
+			err := SignCmd.RunE(nil, []string{})
+			if test.Error != "" {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), test.Error)
+				return
+			}
+
+			require.NoError(t, err)
+			output := strings.TrimSpace(out.(*bytes.Buffer).String())
+			assert.Contains(t, output, test.Want)
+
+			lines := strings.Split(output, "\n")
+			var txe xdr.TransactionEnvelope
+			err = xdr.SafeUnmarshalBase64(lines[len(lines)-1], &txe)
+			require.NoError(t, err)
+			assert.Len(t, txe.Signatures, 2)
+		})
+	}
+}
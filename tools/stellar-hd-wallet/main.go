@@ -15,6 +15,7 @@ var mainCmd = &cobra.Command{
 func init() {
 	mainCmd.AddCommand(commands.NewCmd)
 	mainCmd.AddCommand(commands.AccountsCmd)
+	mainCmd.AddCommand(commands.SignCmd)
 }
 
 func main() {